@@ -8,11 +8,13 @@ import (
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"net/http"
 )
 
 type (
 	httpApiServer interface {
 		MountRoutes(mm ...func(chi.Router))
+		Use(mm ...func(http.Handler) http.Handler)
 		Serve(ctx context.Context)
 	}
 
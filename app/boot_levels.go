@@ -25,6 +25,7 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/provision"
 	"github.com/cortezaproject/corteza-server/pkg/rbac"
 	"github.com/cortezaproject/corteza-server/pkg/scheduler"
+	"github.com/cortezaproject/corteza-server/pkg/secrets"
 	"github.com/cortezaproject/corteza-server/pkg/sentry"
 	"github.com/cortezaproject/corteza-server/store"
 	"github.com/cortezaproject/corteza-server/system/auth/external"
@@ -211,6 +212,16 @@ func (app *CortezaApp) InitServices(ctx context.Context) (err error) {
 		rbac.Global().Reload(ctx)
 	}
 
+	// Keys for encryption of secrets at rest
+	keyring, err := secrets.Load(app.Opt.Secrets.Key, app.Opt.Secrets.KeyFile, app.Opt.Secrets.PreviousKeys)
+	if err != nil {
+		return
+	}
+
+	if keyring == nil {
+		app.Log.Warn("secrets encryption key is not set (SECRETS_KEY), secrets are stored unencrypted and encrypted record fields can not be used")
+	}
+
 	// Initializes system services
 	//
 	// Note: this is a legacy approach, all services from all 3 apps
@@ -218,6 +229,8 @@ func (app *CortezaApp) InitServices(ctx context.Context) (err error) {
 	err = sysService.Initialize(ctx, app.Log, app.Store, sysService.Config{
 		ActionLog: app.Opt.ActionLog,
 		Storage:   app.Opt.ObjStore,
+		Auth:      app.Opt.Auth,
+		Keyring:   keyring,
 	})
 
 	if err != nil {
@@ -231,6 +244,7 @@ func (app *CortezaApp) InitServices(ctx context.Context) (err error) {
 	err = cmpService.Initialize(ctx, app.Log, app.Store, cmpService.Config{
 		ActionLog: app.Opt.ActionLog,
 		Storage:   app.Opt.ObjStore,
+		Keyring:   keyring,
 	})
 
 	if err != nil {
@@ -250,6 +264,20 @@ func (app *CortezaApp) InitServices(ctx context.Context) (err error) {
 		return
 	}
 
+	// After-events are stored to the outbox and delivered to the
+	// event bus handlers by the outbox worker
+	eventbus.SetupOutbox(
+		app.Log,
+		app.Store,
+		eventbus.Service(),
+		app.Opt.Eventbus,
+		sysEvent.Restore,
+		cmpService.RestoreEvent,
+		msgEvent.Restore,
+	)
+
+	eventbus.Outbox().SetUserFinder(sysService.DefaultUser)
+
 	corredor.Service().SetUserFinder(sysService.DefaultUser)
 	corredor.Service().SetRoleFinder(sysService.DefaultRole)
 
@@ -328,6 +356,13 @@ func (app *CortezaApp) Activate(ctx context.Context) (err error) {
 		scheduler.Service().Start(ctx)
 	}
 
+	// Start delivering events from the outbox
+	if app.Opt.Eventbus.OutboxEnabled {
+		eventbus.Outbox().Start(ctx)
+	} else {
+		app.Log.Debug("eventbus outbox disabled (EVENTBUS_OUTBOX_ENABLED=false)")
+	}
+
 	// Load corredor scripts & init watcher (script reloader)
 	corredor.Service().Load(ctx)
 	corredor.Service().Watch(ctx)
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 
+	composeCommands "github.com/cortezaproject/corteza-server/compose/commands"
 	federationCommands "github.com/cortezaproject/corteza-server/federation/commands"
 	"github.com/cortezaproject/corteza-server/pkg/cli"
 	"github.com/cortezaproject/corteza-server/pkg/rbac"
@@ -51,14 +52,18 @@ func (app *CortezaApp) InitCLI() {
 	app.Command.AddCommand(
 		systemCommands.Users(app),
 		systemCommands.Roles(app),
+		systemCommands.Templates(app),
+		systemCommands.EventOutbox(app),
 		systemCommands.Auth(app),
 		systemCommands.RBAC(app),
 		systemCommands.Sink(app),
-		systemCommands.Settings(),
+		systemCommands.Settings(app),
 		systemCommands.Import(storeInit),
 		serveCmd,
 		upgradeCmd,
 		provisionCmd,
+		composeCommands.Namespaces(app),
+		composeCommands.Records(app),
 		federationCommands.Sync(app),
 		cli.VersionCommand(),
 	)
@@ -9,6 +9,7 @@ type (
 		Environment options.EnvironmentOpt
 		ActionLog   options.ActionLogOpt
 		SMTP        options.SMTPOpt
+		SMTPServer  options.SMTPServerOpt
 		Auth        options.AuthOpt
 		HTTPClient  options.HTTPClientOpt
 		DB          options.DBOpt
@@ -24,6 +25,8 @@ type (
 		Eventbus    options.EventbusOpt
 		Federation  options.FederationOpt
 		SCIM        options.SCIMOpt
+		RateLimit   options.RateLimitOpt
+		Secrets     options.SecretsOpt
 	}
 )
 
@@ -33,6 +36,7 @@ func NewOptions() *Options {
 		ActionLog:   *options.ActionLog(),
 		Auth:        *options.Auth(),
 		SMTP:        *options.SMTP(),
+		SMTPServer:  *options.SMTPServer(),
 		HTTPClient:  *options.HTTPClient(),
 		DB:          *options.DB(),
 		Upgrade:     *options.Upgrade(),
@@ -47,5 +51,7 @@ func NewOptions() *Options {
 		Eventbus:    *options.Eventbus(),
 		Federation:  *options.Federation(),
 		SCIM:        *options.SCIM(),
+		RateLimit:   *options.RateLimit(),
+		Secrets:     *options.Secrets(),
 	}
 }
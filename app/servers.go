@@ -2,17 +2,23 @@ package app
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
 	composeRest "github.com/cortezaproject/corteza-server/compose/rest"
 	federationRest "github.com/cortezaproject/corteza-server/federation/rest"
 	messagingRest "github.com/cortezaproject/corteza-server/messaging/rest"
 	"github.com/cortezaproject/corteza-server/pkg/actionlog"
 	"github.com/cortezaproject/corteza-server/pkg/api/server"
 	"github.com/cortezaproject/corteza-server/pkg/logger"
+	"github.com/cortezaproject/corteza-server/pkg/ratelimit"
+	"github.com/cortezaproject/corteza-server/pkg/smtpd"
 	"github.com/cortezaproject/corteza-server/pkg/webapp"
 	systemRest "github.com/cortezaproject/corteza-server/system/rest"
 	"github.com/cortezaproject/corteza-server/system/scim"
+	systemService "github.com/cortezaproject/corteza-server/system/service"
 	"github.com/go-chi/chi"
 	"go.uber.org/zap"
+	"io"
 	"net/http"
 	"regexp"
 	"strings"
@@ -27,6 +33,15 @@ func (app *CortezaApp) Serve(ctx context.Context) (err error) {
 		app.HttpServer = server.New(app.Log, app.Opt.Environment, app.Opt.HTTPServer, app.Opt.WaitFor)
 		app.HttpServer.MountRoutes(app.mountHttpRoutes)
 
+		if app.Opt.RateLimit.Enabled {
+			rl, err := app.makeRateLimiter()
+			if err != nil {
+				return err
+			}
+
+			app.HttpServer.Use(rl)
+		}
+
 		wg.Add(1)
 		go func() {
 			app.HttpServer.Serve(actionlog.RequestOriginToContext(ctx, actionlog.RequestOrigin_API_REST))
@@ -34,6 +49,22 @@ func (app *CortezaApp) Serve(ctx context.Context) (err error) {
 		}()
 	}
 
+	if app.Opt.SMTPServer.Enabled {
+		srv, err := app.makeSmtpServer()
+		if err != nil {
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			if err := srv.ListenAndServe(actionlog.RequestOriginToContext(ctx, actionlog.RequestOrigin_SMTP)); err != nil {
+				app.Log.Error("SMTP server failed", zap.Error(err))
+			}
+
+			wg.Done()
+		}()
+	}
+
 	{
 		//wg.Add(1)
 		//go func(ctx context.Context) {
@@ -48,6 +79,74 @@ func (app *CortezaApp) Serve(ctx context.Context) (err error) {
 	return nil
 }
 
+// makeSmtpServer configures embedded SMTP server that passes
+// all received messages to sink
+func (app *CortezaApp) makeSmtpServer() (*smtpd.Server, error) {
+	var (
+		opt = app.Opt.SMTPServer
+		srv = &smtpd.Server{
+			Addr:            opt.Addr,
+			Hostname:        opt.Hostname,
+			MaxMessageSize:  opt.MaxMessageSize,
+			MaxRecipients:   opt.MaxRecipients,
+			Timeout:         opt.Timeout,
+			AcceptRecipient: smtpd.DomainFilter(strings.Split(opt.AllowedDomains, ",")...),
+			Log:             app.Log.Named("smtp"),
+
+			Handler: func(ctx context.Context, _ *smtpd.Envelope, data io.Reader) error {
+				return systemService.DefaultSink.ProcessMail(ctx, data)
+			},
+		}
+	)
+
+	if opt.TlsCertFile != "" && opt.TlsKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(opt.TlsCertFile, opt.TlsKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load SMTP server certificate: %w", err)
+		}
+
+		srv.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+	}
+
+	app.Log.Info(
+		"SMTP server enabled",
+		zap.String("addr", opt.Addr),
+		zap.String("allowedDomains", opt.AllowedDomains),
+		zap.Bool("starttls", srv.TLSConfig != nil),
+	)
+
+	return srv, nil
+}
+
+// makeRateLimiter configures API rate limiting middleware with memory or store backend
+func (app *CortezaApp) makeRateLimiter() (func(http.Handler) http.Handler, error) {
+	var (
+		opt     = app.Opt.RateLimit
+		backend ratelimit.Backend
+	)
+
+	switch opt.Backend {
+	case "", "memory":
+		backend = ratelimit.Memory()
+	case "store":
+		backend = ratelimit.Store(app.Store)
+	default:
+		return nil, fmt.Errorf("unknown rate limit backend %q", opt.Backend)
+	}
+
+	app.Log.Info(
+		"API rate limiting enabled",
+		zap.String("backend", opt.Backend),
+		zap.String("user", opt.User),
+		zap.String("token", opt.Token),
+		zap.String("ip", opt.IP),
+		zap.String("auth", opt.Auth),
+		zap.String("groups", opt.Groups),
+	)
+
+	return server.RateLimit(app.Log, opt, app.Opt.HTTPServer.ApiBaseUrl, backend)
+}
+
 func (app *CortezaApp) mountHttpRoutes(r chi.Router) {
 	var (
 		apiBaseUrl    = strings.Trim(app.Opt.HTTPServer.ApiBaseUrl, "/")
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+func Namespaces(app serviceInitializer) *cobra.Command {
+	var (
+		flagName    string
+		flagRecords bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "namespaces",
+		Short: "Namespace management",
+	}
+
+	cloneCmd := &cobra.Command{
+		Use:   "clone [namespace-ID-or-slug] [slug]",
+		Short: "Clone namespace with modules, charts, pages and permissions",
+		Long: "Clone namespace with all modules, charts, pages and permission rules.\n\n" +
+			"References between cloned resources are remapped to the new IDs; " +
+			"records are cloned only when --records flag is set.",
+		Args:    cobra.ExactArgs(2),
+		PreRunE: commandPreRunInitService(app),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx = auth.SetSuperUserContext(cli.Context())
+			)
+
+			ns, err := service.DefaultNamespace.With(ctx).FindByAny(args[0])
+			cli.HandleError(err)
+
+			ns, err = service.DefaultNamespace.With(ctx).Clone(ns.ID, &types.Namespace{Name: flagName, Slug: args[1]}, flagRecords)
+			cli.HandleError(err)
+
+			cmd.Printf("Namespace %q cloned as [%d] %q\n", args[0], ns.ID, ns.Slug)
+		},
+	}
+
+	cloneCmd.Flags().StringVar(&flagName, "name", "", "Name of the new namespace (defaults to the name of the original)")
+	cloneCmd.Flags().BoolVar(&flagRecords, "records", false, "Clone records")
+
+	cmd.AddCommand(cloneCmd)
+
+	return cmd
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+func Records(app serviceInitializer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "records",
+		Short: "Record management",
+	}
+
+	storageCmd := &cobra.Command{
+		Use:   "storage [namespace-ID-or-slug] [module-ID-or-handle] [default|dedicated]",
+		Short: "Move module records between the shared and the dedicated storage",
+		Long: "Move module records between the shared record value table (default) " +
+			"and the table dedicated to the module (dedicated).\n\n" +
+			"Records are moved in a single transaction; on MySQL schema changes can not be rolled back.",
+		Args:    cobra.ExactArgs(3),
+		PreRunE: commandPreRunInitService(app),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx = auth.SetSuperUserContext(cli.Context())
+
+				nsStr, modStr, storage = args[0], args[1], args[2]
+
+				ns  *types.Namespace
+				mod *types.Module
+
+				err error
+			)
+
+			if storage == "default" {
+				storage = types.ModuleStorageDefault
+			}
+
+			ns, err = service.DefaultNamespace.With(ctx).FindByAny(nsStr)
+			cli.HandleError(err)
+
+			mod, err = service.DefaultModule.With(ctx).FindByAny(ns.ID, modStr)
+			cli.HandleError(err)
+
+			mod, err = service.DefaultModule.With(ctx).MigrateStorage(ns.ID, mod.ID, storage)
+			cli.HandleError(err)
+
+			cmd.Printf("Records of module [%d] %q are in %q storage\n", mod.ID, mod.Handle, args[2])
+		},
+	}
+
+	rotateKeyCmd := &cobra.Command{
+		Use:   "rotate-key",
+		Short: "Re-encrypt values of encrypted fields with the current encryption key",
+		Long: "Re-encrypts values of encrypted fields that were encrypted with one of the previous keys (SECRETS_PREVIOUS_KEYS) " +
+			"and encrypts the unencrypted ones.\n\n" +
+			"Previous keys can be removed from the configuration once all values are re-encrypted.",
+		PreRunE: commandPreRunInitService(app),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx = auth.SetSuperUserContext(cli.Context())
+			)
+
+			rotated, err := service.DefaultRecord.With(ctx).RotateKey()
+			cli.HandleError(err)
+
+			cmd.Printf("%d value(s) of encrypted fields re-encrypted\n", rotated)
+		},
+	}
+
+	cmd.AddCommand(
+		storageCmd,
+		rotateKeyCmd,
+	)
+
+	return cmd
+}
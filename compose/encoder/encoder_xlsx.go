@@ -8,22 +8,35 @@ import (
 
 type (
 	excelizeEncoder struct {
-		row int
-		f   *excelize.File
-		w   io.Writer
-		ff  []field
-		u   userFinder
-		tz  string
+		row  int
+		name string
+		f    *excelize.File
+		w    io.Writer
+		ff   []field
+		u    userFinder
+		tz   string
+
+		// sheets with related records, shared by all encoders of the file
+		sheets map[string]*excelizeEncoder
+
+		// ID of the parent record, written in the first column of the related sheet
+		parentID uint64
 	}
 )
 
+const (
+	excelizeParentColumn = "parentRecordID"
+)
+
 func NewExcelizeEncoder(w io.Writer, header bool, u userFinder, tz string, ff ...field) *excelizeEncoder {
 	enc := &excelizeEncoder{
-		f:  excelize.NewFile(),
-		w:  w,
-		ff: preprocessHeader(ff, tz),
-		u:  u,
-		tz: tz,
+		name:   "Sheet1",
+		f:      excelize.NewFile(),
+		w:      w,
+		ff:     preprocessHeader(ff, tz),
+		u:      u,
+		tz:     tz,
+		sheets: make(map[string]*excelizeEncoder),
 	}
 
 	if header {
@@ -44,7 +57,32 @@ func (enc excelizeEncoder) pos(col int) string {
 }
 
 func (enc excelizeEncoder) sheet() string {
-	return "Sheet1"
+	return enc.name
+}
+
+// related returns encoder for the sheet with related records
+//
+// Sheet is created (with header) on first use
+func (enc *excelizeEncoder) related(name string, fields []string) *excelizeEncoder {
+	if sheet, ok := enc.sheets[name]; ok {
+		return sheet
+	}
+
+	sheet := &excelizeEncoder{
+		name:   name,
+		f:      enc.f,
+		w:      enc.w,
+		ff:     preprocessHeader(MakeFields(append([]string{excelizeParentColumn}, fields...)...), enc.tz),
+		u:      enc.u,
+		tz:     enc.tz,
+		sheets: enc.sheets,
+	}
+
+	enc.f.NewSheet(name)
+	sheet.writeHeader()
+	enc.sheets[name] = sheet
+
+	return sheet
 }
 
 func (enc *excelizeEncoder) writeHeader() {
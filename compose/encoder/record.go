@@ -145,21 +145,64 @@ func (enc flatWriter) Record(r *types.Record) (err error) {
 }
 
 func (enc structuredEncoder) Record(r *types.Record) (err error) {
+	out, err := enc.encode(r, enc.ff)
+	if err != nil {
+		return err
+	}
+
+	return enc.w.Encode(out)
+}
+
+// Related encodes record with related records nested under their names
+func (enc structuredEncoder) Related(r *types.Record, rel []*types.RelatedRecords) (err error) {
+	out, err := enc.encodeRelated(r, enc.ff, rel)
+	if err != nil {
+		return err
+	}
+
+	return enc.w.Encode(out)
+}
+
+func (enc structuredEncoder) encodeRelated(r *types.Record, ff []field, rel []*types.RelatedRecords) (out map[string]interface{}, err error) {
+	if out, err = enc.encode(r, ff); err != nil {
+		return
+	}
+
+	for _, rr := range rel {
+		var (
+			cff = MakeFields(rr.Fields...)
+			set = make([]map[string]interface{}, len(rr.Records))
+		)
+
+		for i, c := range rr.Records {
+			if set[i], err = enc.encodeRelated(c, cff, rr.Related[c.ID]); err != nil {
+				return
+			}
+		}
+
+		out[rr.Name] = set
+	}
+
+	return
+}
+
+func (enc structuredEncoder) encode(r *types.Record, ff []field) (out map[string]interface{}, err error) {
 	var (
-		// Exporter can choose fields so we need this buffer
-		// to hold just what we need
-		out = make(map[string]interface{})
-		vv  types.RecordValueSet
-		c   int
+		vv types.RecordValueSet
+		c  int
 	)
 
+	// Exporter can choose fields so we need this buffer
+	// to hold just what we need
+	out = make(map[string]interface{})
+
 	procTime := func(d map[string]interface{}, pts []*parsedTime) {
 		for _, p := range pts {
 			d[p.field] = p.value
 		}
 	}
 
-	for _, f := range enc.ff {
+	for _, f := range ff {
 		switch f.name {
 		case "recordID", "ID":
 			// encode all numbers as string (to prevent overflow of uint64 values)
@@ -173,23 +216,23 @@ func (enc structuredEncoder) Record(r *types.Record) (err error) {
 		case "ownedBy":
 			out[f.name], err = fmtSysUser(r.OwnedBy, enc.u)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		case "createdBy":
 			out[f.name], err = fmtSysUser(r.CreatedBy, enc.u)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		case "createdAt":
 			tt, err := fmtTime("createdAt", &r.CreatedAt, enc.tz)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			procTime(out, tt)
 		case "updatedBy":
 			out[f.name], err = fmtSysUser(r.UpdatedBy, enc.u)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		case "updatedAt":
 			if r.UpdatedAt == nil {
@@ -197,7 +240,7 @@ func (enc structuredEncoder) Record(r *types.Record) (err error) {
 			} else {
 				tt, err := fmtTime("updatedAt", r.UpdatedAt, enc.tz)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				procTime(out, tt)
 			}
@@ -205,7 +248,7 @@ func (enc structuredEncoder) Record(r *types.Record) (err error) {
 		case "deletedBy":
 			out[f.name], err = fmtSysUser(r.DeletedBy, enc.u)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		case "deletedAt":
 			if r.DeletedAt == nil {
@@ -213,7 +256,7 @@ func (enc structuredEncoder) Record(r *types.Record) (err error) {
 			} else {
 				tt, err := fmtTime("deletedAt", r.DeletedAt, enc.tz)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				procTime(out, tt)
 			}
@@ -240,7 +283,7 @@ func (enc structuredEncoder) Record(r *types.Record) (err error) {
 		}
 	}
 
-	return enc.w.Encode(out)
+	return out, nil
 }
 
 func (enc *excelizeEncoder) Record(r *types.Record) (err error) {
@@ -255,6 +298,12 @@ func (enc *excelizeEncoder) Record(r *types.Record) (err error) {
 
 	for p, f := range enc.ff {
 		p++
+		if enc.parentID > 0 && p == 1 {
+			// first column of related sheets links rows to the parent record
+			_ = enc.f.SetCellStr(enc.sheet(), enc.pos(p), fmtUint64(enc.parentID))
+			continue
+		}
+
 		switch f.name {
 		case "recordID", "ID":
 			_ = enc.f.SetCellStr(enc.sheet(), enc.pos(p), fmtUint64(r.ID))
@@ -314,3 +363,24 @@ func (enc *excelizeEncoder) Record(r *types.Record) (err error) {
 
 	return nil
 }
+
+// Related encodes record and writes related records to
+// separate sheets, linked with the parent record ID
+func (enc *excelizeEncoder) Related(r *types.Record, rel []*types.RelatedRecords) (err error) {
+	if err = enc.Record(r); err != nil {
+		return
+	}
+
+	for _, rr := range rel {
+		sheet := enc.related(rr.Name, rr.Fields)
+
+		for _, c := range rr.Records {
+			sheet.parentID = r.ID
+			if err = sheet.Related(c, rr.Related[c.ID]); err != nil {
+				return
+			}
+		}
+	}
+
+	return nil
+}
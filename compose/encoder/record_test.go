@@ -100,3 +100,30 @@ func Test_RecordEncoding(t *testing.T) {
 		})
 	}
 }
+
+func Test_RelatedRecordEncoding(t *testing.T) {
+	var (
+		buf = bytes.NewBuffer([]byte{})
+
+		r = &types.Record{ID: 1, Values: types.RecordValueSet{{Name: "name", Value: "Acme"}}}
+
+		rel = []*types.RelatedRecords{{
+			Name:   "contacts",
+			Fields: []string{"name"},
+			Records: types.RecordSet{
+				{ID: 2, Values: types.RecordValueSet{{Name: "name", Value: "John"}}},
+				{ID: 3, Values: types.RecordValueSet{{Name: "name", Value: "Jane"}}},
+			},
+			Related: map[uint64][]*types.RelatedRecords{
+				2: {{Name: "calls", Fields: []string{"recordID"}, Records: types.RecordSet{{ID: 4}}}},
+			},
+		}}
+	)
+
+	senc := NewStructuredEncoder(json.NewEncoder(buf), nil, "UTC", MakeFields("recordID", "name")...)
+	require.NoError(t, senc.Related(r, rel))
+	require.Equal(t,
+		`{"contacts":[{"calls":[{"recordID":"4"}],"name":"John"},{"name":"Jane"}],"name":"Acme","recordID":"1"}`+"\n",
+		buf.String(),
+	)
+}
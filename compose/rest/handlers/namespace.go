@@ -25,6 +25,7 @@ type (
 		Update(context.Context, *request.NamespaceUpdate) (interface{}, error)
 		Delete(context.Context, *request.NamespaceDelete) (interface{}, error)
 		TriggerScript(context.Context, *request.NamespaceTriggerScript) (interface{}, error)
+		Clone(context.Context, *request.NamespaceClone) (interface{}, error)
 	}
 
 	// HTTP API interface
@@ -35,6 +36,7 @@ type (
 		Update        func(http.ResponseWriter, *http.Request)
 		Delete        func(http.ResponseWriter, *http.Request)
 		TriggerScript func(http.ResponseWriter, *http.Request)
+		Clone         func(http.ResponseWriter, *http.Request)
 	}
 )
 
@@ -134,6 +136,22 @@ func NewNamespace(h NamespaceAPI) *Namespace {
 				return
 			}
 
+			api.Send(w, r, value)
+		},
+		Clone: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewNamespaceClone()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.Clone(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
 			api.Send(w, r, value)
 		},
 	}
@@ -148,5 +166,6 @@ func (h Namespace) MountRoutes(r chi.Router, middlewares ...func(http.Handler) h
 		r.Post("/namespace/{namespaceID}", h.Update)
 		r.Delete("/namespace/{namespaceID}", h.Delete)
 		r.Post("/namespace/{namespaceID}/trigger", h.TriggerScript)
+		r.Post("/namespace/{namespaceID}/clone", h.Clone)
 	})
 }
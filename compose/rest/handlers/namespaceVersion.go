@@ -0,0 +1,209 @@
+package handlers
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+//
+
+import (
+	"context"
+	"github.com/cortezaproject/corteza-server/compose/rest/request"
+	"github.com/cortezaproject/corteza-server/pkg/api"
+	"github.com/go-chi/chi"
+	"net/http"
+)
+
+type (
+	// Internal API interface
+	NamespaceVersionAPI interface {
+		List(context.Context, *request.NamespaceVersionList) (interface{}, error)
+		ReadDraft(context.Context, *request.NamespaceVersionReadDraft) (interface{}, error)
+		CreateDraft(context.Context, *request.NamespaceVersionCreateDraft) (interface{}, error)
+		UpdateDraft(context.Context, *request.NamespaceVersionUpdateDraft) (interface{}, error)
+		DiscardDraft(context.Context, *request.NamespaceVersionDiscardDraft) (interface{}, error)
+		Publish(context.Context, *request.NamespaceVersionPublish) (interface{}, error)
+		Read(context.Context, *request.NamespaceVersionRead) (interface{}, error)
+		Diff(context.Context, *request.NamespaceVersionDiff) (interface{}, error)
+		Rollback(context.Context, *request.NamespaceVersionRollback) (interface{}, error)
+	}
+
+	// HTTP API interface
+	NamespaceVersion struct {
+		List         func(http.ResponseWriter, *http.Request)
+		ReadDraft    func(http.ResponseWriter, *http.Request)
+		CreateDraft  func(http.ResponseWriter, *http.Request)
+		UpdateDraft  func(http.ResponseWriter, *http.Request)
+		DiscardDraft func(http.ResponseWriter, *http.Request)
+		Publish      func(http.ResponseWriter, *http.Request)
+		Read         func(http.ResponseWriter, *http.Request)
+		Diff         func(http.ResponseWriter, *http.Request)
+		Rollback     func(http.ResponseWriter, *http.Request)
+	}
+)
+
+func NewNamespaceVersion(h NamespaceVersionAPI) *NamespaceVersion {
+	return &NamespaceVersion{
+		List: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewNamespaceVersionList()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.List(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		ReadDraft: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewNamespaceVersionReadDraft()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.ReadDraft(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		CreateDraft: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewNamespaceVersionCreateDraft()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.CreateDraft(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		UpdateDraft: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewNamespaceVersionUpdateDraft()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.UpdateDraft(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		DiscardDraft: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewNamespaceVersionDiscardDraft()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.DiscardDraft(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		Publish: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewNamespaceVersionPublish()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.Publish(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		Read: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewNamespaceVersionRead()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.Read(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		Diff: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewNamespaceVersionDiff()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.Diff(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		Rollback: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewNamespaceVersionRollback()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.Rollback(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+	}
+}
+
+func (h NamespaceVersion) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares...)
+		r.Get("/namespace/{namespaceID}/version/", h.List)
+		r.Get("/namespace/{namespaceID}/version/draft", h.ReadDraft)
+		r.Post("/namespace/{namespaceID}/version/draft", h.CreateDraft)
+		r.Post("/namespace/{namespaceID}/version/draft/config", h.UpdateDraft)
+		r.Delete("/namespace/{namespaceID}/version/draft", h.DiscardDraft)
+		r.Post("/namespace/{namespaceID}/version/draft/publish", h.Publish)
+		r.Get("/namespace/{namespaceID}/version/{versionID}", h.Read)
+		r.Get("/namespace/{namespaceID}/version/{versionID}/diff", h.Diff)
+		r.Post("/namespace/{namespaceID}/version/{versionID}/rollback", h.Rollback)
+	})
+}
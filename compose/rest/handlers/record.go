@@ -32,6 +32,7 @@ type (
 		BulkDelete(context.Context, *request.RecordBulkDelete) (interface{}, error)
 		Delete(context.Context, *request.RecordDelete) (interface{}, error)
 		Upload(context.Context, *request.RecordUpload) (interface{}, error)
+		UploadFromMail(context.Context, *request.RecordUploadFromMail) (interface{}, error)
 		TriggerScript(context.Context, *request.RecordTriggerScript) (interface{}, error)
 		TriggerScriptOnList(context.Context, *request.RecordTriggerScriptOnList) (interface{}, error)
 	}
@@ -51,6 +52,7 @@ type (
 		BulkDelete          func(http.ResponseWriter, *http.Request)
 		Delete              func(http.ResponseWriter, *http.Request)
 		Upload              func(http.ResponseWriter, *http.Request)
+		UploadFromMail      func(http.ResponseWriter, *http.Request)
 		TriggerScript       func(http.ResponseWriter, *http.Request)
 		TriggerScriptOnList func(http.ResponseWriter, *http.Request)
 	}
@@ -266,6 +268,22 @@ func NewRecord(h RecordAPI) *Record {
 
 			api.Send(w, r, value)
 		},
+		UploadFromMail: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordUploadFromMail()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.UploadFromMail(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
 		TriggerScript: func(w http.ResponseWriter, r *http.Request) {
 			defer r.Body.Close()
 			params := request.NewRecordTriggerScript()
@@ -317,6 +335,7 @@ func (h Record) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http
 		r.Delete("/namespace/{namespaceID}/module/{moduleID}/record/", h.BulkDelete)
 		r.Delete("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}", h.Delete)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/attachment", h.Upload)
+		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/attachment/mail", h.UploadFromMail)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}/trigger", h.TriggerScript)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/trigger", h.TriggerScriptOnList)
 	})
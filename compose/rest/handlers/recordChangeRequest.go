@@ -0,0 +1,133 @@
+package handlers
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+//
+
+import (
+	"context"
+	"github.com/cortezaproject/corteza-server/compose/rest/request"
+	"github.com/cortezaproject/corteza-server/pkg/api"
+	"github.com/go-chi/chi"
+	"net/http"
+)
+
+type (
+	// Internal API interface
+	RecordChangeRequestAPI interface {
+		List(context.Context, *request.RecordChangeRequestList) (interface{}, error)
+		Read(context.Context, *request.RecordChangeRequestRead) (interface{}, error)
+		Approve(context.Context, *request.RecordChangeRequestApprove) (interface{}, error)
+		Reject(context.Context, *request.RecordChangeRequestReject) (interface{}, error)
+		Cancel(context.Context, *request.RecordChangeRequestCancel) (interface{}, error)
+	}
+
+	// HTTP API interface
+	RecordChangeRequest struct {
+		List    func(http.ResponseWriter, *http.Request)
+		Read    func(http.ResponseWriter, *http.Request)
+		Approve func(http.ResponseWriter, *http.Request)
+		Reject  func(http.ResponseWriter, *http.Request)
+		Cancel  func(http.ResponseWriter, *http.Request)
+	}
+)
+
+func NewRecordChangeRequest(h RecordChangeRequestAPI) *RecordChangeRequest {
+	return &RecordChangeRequest{
+		List: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordChangeRequestList()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.List(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		Read: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordChangeRequestRead()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.Read(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		Approve: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordChangeRequestApprove()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.Approve(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		Reject: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordChangeRequestReject()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.Reject(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		Cancel: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordChangeRequestCancel()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.Cancel(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+	}
+}
+
+func (h RecordChangeRequest) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares...)
+		r.Get("/namespace/{namespaceID}/change-request/", h.List)
+		r.Get("/namespace/{namespaceID}/change-request/{changeRequestID}", h.Read)
+		r.Post("/namespace/{namespaceID}/change-request/{changeRequestID}/approve", h.Approve)
+		r.Post("/namespace/{namespaceID}/change-request/{changeRequestID}/reject", h.Reject)
+		r.Post("/namespace/{namespaceID}/change-request/{changeRequestID}/cancel", h.Cancel)
+	})
+}
@@ -36,8 +36,14 @@ type (
 	moduleFieldPayload struct {
 		*types.ModuleField
 
-		CanReadRecordValue   bool `json:"canReadRecordValue"`
-		CanUpdateRecordValue bool `json:"canUpdateRecordValue"`
+		CanReadRecordValue    bool `json:"canReadRecordValue"`
+		CanUpdateRecordValue  bool `json:"canUpdateRecordValue"`
+		CanDecryptRecordValue bool `json:"canDecryptRecordValue"`
+	}
+
+	// moduleDryRunPayload reports values that would fail to convert on module update
+	moduleDryRunPayload struct {
+		Set []types.RecordValueError `json:"set"`
 	}
 
 	Module struct {
@@ -58,6 +64,7 @@ type (
 
 		CanReadRecordValue(context.Context, *types.ModuleField) bool
 		CanUpdateRecordValue(context.Context, *types.ModuleField) bool
+		CanDecryptRecordValue(context.Context, *types.ModuleField) bool
 
 		CanManageAutomationTriggersOnModule(context.Context, *types.Module) bool
 	}
@@ -109,10 +116,23 @@ func (ctrl *Module) Create(ctx context.Context, r *request.ModuleCreate) (interf
 			Handle:      r.Handle,
 			Fields:      r.Fields,
 			Meta:        r.Meta,
+			Storage:     r.Storage,
 			Labels:      r.Labels,
 		}
 	)
 
+	if len(r.Workflow) > 0 {
+		if err = r.Workflow.Unmarshal(&mod.Workflow); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(r.Approval) > 0 {
+		if err = r.Approval.Unmarshal(&mod.Approval); err != nil {
+			return nil, err
+		}
+	}
+
 	mod, err = ctrl.module.With(ctx).Create(mod)
 	return ctrl.makePayload(ctx, mod, err)
 }
@@ -132,7 +152,38 @@ func (ctrl *Module) Update(ctx context.Context, r *request.ModuleUpdate) (interf
 		}
 	)
 
-	mod, err = ctrl.module.With(ctx).Update(mod)
+	if len(r.Workflow) > 0 {
+		if err = r.Workflow.Unmarshal(&mod.Workflow); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(r.Approval) > 0 {
+		if err = r.Approval.Unmarshal(&mod.Approval); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.DryRun {
+		rve, err := ctrl.module.With(ctx).DryRunUpdate(mod)
+		if err != nil {
+			return nil, err
+		}
+
+		rsp := &moduleDryRunPayload{Set: []types.RecordValueError{}}
+		if rve != nil {
+			rsp.Set = append(rsp.Set, rve.Set...)
+		}
+
+		return rsp, nil
+	}
+
+	if r.AcceptLosses {
+		mod, err = ctrl.module.With(ctx).ForceUpdate(mod)
+	} else {
+		mod, err = ctrl.module.With(ctx).Update(mod)
+	}
+
 	return ctrl.makePayload(ctx, mod, err)
 }
 
@@ -199,8 +250,9 @@ func (ctrl Module) makeFieldsPayload(ctx context.Context, m *types.Module) (out
 		out[i] = &moduleFieldPayload{
 			ModuleField: f,
 
-			CanReadRecordValue:   ctrl.ac.CanReadRecordValue(ctx, f),
-			CanUpdateRecordValue: ctrl.ac.CanUpdateRecordValue(ctx, f),
+			CanReadRecordValue:    ctrl.ac.CanReadRecordValue(ctx, f),
+			CanUpdateRecordValue:  ctrl.ac.CanUpdateRecordValue(ctx, f),
+			CanDecryptRecordValue: ctrl.ac.CanDecryptRecordValue(ctx, f),
 		}
 	}
 
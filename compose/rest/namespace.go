@@ -130,6 +130,11 @@ func (ctrl Namespace) Delete(ctx context.Context, r *request.NamespaceDelete) (i
 	return api.OK(), ctrl.namespace.With(ctx).DeleteByID(r.NamespaceID)
 }
 
+func (ctrl Namespace) Clone(ctx context.Context, r *request.NamespaceClone) (interface{}, error) {
+	ns, err := ctrl.namespace.With(ctx).Clone(r.NamespaceID, &types.Namespace{Name: r.Name, Slug: r.Slug}, r.Records)
+	return ctrl.makePayload(ctx, ns, err)
+}
+
 func (ctrl *Namespace) TriggerScript(ctx context.Context, r *request.NamespaceTriggerScript) (rsp interface{}, err error) {
 	var (
 		namespace *types.Namespace
@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/cortezaproject/corteza-server/compose/rest/request"
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/api"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+)
+
+type (
+	namespaceVersionSetPayload struct {
+		Filter types.NamespaceVersionFilter `json:"filter"`
+		Set    types.NamespaceVersionSet    `json:"set"`
+	}
+
+	NamespaceVersion struct {
+		version service.NamespaceVersionService
+	}
+)
+
+func (NamespaceVersion) New() *NamespaceVersion {
+	return &NamespaceVersion{
+		version: service.DefaultNamespaceVersion,
+	}
+}
+
+func (ctrl NamespaceVersion) List(ctx context.Context, r *request.NamespaceVersionList) (interface{}, error) {
+	var (
+		err error
+		f   = types.NamespaceVersionFilter{
+			NamespaceID: r.NamespaceID,
+			Status:      types.NamespaceVersionStatus(r.Status),
+		}
+	)
+
+	if f.Paging, err = filter.NewPaging(r.Limit, r.PageCursor); err != nil {
+		return nil, err
+	}
+
+	if f.Sorting, err = filter.NewSorting(r.Sort); err != nil {
+		return nil, err
+	}
+
+	set, f, err := ctrl.version.With(ctx).Find(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &namespaceVersionSetPayload{Filter: f, Set: set}, nil
+}
+
+func (ctrl NamespaceVersion) ReadDraft(ctx context.Context, r *request.NamespaceVersionReadDraft) (interface{}, error) {
+	return ctrl.version.With(ctx).Draft(r.NamespaceID)
+}
+
+func (ctrl NamespaceVersion) CreateDraft(ctx context.Context, r *request.NamespaceVersionCreateDraft) (interface{}, error) {
+	return ctrl.version.With(ctx).CreateDraft(r.NamespaceID)
+}
+
+func (ctrl NamespaceVersion) UpdateDraft(ctx context.Context, r *request.NamespaceVersionUpdateDraft) (interface{}, error) {
+	upd := &types.NamespaceVersion{Comment: r.Comment}
+	if err := r.Config.Unmarshal(&upd.Config); err != nil {
+		return nil, err
+	}
+
+	return ctrl.version.With(ctx).UpdateDraft(r.NamespaceID, upd)
+}
+
+func (ctrl NamespaceVersion) DiscardDraft(ctx context.Context, r *request.NamespaceVersionDiscardDraft) (interface{}, error) {
+	return api.OK(), ctrl.version.With(ctx).DiscardDraft(r.NamespaceID)
+}
+
+func (ctrl NamespaceVersion) Publish(ctx context.Context, r *request.NamespaceVersionPublish) (interface{}, error) {
+	return ctrl.version.With(ctx).Publish(r.NamespaceID)
+}
+
+func (ctrl NamespaceVersion) Read(ctx context.Context, r *request.NamespaceVersionRead) (interface{}, error) {
+	return ctrl.version.With(ctx).FindByID(r.NamespaceID, r.VersionID)
+}
+
+func (ctrl NamespaceVersion) Diff(ctx context.Context, r *request.NamespaceVersionDiff) (interface{}, error) {
+	return ctrl.version.With(ctx).Diff(r.NamespaceID, r.VersionID)
+}
+
+func (ctrl NamespaceVersion) Rollback(ctx context.Context, r *request.NamespaceVersionRollback) (interface{}, error) {
+	return ctrl.version.With(ctx).Rollback(r.NamespaceID, r.VersionID)
+}
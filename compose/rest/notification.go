@@ -38,12 +38,18 @@ func (ctrl *Notification) EmailSend(ctx context.Context, r *request.Notification
 		ReplyTo:           r.ReplyTo,
 		Subject:           r.Subject,
 		RemoteAttachments: r.RemoteAttachments,
+		Template:          r.Template,
+		Language:          r.Language,
 	}
 
 	var cp = contentPayload{}
-	if err := r.Content.Unmarshal(&cp); err != nil {
-		return false, fmt.Errorf("could not unmarshal content: %w", err)
-	} else {
+	if len(r.Content) == 0 && r.Template == "" {
+		return false, fmt.Errorf("content or template required")
+	} else if len(r.Content) > 0 {
+		if err := r.Content.Unmarshal(&cp); err != nil {
+			return false, fmt.Errorf("could not unmarshal content: %w", err)
+		}
+
 		if len(cp.HTML) > 0 {
 			ntf.ContentHTML = cp.HTML
 
@@ -54,6 +60,12 @@ func (ctrl *Notification) EmailSend(ctx context.Context, r *request.Notification
 		}
 	}
 
+	if len(r.Variables) > 0 {
+		if err := r.Variables.Unmarshal(&ntf.Variables); err != nil {
+			return false, fmt.Errorf("could not unmarshal variables: %w", err)
+		}
+	}
+
 	if err := ctrl.svc.SendEmail(ctx, ntf); err != nil {
 		return false, err
 	} else {
@@ -30,6 +30,9 @@ type (
 
 		CanUpdateRecord bool `json:"canUpdateRecord"`
 		CanDeleteRecord bool `json:"canDeleteRecord"`
+
+		// Workflow transitions available to the current user
+		Transitions []*types.ModuleWorkflowTransition `json:"transitions,omitempty"`
 	}
 
 	recordSetPayload struct {
@@ -48,8 +51,8 @@ type (
 	}
 
 	recordAccessController interface {
-		CanUpdateRecord(context.Context, *types.Module) bool
-		CanDeleteRecord(context.Context, *types.Module) bool
+		CanUpdateModuleRecord(context.Context, *types.Module, *types.Record) bool
+		CanDeleteModuleRecord(context.Context, *types.Module, *types.Record) bool
 	}
 )
 
@@ -134,7 +137,12 @@ func (ctrl *Record) Read(ctx context.Context, r *request.RecordRead) (interface{
 		return nil, store.ErrNotFound
 	}
 
-	return ctrl.makePayload(ctx, m, record, err)
+	payload, err := ctrl.makePayload(ctx, m, record, err)
+	if payload != nil && m.Workflow.IsEnabled() {
+		payload.Transitions = ctrl.record.With(ctx).Transitions(m, record)
+	}
+
+	return payload, err
 }
 
 func (ctrl *Record) Create(ctx context.Context, r *request.RecordCreate) (interface{}, error) {
@@ -274,6 +282,18 @@ func (ctrl *Record) Upload(ctx context.Context, r *request.RecordUpload) (interf
 	return makeAttachmentPayload(ctx, a, err)
 }
 
+func (ctrl *Record) UploadFromMail(ctx context.Context, r *request.RecordUploadFromMail) (interface{}, error) {
+	a, err := ctrl.attachment.With(ctx).CreateRecordAttachmentFromMail(
+		r.NamespaceID,
+		r.MailAttachmentID,
+		r.ModuleID,
+		r.RecordID,
+		r.FieldName,
+	)
+
+	return makeAttachmentPayload(ctx, a, err)
+}
+
 func (ctrl *Record) ImportInit(ctx context.Context, r *request.RecordImportInit) (interface{}, error) {
 	if _, err := ctrl.module.With(ctx).FindByID(r.NamespaceID, r.ModuleID); err != nil {
 		return nil, err
@@ -288,7 +308,7 @@ func (ctrl *Record) ImportInit(ctx context.Context, r *request.RecordImportInit)
 	// Mime type detection library fails for some .csv files, so let's help them out a bit.
 	// The detection can now fallback to the user-provided content-type.
 	ct := r.Upload.Header.Get("Content-Type")
-	return ctrl.importSession.Create(ctx, f, r.Upload.Filename, ct, r.NamespaceID, r.ModuleID)
+	return ctrl.importSession.Create(ctx, f, r.Upload.Filename, ct, r.Sheet, r.HeaderRow, r.NamespaceID, r.ModuleID)
 }
 
 func (ctrl *Record) ImportRun(ctx context.Context, r *request.RecordImportRun) (interface{}, error) {
@@ -354,6 +374,8 @@ func (ctrl *Record) Export(ctx context.Context, r *request.RecordExport) (interf
 		}
 
 		contentType string
+
+		x = types.RecordExport{Resolve: r.Resolve}
 	)
 	// Access control.
 	if _, err = ctrl.module.With(ctx).FindByID(r.NamespaceID, r.ModuleID); err != nil {
@@ -364,6 +386,14 @@ func (ctrl *Record) Export(ctx context.Context, r *request.RecordExport) (interf
 		r.Fields = strings.Split(r.Fields[0], ",")
 	}
 
+	x.Fields = r.Fields
+
+	if r.Children != "" {
+		if err = json.Unmarshal([]byte(r.Children), &x.Children); err != nil {
+			return nil, fmt.Errorf("invalid export definition of related records: %w", err)
+		}
+	}
+
 	return func(w http.ResponseWriter, req *http.Request) {
 		ff := encoder.MakeFields(r.Fields...)
 
@@ -400,6 +430,11 @@ func (ctrl *Record) Export(ctx context.Context, r *request.RecordExport) (interf
 			recordEncoder = encoder.NewStructuredEncoder(json.NewEncoder(w), uf, r.Timezone, ff...)
 
 		case "csv":
+			if len(x.Children) > 0 {
+				http.Error(w, "related records can not be exported to csv", http.StatusBadRequest)
+				return
+			}
+
 			contentType = "text/csv"
 			recordEncoder = encoder.NewFlatWriter(csv.NewWriter(w), true, uf, r.Timezone, ff...)
 
@@ -415,7 +450,7 @@ func (ctrl *Record) Export(ctx context.Context, r *request.RecordExport) (interf
 		w.Header().Add("Content-Type", contentType)
 		w.Header().Add("Content-Disposition", "attachment"+filename)
 
-		if err = ctrl.record.With(ctx).Export(f, recordEncoder); err != nil {
+		if err = ctrl.record.With(ctx).Export(f, x, recordEncoder); err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
@@ -481,8 +516,8 @@ func (ctrl Record) makeBulkPayload(ctx context.Context, m *types.Module, err err
 		Record:  rr[0],
 		Records: rr[1:],
 
-		CanUpdateRecord: ctrl.ac.CanUpdateRecord(ctx, m),
-		CanDeleteRecord: ctrl.ac.CanDeleteRecord(ctx, m),
+		CanUpdateRecord: ctrl.ac.CanUpdateModuleRecord(ctx, m, rr[0]),
+		CanDeleteRecord: ctrl.ac.CanDeleteModuleRecord(ctx, m, rr[0]),
 	}, nil
 }
 
@@ -494,8 +529,8 @@ func (ctrl Record) makePayload(ctx context.Context, m *types.Module, r *types.Re
 	return &recordPayload{
 		Record: r,
 
-		CanUpdateRecord: ctrl.ac.CanUpdateRecord(ctx, m),
-		CanDeleteRecord: ctrl.ac.CanDeleteRecord(ctx, m),
+		CanUpdateRecord: ctrl.ac.CanUpdateModuleRecord(ctx, m, r),
+		CanDeleteRecord: ctrl.ac.CanDeleteModuleRecord(ctx, m, r),
 	}, nil
 }
 
@@ -0,0 +1,71 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/cortezaproject/corteza-server/compose/rest/request"
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+)
+
+type (
+	recordChangeRequestSetPayload struct {
+		Filter types.RecordChangeRequestFilter `json:"filter"`
+		Set    types.RecordChangeRequestSet    `json:"set"`
+	}
+
+	RecordChangeRequest struct {
+		changeRequest service.RecordChangeRequestService
+	}
+)
+
+func (RecordChangeRequest) New() *RecordChangeRequest {
+	return &RecordChangeRequest{
+		changeRequest: service.DefaultRecordChangeRequest,
+	}
+}
+
+func (ctrl RecordChangeRequest) List(ctx context.Context, r *request.RecordChangeRequestList) (interface{}, error) {
+	var (
+		err error
+		f   = types.RecordChangeRequestFilter{
+			NamespaceID: r.NamespaceID,
+			ModuleID:    r.ModuleID,
+			RecordID:    r.RecordID,
+			Status:      types.RecordChangeRequestStatus(r.Status),
+			Approvable:  r.Approvable,
+		}
+	)
+
+	if f.Paging, err = filter.NewPaging(r.Limit, r.PageCursor); err != nil {
+		return nil, err
+	}
+
+	if f.Sorting, err = filter.NewSorting(r.Sort); err != nil {
+		return nil, err
+	}
+
+	set, f, err := ctrl.changeRequest.With(ctx).Find(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordChangeRequestSetPayload{Filter: f, Set: set}, nil
+}
+
+func (ctrl RecordChangeRequest) Read(ctx context.Context, r *request.RecordChangeRequestRead) (interface{}, error) {
+	return ctrl.changeRequest.With(ctx).FindByID(r.NamespaceID, r.ChangeRequestID)
+}
+
+func (ctrl RecordChangeRequest) Approve(ctx context.Context, r *request.RecordChangeRequestApprove) (interface{}, error) {
+	return ctrl.changeRequest.With(ctx).Approve(r.NamespaceID, r.ChangeRequestID, r.Comment)
+}
+
+func (ctrl RecordChangeRequest) Reject(ctx context.Context, r *request.RecordChangeRequestReject) (interface{}, error) {
+	return ctrl.changeRequest.With(ctx).Reject(r.NamespaceID, r.ChangeRequestID, r.Comment)
+}
+
+func (ctrl RecordChangeRequest) Cancel(ctx context.Context, r *request.RecordChangeRequestCancel) (interface{}, error) {
+	return ctrl.changeRequest.With(ctx).Cancel(r.NamespaceID, r.ChangeRequestID)
+}
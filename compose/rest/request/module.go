@@ -101,6 +101,21 @@ type (
 		// Module meta data
 		Meta sqlxTypes.JSONText
 
+		// Storage POST parameter
+		//
+		// Record storage (empty for shared, "dedicated" for module's own table)
+		Storage string
+
+		// Workflow POST parameter
+		//
+		// Record workflow (state machine) definition
+		Workflow sqlxTypes.JSONText
+
+		// Approval POST parameter
+		//
+		// Approval flow for record changes
+		Approval sqlxTypes.JSONText
+
 		// Labels POST parameter
 		//
 		// Module labels
@@ -150,6 +165,16 @@ type (
 		// Module meta data
 		Meta sqlxTypes.JSONText
 
+		// Workflow POST parameter
+		//
+		// Record workflow (state machine) definition; kept unchanged when omitted, removed when empty
+		Workflow sqlxTypes.JSONText
+
+		// Approval POST parameter
+		//
+		// Approval flow for record changes; kept unchanged when omitted, removed when empty
+		Approval sqlxTypes.JSONText
+
 		// UpdatedAt POST parameter
 		//
 		// Last update (or creation) date
@@ -159,6 +184,16 @@ type (
 		//
 		// Module labels
 		Labels map[string]string
+
+		// DryRun POST parameter
+		//
+		// Report record values that can not be converted to the new field kinds without updating the module
+		DryRun bool
+
+		// AcceptLosses POST parameter
+		//
+		// Clear record values that can not be converted to the new field kinds instead of failing the update
+		AcceptLosses bool
 	}
 
 	ModuleDelete struct {
@@ -344,6 +379,9 @@ func (r ModuleCreate) Auditable() map[string]interface{} {
 		"handle":      r.Handle,
 		"fields":      r.Fields,
 		"meta":        r.Meta,
+		"storage":     r.Storage,
+		"workflow":    r.Workflow,
+		"approval":    r.Approval,
 		"labels":      r.Labels,
 	}
 }
@@ -373,6 +411,21 @@ func (r ModuleCreate) GetMeta() sqlxTypes.JSONText {
 	return r.Meta
 }
 
+// Auditable returns all auditable/loggable parameters
+func (r ModuleCreate) GetStorage() string {
+	return r.Storage
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r ModuleCreate) GetWorkflow() sqlxTypes.JSONText {
+	return r.Workflow
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r ModuleCreate) GetApproval() sqlxTypes.JSONText {
+	return r.Approval
+}
+
 // Auditable returns all auditable/loggable parameters
 func (r ModuleCreate) GetLabels() map[string]string {
 	return r.Labels
@@ -426,6 +479,27 @@ func (r *ModuleCreate) Fill(req *http.Request) (err error) {
 			}
 		}
 
+		if val, ok := req.Form["storage"]; ok && len(val) > 0 {
+			r.Storage, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["workflow"]; ok && len(val) > 0 {
+			r.Workflow, err = payload.ParseJSONTextWithErr(val[0])
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["approval"]; ok && len(val) > 0 {
+			r.Approval, err = payload.ParseJSONTextWithErr(val[0])
+			if err != nil {
+				return err
+			}
+		}
+
 		if val, ok := req.Form["labels[]"]; ok {
 			r.Labels, err = label.ParseStrings(val)
 			if err != nil {
@@ -519,14 +593,18 @@ func NewModuleUpdate() *ModuleUpdate {
 // Auditable returns all auditable/loggable parameters
 func (r ModuleUpdate) Auditable() map[string]interface{} {
 	return map[string]interface{}{
-		"namespaceID": r.NamespaceID,
-		"moduleID":    r.ModuleID,
-		"name":        r.Name,
-		"handle":      r.Handle,
-		"fields":      r.Fields,
-		"meta":        r.Meta,
-		"updatedAt":   r.UpdatedAt,
-		"labels":      r.Labels,
+		"namespaceID":  r.NamespaceID,
+		"moduleID":     r.ModuleID,
+		"name":         r.Name,
+		"handle":       r.Handle,
+		"fields":       r.Fields,
+		"meta":         r.Meta,
+		"workflow":     r.Workflow,
+		"approval":     r.Approval,
+		"updatedAt":    r.UpdatedAt,
+		"labels":       r.Labels,
+		"dryRun":       r.DryRun,
+		"acceptLosses": r.AcceptLosses,
 	}
 }
 
@@ -560,6 +638,16 @@ func (r ModuleUpdate) GetMeta() sqlxTypes.JSONText {
 	return r.Meta
 }
 
+// Auditable returns all auditable/loggable parameters
+func (r ModuleUpdate) GetWorkflow() sqlxTypes.JSONText {
+	return r.Workflow
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r ModuleUpdate) GetApproval() sqlxTypes.JSONText {
+	return r.Approval
+}
+
 // Auditable returns all auditable/loggable parameters
 func (r ModuleUpdate) GetUpdatedAt() *time.Time {
 	return r.UpdatedAt
@@ -570,6 +658,16 @@ func (r ModuleUpdate) GetLabels() map[string]string {
 	return r.Labels
 }
 
+// Auditable returns all auditable/loggable parameters
+func (r ModuleUpdate) GetDryRun() bool {
+	return r.DryRun
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r ModuleUpdate) GetAcceptLosses() bool {
+	return r.AcceptLosses
+}
+
 // Fill processes request and fills internal variables
 func (r *ModuleUpdate) Fill(req *http.Request) (err error) {
 	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
@@ -618,6 +716,20 @@ func (r *ModuleUpdate) Fill(req *http.Request) (err error) {
 			}
 		}
 
+		if val, ok := req.Form["workflow"]; ok && len(val) > 0 {
+			r.Workflow, err = payload.ParseJSONTextWithErr(val[0])
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["approval"]; ok && len(val) > 0 {
+			r.Approval, err = payload.ParseJSONTextWithErr(val[0])
+			if err != nil {
+				return err
+			}
+		}
+
 		if val, ok := req.Form["updatedAt"]; ok && len(val) > 0 {
 			r.UpdatedAt, err = payload.ParseISODatePtrWithErr(val[0])
 			if err != nil {
@@ -636,6 +748,20 @@ func (r *ModuleUpdate) Fill(req *http.Request) (err error) {
 				return err
 			}
 		}
+
+		if val, ok := req.Form["dryRun"]; ok && len(val) > 0 {
+			r.DryRun, err = payload.ParseBool(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["acceptLosses"]; ok && len(val) > 0 {
+			r.AcceptLosses, err = payload.ParseBool(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	{
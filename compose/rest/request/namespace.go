@@ -153,6 +153,28 @@ type (
 		// Script to execute
 		Script string
 	}
+
+	NamespaceClone struct {
+		// NamespaceID PATH parameter
+		//
+		// ID
+		NamespaceID uint64 `json:",string"`
+
+		// Name POST parameter
+		//
+		// Name of the new namespace (defaults to the name of the original)
+		Name string
+
+		// Slug POST parameter
+		//
+		// Slug of the new namespace
+		Slug string
+
+		// Records POST parameter
+		//
+		// Clone records
+		Records bool
+	}
 )
 
 // NewNamespaceList request
@@ -661,3 +683,95 @@ func (r *NamespaceTriggerScript) Fill(req *http.Request) (err error) {
 
 	return err
 }
+
+// NewNamespaceClone request
+func NewNamespaceClone() *NamespaceClone {
+	return &NamespaceClone{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceClone) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceID": r.NamespaceID,
+		"name":        r.Name,
+		"slug":        r.Slug,
+		"records":     r.Records,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceClone) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceClone) GetName() string {
+	return r.Name
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceClone) GetSlug() string {
+	return r.Slug
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceClone) GetRecords() bool {
+	return r.Records
+}
+
+// Fill processes request and fills internal variables
+func (r *NamespaceClone) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		if err = req.ParseForm(); err != nil {
+			return err
+		}
+
+		// POST params
+
+		if val, ok := req.Form["name"]; ok && len(val) > 0 {
+			r.Name, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["slug"]; ok && len(val) > 0 {
+			r.Slug, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["records"]; ok && len(val) > 0 {
+			r.Records, err = payload.ParseBool(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "namespaceID")
+		r.NamespaceID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
@@ -0,0 +1,669 @@
+package request
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+//
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/cortezaproject/corteza-server/pkg/payload"
+	"github.com/go-chi/chi"
+	sqlxTypes "github.com/jmoiron/sqlx/types"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// dummy vars to prevent
+// unused imports complain
+var (
+	_ = chi.URLParam
+	_ = multipart.ErrMessageTooLarge
+	_ = payload.ParseUint64s
+)
+
+type (
+	// Internal API interface
+	NamespaceVersionList struct {
+		// NamespaceID PATH parameter
+		//
+		// Namespace ID
+		NamespaceID uint64 `json:",string"`
+
+		// Status GET parameter
+		//
+		// Filter by status (draft, published)
+		Status string
+
+		// Limit GET parameter
+		//
+		// Limit
+		Limit uint
+
+		// PageCursor GET parameter
+		//
+		// Page cursor
+		PageCursor string
+
+		// Sort GET parameter
+		//
+		// Sort items
+		Sort string
+	}
+
+	NamespaceVersionReadDraft struct {
+		// NamespaceID PATH parameter
+		//
+		// Namespace ID
+		NamespaceID uint64 `json:",string"`
+	}
+
+	NamespaceVersionCreateDraft struct {
+		// NamespaceID PATH parameter
+		//
+		// Namespace ID
+		NamespaceID uint64 `json:",string"`
+	}
+
+	NamespaceVersionUpdateDraft struct {
+		// NamespaceID PATH parameter
+		//
+		// Namespace ID
+		NamespaceID uint64 `json:",string"`
+
+		// Config POST parameter
+		//
+		// Namespace configuration (modules, pages and charts)
+		Config sqlxTypes.JSONText
+
+		// Comment POST parameter
+		//
+		// Comment
+		Comment string
+	}
+
+	NamespaceVersionDiscardDraft struct {
+		// NamespaceID PATH parameter
+		//
+		// Namespace ID
+		NamespaceID uint64 `json:",string"`
+	}
+
+	NamespaceVersionPublish struct {
+		// NamespaceID PATH parameter
+		//
+		// Namespace ID
+		NamespaceID uint64 `json:",string"`
+	}
+
+	NamespaceVersionRead struct {
+		// NamespaceID PATH parameter
+		//
+		// Namespace ID
+		NamespaceID uint64 `json:",string"`
+
+		// VersionID PATH parameter
+		//
+		// Version ID
+		VersionID uint64 `json:",string"`
+	}
+
+	NamespaceVersionDiff struct {
+		// NamespaceID PATH parameter
+		//
+		// Namespace ID
+		NamespaceID uint64 `json:",string"`
+
+		// VersionID PATH parameter
+		//
+		// Version ID
+		VersionID uint64 `json:",string"`
+	}
+
+	NamespaceVersionRollback struct {
+		// NamespaceID PATH parameter
+		//
+		// Namespace ID
+		NamespaceID uint64 `json:",string"`
+
+		// VersionID PATH parameter
+		//
+		// Version ID
+		VersionID uint64 `json:",string"`
+	}
+)
+
+// NewNamespaceVersionList request
+func NewNamespaceVersionList() *NamespaceVersionList {
+	return &NamespaceVersionList{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionList) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceID": r.NamespaceID,
+		"status":      r.Status,
+		"limit":       r.Limit,
+		"pageCursor":  r.PageCursor,
+		"sort":        r.Sort,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionList) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionList) GetStatus() string {
+	return r.Status
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionList) GetLimit() uint {
+	return r.Limit
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionList) GetPageCursor() string {
+	return r.PageCursor
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionList) GetSort() string {
+	return r.Sort
+}
+
+// Fill processes request and fills internal variables
+func (r *NamespaceVersionList) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		// GET params
+		tmp := req.URL.Query()
+
+		if val, ok := tmp["status"]; ok && len(val) > 0 {
+			r.Status, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["limit"]; ok && len(val) > 0 {
+			r.Limit, err = payload.ParseUint(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["pageCursor"]; ok && len(val) > 0 {
+			r.PageCursor, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["sort"]; ok && len(val) > 0 {
+			r.Sort, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "namespaceID")
+		r.NamespaceID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewNamespaceVersionReadDraft request
+func NewNamespaceVersionReadDraft() *NamespaceVersionReadDraft {
+	return &NamespaceVersionReadDraft{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionReadDraft) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceID": r.NamespaceID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionReadDraft) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// Fill processes request and fills internal variables
+func (r *NamespaceVersionReadDraft) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "namespaceID")
+		r.NamespaceID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewNamespaceVersionCreateDraft request
+func NewNamespaceVersionCreateDraft() *NamespaceVersionCreateDraft {
+	return &NamespaceVersionCreateDraft{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionCreateDraft) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceID": r.NamespaceID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionCreateDraft) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// Fill processes request and fills internal variables
+func (r *NamespaceVersionCreateDraft) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "namespaceID")
+		r.NamespaceID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewNamespaceVersionUpdateDraft request
+func NewNamespaceVersionUpdateDraft() *NamespaceVersionUpdateDraft {
+	return &NamespaceVersionUpdateDraft{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionUpdateDraft) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceID": r.NamespaceID,
+		"config":      r.Config,
+		"comment":     r.Comment,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionUpdateDraft) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionUpdateDraft) GetConfig() sqlxTypes.JSONText {
+	return r.Config
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionUpdateDraft) GetComment() string {
+	return r.Comment
+}
+
+// Fill processes request and fills internal variables
+func (r *NamespaceVersionUpdateDraft) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		if err = req.ParseForm(); err != nil {
+			return err
+		}
+
+		// POST params
+
+		if val, ok := req.Form["config"]; ok && len(val) > 0 {
+			r.Config, err = payload.ParseJSONTextWithErr(val[0])
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["comment"]; ok && len(val) > 0 {
+			r.Comment, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "namespaceID")
+		r.NamespaceID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewNamespaceVersionDiscardDraft request
+func NewNamespaceVersionDiscardDraft() *NamespaceVersionDiscardDraft {
+	return &NamespaceVersionDiscardDraft{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionDiscardDraft) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceID": r.NamespaceID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionDiscardDraft) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// Fill processes request and fills internal variables
+func (r *NamespaceVersionDiscardDraft) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "namespaceID")
+		r.NamespaceID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewNamespaceVersionPublish request
+func NewNamespaceVersionPublish() *NamespaceVersionPublish {
+	return &NamespaceVersionPublish{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionPublish) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceID": r.NamespaceID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionPublish) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// Fill processes request and fills internal variables
+func (r *NamespaceVersionPublish) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "namespaceID")
+		r.NamespaceID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewNamespaceVersionRead request
+func NewNamespaceVersionRead() *NamespaceVersionRead {
+	return &NamespaceVersionRead{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionRead) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceID": r.NamespaceID,
+		"versionID":   r.VersionID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionRead) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionRead) GetVersionID() uint64 {
+	return r.VersionID
+}
+
+// Fill processes request and fills internal variables
+func (r *NamespaceVersionRead) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "namespaceID")
+		r.NamespaceID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+		val = chi.URLParam(req, "versionID")
+		r.VersionID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewNamespaceVersionDiff request
+func NewNamespaceVersionDiff() *NamespaceVersionDiff {
+	return &NamespaceVersionDiff{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionDiff) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceID": r.NamespaceID,
+		"versionID":   r.VersionID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionDiff) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionDiff) GetVersionID() uint64 {
+	return r.VersionID
+}
+
+// Fill processes request and fills internal variables
+func (r *NamespaceVersionDiff) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "namespaceID")
+		r.NamespaceID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+		val = chi.URLParam(req, "versionID")
+		r.VersionID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewNamespaceVersionRollback request
+func NewNamespaceVersionRollback() *NamespaceVersionRollback {
+	return &NamespaceVersionRollback{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionRollback) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceID": r.NamespaceID,
+		"versionID":   r.VersionID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionRollback) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceVersionRollback) GetVersionID() uint64 {
+	return r.VersionID
+}
+
+// Fill processes request and fills internal variables
+func (r *NamespaceVersionRollback) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "namespaceID")
+		r.NamespaceID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+		val = chi.URLParam(req, "versionID")
+		r.VersionID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
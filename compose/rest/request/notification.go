@@ -53,9 +53,24 @@ type (
 
 		// Content POST parameter
 		//
-		// Message content
+		// Message content (required when template is not used)
 		Content sqlxTypes.JSONText
 
+		// Template POST parameter
+		//
+		// Handle of the system template used to render the subject and content
+		Template string
+
+		// Language POST parameter
+		//
+		// Template language (defaults to recipient's or current user's preferred language)
+		Language string
+
+		// Variables POST parameter
+		//
+		// Template variables
+		Variables sqlxTypes.JSONText
+
 		// RemoteAttachments POST parameter
 		//
 		// Remote files to attach to the email
@@ -76,6 +91,9 @@ func (r NotificationEmailSend) Auditable() map[string]interface{} {
 		"replyTo":           r.ReplyTo,
 		"subject":           r.Subject,
 		"content":           r.Content,
+		"template":          r.Template,
+		"language":          r.Language,
+		"variables":         r.Variables,
 		"remoteAttachments": r.RemoteAttachments,
 	}
 }
@@ -105,6 +123,21 @@ func (r NotificationEmailSend) GetContent() sqlxTypes.JSONText {
 	return r.Content
 }
 
+// Auditable returns all auditable/loggable parameters
+func (r NotificationEmailSend) GetTemplate() string {
+	return r.Template
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NotificationEmailSend) GetLanguage() string {
+	return r.Language
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NotificationEmailSend) GetVariables() sqlxTypes.JSONText {
+	return r.Variables
+}
+
 // Auditable returns all auditable/loggable parameters
 func (r NotificationEmailSend) GetRemoteAttachments() []string {
 	return r.RemoteAttachments
@@ -165,6 +198,27 @@ func (r *NotificationEmailSend) Fill(req *http.Request) (err error) {
 			}
 		}
 
+		if val, ok := req.Form["template"]; ok && len(val) > 0 {
+			r.Template, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["language"]; ok && len(val) > 0 {
+			r.Language, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["variables"]; ok && len(val) > 0 {
+			r.Variables, err = payload.ParseJSONTextWithErr(val[0])
+			if err != nil {
+				return err
+			}
+		}
+
 		//if val, ok := req.Form["remoteAttachments[]"]; ok && len(val) > 0  {
 		//    r.RemoteAttachments, err = val, nil
 		//    if err != nil {
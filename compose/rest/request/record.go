@@ -130,6 +130,16 @@ type (
 		//
 		// File import
 		Upload *multipart.FileHeader
+
+		// Sheet POST parameter
+		//
+		// Sheet name or position (1-based) when importing a spreadsheet; first non-empty sheet when omitted
+		Sheet string
+
+		// HeaderRow POST parameter
+		//
+		// Header row position (1-based) when importing a spreadsheet; detected when omitted
+		HeaderRow uint
 	}
 
 	RecordImportRun struct {
@@ -211,6 +221,16 @@ type (
 		//
 		// Convert times to this timezone
 		Timezone string
+
+		// Resolve GET parameter
+		//
+		// Replace IDs in record fields with label field values of the referenced records
+		Resolve bool
+
+		// Children GET parameter
+		//
+		// Related records from child modules to export (JSON)
+		Children string
 	}
 
 	RecordExec struct {
@@ -377,6 +397,33 @@ type (
 		Upload *multipart.FileHeader
 	}
 
+	RecordUploadFromMail struct {
+		// NamespaceID PATH parameter
+		//
+		// Namespace ID
+		NamespaceID uint64 `json:",string"`
+
+		// ModuleID PATH parameter
+		//
+		// Module ID
+		ModuleID uint64 `json:",string"`
+
+		// RecordID POST parameter
+		//
+		// Record ID
+		RecordID uint64 `json:",string"`
+
+		// FieldName POST parameter
+		//
+		// Field name
+		FieldName string
+
+		// MailAttachmentID POST parameter
+		//
+		// ID of the (system) attachment of the received mail message
+		MailAttachmentID uint64 `json:",string"`
+	}
+
 	RecordTriggerScript struct {
 		// NamespaceID PATH parameter
 		//
@@ -708,6 +755,8 @@ func (r RecordImportInit) Auditable() map[string]interface{} {
 		"namespaceID": r.NamespaceID,
 		"moduleID":    r.ModuleID,
 		"upload":      r.Upload,
+		"sheet":       r.Sheet,
+		"headerRow":   r.HeaderRow,
 	}
 }
 
@@ -726,6 +775,16 @@ func (r RecordImportInit) GetUpload() *multipart.FileHeader {
 	return r.Upload
 }
 
+// Auditable returns all auditable/loggable parameters
+func (r RecordImportInit) GetSheet() string {
+	return r.Sheet
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordImportInit) GetHeaderRow() uint {
+	return r.HeaderRow
+}
+
 // Fill processes request and fills internal variables
 func (r *RecordImportInit) Fill(req *http.Request) (err error) {
 	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
@@ -750,6 +809,19 @@ func (r *RecordImportInit) Fill(req *http.Request) (err error) {
 			return fmt.Errorf("error processing uploaded file: %w", err)
 		}
 
+		if val, ok := req.Form["sheet"]; ok && len(val) > 0 {
+			r.Sheet, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["headerRow"]; ok && len(val) > 0 {
+			r.HeaderRow, err = payload.ParseUint(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	{
@@ -960,6 +1032,8 @@ func (r RecordExport) Auditable() map[string]interface{} {
 		"filter":      r.Filter,
 		"fields":      r.Fields,
 		"timezone":    r.Timezone,
+		"resolve":     r.Resolve,
+		"children":    r.Children,
 	}
 }
 
@@ -998,6 +1072,16 @@ func (r RecordExport) GetTimezone() string {
 	return r.Timezone
 }
 
+// Auditable returns all auditable/loggable parameters
+func (r RecordExport) GetResolve() bool {
+	return r.Resolve
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordExport) GetChildren() string {
+	return r.Children
+}
+
 // Fill processes request and fills internal variables
 func (r *RecordExport) Fill(req *http.Request) (err error) {
 	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
@@ -1038,6 +1122,18 @@ func (r *RecordExport) Fill(req *http.Request) (err error) {
 				return err
 			}
 		}
+		if val, ok := tmp["resolve"]; ok && len(val) > 0 {
+			r.Resolve, err = payload.ParseBool(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["children"]; ok && len(val) > 0 {
+			r.Children, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	{
@@ -1724,6 +1820,110 @@ func (r *RecordUpload) Fill(req *http.Request) (err error) {
 	return err
 }
 
+// NewRecordUploadFromMail request
+func NewRecordUploadFromMail() *RecordUploadFromMail {
+	return &RecordUploadFromMail{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordUploadFromMail) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceID":      r.NamespaceID,
+		"moduleID":         r.ModuleID,
+		"recordID":         r.RecordID,
+		"fieldName":        r.FieldName,
+		"mailAttachmentID": r.MailAttachmentID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordUploadFromMail) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordUploadFromMail) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordUploadFromMail) GetRecordID() uint64 {
+	return r.RecordID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordUploadFromMail) GetFieldName() string {
+	return r.FieldName
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordUploadFromMail) GetMailAttachmentID() uint64 {
+	return r.MailAttachmentID
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordUploadFromMail) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		if err = req.ParseForm(); err != nil {
+			return err
+		}
+
+		// POST params
+
+		if val, ok := req.Form["recordID"]; ok && len(val) > 0 {
+			r.RecordID, err = payload.ParseUint64(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["fieldName"]; ok && len(val) > 0 {
+			r.FieldName, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["mailAttachmentID"]; ok && len(val) > 0 {
+			r.MailAttachmentID, err = payload.ParseUint64(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "namespaceID")
+		r.NamespaceID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+		val = chi.URLParam(req, "moduleID")
+		r.ModuleID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
 // NewRecordTriggerScript request
 func NewRecordTriggerScript() *RecordTriggerScript {
 	return &RecordTriggerScript{}
@@ -0,0 +1,536 @@
+package request
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+//
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/cortezaproject/corteza-server/pkg/payload"
+	"github.com/go-chi/chi"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// dummy vars to prevent
+// unused imports complain
+var (
+	_ = chi.URLParam
+	_ = multipart.ErrMessageTooLarge
+	_ = payload.ParseUint64s
+)
+
+type (
+	// Internal API interface
+	RecordChangeRequestList struct {
+		// NamespaceID PATH parameter
+		//
+		// Namespace ID
+		NamespaceID uint64 `json:",string"`
+
+		// ModuleID GET parameter
+		//
+		// Filter by module ID
+		ModuleID uint64 `json:",string"`
+
+		// RecordID GET parameter
+		//
+		// Filter by record ID
+		RecordID uint64 `json:",string"`
+
+		// Status GET parameter
+		//
+		// Filter by status (pending, approved, rejected, canceled)
+		Status string
+
+		// Approvable GET parameter
+		//
+		// Only pending requests current user can approve or reject
+		Approvable bool
+
+		// Limit GET parameter
+		//
+		// Limit
+		Limit uint
+
+		// PageCursor GET parameter
+		//
+		// Page cursor
+		PageCursor string
+
+		// Sort GET parameter
+		//
+		// Sort items
+		Sort string
+	}
+
+	RecordChangeRequestRead struct {
+		// NamespaceID PATH parameter
+		//
+		// Namespace ID
+		NamespaceID uint64 `json:",string"`
+
+		// ChangeRequestID PATH parameter
+		//
+		// Change request ID
+		ChangeRequestID uint64 `json:",string"`
+	}
+
+	RecordChangeRequestApprove struct {
+		// NamespaceID PATH parameter
+		//
+		// Namespace ID
+		NamespaceID uint64 `json:",string"`
+
+		// ChangeRequestID PATH parameter
+		//
+		// Change request ID
+		ChangeRequestID uint64 `json:",string"`
+
+		// Comment POST parameter
+		//
+		// Comment
+		Comment string
+	}
+
+	RecordChangeRequestReject struct {
+		// NamespaceID PATH parameter
+		//
+		// Namespace ID
+		NamespaceID uint64 `json:",string"`
+
+		// ChangeRequestID PATH parameter
+		//
+		// Change request ID
+		ChangeRequestID uint64 `json:",string"`
+
+		// Comment POST parameter
+		//
+		// Comment
+		Comment string
+	}
+
+	RecordChangeRequestCancel struct {
+		// NamespaceID PATH parameter
+		//
+		// Namespace ID
+		NamespaceID uint64 `json:",string"`
+
+		// ChangeRequestID PATH parameter
+		//
+		// Change request ID
+		ChangeRequestID uint64 `json:",string"`
+	}
+)
+
+// NewRecordChangeRequestList request
+func NewRecordChangeRequestList() *RecordChangeRequestList {
+	return &RecordChangeRequestList{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestList) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceID": r.NamespaceID,
+		"moduleID":    r.ModuleID,
+		"recordID":    r.RecordID,
+		"status":      r.Status,
+		"approvable":  r.Approvable,
+		"limit":       r.Limit,
+		"pageCursor":  r.PageCursor,
+		"sort":        r.Sort,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestList) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestList) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestList) GetRecordID() uint64 {
+	return r.RecordID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestList) GetStatus() string {
+	return r.Status
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestList) GetApprovable() bool {
+	return r.Approvable
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestList) GetLimit() uint {
+	return r.Limit
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestList) GetPageCursor() string {
+	return r.PageCursor
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestList) GetSort() string {
+	return r.Sort
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordChangeRequestList) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		// GET params
+		tmp := req.URL.Query()
+
+		if val, ok := tmp["moduleID"]; ok && len(val) > 0 {
+			r.ModuleID, err = payload.ParseUint64(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["recordID"]; ok && len(val) > 0 {
+			r.RecordID, err = payload.ParseUint64(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["status"]; ok && len(val) > 0 {
+			r.Status, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["approvable"]; ok && len(val) > 0 {
+			r.Approvable, err = payload.ParseBool(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["limit"]; ok && len(val) > 0 {
+			r.Limit, err = payload.ParseUint(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["pageCursor"]; ok && len(val) > 0 {
+			r.PageCursor, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["sort"]; ok && len(val) > 0 {
+			r.Sort, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "namespaceID")
+		r.NamespaceID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewRecordChangeRequestRead request
+func NewRecordChangeRequestRead() *RecordChangeRequestRead {
+	return &RecordChangeRequestRead{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestRead) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceID":     r.NamespaceID,
+		"changeRequestID": r.ChangeRequestID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestRead) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestRead) GetChangeRequestID() uint64 {
+	return r.ChangeRequestID
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordChangeRequestRead) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "namespaceID")
+		r.NamespaceID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+		val = chi.URLParam(req, "changeRequestID")
+		r.ChangeRequestID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewRecordChangeRequestApprove request
+func NewRecordChangeRequestApprove() *RecordChangeRequestApprove {
+	return &RecordChangeRequestApprove{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestApprove) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceID":     r.NamespaceID,
+		"changeRequestID": r.ChangeRequestID,
+		"comment":         r.Comment,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestApprove) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestApprove) GetChangeRequestID() uint64 {
+	return r.ChangeRequestID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestApprove) GetComment() string {
+	return r.Comment
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordChangeRequestApprove) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		if err = req.ParseForm(); err != nil {
+			return err
+		}
+
+		// POST params
+
+		if val, ok := req.Form["comment"]; ok && len(val) > 0 {
+			r.Comment, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "namespaceID")
+		r.NamespaceID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+		val = chi.URLParam(req, "changeRequestID")
+		r.ChangeRequestID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewRecordChangeRequestReject request
+func NewRecordChangeRequestReject() *RecordChangeRequestReject {
+	return &RecordChangeRequestReject{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestReject) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceID":     r.NamespaceID,
+		"changeRequestID": r.ChangeRequestID,
+		"comment":         r.Comment,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestReject) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestReject) GetChangeRequestID() uint64 {
+	return r.ChangeRequestID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestReject) GetComment() string {
+	return r.Comment
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordChangeRequestReject) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		if err = req.ParseForm(); err != nil {
+			return err
+		}
+
+		// POST params
+
+		if val, ok := req.Form["comment"]; ok && len(val) > 0 {
+			r.Comment, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "namespaceID")
+		r.NamespaceID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+		val = chi.URLParam(req, "changeRequestID")
+		r.ChangeRequestID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewRecordChangeRequestCancel request
+func NewRecordChangeRequestCancel() *RecordChangeRequestCancel {
+	return &RecordChangeRequestCancel{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestCancel) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceID":     r.NamespaceID,
+		"changeRequestID": r.ChangeRequestID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestCancel) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordChangeRequestCancel) GetChangeRequestID() uint64 {
+	return r.ChangeRequestID
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordChangeRequestCancel) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "namespaceID")
+		r.NamespaceID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+		val = chi.URLParam(req, "changeRequestID")
+		r.ChangeRequestID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
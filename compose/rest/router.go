@@ -9,14 +9,16 @@ import (
 
 func MountRoutes(r chi.Router) {
 	var (
-		namespace    = Namespace{}.New()
-		module       = Module{}.New()
-		record       = Record{}.New()
-		page         = Page{}.New()
-		chart        = Chart{}.New()
-		notification = Notification{}.New()
-		attachment   = Attachment{}.New()
-		automation   = Automation{}.New()
+		namespace        = Namespace{}.New()
+		namespaceVersion = NamespaceVersion{}.New()
+		changeRequest    = RecordChangeRequest{}.New()
+		module           = Module{}.New()
+		record           = Record{}.New()
+		page             = Page{}.New()
+		chart            = Chart{}.New()
+		notification     = Notification{}.New()
+		attachment       = Attachment{}.New()
+		automation       = Automation{}.New()
 	)
 
 	// Initialize handlers & controllers.
@@ -33,6 +35,8 @@ func MountRoutes(r chi.Router) {
 		r.Group(func(r chi.Router) {
 			r.Use(middlewareAllowedAccess)
 			handlers.NewNamespace(namespace).MountRoutes(r)
+			handlers.NewNamespaceVersion(namespaceVersion).MountRoutes(r)
+			handlers.NewRecordChangeRequest(changeRequest).MountRoutes(r)
 			handlers.NewPage(page).MountRoutes(r)
 			handlers.NewAutomation(automation).MountRoutes(r)
 			handlers.NewModule(module).MountRoutes(r)
@@ -15,9 +15,10 @@ type (
 	}
 
 	accessControlRBACServicer interface {
-		Can([]uint64, rbac.Resource, rbac.Operation, ...rbac.CheckAccessFunc) bool
+		Can(context.Context, []uint64, rbac.Resource, rbac.Operation, ...rbac.CheckAccessFunc) bool
 		Grant(context.Context, rbac.Whitelist, ...*rbac.Rule) error
 		FindRulesByRoleID(roleID uint64) (rr rbac.RuleSet)
+		Rules() (rr rbac.RuleSet)
 	}
 
 	secureResource interface {
@@ -26,6 +27,14 @@ type (
 	}
 )
 
+var (
+	// access of operations when no rule matches
+	accessDefaults = rbac.RegisterDefaults(rbac.Defaults{
+		types.NamespaceRBACResource:   {"read": rbac.Allow},
+		types.ModuleFieldRBACResource: {"record.value.read": rbac.Allow, "record.value.update": rbac.Allow},
+	})
+)
+
 func AccessControl(perm accessControlRBACServicer) *accessControl {
 	return &accessControl{
 		permissions: perm,
@@ -67,7 +76,7 @@ func (svc accessControl) CanCreateNamespace(ctx context.Context) bool {
 }
 
 func (svc accessControl) CanReadNamespace(ctx context.Context, r *types.Namespace) bool {
-	return svc.can(ctx, r, "read", rbac.Allowed)
+	return svc.can(ctx, r, "read")
 }
 
 func (svc accessControl) CanUpdateNamespace(ctx context.Context, r *types.Namespace) bool {
@@ -99,11 +108,18 @@ func (svc accessControl) CanDeleteModule(ctx context.Context, r *types.Module) b
 }
 
 func (svc accessControl) CanReadRecordValue(ctx context.Context, r *types.ModuleField) bool {
-	return svc.can(ctx, r, "record.value.read", rbac.Allowed)
+	return svc.can(ctx, r, "record.value.read")
 }
 
 func (svc accessControl) CanUpdateRecordValue(ctx context.Context, r *types.ModuleField) bool {
-	return svc.can(ctx, r, "record.value.update", rbac.Allowed)
+	return svc.can(ctx, r, "record.value.update")
+}
+
+// CanDecryptRecordValue checks if values of encrypted field can be read
+//
+// Unlike other record value operations, decryption is denied unless explicitly allowed
+func (svc accessControl) CanDecryptRecordValue(ctx context.Context, r *types.ModuleField) bool {
+	return svc.can(ctx, r, "record.value.decrypt")
 }
 
 func (svc accessControl) CanCreateRecord(ctx context.Context, r *types.Module) bool {
@@ -122,6 +138,23 @@ func (svc accessControl) CanDeleteRecord(ctx context.Context, r *types.Module) b
 	return svc.can(ctx, r, "record.delete")
 }
 
+// CanReadModuleRecord checks if user can read a specific record of the module
+//
+// Unlike CanReadRecord, dynamic roles user has on the record are considered
+func (svc accessControl) CanReadModuleRecord(ctx context.Context, m *types.Module, r *types.Record) bool {
+	return svc.can(ctx, r.WithModule(m), "record.read")
+}
+
+// CanUpdateModuleRecord checks if user can update a specific record of the module
+func (svc accessControl) CanUpdateModuleRecord(ctx context.Context, m *types.Module, r *types.Record) bool {
+	return svc.can(ctx, r.WithModule(m), "record.update")
+}
+
+// CanDeleteModuleRecord checks if user can delete a specific record of the module
+func (svc accessControl) CanDeleteModuleRecord(ctx context.Context, m *types.Module, r *types.Record) bool {
+	return svc.can(ctx, r.WithModule(m), "record.delete")
+}
+
 func (svc accessControl) CanManageAutomationTriggersOnModule(ctx context.Context, r *types.Module) bool {
 	return svc.can(ctx, r, "automation-trigger.manage")
 }
@@ -169,10 +202,11 @@ func (svc accessControl) can(ctx context.Context, res secureResource, op rbac.Op
 	}
 
 	return svc.permissions.Can(
+		ctx,
 		append(u.Roles(), res.DynamicRoles(u.Identity())...),
 		res.RBACResource(),
 		op,
-		ff...,
+		append(ff, accessDefaults.Fallback(res.RBACResource(), op))...,
 	)
 }
 
@@ -181,6 +215,12 @@ func (svc accessControl) Grant(ctx context.Context, rr ...*rbac.Rule) error {
 		return AccessControlErrNotAllowedToSetPermissions()
 	}
 
+	for _, r := range rr {
+		if err := r.Conditions.Validate(); err != nil {
+			return AccessControlErrInvalidConditions(&accessControlActionProps{rule: r}).Wrap(err)
+		}
+	}
+
 	if err := svc.permissions.Grant(ctx, svc.Whitelist(), rr...); err != nil {
 		return AccessControlErrGeneric().Wrap(err)
 	}
@@ -212,6 +252,14 @@ func (svc accessControl) FindRulesByRoleID(ctx context.Context, roleID uint64) (
 	return svc.permissions.FindRulesByRoleID(roleID), nil
 }
 
+func (svc accessControl) FindRules(ctx context.Context) (rbac.RuleSet, error) {
+	if !svc.CanGrant(ctx) {
+		return nil, AccessControlErrNotAllowedToSetPermissions()
+	}
+
+	return svc.permissions.Rules(), nil
+}
+
 func (svc accessControl) Whitelist() rbac.Whitelist {
 	var wl = rbac.Whitelist{}
 
@@ -250,6 +298,7 @@ func (svc accessControl) Whitelist() rbac.Whitelist {
 		types.ModuleFieldRBACResource,
 		"record.value.read",
 		"record.value.update",
+		"record.value.decrypt",
 	)
 
 	wl.Set(
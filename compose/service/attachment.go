@@ -8,6 +8,8 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/auth"
 	"github.com/cortezaproject/corteza-server/pkg/objstore"
 	"github.com/cortezaproject/corteza-server/store"
+	systemService "github.com/cortezaproject/corteza-server/system/service"
+	systemTypes "github.com/cortezaproject/corteza-server/system/types"
 	"github.com/disintegration/imaging"
 	"github.com/edwvee/exiffix"
 	"github.com/pkg/errors"
@@ -31,6 +33,9 @@ type (
 		objects   objstore.Store
 		ac        attachmentAccessController
 		store     store.Storer
+
+		// attachments of the received mail messages
+		mail systemService.AttachmentService
 	}
 
 	attachmentAccessController interface {
@@ -50,6 +55,7 @@ type (
 		Find(filter types.AttachmentFilter) (types.AttachmentSet, types.AttachmentFilter, error)
 		CreatePageAttachment(namespaceID uint64, name string, size int64, fh io.ReadSeeker, pageID uint64) (*types.Attachment, error)
 		CreateRecordAttachment(namespaceID uint64, name string, size int64, fh io.ReadSeeker, moduleID, recordID uint64, fieldName string) (*types.Attachment, error)
+		CreateRecordAttachmentFromMail(namespaceID, mailAttachmentID, moduleID, recordID uint64, fieldName string) (*types.Attachment, error)
 		OpenOriginal(att *types.Attachment) (io.ReadSeeker, error)
 		OpenPreview(att *types.Attachment) (io.ReadSeeker, error)
 		DeleteByID(namespaceID, attachmentID uint64) error
@@ -61,6 +67,7 @@ func Attachment(store objstore.Store) AttachmentService {
 		objects: store,
 		ac:      DefaultAccessControl,
 		store:   DefaultStore,
+		mail:    systemService.DefaultAttachment,
 	}).With(context.Background())
 }
 
@@ -71,6 +78,7 @@ func (svc attachment) With(ctx context.Context) AttachmentService {
 		ac:        svc.ac,
 		objects:   svc.objects,
 		store:     svc.store,
+		mail:      svc.mail,
 	}
 }
 
@@ -281,6 +289,39 @@ func (svc attachment) CreatePageAttachment(namespaceID uint64, name string, size
 	return att, svc.recordAction(svc.ctx, aProps, AttachmentActionCreate, err)
 
 }
+
+// CreateRecordAttachmentFromMail copies attachment of the received mail message to the record field
+//
+// Mail attachments are stored by the (system) sink before the message is passed
+// to the onReceive handlers; handlers use this to keep them with the records
+func (svc attachment) CreateRecordAttachmentFromMail(namespaceID, mailAttachmentID, moduleID, recordID uint64, fieldName string) (*types.Attachment, error) {
+	var (
+		ma  *systemTypes.Attachment
+		fh  io.ReadSeeker
+		err error
+	)
+
+	if svc.mail == nil {
+		return nil, AttachmentErrNotFound()
+	}
+
+	if ma, err = svc.mail.With(svc.ctx).FindByID(mailAttachmentID); err != nil {
+		return nil, err
+	}
+
+	if ma.Kind != systemTypes.AttachmentKindMail || ma.DeletedAt != nil {
+		return nil, AttachmentErrNotFound()
+	}
+
+	if fh, err = svc.mail.With(svc.ctx).OpenOriginal(ma); err != nil {
+		return nil, err
+	} else if fh == nil {
+		return nil, AttachmentErrNotFound()
+	}
+
+	return svc.CreateRecordAttachment(namespaceID, ma.Name, ma.Meta.Original.Size, fh, moduleID, recordID, fieldName)
+}
+
 func (svc attachment) CreateRecordAttachment(namespaceID uint64, name string, size int64, fh io.ReadSeeker, moduleID, recordID uint64, fieldName string) (att *types.Attachment, err error) {
 	var (
 		ns *types.Namespace
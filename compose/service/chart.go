@@ -17,6 +17,10 @@ type (
 		actionlog actionlog.Recorder
 		ac        chartAccessController
 		store     store.Storer
+
+		// create keeps the ID that is already set on the chart
+		// (charts in namespace drafts are referenced by their IDs)
+		presetID bool
 	}
 
 	chartAccessController interface {
@@ -168,7 +172,10 @@ func (svc chart) Create(new *types.Chart) (*types.Chart, error) {
 			return err
 		}
 
-		new.ID = nextID()
+		if !svc.presetID || new.ID == 0 {
+			new.ID = nextID()
+		}
+
 		new.CreatedAt = *now()
 		new.UpdatedAt = nil
 		new.DeletedAt = nil
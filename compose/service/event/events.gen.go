@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 )
 
 // dummy placing to simplify import generation logic
@@ -254,6 +255,13 @@ type (
 		*recordBase
 	}
 
+	// recordOnTransition
+	//
+	// This type is auto-generated.
+	recordOnTransition struct {
+		*recordBase
+	}
+
 	// recordBeforeCreate
 	//
 	// This type is auto-generated.
@@ -438,6 +446,11 @@ func (res *composeBase) Decode(results map[string][]byte) (err error) {
 	return
 }
 
+// restore decodes encoded arguments into struct props
+func (res *composeBase) restore(args map[string][]byte) (err error) {
+	return
+}
+
 // ResourceType returns "compose:module"
 //
 // This function is auto-generated.
@@ -861,6 +874,29 @@ func (res *moduleBase) Decode(results map[string][]byte) (err error) {
 	return
 }
 
+// restore decodes encoded arguments into struct props
+func (res *moduleBase) restore(args map[string][]byte) (err error) {
+	if r, ok := args["module"]; ok {
+		if err = json.Unmarshal(r, &res.module); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["oldModule"]; ok {
+		if err = json.Unmarshal(r, &res.oldModule); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["namespace"]; ok {
+		if err = json.Unmarshal(r, &res.namespace); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 // ResourceType returns "compose:namespace"
 //
 // This function is auto-generated.
@@ -1243,6 +1279,23 @@ func (res *namespaceBase) Decode(results map[string][]byte) (err error) {
 	return
 }
 
+// restore decodes encoded arguments into struct props
+func (res *namespaceBase) restore(args map[string][]byte) (err error) {
+	if r, ok := args["namespace"]; ok {
+		if err = json.Unmarshal(r, &res.namespace); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["oldNamespace"]; ok {
+		if err = json.Unmarshal(r, &res.oldNamespace); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 // ResourceType returns "compose:page"
 //
 // This function is auto-generated.
@@ -1666,6 +1719,29 @@ func (res *pageBase) Decode(results map[string][]byte) (err error) {
 	return
 }
 
+// restore decodes encoded arguments into struct props
+func (res *pageBase) restore(args map[string][]byte) (err error) {
+	if r, ok := args["page"]; ok {
+		if err = json.Unmarshal(r, &res.page); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["oldPage"]; ok {
+		if err = json.Unmarshal(r, &res.oldPage); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["namespace"]; ok {
+		if err = json.Unmarshal(r, &res.namespace); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 // ResourceType returns "compose:record"
 //
 // This function is auto-generated.
@@ -1687,6 +1763,13 @@ func (recordOnIteration) EventType() string {
 	return "onIteration"
 }
 
+// EventType on recordOnTransition returns "onTransition"
+//
+// This function is auto-generated.
+func (recordOnTransition) EventType() string {
+	return "onTransition"
+}
+
 // EventType on recordBeforeCreate returns "beforeCreate"
 //
 // This function is auto-generated.
@@ -1821,6 +1904,52 @@ func RecordOnIterationImmutable(
 	}
 }
 
+// RecordOnTransition creates onTransition for compose:record resource
+//
+// This function is auto-generated.
+func RecordOnTransition(
+	argRecord *types.Record,
+	argOldRecord *types.Record,
+	argModule *types.Module,
+	argNamespace *types.Namespace,
+	argRecordValueErrors *types.RecordValueErrorSet,
+) *recordOnTransition {
+	return &recordOnTransition{
+		recordBase: &recordBase{
+			immutable:         false,
+			record:            argRecord,
+			oldRecord:         argOldRecord,
+			module:            argModule,
+			namespace:         argNamespace,
+			recordValueErrors: argRecordValueErrors,
+		},
+	}
+}
+
+// RecordOnTransitionImmutable creates onTransition for compose:record resource
+//
+// None of the arguments will be mutable!
+//
+// This function is auto-generated.
+func RecordOnTransitionImmutable(
+	argRecord *types.Record,
+	argOldRecord *types.Record,
+	argModule *types.Module,
+	argNamespace *types.Namespace,
+	argRecordValueErrors *types.RecordValueErrorSet,
+) *recordOnTransition {
+	return &recordOnTransition{
+		recordBase: &recordBase{
+			immutable:         true,
+			record:            argRecord,
+			oldRecord:         argOldRecord,
+			module:            argModule,
+			namespace:         argNamespace,
+			recordValueErrors: argRecordValueErrors,
+		},
+	}
+}
+
 // RecordBeforeCreate creates beforeCreate for compose:record resource
 //
 // This function is auto-generated.
@@ -2236,3 +2365,156 @@ func (res *recordBase) Decode(results map[string][]byte) (err error) {
 	}
 	return
 }
+
+// restore decodes encoded arguments into struct props
+func (res *recordBase) restore(args map[string][]byte) (err error) {
+	if r, ok := args["record"]; ok {
+		if err = json.Unmarshal(r, &res.record); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["oldRecord"]; ok {
+		if err = json.Unmarshal(r, &res.oldRecord); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["module"]; ok {
+		if err = json.Unmarshal(r, &res.module); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["namespace"]; ok {
+		if err = json.Unmarshal(r, &res.namespace); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["recordValueErrors"]; ok {
+		if err = json.Unmarshal(r, &res.recordValueErrors); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Restore creates event from the encoded arguments
+//
+// Restored events are immutable. Returns nil for unknown resource or event types.
+//
+// This function is auto-generated.
+func Restore(resourceType, eventType string, args map[string][]byte) (eventbus.Event, error) {
+	switch resourceType {
+	case "compose":
+		res := &composeBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "onManual":
+			return &composeOnManual{composeBase: res}, nil
+		case "onInterval":
+			return &composeOnInterval{composeBase: res}, nil
+		case "onTimestamp":
+			return &composeOnTimestamp{composeBase: res}, nil
+		}
+	case "compose:module":
+		res := &moduleBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "onManual":
+			return &moduleOnManual{moduleBase: res}, nil
+		case "beforeCreate":
+			return &moduleBeforeCreate{moduleBase: res}, nil
+		case "beforeUpdate":
+			return &moduleBeforeUpdate{moduleBase: res}, nil
+		case "beforeDelete":
+			return &moduleBeforeDelete{moduleBase: res}, nil
+		case "afterCreate":
+			return &moduleAfterCreate{moduleBase: res}, nil
+		case "afterUpdate":
+			return &moduleAfterUpdate{moduleBase: res}, nil
+		case "afterDelete":
+			return &moduleAfterDelete{moduleBase: res}, nil
+		}
+	case "compose:namespace":
+		res := &namespaceBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "onManual":
+			return &namespaceOnManual{namespaceBase: res}, nil
+		case "beforeCreate":
+			return &namespaceBeforeCreate{namespaceBase: res}, nil
+		case "beforeUpdate":
+			return &namespaceBeforeUpdate{namespaceBase: res}, nil
+		case "beforeDelete":
+			return &namespaceBeforeDelete{namespaceBase: res}, nil
+		case "afterCreate":
+			return &namespaceAfterCreate{namespaceBase: res}, nil
+		case "afterUpdate":
+			return &namespaceAfterUpdate{namespaceBase: res}, nil
+		case "afterDelete":
+			return &namespaceAfterDelete{namespaceBase: res}, nil
+		}
+	case "compose:page":
+		res := &pageBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "onManual":
+			return &pageOnManual{pageBase: res}, nil
+		case "beforeCreate":
+			return &pageBeforeCreate{pageBase: res}, nil
+		case "beforeUpdate":
+			return &pageBeforeUpdate{pageBase: res}, nil
+		case "beforeDelete":
+			return &pageBeforeDelete{pageBase: res}, nil
+		case "afterCreate":
+			return &pageAfterCreate{pageBase: res}, nil
+		case "afterUpdate":
+			return &pageAfterUpdate{pageBase: res}, nil
+		case "afterDelete":
+			return &pageAfterDelete{pageBase: res}, nil
+		}
+	case "compose:record":
+		res := &recordBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "onManual":
+			return &recordOnManual{recordBase: res}, nil
+		case "onIteration":
+			return &recordOnIteration{recordBase: res}, nil
+		case "onTransition":
+			return &recordOnTransition{recordBase: res}, nil
+		case "beforeCreate":
+			return &recordBeforeCreate{recordBase: res}, nil
+		case "beforeUpdate":
+			return &recordBeforeUpdate{recordBase: res}, nil
+		case "beforeDelete":
+			return &recordBeforeDelete{recordBase: res}, nil
+		case "afterCreate":
+			return &recordAfterCreate{recordBase: res}, nil
+		case "afterUpdate":
+			return &recordAfterUpdate{recordBase: res}, nil
+		case "afterDelete":
+			return &recordAfterDelete{recordBase: res}, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -41,3 +41,24 @@ func recordMatch(r *types.Record, c eventbus.ConstraintMatcher) bool {
 
 	return false
 }
+
+// ExprScope provides record values (by field name and type) to expression constraints
+func (res recordBase) ExprScope() map[string]interface{} {
+	var (
+		scope = make(map[string]interface{})
+	)
+
+	if res.module == nil {
+		return scope
+	}
+
+	if res.record != nil {
+		scope["record"] = res.record.Dict(res.module)
+	}
+
+	if res.oldRecord != nil {
+		scope["oldRecord"] = res.oldRecord.Dict(res.module)
+	}
+
+	return scope
+}
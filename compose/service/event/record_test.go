@@ -5,6 +5,7 @@ import (
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -56,3 +57,65 @@ func TestRecordMatchValues(t *testing.T) {
 		)
 	}
 }
+
+func TestRecordRestore(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		rec = &types.Record{ID: 42, Values: types.RecordValueSet{&types.RecordValue{Name: "fld1", Value: "val1"}}}
+		mod = &types.Module{ID: 1, Handle: "mh1"}
+		nms = &types.Namespace{ID: 2, Slug: "slg1"}
+	)
+
+	qe, err := eventbus.NewQueuedEvent(RecordAfterUpdateImmutable(rec, nil, mod, nms, nil), 0)
+	req.NoError(err)
+
+	ev, err := qe.Restore(Restore)
+	req.NoError(err)
+	req.IsType(&recordAfterUpdate{}, ev)
+
+	res := ev.(*recordAfterUpdate)
+	req.Equal(rec.ID, res.Record().ID)
+	req.Equal("val1", res.Record().Values.Get("fld1", 0).Value)
+	req.Equal(mod.Handle, res.Module().Handle)
+	req.True(res.Match(eventbus.MustMakeConstraint("namespace", "eq", "slg1")))
+
+	ev, err = eventbus.QueuedEvent{ResourceType: "compose:record", EventType: "onUnknown"}.Restore(Restore)
+	req.Error(err)
+	req.Nil(ev)
+}
+
+func TestRecordExprConstraint(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		mod = &types.Module{
+			Handle: "mh1",
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "amount", Kind: "Number"},
+				&types.ModuleField{Name: "status", Kind: "String"},
+			},
+		}
+
+		old = &types.Record{Values: types.RecordValueSet{
+			&types.RecordValue{Name: "amount", Value: "100"},
+			&types.RecordValue{Name: "status", Value: "Open"},
+		}}
+
+		rec = &types.Record{Values: types.RecordValueSet{
+			&types.RecordValue{Name: "amount", Value: "12000"},
+			&types.RecordValue{Name: "status", Value: "Closed"},
+		}}
+
+		ev = RecordAfterUpdateImmutable(rec, old, mod, &types.Namespace{}, nil)
+
+		match = func(e string) bool {
+			return eventbus.MustMakeConstraint("", "expr", e).(eventbus.EventConstraintMatcher).MatchEvent(ev)
+		}
+	)
+
+	req.True(match(`record.values.amount > 10000`))
+	req.False(match(`oldRecord.values.amount > 10000`))
+	req.True(match(`oldRecord.values.status == "Open" && record.values.status == "Closed"`))
+	req.True(match(`module.handle == "mh1"`))
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/envoy/csv"
 	"github.com/cortezaproject/corteza-server/pkg/envoy/json"
 	"github.com/cortezaproject/corteza-server/pkg/envoy/resource"
+	"github.com/cortezaproject/corteza-server/pkg/envoy/spreadsheet"
 )
 
 type (
@@ -23,7 +24,7 @@ type (
 	}
 
 	ImportSessionService interface {
-		Create(ctx context.Context, f io.ReadSeeker, name, contentType string, namespaceID, moduleID uint64) (*recordImportSession, error)
+		Create(ctx context.Context, f io.ReadSeeker, name, contentType, sheet string, headerRow uint, namespaceID, moduleID uint64) (*recordImportSession, error)
 		FindByID(ctx context.Context, sessionID uint64) (*recordImportSession, error)
 		DeleteByID(ctx context.Context, sessionID uint64) error
 	}
@@ -45,7 +46,7 @@ func (svc *importSession) indexOf(userID, sessionID uint64) int {
 	return -1
 }
 
-func (svc *importSession) Create(ctx context.Context, f io.ReadSeeker, name, contentType string, namespaceID, moduleID uint64) (*recordImportSession, error) {
+func (svc *importSession) Create(ctx context.Context, f io.ReadSeeker, name, contentType, sheet string, headerRow uint, namespaceID, moduleID uint64) (*recordImportSession, error) {
 	svc.l.Lock()
 	defer svc.l.Unlock()
 
@@ -65,15 +66,18 @@ func (svc *importSession) Create(ctx context.Context, f io.ReadSeeker, name, con
 		UpdatedAt: time.Now(),
 	}
 
-	// Decoders; We only need to do csv & yaml here
+	// Decoders; We only need to do csv, json & spreadsheets here
 	cd := csv.Decoder()
 	jd := json.Decoder()
+	sd := spreadsheet.Decoder()
 
 	// This will really be at most 1
 	var err error
 	do := &envoy.DecoderOpts{
-		Name: name,
-		Path: "",
+		Name:      name,
+		Path:      "",
+		Sheet:     sheet,
+		HeaderRow: headerRow,
 	}
 
 	sh.Resources, err = func() ([]resource.Interface, error) {
@@ -82,6 +86,12 @@ func (svc *importSession) Create(ctx context.Context, f io.ReadSeeker, name, con
 			return cd.Decode(ctx, f, do)
 		}
 
+		f.Seek(0, 0)
+		if sd.CanDecodeFile(f) || sd.CanDecodeMime(contentType) || sd.CanDecodeExt(name) {
+			f.Seek(0, 0)
+			return sd.Decode(ctx, f, do)
+		}
+
 		f.Seek(0, 0)
 		if jd.CanDecodeFile(f) {
 			f.Seek(0, 0)
@@ -107,6 +117,15 @@ func (svc *importSession) Create(ctx context.Context, f io.ReadSeeker, name, con
 		sh.Fields[f] = ""
 	}
 
+	// Spreadsheets; let the user know what other sheets are there
+	if sp, ok := n.P.(interface {
+		Sheets() []string
+		Sheet() string
+	}); ok {
+		sh.Sheets = sp.Sheets()
+		sh.Sheet = sp.Sheet()
+	}
+
 	// Create it
 	svc.records = append(svc.records, sh)
 	return sh, nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/cortezaproject/corteza-server/compose/service/event"
+	"github.com/cortezaproject/corteza-server/compose/service/values"
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/actionlog"
 	"github.com/cortezaproject/corteza-server/pkg/errors"
@@ -11,10 +12,13 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/filter"
 	"github.com/cortezaproject/corteza-server/pkg/handle"
 	"github.com/cortezaproject/corteza-server/pkg/label"
+	"github.com/cortezaproject/corteza-server/pkg/rbac"
+	"github.com/cortezaproject/corteza-server/pkg/secrets"
 	"github.com/cortezaproject/corteza-server/store"
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 type (
@@ -24,6 +28,27 @@ type (
 		ac        moduleAccessController
 		eventbus  eventDispatcher
 		store     store.Storer
+		converter moduleValueConverter
+
+		// encrypts and decrypts values of fields that change their encrypted option
+		keyring *secrets.Keyring
+
+		// update clears values that can not be converted
+		// to the new field kind instead of failing
+		acceptLosses bool
+
+		// create keeps the ID that is already set on the module
+		// (modules in namespace drafts are referenced by their IDs)
+		presetID bool
+	}
+
+	moduleValueConverter interface {
+		Run(context.Context, store.Storer, *types.ModuleField, *types.ModuleField, *types.RecordValue) (*types.RecordValue, *types.RecordValueError)
+	}
+
+	// field that changes its name or kind
+	moduleFieldMigration struct {
+		old, new *types.ModuleField
 	}
 
 	moduleAccessController interface {
@@ -32,6 +57,7 @@ type (
 		CanReadModule(context.Context, *types.Module) bool
 		CanUpdateModule(context.Context, *types.Module) bool
 		CanDeleteModule(context.Context, *types.Module) bool
+		CanGrant(context.Context) bool
 	}
 
 	ModuleService interface {
@@ -45,7 +71,11 @@ type (
 
 		Create(module *types.Module) (*types.Module, error)
 		Update(module *types.Module) (*types.Module, error)
+		ForceUpdate(module *types.Module) (*types.Module, error)
+		DryRunUpdate(module *types.Module) (*types.RecordValueErrorSet, error)
 		DeleteByID(namespaceID, moduleID uint64) error
+
+		MigrateStorage(namespaceID, moduleID uint64, storage string) (*types.Module, error)
 	}
 
 	moduleUpdateHandler func(ctx context.Context, ns *types.Namespace, c *types.Module) (moduleChanges, error)
@@ -65,6 +95,7 @@ func Module() ModuleService {
 		ctx:      context.Background(),
 		ac:       DefaultAccessControl,
 		eventbus: eventbus.Service(),
+		keyring:  DefaultKeyring,
 	}).With(context.Background())
 }
 
@@ -75,9 +106,27 @@ func (svc module) With(ctx context.Context) ModuleService {
 		ac:        svc.ac,
 		eventbus:  svc.eventbus,
 		store:     DefaultStore,
+		converter: newModuleValueConverter(),
+		keyring:   svc.keyring,
 	}
 }
 
+// newModuleValueConverter returns converter for values of fields that change their kind
+func newModuleValueConverter() moduleValueConverter {
+	converter := values.Converter()
+
+	converter.UserResolver(func(ctx context.Context, s store.Storer, email string) (uint64, error) {
+		u, err := store.LookupUserByEmail(ctx, s, email)
+		if err != nil {
+			return 0, err
+		}
+
+		return u.ID, nil
+	})
+
+	return converter
+}
+
 func (svc module) Find(filter types.ModuleFilter) (set types.ModuleSet, f types.ModuleFilter, err error) {
 	var (
 		aProps = &moduleActionProps{filter: &filter}
@@ -193,8 +242,9 @@ func (svc module) FindByAny(namespaceID uint64, identifier interface{}) (m *type
 
 func (svc module) Create(new *types.Module) (*types.Module, error) {
 	var (
-		ns     *types.Namespace
-		aProps = &moduleActionProps{changed: new}
+		ns       *types.Namespace
+		aProps   = &moduleActionProps{changed: new}
+		deferred eventbus.Deferred
 	)
 
 	err := store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
@@ -221,7 +271,38 @@ func (svc module) Create(new *types.Module) (*types.Module, error) {
 			return err
 		}
 
-		new.ID = nextID()
+		if !isValidModuleStorage(new.Storage) {
+			return ModuleErrInvalidStorage()
+		}
+
+		if err = validateModuleWorkflow(new.Workflow, new.Fields); err != nil {
+			return ModuleErrInvalidWorkflow(aProps.setDetails(err.Error()))
+		} else if !new.Workflow.IsEnabled() {
+			new.Workflow = nil
+		}
+
+		if err = validateModuleApproval(new.Approval); err != nil {
+			return ModuleErrInvalidApproval(aProps.setDetails(err.Error()))
+		} else if !new.Approval.IsEnabled() {
+			new.Approval = nil
+		}
+
+		if err = validateModuleDynamicRoles(new.Fields); err != nil {
+			return ModuleErrInvalidDynamicRole(aProps.setDetails(err.Error()))
+		}
+
+		if err = svc.checkDynamicRoles(ctx, new.Fields, nil); err != nil {
+			return err
+		}
+
+		if svc.keyring == nil && hasEncryptedFields(new.Fields) {
+			return ModuleErrEncryptionKeyMissing()
+		}
+
+		if !svc.presetID || new.ID == 0 {
+			new.ID = nextID()
+		}
+
 		new.CreatedAt = *now()
 		new.UpdatedAt = nil
 		new.DeletedAt = nil
@@ -247,21 +328,72 @@ func (svc module) Create(new *types.Module) (*types.Module, error) {
 			return err
 		}
 
+		if err = store.UpgradeComposeRecordStorage(ctx, s, nil, new); err != nil {
+			return err
+		}
+
 		if err = label.Create(ctx, s, new); err != nil {
 			return
 		}
 
-		_ = svc.eventbus.WaitFor(ctx, event.ModuleAfterCreate(new, nil, ns))
-		return nil
+		return deferred.Enqueue(ctx, s, event.ModuleAfterCreate(new, nil, ns))
 	})
 
+	if err == nil {
+		_ = deferred.WaitFor(svc.ctx, svc.eventbus)
+	}
+
 	return new, svc.recordAction(svc.ctx, aProps, ModuleActionCreate, err)
 }
 
+// Update updates the module
+//
+// Update fails when record values can not be converted
+// to the new kind of the fields
 func (svc module) Update(upd *types.Module) (c *types.Module, err error) {
 	return svc.updater(upd.NamespaceID, upd.ID, ModuleActionUpdate, svc.handleUpdate(upd))
 }
 
+// ForceUpdate updates the module and clears record values
+// that can not be converted to the new kind of the fields
+func (svc module) ForceUpdate(upd *types.Module) (c *types.Module, err error) {
+	svc.acceptLosses = true
+	return svc.Update(upd)
+}
+
+// DryRunUpdate reports record values that can not be converted
+// when fields of the module change their kind
+//
+// Module and records are not modified
+func (svc module) DryRunUpdate(upd *types.Module) (report *types.RecordValueErrorSet, err error) {
+	var (
+		aProps = &moduleActionProps{module: &types.Module{ID: upd.ID, NamespaceID: upd.NamespaceID}}
+	)
+
+	err = func() (err error) {
+		var (
+			ns *types.Namespace
+			m  *types.Module
+		)
+
+		if ns, m, err = loadModuleWithNamespace(svc.ctx, svc.store, upd.NamespaceID, upd.ID); err != nil {
+			return
+		}
+
+		aProps.setNamespace(ns)
+		aProps.setModule(m)
+
+		if !svc.ac.CanUpdateModule(svc.ctx, m) {
+			return ModuleErrNotAllowedToUpdate()
+		}
+
+		_, report, err = svc.convertFieldValues(svc.ctx, svc.store, m, moduleFieldMigrations(m.Fields, upd.Fields))
+		return
+	}()
+
+	return report, svc.recordAction(svc.ctx, aProps, ModuleActionUpdateDryRun, err)
+}
+
 func (svc module) DeleteByID(namespaceID, moduleID uint64) error {
 	return trim1st(svc.updater(namespaceID, moduleID, ModuleActionDelete, svc.handleDelete))
 }
@@ -278,6 +410,11 @@ func (svc module) updater(namespaceID, moduleID uint64, action func(...*moduleAc
 		m, old *types.Module
 		aProps = &moduleActionProps{module: &types.Module{ID: moduleID, NamespaceID: namespaceID}}
 		err    error
+
+		// field changes are recorded when the whole update is done
+		fieldActions []func() error
+
+		deferred eventbus.Deferred
 	)
 
 	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
@@ -317,19 +454,67 @@ func (svc module) updater(namespaceID, moduleID uint64, action func(...*moduleAc
 
 		if changes&moduleFieldsChanged > 0 {
 			var (
-				hasRecords bool
-				set        types.RecordSet
+				set types.RecordSet
+
+				mm        []moduleFieldMigration
+				converted types.RecordValueSet
+				report    *types.RecordValueErrorSet
+
+				// fields with values that are encrypted or decrypted
+				encrypting, decrypting types.ModuleFieldSet
 			)
 
-			if set, _, err = store.SearchComposeRecords(ctx, s, m, types.RecordFilter{Paging: filter.Paging{Limit: 1}}); err != nil {
+			// fields are not changed in the storage yet
+			if set, _, err = store.SearchComposeRecords(ctx, s, old, types.RecordFilter{Paging: filter.Paging{Limit: 1}}); err != nil {
 				return err
 			}
 
-			hasRecords = len(set) > 0
+			if len(set) > 0 {
+				// values are converted before the fields are changed;
+				// storage upgrade might not preserve them
+				mm = moduleFieldMigrations(old.Fields, m.Fields)
+				if converted, report, err = svc.convertFieldValues(ctx, s, old, mm); err != nil {
+					return err
+				}
 
-			if err = updateModuleFields(ctx, s, m, m.Fields, hasRecords); err != nil {
+				if !svc.acceptLosses && !report.IsValid() {
+					aProps.setFailed(len(report.Set))
+					return ModuleErrFieldConversionFailed(aProps)
+				}
+
+				for _, f := range moduleFieldEncryptionChanges(old.Fields, m.Fields) {
+					if f.IsEncrypted() {
+						encrypting = append(encrypting, f)
+					} else {
+						decrypting = append(decrypting, f)
+					}
+				}
+
+				// values are decrypted before the storage upgrade
+				// moves them to the columns of the dedicated table
+				if _, err = recryptRecordValues(ctx, s, svc.keyring, old, decrypting); err != nil {
+					return err
+				}
+			}
+
+			if err = updateModuleFields(ctx, s, m, m.Fields); err != nil {
+				return err
+			}
+
+			if err = store.UpgradeComposeRecordStorage(ctx, s, old, m); err != nil {
+				return err
+			}
+
+			if err = migrateFieldValues(ctx, s, m, mm, converted); err != nil {
+				return err
+			}
+
+			if _, err = recryptRecordValues(ctx, s, svc.keyring, m, encrypting); err != nil {
 				return err
 			}
+
+			fieldActions = svc.fieldMigrationActions(m, mm, report)
+			fieldActions = append(fieldActions, svc.fieldEncryptionActions(m, old.Fields, encrypting, decrypting)...)
 		}
 
 		if changes&moduleLabelsChanged > 0 {
@@ -339,17 +524,71 @@ func (svc module) updater(namespaceID, moduleID uint64, action func(...*moduleAc
 		}
 
 		if m.DeletedAt == nil {
-			err = svc.eventbus.WaitFor(svc.ctx, event.ModuleAfterUpdate(m, old, ns))
+			err = deferred.Enqueue(ctx, s, event.ModuleAfterUpdate(m, old, ns))
 		} else {
-			err = svc.eventbus.WaitFor(svc.ctx, event.ModuleAfterDelete(nil, old, ns))
+			err = deferred.Enqueue(ctx, s, event.ModuleAfterDelete(nil, old, ns))
 		}
 
 		return err
 	})
 
+	if err == nil {
+		err = deferred.WaitFor(svc.ctx, svc.eventbus)
+	}
+
+	if err == nil {
+		for _, fn := range fieldActions {
+			_ = fn()
+		}
+	}
+
 	return m, svc.recordAction(svc.ctx, aProps, action, err)
 }
 
+// MigrateStorage moves all module records to the given storage
+//
+// Records are moved between the shared record value table and the
+// module's dedicated table (see types.ModuleStorageDedicated)
+func (svc module) MigrateStorage(namespaceID, moduleID uint64, storage string) (m *types.Module, err error) {
+	var (
+		aProps = &moduleActionProps{module: &types.Module{ID: moduleID, NamespaceID: namespaceID}}
+	)
+
+	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+		var ns *types.Namespace
+
+		if ns, m, err = loadModuleWithNamespace(ctx, s, namespaceID, moduleID); err != nil {
+			return
+		}
+
+		aProps.setNamespace(ns)
+		aProps.setModule(m)
+
+		if !svc.ac.CanUpdateModule(ctx, m) {
+			return ModuleErrNotAllowedToUpdate()
+		}
+
+		if !isValidModuleStorage(storage) {
+			return ModuleErrInvalidStorage()
+		}
+
+		if m.Storage == storage {
+			return nil
+		}
+
+		if err = store.MigrateComposeRecordStorage(ctx, s, m, storage); err != nil {
+			return
+		}
+
+		m.Storage = storage
+		m.UpdatedAt = now()
+
+		return store.UpdateComposeModule(ctx, s, m)
+	})
+
+	return m, svc.recordAction(svc.ctx, aProps, ModuleActionMigrateStorage, err)
+}
+
 // lookup fn() orchestrates module lookup, namespace preload and check, module reading...
 func (svc module) lookup(namespaceID uint64, lookup func(*moduleActionProps) (*types.Module, error)) (m *types.Module, err error) {
 	var aProps = &moduleActionProps{module: &types.Module{NamespaceID: namespaceID}}
@@ -448,10 +687,48 @@ func (svc module) handleUpdate(upd *types.Module) moduleUpdateHandler {
 
 		// @todo make field-change detection more optimal
 		if !reflect.DeepEqual(res.Fields, upd.Fields) {
+			if err = validateModuleDynamicRoles(upd.Fields); err != nil {
+				return moduleUnchanged, ModuleErrInvalidDynamicRole((&moduleActionProps{}).setDetails(err.Error()))
+			}
+
+			if err = svc.checkDynamicRoles(ctx, upd.Fields, res.Fields); err != nil {
+				return moduleUnchanged, err
+			}
+
+			if svc.keyring == nil && hasEncryptedFields(upd.Fields) {
+				return moduleUnchanged, ModuleErrEncryptionKeyMissing()
+			}
+
 			changes |= moduleFieldsChanged
 			res.Fields = upd.Fields
 		}
 
+		if upd.Workflow != nil && !reflect.DeepEqual(res.Workflow, upd.Workflow) {
+			if err = validateModuleWorkflow(upd.Workflow, upd.Fields); err != nil {
+				return moduleUnchanged, ModuleErrInvalidWorkflow((&moduleActionProps{}).setDetails(err.Error()))
+			}
+
+			changes |= moduleChanged
+			res.Workflow = upd.Workflow
+
+			if !res.Workflow.IsEnabled() {
+				res.Workflow = nil
+			}
+		}
+
+		if upd.Approval != nil && !reflect.DeepEqual(res.Approval, upd.Approval) {
+			if err = validateModuleApproval(upd.Approval); err != nil {
+				return moduleUnchanged, ModuleErrInvalidApproval((&moduleActionProps{}).setDetails(err.Error()))
+			}
+
+			changes |= moduleChanged
+			res.Approval = upd.Approval
+
+			if !res.Approval.IsEnabled() {
+				res.Approval = nil
+			}
+		}
+
 		if upd.Labels != nil {
 			if label.Changed(res.Labels, upd.Labels) {
 				changes |= moduleLabelsChanged
@@ -497,9 +774,226 @@ func (svc module) handleUndelete(ctx context.Context, ns *types.Namespace, m *ty
 }
 
 // updates module fields
+// convertFieldValues converts values of the fields that change their kind
+//
+// Values that can not be converted are reported and cleared
+func (svc module) convertFieldValues(ctx context.Context, s store.Storer, m *types.Module, mm []moduleFieldMigration) (out types.RecordValueSet, report *types.RecordValueErrorSet, err error) {
+	var (
+		rr types.RecordSet
+
+		converting = make([]moduleFieldMigration, 0, len(mm))
+	)
+
+	report = &types.RecordValueErrorSet{}
+
+	for _, fm := range mm {
+		if !strings.EqualFold(fm.old.Kind, fm.new.Kind) {
+			converting = append(converting, fm)
+		}
+	}
+
+	if len(converting) == 0 {
+		return
+	}
+
+	if rr, _, err = store.SearchComposeRecords(ctx, s, m, types.RecordFilter{Deleted: filter.StateInclusive}); err != nil {
+		return
+	}
+
+	// values are converted unencrypted and are
+	// encrypted again after the fields are changed
+	if err = decryptRecordValues(svc.keyring, rr...); err != nil {
+		return
+	}
+
+	for _, r := range rr {
+		for _, fm := range converting {
+			for _, v := range r.Values.FilterByName(fm.old.Name) {
+				v.BlindIndex = ""
+
+				c, rve := svc.converter.Run(ctx, s, fm.old, fm.new, v)
+				if rve != nil {
+					report.Push(*rve)
+
+					c = v.Clone()
+					c.Name = fm.new.Name
+					c.Value, c.Ref = "", 0
+					if c.DeletedAt == nil {
+						c.DeletedAt = now()
+					}
+				}
+
+				c.RecordID = r.ID
+				out = append(out, c)
+			}
+		}
+	}
+
+	return
+}
+
+// fieldMigrationActions prepares action log entries of the field changes
+func (svc module) fieldMigrationActions(m *types.Module, mm []moduleFieldMigration, report *types.RecordValueErrorSet) (aa []func() error) {
+	failed := func(name string) (c int) {
+		if report == nil {
+			return
+		}
+
+		for _, rve := range report.Set {
+			if rve.Meta["field"] == name {
+				c++
+			}
+		}
+
+		return
+	}
+
+	for _, fm := range mm {
+		if fm.old.Name != fm.new.Name {
+			aProps := (&moduleActionProps{}).setModule(m).setField(fm.new).setOldField(fm.old)
+			aa = append(aa, func() error { return svc.recordAction(svc.ctx, aProps, ModuleActionRenameField, nil) })
+		}
+
+		if !strings.EqualFold(fm.old.Kind, fm.new.Kind) {
+			aProps := (&moduleActionProps{}).setModule(m).setField(fm.new).setOldField(fm.old).setFailed(failed(fm.new.Name))
+			aa = append(aa, func() error { return svc.recordAction(svc.ctx, aProps, ModuleActionConvertField, nil) })
+		}
+	}
+
+	return
+}
+
+// fieldEncryptionActions prepares action log entries of the fields that change their encrypted option
+func (svc module) fieldEncryptionActions(m *types.Module, existing, encrypting, decrypting types.ModuleFieldSet) (aa []func() error) {
+	for _, f := range encrypting {
+		if e := existing.FindByID(f.ID); e == nil || e.IsEncrypted() {
+			// encrypted field with converted values
+			continue
+		}
+
+		aProps := (&moduleActionProps{}).setModule(m).setField(f)
+		aa = append(aa, func() error { return svc.recordAction(svc.ctx, aProps, ModuleActionEncryptField, nil) })
+	}
+
+	for _, f := range decrypting {
+		aProps := (&moduleActionProps{}).setModule(m).setField(f)
+		aa = append(aa, func() error { return svc.recordAction(svc.ctx, aProps, ModuleActionDecryptField, nil) })
+	}
+
+	return
+}
+
+// moduleFieldMigrations returns existing fields that change name or kind
+func moduleFieldMigrations(existing, upd types.ModuleFieldSet) (mm []moduleFieldMigration) {
+	for _, f := range upd {
+		if f.ID == 0 || f.DeletedAt != nil {
+			continue
+		}
+
+		e := existing.FindByID(f.ID)
+		if e == nil {
+			continue
+		}
+
+		if e.Name != f.Name || !strings.EqualFold(e.Kind, f.Kind) {
+			mm = append(mm, moduleFieldMigration{old: e.Clone(), new: f})
+		}
+	}
+
+	return
+}
+
+// migrateFieldValues renames values of the renamed fields and stores converted values
+func migrateFieldValues(ctx context.Context, s store.Storer, m *types.Module, mm []moduleFieldMigration, converted types.RecordValueSet) (err error) {
+	for _, fm := range mm {
+		if fm.old.Name == fm.new.Name {
+			continue
+		}
+
+		if err = store.RenameComposeRecordValues(ctx, s, m, fm.old.Name, fm.new.Name); err != nil {
+			return
+		}
+	}
+
+	if len(converted) > 0 {
+		return store.PartialComposeRecordValueUpdate(ctx, s, m, converted...)
+	}
+
+	return nil
+}
+
+// hasEncryptedFields checks if values of any of the fields are encrypted
+func hasEncryptedFields(ff types.ModuleFieldSet) bool {
+	for _, f := range ff {
+		if f.DeletedAt == nil && f.IsEncrypted() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateModuleDynamicRoles checks dynamic roles set on the module fields
+//
+// Only User fields can be used as a source of dynamic role; everyone role
+// and built-in dynamic roles can not be used.
+func validateModuleDynamicRoles(ff types.ModuleFieldSet) error {
+	for _, f := range ff {
+		if !f.Options.HasDynamicRole() {
+			continue
+		}
+
+		if f.Kind != "User" {
+			return fmt.Errorf("field %q is not a User field", f.Name)
+		}
+
+		roleID := f.Options.DynamicRole()
+		if roleID == 0 || roleID == rbac.EveryoneRoleID || rbac.IsDynamicRole(roleID) {
+			return fmt.Errorf("invalid role on field %q", f.Name)
+		}
+	}
+
+	return nil
+}
+
+// checkDynamicRoles checks if the current user can set dynamic roles of the fields
+//
+// Users referenced by the field get permissions of the dynamic role;
+// setting (or changing) it requires permission to grant and an existing role.
+// Dynamic roles that are already set on the old fields are not checked.
+func (svc module) checkDynamicRoles(ctx context.Context, ff, old types.ModuleFieldSet) error {
+	for _, f := range ff {
+		roleID := f.Options.DynamicRole()
+		if roleID == 0 {
+			continue
+		}
+
+		if o := old.FindByID(f.ID); f.ID > 0 && o != nil && o.Options.DynamicRole() == roleID {
+			continue
+		}
+
+		if !svc.ac.CanGrant(ctx) {
+			return ModuleErrNotAllowedToSetDynamicRole()
+		}
+
+		r, err := store.LookupRoleByID(ctx, svc.store, roleID)
+		if errors.IsNotFound(err) || (err == nil && (r.DeletedAt != nil || r.ArchivedAt != nil)) {
+			return ModuleErrInvalidDynamicRole((&moduleActionProps{}).setDetails(fmt.Sprintf("role on field %q does not exist", f.Name)))
+		} else if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isValidModuleStorage(storage string) bool {
+	return storage == types.ModuleStorageDefault || storage == types.ModuleStorageDedicated
+}
+
 // expecting to receive all module fields, as it deletes the rest
 // also, sort order of the fields is also important as this fn stores and updates field's place as send
-func updateModuleFields(ctx context.Context, s store.Storer, m *types.Module, newFields types.ModuleFieldSet, hasRecords bool) (err error) {
+func updateModuleFields(ctx context.Context, s store.Storer, m *types.Module, newFields types.ModuleFieldSet) (err error) {
 	for _, f := range newFields {
 		// Set module ID to all new fields
 		if f.ModuleID == 0 {
@@ -530,20 +1024,15 @@ func updateModuleFields(ctx context.Context, s store.Storer, m *types.Module, ne
 	}
 
 	for idx, f := range newFields {
+		if f.DeletedAt != nil {
+			// removed above
+			continue
+		}
+
 		f.Place = idx
-		f.DeletedAt = nil
 
 		if e := m.Fields.FindByID(f.ID); e != nil {
 			f.CreatedAt = e.CreatedAt
-
-			// We do not have any other code in place that would handle changes of field name and kind, so we need
-			// to reset any changes made to the field.
-			// @todo remove when we are able to handle field rename & type change
-			if hasRecords {
-				f.Name = e.Name
-				f.Kind = e.Kind
-			}
-
 			f.UpdatedAt = now()
 
 			err = store.UpdateComposeModuleField(ctx, s, f)
@@ -578,6 +1067,11 @@ func updateModuleFields(ctx context.Context, s store.Storer, m *types.Module, ne
 		}
 	}
 
+	// keep only the fields that were not removed
+	m.Fields, _ = m.Fields.Filter(func(f *types.ModuleField) (bool, error) {
+		return f.DeletedAt == nil, nil
+	})
+
 	sort.Sort(m.Fields)
 
 	return nil
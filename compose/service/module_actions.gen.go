@@ -24,6 +24,10 @@ type (
 		changed   *types.Module
 		filter    *types.ModuleFilter
 		namespace *types.Namespace
+		field     *types.ModuleField
+		oldField  *types.ModuleField
+		failed    int
+		details   string
 	}
 
 	moduleAction struct {
@@ -95,6 +99,50 @@ func (p *moduleActionProps) setNamespace(namespace *types.Namespace) *moduleActi
 	return p
 }
 
+// setField updates moduleActionProps's field
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *moduleActionProps) setField(field *types.ModuleField) *moduleActionProps {
+	p.field = field
+	return p
+}
+
+// setOldField updates moduleActionProps's oldField
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *moduleActionProps) setOldField(oldField *types.ModuleField) *moduleActionProps {
+	p.oldField = oldField
+	return p
+}
+
+// setFailed updates moduleActionProps's failed
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *moduleActionProps) setFailed(failed int) *moduleActionProps {
+	p.failed = failed
+	return p
+}
+
+// setDetails updates moduleActionProps's details
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *moduleActionProps) setDetails(details string) *moduleActionProps {
+	p.details = details
+	return p
+}
+
 // Serialize converts moduleActionProps to actionlog.Meta
 //
 // This function is auto-generated.
@@ -109,6 +157,7 @@ func (p moduleActionProps) Serialize() actionlog.Meta {
 		m.Set("module.handle", p.module.Handle, true)
 		m.Set("module.ID", p.module.ID, true)
 		m.Set("module.namespaceID", p.module.NamespaceID, true)
+		m.Set("module.storage", p.module.Storage, true)
 	}
 	if p.changed != nil {
 		m.Set("changed.name", p.changed.Name, true)
@@ -132,6 +181,18 @@ func (p moduleActionProps) Serialize() actionlog.Meta {
 		m.Set("namespace.slug", p.namespace.Slug, true)
 		m.Set("namespace.ID", p.namespace.ID, true)
 	}
+	if p.field != nil {
+		m.Set("field.name", p.field.Name, true)
+		m.Set("field.kind", p.field.Kind, true)
+		m.Set("field.ID", p.field.ID, true)
+	}
+	if p.oldField != nil {
+		m.Set("oldField.name", p.oldField.Name, true)
+		m.Set("oldField.kind", p.oldField.Kind, true)
+		m.Set("oldField.ID", p.oldField.ID, true)
+	}
+	m.Set("failed", p.failed, true)
+	m.Set("details", p.details, true)
 
 	return m
 }
@@ -171,12 +232,14 @@ func (p moduleActionProps) Format(in string, err error) string {
 				p.module.Handle,
 				p.module.ID,
 				p.module.NamespaceID,
+				p.module.Storage,
 			),
 		)
 		pairs = append(pairs, "{module.name}", fns(p.module.Name))
 		pairs = append(pairs, "{module.handle}", fns(p.module.Handle))
 		pairs = append(pairs, "{module.ID}", fns(p.module.ID))
 		pairs = append(pairs, "{module.namespaceID}", fns(p.module.NamespaceID))
+		pairs = append(pairs, "{module.storage}", fns(p.module.Storage))
 	}
 
 	if p.changed != nil {
@@ -240,6 +303,40 @@ func (p moduleActionProps) Format(in string, err error) string {
 		pairs = append(pairs, "{namespace.slug}", fns(p.namespace.Slug))
 		pairs = append(pairs, "{namespace.ID}", fns(p.namespace.ID))
 	}
+
+	if p.field != nil {
+		// replacement for "{field}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{field}",
+			fns(
+				p.field.Name,
+				p.field.Kind,
+				p.field.ID,
+			),
+		)
+		pairs = append(pairs, "{field.name}", fns(p.field.Name))
+		pairs = append(pairs, "{field.kind}", fns(p.field.Kind))
+		pairs = append(pairs, "{field.ID}", fns(p.field.ID))
+	}
+
+	if p.oldField != nil {
+		// replacement for "{oldField}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{oldField}",
+			fns(
+				p.oldField.Name,
+				p.oldField.Kind,
+				p.oldField.ID,
+			),
+		)
+		pairs = append(pairs, "{oldField.name}", fns(p.oldField.Name))
+		pairs = append(pairs, "{oldField.kind}", fns(p.oldField.Kind))
+		pairs = append(pairs, "{oldField.ID}", fns(p.oldField.ID))
+	}
+	pairs = append(pairs, "{failed}", fns(p.failed))
+	pairs = append(pairs, "{details}", fns(p.details))
 	return strings.NewReplacer(pairs...).Replace(in)
 }
 
@@ -395,6 +492,126 @@ func ModuleActionUndelete(props ...*moduleActionProps) *moduleAction {
 	return a
 }
 
+// ModuleActionUpdateDryRun returns "compose:module.updateDryRun" action
+//
+// This function is auto-generated.
+//
+func ModuleActionUpdateDryRun(props ...*moduleActionProps) *moduleAction {
+	a := &moduleAction{
+		timestamp: time.Now(),
+		resource:  "compose:module",
+		action:    "updateDryRun",
+		log:       "checked field changes of {module}",
+		severity:  actionlog.Info,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// ModuleActionRenameField returns "compose:module.renameField" action
+//
+// This function is auto-generated.
+//
+func ModuleActionRenameField(props ...*moduleActionProps) *moduleAction {
+	a := &moduleAction{
+		timestamp: time.Now(),
+		resource:  "compose:module",
+		action:    "renameField",
+		log:       "renamed field {oldField.name} of {module} to {field.name}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// ModuleActionConvertField returns "compose:module.convertField" action
+//
+// This function is auto-generated.
+//
+func ModuleActionConvertField(props ...*moduleActionProps) *moduleAction {
+	a := &moduleAction{
+		timestamp: time.Now(),
+		resource:  "compose:module",
+		action:    "convertField",
+		log:       "converted values of field {field.name} of {module} from {oldField.kind} to {field.kind}, {failed} value(s) could not be converted",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// ModuleActionEncryptField returns "compose:module.encryptField" action
+//
+// This function is auto-generated.
+//
+func ModuleActionEncryptField(props ...*moduleActionProps) *moduleAction {
+	a := &moduleAction{
+		timestamp: time.Now(),
+		resource:  "compose:module",
+		action:    "encryptField",
+		log:       "encrypted values of field {field.name} of {module}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// ModuleActionDecryptField returns "compose:module.decryptField" action
+//
+// This function is auto-generated.
+//
+func ModuleActionDecryptField(props ...*moduleActionProps) *moduleAction {
+	a := &moduleAction{
+		timestamp: time.Now(),
+		resource:  "compose:module",
+		action:    "decryptField",
+		log:       "decrypted values of field {field.name} of {module}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// ModuleActionMigrateStorage returns "compose:module.migrateStorage" action
+//
+// This function is auto-generated.
+//
+func ModuleActionMigrateStorage(props ...*moduleActionProps) *moduleAction {
+	a := &moduleAction{
+		timestamp: time.Now(),
+		resource:  "compose:module",
+		action:    "migrateStorage",
+		log:       "migrated records of {module} to {module.storage} storage",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
 // *********************************************************************************************************************
 // *********************************************************************************************************************
 // Error constructors
@@ -615,6 +832,156 @@ func ModuleErrNameNotUnique(mm ...*moduleActionProps) *errors.Error {
 	return e
 }
 
+// ModuleErrInvalidStorage returns "compose:module.invalidStorage" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func ModuleErrInvalidStorage(mm ...*moduleActionProps) *errors.Error {
+	var p = &moduleActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid storage", nil),
+
+		errors.Meta("type", "invalidStorage"),
+		errors.Meta("resource", "compose:module"),
+
+		errors.Meta(modulePropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// ModuleErrInvalidWorkflow returns "compose:module.invalidWorkflow" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func ModuleErrInvalidWorkflow(mm ...*moduleActionProps) *errors.Error {
+	var p = &moduleActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid workflow: {details}", nil),
+
+		errors.Meta("type", "invalidWorkflow"),
+		errors.Meta("resource", "compose:module"),
+
+		errors.Meta(modulePropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// ModuleErrInvalidApproval returns "compose:module.invalidApproval" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func ModuleErrInvalidApproval(mm ...*moduleActionProps) *errors.Error {
+	var p = &moduleActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid approval flow: {details}", nil),
+
+		errors.Meta("type", "invalidApproval"),
+		errors.Meta("resource", "compose:module"),
+
+		errors.Meta(modulePropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// ModuleErrInvalidDynamicRole returns "compose:module.invalidDynamicRole" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func ModuleErrInvalidDynamicRole(mm ...*moduleActionProps) *errors.Error {
+	var p = &moduleActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid dynamic role: {details}", nil),
+
+		errors.Meta("type", "invalidDynamicRole"),
+		errors.Meta("resource", "compose:module"),
+
+		errors.Meta(modulePropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// ModuleErrEncryptionKeyMissing returns "compose:module.encryptionKeyMissing" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func ModuleErrEncryptionKeyMissing(mm ...*moduleActionProps) *errors.Error {
+	var p = &moduleActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("can not encrypt field values; encryption key is not configured", nil),
+
+		errors.Meta("type", "encryptionKeyMissing"),
+		errors.Meta("resource", "compose:module"),
+
+		errors.Meta(modulePropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
 // ModuleErrStaleData returns "compose:module.staleData" as *errors.Error
 //
 //
@@ -645,6 +1012,36 @@ func ModuleErrStaleData(mm ...*moduleActionProps) *errors.Error {
 	return e
 }
 
+// ModuleErrFieldConversionFailed returns "compose:module.fieldConversionFailed" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func ModuleErrFieldConversionFailed(mm ...*moduleActionProps) *errors.Error {
+	var p = &moduleActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("{failed} record value(s) can not be converted to the new field kind", nil),
+
+		errors.Meta("type", "fieldConversionFailed"),
+		errors.Meta("resource", "compose:module"),
+
+		errors.Meta(modulePropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
 // ModuleErrInvalidNamespaceID returns "compose:module.invalidNamespaceID" as *errors.Error
 //
 //
@@ -899,6 +1296,38 @@ func ModuleErrNotAllowedToUndelete(mm ...*moduleActionProps) *errors.Error {
 	return e
 }
 
+// ModuleErrNotAllowedToSetDynamicRole returns "compose:module.notAllowedToSetDynamicRole" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func ModuleErrNotAllowedToSetDynamicRole(mm ...*moduleActionProps) *errors.Error {
+	var p = &moduleActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("not allowed to set dynamic roles of the fields", nil),
+
+		errors.Meta("type", "notAllowedToSetDynamicRole"),
+		errors.Meta("resource", "compose:module"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(moduleLogMetaKey{}, "could not set dynamic roles of {module} fields; insufficient permissions"),
+		errors.Meta(modulePropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
 // *********************************************************************************************************************
 // *********************************************************************************************************************
 
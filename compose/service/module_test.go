@@ -1,10 +1,12 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/pkg/rbac"
+	"github.com/cortezaproject/corteza-server/pkg/secrets"
 	"github.com/cortezaproject/corteza-server/store"
 	"github.com/cortezaproject/corteza-server/store/sqlite3"
 	"github.com/stretchr/testify/require"
@@ -210,4 +212,61 @@ func TestModules(t *testing.T) {
 			req.Empty(findAndReturnLabel(res.ID))
 		})
 	})
+
+	t.Run("encrypted fields", func(t *testing.T) {
+		req := require.New(t)
+		svc := module{
+			store:    s,
+			ctx:      ctx,
+			ac:       AccessControl(&rbac.ServiceAllowAll{}),
+			eventbus: eventbus.New(),
+		}
+
+		encrypted := types.ModuleFieldOptions{}
+		encrypted.SetIsEncrypted(true)
+
+		// encrypted fields can not be used without the key
+		_, err := svc.Create(&types.Module{Name: "encrypted", NamespaceID: namespaceID, Fields: types.ModuleFieldSet{
+			{Name: "num", Kind: "Number", Options: encrypted},
+		}})
+		req.Error(err)
+
+		svc.keyring, err = secrets.NewKeyring(bytes.Repeat([]byte{1}, secrets.KeySize))
+		req.NoError(err)
+
+		m, err := svc.Create(&types.Module{Name: "encrypted", NamespaceID: namespaceID, Storage: types.ModuleStorageDedicated, Fields: types.ModuleFieldSet{
+			{Name: "num", Kind: "Number"},
+		}})
+		req.NoError(err)
+
+		rec := &types.Record{ID: nextID(), ModuleID: m.ID, NamespaceID: namespaceID, Values: types.RecordValueSet{{Name: "num", Value: "42"}}}
+		req.NoError(store.CreateComposeRecord(ctx, s, m, rec))
+
+		storedNum := func() string {
+			m, err = svc.FindByID(namespaceID, m.ID)
+			req.NoError(err)
+
+			r, err := store.LookupComposeRecordByID(ctx, s, m, rec.ID)
+			req.NoError(err)
+			return r.Values.Get("num", 0).Value
+		}
+
+		// values are encrypted when field becomes encrypted
+		m.Fields[0].Options = encrypted
+		_, err = svc.Update(m)
+		req.NoError(err)
+
+		num := storedNum()
+		req.True(secrets.IsEncrypted(num))
+
+		plain, err := svc.keyring.Decrypt(num)
+		req.NoError(err)
+		req.Equal("42", string(plain))
+
+		// and decrypted (and moved back to the dedicated table) when it is not encrypted any more
+		m.Fields[0].Options = types.ModuleFieldOptions{}
+		_, err = svc.Update(m)
+		req.NoError(err)
+		req.Equal("42", storedNum())
+	})
 }
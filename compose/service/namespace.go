@@ -9,6 +9,7 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/pkg/handle"
 	"github.com/cortezaproject/corteza-server/pkg/label"
+	"github.com/cortezaproject/corteza-server/pkg/objstore"
 	"github.com/cortezaproject/corteza-server/pkg/rbac"
 	"github.com/cortezaproject/corteza-server/store"
 	"reflect"
@@ -22,6 +23,7 @@ type (
 		ac        namespaceAccessController
 		eventbus  eventDispatcher
 		store     store.Storer
+		objects   objstore.Store
 	}
 
 	namespaceAccessController interface {
@@ -29,7 +31,12 @@ type (
 		CanReadNamespace(context.Context, *types.Namespace) bool
 		CanUpdateNamespace(context.Context, *types.Namespace) bool
 		CanDeleteNamespace(context.Context, *types.Namespace) bool
+		CanReadModule(context.Context, *types.Module) bool
+		CanReadModuleRecord(context.Context, *types.Module, *types.Record) bool
+		recordValueAccessController
 
+		CanGrant(context.Context) bool
+		FindRules(context.Context) (rbac.RuleSet, error)
 		Grant(ctx context.Context, rr ...*rbac.Rule) error
 	}
 
@@ -44,6 +51,8 @@ type (
 		Create(namespace *types.Namespace) (*types.Namespace, error)
 		Update(namespace *types.Namespace) (*types.Namespace, error)
 		DeleteByID(namespaceID uint64) error
+
+		Clone(namespaceID uint64, dup *types.Namespace, withRecords bool) (*types.Namespace, error)
 	}
 
 	namespaceUpdateHandler func(ctx context.Context, ns *types.Namespace) (namespaceChanges, error)
@@ -60,6 +69,7 @@ func Namespace() NamespaceService {
 	return (&namespace{
 		ac:       DefaultAccessControl,
 		eventbus: eventbus.Service(),
+		objects:  DefaultObjectStore,
 	}).With(context.Background())
 }
 
@@ -70,6 +80,7 @@ func (svc namespace) With(ctx context.Context) NamespaceService {
 		ac:        svc.ac,
 		eventbus:  svc.eventbus,
 		store:     DefaultStore,
+		objects:   svc.objects,
 	}
 }
 
@@ -175,7 +186,8 @@ func (svc namespace) FindByAny(identifier interface{}) (r *types.Namespace, err
 // Create adds namespace and presets access rules for role everyone
 func (svc namespace) Create(new *types.Namespace) (*types.Namespace, error) {
 	var (
-		aProps = &namespaceActionProps{changed: new}
+		aProps   = &namespaceActionProps{changed: new}
+		deferred eventbus.Deferred
 	)
 
 	err := store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
@@ -208,13 +220,129 @@ func (svc namespace) Create(new *types.Namespace) (*types.Namespace, error) {
 			return
 		}
 
-		_ = svc.eventbus.WaitFor(svc.ctx, event.NamespaceAfterCreate(new, nil))
-		return nil
+		return deferred.Enqueue(ctx, s, event.NamespaceAfterCreate(new, nil))
 	})
 
+	if err == nil {
+		_ = deferred.WaitFor(svc.ctx, svc.eventbus)
+	}
+
 	return new, svc.recordAction(svc.ctx, aProps, NamespaceActionCreate, err)
 }
 
+// Clone duplicates namespace together with its modules, charts and pages
+//
+// All references between cloned resources (page & chart modules, record field
+// options, page block options) are remapped to the new IDs. Records are copied
+// when withRecords is set. Permission rules set on the cloned resources are
+// copied only when current user is allowed to grant permissions.
+//
+// Cloning is refused when user can not read all modules of the namespace.
+// Only records (and values) that user can read are copied; records can be
+// copied only when rules can be copied too, otherwise records would end up
+// in a namespace without any of the rules that restrict access to them.
+func (svc namespace) Clone(namespaceID uint64, dup *types.Namespace, withRecords bool) (*types.Namespace, error) {
+	var (
+		ns       *types.Namespace
+		rr       []*rbac.Rule
+		aProps   = &namespaceActionProps{namespace: &types.Namespace{ID: namespaceID}, changed: dup}
+		deferred eventbus.Deferred
+	)
+
+	err := store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+		var (
+			src *types.Namespace
+			c   *namespaceCloner
+		)
+
+		if src, err = loadNamespace(ctx, s, namespaceID); err != nil {
+			return
+		}
+
+		aProps.setNamespace(src)
+
+		if !svc.ac.CanReadNamespace(ctx, src) {
+			return NamespaceErrNotAllowedToRead()
+		}
+
+		if !svc.ac.CanCreateNamespace(ctx) {
+			return NamespaceErrNotAllowedToCreate()
+		}
+
+		if withRecords && !svc.ac.CanGrant(ctx) {
+			return NamespaceErrNotAllowedToCloneRecords()
+		}
+
+		if !handle.IsValid(dup.Slug) {
+			return NamespaceErrInvalidHandle()
+		}
+
+		if err = svc.uniqueCheck(dup); err != nil {
+			return
+		}
+
+		if err = label.Load(ctx, s, src); err != nil {
+			return
+		}
+
+		ns = src.Clone()
+		ns.ID = nextID()
+		ns.Slug = dup.Slug
+		ns.CreatedAt = *now()
+		ns.UpdatedAt = nil
+		ns.DeletedAt = nil
+
+		if dup.Name != "" {
+			ns.Name = dup.Name
+		}
+
+		aProps.setChanged(ns)
+		c = newNamespaceCloner(src, ns, svc.objects)
+
+		if err = svc.eventbus.WaitFor(ctx, event.NamespaceBeforeCreate(ns, nil)); err != nil {
+			return err
+		}
+
+		if err = store.CreateComposeNamespace(ctx, s, ns); err != nil {
+			return
+		}
+
+		if err = label.Create(ctx, s, ns); err != nil {
+			return
+		}
+
+		if err = c.load(ctx, s, svc.ac, src.ID, withRecords); err != nil {
+			return
+		}
+
+		if err = c.create(ctx, s, ns.ID); err != nil {
+			return
+		}
+
+		if svc.ac.CanGrant(ctx) {
+			if rr, err = svc.ac.FindRules(ctx); err != nil {
+				return
+			}
+
+			rr = c.rules(rr)
+		}
+
+		return deferred.Enqueue(ctx, s, event.NamespaceAfterCreate(ns, nil))
+	})
+
+	if err == nil && len(rr) > 0 {
+		// rules are stored by RBAC service outside of the
+		// cloning transaction
+		err = svc.ac.Grant(svc.ctx, rr...)
+	}
+
+	if err == nil {
+		_ = deferred.WaitFor(svc.ctx, svc.eventbus)
+	}
+
+	return ns, svc.recordAction(svc.ctx, aProps, NamespaceActionClone, err)
+}
+
 func (svc namespace) Update(upd *types.Namespace) (c *types.Namespace, err error) {
 	return svc.updater(upd.ID, NamespaceActionUpdate, svc.handleUpdate(upd))
 }
@@ -229,10 +357,11 @@ func (svc namespace) UndeleteByID(namespaceID uint64) error {
 
 func (svc namespace) updater(namespaceID uint64, action func(...*namespaceActionProps) *namespaceAction, fn namespaceUpdateHandler) (*types.Namespace, error) {
 	var (
-		changes namespaceChanges
-		ns, old *types.Namespace
-		aProps  = &namespaceActionProps{namespace: &types.Namespace{ID: namespaceID}}
-		err     error
+		changes  namespaceChanges
+		ns, old  *types.Namespace
+		aProps   = &namespaceActionProps{namespace: &types.Namespace{ID: namespaceID}}
+		err      error
+		deferred eventbus.Deferred
 	)
 
 	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
@@ -277,14 +406,18 @@ func (svc namespace) updater(namespaceID uint64, action func(...*namespaceAction
 		}
 
 		if ns.DeletedAt == nil {
-			err = svc.eventbus.WaitFor(svc.ctx, event.NamespaceAfterUpdate(ns, old))
+			err = deferred.Enqueue(ctx, s, event.NamespaceAfterUpdate(ns, old))
 		} else {
-			err = svc.eventbus.WaitFor(svc.ctx, event.NamespaceAfterDelete(nil, old))
+			err = deferred.Enqueue(ctx, s, event.NamespaceAfterDelete(nil, old))
 		}
 
 		return err
 	})
 
+	if err == nil {
+		err = deferred.WaitFor(svc.ctx, svc.eventbus)
+	}
+
 	return ns, svc.recordAction(svc.ctx, aProps, action, err)
 }
 
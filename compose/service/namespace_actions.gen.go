@@ -370,6 +370,26 @@ func NamespaceActionReorder(props ...*namespaceActionProps) *namespaceAction {
 	return a
 }
 
+// NamespaceActionClone returns "compose:namespace.clone" action
+//
+// This function is auto-generated.
+//
+func NamespaceActionClone(props ...*namespaceActionProps) *namespaceAction {
+	a := &namespaceAction{
+		timestamp: time.Now(),
+		resource:  "compose:namespace",
+		action:    "clone",
+		log:       "cloned {namespace} to {changed}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
 // *********************************************************************************************************************
 // *********************************************************************************************************************
 // Error constructors
@@ -750,6 +770,70 @@ func NamespaceErrNotAllowedToUndelete(mm ...*namespaceActionProps) *errors.Error
 	return e
 }
 
+// NamespaceErrNotAllowedToCloneModule returns "compose:namespace.notAllowedToCloneModule" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func NamespaceErrNotAllowedToCloneModule(mm ...*namespaceActionProps) *errors.Error {
+	var p = &namespaceActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("not allowed to read all modules of this namespace", nil),
+
+		errors.Meta("type", "notAllowedToCloneModule"),
+		errors.Meta("resource", "compose:namespace"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(namespaceLogMetaKey{}, "could not clone {namespace}; insufficient permissions to read all modules"),
+		errors.Meta(namespacePropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// NamespaceErrNotAllowedToCloneRecords returns "compose:namespace.notAllowedToCloneRecords" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func NamespaceErrNotAllowedToCloneRecords(mm ...*namespaceActionProps) *errors.Error {
+	var p = &namespaceActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("not allowed to clone records without permission to grant", nil),
+
+		errors.Meta("type", "notAllowedToCloneRecords"),
+		errors.Meta("resource", "compose:namespace"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(namespaceLogMetaKey{}, "could not clone records of {namespace}; insufficient permissions to copy access rules"),
+		errors.Meta(namespacePropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
 // *********************************************************************************************************************
 // *********************************************************************************************************************
 
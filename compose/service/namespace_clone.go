@@ -0,0 +1,488 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/label"
+	"github.com/cortezaproject/corteza-server/pkg/objstore"
+	"github.com/cortezaproject/corteza-server/pkg/rbac"
+	"github.com/cortezaproject/corteza-server/store"
+	sqlxTypes "github.com/jmoiron/sqlx/types"
+)
+
+type (
+	// namespaceCloner copies namespace resources and remaps
+	// references between them
+	namespaceCloner struct {
+		// old => new ID of all cloned resources
+		ids map[uint64]uint64
+
+		// old => new RBAC resource of all cloned resources
+		resources map[rbac.Resource]rbac.Resource
+
+		modules types.ModuleSet
+		charts  types.ChartSet
+		pages   types.PageSet
+
+		// records of each module, indexed by (old) module ID
+		records map[uint64]types.RecordSet
+
+		// files of the attachments, referenced by the cloned records, are copied here
+		objects objstore.Store
+	}
+)
+
+func newNamespaceCloner(src, dst *types.Namespace, objects objstore.Store) *namespaceCloner {
+	return &namespaceCloner{
+		ids:       map[uint64]uint64{src.ID: dst.ID},
+		resources: map[rbac.Resource]rbac.Resource{src.RBACResource(): dst.RBACResource()},
+		records:   make(map[uint64]types.RecordSet),
+		objects:   objects,
+	}
+}
+
+// load fetches all resources from the source namespace and assigns new IDs
+//
+// Only records and record values that are readable by the current user are loaded
+func (c *namespaceCloner) load(ctx context.Context, s store.Storer, ac namespaceAccessController, namespaceID uint64, withRecords bool) (err error) {
+	if c.modules, _, err = store.SearchComposeModules(ctx, s, types.ModuleFilter{NamespaceID: namespaceID}); err != nil {
+		return
+	}
+
+	if err = loadModuleFields(ctx, s, c.modules...); err != nil {
+		return
+	}
+
+	if err = loadModuleLabels(ctx, s, c.modules...); err != nil {
+		return
+	}
+
+	if !ac.CanGrant(ctx) {
+		// users referenced by the fields get permissions of the dynamic role,
+		// these are cloned only by users that can grant permissions
+		for _, m := range c.modules {
+			for _, f := range m.Fields {
+				f.Options.RemoveDynamicRole()
+			}
+		}
+	}
+
+	if c.charts, _, err = store.SearchComposeCharts(ctx, s, types.ChartFilter{NamespaceID: namespaceID}); err != nil {
+		return
+	}
+
+	if err = label.Load(ctx, s, toLabeledCharts(c.charts)...); err != nil {
+		return
+	}
+
+	if c.pages, _, err = store.SearchComposePages(ctx, s, types.PageFilter{NamespaceID: namespaceID}); err != nil {
+		return
+	}
+
+	if err = label.Load(ctx, s, toLabeledPages(c.pages)...); err != nil {
+		return
+	}
+
+	for _, m := range c.modules {
+		if !ac.CanReadModule(ctx, m) {
+			return NamespaceErrNotAllowedToCloneModule()
+		}
+
+		c.ids[m.ID] = nextID()
+		for _, f := range m.Fields {
+			c.ids[f.ID] = nextID()
+		}
+
+		if !withRecords {
+			continue
+		}
+
+		rr, _, err := store.SearchComposeRecords(ctx, s, m, types.RecordFilter{ModuleID: m.ID, NamespaceID: namespaceID})
+		if err != nil {
+			return err
+		}
+
+		rr, _ = rr.Filter(func(r *types.Record) (bool, error) {
+			return ac.CanReadModuleRecord(ctx, m, r), nil
+		})
+
+		trimUnreadableRecordFields(ctx, ac, m, rr...)
+
+		if err = label.Load(ctx, s, toLabeledRecords(rr)...); err != nil {
+			return err
+		}
+
+		for _, r := range rr {
+			c.ids[r.ID] = nextID()
+		}
+
+		c.records[m.ID] = rr
+	}
+
+	for _, ch := range c.charts {
+		c.ids[ch.ID] = nextID()
+	}
+
+	for _, p := range c.pages {
+		c.ids[p.ID] = nextID()
+	}
+
+	return nil
+}
+
+// create stores copies of all loaded resources under the new namespace
+func (c *namespaceCloner) create(ctx context.Context, s store.Storer, namespaceID uint64) (err error) {
+	var (
+		mm = make(map[uint64]*types.Module, len(c.modules))
+	)
+
+	for _, m := range c.modules {
+		dm := m.Clone()
+		dm.ID = c.id(m.ID)
+		dm.NamespaceID = namespaceID
+		dm.Meta = c.remapJSON(m.Meta)
+		dm.CreatedAt = *now()
+		dm.UpdatedAt = nil
+		dm.DeletedAt = nil
+
+		c.resources[m.RBACResource()] = dm.RBACResource()
+
+		for i, f := range dm.Fields {
+			c.resources[m.Fields[i].RBACResource()] = types.ModuleFieldRBACResource.AppendID(c.id(f.ID))
+
+			f.ID = c.id(f.ID)
+			f.ModuleID = dm.ID
+			f.Options = c.remapMap(f.Options)
+			f.CreatedAt = *now()
+			f.UpdatedAt = nil
+			f.DeletedAt = nil
+
+			if f.Kind == "Record" {
+				f.DefaultValue = c.remapValues(f.DefaultValue)
+			}
+		}
+
+		if err = store.CreateComposeModule(ctx, s, dm); err != nil {
+			return
+		}
+
+		if err = store.CreateComposeModuleField(ctx, s, dm.Fields...); err != nil {
+			return
+		}
+
+		if err = store.UpgradeComposeRecordStorage(ctx, s, nil, dm); err != nil {
+			return
+		}
+
+		if err = label.Create(ctx, s, dm); err != nil {
+			return
+		}
+
+		for _, f := range dm.Fields {
+			if err = label.Create(ctx, s, f); err != nil {
+				return
+			}
+		}
+
+		mm[m.ID] = dm
+	}
+
+	for _, ch := range c.charts {
+		dc := *ch
+		dc.ID = c.id(ch.ID)
+		dc.NamespaceID = namespaceID
+		dc.CreatedAt = *now()
+		dc.UpdatedAt = nil
+		dc.DeletedAt = nil
+
+		dc.Config.Reports = make([]*types.ChartConfigReport, len(ch.Config.Reports))
+		for i, r := range ch.Config.Reports {
+			dr := *r
+			dr.ModuleID = c.id(r.ModuleID)
+			dr.Metrics = c.remapMaps(r.Metrics)
+			dr.Dimensions = c.remapMaps(r.Dimensions)
+			dc.Config.Reports[i] = &dr
+		}
+
+		c.resources[ch.RBACResource()] = dc.RBACResource()
+
+		if err = store.CreateComposeChart(ctx, s, &dc); err != nil {
+			return
+		}
+
+		if err = label.Create(ctx, s, &dc); err != nil {
+			return
+		}
+	}
+
+	for _, p := range c.pages {
+		dp := p.Clone()
+		dp.ID = c.id(p.ID)
+		dp.SelfID = c.id(p.SelfID)
+		dp.ModuleID = c.id(p.ModuleID)
+		dp.NamespaceID = namespaceID
+		dp.Children = nil
+		dp.CreatedAt = *now()
+		dp.UpdatedAt = nil
+		dp.DeletedAt = nil
+
+		dp.Blocks = make(types.PageBlocks, len(p.Blocks))
+		for i, b := range p.Blocks {
+			b.Options = c.remapMap(b.Options)
+			dp.Blocks[i] = b
+		}
+
+		c.resources[p.RBACResource()] = dp.RBACResource()
+
+		if err = store.CreateComposePage(ctx, s, dp); err != nil {
+			return
+		}
+
+		if err = label.Create(ctx, s, dp); err != nil {
+			return
+		}
+	}
+
+	for _, m := range c.modules {
+		var (
+			dm = mm[m.ID]
+			rr = c.records[m.ID]
+			dd = make(types.RecordSet, len(rr))
+		)
+
+		if len(rr) == 0 {
+			continue
+		}
+
+		for i, r := range rr {
+			dd[i] = r.Clone()
+			dd[i].ID = c.id(r.ID)
+			dd[i].ModuleID = dm.ID
+			dd[i].NamespaceID = namespaceID
+
+			vv := make(types.RecordValueSet, 0, len(dd[i].Values))
+			for _, v := range dd[i].Values {
+				v.RecordID = dd[i].ID
+				f := m.Fields.FindByName(v.Name)
+
+				if f != nil && f.Kind == "Record" {
+					c.remapValue(v)
+				}
+
+				if f != nil && f.Kind == "File" {
+					var attachmentID uint64
+					if attachmentID, err = c.cloneAttachment(ctx, s, namespaceID, v.Ref); err != nil {
+						return
+					}
+
+					if attachmentID == 0 {
+						// attachment is gone; do not keep
+						// a reference to the source namespace
+						continue
+					}
+
+					v.Value, v.Ref = strconv.FormatUint(attachmentID, 10), attachmentID
+				}
+
+				vv = append(vv, v)
+			}
+
+			dd[i].Values = vv
+		}
+
+		if err = store.CreateComposeRecord(ctx, s, dm, dd...); err != nil {
+			return
+		}
+
+		for _, r := range dd {
+			if err = label.Create(ctx, s, r); err != nil {
+				return
+			}
+		}
+	}
+
+	return nil
+}
+
+// cloneAttachment copies record attachment (with its files) to the new namespace
+//
+// Returns ID of the copy or 0 when the source attachment does not exist
+func (c *namespaceCloner) cloneAttachment(ctx context.Context, s store.Storer, namespaceID, attachmentID uint64) (uint64, error) {
+	if n, ok := c.ids[attachmentID]; ok {
+		return n, nil
+	}
+
+	att, err := store.LookupComposeAttachmentByID(ctx, s, attachmentID)
+	if errors.Is(err, store.ErrNotFound) || (err == nil && att.DeletedAt != nil) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	if c.objects == nil {
+		return 0, fmt.Errorf("can not clone attachment: store handler not set")
+	}
+
+	dup := *att
+	dup.ID = nextID()
+	dup.NamespaceID = namespaceID
+	dup.CreatedAt = *now()
+	dup.UpdatedAt = nil
+
+	if dup.Url, err = c.copyFile(att.Url, c.objects.Original(dup.ID, att.Meta.Original.Extension)); err != nil {
+		return 0, err
+	}
+
+	if att.PreviewUrl != "" && att.Meta.Preview != nil {
+		if dup.PreviewUrl, err = c.copyFile(att.PreviewUrl, c.objects.Preview(dup.ID, att.Meta.Preview.Extension)); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = store.CreateComposeAttachment(ctx, s, &dup); err != nil {
+		return 0, err
+	}
+
+	c.ids[att.ID] = dup.ID
+	return dup.ID, nil
+}
+
+func (c *namespaceCloner) copyFile(src, dst string) (string, error) {
+	fh, err := c.objects.Open(src)
+	if err != nil {
+		return "", fmt.Errorf("can not open attachment file %s: %w", src, err)
+	}
+
+	if err = c.objects.Save(dst, fh); err != nil {
+		return "", fmt.Errorf("can not store attachment file %s: %w", dst, err)
+	}
+
+	return dst, nil
+}
+
+// rules returns copies of permission rules set on cloned resources
+func (c *namespaceCloner) rules(rr rbac.RuleSet) rbac.RuleSet {
+	out := make(rbac.RuleSet, 0)
+	for _, r := range rr {
+		if res, ok := c.resources[r.Resource]; ok {
+			out = append(out, &rbac.Rule{
+				RoleID:     r.RoleID,
+				Resource:   res,
+				Operation:  r.Operation,
+				Access:     r.Access,
+				Conditions: r.Conditions,
+			})
+		}
+	}
+
+	return out
+}
+
+// id returns new ID of the cloned resource
+//
+// IDs of resources that were not cloned are returned as they are
+func (c *namespaceCloner) id(ID uint64) uint64 {
+	if n, ok := c.ids[ID]; ok {
+		return n
+	}
+
+	return ID
+}
+
+func (c *namespaceCloner) remapValues(vv types.RecordValueSet) types.RecordValueSet {
+	vv = vv.Clone()
+	for _, v := range vv {
+		c.remapValue(v)
+	}
+
+	return vv
+}
+
+func (c *namespaceCloner) remapValue(v *types.RecordValue) {
+	if ID, err := strconv.ParseUint(v.Value, 10, 64); err == nil {
+		v.Value = strconv.FormatUint(c.id(ID), 10)
+	}
+
+	v.Ref = c.id(v.Ref)
+}
+
+func (c *namespaceCloner) remapMaps(mm []map[string]interface{}) []map[string]interface{} {
+	if mm == nil {
+		return nil
+	}
+
+	out := make([]map[string]interface{}, len(mm))
+	for i := range mm {
+		out[i] = c.remapMap(mm[i])
+	}
+
+	return out
+}
+
+func (c *namespaceCloner) remapMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+
+	return c.remap("", m).(map[string]interface{})
+}
+
+func (c *namespaceCloner) remapJSON(j sqlxTypes.JSONText) sqlxTypes.JSONText {
+	var aux interface{}
+	if len(j) == 0 || json.Unmarshal(j, &aux) != nil {
+		return j
+	}
+
+	if out, err := json.Marshal(c.remap("", aux)); err == nil {
+		return out
+	}
+
+	return j
+}
+
+// remap walks through (unmarshalled JSON) options and returns
+// copy with IDs replaced
+//
+// Only values under keys that end with "ID" or "IDs"
+// (moduleID, chartID, pageID, ...) are considered
+func (c *namespaceCloner) remap(key string, v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k := range v {
+			out[k] = c.remap(k, v[k])
+		}
+
+		return out
+
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i := range v {
+			out[i] = c.remap(key, v[i])
+		}
+
+		return out
+
+	case string:
+		if !strings.HasSuffix(key, "ID") && !strings.HasSuffix(key, "IDs") {
+			return v
+		}
+
+		if ID, err := strconv.ParseUint(v, 10, 64); err == nil {
+			return strconv.FormatUint(c.id(ID), 10)
+		}
+
+	case uint64:
+		if strings.HasSuffix(key, "ID") {
+			return c.id(v)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,783 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/pkg/handle"
+	"github.com/cortezaproject/corteza-server/pkg/label"
+	"github.com/cortezaproject/corteza-server/store"
+)
+
+type (
+	namespaceVersion struct {
+		ctx       context.Context
+		actionlog actionlog.Recorder
+		ac        namespaceVersionAccessController
+		eventbus  eventDispatcher
+		store     store.Storer
+	}
+
+	namespaceVersionAccessController interface {
+		CanManageNamespace(context.Context, *types.Namespace) bool
+	}
+
+	// NamespaceVersionService handles drafts & published versions of namespace configuration
+	//
+	// Changes to modules, pages and charts are staged in a draft,
+	// compared against the live configuration and published in one transaction.
+	NamespaceVersionService interface {
+		With(ctx context.Context) NamespaceVersionService
+
+		Find(filter types.NamespaceVersionFilter) (types.NamespaceVersionSet, types.NamespaceVersionFilter, error)
+		FindByID(namespaceID, versionID uint64) (*types.NamespaceVersion, error)
+		Draft(namespaceID uint64) (*types.NamespaceVersion, error)
+
+		CreateDraft(namespaceID uint64) (*types.NamespaceVersion, error)
+		UpdateDraft(namespaceID uint64, upd *types.NamespaceVersion) (*types.NamespaceVersion, error)
+		DiscardDraft(namespaceID uint64) error
+		Diff(namespaceID, versionID uint64) (types.NamespaceConfigDiff, error)
+
+		Publish(namespaceID uint64) (*types.NamespaceVersion, error)
+		Rollback(namespaceID, versionID uint64) (*types.NamespaceVersion, error)
+	}
+)
+
+func NamespaceVersion() NamespaceVersionService {
+	return (&namespaceVersion{
+		ac:       DefaultAccessControl,
+		eventbus: eventbus.Service(),
+	}).With(context.Background())
+}
+
+func (svc namespaceVersion) With(ctx context.Context) NamespaceVersionService {
+	return &namespaceVersion{
+		ctx:       ctx,
+		actionlog: DefaultActionlog,
+		ac:        svc.ac,
+		eventbus:  svc.eventbus,
+		store:     DefaultStore,
+	}
+}
+
+// Find returns versions of the namespace, latest first
+func (svc namespaceVersion) Find(f types.NamespaceVersionFilter) (set types.NamespaceVersionSet, _ types.NamespaceVersionFilter, err error) {
+	var (
+		aProps = &namespaceVersionActionProps{}
+	)
+
+	err = func() error {
+		if _, err = svc.loadNamespace(svc.ctx, svc.store, aProps, f.NamespaceID); err != nil {
+			return err
+		}
+
+		if len(f.Sort) == 0 {
+			f.Sort = filter.SortExprSet{&filter.SortExpr{Column: "id", Descending: true}}
+		}
+
+		set, f, err = store.SearchComposeNamespaceVersions(svc.ctx, svc.store, f)
+		return err
+	}()
+
+	return set, f, svc.recordAction(svc.ctx, aProps, NamespaceVersionActionSearch, err)
+}
+
+func (svc namespaceVersion) FindByID(namespaceID, versionID uint64) (v *types.NamespaceVersion, err error) {
+	var (
+		aProps = &namespaceVersionActionProps{version: &types.NamespaceVersion{ID: versionID, NamespaceID: namespaceID}}
+	)
+
+	err = func() error {
+		if _, err = svc.loadNamespace(svc.ctx, svc.store, aProps, namespaceID); err != nil {
+			return err
+		}
+
+		v, err = loadNamespaceVersion(svc.ctx, svc.store, namespaceID, versionID)
+		return err
+	}()
+
+	return v, svc.recordAction(svc.ctx, aProps, NamespaceVersionActionLookup, err)
+}
+
+// Draft returns current draft of the namespace
+func (svc namespaceVersion) Draft(namespaceID uint64) (v *types.NamespaceVersion, err error) {
+	var (
+		aProps = &namespaceVersionActionProps{}
+	)
+
+	err = func() error {
+		if _, err = svc.loadNamespace(svc.ctx, svc.store, aProps, namespaceID); err != nil {
+			return err
+		}
+
+		if v, err = loadNamespaceDraft(svc.ctx, svc.store, namespaceID); err != nil {
+			return err
+		}
+
+		if v == nil {
+			return NamespaceVersionErrDraftNotFound()
+		}
+
+		return nil
+	}()
+
+	return v, svc.recordAction(svc.ctx, aProps, NamespaceVersionActionLookup, err)
+}
+
+// CreateDraft creates draft with a copy of the live namespace configuration
+//
+// When the first draft is created, the live configuration is stored in the
+// history as well, so it can be restored with a rollback.
+func (svc namespaceVersion) CreateDraft(namespaceID uint64) (v *types.NamespaceVersion, err error) {
+	var (
+		aProps = &namespaceVersionActionProps{}
+	)
+
+	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+		if _, err = svc.loadNamespace(ctx, s, aProps, namespaceID); err != nil {
+			return
+		}
+
+		if v, err = loadNamespaceDraft(ctx, s, namespaceID); err != nil {
+			return
+		} else if v != nil {
+			return NamespaceVersionErrDraftExists()
+		}
+
+		if err = storeInitialNamespaceVersion(ctx, s, namespaceID); err != nil {
+			return
+		}
+
+		v = &types.NamespaceVersion{
+			ID:          nextID(),
+			NamespaceID: namespaceID,
+			Status:      types.NamespaceVersionDraft,
+			CreatedAt:   *now(),
+			CreatedBy:   auth.GetIdentityFromContext(ctx).Identity(),
+		}
+
+		if v.Config, err = loadNamespaceConfig(ctx, s, namespaceID, filter.StateExcluded); err != nil {
+			return
+		}
+
+		if v.Base, err = namespaceConfigFingerprint(ctx, s, namespaceID); err != nil {
+			return
+		}
+
+		aProps.setVersion(v)
+		return store.CreateComposeNamespaceVersion(ctx, s, v)
+	})
+
+	return v, svc.recordAction(svc.ctx, aProps, NamespaceVersionActionCreateDraft, err)
+}
+
+// UpdateDraft replaces configuration of the draft
+//
+// New resources (modules, fields, pages and charts without an ID) get their IDs
+// assigned so that they can be referenced from other resources in the draft
+func (svc namespaceVersion) UpdateDraft(namespaceID uint64, upd *types.NamespaceVersion) (v *types.NamespaceVersion, err error) {
+	var (
+		aProps = &namespaceVersionActionProps{}
+	)
+
+	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+		if _, err = svc.loadNamespace(ctx, s, aProps, namespaceID); err != nil {
+			return
+		}
+
+		if v, err = loadNamespaceDraft(ctx, s, namespaceID); err != nil {
+			return
+		} else if v == nil {
+			return NamespaceVersionErrDraftNotFound()
+		}
+
+		aProps.setVersion(v)
+
+		if err = prepareNamespaceConfig(namespaceID, &upd.Config); err != nil {
+			return NamespaceVersionErrInvalidConfig(aProps.setDetails(err.Error()))
+		}
+
+		v.Config = upd.Config
+		v.Comment = upd.Comment
+		v.UpdatedAt = now()
+
+		return store.UpdateComposeNamespaceVersion(ctx, s, v)
+	})
+
+	return v, svc.recordAction(svc.ctx, aProps, NamespaceVersionActionUpdateDraft, err)
+}
+
+// DiscardDraft removes draft without applying the changes
+func (svc namespaceVersion) DiscardDraft(namespaceID uint64) (err error) {
+	var (
+		v      *types.NamespaceVersion
+		aProps = &namespaceVersionActionProps{}
+	)
+
+	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+		if _, err = svc.loadNamespace(ctx, s, aProps, namespaceID); err != nil {
+			return
+		}
+
+		if v, err = loadNamespaceDraft(ctx, s, namespaceID); err != nil {
+			return
+		} else if v == nil {
+			return NamespaceVersionErrDraftNotFound()
+		}
+
+		aProps.setVersion(v)
+		return store.DeleteComposeNamespaceVersionByID(ctx, s, v.ID)
+	})
+
+	return svc.recordAction(svc.ctx, aProps, NamespaceVersionActionDiscardDraft, err)
+}
+
+// Diff compares configuration of the version with the live configuration
+func (svc namespaceVersion) Diff(namespaceID, versionID uint64) (diff types.NamespaceConfigDiff, err error) {
+	var (
+		v      *types.NamespaceVersion
+		live   types.NamespaceConfig
+		aProps = &namespaceVersionActionProps{version: &types.NamespaceVersion{ID: versionID, NamespaceID: namespaceID}}
+	)
+
+	err = func() error {
+		if _, err = svc.loadNamespace(svc.ctx, svc.store, aProps, namespaceID); err != nil {
+			return err
+		}
+
+		if v, err = loadNamespaceVersion(svc.ctx, svc.store, namespaceID, versionID); err != nil {
+			return err
+		}
+
+		aProps.setVersion(v)
+
+		if live, err = loadNamespaceConfig(svc.ctx, svc.store, namespaceID, filter.StateExcluded); err != nil {
+			return err
+		}
+
+		diff = v.Config.Diff(live)
+		return nil
+	}()
+
+	return diff, svc.recordAction(svc.ctx, aProps, NamespaceVersionActionLookup, err)
+}
+
+// Publish applies the draft to the live namespace configuration
+//
+// Draft is not published when live configuration changed after the draft was created;
+// changes made in the meantime would be overwritten (or resources removed)
+func (svc namespaceVersion) Publish(namespaceID uint64) (v *types.NamespaceVersion, err error) {
+	var (
+		aProps = &namespaceVersionActionProps{}
+	)
+
+	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+		var (
+			ns *types.Namespace
+		)
+
+		if ns, err = svc.loadNamespace(ctx, s, aProps, namespaceID); err != nil {
+			return
+		}
+
+		if v, err = loadNamespaceDraft(ctx, s, namespaceID); err != nil {
+			return
+		} else if v == nil {
+			return NamespaceVersionErrDraftNotFound()
+		}
+
+		aProps.setVersion(v)
+
+		if base, err := namespaceConfigFingerprint(ctx, s, namespaceID); err != nil {
+			return err
+		} else if base != v.Base {
+			return NamespaceVersionErrStaleDraft()
+		}
+
+		if err = svc.apply(ctx, s, ns, v.Config); err != nil {
+			return
+		}
+
+		// version holds what was actually published
+		if v.Config, err = loadNamespaceConfig(ctx, s, namespaceID, filter.StateExcluded); err != nil {
+			return
+		}
+
+		v.Status = types.NamespaceVersionPublished
+		v.PublishedAt = now()
+		v.PublishedBy = auth.GetIdentityFromContext(ctx).Identity()
+
+		return store.UpdateComposeNamespaceVersion(ctx, s, v)
+	})
+
+	return v, svc.recordAction(svc.ctx, aProps, NamespaceVersionActionPublish, err)
+}
+
+// Rollback restores namespace configuration from a published version
+//
+// Rollback is recorded as a new published version; draft (if any) is kept
+func (svc namespaceVersion) Rollback(namespaceID, versionID uint64) (v *types.NamespaceVersion, err error) {
+	var (
+		aProps = &namespaceVersionActionProps{version: &types.NamespaceVersion{ID: versionID, NamespaceID: namespaceID}}
+	)
+
+	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+		var (
+			ns  *types.Namespace
+			old *types.NamespaceVersion
+		)
+
+		if ns, err = svc.loadNamespace(ctx, s, aProps, namespaceID); err != nil {
+			return
+		}
+
+		if old, err = loadNamespaceVersion(ctx, s, namespaceID, versionID); err != nil {
+			return
+		}
+
+		aProps.setVersion(old)
+
+		if old.Status != types.NamespaceVersionPublished {
+			return NamespaceVersionErrNotPublished()
+		}
+
+		if err = svc.apply(ctx, s, ns, old.Config); err != nil {
+			return
+		}
+
+		v = &types.NamespaceVersion{
+			ID:          nextID(),
+			NamespaceID: namespaceID,
+			Status:      types.NamespaceVersionPublished,
+			Comment:     fmt.Sprintf("rollback to version %d", old.ID),
+			CreatedAt:   *now(),
+			CreatedBy:   auth.GetIdentityFromContext(ctx).Identity(),
+			PublishedAt: now(),
+			PublishedBy: auth.GetIdentityFromContext(ctx).Identity(),
+		}
+
+		if v.Config, err = loadNamespaceConfig(ctx, s, namespaceID, filter.StateExcluded); err != nil {
+			return
+		}
+
+		return store.CreateComposeNamespaceVersion(ctx, s, v)
+	})
+
+	return v, svc.recordAction(svc.ctx, aProps, NamespaceVersionActionRollback, err)
+}
+
+// loadNamespace loads namespace and checks if versions can be managed
+func (svc namespaceVersion) loadNamespace(ctx context.Context, s store.Storer, aProps *namespaceVersionActionProps, namespaceID uint64) (ns *types.Namespace, err error) {
+	if ns, err = loadNamespace(ctx, s, namespaceID); err != nil {
+		return
+	}
+
+	aProps.setNamespace(ns)
+
+	if !svc.ac.CanManageNamespace(ctx, ns) {
+		return nil, NamespaceVersionErrNotAllowedToManage()
+	}
+
+	return
+}
+
+// apply changes live modules, pages and charts to match the configuration
+//
+// Changes are made with module, page and chart services (in the same transaction)
+// so that permissions are checked, events dispatched and actions recorded
+// as if resources were changed one by one.
+//
+// Resources that are not part of the configuration are deleted,
+// resources that were deleted are restored.
+func (svc namespaceVersion) apply(ctx context.Context, s store.Storer, ns *types.Namespace, cfg types.NamespaceConfig) (err error) {
+	var (
+		live types.NamespaceConfig
+
+		modSvc, pageSvc, chartSvc = svc.services(ctx, s)
+	)
+
+	if err = prepareNamespaceConfig(ns.ID, &cfg); err != nil {
+		return NamespaceVersionErrInvalidConfig((&namespaceVersionActionProps{}).setDetails(err.Error()))
+	}
+
+	if live, err = loadNamespaceConfig(ctx, s, ns.ID, filter.StateInclusive); err != nil {
+		return
+	}
+
+	// resources are removed first to free their handles
+	for _, e := range live.Modules {
+		if e.DeletedAt == nil && cfg.Modules.FindByID(e.ID) == nil {
+			if err = modSvc.DeleteByID(ns.ID, e.ID); err != nil {
+				return
+			}
+		}
+	}
+
+	for _, e := range live.Pages {
+		if e.DeletedAt == nil && cfg.Pages.FindByID(e.ID) == nil {
+			if err = pageSvc.DeleteByID(ns.ID, e.ID); err != nil {
+				return
+			}
+		}
+	}
+
+	for _, e := range live.Charts {
+		if e.DeletedAt == nil && cfg.Charts.FindByID(e.ID) == nil {
+			if err = chartSvc.DeleteByID(ns.ID, e.ID); err != nil {
+				return
+			}
+		}
+	}
+
+	for _, m := range cfg.Modules {
+		var (
+			e = live.Modules.FindByID(m.ID)
+			c = m.Clone()
+		)
+
+		if e == nil {
+			_, err = modSvc.Create(c)
+		} else {
+			if e.DeletedAt != nil {
+				if err = modSvc.UndeleteByID(ns.ID, e.ID); err != nil {
+					return
+				}
+			}
+
+			// fields that are not part of the configuration are removed
+			for _, f := range e.Fields {
+				if c.Fields.FindByID(f.ID) == nil {
+					f.DeletedAt = now()
+					c.Fields = append(c.Fields, f)
+				}
+			}
+
+			c.UpdatedAt = nil
+			_, err = modSvc.Update(c)
+		}
+
+		if err != nil {
+			return
+		}
+	}
+
+	for _, p := range cfg.Pages {
+		var (
+			e = live.Pages.FindByID(p.ID)
+			c = p.Clone()
+		)
+
+		c.Children = nil
+
+		if e == nil {
+			_, err = pageSvc.Create(c)
+		} else {
+			if e.DeletedAt != nil {
+				if err = pageSvc.UndeleteByID(ns.ID, e.ID); err != nil {
+					return
+				}
+			}
+
+			c.UpdatedAt = nil
+			_, err = pageSvc.Update(c)
+		}
+
+		if err != nil {
+			return
+		}
+	}
+
+	for _, ch := range cfg.Charts {
+		var (
+			e = live.Charts.FindByID(ch.ID)
+			c = *ch
+		)
+
+		if e == nil {
+			_, err = chartSvc.Create(&c)
+		} else {
+			if e.DeletedAt != nil {
+				if err = chartSvc.UndeleteByID(ns.ID, e.ID); err != nil {
+					return
+				}
+			}
+
+			c.UpdatedAt = nil
+			_, err = chartSvc.Update(&c)
+		}
+
+		if err != nil {
+			return
+		}
+	}
+
+	return nil
+}
+
+// services returns module, page and chart services that work in the given transaction
+//
+// Created resources keep IDs from the configuration; they can be referenced by other resources
+func (svc namespaceVersion) services(ctx context.Context, s store.Storer) (*module, *page, *chart) {
+	return &module{
+			ctx:       ctx,
+			actionlog: svc.actionlog,
+			ac:        DefaultAccessControl,
+			eventbus:  svc.eventbus,
+			store:     s,
+			converter: newModuleValueConverter(),
+			keyring:   DefaultKeyring,
+			presetID:  true,
+		},
+		&page{
+			ctx:       ctx,
+			actionlog: svc.actionlog,
+			ac:        DefaultAccessControl,
+			eventbus:  svc.eventbus,
+			store:     s,
+			presetID:  true,
+		},
+		&chart{
+			ctx:       ctx,
+			actionlog: svc.actionlog,
+			ac:        DefaultAccessControl,
+			store:     s,
+			presetID:  true,
+		}
+}
+
+// prepareNamespaceConfig validates configuration and assigns IDs to new resources
+func prepareNamespaceConfig(namespaceID uint64, cfg *types.NamespaceConfig) error {
+	var (
+		handles = make(map[string]bool)
+
+		checkHandle = func(kind, h string) error {
+			if h == "" {
+				return nil
+			}
+
+			if !handle.IsValid(h) {
+				return fmt.Errorf("invalid %s handle %q", kind, h)
+			}
+
+			if handles[kind+h] {
+				return fmt.Errorf("duplicate %s handle %q", kind, h)
+			}
+
+			handles[kind+h] = true
+			return nil
+		}
+	)
+
+	for _, m := range cfg.Modules {
+		if err := checkHandle("module", m.Handle); err != nil {
+			return err
+		}
+
+		if m.ID == 0 {
+			m.ID = nextID()
+		}
+
+		m.NamespaceID = namespaceID
+
+		names := make(map[string]bool)
+		for _, f := range m.Fields {
+			if f.Name == "" {
+				return fmt.Errorf("missing field name in module %q", m.Handle)
+			}
+
+			if names[f.Name] {
+				return fmt.Errorf("duplicate field %q in module %q", f.Name, m.Handle)
+			}
+
+			names[f.Name] = true
+
+			if f.ID == 0 {
+				f.ID = nextID()
+			}
+
+			f.ModuleID = m.ID
+		}
+
+		if err := validateModuleWorkflow(m.Workflow, m.Fields); err != nil {
+			return fmt.Errorf("invalid workflow of module %q: %v", m.Handle, err)
+		}
+
+		if err := validateModuleApproval(m.Approval); err != nil {
+			return fmt.Errorf("invalid approval flow of module %q: %v", m.Handle, err)
+		}
+
+		if err := validateModuleDynamicRoles(m.Fields); err != nil {
+			return fmt.Errorf("invalid dynamic role of module %q: %v", m.Handle, err)
+		}
+	}
+
+	for _, p := range cfg.Pages {
+		if err := checkHandle("page", p.Handle); err != nil {
+			return err
+		}
+
+		if p.ID == 0 {
+			p.ID = nextID()
+		}
+
+		p.NamespaceID = namespaceID
+	}
+
+	for _, p := range cfg.Pages {
+		if p.ModuleID > 0 && cfg.Modules.FindByID(p.ModuleID) == nil {
+			return fmt.Errorf("page %q references unknown module", p.Title)
+		}
+
+		if p.SelfID > 0 && cfg.Pages.FindByID(p.SelfID) == nil {
+			return fmt.Errorf("page %q references unknown parent page", p.Title)
+		}
+	}
+
+	for _, c := range cfg.Charts {
+		if err := checkHandle("chart", c.Handle); err != nil {
+			return err
+		}
+
+		if c.ID == 0 {
+			c.ID = nextID()
+		}
+
+		c.NamespaceID = namespaceID
+	}
+
+	return nil
+}
+
+// loadNamespaceConfig loads modules (with fields), pages and charts of the namespace
+func loadNamespaceConfig(ctx context.Context, s store.Storer, namespaceID uint64, deleted filter.State) (cfg types.NamespaceConfig, err error) {
+	if cfg.Modules, _, err = store.SearchComposeModules(ctx, s, types.ModuleFilter{NamespaceID: namespaceID, Deleted: deleted}); err != nil {
+		return
+	}
+
+	if err = loadModuleFields(ctx, s, cfg.Modules...); err != nil {
+		return
+	}
+
+	if err = loadModuleLabels(ctx, s, cfg.Modules...); err != nil {
+		return
+	}
+
+	if cfg.Pages, _, err = store.SearchComposePages(ctx, s, types.PageFilter{NamespaceID: namespaceID, Deleted: deleted}); err != nil {
+		return
+	}
+
+	if err = label.Load(ctx, s, toLabeledPages(cfg.Pages)...); err != nil {
+		return
+	}
+
+	if cfg.Charts, _, err = store.SearchComposeCharts(ctx, s, types.ChartFilter{NamespaceID: namespaceID, Deleted: deleted}); err != nil {
+		return
+	}
+
+	if err = label.Load(ctx, s, toLabeledCharts(cfg.Charts)...); err != nil {
+		return
+	}
+
+	return
+}
+
+// namespaceConfigFingerprint returns fingerprint of the live namespace configuration
+//
+// Deleted modules, fields, pages and charts are included;
+// removal of a resource changes the fingerprint as well
+func namespaceConfigFingerprint(ctx context.Context, s store.Storer, namespaceID uint64) (string, error) {
+	live, err := loadNamespaceConfig(ctx, s, namespaceID, filter.StateInclusive)
+	if err != nil {
+		return "", err
+	}
+
+	if len(live.Modules) > 0 {
+		ff, _, err := store.SearchComposeModuleFields(ctx, s, types.ModuleFieldFilter{
+			ModuleID: live.Modules.IDs(),
+			Deleted:  filter.StateInclusive,
+		})
+
+		if err != nil {
+			return "", err
+		}
+
+		for _, m := range live.Modules {
+			m.Fields = ff.FilterByModule(m.ID)
+		}
+	}
+
+	return live.Fingerprint(), nil
+}
+
+// loadNamespaceDraft returns draft version of the namespace or nil if there is none
+func loadNamespaceDraft(ctx context.Context, s store.Storer, namespaceID uint64) (*types.NamespaceVersion, error) {
+	set, _, err := store.SearchComposeNamespaceVersions(ctx, s, types.NamespaceVersionFilter{
+		NamespaceID: namespaceID,
+		Status:      types.NamespaceVersionDraft,
+	})
+
+	if err != nil || len(set) == 0 {
+		return nil, err
+	}
+
+	return set[0], nil
+}
+
+func loadNamespaceVersion(ctx context.Context, s store.Storer, namespaceID, versionID uint64) (v *types.NamespaceVersion, err error) {
+	if versionID == 0 {
+		return nil, NamespaceVersionErrInvalidID()
+	}
+
+	if v, err = store.LookupComposeNamespaceVersionByID(ctx, s, versionID); errors.IsNotFound(err) {
+		return nil, NamespaceVersionErrNotFound()
+	} else if err != nil {
+		return nil, err
+	}
+
+	if v.NamespaceID != namespaceID {
+		return nil, NamespaceVersionErrNotFound()
+	}
+
+	return v, nil
+}
+
+// storeInitialNamespaceVersion stores the live configuration as the first
+// published version when namespace has no history yet
+func storeInitialNamespaceVersion(ctx context.Context, s store.Storer, namespaceID uint64) (err error) {
+	var (
+		hist types.NamespaceVersionSet
+		v    = &types.NamespaceVersion{
+			ID:          nextID(),
+			NamespaceID: namespaceID,
+			Status:      types.NamespaceVersionPublished,
+			Comment:     "initial configuration",
+			CreatedAt:   *now(),
+			CreatedBy:   auth.GetIdentityFromContext(ctx).Identity(),
+		}
+	)
+
+	hist, _, err = store.SearchComposeNamespaceVersions(ctx, s, types.NamespaceVersionFilter{
+		NamespaceID: namespaceID,
+		Status:      types.NamespaceVersionPublished,
+		Paging:      filter.Paging{Limit: 1},
+	})
+
+	if err != nil || len(hist) > 0 {
+		return
+	}
+
+	if v.Config, err = loadNamespaceConfig(ctx, s, namespaceID, filter.StateExcluded); err != nil {
+		return
+	}
+
+	v.PublishedAt = &v.CreatedAt
+	v.PublishedBy = v.CreatedBy
+
+	return store.CreateComposeNamespaceVersion(ctx, s, v)
+}
@@ -0,0 +1,663 @@
+package service
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+// compose/service/namespace_version_actions.yaml
+
+import (
+	"context"
+	"fmt"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"strings"
+	"time"
+)
+
+type (
+	namespaceVersionActionProps struct {
+		version   *types.NamespaceVersion
+		namespace *types.Namespace
+		details   string
+	}
+
+	namespaceVersionAction struct {
+		timestamp time.Time
+		resource  string
+		action    string
+		log       string
+		severity  actionlog.Severity
+
+		// prefix for error when action fails
+		errorMessage string
+
+		props *namespaceVersionActionProps
+	}
+
+	namespaceVersionLogMetaKey   struct{}
+	namespaceVersionPropsMetaKey struct{}
+)
+
+var (
+	// just a placeholder to cover template cases w/o fmt package use
+	_ = fmt.Println
+)
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Props methods
+// setVersion updates namespaceVersionActionProps's version
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *namespaceVersionActionProps) setVersion(version *types.NamespaceVersion) *namespaceVersionActionProps {
+	p.version = version
+	return p
+}
+
+// setNamespace updates namespaceVersionActionProps's namespace
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *namespaceVersionActionProps) setNamespace(namespace *types.Namespace) *namespaceVersionActionProps {
+	p.namespace = namespace
+	return p
+}
+
+// setDetails updates namespaceVersionActionProps's details
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *namespaceVersionActionProps) setDetails(details string) *namespaceVersionActionProps {
+	p.details = details
+	return p
+}
+
+// Serialize converts namespaceVersionActionProps to actionlog.Meta
+//
+// This function is auto-generated.
+//
+func (p namespaceVersionActionProps) Serialize() actionlog.Meta {
+	var (
+		m = make(actionlog.Meta)
+	)
+
+	if p.version != nil {
+		m.Set("version.ID", p.version.ID, true)
+		m.Set("version.namespaceID", p.version.NamespaceID, true)
+		m.Set("version.status", p.version.Status, true)
+	}
+	if p.namespace != nil {
+		m.Set("namespace.name", p.namespace.Name, true)
+		m.Set("namespace.slug", p.namespace.Slug, true)
+		m.Set("namespace.ID", p.namespace.ID, true)
+	}
+	m.Set("details", p.details, true)
+
+	return m
+}
+
+// tr translates string and replaces meta value placeholder with values
+//
+// This function is auto-generated.
+//
+func (p namespaceVersionActionProps) Format(in string, err error) string {
+	var (
+		pairs = []string{"{err}"}
+		// first non-empty string
+		fns = func(ii ...interface{}) string {
+			for _, i := range ii {
+				if s := fmt.Sprintf("%v", i); len(s) > 0 {
+					return s
+				}
+			}
+
+			return ""
+		}
+	)
+
+	if err != nil {
+		pairs = append(pairs, err.Error())
+	} else {
+		pairs = append(pairs, "nil")
+	}
+
+	if p.version != nil {
+		// replacement for "{version}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{version}",
+			fns(
+				p.version.ID,
+				p.version.NamespaceID,
+				p.version.Status,
+			),
+		)
+		pairs = append(pairs, "{version.ID}", fns(p.version.ID))
+		pairs = append(pairs, "{version.namespaceID}", fns(p.version.NamespaceID))
+		pairs = append(pairs, "{version.status}", fns(p.version.Status))
+	}
+
+	if p.namespace != nil {
+		// replacement for "{namespace}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{namespace}",
+			fns(
+				p.namespace.Name,
+				p.namespace.Slug,
+				p.namespace.ID,
+			),
+		)
+		pairs = append(pairs, "{namespace.name}", fns(p.namespace.Name))
+		pairs = append(pairs, "{namespace.slug}", fns(p.namespace.Slug))
+		pairs = append(pairs, "{namespace.ID}", fns(p.namespace.ID))
+	}
+	pairs = append(pairs, "{details}", fns(p.details))
+	return strings.NewReplacer(pairs...).Replace(in)
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Action methods
+
+// String returns loggable description as string
+//
+// This function is auto-generated.
+//
+func (a *namespaceVersionAction) String() string {
+	var props = &namespaceVersionActionProps{}
+
+	if a.props != nil {
+		props = a.props
+	}
+
+	return props.Format(a.log, nil)
+}
+
+func (e *namespaceVersionAction) ToAction() *actionlog.Action {
+	return &actionlog.Action{
+		Resource:    e.resource,
+		Action:      e.action,
+		Severity:    e.severity,
+		Description: e.String(),
+		Meta:        e.props.Serialize(),
+	}
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Action constructors
+
+// NamespaceVersionActionSearch returns "compose:namespace-version.search" action
+//
+// This function is auto-generated.
+//
+func NamespaceVersionActionSearch(props ...*namespaceVersionActionProps) *namespaceVersionAction {
+	a := &namespaceVersionAction{
+		timestamp: time.Now(),
+		resource:  "compose:namespace-version",
+		action:    "search",
+		log:       "searched for namespace versions",
+		severity:  actionlog.Info,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// NamespaceVersionActionLookup returns "compose:namespace-version.lookup" action
+//
+// This function is auto-generated.
+//
+func NamespaceVersionActionLookup(props ...*namespaceVersionActionProps) *namespaceVersionAction {
+	a := &namespaceVersionAction{
+		timestamp: time.Now(),
+		resource:  "compose:namespace-version",
+		action:    "lookup",
+		log:       "looked-up for a {version}",
+		severity:  actionlog.Info,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// NamespaceVersionActionCreateDraft returns "compose:namespace-version.createDraft" action
+//
+// This function is auto-generated.
+//
+func NamespaceVersionActionCreateDraft(props ...*namespaceVersionActionProps) *namespaceVersionAction {
+	a := &namespaceVersionAction{
+		timestamp: time.Now(),
+		resource:  "compose:namespace-version",
+		action:    "createDraft",
+		log:       "created draft of {namespace}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// NamespaceVersionActionUpdateDraft returns "compose:namespace-version.updateDraft" action
+//
+// This function is auto-generated.
+//
+func NamespaceVersionActionUpdateDraft(props ...*namespaceVersionActionProps) *namespaceVersionAction {
+	a := &namespaceVersionAction{
+		timestamp: time.Now(),
+		resource:  "compose:namespace-version",
+		action:    "updateDraft",
+		log:       "updated draft of {namespace}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// NamespaceVersionActionDiscardDraft returns "compose:namespace-version.discardDraft" action
+//
+// This function is auto-generated.
+//
+func NamespaceVersionActionDiscardDraft(props ...*namespaceVersionActionProps) *namespaceVersionAction {
+	a := &namespaceVersionAction{
+		timestamp: time.Now(),
+		resource:  "compose:namespace-version",
+		action:    "discardDraft",
+		log:       "discarded draft of {namespace}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// NamespaceVersionActionPublish returns "compose:namespace-version.publish" action
+//
+// This function is auto-generated.
+//
+func NamespaceVersionActionPublish(props ...*namespaceVersionActionProps) *namespaceVersionAction {
+	a := &namespaceVersionAction{
+		timestamp: time.Now(),
+		resource:  "compose:namespace-version",
+		action:    "publish",
+		log:       "published draft of {namespace}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// NamespaceVersionActionRollback returns "compose:namespace-version.rollback" action
+//
+// This function is auto-generated.
+//
+func NamespaceVersionActionRollback(props ...*namespaceVersionActionProps) *namespaceVersionAction {
+	a := &namespaceVersionAction{
+		timestamp: time.Now(),
+		resource:  "compose:namespace-version",
+		action:    "rollback",
+		log:       "rolled back {namespace} to {version}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Error constructors
+
+// NamespaceVersionErrGeneric returns "compose:namespace-version.generic" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func NamespaceVersionErrGeneric(mm ...*namespaceVersionActionProps) *errors.Error {
+	var p = &namespaceVersionActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("failed to complete request due to internal error", nil),
+
+		errors.Meta("type", "generic"),
+		errors.Meta("resource", "compose:namespace-version"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(namespaceVersionLogMetaKey{}, "{err}"),
+		errors.Meta(namespaceVersionPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// NamespaceVersionErrNotFound returns "compose:namespace-version.notFound" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func NamespaceVersionErrNotFound(mm ...*namespaceVersionActionProps) *errors.Error {
+	var p = &namespaceVersionActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("namespace version does not exist", nil),
+
+		errors.Meta("type", "notFound"),
+		errors.Meta("resource", "compose:namespace-version"),
+
+		errors.Meta(namespaceVersionPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// NamespaceVersionErrInvalidID returns "compose:namespace-version.invalidID" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func NamespaceVersionErrInvalidID(mm ...*namespaceVersionActionProps) *errors.Error {
+	var p = &namespaceVersionActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid ID", nil),
+
+		errors.Meta("type", "invalidID"),
+		errors.Meta("resource", "compose:namespace-version"),
+
+		errors.Meta(namespaceVersionPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// NamespaceVersionErrDraftNotFound returns "compose:namespace-version.draftNotFound" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func NamespaceVersionErrDraftNotFound(mm ...*namespaceVersionActionProps) *errors.Error {
+	var p = &namespaceVersionActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("namespace does not have a draft", nil),
+
+		errors.Meta("type", "draftNotFound"),
+		errors.Meta("resource", "compose:namespace-version"),
+
+		errors.Meta(namespaceVersionPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// NamespaceVersionErrDraftExists returns "compose:namespace-version.draftExists" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func NamespaceVersionErrDraftExists(mm ...*namespaceVersionActionProps) *errors.Error {
+	var p = &namespaceVersionActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("namespace already has a draft", nil),
+
+		errors.Meta("type", "draftExists"),
+		errors.Meta("resource", "compose:namespace-version"),
+
+		errors.Meta(namespaceVersionPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// NamespaceVersionErrNotPublished returns "compose:namespace-version.notPublished" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func NamespaceVersionErrNotPublished(mm ...*namespaceVersionActionProps) *errors.Error {
+	var p = &namespaceVersionActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("namespace version is not published", nil),
+
+		errors.Meta("type", "notPublished"),
+		errors.Meta("resource", "compose:namespace-version"),
+
+		errors.Meta(namespaceVersionPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// NamespaceVersionErrStaleDraft returns "compose:namespace-version.staleDraft" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func NamespaceVersionErrStaleDraft(mm ...*namespaceVersionActionProps) *errors.Error {
+	var p = &namespaceVersionActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("namespace configuration changed after the draft was created", nil),
+
+		errors.Meta("type", "staleDraft"),
+		errors.Meta("resource", "compose:namespace-version"),
+
+		errors.Meta(namespaceVersionPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// NamespaceVersionErrInvalidConfig returns "compose:namespace-version.invalidConfig" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func NamespaceVersionErrInvalidConfig(mm ...*namespaceVersionActionProps) *errors.Error {
+	var p = &namespaceVersionActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid namespace configuration: {details}", nil),
+
+		errors.Meta("type", "invalidConfig"),
+		errors.Meta("resource", "compose:namespace-version"),
+
+		errors.Meta(namespaceVersionPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// NamespaceVersionErrNotAllowedToManage returns "compose:namespace-version.notAllowedToManage" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func NamespaceVersionErrNotAllowedToManage(mm ...*namespaceVersionActionProps) *errors.Error {
+	var p = &namespaceVersionActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("not allowed to manage versions of this namespace", nil),
+
+		errors.Meta("type", "notAllowedToManage"),
+		errors.Meta("resource", "compose:namespace-version"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(namespaceVersionLogMetaKey{}, "could not manage versions of {namespace}; insufficient permissions"),
+		errors.Meta(namespaceVersionPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+
+// recordAction is a service helper function wraps function that can return error
+//
+// It will wrap unrecognized/internal errors with generic errors.
+//
+// This function is auto-generated.
+//
+func (svc namespaceVersion) recordAction(ctx context.Context, props *namespaceVersionActionProps, actionFn func(...*namespaceVersionActionProps) *namespaceVersionAction, err error) error {
+	if svc.actionlog == nil || actionFn == nil {
+		// action log disabled or no action fn passed, return error as-is
+		return err
+	} else if err == nil {
+		// action completed w/o error, record it
+		svc.actionlog.Record(ctx, actionFn(props).ToAction())
+		return nil
+	}
+
+	a := actionFn(props).ToAction()
+
+	// Extracting error information and recording it as action
+	a.Error = err.Error()
+
+	switch c := err.(type) {
+	case *errors.Error:
+		m := c.Meta()
+
+		a.Error = err.Error()
+		a.Severity = actionlog.Severity(m.AsInt("severity"))
+		a.Description = props.Format(m.AsString(namespaceVersionLogMetaKey{}), err)
+
+		if p, has := m[namespaceVersionPropsMetaKey{}]; has {
+			a.Meta = p.(*namespaceVersionActionProps).Serialize()
+		}
+
+		svc.actionlog.Record(ctx, a)
+	default:
+		svc.actionlog.Record(ctx, a)
+	}
+
+	// Original error is passed on
+	return err
+}
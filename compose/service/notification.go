@@ -28,7 +28,12 @@ type (
 	//       Warning: API endpoints on compose should be kept so that we do not break backward compatibility)
 	notification struct {
 		actionlog actionlog.Recorder
-		users     systemService.UserService
+		users     notificationUserFinder
+		templates notificationTemplateRenderer
+	}
+
+	notificationTemplateRenderer interface {
+		Render(ctx context.Context, handle, lang string, vars interface{}) (*systemTypes.RenderedTemplate, error)
 	}
 
 	notificationUserFinder interface {
@@ -40,6 +45,7 @@ func Notification() *notification {
 	return &notification{
 		actionlog: DefaultActionlog,
 		users:     systemService.DefaultUser,
+		templates: systemService.DefaultTemplate,
 	}
 }
 
@@ -71,6 +77,12 @@ func (svc notification) SendEmail(ctx context.Context, n *types.EmailNotificatio
 			}
 		}
 
+		if n.Template != "" {
+			if err = svc.procEmailTemplate(ctx, n); err != nil {
+				return err
+			}
+		}
+
 		msg.SetHeader("Subject", n.Subject)
 
 		if len(n.ContentHTML) > 0 {
@@ -94,6 +106,44 @@ func (svc notification) SendEmail(ctx context.Context, n *types.EmailNotificatio
 	return svc.recordAction(ctx, aProps, NotificationActionSend, err)
 }
 
+// procEmailTemplate renders subject and content from the system template
+//
+// When language is not set, preferred language of the (only) recipient user
+// is used and if that is not possible, preferred language of the current user
+func (svc notification) procEmailTemplate(ctx context.Context, n *types.EmailNotification) error {
+	var (
+		aProps = &notificationActionProps{mail: n}
+		lang   = n.Language
+	)
+
+	if lang == "" && len(n.To) == 1 {
+		if userID, err := strconv.ParseUint(strings.TrimSpace(n.To[0]), 10, 64); err == nil && userID > 0 {
+			if user, err := svc.users.FindByID(userID); err != nil {
+				return NotificationErrFailedToLoadUser(aProps.setRecipient(n.To[0])).Wrap(err)
+			} else {
+				lang = user.PreferredLanguage()
+			}
+		}
+	}
+
+	out, err := svc.templates.Render(ctx, n.Template, lang, n.Variables)
+	if err != nil {
+		return err
+	}
+
+	if n.Subject == "" {
+		n.Subject = out.Subject
+	}
+
+	if out.Type == systemTypes.TemplateTypeHTML {
+		n.ContentHTML = out.Content
+	} else {
+		n.ContentPlain = out.Content
+	}
+
+	return nil
+}
+
 // procEmailRecipients validates, resolves, formats and attaches set of recipients to message
 //
 // Supports 3 input formats:
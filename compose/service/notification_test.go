@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	systemTypes "github.com/cortezaproject/corteza-server/system/types"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	notificationUsersStub map[uint64]*systemTypes.User
+
+	notificationTemplatesStub struct {
+		lang string
+		vars interface{}
+	}
+)
+
+func (s notificationUsersStub) FindByID(ID uint64) (*systemTypes.User, error) {
+	return s[ID], nil
+}
+
+func (s *notificationTemplatesStub) Render(ctx context.Context, handle, lang string, vars interface{}) (*systemTypes.RenderedTemplate, error) {
+	s.lang, s.vars = lang, vars
+	return &systemTypes.RenderedTemplate{
+		Handle:   handle,
+		Language: lang,
+		Type:     systemTypes.TemplateTypeHTML,
+		Subject:  "rendered subject",
+		Content:  "<p>rendered</p>",
+	}, nil
+}
+
+func TestNotification_procEmailTemplate(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		users = notificationUsersStub{
+			42: &systemTypes.User{ID: 42, Meta: &systemTypes.UserMeta{PreferredLanguage: "de"}},
+		}
+	)
+
+	t.Run("recipient's language", func(t *testing.T) {
+		var (
+			req = require.New(t)
+			tpl = &notificationTemplatesStub{}
+			svc = notification{users: users, templates: tpl}
+			n   = &types.EmailNotification{To: []string{"42"}, Template: "welcome", Variables: map[string]interface{}{"a": 1}}
+		)
+
+		req.NoError(svc.procEmailTemplate(ctx, n))
+		req.Equal("de", tpl.lang)
+		req.Equal(n.Variables, tpl.vars)
+		req.Equal("rendered subject", n.Subject)
+		req.Equal("<p>rendered</p>", n.ContentHTML)
+	})
+
+	t.Run("explicit language and subject", func(t *testing.T) {
+		var (
+			req = require.New(t)
+			tpl = &notificationTemplatesStub{}
+			svc = notification{users: users, templates: tpl}
+			n   = &types.EmailNotification{To: []string{"42"}, Template: "welcome", Language: "fr", Subject: "given"}
+		)
+
+		req.NoError(svc.procEmailTemplate(ctx, n))
+		req.Equal("fr", tpl.lang)
+		req.Equal("given", n.Subject)
+	})
+
+	t.Run("multiple recipients", func(t *testing.T) {
+		var (
+			req = require.New(t)
+			tpl = &notificationTemplatesStub{}
+			svc = notification{users: users, templates: tpl}
+			n   = &types.EmailNotification{To: []string{"42", "foo@example.tld"}, Template: "welcome"}
+		)
+
+		req.NoError(svc.procEmailTemplate(ctx, n))
+		req.Equal("", tpl.lang)
+	})
+}
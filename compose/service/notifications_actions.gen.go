@@ -124,6 +124,8 @@ func (p notificationActionProps) Serialize() actionlog.Meta {
 		m.Set("mail.contentPlain", p.mail.ContentPlain, true)
 		m.Set("mail.contentHTML", p.mail.ContentHTML, true)
 		m.Set("mail.remoteAttachments", p.mail.RemoteAttachments, true)
+		m.Set("mail.template", p.mail.Template, true)
+		m.Set("mail.language", p.mail.Language, true)
 	}
 	m.Set("recipient", p.recipient, true)
 	m.Set("attachmentURL", p.attachmentURL, true)
@@ -171,6 +173,8 @@ func (p notificationActionProps) Format(in string, err error) string {
 				p.mail.ContentPlain,
 				p.mail.ContentHTML,
 				p.mail.RemoteAttachments,
+				p.mail.Template,
+				p.mail.Language,
 			),
 		)
 		pairs = append(pairs, "{mail.subject}", fns(p.mail.Subject))
@@ -180,6 +184,8 @@ func (p notificationActionProps) Format(in string, err error) string {
 		pairs = append(pairs, "{mail.contentPlain}", fns(p.mail.ContentPlain))
 		pairs = append(pairs, "{mail.contentHTML}", fns(p.mail.ContentHTML))
 		pairs = append(pairs, "{mail.remoteAttachments}", fns(p.mail.RemoteAttachments))
+		pairs = append(pairs, "{mail.template}", fns(p.mail.Template))
+		pairs = append(pairs, "{mail.language}", fns(p.mail.Language))
 	}
 	pairs = append(pairs, "{recipient}", fns(p.recipient))
 	pairs = append(pairs, "{attachmentURL}", fns(p.attachmentURL))
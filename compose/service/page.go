@@ -20,6 +20,10 @@ type (
 		ac        pageAccessController
 		eventbus  eventDispatcher
 		store     store.Storer
+
+		// create keeps the ID that is already set on the page
+		// (pages in namespace drafts are referenced by their IDs)
+		presetID bool
 	}
 
 	pageAccessController interface {
@@ -264,11 +268,14 @@ func (svc page) Reorder(namespaceID, parentID uint64, pageIDs []uint64) (err err
 
 func (svc page) Create(new *types.Page) (*types.Page, error) {
 	var (
-		ns     *types.Namespace
-		aProps = &pageActionProps{changed: new}
+		ns       *types.Namespace
+		aProps   = &pageActionProps{changed: new}
+		deferred eventbus.Deferred
 	)
 
-	new.ID = 0
+	if !svc.presetID {
+		new.ID = 0
+	}
 
 	err := store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
 		if !handle.IsValid(new.Handle) {
@@ -293,7 +300,10 @@ func (svc page) Create(new *types.Page) (*types.Page, error) {
 			return err
 		}
 
-		new.ID = nextID()
+		if new.ID == 0 {
+			new.ID = nextID()
+		}
+
 		new.CreatedAt = *now()
 		new.UpdatedAt = nil
 		new.DeletedAt = nil
@@ -306,10 +316,13 @@ func (svc page) Create(new *types.Page) (*types.Page, error) {
 			return
 		}
 
-		_ = svc.eventbus.WaitFor(svc.ctx, event.PageAfterCreate(new, nil, ns))
-		return err
+		return deferred.Enqueue(ctx, s, event.PageAfterCreate(new, nil, ns))
 	})
 
+	if err == nil {
+		_ = deferred.WaitFor(svc.ctx, svc.eventbus)
+	}
+
 	return new, svc.recordAction(svc.ctx, aProps, PageActionCreate, err)
 }
 
@@ -329,10 +342,11 @@ func (svc page) updater(namespaceID, pageID uint64, action func(...*pageActionPr
 	var (
 		changes pageChanges
 
-		ns     *types.Namespace
-		p, old *types.Page
-		aProps = &pageActionProps{page: &types.Page{ID: pageID, NamespaceID: namespaceID}}
-		err    error
+		ns       *types.Namespace
+		p, old   *types.Page
+		aProps   = &pageActionProps{page: &types.Page{ID: pageID, NamespaceID: namespaceID}}
+		err      error
+		deferred eventbus.Deferred
 	)
 
 	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
@@ -377,14 +391,18 @@ func (svc page) updater(namespaceID, pageID uint64, action func(...*pageActionPr
 		}
 
 		if p.DeletedAt == nil {
-			err = svc.eventbus.WaitFor(svc.ctx, event.PageAfterUpdate(p, old, ns))
+			err = deferred.Enqueue(ctx, s, event.PageAfterUpdate(p, old, ns))
 		} else {
-			err = svc.eventbus.WaitFor(svc.ctx, event.PageAfterDelete(nil, old, ns))
+			err = deferred.Enqueue(ctx, s, event.PageAfterDelete(nil, old, ns))
 		}
 
 		return err
 	})
 
+	if err == nil {
+		err = deferred.WaitFor(svc.ctx, svc.eventbus)
+	}
+
 	return p, svc.recordAction(svc.ctx, aProps, action, err)
 }
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cortezaproject/corteza-server/compose/service/event"
@@ -19,6 +20,7 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/errors"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/pkg/label"
+	"github.com/cortezaproject/corteza-server/pkg/secrets"
 	"github.com/cortezaproject/corteza-server/store"
 )
 
@@ -38,11 +40,17 @@ type (
 
 		store store.Storer
 
+		// encrypts values of encrypted fields
+		keyring *secrets.Keyring
+
 		formatter recordValuesFormatter
 		sanitizer recordValuesSanitizer
 		validator recordValuesValidator
 
 		optEmitEvents bool
+
+		// apply changes without requesting an approval
+		optSkipApproval bool
 	}
 
 	recordValuesFormatter interface {
@@ -63,6 +71,7 @@ type (
 	recordValueAccessController interface {
 		CanReadRecordValue(context.Context, *types.ModuleField) bool
 		CanUpdateRecordValue(context.Context, *types.ModuleField) bool
+		CanDecryptRecordValue(context.Context, *types.ModuleField) bool
 	}
 
 	recordAccessController interface {
@@ -73,17 +82,23 @@ type (
 		CanUpdateRecord(context.Context, *types.Module) bool
 		CanDeleteRecord(context.Context, *types.Module) bool
 
+		CanReadModuleRecord(context.Context, *types.Module, *types.Record) bool
+		CanUpdateModuleRecord(context.Context, *types.Module, *types.Record) bool
+		CanDeleteModuleRecord(context.Context, *types.Module, *types.Record) bool
+
 		recordValueAccessController
 	}
 
 	RecordService interface {
 		With(ctx context.Context) RecordService
+		WithStore(s store.Storer) RecordService
 
 		FindByID(namespaceID, moduleID, recordID uint64) (*types.Record, error)
 
 		Report(namespaceID, moduleID uint64, metrics, dimensions, filter string) (interface{}, error)
 		Find(filter types.RecordFilter) (set types.RecordSet, f types.RecordFilter, err error)
-		Export(types.RecordFilter, Encoder) error
+		Export(types.RecordFilter, types.RecordExport, Encoder) error
+		Transitions(*types.Module, *types.Record) []*types.ModuleWorkflowTransition
 		Import(*recordImportSession) error
 
 		Create(record *types.Record) (*types.Record, error)
@@ -99,12 +114,20 @@ type (
 		TriggerScript(ctx context.Context, namespaceID, moduleID, recordID uint64, rvs types.RecordValueSet, script string) (*types.Module, *types.Record, error)
 
 		EventEmitting(enable bool)
+
+		RotateKey() (int, error)
 	}
 
 	Encoder interface {
 		Record(*types.Record) error
 	}
 
+	// RelatedEncoder encodes records together with their related (child) records
+	RelatedEncoder interface {
+		Encoder
+		Related(*types.Record, []*types.RelatedRecords) error
+	}
+
 	recordImportSession struct {
 		Name        string `json:"-"`
 		SessionID   uint64 `json:"sessionID,string"`
@@ -116,6 +139,10 @@ type (
 		Fields   map[string]string     `json:"fields"`
 		Progress *RecordImportProgress `json:"progress"`
 
+		// Sheets of the imported spreadsheet and the one being imported
+		Sheets []string `json:"sheets,omitempty"`
+		Sheet  string   `json:"sheet,omitempty"`
+
 		CreatedAt time.Time `json:"createdAt"`
 		UpdatedAt time.Time `json:"updatedAt"`
 
@@ -138,6 +165,7 @@ func Record() RecordService {
 		eventbus:      eventbus.Service(),
 		optEmitEvents: true,
 		store:         DefaultStore,
+		keyring:       DefaultKeyring,
 	}).With(context.Background())
 }
 
@@ -184,16 +212,26 @@ func (svc record) With(ctx context.Context) RecordService {
 		ac:       svc.ac,
 		eventbus: svc.eventbus,
 
-		store: svc.store,
+		store:   svc.store,
+		keyring: svc.keyring,
 
 		formatter: values.Formatter(),
 		sanitizer: values.Sanitizer(),
 		validator: validator,
 
-		optEmitEvents: svc.optEmitEvents,
+		optEmitEvents:   svc.optEmitEvents,
+		optSkipApproval: svc.optSkipApproval,
 	}
 }
 
+// WithStore returns copy of the service that uses the given store
+//
+// Used to make record changes in the transaction of the caller
+func (svc record) WithStore(s store.Storer) RecordService {
+	svc.store = s
+	return &svc
+}
+
 func (svc *record) EventEmitting(enable bool) {
 	svc.optEmitEvents = enable
 }
@@ -222,12 +260,16 @@ func (svc record) lookup(namespaceID, moduleID uint64, lookup func(*types.Module
 
 		aProps.setRecord(r)
 
-		if !svc.ac.CanReadRecord(svc.ctx, m) {
+		if !svc.ac.CanReadModuleRecord(svc.ctx, m, r) {
 			return RecordErrNotAllowedToRead()
 		}
 
 		trimUnreadableRecordFields(svc.ctx, svc.ac, m, r)
 
+		if err = decryptRecordValues(svc.keyring, r); err != nil {
+			return err
+		}
+
 		if err = label.Load(svc.ctx, svc.store, r); err != nil {
 			return err
 		}
@@ -279,14 +321,26 @@ func (svc record) Find(filter types.RecordFilter) (set types.RecordSet, f types.
 			return err
 		}
 
+		// Values are not loaded when records are checked so records readable
+		// only through user fields with dynamic roles are matched with the query;
+		// query is restricted on the server side and returned as requested
+		var (
+			query        = filter.Query
+			byUserFields = !svc.ac.CanReadRecord(svc.ctx, m) && svc.restrictToReadableUserFields(m, &filter)
+		)
+
 		filter.Check = func(res *types.Record) (bool, error) {
-			if !svc.ac.CanReadRecord(svc.ctx, m) {
+			if !byUserFields && !svc.ac.CanReadModuleRecord(svc.ctx, m, res) {
 				return false, nil
 			}
 
 			return true, nil
 		}
 
+		if svc.canDecryptRecordValues(m) {
+			filter.BlindIndexes = recordBlindIndexes(svc.keyring)
+		}
+
 		if len(filter.Labels) > 0 {
 			filter.LabeledIDs, err = label.Search(
 				svc.ctx,
@@ -310,18 +364,70 @@ func (svc record) Find(filter types.RecordFilter) (set types.RecordSet, f types.
 			return err
 		}
 
+		f.Query = query
+
 		if err = label.Load(svc.ctx, svc.store, toLabeledRecords(set)...); err != nil {
 			return err
 		}
 
 		trimUnreadableRecordFields(svc.ctx, svc.ac, m, set...)
 
-		return nil
+		return decryptRecordValues(svc.keyring, set...)
 	}()
 
 	return set, f, svc.recordAction(svc.ctx, aProps, RecordActionSearch, err)
 }
 
+// canDecryptRecordValues checks if current user can decrypt values of all encrypted fields
+//
+// Encrypted fields can be used in the record filter only when that is the case;
+// matching values by their blind indexes would otherwise reveal them
+func (svc record) canDecryptRecordValues(m *types.Module) bool {
+	for _, f := range m.Fields {
+		if f.IsEncrypted() && !svc.ac.CanDecryptRecordValue(svc.ctx, f) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// restrictToReadableUserFields limits filter to records where current user is set
+// in one of the user fields with dynamic role that can read the records
+//
+// Returns false when there are no such fields
+func (svc record) restrictToReadableUserFields(m *types.Module, f *types.RecordFilter) bool {
+	var (
+		userID = auth.GetIdentityFromContext(svc.ctx).Identity()
+		strID  = strconv.FormatUint(userID, 10)
+		cc     = make([]string, 0)
+	)
+
+	for _, fld := range m.Fields {
+		if fld.DynamicRole() == 0 {
+			continue
+		}
+
+		// record with the user set in this field only
+		r := &types.Record{ModuleID: m.ID, Values: types.RecordValueSet{{Name: fld.Name, Value: strID}}}
+		if svc.ac.CanReadModuleRecord(svc.ctx, m, r) {
+			cc = append(cc, fmt.Sprintf("%s = %d", fld.Name, userID))
+		}
+	}
+
+	if userID == 0 || len(cc) == 0 {
+		return false
+	}
+
+	if f.Query != "" {
+		f.Query = fmt.Sprintf("(%s) AND (%s)", f.Query, strings.Join(cc, " OR "))
+	} else {
+		f.Query = strings.Join(cc, " OR ")
+	}
+
+	return true
+}
+
 func (svc record) Import(ses *recordImportSession) (err error) {
 	var (
 		aProps = &recordActionProps{}
@@ -355,6 +461,9 @@ func (svc record) Import(ses *recordImportSession) (err error) {
 			Defer: func() {
 				ses.Progress.Completed++
 			},
+			EncryptRecord: func(m *types.Module, r *types.Record) (*types.Record, error) {
+				return encryptedRecord(svc.keyring, m, r)
+			},
 		}
 		if ses.OnError == IMPORT_ON_ERROR_SKIP {
 			cfg.DeferNok = func(err error) error {
@@ -388,12 +497,14 @@ func (svc record) Import(ses *recordImportSession) (err error) {
 // Export returns all records
 //
 // @todo better value handling
-func (svc record) Export(f types.RecordFilter, enc Encoder) (err error) {
+func (svc record) Export(f types.RecordFilter, x types.RecordExport, enc Encoder) (err error) {
 	var (
 		aProps = &recordActionProps{filter: &f}
 
 		m   *types.Module
 		set types.RecordSet
+		rel map[uint64][]*types.RelatedRecords
+		exp = newRecordExporter(svc.ctx, svc.store, svc.ac, svc.keyring, x.Resolve)
 	)
 
 	err = func() (err error) {
@@ -402,12 +513,44 @@ func (svc record) Export(f types.RecordFilter, enc Encoder) (err error) {
 			return err
 		}
 
+		cc, err := exp.children(m, x.Children)
+		if err != nil {
+			return err
+		}
+
+		renc, isRelated := enc.(RelatedEncoder)
+		if len(cc) > 0 && !isRelated {
+			return RecordErrInvalidExportDefinition(aProps.setDetails("format does not support related records"))
+		}
+
+		if svc.canDecryptRecordValues(m) {
+			f.BlindIndexes = recordBlindIndexes(svc.keyring)
+		}
+
 		set, _, err = store.SearchComposeRecords(svc.ctx, svc.store, m, f)
 		if err != nil {
 			return err
 		}
 
-		return set.Walk(enc.Record)
+		if err = exp.readable(m, set); err != nil {
+			return err
+		}
+
+		if rel, err = exp.related(set, cc); err != nil {
+			return err
+		}
+
+		if set, err = exp.resolve(m, x.Fields, set); err != nil {
+			return err
+		}
+
+		if len(cc) == 0 {
+			return set.Walk(enc.Record)
+		}
+
+		return set.Walk(func(r *types.Record) error {
+			return renc.Related(r, rel[r.ID])
+		})
 	}()
 
 	return svc.recordAction(svc.ctx, aProps, RecordActionExport, err)
@@ -416,9 +559,21 @@ func (svc record) Export(f types.RecordFilter, enc Encoder) (err error) {
 // Bulk handles provided set of bulk record operations.
 // It's able to create, update or delete records in a single transaction.
 func (svc record) Bulk(oo ...*types.RecordBulkOperation) (rr types.RecordSet, err error) {
-	var pr *types.Record
+	var (
+		pr *types.Record
+
+		// changes that require an approval are not applied but the
+		// change requests are stored with the rest of the bulk; error
+		// is returned after the transaction is committed
+		approvalErr error
+	)
+
+	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) error {
+		// all operations (and change requests they make)
+		// are stored in the transaction of the bulk
+		svc.store = s
+		approvalErr = nil
 
-	err = func() error {
 		// pre-verify all
 		for _, p := range oo {
 			switch p.Operation {
@@ -480,6 +635,11 @@ func (svc record) Bulk(oo ...*types.RecordBulkOperation) (rr types.RecordSet, er
 				r, err = svc.delete(r.NamespaceID, r.ModuleID, r.ID)
 			}
 
+			if errors.Is(err, RecordErrApprovalRequired()) {
+				approvalErr = svc.recordAction(svc.ctx, aProp, action, err)
+				continue
+			}
+
 			if rve := types.IsRecordValueErrorSet(err); rve != nil {
 				// Attach additional meta to each value error for FE identification
 				for _, re := range rve.Set {
@@ -512,7 +672,11 @@ func (svc record) Bulk(oo ...*types.RecordBulkOperation) (rr types.RecordSet, er
 		}
 
 		return nil
-	}()
+	})
+
+	if err == nil && approvalErr != nil {
+		err = approvalErr
+	}
 
 	if len(oo) == 1 {
 		// was not really a bulk operation and we already recorded the action
@@ -537,6 +701,8 @@ func (svc record) create(new *types.Record) (rec *types.Record, err error) {
 
 		ns *types.Namespace
 		m  *types.Module
+
+		deferred eventbus.Deferred
 	)
 
 	ns, m, err = loadModuleWithNamespace(svc.ctx, svc.store, new.NamespaceID, new.ModuleID)
@@ -581,8 +747,34 @@ func (svc record) create(new *types.Record) (rec *types.Record, err error) {
 		return nil, RecordErrValueInput().Wrap(rve)
 	}
 
-	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) error {
-		return store.CreateComposeRecord(ctx, s, m, new)
+	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+		var enc *types.Record
+		if enc, err = encryptedRecord(svc.keyring, m, new); err != nil {
+			return
+		}
+
+		if err = store.CreateComposeRecord(ctx, s, m, enc); err != nil {
+			return
+		}
+
+		if svc.optEmitEvents {
+			// After-create scripts are invoked by the outbox worker
+			var (
+				out    = svc.formatted(m, new)
+				sealed *types.Record
+			)
+
+			if sealed, err = encryptedRecord(svc.keyring, m, out); err != nil {
+				return
+			}
+
+			return deferred.EnqueueSealed(ctx, s,
+				event.RecordAfterCreateImmutable(out, nil, m, ns, nil),
+				event.RecordAfterCreateImmutable(sealed, nil, m, ns, nil),
+			)
+		}
+
+		return
 	})
 
 	if err != nil {
@@ -593,14 +785,16 @@ func (svc record) create(new *types.Record) (rec *types.Record, err error) {
 		return
 	}
 
+	_ = deferred.WaitFor(svc.ctx, svc.eventbus)
+
 	// At this point we can return the value
 	rec = new
 
 	if svc.optEmitEvents {
 		new.Values = svc.formatter.Run(m, new.Values)
-		_ = svc.eventbus.WaitFor(svc.ctx, event.RecordAfterCreateImmutable(new, nil, m, ns, nil))
 	}
 
+	trimUndecryptableRecordFields(svc.ctx, svc.ac, m, rec)
 	return
 }
 
@@ -614,6 +808,8 @@ func (svc record) update(upd *types.Record) (rec *types.Record, err error) {
 		ns  *types.Namespace
 		m   *types.Module
 		old *types.Record
+
+		deferred eventbus.Deferred
 	)
 
 	if upd.ID == 0 {
@@ -625,11 +821,15 @@ func (svc record) update(upd *types.Record) (rec *types.Record, err error) {
 		return
 	}
 
+	if err = decryptRecordValues(svc.keyring, old); err != nil {
+		return
+	}
+
 	aProps.setNamespace(ns)
 	aProps.setModule(m)
 	aProps.setRecord(old)
 
-	if !svc.ac.CanUpdateRecord(svc.ctx, m) {
+	if !svc.ac.CanUpdateModuleRecord(svc.ctx, m, old) {
 		return nil, RecordErrNotAllowedToUpdate()
 	}
 
@@ -680,6 +880,17 @@ func (svc record) update(upd *types.Record) (rec *types.Record, err error) {
 		return nil, RecordErrValueInput().Wrap(rve)
 	}
 
+	if !svc.optSkipApproval && m.Approval.IsEnabled() {
+		// Changes that require an approval are stored as change request
+		// and applied when approved
+		var cr *types.RecordChangeRequest
+		if cr, err = DefaultRecordChangeRequest.With(svc.ctx).WithStore(svc.store).Request(m, upd, old); err != nil {
+			return
+		} else if cr != nil {
+			return nil, RecordErrApprovalRequired(aProps.setChangeRequest(cr))
+		}
+	}
+
 	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) error {
 		if label.Changed(old.Labels, upd.Labels) {
 			if err = label.Update(ctx, s, upd); err != nil {
@@ -687,13 +898,44 @@ func (svc record) update(upd *types.Record) (rec *types.Record, err error) {
 			}
 		}
 
-		return store.UpdateComposeRecord(ctx, s, m, upd)
+		enc, err := encryptedRecord(svc.keyring, m, upd)
+		if err != nil {
+			return err
+		}
+
+		if err = store.UpdateComposeRecord(ctx, s, m, enc); err != nil {
+			return err
+		}
+
+		if svc.optEmitEvents {
+			// After-update scripts are invoked by the outbox worker
+			out := svc.formatted(m, upd)
+
+			sealed, err := encryptedRecord(svc.keyring, m, out)
+			if err != nil {
+				return err
+			}
+
+			sealedOld, err := encryptedRecord(svc.keyring, m, old)
+			if err != nil {
+				return err
+			}
+
+			return deferred.EnqueueSealed(ctx, s,
+				event.RecordAfterUpdateImmutable(out, old, m, ns, nil),
+				event.RecordAfterUpdateImmutable(sealed, sealedOld, m, ns, nil),
+			)
+		}
+
+		return nil
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
+	_ = deferred.WaitFor(svc.ctx, svc.eventbus)
+
 	// Final value cleanup
 	// These (clean) values are returned (and sent to after-update handler)
 	upd.Values = upd.Values.GetClean()
@@ -704,8 +946,17 @@ func (svc record) update(upd *types.Record) (rec *types.Record, err error) {
 	if svc.optEmitEvents {
 		// Before we pass values to automation scripts, they should be formatted
 		upd.Values = svc.formatter.Run(m, upd.Values)
-		_ = svc.eventbus.WaitFor(svc.ctx, event.RecordAfterUpdateImmutable(upd, old, m, ns, nil))
 	}
+
+	if m.Workflow.IsEnabled() && recordState(m, upd) != recordState(m, old) {
+		_ = svc.recordAction(svc.ctx, aProps.setValue(recordState(m, upd)), RecordActionTransition, nil)
+
+		if svc.optEmitEvents {
+			_ = svc.eventbus.WaitFor(svc.ctx, event.RecordOnTransitionImmutable(upd, old, m, ns, nil))
+		}
+	}
+
+	trimUndecryptableRecordFields(svc.ctx, svc.ac, m, rec)
 	return
 }
 
@@ -723,6 +974,14 @@ func (svc record) Create(new *types.Record) (rec *types.Record, err error) {
 	return rec, svc.recordAction(svc.ctx, aProps, RecordActionCreate, err)
 }
 
+// formatted returns a copy of the record with clean values
+// formatted for automation scripts
+func (svc record) formatted(m *types.Module, r *types.Record) *types.Record {
+	c := r.Clone()
+	c.Values = svc.formatter.Run(m, c.Values.GetClean())
+	return c
+}
+
 // Runs value sanitization, sets values that should be used
 // and validates the final result
 //
@@ -774,6 +1033,12 @@ func (svc record) procCreate(ctx context.Context, s store.Storer, invokerID uint
 		return rve
 	}
 
+	procWorkflow(ctx, m, new, nil, rve)
+
+	if !rve.IsValid() {
+		return rve
+	}
+
 	// Run validation of the updated records
 	return svc.validator.Run(ctx, s, m, new)
 }
@@ -835,8 +1100,14 @@ func (svc record) procUpdate(ctx context.Context, s store.Storer, invokerID uint
 	// Value merge process does not know anything about permissions so
 	// in case when new values are missing but do exist in the old set and their update/read is denied
 	// we need to copy them to ensure value merge process them correctly
+	//
+	// Same goes for encrypted values that were not decrypted for the user
 	for _, f := range m.Fields {
-		if len(upd.Values.FilterByName(f.Name)) == 0 && !svc.ac.CanUpdateRecordValue(svc.ctx, m.Fields.FindByName(f.Name)) {
+		if len(upd.Values.FilterByName(f.Name)) > 0 {
+			continue
+		}
+
+		if !svc.ac.CanUpdateRecordValue(svc.ctx, f) || (f.IsEncrypted() && !svc.ac.CanDecryptRecordValue(svc.ctx, f)) {
 			// copy all fields from old to new
 			upd.Values = append(upd.Values, old.Values.FilterByName(f.Name).GetClean()...)
 		}
@@ -866,6 +1137,12 @@ func (svc record) procUpdate(ctx context.Context, s store.Storer, invokerID uint
 		return rve
 	}
 
+	procWorkflow(ctx, m, upd, old, rve)
+
+	if !rve.IsValid() {
+		return rve
+	}
+
 	// Run validation of the updated records
 	return svc.validator.Run(ctx, s, m, upd)
 }
@@ -881,6 +1158,8 @@ func (svc record) delete(namespaceID, moduleID, recordID uint64) (del *types.Rec
 		m  *types.Module
 
 		invokerID = auth.GetIdentityFromContext(svc.ctx).Identity()
+
+		deferred eventbus.Deferred
 	)
 
 	if namespaceID == 0 {
@@ -898,7 +1177,11 @@ func (svc record) delete(namespaceID, moduleID, recordID uint64) (del *types.Rec
 		return nil, err
 	}
 
-	if !svc.ac.CanDeleteRecord(svc.ctx, m) {
+	if err = decryptRecordValues(svc.keyring, del); err != nil {
+		return nil, err
+	}
+
+	if !svc.ac.CanDeleteModuleRecord(svc.ctx, m, del) {
 		return nil, RecordErrNotAllowedToDelete()
 	}
 
@@ -909,21 +1192,45 @@ func (svc record) delete(namespaceID, moduleID, recordID uint64) (del *types.Rec
 		}
 	}
 
+	if !svc.optSkipApproval && m.Approval.IsEnabled() {
+		// Deletes that require an approval are stored as change request
+		// and applied when approved
+		var cr *types.RecordChangeRequest
+		if cr, err = DefaultRecordChangeRequest.With(svc.ctx).WithStore(svc.store).RequestDelete(m, del); err != nil {
+			return nil, err
+		} else if cr != nil {
+			return nil, RecordErrApprovalRequired((&recordActionProps{}).setRecord(del).setChangeRequest(cr))
+		}
+	}
+
 	del.DeletedAt = now()
 	del.DeletedBy = invokerID
 
 	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) error {
-		return store.UpdateComposeRecord(ctx, s, m, del)
+		enc, err := encryptedRecord(svc.keyring, m, del)
+		if err != nil {
+			return err
+		}
+
+		if err = store.UpdateComposeRecord(ctx, s, m, enc); err != nil {
+			return err
+		}
+
+		if svc.optEmitEvents {
+			return deferred.EnqueueSealed(ctx, s,
+				event.RecordAfterDeleteImmutable(nil, del, m, ns, nil),
+				event.RecordAfterDeleteImmutable(nil, enc, m, ns, nil),
+			)
+		}
+
+		return nil
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	if svc.optEmitEvents {
-		_ = svc.eventbus.WaitFor(svc.ctx, event.RecordAfterDeleteImmutable(nil, del, m, ns, nil))
-	}
-
+	_ = deferred.WaitFor(svc.ctx, svc.eventbus)
 	return del, nil
 }
 
@@ -961,7 +1268,9 @@ func (svc record) DeleteByID(namespaceID, moduleID uint64, recordIDs ...uint64)
 		aProps.setNamespace(ns)
 		aProps.setModule(m)
 
-		if !svc.ac.CanDeleteRecord(svc.ctx, m) {
+		// Single record deletion is checked against the record itself
+		// so that dynamic roles user has on the record are considered
+		if isBulkDelete && !svc.ac.CanDeleteRecord(svc.ctx, m) {
 			return RecordErrNotAllowedToDelete()
 		}
 
@@ -1018,7 +1327,7 @@ func (svc record) Organize(namespaceID, moduleID, recordID uint64, posField, pos
 		aProps.setModule(m)
 		aProps.setRecord(r)
 
-		if !svc.ac.CanUpdateRecord(svc.ctx, m) {
+		if !svc.ac.CanUpdateModuleRecord(svc.ctx, m, r) {
 			return RecordErrNotAllowedToUpdate()
 		}
 
@@ -1045,6 +1354,10 @@ func (svc record) Organize(namespaceID, moduleID, recordID uint64, posField, pos
 				return fmt.Errorf("can not reorder on multi-value field %q", posField)
 			}
 
+			if sf.IsEncrypted() {
+				return fmt.Errorf("can not reorder on encrypted field %q", posField)
+			}
+
 			if !svc.ac.CanUpdateRecordValue(svc.ctx, sf) {
 				return RecordErrNotAllowedToUpdate()
 			}
@@ -1081,6 +1394,10 @@ func (svc record) Organize(namespaceID, moduleID, recordID uint64, posField, pos
 			})
 		}
 
+		if recordValues, err = encryptRecordValues(svc.keyring, m, recordValues); err != nil {
+			return err
+		}
+
 		return store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) error {
 			if len(recordValues) > 0 {
 				//svc.recordInfoUpdate(r)
@@ -1118,6 +1435,10 @@ func (svc record) Organize(namespaceID, moduleID, recordID uint64, posField, pos
 					return err
 				}
 
+				if svc.canDecryptRecordValues(m) {
+					reorderFilter.BlindIndexes = recordBlindIndexes(svc.keyring)
+				}
+
 				set, _, err = store.SearchComposeRecords(ctx, s, m, reorderFilter)
 				if err != nil {
 					return err
@@ -1160,6 +1481,11 @@ func (svc record) TriggerScript(ctx context.Context, namespaceID, moduleID, reco
 		return nil, nil, err
 	}
 
+	trimUndecryptableRecordFields(ctx, svc.ac, m, r)
+	if err = decryptRecordValues(svc.keyring, r); err != nil {
+		return nil, nil, err
+	}
+
 	original := r.Clone()
 	r.Values = values.Sanitizer().Run(m, rvs)
 	validated := values.Validator().Run(ctx, svc.store, m, r)
@@ -1248,12 +1574,22 @@ func (svc record) Iterator(f types.RecordFilter, fn eventbus.HandlerFn, action s
 			}
 		}
 
+		if svc.canDecryptRecordValues(m) {
+			f.BlindIndexes = recordBlindIndexes(svc.keyring)
+		}
+
 		// @todo might be good to split set into smaller chunks
 		set, f, err = store.SearchComposeRecords(svc.ctx, svc.store, m, f)
 		if err != nil {
 			return err
 		}
 
+		// Iteration is internal (invoked by automation scripts),
+		// values of all encrypted fields are decrypted
+		if err = decryptRecordValues(svc.keyring, set...); err != nil {
+			return err
+		}
+
 		for _, rec := range set {
 			recordableAction := RecordActionIteratorIteration
 
@@ -1280,7 +1616,12 @@ func (svc record) Iterator(f types.RecordFilter, fn eventbus.HandlerFn, action s
 					}
 
 					return store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) error {
-						return store.CreateComposeRecord(ctx, s, m, rec)
+						enc, err := encryptedRecord(svc.keyring, m, rec)
+						if err != nil {
+							return err
+						}
+
+						return store.CreateComposeRecord(ctx, s, m, enc)
 					})
 				case "update":
 					recordableAction = RecordActionIteratorUpdate
@@ -1291,7 +1632,12 @@ func (svc record) Iterator(f types.RecordFilter, fn eventbus.HandlerFn, action s
 					}
 
 					return store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) error {
-						return store.UpdateComposeRecord(ctx, s, m, rec)
+						enc, err := encryptedRecord(svc.keyring, m, rec)
+						if err != nil {
+							return err
+						}
+
+						return store.UpdateComposeRecord(ctx, s, m, enc)
 					})
 				case "delete":
 					recordableAction = RecordActionIteratorDelete
@@ -1299,7 +1645,13 @@ func (svc record) Iterator(f types.RecordFilter, fn eventbus.HandlerFn, action s
 					return store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) error {
 						rec.DeletedAt = now()
 						rec.DeletedBy = invokerID
-						return store.UpdateComposeRecord(ctx, s, m, rec)
+
+						enc, err := encryptedRecord(svc.keyring, m, rec)
+						if err != nil {
+							return err
+						}
+
+						return store.UpdateComposeRecord(ctx, s, m, enc)
 					})
 				}
 
@@ -1321,6 +1673,75 @@ func (svc record) Iterator(f types.RecordFilter, fn eventbus.HandlerFn, action s
 
 }
 
+// RotateKey re-encrypts values of encrypted fields with the primary encryption key
+//
+// Values that are already encrypted with the primary key are left as they are;
+// number of re-encrypted values is returned
+func (svc record) RotateKey() (rotated int, err error) {
+	var (
+		mm types.ModuleSet
+
+		aProps = &recordActionProps{}
+	)
+
+	err = func() error {
+		if mm, _, err = store.SearchComposeModules(svc.ctx, svc.store, types.ModuleFilter{}); err != nil {
+			return err
+		}
+
+		if err = loadModuleFields(svc.ctx, svc.store, mm...); err != nil {
+			return err
+		}
+
+		for _, m := range mm {
+			var (
+				ff types.ModuleFieldSet
+				n  int
+			)
+
+			for _, f := range m.Fields {
+				if f.IsEncrypted() {
+					ff = append(ff, f)
+				}
+			}
+
+			if len(ff) == 0 {
+				continue
+			}
+
+			aProps.setModule(m)
+
+			if !svc.ac.CanUpdateRecord(svc.ctx, m) {
+				return RecordErrNotAllowedToUpdate()
+			}
+
+			if svc.keyring == nil {
+				return RecordErrEncryptionKeyMissing()
+			}
+
+			err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+				n, err = recryptRecordValues(ctx, s, svc.keyring, m, ff)
+				return
+			})
+
+			if err != nil {
+				return err
+			}
+
+			rotated += n
+			_ = svc.recordAction(svc.ctx, aProps.setValue(strconv.Itoa(n)), RecordActionRotateKey, nil)
+		}
+
+		return nil
+	}()
+
+	if err != nil {
+		return rotated, svc.recordAction(svc.ctx, aProps, RecordActionRotateKey, err)
+	}
+
+	return rotated, nil
+}
+
 func (svc record) setDefaultValues(m *types.Module, vv types.RecordValueSet) (out types.RecordValueSet) {
 	out = vv
 
@@ -1399,13 +1820,15 @@ func (svc record) generalValueSetValidation(m *types.Module, vv types.RecordValu
 }
 
 // checks record-value-read access permissions for all module fields and removes unreadable fields from all records
+//
+// Values of encrypted fields are removed as well unless user can decrypt them
 func trimUnreadableRecordFields(ctx context.Context, ac recordValueAccessController, m *types.Module, rr ...*types.Record) {
 	var (
 		readableFields = map[string]bool{}
 	)
 
 	for _, f := range m.Fields {
-		readableFields[f.Name] = ac.CanReadRecordValue(ctx, f)
+		readableFields[f.Name] = ac.CanReadRecordValue(ctx, f) && (!f.IsEncrypted() || ac.CanDecryptRecordValue(ctx, f))
 	}
 
 	for _, r := range rr {
@@ -28,7 +28,9 @@ type (
 		bulkOperation string
 		field         string
 		value         string
+		details       string
 		valueErrors   *types.RecordValueErrorSet
+		changeRequest *types.RecordChangeRequest
 	}
 
 	recordAction struct {
@@ -144,6 +146,17 @@ func (p *recordActionProps) setValue(value string) *recordActionProps {
 	return p
 }
 
+// setDetails updates recordActionProps's details
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *recordActionProps) setDetails(details string) *recordActionProps {
+	p.details = details
+	return p
+}
+
 // setValueErrors updates recordActionProps's valueErrors
 //
 // Allows method chaining
@@ -155,6 +168,17 @@ func (p *recordActionProps) setValueErrors(valueErrors *types.RecordValueErrorSe
 	return p
 }
 
+// setChangeRequest updates recordActionProps's changeRequest
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *recordActionProps) setChangeRequest(changeRequest *types.RecordChangeRequest) *recordActionProps {
+	p.changeRequest = changeRequest
+	return p
+}
+
 // Serialize converts recordActionProps to actionlog.Meta
 //
 // This function is auto-generated.
@@ -198,9 +222,14 @@ func (p recordActionProps) Serialize() actionlog.Meta {
 	m.Set("bulkOperation", p.bulkOperation, true)
 	m.Set("field", p.field, true)
 	m.Set("value", p.value, true)
+	m.Set("details", p.details, true)
 	if p.valueErrors != nil {
 		m.Set("valueErrors.set", p.valueErrors.Set, true)
 	}
+	if p.changeRequest != nil {
+		m.Set("changeRequest.ID", p.changeRequest.ID, true)
+		m.Set("changeRequest.status", p.changeRequest.Status, true)
+	}
 
 	return m
 }
@@ -324,6 +353,7 @@ func (p recordActionProps) Format(in string, err error) string {
 	pairs = append(pairs, "{bulkOperation}", fns(p.bulkOperation))
 	pairs = append(pairs, "{field}", fns(p.field))
 	pairs = append(pairs, "{value}", fns(p.value))
+	pairs = append(pairs, "{details}", fns(p.details))
 
 	if p.valueErrors != nil {
 		// replacement for "{valueErrors}" (in order how fields are defined)
@@ -336,6 +366,20 @@ func (p recordActionProps) Format(in string, err error) string {
 		)
 		pairs = append(pairs, "{valueErrors.set}", fns(p.valueErrors.Set))
 	}
+
+	if p.changeRequest != nil {
+		// replacement for "{changeRequest}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{changeRequest}",
+			fns(
+				p.changeRequest.ID,
+				p.changeRequest.Status,
+			),
+		)
+		pairs = append(pairs, "{changeRequest.ID}", fns(p.changeRequest.ID))
+		pairs = append(pairs, "{changeRequest.status}", fns(p.changeRequest.Status))
+	}
 	return strings.NewReplacer(pairs...).Replace(in)
 }
 
@@ -591,6 +635,26 @@ func RecordActionOrganize(props ...*recordActionProps) *recordAction {
 	return a
 }
 
+// RecordActionTransition returns "compose:record.transition" action
+//
+// This function is auto-generated.
+//
+func RecordActionTransition(props ...*recordActionProps) *recordAction {
+	a := &recordAction{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		action:    "transition",
+		log:       "changed state of {record} to {value}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
 // RecordActionIteratorInvoked returns "compose:record.iteratorInvoked" action
 //
 // This function is auto-generated.
@@ -691,6 +755,26 @@ func RecordActionIteratorDelete(props ...*recordActionProps) *recordAction {
 	return a
 }
 
+// RecordActionRotateKey returns "compose:record.rotateKey" action
+//
+// This function is auto-generated.
+//
+func RecordActionRotateKey(props ...*recordActionProps) *recordAction {
+	a := &recordAction{
+		timestamp: time.Now(),
+		resource:  "compose:record",
+		action:    "rotateKey",
+		log:       "re-encrypted {value} value(s) of encrypted fields of {module}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
 // *********************************************************************************************************************
 // *********************************************************************************************************************
 // Error constructors
@@ -1377,6 +1461,100 @@ func RecordErrInvalidReferenceFormat(mm ...*recordActionProps) *errors.Error {
 	return e
 }
 
+// RecordErrInvalidExportDefinition returns "compose:record.invalidExportDefinition" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordErrInvalidExportDefinition(mm ...*recordActionProps) *errors.Error {
+	var p = &recordActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid export definition: {details}", nil),
+
+		errors.Meta("type", "invalidExportDefinition"),
+		errors.Meta("resource", "compose:record"),
+
+		errors.Meta(recordPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordErrApprovalRequired returns "compose:record.approvalRequired" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordErrApprovalRequired(mm ...*recordActionProps) *errors.Error {
+	var p = &recordActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("change is waiting for approval", nil),
+
+		errors.Meta("type", "approvalRequired"),
+		errors.Meta("resource", "compose:record"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(recordLogMetaKey{}, "change of {record} is waiting for approval ({changeRequest})"),
+		errors.Meta(recordPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordErrPendingChangeRequest returns "compose:record.pendingChangeRequest" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordErrPendingChangeRequest(mm ...*recordActionProps) *errors.Error {
+	var p = &recordActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("record has a pending change request", nil),
+
+		errors.Meta("type", "pendingChangeRequest"),
+		errors.Meta("resource", "compose:record"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(recordLogMetaKey{}, "failed to change {record}; pending change request"),
+		errors.Meta(recordPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
 // RecordErrValueInput returns "compose:record.valueInput" as *errors.Error
 //
 //
@@ -1407,6 +1585,68 @@ func RecordErrValueInput(mm ...*recordActionProps) *errors.Error {
 	return e
 }
 
+// RecordErrEncryptionKeyMissing returns "compose:record.encryptionKeyMissing" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordErrEncryptionKeyMissing(mm ...*recordActionProps) *errors.Error {
+	var p = &recordActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("can not encrypt or decrypt values; encryption key is not configured", nil),
+
+		errors.Meta("type", "encryptionKeyMissing"),
+		errors.Meta("resource", "compose:record"),
+
+		errors.Meta(recordPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordErrDecryptionFailed returns "compose:record.decryptionFailed" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordErrDecryptionFailed(mm ...*recordActionProps) *errors.Error {
+	var p = &recordActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("could not decrypt value of field {field}", nil),
+
+		errors.Meta("type", "decryptionFailed"),
+		errors.Meta("resource", "compose:record"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(recordLogMetaKey{}, "could not decrypt value of field {field}: {details}"),
+		errors.Meta(recordPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
 // *********************************************************************************************************************
 // *********************************************************************************************************************
 
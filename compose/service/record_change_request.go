@@ -0,0 +1,752 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/expr"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/pkg/label"
+	"github.com/cortezaproject/corteza-server/store"
+	systemService "github.com/cortezaproject/corteza-server/system/service"
+	systemTypes "github.com/cortezaproject/corteza-server/system/types"
+)
+
+type (
+	recordChangeRequest struct {
+		ctx       context.Context
+		actionlog actionlog.Recorder
+		ac        recordChangeRequestAccessController
+		store     store.Storer
+
+		// record service that applies approved changes
+		records *record
+
+		notifier  recordChangeRequestNotifier
+		templates notificationTemplateRenderer
+	}
+
+	recordChangeRequestAccessController interface {
+		CanReadRecord(context.Context, *types.Module) bool
+		CanDecryptRecordValue(context.Context, *types.ModuleField) bool
+	}
+
+	recordChangeRequestNotifier interface {
+		SendEmail(ctx context.Context, n *types.EmailNotification) error
+	}
+
+	// RecordChangeRequestService handles record changes that are waiting for approval
+	//
+	// Change requests are created by the record service when record update
+	// or delete requires an approval; approved changes are applied with
+	// record update (or delete).
+	RecordChangeRequestService interface {
+		With(ctx context.Context) RecordChangeRequestService
+		WithStore(s store.Storer) RecordChangeRequestService
+
+		Find(filter types.RecordChangeRequestFilter) (types.RecordChangeRequestSet, types.RecordChangeRequestFilter, error)
+		FindByID(namespaceID, changeRequestID uint64) (*types.RecordChangeRequest, error)
+
+		Request(m *types.Module, upd, old *types.Record) (*types.RecordChangeRequest, error)
+		RequestDelete(m *types.Module, del *types.Record) (*types.RecordChangeRequest, error)
+		Approve(namespaceID, changeRequestID uint64, comment string) (*types.RecordChangeRequest, error)
+		Reject(namespaceID, changeRequestID uint64, comment string) (*types.RecordChangeRequest, error)
+		Cancel(namespaceID, changeRequestID uint64) (*types.RecordChangeRequest, error)
+	}
+)
+
+const (
+	// Handles of the stored templates used for change request notifications
+	//
+	// Simple plain-text notification is sent when there is no stored template
+	RecordChangeRequestTemplateApproval = "compose_record_change_request_approval"
+	RecordChangeRequestTemplateDecision = "compose_record_change_request_decision"
+)
+
+func RecordChangeRequest() RecordChangeRequestService {
+	return (&recordChangeRequest{
+		ac: DefaultAccessControl,
+		records: &record{
+			ac:              DefaultAccessControl,
+			eventbus:        eventbus.Service(),
+			optEmitEvents:   true,
+			optSkipApproval: true,
+			store:           DefaultStore,
+			keyring:         DefaultKeyring,
+		},
+		notifier:  DefaultNotification,
+		templates: systemService.DefaultTemplate,
+	}).With(context.Background())
+}
+
+func (svc recordChangeRequest) With(ctx context.Context) RecordChangeRequestService {
+	return &recordChangeRequest{
+		ctx:       ctx,
+		actionlog: DefaultActionlog,
+		ac:        svc.ac,
+		store:     DefaultStore,
+		records:   svc.records,
+		notifier:  svc.notifier,
+		templates: svc.templates,
+	}
+}
+
+// WithStore returns copy of the service that uses the given store
+//
+// Used to store change requests in the transaction of the caller
+func (svc recordChangeRequest) WithStore(s store.Storer) RecordChangeRequestService {
+	svc.store = s
+	return &svc
+}
+
+// Find returns change requests that the current user can see, latest first
+//
+// Requests are visible to users that can read module's records,
+// to the user that requested the change and to the approvers
+func (svc recordChangeRequest) Find(f types.RecordChangeRequestFilter) (set types.RecordChangeRequestSet, _ types.RecordChangeRequestFilter, err error) {
+	var (
+		aProps  = &recordChangeRequestActionProps{}
+		modules = make(map[uint64]*types.Module)
+	)
+
+	err = func() error {
+		if _, err = loadNamespace(svc.ctx, svc.store, f.NamespaceID); err != nil {
+			return err
+		}
+
+		if f.Approvable {
+			f.Status = types.RecordChangeRequestPending
+		}
+
+		if len(f.Sort) == 0 {
+			f.Sort = filter.SortExprSet{&filter.SortExpr{Column: "id", Descending: true}}
+		}
+
+		f.Check = func(cr *types.RecordChangeRequest) (bool, error) {
+			m, ok := modules[cr.ModuleID]
+			if !ok {
+				var lErr error
+				if m, lErr = store.LookupComposeModuleByID(svc.ctx, svc.store, cr.ModuleID); errors.IsNotFound(lErr) {
+					m = nil
+				} else if lErr != nil {
+					return false, lErr
+				} else if lErr = loadModuleFields(svc.ctx, svc.store, m); lErr != nil {
+					return false, lErr
+				}
+
+				modules[cr.ModuleID] = m
+			}
+
+			if m == nil || !svc.canRead(m, cr) {
+				return false, nil
+			}
+
+			if f.Approvable && !svc.canDecide(m, cr) {
+				return false, nil
+			}
+
+			return true, svc.decrypt(m, cr)
+		}
+
+		set, f, err = store.SearchComposeRecordChangeRequests(svc.ctx, svc.store, f)
+		return err
+	}()
+
+	return set, f, svc.recordAction(svc.ctx, aProps, RecordChangeRequestActionSearch, err)
+}
+
+func (svc recordChangeRequest) FindByID(namespaceID, changeRequestID uint64) (cr *types.RecordChangeRequest, err error) {
+	var (
+		aProps = &recordChangeRequestActionProps{changeRequest: &types.RecordChangeRequest{ID: changeRequestID}}
+	)
+
+	err = func() error {
+		var m *types.Module
+		if cr, m, err = svc.load(svc.ctx, svc.store, aProps, namespaceID, changeRequestID); err != nil {
+			return err
+		}
+
+		return svc.decrypt(m, cr)
+	}()
+
+	return cr, svc.recordAction(svc.ctx, aProps, RecordChangeRequestActionLookup, err)
+}
+
+// Request creates change request when update of the record requires an approval
+//
+// Nil is returned when approval is not required: module has no approval steps,
+// values were not changed or the approval condition is not met.
+//
+// Record update is expected to be fully processed (merged & validated)
+func (svc recordChangeRequest) Request(m *types.Module, upd, old *types.Record) (cr *types.RecordChangeRequest, err error) {
+	var (
+		change = makeRecordChange(upd, old)
+	)
+
+	if !m.Approval.IsEnabled() || len(change.Fields) == 0 {
+		return nil, nil
+	}
+
+	return svc.request(m, change, upd, old)
+}
+
+// RequestDelete creates change request when delete of the record requires an approval
+//
+// Approval condition is evaluated against the record that is about to be deleted.
+// Nil is returned when approval is not required.
+func (svc recordChangeRequest) RequestDelete(m *types.Module, del *types.Record) (cr *types.RecordChangeRequest, err error) {
+	if !m.Approval.IsEnabled() {
+		return nil, nil
+	}
+
+	return svc.request(m, types.RecordChange{
+		Fields:    []string{},
+		Values:    types.RecordValueSet{},
+		OldValues: types.RecordValueSet{},
+		Delete:    true,
+	}, del, del)
+}
+
+// request stores the change request when the approval condition is met
+func (svc recordChangeRequest) request(m *types.Module, change types.RecordChange, upd, old *types.Record) (cr *types.RecordChangeRequest, err error) {
+	var (
+		aProps = &recordChangeRequestActionProps{module: m}
+	)
+
+	if m.Approval.Condition != "" {
+		if ok, err := evalRecordCondition(svc.ctx, m.Approval.Condition, m, upd, old); err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, nil
+		}
+	}
+
+	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+		var (
+			pending types.RecordChangeRequestSet
+		)
+
+		pending, _, err = store.SearchComposeRecordChangeRequests(ctx, s, types.RecordChangeRequestFilter{
+			RecordID: old.ID,
+			Status:   types.RecordChangeRequestPending,
+			Paging:   filter.Paging{Limit: 1},
+		})
+
+		if err != nil {
+			return
+		} else if len(pending) > 0 {
+			return RecordErrPendingChangeRequest((&recordActionProps{}).setRecord(old))
+		}
+
+		cr = &types.RecordChangeRequest{
+			ID:          nextID(),
+			NamespaceID: old.NamespaceID,
+			ModuleID:    old.ModuleID,
+			RecordID:    old.ID,
+			Status:      types.RecordChangeRequestPending,
+			Change:      change,
+			Decisions:   types.RecordChangeDecisionSet{},
+			CreatedAt:   *now(),
+			CreatedBy:   auth.GetIdentityFromContext(ctx).Identity(),
+		}
+
+		// proposed and old values of encrypted fields
+		// are stored encrypted, same as record values
+		if cr.Change.Values, err = encryptRecordValues(svc.records.keyring, m, change.Values); err != nil {
+			return
+		}
+
+		if cr.Change.OldValues, err = encryptRecordValues(svc.records.keyring, m, change.OldValues); err != nil {
+			return
+		}
+
+		aProps.setChangeRequest(cr)
+		return store.CreateComposeRecordChangeRequest(ctx, s, cr)
+	})
+
+	if err = svc.recordAction(svc.ctx, aProps, RecordChangeRequestActionRequest, err); err != nil {
+		return nil, err
+	}
+
+	svc.notifyApprovers(m, cr)
+	return cr, nil
+}
+
+// Approve records approval of the current user on the current step
+//
+// When the last step is complete, change is applied to the record with the
+// permissions of the approving user; change request is left pending when
+// change can not be applied and marked as conflicted when the record was
+// changed after the request.
+func (svc recordChangeRequest) Approve(namespaceID, changeRequestID uint64, comment string) (cr *types.RecordChangeRequest, err error) {
+	var (
+		aProps = &recordChangeRequestActionProps{changeRequest: &types.RecordChangeRequest{ID: changeRequestID}}
+		m      *types.Module
+		step   int
+	)
+
+	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+		var prev *types.RecordChangeRequest
+		if cr, prev, m, err = svc.decide(ctx, s, aProps, namespaceID, changeRequestID, true, comment); err != nil {
+			return err
+		}
+
+		if step = cr.Step; m.Approval.Step(step).IsComplete(cr.Decisions.FilterByStep(step)) {
+			cr.Step++
+		}
+
+		if cr.Step >= len(m.Approval.Steps) {
+			if err = svc.apply(ctx, s, m, cr); err != nil {
+				return err
+			}
+		}
+
+		return svc.save(ctx, s, cr, prev)
+	})
+
+	if err = svc.recordAction(svc.ctx, aProps, RecordChangeRequestActionApprove, err); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case cr.Status == types.RecordChangeRequestApproved:
+		_ = svc.recordAction(svc.ctx, aProps, RecordChangeRequestActionApply, nil)
+		svc.notifyRequester(m, cr)
+	case cr.Status == types.RecordChangeRequestConflicted:
+		_ = svc.recordAction(svc.ctx, aProps, RecordChangeRequestActionConflict, nil)
+		svc.notifyRequester(m, cr)
+	case cr.Step > step:
+		svc.notifyApprovers(m, cr)
+	}
+
+	return cr, nil
+}
+
+// Reject records rejection of the current user; rejected change is not applied
+func (svc recordChangeRequest) Reject(namespaceID, changeRequestID uint64, comment string) (cr *types.RecordChangeRequest, err error) {
+	var (
+		aProps = &recordChangeRequestActionProps{changeRequest: &types.RecordChangeRequest{ID: changeRequestID}}
+		m      *types.Module
+	)
+
+	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+		var prev *types.RecordChangeRequest
+		if cr, prev, m, err = svc.decide(ctx, s, aProps, namespaceID, changeRequestID, false, comment); err != nil {
+			return err
+		}
+
+		svc.complete(cr, types.RecordChangeRequestRejected)
+		return svc.save(ctx, s, cr, prev)
+	})
+
+	if err = svc.recordAction(svc.ctx, aProps, RecordChangeRequestActionReject, err); err != nil {
+		return nil, err
+	}
+
+	svc.notifyRequester(m, cr)
+	return cr, nil
+}
+
+// Cancel cancels pending change request; only user that requested the change can cancel it
+func (svc recordChangeRequest) Cancel(namespaceID, changeRequestID uint64) (cr *types.RecordChangeRequest, err error) {
+	var (
+		aProps = &recordChangeRequestActionProps{changeRequest: &types.RecordChangeRequest{ID: changeRequestID}}
+	)
+
+	err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+		if cr, _, err = svc.load(ctx, s, aProps, namespaceID, changeRequestID); err != nil {
+			return err
+		}
+
+		if cr.CreatedBy != auth.GetIdentityFromContext(svc.ctx).Identity() {
+			return RecordChangeRequestErrNotAllowedToCancel()
+		}
+
+		if !cr.IsPending() {
+			return RecordChangeRequestErrNotPending()
+		}
+
+		prev := *cr
+		svc.complete(cr, types.RecordChangeRequestCanceled)
+		cr.UpdatedAt = cr.CompletedAt
+		return svc.save(ctx, s, cr, &prev)
+	})
+
+	return cr, svc.recordAction(svc.ctx, aProps, RecordChangeRequestActionCancel, err)
+}
+
+// decide loads pending change request and adds decision of the current user on the current step
+//
+// Change request as it was loaded is returned as well (prev) so that
+// the update can be guarded against concurrent decisions
+func (svc recordChangeRequest) decide(ctx context.Context, s store.Storer, aProps *recordChangeRequestActionProps, namespaceID, changeRequestID uint64, approved bool, comment string) (cr, prev *types.RecordChangeRequest, m *types.Module, err error) {
+	var (
+		i = auth.GetIdentityFromContext(svc.ctx)
+	)
+
+	if cr, m, err = svc.load(ctx, s, aProps, namespaceID, changeRequestID); err != nil {
+		return
+	}
+
+	if !cr.IsPending() {
+		return nil, nil, nil, RecordChangeRequestErrNotPending()
+	}
+
+	if cr.Decisions.FilterByStep(cr.Step).HasUser(i.Identity()) {
+		return nil, nil, nil, RecordChangeRequestErrAlreadyDecided()
+	}
+
+	if !svc.canDecide(m, cr) {
+		return nil, nil, nil, RecordChangeRequestErrNotAllowedToApprove()
+	}
+
+	prev = &types.RecordChangeRequest{ID: cr.ID, Status: cr.Status, UpdatedAt: cr.UpdatedAt}
+
+	cr.Decisions = append(cr.Decisions, &types.RecordChangeDecision{
+		Step:      cr.Step,
+		UserID:    i.Identity(),
+		Approved:  approved,
+		Comment:   comment,
+		Roles:     m.Approval.Step(cr.Step).ApproverRoles(i.Roles()),
+		CreatedAt: *now(),
+	})
+
+	cr.UpdatedAt = now()
+	return
+}
+
+// save updates change request only if it was not changed since it was loaded (prev)
+func (svc recordChangeRequest) save(ctx context.Context, s store.Storer, cr, prev *types.RecordChangeRequest) error {
+	if ok, err := store.CompareAndSwapComposeRecordChangeRequest(ctx, s, cr, prev); err != nil {
+		return err
+	} else if !ok {
+		return RecordChangeRequestErrChangedMeanwhile()
+	}
+
+	return nil
+}
+
+func (svc recordChangeRequest) complete(cr *types.RecordChangeRequest, status types.RecordChangeRequestStatus) {
+	cr.Status = status
+	cr.CompletedAt = now()
+	cr.CompletedBy = auth.GetIdentityFromContext(svc.ctx).Identity()
+}
+
+// apply updates (or deletes) the record and completes the change request
+//
+// Change is not applied when current values of the changed fields
+// differ from the values at the time of the request; change request
+// is marked as conflicted instead
+func (svc recordChangeRequest) apply(ctx context.Context, s store.Storer, m *types.Module, cr *types.RecordChangeRequest) (err error) {
+	var (
+		r *types.Record
+
+		// change with decrypted old values
+		change = cr.Change
+	)
+
+	change.OldValues = cr.Change.OldValues.Clone()
+
+	if r, err = store.LookupComposeRecordByID(ctx, s, m, cr.RecordID); errors.IsNotFound(err) {
+		return RecordErrNotFound()
+	} else if err != nil {
+		return err
+	}
+
+	if err = decryptRecordValues(svc.records.keyring, r, &types.Record{Values: change.OldValues}); err != nil {
+		return err
+	}
+
+	if change.Conflicts(r.Values) {
+		svc.complete(cr, types.RecordChangeRequestConflicted)
+		return nil
+	}
+
+	if cr.Change.Delete {
+		if err = svc.records.With(svc.ctx).WithStore(s).DeleteByID(r.NamespaceID, r.ModuleID, r.ID); err != nil {
+			return err
+		}
+
+		svc.complete(cr, types.RecordChangeRequestApproved)
+		return nil
+	}
+
+	if err = label.Load(ctx, s, r); err != nil {
+		return err
+	}
+
+	upd := &types.Record{
+		ID:          r.ID,
+		ModuleID:    r.ModuleID,
+		NamespaceID: r.NamespaceID,
+		OwnedBy:     r.OwnedBy,
+		Labels:      r.Labels,
+		Values:      cr.Change.Apply(r.Values),
+	}
+
+	if err = decryptRecordValues(svc.records.keyring, upd); err != nil {
+		return err
+	}
+
+	if _, err = svc.records.With(svc.ctx).WithStore(s).Update(upd); err != nil {
+		return err
+	}
+
+	svc.complete(cr, types.RecordChangeRequestApproved)
+	return nil
+}
+
+// decrypt decrypts proposed and old values of encrypted fields
+//
+// Values of encrypted fields that the current user can not decrypt are removed
+func (svc recordChangeRequest) decrypt(m *types.Module, cr *types.RecordChangeRequest) error {
+	var (
+		decryptable = func(v *types.RecordValue) (bool, error) {
+			f := m.Fields.FindByName(v.Name)
+			return f == nil || !f.IsEncrypted() || svc.ac.CanDecryptRecordValue(svc.ctx, f), nil
+		}
+	)
+
+	cr.Change.Values, _ = cr.Change.Values.Filter(decryptable)
+	cr.Change.OldValues, _ = cr.Change.OldValues.Filter(decryptable)
+
+	return decryptRecordValues(
+		svc.records.keyring,
+		&types.Record{Values: cr.Change.Values},
+		&types.Record{Values: cr.Change.OldValues},
+	)
+}
+
+func (svc recordChangeRequest) load(ctx context.Context, s store.Storer, aProps *recordChangeRequestActionProps, namespaceID, changeRequestID uint64) (cr *types.RecordChangeRequest, m *types.Module, err error) {
+	if changeRequestID == 0 {
+		return nil, nil, RecordChangeRequestErrInvalidID()
+	}
+
+	if cr, err = store.LookupComposeRecordChangeRequestByID(ctx, s, changeRequestID); errors.IsNotFound(err) {
+		return nil, nil, RecordChangeRequestErrNotFound()
+	} else if err != nil {
+		return
+	}
+
+	if cr.NamespaceID != namespaceID {
+		return nil, nil, RecordChangeRequestErrNotFound()
+	}
+
+	aProps.setChangeRequest(cr)
+
+	if _, m, err = loadModuleWithNamespace(ctx, s, cr.NamespaceID, cr.ModuleID); err != nil {
+		return
+	}
+
+	aProps.setModule(m)
+
+	if !svc.canRead(m, cr) {
+		return nil, nil, RecordChangeRequestErrNotAllowedToRead()
+	}
+
+	return
+}
+
+// canRead checks if current user can read records of the module or is involved in the change request
+func (svc recordChangeRequest) canRead(m *types.Module, cr *types.RecordChangeRequest) bool {
+	var (
+		i = auth.GetIdentityFromContext(svc.ctx)
+	)
+
+	if cr.CreatedBy == i.Identity() || svc.ac.CanReadRecord(svc.ctx, m) {
+		return true
+	}
+
+	if m.Approval.IsEnabled() {
+		for _, s := range m.Approval.Steps {
+			if s.IsApprover(i.Identity(), i.Roles()) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// canDecide checks if current user can approve or reject the change request on the current step
+//
+// User that requested the change can not approve it
+func (svc recordChangeRequest) canDecide(m *types.Module, cr *types.RecordChangeRequest) bool {
+	var (
+		i = auth.GetIdentityFromContext(svc.ctx)
+	)
+
+	if !cr.IsPending() || !m.Approval.IsEnabled() || cr.CreatedBy == i.Identity() {
+		return false
+	}
+
+	s := m.Approval.Step(cr.Step)
+	return s != nil && s.IsApprover(i.Identity(), i.Roles()) && !cr.Decisions.FilterByStep(cr.Step).HasUser(i.Identity())
+}
+
+// notifyApprovers sends notification to approvers of the current step
+func (svc recordChangeRequest) notifyApprovers(m *types.Module, cr *types.RecordChangeRequest) {
+	var (
+		s    = m.Approval.Step(cr.Step)
+		seen = map[uint64]bool{cr.CreatedBy: true}
+	)
+
+	if s == nil {
+		return
+	}
+
+	userIDs := append([]uint64{}, s.Users...)
+	for _, roleID := range s.Roles {
+		mm, _, err := store.SearchRoleMembers(svc.ctx, svc.store, systemTypes.RoleMemberFilter{RoleID: roleID})
+		if err != nil {
+			return
+		}
+
+		for _, rm := range mm {
+			userIDs = append(userIDs, rm.UserID)
+		}
+	}
+
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+
+		seen[userID] = true
+		svc.notify(userID, RecordChangeRequestTemplateApproval, m, cr,
+			fmt.Sprintf("Change of %s record is waiting for your approval", moduleLabel(m)),
+			fmt.Sprintf(
+				"Change of record %d (%s) is waiting for your approval.",
+				cr.RecordID,
+				changedFields(cr),
+			),
+		)
+	}
+}
+
+// notifyRequester sends notification about the final decision to the user that requested the change
+func (svc recordChangeRequest) notifyRequester(m *types.Module, cr *types.RecordChangeRequest) {
+	svc.notify(cr.CreatedBy, RecordChangeRequestTemplateDecision, m, cr,
+		fmt.Sprintf("Change of %s record was %s", moduleLabel(m), cr.Status),
+		fmt.Sprintf(
+			"Change of record %d (%s) was %s.",
+			cr.RecordID,
+			changedFields(cr),
+			cr.Status,
+		),
+	)
+}
+
+// notify sends email notification to the user
+//
+// Notification is rendered from the stored template (in user's preferred language)
+// or from the given subject and content when template can not be rendered.
+// Errors are not returned, failed notification is recorded by the notification service
+func (svc recordChangeRequest) notify(userID uint64, handle string, m *types.Module, cr *types.RecordChangeRequest, subject, content string) {
+	if svc.notifier == nil {
+		return
+	}
+
+	u, err := store.LookupUserByID(svc.ctx, svc.store, userID)
+	if err != nil || u.Email == "" {
+		return
+	}
+
+	var (
+		n = &types.EmailNotification{
+			To:           []string{strings.TrimSpace(u.Email + " " + u.Name)},
+			Subject:      subject,
+			ContentPlain: content,
+		}
+
+		vars = map[string]interface{}{
+			"changeRequest": cr,
+			"module":        m,
+			"user":          u,
+		}
+	)
+
+	if svc.templates != nil {
+		if out, err := svc.templates.Render(svc.ctx, handle, u.PreferredLanguage(), vars); err == nil {
+			n.Subject, n.ContentPlain = out.Subject, ""
+
+			if out.Type == systemTypes.TemplateTypeHTML {
+				n.ContentHTML = out.Content
+			} else {
+				n.ContentPlain = out.Content
+			}
+		}
+	}
+
+	_ = svc.notifier.SendEmail(svc.ctx, n)
+}
+
+// validateModuleApproval checks approval definition
+func validateModuleApproval(a *types.ModuleApproval) error {
+	if a == nil {
+		return nil
+	}
+
+	for i, s := range a.Steps {
+		if len(s.Roles) == 0 && len(s.Users) == 0 {
+			return fmt.Errorf("step %d has no approvers", i+1)
+		}
+	}
+
+	if a.Condition != "" {
+		if !a.IsEnabled() {
+			return fmt.Errorf("condition without approval steps")
+		}
+
+		if _, err := expr.Parser().NewEvaluable(a.Condition); err != nil {
+			return fmt.Errorf("invalid condition expression %q: %v", a.Condition, err)
+		}
+	}
+
+	return nil
+}
+
+// makeRecordChange collects updated values from the merged record values
+func makeRecordChange(upd, old *types.Record) (c types.RecordChange) {
+	c = types.RecordChange{
+		Fields:    []string{},
+		Values:    types.RecordValueSet{},
+		OldValues: types.RecordValueSet{},
+	}
+
+	for _, v := range upd.Values {
+		if v.IsUpdated() && !c.HasField(v.Name) {
+			c.Fields = append(c.Fields, v.Name)
+		}
+	}
+
+	for _, name := range c.Fields {
+		c.Values = append(c.Values, upd.Values.FilterByName(name).GetClean()...)
+		c.OldValues = append(c.OldValues, old.Values.FilterByName(name).GetClean()...)
+	}
+
+	return
+}
+
+// changedFields returns comma separated names of the changed fields or "delete" for deletes
+func changedFields(cr *types.RecordChangeRequest) string {
+	if cr.Change.Delete {
+		return "delete"
+	}
+
+	return strings.Join(cr.Change.Fields, ", ")
+}
+
+func moduleLabel(m *types.Module) string {
+	if m.Name != "" {
+		return m.Name
+	}
+
+	return m.Handle
+}
@@ -0,0 +1,729 @@
+package service
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+// compose/service/record_change_request_actions.yaml
+
+import (
+	"context"
+	"fmt"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"strings"
+	"time"
+)
+
+type (
+	recordChangeRequestActionProps struct {
+		changeRequest *types.RecordChangeRequest
+		module        *types.Module
+		namespace     *types.Namespace
+		details       string
+	}
+
+	recordChangeRequestAction struct {
+		timestamp time.Time
+		resource  string
+		action    string
+		log       string
+		severity  actionlog.Severity
+
+		// prefix for error when action fails
+		errorMessage string
+
+		props *recordChangeRequestActionProps
+	}
+
+	recordChangeRequestLogMetaKey   struct{}
+	recordChangeRequestPropsMetaKey struct{}
+)
+
+var (
+	// just a placeholder to cover template cases w/o fmt package use
+	_ = fmt.Println
+)
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Props methods
+// setChangeRequest updates recordChangeRequestActionProps's changeRequest
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *recordChangeRequestActionProps) setChangeRequest(changeRequest *types.RecordChangeRequest) *recordChangeRequestActionProps {
+	p.changeRequest = changeRequest
+	return p
+}
+
+// setModule updates recordChangeRequestActionProps's module
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *recordChangeRequestActionProps) setModule(module *types.Module) *recordChangeRequestActionProps {
+	p.module = module
+	return p
+}
+
+// setNamespace updates recordChangeRequestActionProps's namespace
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *recordChangeRequestActionProps) setNamespace(namespace *types.Namespace) *recordChangeRequestActionProps {
+	p.namespace = namespace
+	return p
+}
+
+// setDetails updates recordChangeRequestActionProps's details
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *recordChangeRequestActionProps) setDetails(details string) *recordChangeRequestActionProps {
+	p.details = details
+	return p
+}
+
+// Serialize converts recordChangeRequestActionProps to actionlog.Meta
+//
+// This function is auto-generated.
+//
+func (p recordChangeRequestActionProps) Serialize() actionlog.Meta {
+	var (
+		m = make(actionlog.Meta)
+	)
+
+	if p.changeRequest != nil {
+		m.Set("changeRequest.ID", p.changeRequest.ID, true)
+		m.Set("changeRequest.recordID", p.changeRequest.RecordID, true)
+		m.Set("changeRequest.moduleID", p.changeRequest.ModuleID, true)
+		m.Set("changeRequest.status", p.changeRequest.Status, true)
+		m.Set("changeRequest.step", p.changeRequest.Step, true)
+	}
+	if p.module != nil {
+		m.Set("module.name", p.module.Name, true)
+		m.Set("module.handle", p.module.Handle, true)
+		m.Set("module.ID", p.module.ID, true)
+		m.Set("module.namespaceID", p.module.NamespaceID, true)
+	}
+	if p.namespace != nil {
+		m.Set("namespace.name", p.namespace.Name, true)
+		m.Set("namespace.slug", p.namespace.Slug, true)
+		m.Set("namespace.ID", p.namespace.ID, true)
+	}
+	m.Set("details", p.details, true)
+
+	return m
+}
+
+// tr translates string and replaces meta value placeholder with values
+//
+// This function is auto-generated.
+//
+func (p recordChangeRequestActionProps) Format(in string, err error) string {
+	var (
+		pairs = []string{"{err}"}
+		// first non-empty string
+		fns = func(ii ...interface{}) string {
+			for _, i := range ii {
+				if s := fmt.Sprintf("%v", i); len(s) > 0 {
+					return s
+				}
+			}
+
+			return ""
+		}
+	)
+
+	if err != nil {
+		pairs = append(pairs, err.Error())
+	} else {
+		pairs = append(pairs, "nil")
+	}
+
+	if p.changeRequest != nil {
+		// replacement for "{changeRequest}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{changeRequest}",
+			fns(
+				p.changeRequest.ID,
+				p.changeRequest.RecordID,
+				p.changeRequest.ModuleID,
+				p.changeRequest.Status,
+				p.changeRequest.Step,
+			),
+		)
+		pairs = append(pairs, "{changeRequest.ID}", fns(p.changeRequest.ID))
+		pairs = append(pairs, "{changeRequest.recordID}", fns(p.changeRequest.RecordID))
+		pairs = append(pairs, "{changeRequest.moduleID}", fns(p.changeRequest.ModuleID))
+		pairs = append(pairs, "{changeRequest.status}", fns(p.changeRequest.Status))
+		pairs = append(pairs, "{changeRequest.step}", fns(p.changeRequest.Step))
+	}
+
+	if p.module != nil {
+		// replacement for "{module}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{module}",
+			fns(
+				p.module.Name,
+				p.module.Handle,
+				p.module.ID,
+				p.module.NamespaceID,
+			),
+		)
+		pairs = append(pairs, "{module.name}", fns(p.module.Name))
+		pairs = append(pairs, "{module.handle}", fns(p.module.Handle))
+		pairs = append(pairs, "{module.ID}", fns(p.module.ID))
+		pairs = append(pairs, "{module.namespaceID}", fns(p.module.NamespaceID))
+	}
+
+	if p.namespace != nil {
+		// replacement for "{namespace}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{namespace}",
+			fns(
+				p.namespace.Name,
+				p.namespace.Slug,
+				p.namespace.ID,
+			),
+		)
+		pairs = append(pairs, "{namespace.name}", fns(p.namespace.Name))
+		pairs = append(pairs, "{namespace.slug}", fns(p.namespace.Slug))
+		pairs = append(pairs, "{namespace.ID}", fns(p.namespace.ID))
+	}
+	pairs = append(pairs, "{details}", fns(p.details))
+	return strings.NewReplacer(pairs...).Replace(in)
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Action methods
+
+// String returns loggable description as string
+//
+// This function is auto-generated.
+//
+func (a *recordChangeRequestAction) String() string {
+	var props = &recordChangeRequestActionProps{}
+
+	if a.props != nil {
+		props = a.props
+	}
+
+	return props.Format(a.log, nil)
+}
+
+func (e *recordChangeRequestAction) ToAction() *actionlog.Action {
+	return &actionlog.Action{
+		Resource:    e.resource,
+		Action:      e.action,
+		Severity:    e.severity,
+		Description: e.String(),
+		Meta:        e.props.Serialize(),
+	}
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Action constructors
+
+// RecordChangeRequestActionSearch returns "compose:record-change-request.search" action
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestActionSearch(props ...*recordChangeRequestActionProps) *recordChangeRequestAction {
+	a := &recordChangeRequestAction{
+		timestamp: time.Now(),
+		resource:  "compose:record-change-request",
+		action:    "search",
+		log:       "searched for record change requests",
+		severity:  actionlog.Info,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordChangeRequestActionLookup returns "compose:record-change-request.lookup" action
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestActionLookup(props ...*recordChangeRequestActionProps) *recordChangeRequestAction {
+	a := &recordChangeRequestAction{
+		timestamp: time.Now(),
+		resource:  "compose:record-change-request",
+		action:    "lookup",
+		log:       "looked-up for a {changeRequest}",
+		severity:  actionlog.Info,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordChangeRequestActionRequest returns "compose:record-change-request.request" action
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestActionRequest(props ...*recordChangeRequestActionProps) *recordChangeRequestAction {
+	a := &recordChangeRequestAction{
+		timestamp: time.Now(),
+		resource:  "compose:record-change-request",
+		action:    "request",
+		log:       "requested approval of {changeRequest}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordChangeRequestActionApprove returns "compose:record-change-request.approve" action
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestActionApprove(props ...*recordChangeRequestActionProps) *recordChangeRequestAction {
+	a := &recordChangeRequestAction{
+		timestamp: time.Now(),
+		resource:  "compose:record-change-request",
+		action:    "approve",
+		log:       "approved {changeRequest}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordChangeRequestActionReject returns "compose:record-change-request.reject" action
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestActionReject(props ...*recordChangeRequestActionProps) *recordChangeRequestAction {
+	a := &recordChangeRequestAction{
+		timestamp: time.Now(),
+		resource:  "compose:record-change-request",
+		action:    "reject",
+		log:       "rejected {changeRequest}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordChangeRequestActionCancel returns "compose:record-change-request.cancel" action
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestActionCancel(props ...*recordChangeRequestActionProps) *recordChangeRequestAction {
+	a := &recordChangeRequestAction{
+		timestamp: time.Now(),
+		resource:  "compose:record-change-request",
+		action:    "cancel",
+		log:       "canceled {changeRequest}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordChangeRequestActionApply returns "compose:record-change-request.apply" action
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestActionApply(props ...*recordChangeRequestActionProps) *recordChangeRequestAction {
+	a := &recordChangeRequestAction{
+		timestamp: time.Now(),
+		resource:  "compose:record-change-request",
+		action:    "apply",
+		log:       "applied approved {changeRequest}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordChangeRequestActionConflict returns "compose:record-change-request.conflict" action
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestActionConflict(props ...*recordChangeRequestActionProps) *recordChangeRequestAction {
+	a := &recordChangeRequestAction{
+		timestamp: time.Now(),
+		resource:  "compose:record-change-request",
+		action:    "conflict",
+		log:       "approved {changeRequest} not applied; record was changed after the request",
+		severity:  actionlog.Warning,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Error constructors
+
+// RecordChangeRequestErrGeneric returns "compose:record-change-request.generic" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestErrGeneric(mm ...*recordChangeRequestActionProps) *errors.Error {
+	var p = &recordChangeRequestActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("failed to complete request due to internal error", nil),
+
+		errors.Meta("type", "generic"),
+		errors.Meta("resource", "compose:record-change-request"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(recordChangeRequestLogMetaKey{}, "{err}"),
+		errors.Meta(recordChangeRequestPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordChangeRequestErrNotFound returns "compose:record-change-request.notFound" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestErrNotFound(mm ...*recordChangeRequestActionProps) *errors.Error {
+	var p = &recordChangeRequestActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("record change request does not exist", nil),
+
+		errors.Meta("type", "notFound"),
+		errors.Meta("resource", "compose:record-change-request"),
+
+		errors.Meta(recordChangeRequestPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordChangeRequestErrInvalidID returns "compose:record-change-request.invalidID" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestErrInvalidID(mm ...*recordChangeRequestActionProps) *errors.Error {
+	var p = &recordChangeRequestActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid ID", nil),
+
+		errors.Meta("type", "invalidID"),
+		errors.Meta("resource", "compose:record-change-request"),
+
+		errors.Meta(recordChangeRequestPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordChangeRequestErrNotPending returns "compose:record-change-request.notPending" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestErrNotPending(mm ...*recordChangeRequestActionProps) *errors.Error {
+	var p = &recordChangeRequestActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("record change request is not pending", nil),
+
+		errors.Meta("type", "notPending"),
+		errors.Meta("resource", "compose:record-change-request"),
+
+		errors.Meta(recordChangeRequestPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordChangeRequestErrAlreadyDecided returns "compose:record-change-request.alreadyDecided" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestErrAlreadyDecided(mm ...*recordChangeRequestActionProps) *errors.Error {
+	var p = &recordChangeRequestActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("already decided on this approval step", nil),
+
+		errors.Meta("type", "alreadyDecided"),
+		errors.Meta("resource", "compose:record-change-request"),
+
+		errors.Meta(recordChangeRequestPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordChangeRequestErrChangedMeanwhile returns "compose:record-change-request.changedMeanwhile" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestErrChangedMeanwhile(mm ...*recordChangeRequestActionProps) *errors.Error {
+	var p = &recordChangeRequestActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("record change request was changed in the meantime, try again", nil),
+
+		errors.Meta("type", "changedMeanwhile"),
+		errors.Meta("resource", "compose:record-change-request"),
+
+		errors.Meta(recordChangeRequestPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordChangeRequestErrNotAllowedToRead returns "compose:record-change-request.notAllowedToRead" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestErrNotAllowedToRead(mm ...*recordChangeRequestActionProps) *errors.Error {
+	var p = &recordChangeRequestActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("not allowed to read record change requests of this module", nil),
+
+		errors.Meta("type", "notAllowedToRead"),
+		errors.Meta("resource", "compose:record-change-request"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(recordChangeRequestLogMetaKey{}, "could not read {changeRequest}; insufficient permissions"),
+		errors.Meta(recordChangeRequestPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordChangeRequestErrNotAllowedToApprove returns "compose:record-change-request.notAllowedToApprove" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestErrNotAllowedToApprove(mm ...*recordChangeRequestActionProps) *errors.Error {
+	var p = &recordChangeRequestActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("not allowed to approve or reject this record change request", nil),
+
+		errors.Meta("type", "notAllowedToApprove"),
+		errors.Meta("resource", "compose:record-change-request"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(recordChangeRequestLogMetaKey{}, "could not approve or reject {changeRequest}; insufficient permissions"),
+		errors.Meta(recordChangeRequestPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordChangeRequestErrNotAllowedToCancel returns "compose:record-change-request.notAllowedToCancel" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordChangeRequestErrNotAllowedToCancel(mm ...*recordChangeRequestActionProps) *errors.Error {
+	var p = &recordChangeRequestActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("not allowed to cancel this record change request", nil),
+
+		errors.Meta("type", "notAllowedToCancel"),
+		errors.Meta("resource", "compose:record-change-request"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(recordChangeRequestLogMetaKey{}, "could not cancel {changeRequest}; insufficient permissions"),
+		errors.Meta(recordChangeRequestPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+
+// recordAction is a service helper function wraps function that can return error
+//
+// It will wrap unrecognized/internal errors with generic errors.
+//
+// This function is auto-generated.
+//
+func (svc recordChangeRequest) recordAction(ctx context.Context, props *recordChangeRequestActionProps, actionFn func(...*recordChangeRequestActionProps) *recordChangeRequestAction, err error) error {
+	if svc.actionlog == nil || actionFn == nil {
+		// action log disabled or no action fn passed, return error as-is
+		return err
+	} else if err == nil {
+		// action completed w/o error, record it
+		svc.actionlog.Record(ctx, actionFn(props).ToAction())
+		return nil
+	}
+
+	a := actionFn(props).ToAction()
+
+	// Extracting error information and recording it as action
+	a.Error = err.Error()
+
+	switch c := err.(type) {
+	case *errors.Error:
+		m := c.Meta()
+
+		a.Error = err.Error()
+		a.Severity = actionlog.Severity(m.AsInt("severity"))
+		a.Description = props.Format(m.AsString(recordChangeRequestLogMetaKey{}), err)
+
+		if p, has := m[recordChangeRequestPropsMetaKey{}]; has {
+			a.Meta = p.(*recordChangeRequestActionProps).Serialize()
+		}
+
+		svc.actionlog.Record(ctx, a)
+	default:
+		svc.actionlog.Record(ctx, a)
+	}
+
+	// Original error is passed on
+	return err
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cortezaproject/corteza-server/compose/service/event"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/pkg/secrets"
+	"github.com/cortezaproject/corteza-server/store"
+)
+
+// Encrypted record fields
+//
+// Values of fields with "encrypted" option are encrypted with the keyring
+// before they are stored and decrypted when records are loaded; access to the
+// decrypted values is controlled with record.value.decrypt operation.
+//
+// Each value is stored with its blind index (keyed hash) that
+// makes exact-match lookups possible without decrypting the values.
+
+type (
+	recordEvent interface {
+		Record() *types.Record
+		OldRecord() *types.Record
+	}
+)
+
+// encryptRecordValues returns copy of the values with values of encrypted fields encrypted
+func encryptRecordValues(kr *secrets.Keyring, m *types.Module, vv types.RecordValueSet) (out types.RecordValueSet, err error) {
+	out = make(types.RecordValueSet, len(vv))
+	for i, v := range vv {
+		out[i] = v
+		if f := m.Fields.FindByName(v.Name); f == nil || !f.IsEncrypted() || v.Value == "" || secrets.IsEncrypted(v.Value) {
+			continue
+		}
+
+		if kr == nil {
+			return nil, RecordErrEncryptionKeyMissing()
+		}
+
+		enc := v.Clone()
+		if enc.Value, err = kr.Encrypt([]byte(v.Value)); err != nil {
+			return nil, err
+		}
+
+		enc.BlindIndex = kr.BlindIndex([]byte(v.Value))
+		out[i] = enc
+	}
+
+	return
+}
+
+// decryptRecordValues decrypts values of encrypted fields
+//
+// Values of fields that are not (or no longer) encrypted are decrypted too
+func decryptRecordValues(kr *secrets.Keyring, rr ...*types.Record) (err error) {
+	for _, r := range rr {
+		for _, v := range r.Values {
+			if !secrets.IsEncrypted(v.Value) {
+				continue
+			}
+
+			if kr == nil {
+				return RecordErrEncryptionKeyMissing()
+			}
+
+			plain, err := kr.Decrypt(v.Value)
+			if err != nil {
+				return RecordErrDecryptionFailed((&recordActionProps{}).setField(v.Name).setDetails(err.Error()))
+			}
+
+			v.Value = string(plain)
+		}
+	}
+
+	return nil
+}
+
+// trimUndecryptableRecordFields removes values of encrypted fields that user can not decrypt from all records
+func trimUndecryptableRecordFields(ctx context.Context, ac recordValueAccessController, m *types.Module, rr ...*types.Record) {
+	var (
+		undecryptable = map[string]bool{}
+	)
+
+	for _, f := range m.Fields {
+		undecryptable[f.Name] = f.IsEncrypted() && !ac.CanDecryptRecordValue(ctx, f)
+	}
+
+	for _, r := range rr {
+		r.Values, _ = r.Values.Filter(func(v *types.RecordValue) (bool, error) {
+			return !undecryptable[v.Name], nil
+		})
+	}
+}
+
+// encryptedRecord returns copy of the record with values of encrypted fields encrypted
+func encryptedRecord(kr *secrets.Keyring, m *types.Module, r *types.Record) (*types.Record, error) {
+	var (
+		c   = *r
+		err error
+	)
+
+	c.Values, err = encryptRecordValues(kr, m, r.Values)
+	return &c, err
+}
+
+// recordBlindIndexes returns fn that calculates blind indexes of the values
+// that encrypted fields are compared to in record filters
+func recordBlindIndexes(kr *secrets.Keyring) func(string) []string {
+	if kr == nil {
+		return nil
+	}
+
+	return func(v string) []string {
+		return kr.BlindIndexes([]byte(v))
+	}
+}
+
+// recryptRecordValues brings values of the fields in line with their encrypted option
+//
+// Values of encrypted fields are (re)encrypted with the primary key,
+// values of other fields are decrypted. Given fields can differ from the
+// fields of the module; module is used only to load and store the values.
+// Number of changed values is returned.
+func recryptRecordValues(ctx context.Context, s store.Storer, kr *secrets.Keyring, m *types.Module, ff types.ModuleFieldSet) (changed int, err error) {
+	var (
+		rr types.RecordSet
+		vv types.RecordValueSet
+	)
+
+	if len(ff) == 0 {
+		return
+	}
+
+	if rr, _, err = store.SearchComposeRecords(ctx, s, m, types.RecordFilter{Deleted: filter.StateInclusive}); err != nil {
+		return
+	}
+
+	for _, r := range rr {
+		for _, f := range ff {
+			for _, v := range r.Values.FilterByName(f.Name) {
+				enc := secrets.IsEncrypted(v.Value)
+
+				switch {
+				case !f.IsEncrypted() && !enc:
+					continue
+				case f.IsEncrypted() && v.Value == "":
+					continue
+				case f.IsEncrypted() && enc && kr != nil && !kr.NeedsRotation(v.Value):
+					continue
+				}
+
+				c := v.Clone()
+				c.RecordID = r.ID
+				vv = append(vv, c)
+			}
+		}
+	}
+
+	if len(vv) == 0 {
+		return
+	}
+
+	if err = decryptRecordValues(kr, &types.Record{Values: vv}); err != nil {
+		return
+	}
+
+	for _, v := range vv {
+		v.BlindIndex = ""
+	}
+
+	if vv, err = encryptRecordValues(kr, &types.Module{Fields: ff}, vv); err != nil {
+		return
+	}
+
+	return len(vv), store.PartialComposeRecordValueUpdate(ctx, s, m, vv...)
+}
+
+// moduleFieldEncryptionChanges returns fields with values that need to be encrypted or decrypted
+//
+// These are fields that change their encrypted option and encrypted fields
+// that change their kind (values are converted unencrypted)
+func moduleFieldEncryptionChanges(existing, upd types.ModuleFieldSet) (ff types.ModuleFieldSet) {
+	for _, f := range upd {
+		if f.ID == 0 || f.DeletedAt != nil {
+			continue
+		}
+
+		e := existing.FindByID(f.ID)
+		if e == nil {
+			continue
+		}
+
+		if e.IsEncrypted() != f.IsEncrypted() || (f.IsEncrypted() && e.Kind != f.Kind) {
+			ff = append(ff, f)
+		}
+	}
+
+	return
+}
+
+// RestoreEvent restores compose event stored in the outbox
+//
+// Record events are stored with values of encrypted fields encrypted
+// (see eventbus.Deferred.EnqueueSealed); they are decrypted here with the default keyring
+func RestoreEvent(resourceType, eventType string, args map[string][]byte) (eventbus.Event, error) {
+	ev, err := event.Restore(resourceType, eventType, args)
+	if err != nil || ev == nil {
+		return ev, err
+	}
+
+	if rev, ok := ev.(recordEvent); ok {
+		for _, r := range []*types.Record{rev.Record(), rev.OldRecord()} {
+			if r == nil {
+				continue
+			}
+
+			if err = decryptRecordValues(DefaultKeyring, r); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return ev, nil
+}
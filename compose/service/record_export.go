@@ -0,0 +1,398 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/pkg/secrets"
+	"github.com/cortezaproject/corteza-server/store"
+)
+
+type (
+	// recordExporter loads related & referenced records for export
+	recordExporter struct {
+		ctx     context.Context
+		store   store.Storer
+		ac      recordAccessController
+		keyring *secrets.Keyring
+		labels  bool
+
+		modules map[uint64]*types.Module
+	}
+
+	recordExportChild struct {
+		*types.RecordExportChild
+
+		name     string
+		module   *types.Module
+		field    *types.ModuleField
+		children []*recordExportChild
+	}
+
+	recordExportPath struct {
+		name string
+		ref  *types.ModuleField
+		sub  string
+	}
+)
+
+const (
+	// max number of parent IDs in one query for child records
+	recordExportChunkSize = 100
+)
+
+func newRecordExporter(ctx context.Context, s store.Storer, ac recordAccessController, kr *secrets.Keyring, labels bool) *recordExporter {
+	return &recordExporter{
+		ctx:     ctx,
+		store:   s,
+		ac:      ac,
+		keyring: kr,
+		labels:  labels,
+		modules: make(map[uint64]*types.Module),
+	}
+}
+
+// children validates export definition of child modules
+func (e *recordExporter) children(parent *types.Module, dd []*types.RecordExportChild) (cc []*recordExportChild, err error) {
+	cc = make([]*recordExportChild, 0, len(dd))
+
+	for _, d := range dd {
+		c := &recordExportChild{RecordExportChild: d}
+
+		if c.module, err = e.childModule(parent, d.Module); err != nil {
+			return nil, err
+		}
+
+		if !e.ac.CanReadRecord(e.ctx, c.module) {
+			return nil, RecordErrNotAllowedToListRecords()
+		}
+
+		for _, f := range c.module.Fields {
+			if f.Kind != "Record" || f.Options.ModuleID() != parent.ID {
+				continue
+			}
+
+			if d.Field == "" || d.Field == f.Name {
+				c.field = f
+				break
+			}
+		}
+
+		if c.field == nil {
+			return nil, invalidRecordExport("module %q does not reference module %q", d.Module, parent.Handle)
+		}
+
+		if c.name = d.Name; c.name == "" {
+			if c.name = c.module.Handle; c.name == "" {
+				c.name = strconv.FormatUint(c.module.ID, 10)
+			}
+		}
+
+		if c.children, err = e.children(c.module, d.Children); err != nil {
+			return nil, err
+		}
+
+		cc = append(cc, c)
+	}
+
+	return cc, nil
+}
+
+// childModule finds child module by ID or handle, in the namespace of the parent module
+func (e *recordExporter) childModule(parent *types.Module, ident string) (m *types.Module, err error) {
+	if ID, perr := strconv.ParseUint(ident, 10, 64); perr == nil {
+		m, err = e.module(ID)
+	} else if m, err = store.LookupComposeModuleByNamespaceIDHandle(e.ctx, e.store, parent.NamespaceID, ident); err == nil {
+		err = loadModuleFields(e.ctx, e.store, m)
+	}
+
+	if errors.IsNotFound(err) || (err == nil && m.NamespaceID != parent.NamespaceID) {
+		return nil, invalidRecordExport("module %q not found", ident)
+	}
+
+	return m, err
+}
+
+func (e *recordExporter) module(ID uint64) (m *types.Module, err error) {
+	if m = e.modules[ID]; m != nil {
+		return m, nil
+	}
+
+	if m, err = store.LookupComposeModuleByID(e.ctx, e.store, ID); err != nil {
+		return nil, err
+	}
+
+	if err = loadModuleFields(e.ctx, e.store, m); err != nil {
+		return nil, err
+	}
+
+	e.modules[ID] = m
+	return m, nil
+}
+
+// related loads records of child modules for all parent records
+//
+// Returned related records are indexed by parent record ID
+func (e *recordExporter) related(parents types.RecordSet, cc []*recordExportChild) (out map[uint64][]*types.RelatedRecords, err error) {
+	out = make(map[uint64][]*types.RelatedRecords)
+	if len(parents) == 0 {
+		return
+	}
+
+	for _, c := range cc {
+		var (
+			rr, resolved types.RecordSet
+			nested       map[uint64][]*types.RelatedRecords
+			grouped      = make(map[uint64]types.RecordSet)
+		)
+
+		if rr, err = e.referencing(c.module, c.field, parents.IDs()); err != nil {
+			return
+		}
+
+		if nested, err = e.related(rr, c.children); err != nil {
+			return
+		}
+
+		if resolved, err = e.resolve(c.module, c.Fields, rr); err != nil {
+			return
+		}
+
+		for i, r := range rr {
+			for _, v := range r.Values.FilterByName(c.field.Name) {
+				if ID := recordExportRefID(v); ID > 0 {
+					grouped[ID] = append(grouped[ID], resolved[i])
+				}
+			}
+		}
+
+		for _, p := range parents {
+			rel := &types.RelatedRecords{
+				Name:    c.name,
+				Fields:  c.Fields,
+				Records: grouped[p.ID],
+				Related: nested,
+			}
+
+			if rel.Records == nil {
+				rel.Records = types.RecordSet{}
+			}
+
+			out[p.ID] = append(out[p.ID], rel)
+		}
+	}
+
+	return
+}
+
+// referencing loads records from module that reference any of the given records through the field
+func (e *recordExporter) referencing(m *types.Module, f *types.ModuleField, IDs []uint64) (out types.RecordSet, err error) {
+	for len(IDs) > 0 {
+		var (
+			n  = recordExportChunkSize
+			cc = make([]string, 0, n)
+			rr types.RecordSet
+		)
+
+		if n > len(IDs) {
+			n = len(IDs)
+		}
+
+		for _, ID := range IDs[:n] {
+			cc = append(cc, fmt.Sprintf("%s = %d", f.Name, ID))
+		}
+
+		IDs = IDs[n:]
+
+		rr, _, err = store.SearchComposeRecords(e.ctx, e.store, m, types.RecordFilter{
+			ModuleID:    m.ID,
+			NamespaceID: m.NamespaceID,
+			Query:       strings.Join(cc, " OR "),
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err = e.readable(m, rr); err != nil {
+			return nil, err
+		}
+
+		out = append(out, rr...)
+	}
+
+	return
+}
+
+// resolve returns copies of records with values of referenced records
+//
+// Values on <ref-field>.<field> paths are copied from the referenced records and
+// when resolving is enabled, IDs in record fields are replaced with values from
+// the label field of the referenced record.
+func (e *recordExporter) resolve(m *types.Module, fields []string, rr types.RecordSet) (out types.RecordSet, err error) {
+	var (
+		paths  = make([]*recordExportPath, 0)
+		labels = make(types.ModuleFieldSet, 0)
+
+		// referenced records, indexed by field name and record ID
+		refs = make(map[string]map[uint64]*types.Record)
+	)
+
+	for _, name := range fields {
+		if !strings.Contains(name, ".") {
+			if f := m.Fields.FindByName(name); e.labels && f != nil && f.Kind == "Record" && f.Options.LabelField() != "" {
+				labels = append(labels, f)
+			}
+
+			continue
+		}
+
+		var (
+			dot = strings.Index(name, ".")
+			p   = &recordExportPath{name: name, sub: name[dot+1:]}
+		)
+
+		if p.ref = m.Fields.FindByName(name[:dot]); p.ref == nil || p.ref.Kind != "Record" {
+			return nil, invalidRecordExport("%q is not a record field", name[:dot])
+		}
+
+		paths = append(paths, p)
+	}
+
+	if len(paths) == 0 && len(labels) == 0 {
+		return rr, nil
+	}
+
+	for _, p := range paths {
+		if refs[p.ref.Name], err = e.referenced(p.ref, rr); err != nil {
+			return
+		}
+	}
+
+	for _, f := range labels {
+		if refs[f.Name], err = e.referenced(f, rr); err != nil {
+			return
+		}
+	}
+
+	out = make(types.RecordSet, len(rr))
+	for i, r := range rr {
+		out[i] = r.Clone()
+
+		for _, p := range paths {
+			var place uint
+			for _, v := range r.Values.FilterByName(p.ref.Name) {
+				ref := refs[p.ref.Name][recordExportRefID(v)]
+				if ref == nil {
+					continue
+				}
+
+				for _, rv := range ref.Values.FilterByName(p.sub) {
+					out[i].Values = append(out[i].Values, &types.RecordValue{
+						RecordID: r.ID,
+						Name:     p.name,
+						Value:    rv.Value,
+						Place:    place,
+					})
+
+					place++
+				}
+			}
+		}
+
+		for _, f := range labels {
+			for _, v := range out[i].Values.FilterByName(f.Name) {
+				ref := refs[f.Name][recordExportRefID(v)]
+				if ref == nil {
+					continue
+				}
+
+				if lv := ref.Values.Get(f.Options.LabelField(), 0); lv != nil && lv.Value != "" {
+					v.Value = lv.Value
+				}
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// referenced loads records referenced through the field
+//
+// Referenced records are not loaded if user is not allowed to read them
+func (e *recordExporter) referenced(f *types.ModuleField, rr types.RecordSet) (out map[uint64]*types.Record, err error) {
+	var (
+		m   *types.Module
+		IDs = make([]uint64, 0)
+		set types.RecordSet
+	)
+
+	for _, r := range rr {
+		for _, v := range r.Values.FilterByName(f.Name) {
+			if ID := recordExportRefID(v); ID > 0 {
+				IDs = append(IDs, ID)
+			}
+		}
+	}
+
+	if len(IDs) == 0 || f.Options.ModuleID() == 0 {
+		return nil, nil
+	}
+
+	if m, err = e.module(f.Options.ModuleID()); errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !e.ac.CanReadRecord(e.ctx, m) {
+		return nil, nil
+	}
+
+	set, _, err = store.SearchComposeRecords(e.ctx, e.store, m, types.RecordFilter{
+		ModuleID:    m.ID,
+		NamespaceID: m.NamespaceID,
+		LabeledIDs:  IDs,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = e.readable(m, set); err != nil {
+		return nil, err
+	}
+
+	out = make(map[uint64]*types.Record, len(set))
+	for _, r := range set {
+		out[r.ID] = r
+	}
+
+	return out, nil
+}
+
+// readable removes values of fields user can not read (or decrypt) and decrypts the rest
+//
+// Related and referenced records are loaded directly from the store;
+// their values are copied to the export and must be checked here
+func (e *recordExporter) readable(m *types.Module, rr types.RecordSet) error {
+	trimUnreadableRecordFields(e.ctx, e.ac, m, rr...)
+	return decryptRecordValues(e.keyring, rr...)
+}
+
+func recordExportRefID(v *types.RecordValue) uint64 {
+	if v.Ref > 0 {
+		return v.Ref
+	}
+
+	ID, _ := strconv.ParseUint(v.Value, 10, 64)
+	return ID
+}
+
+func invalidRecordExport(format string, aa ...interface{}) error {
+	return RecordErrInvalidExportDefinition((&recordActionProps{}).setDetails(fmt.Sprintf(format, aa...)))
+}
@@ -1,11 +1,15 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"github.com/cortezaproject/corteza-server/compose/service/event"
 	"github.com/cortezaproject/corteza-server/compose/service/values"
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/pkg/rbac"
+	"github.com/cortezaproject/corteza-server/pkg/secrets"
 	"github.com/cortezaproject/corteza-server/store"
 	"github.com/cortezaproject/corteza-server/store/sqlite3"
 	sysTypes "github.com/cortezaproject/corteza-server/system/types"
@@ -270,3 +274,172 @@ func TestRecord_boolFieldPermissionIssueKBR(t *testing.T) {
 	}
 
 }
+
+func TestRecord_encryptedFields(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		ctx    = context.Background()
+		s, err = sqlite3.ConnectInMemoryWithDebug(ctx)
+	)
+
+	req.NoError(err)
+	req.NoError(store.Upgrade(ctx, zap.NewNop(), s))
+	req.NoError(store.TruncateComposeModules(ctx, s))
+	req.NoError(store.TruncateComposeModuleFields(ctx, s))
+	req.NoError(store.TruncateComposeRecords(ctx, s, nil))
+	req.NoError(store.TruncateRbacRules(ctx, s))
+
+	var (
+		k1 = bytes.Repeat([]byte{1}, secrets.KeySize)
+		k2 = bytes.Repeat([]byte{2}, secrets.KeySize)
+
+		rbacService = rbac.NewService(zap.NewNop(), s)
+		ac          = AccessControl(rbacService)
+
+		svc = record{
+			sanitizer: values.Sanitizer(),
+			validator: values.Validator(),
+			ac:        ac,
+			store:     s,
+		}
+
+		userID    = nextID()
+		ns        = &types.Namespace{ID: nextID()}
+		mod       = &types.Module{ID: nextID(), NamespaceID: ns.ID}
+		nameField = &types.ModuleField{ID: nextID(), ModuleID: mod.ID, Name: "name", Kind: "String"}
+		ssnField  = &types.ModuleField{ID: nextID(), ModuleID: mod.ID, Name: "ssn", Kind: "String", Options: types.ModuleFieldOptions{}}
+
+		adminRole = &sysTypes.Role{Name: "admin", ID: nextID()}
+		clerkRole = &sysTypes.Role{Name: "clerk", ID: nextID()}
+		rec, raw  *types.Record
+		set       types.RecordSet
+		ssnFilter = types.RecordFilter{ModuleID: mod.ID, NamespaceID: ns.ID, Query: "ssn = '123-45'"}
+		allFilter = types.RecordFilter{ModuleID: mod.ID, NamespaceID: ns.ID}
+		storedSsn = func() *types.RecordValue {
+			raw, err = store.LookupComposeRecordByID(ctx, s, mod, rec.ID)
+			req.NoError(err)
+			return raw.Values.Get("ssn", 0)
+		}
+	)
+
+	ssnField.Options.SetIsEncrypted(true)
+	mod.Fields = types.ModuleFieldSet{nameField, ssnField}
+
+	svc.keyring, err = secrets.NewKeyring(k1)
+	req.NoError(err)
+
+	req.NoError(store.CreateComposeNamespace(ctx, s, ns))
+	req.NoError(store.CreateComposeModule(ctx, s, mod))
+	req.NoError(store.CreateComposeModuleField(ctx, s, nameField, ssnField))
+
+	rbacService.Grant(ctx, ac.Whitelist(),
+		rbac.AllowRule(adminRole.ID, mod.RBACResource(), "record.read"),
+		rbac.AllowRule(adminRole.ID, mod.RBACResource(), "record.create"),
+		rbac.AllowRule(adminRole.ID, mod.RBACResource(), "record.update"),
+		rbac.AllowRule(adminRole.ID, ssnField.RBACResource(), "record.value.decrypt"),
+
+		rbac.AllowRule(clerkRole.ID, mod.RBACResource(), "record.read"),
+		rbac.AllowRule(clerkRole.ID, mod.RBACResource(), "record.update"),
+	)
+
+	adminCtx := auth.SetIdentityToContext(ctx, auth.NewIdentity(userID, adminRole.ID))
+	clerkCtx := auth.SetIdentityToContext(ctx, auth.NewIdentity(userID, clerkRole.ID))
+
+	rec, err = svc.With(adminCtx).Create(&types.Record{ModuleID: mod.ID, NamespaceID: ns.ID, Values: types.RecordValueSet{
+		{Name: "name", Value: "John"},
+		{Name: "ssn", Value: "123-45"},
+	}})
+	req.NoError(err)
+	req.Equal("123-45", rec.Values.Get("ssn", 0).Value)
+
+	// values are stored encrypted with the blind index
+	req.True(secrets.IsEncrypted(storedSsn().Value))
+	req.NotEmpty(storedSsn().BlindIndex)
+	req.Equal("John", raw.Values.Get("name", 0).Value)
+
+	set, _, err = svc.With(adminCtx).Find(ssnFilter)
+	req.NoError(err)
+	req.Len(set, 1)
+	req.Equal("123-45", set[0].Values.Get("ssn", 0).Value)
+
+	// values are not decrypted without the permission and
+	// can not be matched by their blind indexes
+	_, _, err = svc.With(clerkCtx).Find(ssnFilter)
+	req.Error(err)
+
+	set, _, err = svc.With(clerkCtx).Find(allFilter)
+	req.NoError(err)
+	req.Len(set, 1)
+	req.Nil(set[0].Values.Get("ssn", 0))
+
+	// encrypted values are preserved on update
+	set[0].Values = set[0].Values.Set(&types.RecordValue{Name: "name", Value: "Jane"})
+	_, err = svc.With(clerkCtx).Update(set[0])
+	req.NoError(err)
+
+	plain, err := svc.keyring.Decrypt(storedSsn().Value)
+	req.NoError(err)
+	req.Equal("123-45", string(plain))
+	req.Equal("Jane", raw.Values.Get("name", 0).Value)
+
+	// values are re-encrypted with the new primary key
+	svc.keyring, err = secrets.NewKeyring(k2, k1)
+	req.NoError(err)
+	req.True(svc.keyring.NeedsRotation(storedSsn().Value))
+
+	rotated, err := svc.With(adminCtx).RotateKey()
+	req.NoError(err)
+	req.Equal(1, rotated)
+	req.False(svc.keyring.NeedsRotation(storedSsn().Value))
+
+	set, _, err = svc.With(adminCtx).Find(ssnFilter)
+	req.NoError(err)
+	req.Len(set, 1)
+
+	// values can not be stored nor loaded without the key
+	svc.keyring = nil
+	_, err = svc.With(adminCtx).FindByID(ns.ID, mod.ID, rec.ID)
+	req.Error(err)
+}
+
+func TestRecordEventSealing(t *testing.T) {
+	var (
+		req = require.New(t)
+		err error
+
+		mod      = &types.Module{ID: nextID()}
+		ssnField = &types.ModuleField{ID: nextID(), ModuleID: mod.ID, Name: "ssn", Kind: "String", Options: types.ModuleFieldOptions{}}
+
+		rec = &types.Record{ID: nextID(), ModuleID: mod.ID, Values: types.RecordValueSet{
+			{Name: "ssn", Value: "123-45"},
+		}}
+
+		sealed *types.Record
+		qe     *eventbus.QueuedEvent
+		ev     eventbus.Event
+	)
+
+	ssnField.Options.SetIsEncrypted(true)
+	mod.Fields = types.ModuleFieldSet{ssnField}
+
+	defer func(kr *secrets.Keyring) { DefaultKeyring = kr }(DefaultKeyring)
+	DefaultKeyring, err = secrets.NewKeyring(bytes.Repeat([]byte{1}, secrets.KeySize))
+	req.NoError(err)
+
+	sealed, err = encryptedRecord(DefaultKeyring, mod, rec)
+	req.NoError(err)
+
+	// values of encrypted fields are not stored as plain text
+	qe, err = eventbus.NewQueuedEvent(event.RecordAfterUpdateImmutable(sealed, sealed, mod, nil, nil), 0)
+	req.NoError(err)
+	req.NotContains(string(qe.Args["record"]), "123-45")
+	req.NotContains(string(qe.Args["oldRecord"]), "123-45")
+
+	// and are decrypted when restored
+	ev, err = qe.Restore(RestoreEvent)
+	req.NoError(err)
+	req.Equal("123-45", ev.(recordEvent).Record().Values.Get("ssn", 0).Value)
+	req.Equal("123-45", ev.(recordEvent).OldRecord().Values.Get("ssn", 0).Value)
+	req.Equal("123-45", rec.Values.Get("ssn", 0).Value)
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/expr"
+)
+
+// Transitions returns workflow transitions that the current user
+// can make on the record
+func (svc record) Transitions(m *types.Module, r *types.Record) []*types.ModuleWorkflowTransition {
+	var (
+		out = make([]*types.ModuleWorkflowTransition, 0)
+	)
+
+	if !m.Workflow.IsEnabled() || !svc.ac.CanUpdateRecord(svc.ctx, m) {
+		return out
+	}
+
+	for _, t := range m.Workflow.TransitionsFrom(recordState(m, r)) {
+		if isTransitionAllowed(svc.ctx, t) {
+			if ok, err := evalTransitionGuard(svc.ctx, t, m, r, r); err == nil && ok {
+				out = append(out, t)
+			}
+		}
+	}
+
+	return out
+}
+
+// procWorkflow enforces module's workflow on created or updated record
+//
+// New records get the initial state and can not be created in any other state;
+// without the initial state, they can only be created in states that can be
+// transitioned to from an empty state. State of existing records can only
+// be changed with one of the transitions allowed for the current user.
+// Fields required by the (resulting) state are checked as well.
+//
+// Super-user (system and federation processes) can create records in any known state.
+func procWorkflow(ctx context.Context, m *types.Module, r, old *types.Record, rve *types.RecordValueErrorSet) {
+	var (
+		w     = m.Workflow
+		state string
+		from  string
+	)
+
+	if !w.IsEnabled() {
+		return
+	}
+
+	if state = recordState(m, r); state == "" && old == nil && w.Initial != "" {
+		state = w.Initial
+		r.Values = r.Values.Set(&types.RecordValue{Name: w.StateField, Value: state, Updated: true})
+	}
+
+	from = recordState(m, old)
+
+	switch {
+	case old == nil && auth.IsSuperUser(auth.GetIdentityFromContext(ctx)):
+		if state != "" && len(w.States) > 0 && w.State(state) == nil {
+			rve.Push(makeTransitionErr("invalidTransition", w, from, state, ""))
+			return
+		}
+
+	case old == nil && w.Initial != "":
+		if state != w.Initial {
+			rve.Push(makeTransitionErr("invalidTransition", w, from, state, ""))
+			return
+		}
+
+	case state != from:
+		t := w.Transition(from, state)
+
+		switch {
+		case t == nil:
+			rve.Push(makeTransitionErr("invalidTransition", w, from, state, ""))
+			return
+
+		case !isTransitionAllowed(ctx, t):
+			rve.Push(makeTransitionErr("transitionDenied", w, from, state, ""))
+			return
+		}
+
+		if ok, err := evalTransitionGuard(ctx, t, m, r, old); err != nil {
+			rve.Push(makeTransitionErr("transitionGuard", w, from, state, err.Error()))
+			return
+		} else if !ok {
+			rve.Push(makeTransitionErr("transitionGuard", w, from, state, ""))
+			return
+		}
+	}
+
+	if s := w.State(state); s != nil {
+		for _, name := range s.Required {
+			if v := r.Values.Get(name, 0); v == nil || v.IsDeleted() || v.Value == "" {
+				rve.Push(types.RecordValueError{
+					Kind: "empty",
+					Meta: map[string]interface{}{"field": name, "state": state},
+				})
+			}
+		}
+	}
+}
+
+// recordState returns value of the record's state field
+func recordState(m *types.Module, r *types.Record) string {
+	if r == nil {
+		return ""
+	}
+
+	if v := r.Values.Get(m.Workflow.StateField, 0); v != nil && !v.IsDeleted() {
+		return v.Value
+	}
+
+	return ""
+}
+
+func isTransitionAllowed(ctx context.Context, t *types.ModuleWorkflowTransition) bool {
+	i := auth.GetIdentityFromContext(ctx)
+	return auth.IsSuperUser(i) || t.IsAllowedFor(i.Roles())
+}
+
+// evalTransitionGuard evaluates guard expression of the transition
+//
+// Same variables as with field value expressions are available
+func evalTransitionGuard(ctx context.Context, t *types.ModuleWorkflowTransition, m *types.Module, r, old *types.Record) (bool, error) {
+	if t.Guard == "" {
+		return true, nil
+	}
+
+	return evalRecordCondition(ctx, t.Guard, m, r, old)
+}
+
+// evalRecordCondition evaluates boolean expression on (changed) record
+func evalRecordCondition(ctx context.Context, condition string, m *types.Module, r, old *types.Record) (bool, error) {
+	eval, err := expr.Parser().NewEvaluable(condition)
+	if err != nil {
+		return false, err
+	}
+
+	scope := r.Values.GetClean().Dict(m.Fields)
+	scope["new"] = r.Dict(m)
+	if old != nil {
+		scope["old"] = old.Dict(m)
+	}
+
+	res, err := eval(ctx, scope)
+	if err != nil {
+		return false, err
+	}
+
+	ok, _ := res.(bool)
+	return ok, nil
+}
+
+func makeTransitionErr(kind string, w *types.ModuleWorkflow, from, to, msg string) types.RecordValueError {
+	return types.RecordValueError{
+		Kind:    kind,
+		Message: msg,
+		Meta:    map[string]interface{}{"field": w.StateField, "from": from, "to": to},
+	}
+}
+
+// validateModuleWorkflow checks workflow definition against module fields
+func validateModuleWorkflow(w *types.ModuleWorkflow, ff types.ModuleFieldSet) error {
+	if w == nil {
+		return nil
+	}
+
+	if !w.IsEnabled() {
+		if len(w.States) > 0 || len(w.Transitions) > 0 {
+			return fmt.Errorf("state field not set")
+		}
+
+		return nil
+	}
+
+	if f := ff.FindByName(w.StateField); f == nil {
+		return fmt.Errorf("state field %q does not exist", w.StateField)
+	} else if f.Multi {
+		return fmt.Errorf("state field %q can not be multi-value", w.StateField)
+	}
+
+	known := func(state string) bool {
+		return len(w.States) == 0 || w.State(state) != nil
+	}
+
+	if w.Initial != "" && !known(w.Initial) {
+		return fmt.Errorf("unknown initial state %q", w.Initial)
+	}
+
+	for _, s := range w.States {
+		if s.Name == "" {
+			return fmt.Errorf("state without a name")
+		}
+
+		for _, name := range s.Required {
+			if ff.FindByName(name) == nil {
+				return fmt.Errorf("field %q required by state %q does not exist", name, s.Name)
+			}
+		}
+	}
+
+	for _, t := range w.Transitions {
+		if !known(t.To) {
+			return fmt.Errorf("unknown state %q", t.To)
+		}
+
+		for _, from := range t.From {
+			if !known(from) {
+				return fmt.Errorf("unknown state %q", from)
+			}
+		}
+
+		if t.Guard != "" {
+			if _, err := expr.Parser().NewEvaluable(t.Guard); err != nil {
+				return fmt.Errorf("invalid guard expression %q: %v", t.Guard, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/objstore/plain"
 	"github.com/cortezaproject/corteza-server/pkg/options"
 	"github.com/cortezaproject/corteza-server/pkg/rbac"
+	"github.com/cortezaproject/corteza-server/pkg/secrets"
 	"github.com/cortezaproject/corteza-server/store"
 	"go.uber.org/zap"
 )
@@ -32,6 +33,9 @@ type (
 	Config struct {
 		ActionLog options.ActionLogOpt
 		Storage   options.ObjectStoreOpt
+
+		// Encrypts values of encrypted module fields
+		Keyring *secrets.Keyring
 	}
 
 	eventDispatcher interface {
@@ -50,19 +54,24 @@ var (
 
 	DefaultLogger *zap.Logger
 
+	// DefaultKeyring encrypts and decrypts values of encrypted module fields
+	DefaultKeyring *secrets.Keyring
+
 	DefaultActionlog actionlog.Recorder
 
 	// DefaultAccessControl Access control checking
 	DefaultAccessControl *accessControl
 
-	DefaultNamespace     NamespaceService
-	DefaultImportSession ImportSessionService
-	DefaultRecord        RecordService
-	DefaultModule        ModuleService
-	DefaultChart         ChartService
-	DefaultPage          PageService
-	DefaultAttachment    AttachmentService
-	DefaultNotification  *notification
+	DefaultNamespace           NamespaceService
+	DefaultNamespaceVersion    NamespaceVersionService
+	DefaultImportSession       ImportSessionService
+	DefaultRecord              RecordService
+	DefaultRecordChangeRequest RecordChangeRequestService
+	DefaultModule              ModuleService
+	DefaultChart               ChartService
+	DefaultPage                PageService
+	DefaultAttachment          AttachmentService
+	DefaultNotification        *notification
 
 	// wrapper around time.Now() that will aid service testing
 	now = func() *time.Time {
@@ -86,6 +95,8 @@ func Initialize(ctx context.Context, log *zap.Logger, s store.Storer, c Config)
 
 	DefaultLogger = log.Named("service")
 
+	DefaultKeyring = c.Keyring
+
 	{
 		tee := zap.NewNop()
 		policy := actionlog.MakeProductionPolicy()
@@ -141,6 +152,7 @@ func Initialize(ctx context.Context, log *zap.Logger, s store.Storer, c Config)
 	}
 
 	DefaultNamespace = Namespace()
+	DefaultNamespaceVersion = NamespaceVersion()
 	DefaultModule = Module()
 
 	DefaultImportSession = ImportSession()
@@ -148,6 +160,7 @@ func Initialize(ctx context.Context, log *zap.Logger, s store.Storer, c Config)
 	DefaultPage = Page()
 	DefaultChart = Chart()
 	DefaultNotification = Notification()
+	DefaultRecordChangeRequest = RecordChangeRequest()
 	DefaultAttachment = Attachment(DefaultObjectStore)
 
 	RegisterIteratorProviders()
@@ -0,0 +1,142 @@
+package values
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/store"
+)
+
+// Converter converts existing record values when module field changes its kind
+//
+// Values are converted to the format of the new field kind (same as sanitizer
+// would do it) and then checked (same as validator would do it). Values that
+// can not be converted are reported as errors.
+
+type (
+	UserResolver func(context.Context, store.Storer, string) (uint64, error)
+
+	converter struct {
+		userResolverFn UserResolver
+	}
+)
+
+func makeConversionErr(from, to *types.ModuleField, v *types.RecordValue) types.RecordValueError {
+	return types.RecordValueError{Kind: "invalidConversion", Meta: map[string]interface{}{
+		"field":    to.Name,
+		"from":     from.Kind,
+		"to":       to.Kind,
+		"value":    v.Value,
+		"recordID": v.RecordID,
+	}}
+}
+
+func Converter() *converter {
+	return &converter{}
+}
+
+// UserResolver sets function that resolves user's ID from the email
+//
+// When not set, only user IDs can be converted to user references
+func (c *converter) UserResolver(fn UserResolver) {
+	c.userResolverFn = fn
+}
+
+// Run converts value from one field kind to another
+//
+// Empty values are returned as they are. Returned value has the name of the new field
+func (c converter) Run(ctx context.Context, s store.Storer, from, to *types.ModuleField, v *types.RecordValue) (*types.RecordValue, *types.RecordValueError) {
+	var (
+		out = v.Clone()
+		raw = v.Value
+
+		vldtr = validator{}
+
+		fail = func() (*types.RecordValue, *types.RecordValueError) {
+			err := makeConversionErr(from, to, v)
+			return nil, &err
+		}
+	)
+
+	out.Name = to.Name
+
+	if from.IsRef() && raw == "" && v.Ref > 0 {
+		raw = strconv.FormatUint(v.Ref, 10)
+	}
+
+	if !strings.EqualFold(to.Kind, "string") {
+		raw = strings.TrimSpace(raw)
+	}
+
+	if raw == "" {
+		out.Value, out.Ref = "", 0
+		return out, nil
+	}
+
+	out.Value, out.Ref = raw, 0
+
+	switch strings.ToLower(to.Kind) {
+	case "bool":
+		switch {
+		case truthy.MatchString(strings.ToLower(raw)):
+			out.Value = strBoolTrue
+		case falsy.MatchString(strings.ToLower(raw)):
+			out.Value = strBoolFalse
+		default:
+			return fail()
+		}
+
+	case "datetime":
+		if out.Value = sDatetime(raw, to.Options.Bool(fieldOpt_Datetime_onlyDate), to.Options.Bool(fieldOpt_Datetime_onlyTime)); out.Value == "" {
+			return fail()
+		}
+
+	case "number":
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return fail()
+		}
+
+		out.Value = sNumber(raw, to.Options.Precision())
+
+	case "email":
+		if len(vldtr.vEmail(out, to, nil, nil)) > 0 {
+			return fail()
+		}
+
+	case "url":
+		if len(vldtr.vUrl(out, to, nil, nil)) > 0 {
+			return fail()
+		}
+
+	case "select":
+		if len(vldtr.vSelect(out, to, nil, nil)) > 0 {
+			return fail()
+		}
+
+	case "user":
+		if refy.MatchString(raw) {
+			out.Ref, _ = strconv.ParseUint(raw, 10, 64)
+		} else if c.userResolverFn != nil && strings.Contains(raw, "@") {
+			userID, err := c.userResolverFn(ctx, s, raw)
+			if err != nil || userID == 0 {
+				return fail()
+			}
+
+			out.Ref = userID
+			out.Value = strconv.FormatUint(userID, 10)
+		} else {
+			return fail()
+		}
+
+	case "record", "file":
+		if !refy.MatchString(raw) {
+			return fail()
+		}
+
+		out.Ref, _ = strconv.ParseUint(raw, 10, 64)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,122 @@
+package values
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/store"
+)
+
+func Test_converter_Run(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   string
+		to     string
+		input  string
+		output string
+		outref uint64
+		fails  bool
+	}{
+		{
+			name:   "numeric strings should be converted to numbers",
+			from:   "String",
+			to:     "Number",
+			input:  " 42 ",
+			output: "42",
+		},
+		{
+			name:  "non-numeric strings should fail",
+			from:  "String",
+			to:    "Number",
+			input: "value",
+			fails: true,
+		},
+		{
+			name:   "empty values should be kept",
+			from:   "String",
+			to:     "Number",
+			input:  "",
+			output: "",
+		},
+		{
+			name:   "strings should be converted to booleans",
+			from:   "String",
+			to:     "Bool",
+			input:  "no",
+			output: strBoolFalse,
+		},
+		{
+			name:  "unknown boolean value should fail",
+			from:  "String",
+			to:    "Bool",
+			input: "maybe",
+			fails: true,
+		},
+		{
+			name:   "emails should be resolved to user references",
+			from:   "Email",
+			to:     "User",
+			input:  "user@example.tld",
+			output: "42",
+			outref: 42,
+		},
+		{
+			name:  "unknown emails should fail",
+			from:  "Email",
+			to:    "User",
+			input: "nobody@example.tld",
+			fails: true,
+		},
+		{
+			name:   "references should be converted to strings",
+			from:   "Record",
+			to:     "String",
+			input:  "133569629112020995",
+			output: "133569629112020995",
+		},
+	}
+
+	c := Converter()
+	c.UserResolver(func(_ context.Context, _ store.Storer, email string) (uint64, error) {
+		if email == "user@example.tld" {
+			return 42, nil
+		}
+
+		return 0, nil
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				from = &types.ModuleField{Name: "old", Kind: tt.from}
+				to   = &types.ModuleField{Name: "new", Kind: tt.to}
+			)
+
+			out, err := c.Run(context.Background(), nil, from, to, &types.RecordValue{Name: "old", Value: tt.input})
+			if tt.fails {
+				if err == nil {
+					t.Errorf("expecting conversion of %q to fail", tt.input)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected conversion error: %v", err)
+			}
+
+			if out.Name != "new" {
+				t.Errorf("expecting value to be renamed, got %q", out.Name)
+			}
+
+			if out.Value != tt.output {
+				t.Errorf("expecting value %q, got %q", tt.output, out.Value)
+			}
+
+			if out.Ref != tt.outref {
+				t.Errorf("expecting ref %d, got %d", tt.outref, out.Ref)
+			}
+		})
+	}
+}
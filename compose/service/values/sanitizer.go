@@ -179,6 +179,7 @@ func sDatetime(v interface{}, onlyDate, onlyTime bool) string {
 		internalFormat = datetimeInternalFormatDate
 		inputFormats = []string{
 			datetimeInternalFormatDate,
+			time.RFC3339,
 			"02 Jan 06",
 			"Monday, 02-Jan-06",
 			"Mon, 02 Jan 2006",
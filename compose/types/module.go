@@ -16,6 +16,15 @@ type (
 		Meta   types.JSONText `json:"meta"`
 		Fields ModuleFieldSet `json:"fields"`
 
+		// Storage tells how the records of the module are stored
+		Storage string `json:"storage"`
+
+		// Workflow (state machine) of module's records
+		Workflow *ModuleWorkflow `json:"workflow,omitempty"`
+
+		// Approval flow for changes of module's records
+		Approval *ModuleApproval `json:"approval,omitempty"`
+
 		Labels map[string]string `json:"labels,omitempty"`
 
 		NamespaceID uint64 `json:"namespaceID,string"`
@@ -49,6 +58,17 @@ type (
 	}
 )
 
+const (
+	// ModuleStorageDefault keeps record values in the shared record value table
+	ModuleStorageDefault = ""
+
+	// ModuleStorageDedicated keeps record values in a table of the module
+	//
+	// Each single-value field gets its own typed column; values of multi-value
+	// fields are kept in the shared record value table
+	ModuleStorageDedicated = "dedicated"
+)
+
 // Resource returns a system resource ID for this type
 func (m Module) RBACResource() rbac.Resource {
 	return ModuleRBACResource.AppendID(m.ID)
@@ -58,6 +78,11 @@ func (m Module) DynamicRoles(userID uint64) []uint64 {
 	return nil
 }
 
+// HasDedicatedStorage tells if module's records are stored in module's own table
+func (m Module) HasDedicatedStorage() bool {
+	return m.Storage == ModuleStorageDedicated
+}
+
 func (m Module) Clone() *Module {
 	c := &m
 	c.Fields = m.Fields.Clone()
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// ModuleApproval defines approval flow for changes of module's records
+	//
+	// When approval is required, record update is not applied but stored
+	// as a change request that is applied after it passes all approval steps.
+	ModuleApproval struct {
+		// Condition expression; approval is required only when it evaluates to true
+		//
+		// Expression is evaluated with the same variables as
+		// field value expressions (field values, new & old).
+		// Approval is required for all changes when empty.
+		Condition string `json:"condition,omitempty"`
+
+		// Steps are processed one after another
+		Steps []*ModuleApprovalStep `json:"steps"`
+	}
+
+	// ModuleApprovalStep defines who can approve the change in one step
+	//
+	// Approvers of the step are handled in parallel; by default, one approval
+	// completes the step. When all approvals are required, each of the users
+	// and one member of each of the roles must approve the change.
+	ModuleApprovalStep struct {
+		Name  string   `json:"name,omitempty"`
+		Roles []uint64 `json:"roles,omitempty"`
+		Users []uint64 `json:"users,omitempty"`
+
+		RequireAll bool `json:"requireAll,omitempty"`
+	}
+)
+
+// IsEnabled returns true when module has approval steps defined
+func (a *ModuleApproval) IsEnabled() bool {
+	return a != nil && len(a.Steps) > 0
+}
+
+// Step returns approval step by index
+func (a ModuleApproval) Step(i int) *ModuleApprovalStep {
+	if i < 0 || i >= len(a.Steps) {
+		return nil
+	}
+
+	return a.Steps[i]
+}
+
+// IsApprover checks if user (or any of the roles) can approve the change in this step
+func (s ModuleApprovalStep) IsApprover(userID uint64, roles []uint64) bool {
+	return s.hasUser(userID) || len(s.ApproverRoles(roles)) > 0
+}
+
+// ApproverRoles returns roles of the step that are in the given set
+func (s ModuleApprovalStep) ApproverRoles(roles []uint64) (out []uint64) {
+	for _, a := range s.Roles {
+		for _, r := range roles {
+			if a == r {
+				out = append(out, a)
+				break
+			}
+		}
+	}
+
+	return
+}
+
+// IsComplete checks if step is complete with the given approvals
+func (s ModuleApprovalStep) IsComplete(dd RecordChangeDecisionSet) bool {
+	if !s.RequireAll {
+		return len(dd) > 0
+	}
+
+	for _, userID := range s.Users {
+		if !dd.HasUser(userID) {
+			return false
+		}
+	}
+
+	for _, roleID := range s.Roles {
+		if !dd.hasRole(roleID) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s ModuleApprovalStep) hasUser(userID uint64) bool {
+	for _, u := range s.Users {
+		if u == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (a *ModuleApproval) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*a = ModuleApproval{}
+	case []uint8:
+		b := value.([]byte)
+		if len(b) == 0 {
+			*a = ModuleApproval{}
+			return nil
+		}
+
+		if err := json.Unmarshal(b, a); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into ModuleApproval", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (a ModuleApproval) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
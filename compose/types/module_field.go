@@ -56,6 +56,16 @@ func (m ModuleField) DynamicRoles(userID uint64) []uint64 {
 	return nil
 }
 
+// DynamicRole returns ID of the role that users referenced
+// by the User field get on the record
+func (m ModuleField) DynamicRole() uint64 {
+	if m.Kind != "User" {
+		return 0
+	}
+
+	return m.Options.DynamicRole()
+}
+
 func (m ModuleField) Clone() *ModuleField {
 	return &m
 }
@@ -144,6 +154,13 @@ func (f ModuleField) IsDateTime() bool {
 	return f.Kind == "DateTime"
 }
 
+// IsEncrypted tells us if values of this field are encrypted at rest
+//
+// References are never encrypted
+func (f ModuleField) IsEncrypted() bool {
+	return !f.IsRef() && f.Options.IsEncrypted()
+}
+
 // IsRef tells us if value of this field be a reference to something
 // (another record, file , user)?
 func (f ModuleField) IsRef() bool {
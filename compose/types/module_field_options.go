@@ -17,6 +17,12 @@ const (
 	moduleFieldOptionExpression         = "expression"
 	moduleFieldOptionIsUnique           = "isUnique"
 	moduleFieldOptionIsUniqueMultiValue = "isUniqueMultiValue"
+	moduleFieldOptionEncrypted          = "encrypted"
+
+	moduleFieldRecordOptionModuleID   = "moduleID"
+	moduleFieldRecordOptionLabelField = "labelField"
+
+	moduleFieldUserOptionDynamicRole = "dynamicRole"
 
 	moduleFieldNumberOptionPrecision         = "precision"
 	moduleFieldNumberOptionPrecisionMin uint = 0
@@ -130,6 +136,56 @@ func (opt ModuleFieldOptions) SetIsUniqueMultiValue(value bool) {
 	opt[moduleFieldOptionIsUniqueMultiValue] = value
 }
 
+// IsEncrypted - should values in this field be encrypted at rest?
+func (opt ModuleFieldOptions) IsEncrypted() bool {
+	return opt.Bool(moduleFieldOptionEncrypted)
+}
+
+// SetIsEncrypted - should values in this field be encrypted at rest?
+func (opt ModuleFieldOptions) SetIsEncrypted(value bool) {
+	opt[moduleFieldOptionEncrypted] = value
+}
+
+// ModuleID returns ID of the module referenced by the Record field
+func (opt ModuleFieldOptions) ModuleID() uint64 {
+	if val, has := opt[moduleFieldRecordOptionModuleID]; has {
+		if ID, err := strconv.ParseUint(fmt.Sprintf("%v", val), 10, 64); err == nil {
+			return ID
+		}
+	}
+
+	return 0
+}
+
+// LabelField returns name of the field on the referenced module that
+// is used to display the referenced record
+func (opt ModuleFieldOptions) LabelField() string {
+	return opt.String(moduleFieldRecordOptionLabelField)
+}
+
+// DynamicRole returns ID of the role that is dynamically assigned
+// to users referenced by the User field
+func (opt ModuleFieldOptions) DynamicRole() uint64 {
+	if val, has := opt[moduleFieldUserOptionDynamicRole]; has {
+		if ID, err := strconv.ParseUint(fmt.Sprintf("%v", val), 10, 64); err == nil {
+			return ID
+		}
+	}
+
+	return 0
+}
+
+// HasDynamicRole returns true when dynamic role option is set (valid or not)
+func (opt ModuleFieldOptions) HasDynamicRole() bool {
+	val, has := opt[moduleFieldUserOptionDynamicRole]
+	return has && val != nil && val != ""
+}
+
+// RemoveDynamicRole removes dynamic role from the User field
+func (opt ModuleFieldOptions) RemoveDynamicRole() {
+	delete(opt, moduleFieldUserOptionDynamicRole)
+}
+
 func (opt ModuleFieldOptions) Precision() (p uint) {
 	p = uint(opt.Int64(moduleFieldNumberOptionPrecision))
 
@@ -0,0 +1,143 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// ModuleWorkflow defines state machine for module's records
+	//
+	// Record state is kept in a (single-value) state field and
+	// can only be changed with one of the defined transitions.
+	ModuleWorkflow struct {
+		// Name of the field that holds record state
+		StateField string `json:"stateField"`
+
+		// State of new records when state field is empty
+		Initial string `json:"initial,omitempty"`
+
+		States      []*ModuleWorkflowState      `json:"states"`
+		Transitions []*ModuleWorkflowTransition `json:"transitions"`
+	}
+
+	ModuleWorkflowState struct {
+		Name string `json:"name"`
+
+		// Fields that must have a value when record is in this state
+		Required []string `json:"required,omitempty"`
+	}
+
+	ModuleWorkflowTransition struct {
+		Name string `json:"name,omitempty"`
+
+		// States that transition can be made from; any state when empty
+		From []string `json:"from,omitempty"`
+		To   string   `json:"to"`
+
+		// Guard expression; transition is allowed only when it evaluates to true
+		//
+		// Expression is evaluated with the same variables as
+		// field value expressions (field values, new & old)
+		Guard string `json:"guard,omitempty"`
+
+		// Roles that are allowed to make the transition; anyone when empty
+		Roles []uint64 `json:"roles,omitempty"`
+	}
+)
+
+// IsEnabled returns true when module has a workflow defined
+func (w *ModuleWorkflow) IsEnabled() bool {
+	return w != nil && w.StateField != ""
+}
+
+// State returns state definition by name
+func (w ModuleWorkflow) State(name string) *ModuleWorkflowState {
+	for _, s := range w.States {
+		if s.Name == name {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// TransitionsFrom returns all transitions that can be made from the given state
+func (w ModuleWorkflow) TransitionsFrom(state string) (tt []*ModuleWorkflowTransition) {
+	tt = make([]*ModuleWorkflowTransition, 0)
+	for _, t := range w.Transitions {
+		if t.IsFrom(state) {
+			tt = append(tt, t)
+		}
+	}
+
+	return
+}
+
+// Transition returns transition between the two states
+func (w ModuleWorkflow) Transition(from, to string) *ModuleWorkflowTransition {
+	for _, t := range w.Transitions {
+		if t.To == to && t.IsFrom(from) {
+			return t
+		}
+	}
+
+	return nil
+}
+
+func (t ModuleWorkflowTransition) IsFrom(state string) bool {
+	if len(t.From) == 0 {
+		return true
+	}
+
+	for _, f := range t.From {
+		if f == state {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsAllowedFor checks if any of the roles is allowed to make the transition
+func (t ModuleWorkflowTransition) IsAllowedFor(roles []uint64) bool {
+	if len(t.Roles) == 0 {
+		return true
+	}
+
+	for _, a := range t.Roles {
+		for _, r := range roles {
+			if a == r {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (w *ModuleWorkflow) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*w = ModuleWorkflow{}
+	case []uint8:
+		b := value.([]byte)
+		if len(b) == 0 {
+			*w = ModuleWorkflow{}
+			return nil
+		}
+
+		if err := json.Unmarshal(b, w); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into ModuleWorkflow", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (w ModuleWorkflow) Value() (driver.Value, error) {
+	return json.Marshal(w)
+}
@@ -0,0 +1,241 @@
+package types
+
+import (
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/pkg/errors"
+)
+
+type (
+	// NamespaceVersion holds a snapshot of namespace configuration
+	//
+	// Each namespace can have one draft version where changes are staged
+	// before they are published. Published versions are kept as a history
+	// and can be used to roll back the namespace configuration.
+	NamespaceVersion struct {
+		ID          uint64                 `json:"versionID,string"`
+		NamespaceID uint64                 `json:"namespaceID,string"`
+		Status      NamespaceVersionStatus `json:"status"`
+		Comment     string                 `json:"comment,omitempty"`
+		Config      NamespaceConfig        `json:"config"`
+
+		// Base is a fingerprint of the live configuration the draft was created from
+		//
+		// Draft can not be published when live configuration changed since then
+		Base string `json:"base,omitempty"`
+
+		CreatedAt   time.Time  `json:"createdAt,omitempty"`
+		CreatedBy   uint64     `json:"createdBy,string"`
+		UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+		PublishedAt *time.Time `json:"publishedAt,omitempty"`
+		PublishedBy uint64     `json:"publishedBy,string,omitempty"`
+	}
+
+	NamespaceVersionStatus string
+
+	// NamespaceConfig is a namespace configuration (modules, pages & charts)
+	NamespaceConfig struct {
+		Modules ModuleSet `json:"modules"`
+		Pages   PageSet   `json:"pages"`
+		Charts  ChartSet  `json:"charts"`
+	}
+
+	// NamespaceConfigChange describes change of one resource between two configurations
+	NamespaceConfigChange struct {
+		Resource   string   `json:"resource"`
+		ResourceID uint64   `json:"resourceID,string"`
+		Handle     string   `json:"handle,omitempty"`
+		Change     string   `json:"change"`
+		Properties []string `json:"properties,omitempty"`
+	}
+
+	NamespaceConfigDiff []*NamespaceConfigChange
+
+	NamespaceVersionFilter struct {
+		NamespaceID uint64                 `json:"namespaceID,string"`
+		Status      NamespaceVersionStatus `json:"status"`
+
+		// Check fn is called by store backend for each resource found function can
+		// modify the resource and return false if store should not return it
+		//
+		// Store then loads additional resources to satisfy the paging parameters
+		Check func(*NamespaceVersion) (bool, error) `json:"-"`
+
+		// Standard helpers for paging and sorting
+		filter.Sorting
+		filter.Paging
+	}
+)
+
+const (
+	NamespaceVersionDraft     NamespaceVersionStatus = "draft"
+	NamespaceVersionPublished NamespaceVersionStatus = "published"
+
+	NamespaceConfigAdded   = "added"
+	NamespaceConfigRemoved = "removed"
+	NamespaceConfigChanged = "changed"
+)
+
+func (v NamespaceVersion) IsDraft() bool {
+	return v.Status == NamespaceVersionDraft
+}
+
+// Fingerprint returns a checksum of the configuration
+//
+// Any change of the resources (including timestamps) results in a different fingerprint
+func (cfg NamespaceConfig) Fingerprint() string {
+	enc, _ := json.Marshal(cfg)
+	return fmt.Sprintf("%x", sha256.Sum256(enc))
+}
+
+// Diff compares configuration with the base configuration
+//
+// Resources are matched by their IDs; for changed resources,
+// names of the changed (JSON) properties are reported
+func (cfg NamespaceConfig) Diff(base NamespaceConfig) (diff NamespaceConfigDiff) {
+	diff = NamespaceConfigDiff{}
+
+	for _, m := range cfg.Modules {
+		diff = diff.compare("module", m.ID, m.Handle, base.Modules.FindByID(m.ID), m)
+	}
+
+	for _, m := range base.Modules {
+		if cfg.Modules.FindByID(m.ID) == nil {
+			diff = diff.compare("module", m.ID, m.Handle, m, nil)
+		}
+	}
+
+	for _, p := range cfg.Pages {
+		diff = diff.compare("page", p.ID, p.Handle, base.Pages.FindByID(p.ID), p)
+	}
+
+	for _, p := range base.Pages {
+		if cfg.Pages.FindByID(p.ID) == nil {
+			diff = diff.compare("page", p.ID, p.Handle, p, nil)
+		}
+	}
+
+	for _, c := range cfg.Charts {
+		diff = diff.compare("chart", c.ID, c.Handle, base.Charts.FindByID(c.ID), c)
+	}
+
+	for _, c := range base.Charts {
+		if cfg.Charts.FindByID(c.ID) == nil {
+			diff = diff.compare("chart", c.ID, c.Handle, c, nil)
+		}
+	}
+
+	return
+}
+
+func (diff NamespaceConfigDiff) compare(res string, ID uint64, handle string, old, new interface{}) NamespaceConfigDiff {
+	var (
+		c = &NamespaceConfigChange{Resource: res, ResourceID: ID, Handle: handle}
+
+		oldProps = configProperties(old)
+		newProps = configProperties(new)
+	)
+
+	switch {
+	case oldProps == nil:
+		c.Change = NamespaceConfigAdded
+	case newProps == nil:
+		c.Change = NamespaceConfigRemoved
+	default:
+		for p := range newProps {
+			if string(oldProps[p]) != string(newProps[p]) {
+				c.Properties = append(c.Properties, p)
+			}
+		}
+
+		for p := range oldProps {
+			if _, ok := newProps[p]; !ok {
+				c.Properties = append(c.Properties, p)
+			}
+		}
+
+		if len(c.Properties) == 0 {
+			return diff
+		}
+
+		sort.Strings(c.Properties)
+		c.Change = NamespaceConfigChanged
+	}
+
+	return append(diff, c)
+}
+
+// configProperties returns encoded properties of the resource
+//
+// Timestamps are ignored; they change on each publish
+func configProperties(r interface{}) map[string]json.RawMessage {
+	switch r := r.(type) {
+	case *Module:
+		if r == nil {
+			return nil
+		}
+	case *Page:
+		if r == nil {
+			return nil
+		}
+	case *Chart:
+		if r == nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	var (
+		pp  = make(map[string]json.RawMessage)
+		enc []byte
+	)
+
+	enc, _ = json.Marshal(r)
+	_ = json.Unmarshal(enc, &pp)
+
+	for _, p := range []string{"createdAt", "updatedAt", "deletedAt", "children"} {
+		delete(pp, p)
+	}
+
+	if ff, ok := pp["fields"]; ok {
+		// remove timestamps from module fields as well
+		var fields []map[string]json.RawMessage
+		if json.Unmarshal(ff, &fields) == nil {
+			for _, f := range fields {
+				delete(f, "createdAt")
+				delete(f, "updatedAt")
+				delete(f, "deletedAt")
+			}
+
+			pp["fields"], _ = json.Marshal(fields)
+		}
+	}
+
+	return pp
+}
+
+func (cfg *NamespaceConfig) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*cfg = NamespaceConfig{}
+	case []uint8:
+		b := value.([]byte)
+		if err := json.Unmarshal(b, cfg); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into NamespaceConfig", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (cfg NamespaceConfig) Value() (driver.Value, error) {
+	return json.Marshal(cfg)
+}
@@ -9,5 +9,12 @@ type (
 		ContentPlain      string
 		ContentHTML       string
 		RemoteAttachments []string
+
+		// System template used to render subject & content
+		//
+		// Rendered subject is used only when subject is not set
+		Template  string
+		Language  string
+		Variables map[string]interface{}
 	}
 )
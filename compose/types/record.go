@@ -49,6 +49,9 @@ type (
 		UpdatedBy uint64     `json:"updatedBy,string,omitempty" `
 		DeletedAt *time.Time `json:"deletedAt,omitempty"`
 		DeletedBy uint64     `json:"deletedBy,string,omitempty" `
+
+		// Module of the record, used for resolving dynamic roles from user fields
+		module *Module
 	}
 
 	RecordFilter struct {
@@ -67,6 +70,12 @@ type (
 		// Store then loads additional resources to satisfy the paging parameters
 		Check func(*Record) (bool, error) `json:"-"`
 
+		// BlindIndexes fn is called by store backend for values that encrypted fields
+		// are compared to; values are compared to the returned blind indexes
+		//
+		// Encrypted fields can not be used in the query when fn is not set
+		BlindIndexes func(string) []string `json:"-"`
+
 		// Standard helpers for paging and sorting
 		filter.Sorting
 		filter.Paging
@@ -108,14 +117,50 @@ func (r Record) RBACResource() rbac.Resource {
 	return ModuleRBACResource.AppendID(r.ModuleID)
 }
 
+// DynamicRoles returns roles user has on this record
+//
+// Besides owner, creator, updater and deleter, roles from user fields
+// with dynamic role are included when record is bound to its module (see WithModule)
 func (r Record) DynamicRoles(userID uint64) []uint64 {
-	return rbac.DynamicRoles(
+	rr := rbac.DynamicRoles(
 		userID,
 		r.OwnedBy, rbac.OwnersDynamicRoleID,
 		r.CreatedBy, rbac.CreatorsDynamicRoleID,
 		r.UpdatedBy, rbac.UpdatersDynamicRoleID,
 		r.DeletedBy, rbac.DeletersDynamicRoleID,
 	)
+
+	if r.module == nil || userID == 0 {
+		return rr
+	}
+
+	var (
+		strUserID = strconv.FormatUint(userID, 10)
+	)
+
+	for _, f := range r.module.Fields {
+		roleID := f.DynamicRole()
+		if roleID == 0 {
+			continue
+		}
+
+		for _, v := range r.Values.FilterByName(f.Name) {
+			if v.Value == strUserID {
+				rr = append(rr, roleID)
+				break
+			}
+		}
+	}
+
+	return rr
+}
+
+// WithModule returns copy of the record bound to the module
+//
+// Module's user fields with dynamic role are then considered in DynamicRoles
+func (r Record) WithModule(m *Module) *Record {
+	r.module = m
+	return &r
 }
 
 func (r Record) Dict(m *Module) map[string]interface{} {
@@ -0,0 +1,227 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/pkg/errors"
+)
+
+type (
+	// RecordChangeRequest holds record change that is waiting for approval
+	//
+	// Change is applied to the record when it is approved
+	// in all approval steps defined on the module.
+	RecordChangeRequest struct {
+		ID          uint64                    `json:"changeRequestID,string"`
+		NamespaceID uint64                    `json:"namespaceID,string"`
+		ModuleID    uint64                    `json:"moduleID,string"`
+		RecordID    uint64                    `json:"recordID,string"`
+		Status      RecordChangeRequestStatus `json:"status"`
+
+		// Index of the current approval step
+		Step int `json:"step"`
+
+		Change    RecordChange            `json:"change"`
+		Decisions RecordChangeDecisionSet `json:"decisions"`
+
+		CreatedAt   time.Time  `json:"createdAt,omitempty"`
+		CreatedBy   uint64     `json:"createdBy,string"`
+		UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+		CompletedAt *time.Time `json:"completedAt,omitempty"`
+		CompletedBy uint64     `json:"completedBy,string,omitempty"`
+	}
+
+	RecordChangeRequestStatus string
+
+	// RecordChange describes proposed changes of record values
+	RecordChange struct {
+		// Names of changed fields
+		Fields []string `json:"fields"`
+
+		// Proposed values of changed fields; field is cleared when it has no values
+		Values RecordValueSet `json:"values"`
+
+		// Values of changed fields at the time of the request
+		OldValues RecordValueSet `json:"oldValues"`
+
+		// Record is deleted when the change is approved
+		Delete bool `json:"delete,omitempty"`
+	}
+
+	// RecordChangeDecision is an approval or rejection of the change request
+	RecordChangeDecision struct {
+		Step     int    `json:"step"`
+		UserID   uint64 `json:"userID,string"`
+		Approved bool   `json:"approved"`
+		Comment  string `json:"comment,omitempty"`
+
+		// Roles of the step the user approved the change for
+		Roles []uint64 `json:"roles,omitempty"`
+
+		CreatedAt time.Time `json:"createdAt"`
+	}
+
+	RecordChangeDecisionSet []*RecordChangeDecision
+
+	RecordChangeRequestFilter struct {
+		NamespaceID uint64                    `json:"namespaceID,string"`
+		ModuleID    uint64                    `json:"moduleID,string"`
+		RecordID    uint64                    `json:"recordID,string"`
+		Status      RecordChangeRequestStatus `json:"status"`
+		CreatedBy   uint64                    `json:"createdBy,string"`
+
+		// Only pending requests current user can approve or reject
+		Approvable bool `json:"approvable"`
+
+		// Check fn is called by store backend for each resource found function can
+		// modify the resource and return false if store should not return it
+		//
+		// Store then loads additional resources to satisfy the paging parameters
+		Check func(*RecordChangeRequest) (bool, error) `json:"-"`
+
+		// Standard helpers for paging and sorting
+		filter.Sorting
+		filter.Paging
+	}
+)
+
+const (
+	RecordChangeRequestPending  RecordChangeRequestStatus = "pending"
+	RecordChangeRequestApproved RecordChangeRequestStatus = "approved"
+	RecordChangeRequestRejected RecordChangeRequestStatus = "rejected"
+	RecordChangeRequestCanceled RecordChangeRequestStatus = "canceled"
+
+	// Change was approved but record was changed after the request
+	// and the change was not applied
+	RecordChangeRequestConflicted RecordChangeRequestStatus = "conflicted"
+)
+
+func (cr RecordChangeRequest) IsPending() bool {
+	return cr.Status == RecordChangeRequestPending
+}
+
+// Apply returns values of the record with the proposed changes
+func (c RecordChange) Apply(vv RecordValueSet) (out RecordValueSet) {
+	out = make(RecordValueSet, 0, len(vv))
+	for _, v := range vv.GetClean() {
+		if !c.HasField(v.Name) {
+			out = append(out, v.Clone())
+		}
+	}
+
+	return append(out, c.Values.Clone()...)
+}
+
+// Conflicts checks if values of the changed fields differ from the values at the time of the request
+//
+// Values are compared in order of their places
+func (c RecordChange) Conflicts(vv RecordValueSet) bool {
+	for _, name := range c.Fields {
+		var (
+			cur = vv.GetClean().FilterByName(name)
+			old = c.OldValues.FilterByName(name)
+		)
+
+		if len(cur) != len(old) {
+			return true
+		}
+
+		sort.Sort(cur)
+		for i := range cur {
+			if cur[i].Value != old[i].Value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (c RecordChange) HasField(name string) bool {
+	for _, f := range c.Fields {
+		if f == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FilterByStep returns decisions made in the approval step
+func (set RecordChangeDecisionSet) FilterByStep(step int) (out RecordChangeDecisionSet) {
+	for _, d := range set {
+		if d.Step == step {
+			out = append(out, d)
+		}
+	}
+
+	return
+}
+
+func (set RecordChangeDecisionSet) HasUser(userID uint64) bool {
+	for _, d := range set {
+		if d.UserID == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (set RecordChangeDecisionSet) hasRole(roleID uint64) bool {
+	for _, d := range set {
+		for _, r := range d.Roles {
+			if r == roleID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (c *RecordChange) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*c = RecordChange{}
+	case []uint8:
+		b := value.([]byte)
+		if err := json.Unmarshal(b, c); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into RecordChange", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (c RecordChange) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+func (set *RecordChangeDecisionSet) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*set = RecordChangeDecisionSet{}
+	case []uint8:
+		b := value.([]byte)
+		if err := json.Unmarshal(b, set); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into RecordChangeDecisionSet", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (set RecordChangeDecisionSet) Value() (driver.Value, error) {
+	if set == nil {
+		set = RecordChangeDecisionSet{}
+	}
+
+	return json.Marshal(set)
+}
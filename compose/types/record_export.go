@@ -0,0 +1,46 @@
+package types
+
+type (
+	// RecordExport defines what is exported
+	//
+	// Values of referenced records can be exported by following record field:
+	// "account.name" exports value of the name field of the record referenced by
+	// the account field.
+	RecordExport struct {
+		Fields []string `json:"fields"`
+
+		// Replace IDs in record fields with label field values of the referenced records
+		Resolve bool `json:"resolve"`
+
+		Children []*RecordExportChild `json:"children,omitempty"`
+	}
+
+	// RecordExportChild defines export of records from a child module,
+	// records that reference the exported (parent) record
+	RecordExportChild struct {
+		// Name of the (JSON) property or (xlsx) sheet
+		// where child records are exported; defaults to module handle
+		Name string `json:"name"`
+
+		// Child module handle or ID
+		Module string `json:"module"`
+
+		// Record field on the child module that references the parent record
+		//
+		// When omitted, first record field that references parent module is used
+		Field string `json:"field"`
+
+		Fields   []string             `json:"fields"`
+		Children []*RecordExportChild `json:"children,omitempty"`
+	}
+
+	// RelatedRecords holds (child) records related to one exported record
+	RelatedRecords struct {
+		Name    string
+		Fields  []string
+		Records RecordSet
+
+		// records related to each of the records in the set, indexed by record ID
+		Related map[uint64][]*RelatedRecords
+	}
+)
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cortezaproject/corteza-server/pkg/rbac"
 	"github.com/stretchr/testify/require"
 )
 
@@ -172,3 +173,30 @@ func TestToBulkOperationsDetermineOperation(t *testing.T) {
 		rr[1].Operation,
 	)
 }
+
+func TestRecord_DynamicRoles(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		m = &Module{Fields: ModuleFieldSet{
+			&ModuleField{Name: "manager", Kind: "User", Options: ModuleFieldOptions{"dynamicRole": "100"}},
+			&ModuleField{Name: "agents", Kind: "User", Multi: true, Options: ModuleFieldOptions{"dynamicRole": "200"}},
+			&ModuleField{Name: "note", Kind: "String", Options: ModuleFieldOptions{"dynamicRole": "300"}},
+		}}
+
+		r = &Record{
+			OwnedBy: 42,
+			Values: RecordValueSet{
+				&RecordValue{Name: "manager", Value: "42"},
+				&RecordValue{Name: "agents", Value: "7", Place: 0},
+				&RecordValue{Name: "agents", Value: "42", Place: 1},
+				&RecordValue{Name: "note", Value: "42"},
+			},
+		}
+	)
+
+	req.Equal([]uint64{rbac.OwnersDynamicRoleID}, r.DynamicRoles(42))
+	req.Equal([]uint64{rbac.OwnersDynamicRoleID, 100, 200}, r.WithModule(m).DynamicRoles(42))
+	req.Equal([]uint64{200}, r.WithModule(m).DynamicRoles(7))
+	req.NotContains(r.WithModule(m).DynamicRoles(0), uint64(100))
+}
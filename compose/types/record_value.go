@@ -22,6 +22,9 @@ type (
 		Place     uint       `json:"-"`
 		DeletedAt *time.Time `json:"deletedAt,omitempty"`
 
+		// Keyed hash of the value of an encrypted field, used for exact-match lookups
+		BlindIndex string `json:"-"`
+
 		Updated  bool   `json:"-"`
 		OldValue string `json:"-"`
 	}
@@ -48,13 +51,16 @@ func (v RecordValue) Clone() *RecordValue {
 		Ref:       v.Ref,
 		Place:     v.Place,
 		DeletedAt: v.DeletedAt,
-		Updated:   v.Updated,
-		OldValue:  v.OldValue,
+
+		BlindIndex: v.BlindIndex,
+
+		Updated:  v.Updated,
+		OldValue: v.OldValue,
 	}
 }
 
 func (set RecordValueSet) Clone() (vv RecordValueSet) {
-	vv = make(RecordValueSet, len(vv))
+	vv = make(RecordValueSet, len(set))
 	for i := range set {
 		vv[i] = set[i].Clone()
 	}
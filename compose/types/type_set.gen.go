@@ -35,6 +35,11 @@ type (
 	// This type is auto-generated.
 	NamespaceSet []*Namespace
 
+	// NamespaceVersionSet slice of NamespaceVersion
+	//
+	// This type is auto-generated.
+	NamespaceVersionSet []*NamespaceVersion
+
 	// PageSet slice of Page
 	//
 	// This type is auto-generated.
@@ -45,6 +50,11 @@ type (
 	// This type is auto-generated.
 	RecordSet []*Record
 
+	// RecordChangeRequestSet slice of RecordChangeRequest
+	//
+	// This type is auto-generated.
+	RecordChangeRequestSet []*RecordChangeRequest
+
 	// RecordValueSet slice of RecordValue
 	//
 	// This type is auto-generated.
@@ -331,6 +341,62 @@ func (set NamespaceSet) IDs() (IDs []uint64) {
 	return
 }
 
+// Walk iterates through every slice item and calls w(NamespaceVersion) err
+//
+// This function is auto-generated.
+func (set NamespaceVersionSet) Walk(w func(*NamespaceVersion) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(NamespaceVersion) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set NamespaceVersionSet) Filter(f func(*NamespaceVersion) (bool, error)) (out NamespaceVersionSet, err error) {
+	var ok bool
+	out = NamespaceVersionSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
+// FindByID finds items from slice by its ID property
+//
+// This function is auto-generated.
+func (set NamespaceVersionSet) FindByID(ID uint64) *NamespaceVersion {
+	for i := range set {
+		if set[i].ID == ID {
+			return set[i]
+		}
+	}
+
+	return nil
+}
+
+// IDs returns a slice of uint64s from all items in the set
+//
+// This function is auto-generated.
+func (set NamespaceVersionSet) IDs() (IDs []uint64) {
+	IDs = make([]uint64, len(set))
+
+	for i := range set {
+		IDs[i] = set[i].ID
+	}
+
+	return
+}
+
 // Walk iterates through every slice item and calls w(Page) err
 //
 // This function is auto-generated.
@@ -443,6 +509,62 @@ func (set RecordSet) IDs() (IDs []uint64) {
 	return
 }
 
+// Walk iterates through every slice item and calls w(RecordChangeRequest) err
+//
+// This function is auto-generated.
+func (set RecordChangeRequestSet) Walk(w func(*RecordChangeRequest) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(RecordChangeRequest) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set RecordChangeRequestSet) Filter(f func(*RecordChangeRequest) (bool, error)) (out RecordChangeRequestSet, err error) {
+	var ok bool
+	out = RecordChangeRequestSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
+// FindByID finds items from slice by its ID property
+//
+// This function is auto-generated.
+func (set RecordChangeRequestSet) FindByID(ID uint64) *RecordChangeRequest {
+	for i := range set {
+		if set[i].ID == ID {
+			return set[i]
+		}
+	}
+
+	return nil
+}
+
+// IDs returns a slice of uint64s from all items in the set
+//
+// This function is auto-generated.
+func (set RecordChangeRequestSet) IDs() (IDs []uint64) {
+	IDs = make([]uint64, len(set))
+
+	for i := range set {
+		IDs[i] = set[i].ID
+	}
+
+	return
+}
+
 // Walk iterates through every slice item and calls w(RecordValue) err
 //
 // This function is auto-generated.
@@ -464,6 +464,96 @@ func TestNamespaceSetIDs(t *testing.T) {
 	}
 }
 
+func TestNamespaceVersionSetWalk(t *testing.T) {
+	var (
+		value = make(NamespaceVersionSet, 3)
+		req   = require.New(t)
+	)
+
+	// check walk with no errors
+	{
+		err := value.Walk(func(*NamespaceVersion) error {
+			return nil
+		})
+		req.NoError(err)
+	}
+
+	// check walk with error
+	req.Error(value.Walk(func(*NamespaceVersion) error { return fmt.Errorf("walk error") }))
+}
+
+func TestNamespaceVersionSetFilter(t *testing.T) {
+	var (
+		value = make(NamespaceVersionSet, 3)
+		req   = require.New(t)
+	)
+
+	// filter nothing
+	{
+		set, err := value.Filter(func(*NamespaceVersion) (bool, error) {
+			return true, nil
+		})
+		req.NoError(err)
+		req.Equal(len(set), len(value))
+	}
+
+	// filter one item
+	{
+		found := false
+		set, err := value.Filter(func(*NamespaceVersion) (bool, error) {
+			if !found {
+				found = true
+				return found, nil
+			}
+			return false, nil
+		})
+		req.NoError(err)
+		req.Len(set, 1)
+	}
+
+	// filter error
+	{
+		_, err := value.Filter(func(*NamespaceVersion) (bool, error) {
+			return false, fmt.Errorf("filter error")
+		})
+		req.Error(err)
+	}
+}
+
+func TestNamespaceVersionSetIDs(t *testing.T) {
+	var (
+		value = make(NamespaceVersionSet, 3)
+		req   = require.New(t)
+	)
+
+	// construct objects
+	value[0] = new(NamespaceVersion)
+	value[1] = new(NamespaceVersion)
+	value[2] = new(NamespaceVersion)
+	// set ids
+	value[0].ID = 1
+	value[1].ID = 2
+	value[2].ID = 3
+
+	// Find existing
+	{
+		val := value.FindByID(2)
+		req.Equal(uint64(2), val.ID)
+	}
+
+	// Find non-existing
+	{
+		val := value.FindByID(4)
+		req.Nil(val)
+	}
+
+	// List IDs from set
+	{
+		val := value.IDs()
+		req.Equal(len(val), len(value))
+	}
+}
+
 func TestPageSetWalk(t *testing.T) {
 	var (
 		value = make(PageSet, 3)
@@ -644,6 +734,96 @@ func TestRecordSetIDs(t *testing.T) {
 	}
 }
 
+func TestRecordChangeRequestSetWalk(t *testing.T) {
+	var (
+		value = make(RecordChangeRequestSet, 3)
+		req   = require.New(t)
+	)
+
+	// check walk with no errors
+	{
+		err := value.Walk(func(*RecordChangeRequest) error {
+			return nil
+		})
+		req.NoError(err)
+	}
+
+	// check walk with error
+	req.Error(value.Walk(func(*RecordChangeRequest) error { return fmt.Errorf("walk error") }))
+}
+
+func TestRecordChangeRequestSetFilter(t *testing.T) {
+	var (
+		value = make(RecordChangeRequestSet, 3)
+		req   = require.New(t)
+	)
+
+	// filter nothing
+	{
+		set, err := value.Filter(func(*RecordChangeRequest) (bool, error) {
+			return true, nil
+		})
+		req.NoError(err)
+		req.Equal(len(set), len(value))
+	}
+
+	// filter one item
+	{
+		found := false
+		set, err := value.Filter(func(*RecordChangeRequest) (bool, error) {
+			if !found {
+				found = true
+				return found, nil
+			}
+			return false, nil
+		})
+		req.NoError(err)
+		req.Len(set, 1)
+	}
+
+	// filter error
+	{
+		_, err := value.Filter(func(*RecordChangeRequest) (bool, error) {
+			return false, fmt.Errorf("filter error")
+		})
+		req.Error(err)
+	}
+}
+
+func TestRecordChangeRequestSetIDs(t *testing.T) {
+	var (
+		value = make(RecordChangeRequestSet, 3)
+		req   = require.New(t)
+	)
+
+	// construct objects
+	value[0] = new(RecordChangeRequest)
+	value[1] = new(RecordChangeRequest)
+	value[2] = new(RecordChangeRequest)
+	// set ids
+	value[0].ID = 1
+	value[1].ID = 2
+	value[2].ID = 3
+
+	// Find existing
+	{
+		val := value.FindByID(2)
+		req.Equal(uint64(2), val.ID)
+	}
+
+	// Find non-existing
+	{
+		val := value.FindByID(4)
+		req.Nil(val)
+	}
+
+	// List IDs from set
+	{
+		val := value.IDs()
+		req.Equal(len(val), len(value))
+	}
+}
+
 func TestRecordValueSetWalk(t *testing.T) {
 	var (
 		value = make(RecordValueSet, 3)
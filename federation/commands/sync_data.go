@@ -15,6 +15,9 @@ func commandSyncData(ctx context.Context) func(*cobra.Command, []string) {
 			&service.Syncer{},
 			&service.Mapper{},
 			service.DefaultSharedModule,
+			service.DefaultRecordOrigin,
+			service.DefaultRecordConflict,
+			service.DefaultAttachment,
 			cs.DefaultRecord,
 			ss.DefaultUser,
 			ss.DefaultRole)
@@ -0,0 +1,95 @@
+package handlers
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+//
+
+import (
+	"context"
+	"github.com/cortezaproject/corteza-server/federation/rest/request"
+	"github.com/cortezaproject/corteza-server/pkg/api"
+	"github.com/go-chi/chi"
+	"net/http"
+)
+
+type (
+	// Internal API interface
+	RecordConflictAPI interface {
+		List(context.Context, *request.RecordConflictList) (interface{}, error)
+		Read(context.Context, *request.RecordConflictRead) (interface{}, error)
+		Resolve(context.Context, *request.RecordConflictResolve) (interface{}, error)
+	}
+
+	// HTTP API interface
+	RecordConflict struct {
+		List    func(http.ResponseWriter, *http.Request)
+		Read    func(http.ResponseWriter, *http.Request)
+		Resolve func(http.ResponseWriter, *http.Request)
+	}
+)
+
+func NewRecordConflict(h RecordConflictAPI) *RecordConflict {
+	return &RecordConflict{
+		List: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordConflictList()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.List(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		Read: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordConflictRead()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.Read(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		Resolve: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordConflictResolve()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.Resolve(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+	}
+}
+
+func (h RecordConflict) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares...)
+		r.Get("/nodes/{nodeID}/conflicts/", h.List)
+		r.Get("/nodes/{nodeID}/conflicts/{conflictID}", h.Read)
+		r.Post("/nodes/{nodeID}/conflicts/{conflictID}/resolve", h.Resolve)
+	})
+}
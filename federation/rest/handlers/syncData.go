@@ -21,12 +21,16 @@ type (
 	SyncDataAPI interface {
 		ReadExposedAll(context.Context, *request.SyncDataReadExposedAll) (interface{}, error)
 		ReadExposed(context.Context, *request.SyncDataReadExposed) (interface{}, error)
+		ReadExposedAttachment(context.Context, *request.SyncDataReadExposedAttachment) (interface{}, error)
+		ReceiveExposed(context.Context, *request.SyncDataReceiveExposed) (interface{}, error)
 	}
 
 	// HTTP API interface
 	SyncData struct {
-		ReadExposedAll func(http.ResponseWriter, *http.Request)
-		ReadExposed    func(http.ResponseWriter, *http.Request)
+		ReadExposedAll        func(http.ResponseWriter, *http.Request)
+		ReadExposed           func(http.ResponseWriter, *http.Request)
+		ReadExposedAttachment func(http.ResponseWriter, *http.Request)
+		ReceiveExposed        func(http.ResponseWriter, *http.Request)
 	}
 )
 
@@ -62,6 +66,38 @@ func NewSyncData(h SyncDataAPI) *SyncData {
 				return
 			}
 
+			api.Send(w, r, value)
+		},
+		ReadExposedAttachment: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewSyncDataReadExposedAttachment()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.ReadExposedAttachment(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		ReceiveExposed: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewSyncDataReceiveExposed()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.ReceiveExposed(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
 			api.Send(w, r, value)
 		},
 	}
@@ -72,5 +108,7 @@ func (h SyncData) MountRoutes(r chi.Router, middlewares ...func(http.Handler) ht
 		r.Use(middlewares...)
 		r.Get("/nodes/{nodeID}/modules/exposed/records/", h.ReadExposedAll)
 		r.Get("/nodes/{nodeID}/modules/{moduleID}/records/", h.ReadExposed)
+		r.Get("/nodes/{nodeID}/modules/{moduleID}/attachments/{attachmentID}/", h.ReadExposedAttachment)
+		r.Post("/nodes/{nodeID}/modules/{moduleID}/records/", h.ReceiveExposed)
 	})
 }
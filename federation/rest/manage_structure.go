@@ -38,6 +38,7 @@ func (ctrl ManageStructure) CreateExposed(ctx context.Context, r *request.Manage
 			Name:               r.Name,
 			Handle:             r.Handle,
 			Fields:             r.Fields,
+			RecordFilter:       r.RecordFilter,
 		}
 	)
 
@@ -60,6 +61,7 @@ func (ctrl ManageStructure) UpdateExposed(ctx context.Context, r *request.Manage
 			Name:               r.Name,
 			Handle:             r.Handle,
 			Fields:             r.Fields,
+			RecordFilter:       r.RecordFilter,
 		}
 	)
 
@@ -84,6 +86,7 @@ func (ctrl ManageStructure) CreateMappings(ctx context.Context, r *request.Manag
 		ComposeModuleID:    r.ComposeModuleID,
 		ComposeNamespaceID: r.ComposeNamespaceID,
 		FieldMapping:       r.Fields,
+		ConflictPolicy:     r.ConflictPolicy,
 	}
 
 	// check if it exists, do an upsert
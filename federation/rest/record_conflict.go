@@ -0,0 +1,58 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/cortezaproject/corteza-server/federation/rest/request"
+	"github.com/cortezaproject/corteza-server/federation/service"
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+)
+
+type (
+	RecordConflict struct{}
+
+	recordConflictSetPayload struct {
+		Filter types.RecordConflictFilter `json:"filter"`
+		Set    types.RecordConflictSet    `json:"set"`
+	}
+)
+
+func (RecordConflict) New() *RecordConflict {
+	return &RecordConflict{}
+}
+
+func (ctrl RecordConflict) List(ctx context.Context, r *request.RecordConflictList) (interface{}, error) {
+	var (
+		err error
+		f   = types.RecordConflictFilter{
+			NodeID:   r.NodeID,
+			RecordID: r.RecordID,
+			Resolved: filter.State(r.Resolved),
+		}
+	)
+
+	if f.Paging, err = filter.NewPaging(r.Limit, r.PageCursor); err != nil {
+		return nil, err
+	}
+
+	if f.Sorting, err = filter.NewSorting(r.Sort); err != nil {
+		return nil, err
+	}
+
+	set, f, err := service.DefaultRecordConflict.Find(ctx, f)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return recordConflictSetPayload{Filter: f, Set: set}, nil
+}
+
+func (ctrl RecordConflict) Read(ctx context.Context, r *request.RecordConflictRead) (interface{}, error) {
+	return service.DefaultRecordConflict.FindByID(ctx, r.NodeID, r.ConflictID)
+}
+
+func (ctrl RecordConflict) Resolve(ctx context.Context, r *request.RecordConflictResolve) (interface{}, error) {
+	return service.DefaultRecordConflict.Resolve(ctx, r.NodeID, r.ConflictID, r.Resolution)
+}
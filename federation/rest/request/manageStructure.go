@@ -72,6 +72,11 @@ type (
 		//
 		// Exposed module fields
 		Fields types.ModuleFieldSet
+
+		// RecordFilter POST parameter
+		//
+		// Filter for the exposed records
+		RecordFilter string
 	}
 
 	ManageStructureUpdateExposed struct {
@@ -109,6 +114,11 @@ type (
 		//
 		// Exposed module fields
 		Fields types.ModuleFieldSet
+
+		// RecordFilter POST parameter
+		//
+		// Filter for the exposed records
+		RecordFilter string
 	}
 
 	ManageStructureRemoveExposed struct {
@@ -160,6 +170,11 @@ type (
 		//
 		// Exposed module fields
 		Fields types.ModuleFieldMappingSet
+
+		// ConflictPolicy POST parameter
+		//
+		// Conflict policy (empty, lastWriterWins or vectorClock)
+		ConflictPolicy string
 	}
 
 	ManageStructureReadMappings struct {
@@ -263,6 +278,7 @@ func (r ManageStructureCreateExposed) Auditable() map[string]interface{} {
 		"name":               r.Name,
 		"handle":             r.Handle,
 		"fields":             r.Fields,
+		"recordFilter":       r.RecordFilter,
 	}
 }
 
@@ -296,6 +312,11 @@ func (r ManageStructureCreateExposed) GetFields() types.ModuleFieldSet {
 	return r.Fields
 }
 
+// Auditable returns all auditable/loggable parameters
+func (r ManageStructureCreateExposed) GetRecordFilter() string {
+	return r.RecordFilter
+}
+
 // Fill processes request and fills internal variables
 func (r *ManageStructureCreateExposed) Fill(req *http.Request) (err error) {
 	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
@@ -350,6 +371,13 @@ func (r *ManageStructureCreateExposed) Fill(req *http.Request) (err error) {
 		//        return err
 		//    }
 		//}
+
+		if val, ok := req.Form["recordFilter"]; ok && len(val) > 0 {
+			r.RecordFilter, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	{
@@ -382,6 +410,7 @@ func (r ManageStructureUpdateExposed) Auditable() map[string]interface{} {
 		"name":               r.Name,
 		"handle":             r.Handle,
 		"fields":             r.Fields,
+		"recordFilter":       r.RecordFilter,
 	}
 }
 
@@ -420,6 +449,11 @@ func (r ManageStructureUpdateExposed) GetFields() types.ModuleFieldSet {
 	return r.Fields
 }
 
+// Auditable returns all auditable/loggable parameters
+func (r ManageStructureUpdateExposed) GetRecordFilter() string {
+	return r.RecordFilter
+}
+
 // Fill processes request and fills internal variables
 func (r *ManageStructureUpdateExposed) Fill(req *http.Request) (err error) {
 	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
@@ -474,6 +508,13 @@ func (r *ManageStructureUpdateExposed) Fill(req *http.Request) (err error) {
 		//        return err
 		//    }
 		//}
+
+		if val, ok := req.Form["recordFilter"]; ok && len(val) > 0 {
+			r.RecordFilter, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	{
@@ -624,6 +665,7 @@ func (r ManageStructureCreateMappings) Auditable() map[string]interface{} {
 		"composeModuleID":    r.ComposeModuleID,
 		"composeNamespaceID": r.ComposeNamespaceID,
 		"fields":             r.Fields,
+		"conflictPolicy":     r.ConflictPolicy,
 	}
 }
 
@@ -652,6 +694,11 @@ func (r ManageStructureCreateMappings) GetFields() types.ModuleFieldMappingSet {
 	return r.Fields
 }
 
+// Auditable returns all auditable/loggable parameters
+func (r ManageStructureCreateMappings) GetConflictPolicy() string {
+	return r.ConflictPolicy
+}
+
 // Fill processes request and fills internal variables
 func (r *ManageStructureCreateMappings) Fill(req *http.Request) (err error) {
 	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
@@ -692,6 +739,13 @@ func (r *ManageStructureCreateMappings) Fill(req *http.Request) (err error) {
 		//        return err
 		//    }
 		//}
+
+		if val, ok := req.Form["conflictPolicy"]; ok && len(val) > 0 {
+			r.ConflictPolicy, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	{
@@ -0,0 +1,338 @@
+package request
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+//
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/cortezaproject/corteza-server/pkg/payload"
+	"github.com/go-chi/chi"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// dummy vars to prevent
+// unused imports complain
+var (
+	_ = chi.URLParam
+	_ = multipart.ErrMessageTooLarge
+	_ = payload.ParseUint64s
+)
+
+type (
+	// Internal API interface
+	RecordConflictList struct {
+		// NodeID PATH parameter
+		//
+		// Node ID
+		NodeID uint64 `json:",string"`
+
+		// RecordID GET parameter
+		//
+		// Filter by local record
+		RecordID uint64 `json:",string"`
+
+		// Resolved GET parameter
+		//
+		// Exclude (0, default), include (1) or return only (2) resolved conflicts
+		Resolved uint
+
+		// Limit GET parameter
+		//
+		// Limit
+		Limit uint
+
+		// PageCursor GET parameter
+		//
+		// Page cursor
+		PageCursor string
+
+		// Sort GET parameter
+		//
+		// Sort items
+		Sort string
+	}
+
+	RecordConflictRead struct {
+		// NodeID PATH parameter
+		//
+		// Node ID
+		NodeID uint64 `json:",string"`
+
+		// ConflictID PATH parameter
+		//
+		// Conflict ID
+		ConflictID uint64 `json:",string"`
+	}
+
+	RecordConflictResolve struct {
+		// NodeID PATH parameter
+		//
+		// Node ID
+		NodeID uint64 `json:",string"`
+
+		// ConflictID PATH parameter
+		//
+		// Conflict ID
+		ConflictID uint64 `json:",string"`
+
+		// Resolution POST parameter
+		//
+		// Keep the local or the remote version of the record
+		Resolution string
+	}
+)
+
+// NewRecordConflictList request
+func NewRecordConflictList() *RecordConflictList {
+	return &RecordConflictList{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordConflictList) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"nodeID":     r.NodeID,
+		"recordID":   r.RecordID,
+		"resolved":   r.Resolved,
+		"limit":      r.Limit,
+		"pageCursor": r.PageCursor,
+		"sort":       r.Sort,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordConflictList) GetNodeID() uint64 {
+	return r.NodeID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordConflictList) GetRecordID() uint64 {
+	return r.RecordID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordConflictList) GetResolved() uint {
+	return r.Resolved
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordConflictList) GetLimit() uint {
+	return r.Limit
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordConflictList) GetPageCursor() string {
+	return r.PageCursor
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordConflictList) GetSort() string {
+	return r.Sort
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordConflictList) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		// GET params
+		tmp := req.URL.Query()
+
+		if val, ok := tmp["recordID"]; ok && len(val) > 0 {
+			r.RecordID, err = payload.ParseUint64(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["resolved"]; ok && len(val) > 0 {
+			r.Resolved, err = payload.ParseUint(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["limit"]; ok && len(val) > 0 {
+			r.Limit, err = payload.ParseUint(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["pageCursor"]; ok && len(val) > 0 {
+			r.PageCursor, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["sort"]; ok && len(val) > 0 {
+			r.Sort, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "nodeID")
+		r.NodeID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewRecordConflictRead request
+func NewRecordConflictRead() *RecordConflictRead {
+	return &RecordConflictRead{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordConflictRead) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"nodeID":     r.NodeID,
+		"conflictID": r.ConflictID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordConflictRead) GetNodeID() uint64 {
+	return r.NodeID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordConflictRead) GetConflictID() uint64 {
+	return r.ConflictID
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordConflictRead) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "nodeID")
+		r.NodeID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+		val = chi.URLParam(req, "conflictID")
+		r.ConflictID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewRecordConflictResolve request
+func NewRecordConflictResolve() *RecordConflictResolve {
+	return &RecordConflictResolve{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordConflictResolve) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"nodeID":     r.NodeID,
+		"conflictID": r.ConflictID,
+		"resolution": r.Resolution,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordConflictResolve) GetNodeID() uint64 {
+	return r.NodeID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordConflictResolve) GetConflictID() uint64 {
+	return r.ConflictID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordConflictResolve) GetResolution() string {
+	return r.Resolution
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordConflictResolve) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		if err = req.ParseForm(); err != nil {
+			return err
+		}
+
+		// POST params
+
+		if val, ok := req.Form["resolution"]; ok && len(val) > 0 {
+			r.Resolution, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "nodeID")
+		r.NodeID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+		val = chi.URLParam(req, "conflictID")
+		r.ConflictID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
@@ -11,6 +11,7 @@ package request
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/cortezaproject/corteza-server/pkg/decoder"
 	"github.com/cortezaproject/corteza-server/pkg/payload"
 	"github.com/go-chi/chi"
 	"io"
@@ -97,6 +98,40 @@ type (
 		// Sort items
 		Sort string
 	}
+
+	SyncDataReadExposedAttachment struct {
+		// NodeID PATH parameter
+		//
+		// Node ID
+		NodeID uint64 `json:",string"`
+
+		// ModuleID PATH parameter
+		//
+		// Module ID
+		ModuleID uint64 `json:",string"`
+
+		// AttachmentID PATH parameter
+		//
+		// Attachment ID
+		AttachmentID uint64 `json:",string"`
+	}
+
+	SyncDataReceiveExposed struct {
+		// NodeID PATH parameter
+		//
+		// Node ID
+		NodeID uint64 `json:",string"`
+
+		// ModuleID PATH parameter
+		//
+		// Module ID
+		ModuleID uint64 `json:",string"`
+
+		// Records POST parameter
+		//
+		// Changed records
+		Records decoder.ExposedRecordSet
+	}
 )
 
 // NewSyncDataReadExposedAll request
@@ -332,3 +367,150 @@ func (r *SyncDataReadExposed) Fill(req *http.Request) (err error) {
 
 	return err
 }
+
+// NewSyncDataReadExposedAttachment request
+func NewSyncDataReadExposedAttachment() *SyncDataReadExposedAttachment {
+	return &SyncDataReadExposedAttachment{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r SyncDataReadExposedAttachment) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"nodeID":       r.NodeID,
+		"moduleID":     r.ModuleID,
+		"attachmentID": r.AttachmentID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r SyncDataReadExposedAttachment) GetNodeID() uint64 {
+	return r.NodeID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r SyncDataReadExposedAttachment) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r SyncDataReadExposedAttachment) GetAttachmentID() uint64 {
+	return r.AttachmentID
+}
+
+// Fill processes request and fills internal variables
+func (r *SyncDataReadExposedAttachment) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "nodeID")
+		r.NodeID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+		val = chi.URLParam(req, "moduleID")
+		r.ModuleID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+		val = chi.URLParam(req, "attachmentID")
+		r.AttachmentID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewSyncDataReceiveExposed request
+func NewSyncDataReceiveExposed() *SyncDataReceiveExposed {
+	return &SyncDataReceiveExposed{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r SyncDataReceiveExposed) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"nodeID":   r.NodeID,
+		"moduleID": r.ModuleID,
+		"records":  r.Records,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r SyncDataReceiveExposed) GetNodeID() uint64 {
+	return r.NodeID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r SyncDataReceiveExposed) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r SyncDataReceiveExposed) GetRecords() decoder.ExposedRecordSet {
+	return r.Records
+}
+
+// Fill processes request and fills internal variables
+func (r *SyncDataReceiveExposed) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		if err = req.ParseForm(); err != nil {
+			return err
+		}
+
+		// POST params
+
+		//if val, ok := req.Form["records[]"]; ok && len(val) > 0  {
+		//    r.Records, err = decoder.ExposedRecordSet(val), nil
+		//    if err != nil {
+		//        return err
+		//    }
+		//}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "nodeID")
+		r.NodeID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+		val = chi.URLParam(req, "moduleID")
+		r.ModuleID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
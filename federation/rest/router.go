@@ -25,6 +25,7 @@ func MountRoutes(r chi.Router) {
 
 			handlers.NewSyncData((SyncData{}.New())).MountRoutes(r)
 			handlers.NewSyncStructure((SyncStructure{}.New())).MountRoutes(r)
+			handlers.NewRecordConflict((RecordConflict{}.New())).MountRoutes(r)
 		})
 	})
 }
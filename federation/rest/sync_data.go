@@ -3,7 +3,8 @@ package rest
 import (
 	"context"
 	"fmt"
-	"strings"
+	"net/http"
+	"net/url"
 	"time"
 
 	cs "github.com/cortezaproject/corteza-server/compose/service"
@@ -11,9 +12,8 @@ import (
 	"github.com/cortezaproject/corteza-server/federation/rest/request"
 	"github.com/cortezaproject/corteza-server/federation/service"
 	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/pkg/decoder"
 	"github.com/cortezaproject/corteza-server/pkg/filter"
-	ss "github.com/cortezaproject/corteza-server/system/service"
-	st "github.com/cortezaproject/corteza-server/system/types"
 )
 
 type (
@@ -28,9 +28,13 @@ type (
 		CanDeleteRecord bool `json:"canDeleteRecord"`
 	}
 
-	listRecordResponse struct {
-		Filter *ct.RecordFilter `json:"filter,omitempty"`
-		Set    *ct.RecordSet    `json:"set"`
+	listExposedRecordResponse struct {
+		Filter *ct.RecordFilter         `json:"filter,omitempty"`
+		Set    decoder.ExposedRecordSet `json:"set"`
+	}
+
+	receiveResponse struct {
+		Processed int `json:"processed"`
 	}
 
 	listResponse struct {
@@ -67,10 +71,10 @@ func (ctrl SyncData) ReadExposedAll(ctx context.Context, r *request.SyncDataRead
 		return nil, err
 	}
 
-	composeModuleList := make(map[uint64][]uint64, len(s))
+	composeModuleList := make(map[uint64]*types.ExposedModule, len(s))
 
 	err = s.Walk(func(em *types.ExposedModule) error {
-		composeModuleList[em.ComposeModuleID] = []uint64{em.ComposeNamespaceID, em.ID}
+		composeModuleList[em.ComposeModuleID] = em
 		return nil
 	})
 
@@ -78,14 +82,13 @@ func (ctrl SyncData) ReadExposedAll(ctx context.Context, r *request.SyncDataRead
 		return nil, err
 	}
 
-	recordQuery := buildLastSyncQuery(r.LastSync)
 	responseSet := responseSet{}
 
-	for composeModuleID, idList := range composeModuleList {
+	for composeModuleID, em := range composeModuleList {
 		rf := ct.RecordFilter{
-			NamespaceID: idList[0],
+			NamespaceID: em.ComposeNamespaceID,
 			ModuleID:    composeModuleID,
-			Query:       recordQuery,
+			Query:       buildRecordQuery(em, r.LastSync),
 			Paging:      filter.Paging{Limit: 1},
 		}
 
@@ -98,7 +101,7 @@ func (ctrl SyncData) ReadExposedAll(ctx context.Context, r *request.SyncDataRead
 		moduleResponse := &moduleResponse{
 			Type: "GET",
 			Rel:  "Federation Module",
-			Href: fmt.Sprintf("/nodes/%d/modules/%d/records/", node.ID, idList[1]),
+			Href: fmt.Sprintf("/nodes/%d/modules/%d/records/", node.ID, em.ID),
 		}
 
 		responseSet = append(responseSet, moduleResponse)
@@ -109,12 +112,12 @@ func (ctrl SyncData) ReadExposedAll(ctx context.Context, r *request.SyncDataRead
 
 func (ctrl SyncData) ReadExposed(ctx context.Context, r *request.SyncDataReadExposed) (interface{}, error) {
 	var (
-		err   error
-		em    *types.ExposedModule
-		users st.UserSet
+		err  error
+		node *types.Node
+		em   *types.ExposedModule
 	)
 
-	if _, err = service.DefaultNode.FindBySharedNodeID(ctx, r.NodeID); err != nil {
+	if node, err = service.DefaultNode.FindBySharedNodeID(ctx, r.NodeID); err != nil {
 		return nil, err
 	}
 
@@ -122,32 +125,9 @@ func (ctrl SyncData) ReadExposed(ctx context.Context, r *request.SyncDataReadExp
 		return nil, err
 	}
 
-	if users, _, err = ss.DefaultUser.With(ctx).Find(st.UserFilter{}); err != nil {
-		return nil, err
-	}
-
-	users, _ = users.Filter(func(u *st.User) (bool, error) {
-		return strings.Contains(u.Handle, "federation_"), nil
-	})
-
-	// TODO - remove once the federation users are doing the persist
-	//        on the data sync, for now, it's superuser
-	users = append(users, &st.User{ID: 10000000000000000})
-
-	query := buildLastSyncQuery(r.LastSync)
-	ignoredUsersQuery := buildIgnoredUsersQuery(users)
-
-	if ignoredUsersQuery != "" {
-		if query != "" {
-			query += fmt.Sprintf(" AND %s", ignoredUsersQuery)
-		} else {
-			query = ignoredUsersQuery
-		}
-	}
-
 	f := ct.RecordFilter{
 		ModuleID: em.ComposeModuleID,
-		Query:    query,
+		Query:    buildRecordQuery(em, r.LastSync),
 		Deleted:  filter.StateInclusive,
 	}
 
@@ -165,64 +145,101 @@ func (ctrl SyncData) ReadExposed(ctx context.Context, r *request.SyncDataReadExp
 		return nil, err
 	}
 
-	// do the actual field filtering
-	err = list.Walk(filterExposedFields(em))
+	// do the actual field filtering and omit the
+	// records that should not be sent to the node
+	set, err := service.DefaultSync.ExposeRecords(ctx, node, em, list)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return listRecordResponse{
-		Set:    &list,
+	return listExposedRecordResponse{
+		Set:    set,
 		Filter: &f,
 	}, nil
 }
 
-func buildLastSyncQuery(ts uint64) string {
-	if ts == 0 {
-		return ""
+// ReadExposedAttachment serves the file that is
+// used by one of the exposed records
+func (ctrl SyncData) ReadExposedAttachment(ctx context.Context, r *request.SyncDataReadExposedAttachment) (interface{}, error) {
+	var (
+		err error
+		em  *types.ExposedModule
+		att *ct.Attachment
+	)
+
+	if _, err = service.DefaultNode.FindBySharedNodeID(ctx, r.NodeID); err != nil {
+		return nil, err
 	}
 
-	t := time.Unix(int64(ts), 0)
+	if em, err = service.DefaultExposedModule.FindByID(ctx, r.NodeID, r.ModuleID); err != nil {
+		return nil, err
+	}
 
-	if t.IsZero() {
-		return ""
+	if att, err = service.DefaultAttachment.FindExposed(ctx, em, r.AttachmentID); err != nil {
+		return nil, err
 	}
 
-	return fmt.Sprintf(
-		"(updated_at >= '%s' OR created_at >= '%s' OR deleted_at >= '%s')",
-		t.UTC().Format(time.RFC3339),
-		t.UTC().Format(time.RFC3339),
-		t.UTC().Format(time.RFC3339))
+	return func(w http.ResponseWriter, req *http.Request) {
+		fh, err := service.DefaultAttachment.OpenOriginal(att)
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if fh == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		name := url.QueryEscape(att.Name)
+
+		w.Header().Add("Content-Disposition", "attachment; filename="+name)
+		http.ServeContent(w, req, name, att.CreatedAt, fh)
+	}, nil
 }
 
-func buildIgnoredUsersQuery(users st.UserSet) string {
-	query := ""
+// ReceiveExposed handles the record changes that
+// were pushed to us by the origin node
+func (ctrl SyncData) ReceiveExposed(ctx context.Context, r *request.SyncDataReceiveExposed) (interface{}, error) {
+	processed, err := service.DefaultSync.ProcessPushedRecords(ctx, r.NodeID, r.ModuleID, r.Records)
 
-	if len(users) == 0 {
-		return query
+	if err != nil {
+		return nil, err
 	}
 
-	for i, u := range users {
-		query += fmt.Sprintf("createdBy != %d", u.ID)
+	return receiveResponse{Processed: processed}, nil
+}
 
-		if i < len(users)-1 {
-			query += " AND "
-		}
+// buildRecordQuery limits the records to the ones that
+// were changed since the last sync
+//
+// Exposed module's filter is applied on the initial sync only;
+// later on, records that stopped matching the filter are
+// needed so the node is notified about them
+func buildRecordQuery(em *types.ExposedModule, ts uint64) string {
+	if ts > 0 || em.RecordFilter == "" {
+		return buildLastSyncQuery(ts)
 	}
 
-	return fmt.Sprintf("(%s)", query)
+	return fmt.Sprintf("(%s)", em.RecordFilter)
 }
 
-// filterExposedFields omits the fields that are not exposed as defined
-// in the exposed module definition in store
-func filterExposedFields(em *types.ExposedModule) func(r *ct.Record) error {
-	return func(r *ct.Record) error {
-		var err error
-		r.Values, err = r.Values.Filter(func(rv *ct.RecordValue) (bool, error) {
-			return em.Fields.HasField(rv.Name)
-		})
+func buildLastSyncQuery(ts uint64) string {
+	if ts == 0 {
+		return ""
+	}
+
+	t := time.Unix(int64(ts), 0)
 
-		return err
+	if t.IsZero() {
+		return ""
 	}
+
+	return fmt.Sprintf(
+		"(updated_at >= '%s' OR created_at >= '%s' OR deleted_at >= '%s')",
+		t.UTC().Format(time.RFC3339),
+		t.UTC().Format(time.RFC3339),
+		t.UTC().Format(time.RFC3339))
 }
@@ -17,7 +17,7 @@ type (
 	}
 
 	accessControlRBACServicer interface {
-		Can([]uint64, rbac.Resource, rbac.Operation, ...rbac.CheckAccessFunc) bool
+		Can(context.Context, []uint64, rbac.Resource, rbac.Operation, ...rbac.CheckAccessFunc) bool
 		Grant(context.Context, rbac.Whitelist, ...*rbac.Rule) error
 		FindRulesByRoleID(roleID uint64) (rr rbac.RuleSet)
 	}
@@ -101,6 +101,7 @@ func (svc accessControl) can(ctx context.Context, res rbac.Resource, op rbac.Ope
 	}
 
 	return svc.permissions.Can(
+		ctx,
 		append(u.Roles(), res.DynamicRoles(u.Identity())...),
 		res.RBACResource(),
 		op,
@@ -113,6 +114,12 @@ func (svc accessControl) Grant(ctx context.Context, rr ...*rbac.Rule) error {
 		return AccessControlErrNotAllowedToSetPermissions()
 	}
 
+	for _, r := range rr {
+		if err := r.Conditions.Validate(); err != nil {
+			return AccessControlErrInvalidConditions(&accessControlActionProps{rule: r}).Wrap(err)
+		}
+	}
+
 	if err := svc.permissions.Grant(ctx, svc.Whitelist(), rr...); err != nil {
 		return AccessControlErrGeneric().Wrap(err)
 	}
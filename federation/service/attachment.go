@@ -0,0 +1,141 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	cs "github.com/cortezaproject/corteza-server/compose/service"
+	ct "github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/store"
+)
+
+type (
+	attachment struct {
+		store      store.Storer
+		syncer     *Syncer
+		record     cs.RecordService
+		attachment cs.AttachmentService
+		actionlog  actionlog.Recorder
+	}
+
+	AttachmentService interface {
+		FindExposed(ctx context.Context, em *types.ExposedModule, attachmentID uint64) (*ct.Attachment, error)
+		OpenOriginal(att *ct.Attachment) (io.ReadSeeker, error)
+		Transfer(ctx context.Context, n *types.Node, moduleID, originAttachmentID, namespaceID, composeModuleID uint64, fieldName string) (uint64, error)
+	}
+)
+
+func Attachment() AttachmentService {
+	return &attachment{
+		store:      DefaultStore,
+		syncer:     &Syncer{},
+		record:     cs.DefaultRecord,
+		attachment: cs.DefaultAttachment,
+		actionlog:  DefaultActionlog,
+	}
+}
+
+// FindExposed finds the attachment that is used by one of
+// the exposed file fields of the exposed module records
+func (svc attachment) FindExposed(ctx context.Context, em *types.ExposedModule, attachmentID uint64) (*ct.Attachment, error) {
+	var (
+		cc []string
+	)
+
+	att, err := svc.attachment.With(ctx).FindByID(em.ComposeNamespaceID, attachmentID)
+
+	if err != nil || att.Kind != ct.RecordAttachment {
+		return nil, AttachmentErrNotFound()
+	}
+
+	for _, f := range em.Fields {
+		if f.Kind == "File" {
+			cc = append(cc, fmt.Sprintf("%s = '%d'", f.Name, att.ID))
+		}
+	}
+
+	if len(cc) == 0 {
+		return nil, AttachmentErrNotFound()
+	}
+
+	query := fmt.Sprintf("(%s)", strings.Join(cc, " OR "))
+
+	if em.RecordFilter != "" {
+		// files of the records that are not exposed are not exposed either
+		query = fmt.Sprintf("(%s) AND %s", em.RecordFilter, query)
+	}
+
+	set, _, err := svc.record.With(ctx).Find(ct.RecordFilter{
+		NamespaceID: em.ComposeNamespaceID,
+		ModuleID:    em.ComposeModuleID,
+		Query:       query,
+		Paging:      filter.Paging{Limit: 1},
+	})
+
+	if err != nil || len(set) == 0 {
+		return nil, AttachmentErrNotFound()
+	}
+
+	return att, nil
+}
+
+func (svc attachment) OpenOriginal(att *ct.Attachment) (io.ReadSeeker, error) {
+	return svc.attachment.OpenOriginal(att)
+}
+
+// Transfer copies the attachment from the node to the local object store
+//
+// Attachments are copied only once; ID of the already
+// copied attachment is returned on any subsequent calls
+func (svc attachment) Transfer(ctx context.Context, n *types.Node, moduleID, originAttachmentID, namespaceID, composeModuleID uint64, fieldName string) (uint64, error) {
+	var (
+		att *ct.Attachment
+
+		ao     = &types.AttachmentOrigin{NodeID: n.ID, OriginAttachmentID: originAttachmentID}
+		aProps = &attachmentActionProps{node: n, origin: ao}
+	)
+
+	existing, err := store.LookupFederationAttachmentOriginByNodeIDOriginAttachmentID(ctx, svc.store, n.ID, originAttachmentID)
+
+	if err == nil {
+		return existing.AttachmentID, nil
+	}
+
+	if !errors.Is(err, store.ErrNotFound) {
+		return 0, err
+	}
+
+	err = func() error {
+		url := fmt.Sprintf("%s/nodes/%d/modules/%d/attachments/%d/", n.BaseURL, n.SharedNodeID, moduleID, originAttachmentID)
+
+		// use the authToken from node pairing
+		name, content, err := svc.syncer.FetchFile(context.WithValue(ctx, FederationUserToken, n.AuthToken), url)
+
+		if err != nil {
+			return AttachmentErrFailedToTransfer(aProps).Wrap(err)
+		}
+
+		att, err = svc.attachment.With(ctx).CreateRecordAttachment(namespaceID, name, int64(len(content)), bytes.NewReader(content), composeModuleID, 0, fieldName)
+
+		if err != nil {
+			return AttachmentErrFailedToTransfer(aProps).Wrap(err)
+		}
+
+		ao.AttachmentID = att.ID
+
+		return store.CreateFederationAttachmentOrigin(ctx, svc.store, ao)
+	}()
+
+	if err != nil {
+		return 0, svc.recordAction(ctx, aProps, AttachmentActionTransfer, err)
+	}
+
+	return ao.AttachmentID, svc.recordAction(ctx, aProps, AttachmentActionTransfer, nil)
+}
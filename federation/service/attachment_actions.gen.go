@@ -0,0 +1,346 @@
+package service
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+// federation/service/attachment_actions.yaml
+
+import (
+	"context"
+	"fmt"
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"strings"
+	"time"
+)
+
+type (
+	attachmentActionProps struct {
+		node   *types.Node
+		origin *types.AttachmentOrigin
+	}
+
+	attachmentAction struct {
+		timestamp time.Time
+		resource  string
+		action    string
+		log       string
+		severity  actionlog.Severity
+
+		// prefix for error when action fails
+		errorMessage string
+
+		props *attachmentActionProps
+	}
+
+	attachmentLogMetaKey   struct{}
+	attachmentPropsMetaKey struct{}
+)
+
+var (
+	// just a placeholder to cover template cases w/o fmt package use
+	_ = fmt.Println
+)
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Props methods
+// setNode updates attachmentActionProps's node
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *attachmentActionProps) setNode(node *types.Node) *attachmentActionProps {
+	p.node = node
+	return p
+}
+
+// setOrigin updates attachmentActionProps's origin
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *attachmentActionProps) setOrigin(origin *types.AttachmentOrigin) *attachmentActionProps {
+	p.origin = origin
+	return p
+}
+
+// Serialize converts attachmentActionProps to actionlog.Meta
+//
+// This function is auto-generated.
+//
+func (p attachmentActionProps) Serialize() actionlog.Meta {
+	var (
+		m = make(actionlog.Meta)
+	)
+
+	if p.node != nil {
+		m.Set("node.ID", p.node.ID, true)
+		m.Set("node.Name", p.node.Name, true)
+	}
+	if p.origin != nil {
+		m.Set("origin.AttachmentID", p.origin.AttachmentID, true)
+		m.Set("origin.NodeID", p.origin.NodeID, true)
+		m.Set("origin.OriginAttachmentID", p.origin.OriginAttachmentID, true)
+	}
+
+	return m
+}
+
+// tr translates string and replaces meta value placeholder with values
+//
+// This function is auto-generated.
+//
+func (p attachmentActionProps) Format(in string, err error) string {
+	var (
+		pairs = []string{"{err}"}
+		// first non-empty string
+		fns = func(ii ...interface{}) string {
+			for _, i := range ii {
+				if s := fmt.Sprintf("%v", i); len(s) > 0 {
+					return s
+				}
+			}
+
+			return ""
+		}
+	)
+
+	if err != nil {
+		pairs = append(pairs, err.Error())
+	} else {
+		pairs = append(pairs, "nil")
+	}
+
+	if p.node != nil {
+		// replacement for "{node}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{node}",
+			fns(
+				p.node.ID,
+				p.node.Name,
+			),
+		)
+		pairs = append(pairs, "{node.ID}", fns(p.node.ID))
+		pairs = append(pairs, "{node.Name}", fns(p.node.Name))
+	}
+
+	if p.origin != nil {
+		// replacement for "{origin}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{origin}",
+			fns(
+				p.origin.AttachmentID,
+				p.origin.NodeID,
+				p.origin.OriginAttachmentID,
+			),
+		)
+		pairs = append(pairs, "{origin.AttachmentID}", fns(p.origin.AttachmentID))
+		pairs = append(pairs, "{origin.NodeID}", fns(p.origin.NodeID))
+		pairs = append(pairs, "{origin.OriginAttachmentID}", fns(p.origin.OriginAttachmentID))
+	}
+	return strings.NewReplacer(pairs...).Replace(in)
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Action methods
+
+// String returns loggable description as string
+//
+// This function is auto-generated.
+//
+func (a *attachmentAction) String() string {
+	var props = &attachmentActionProps{}
+
+	if a.props != nil {
+		props = a.props
+	}
+
+	return props.Format(a.log, nil)
+}
+
+func (e *attachmentAction) ToAction() *actionlog.Action {
+	return &actionlog.Action{
+		Resource:    e.resource,
+		Action:      e.action,
+		Severity:    e.severity,
+		Description: e.String(),
+		Meta:        e.props.Serialize(),
+	}
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Action constructors
+
+// AttachmentActionTransfer returns "federation:attachment.transfer" action
+//
+// This function is auto-generated.
+//
+func AttachmentActionTransfer(props ...*attachmentActionProps) *attachmentAction {
+	a := &attachmentAction{
+		timestamp: time.Now(),
+		resource:  "federation:attachment",
+		action:    "transfer",
+		log:       "transferred attachment from {node}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Error constructors
+
+// AttachmentErrGeneric returns "federation:attachment.generic" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func AttachmentErrGeneric(mm ...*attachmentActionProps) *errors.Error {
+	var p = &attachmentActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("failed to complete request due to internal error", nil),
+
+		errors.Meta("type", "generic"),
+		errors.Meta("resource", "federation:attachment"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(attachmentLogMetaKey{}, "{err}"),
+		errors.Meta(attachmentPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// AttachmentErrNotFound returns "federation:attachment.notFound" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func AttachmentErrNotFound(mm ...*attachmentActionProps) *errors.Error {
+	var p = &attachmentActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("attachment does not exist", nil),
+
+		errors.Meta("type", "notFound"),
+		errors.Meta("resource", "federation:attachment"),
+
+		errors.Meta(attachmentPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// AttachmentErrFailedToTransfer returns "federation:attachment.failedToTransfer" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func AttachmentErrFailedToTransfer(mm ...*attachmentActionProps) *errors.Error {
+	var p = &attachmentActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("could not transfer attachment", nil),
+
+		errors.Meta("type", "failedToTransfer"),
+		errors.Meta("resource", "federation:attachment"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(attachmentLogMetaKey{}, "could not transfer attachment from {node}"),
+		errors.Meta(attachmentPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+
+// recordAction is a service helper function wraps function that can return error
+//
+// It will wrap unrecognized/internal errors with generic errors.
+//
+// This function is auto-generated.
+//
+func (svc attachment) recordAction(ctx context.Context, props *attachmentActionProps, actionFn func(...*attachmentActionProps) *attachmentAction, err error) error {
+	if svc.actionlog == nil || actionFn == nil {
+		// action log disabled or no action fn passed, return error as-is
+		return err
+	} else if err == nil {
+		// action completed w/o error, record it
+		svc.actionlog.Record(ctx, actionFn(props).ToAction())
+		return nil
+	}
+
+	a := actionFn(props).ToAction()
+
+	// Extracting error information and recording it as action
+	a.Error = err.Error()
+
+	switch c := err.(type) {
+	case *errors.Error:
+		m := c.Meta()
+
+		a.Error = err.Error()
+		a.Severity = actionlog.Severity(m.AsInt("severity"))
+		a.Description = props.Format(m.AsString(attachmentLogMetaKey{}), err)
+
+		if p, has := m[attachmentPropsMetaKey{}]; has {
+			a.Meta = p.(*attachmentActionProps).Serialize()
+		}
+
+		svc.actionlog.Record(ctx, a)
+	default:
+		svc.actionlog.Record(ctx, a)
+	}
+
+	// Original error is passed on
+	return err
+}
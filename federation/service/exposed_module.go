@@ -3,12 +3,14 @@ package service
 import (
 	"context"
 	"strconv"
+	"strings"
 
 	cs "github.com/cortezaproject/corteza-server/compose/service"
 	ct "github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/federation/types"
 	"github.com/cortezaproject/corteza-server/pkg/actionlog"
 	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/ql"
 	"github.com/cortezaproject/corteza-server/store"
 )
 
@@ -118,6 +120,10 @@ func (svc exposedModule) Update(ctx context.Context, updated *types.ExposedModul
 			return ExposedModuleErrNotFound()
 		}
 
+		if err = svc.checkRecordFilter(updated); err != nil {
+			return err
+		}
+
 		updated.UpdatedAt = now()
 		updated.CreatedAt = old.CreatedAt
 		updated.UpdatedBy = auth.GetIdentityFromContext(ctx).Identity()
@@ -258,6 +264,10 @@ func (svc exposedModule) Create(ctx context.Context, new *types.ExposedModule) (
 			return err
 		}
 
+		if err = svc.checkRecordFilter(new); err != nil {
+			return err
+		}
+
 		new.ID = nextID()
 		new.CreatedAt = *now()
 		new.CreatedBy = auth.GetIdentityFromContext(ctx).Identity()
@@ -285,6 +295,21 @@ func (svc exposedModule) Create(ctx context.Context, new *types.ExposedModule) (
 	return new, svc.recordAction(ctx, aProps, ExposedModuleActionCreate, err)
 }
 
+// checkRecordFilter makes sure the record filter can be parsed
+// before it is used on the exposed records
+func (svc exposedModule) checkRecordFilter(m *types.ExposedModule) error {
+	if strings.TrimSpace(m.RecordFilter) == "" {
+		m.RecordFilter = ""
+		return nil
+	}
+
+	if _, err := ql.NewParser().ParseExpression(m.RecordFilter); err != nil {
+		return ExposedModuleErrInvalidRecordFilter()
+	}
+
+	return nil
+}
+
 func (svc exposedModule) uniqueCheck(ctx context.Context, m *types.ExposedModule) (err error) {
 	f := types.ExposedModuleFilter{
 		NodeID:             m.NodeID,
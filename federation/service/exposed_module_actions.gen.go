@@ -694,6 +694,36 @@ func ExposedModuleErrComposeNamespaceNotFound(mm ...*exposedModuleActionProps) *
 	return e
 }
 
+// ExposedModuleErrInvalidRecordFilter returns "federation:exposed_module.invalidRecordFilter" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func ExposedModuleErrInvalidRecordFilter(mm ...*exposedModuleActionProps) *errors.Error {
+	var p = &exposedModuleActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid record filter", nil),
+
+		errors.Meta("type", "invalidRecordFilter"),
+		errors.Meta("resource", "federation:exposed_module"),
+
+		errors.Meta(exposedModulePropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
 // ExposedModuleErrNotAllowedToCreate returns "federation:exposed_module.notAllowedToCreate" as *errors.Error
 //
 //
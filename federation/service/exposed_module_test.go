@@ -0,0 +1,63 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExposedModule_checkRecordFilter(t *testing.T) {
+	var (
+		tcc = []struct {
+			name   string
+			filter string
+			out    string
+			err    error
+		}{
+			{
+				"no filter",
+				"",
+				"",
+				nil,
+			},
+			{
+				"whitespace only",
+				"   ",
+				"",
+				nil,
+			},
+			{
+				"valid filter",
+				"region = 'EU' AND status = 'open'",
+				"region = 'EU' AND status = 'open'",
+				nil,
+			},
+			{
+				"invalid filter",
+				"region = 'EU' AND (",
+				"region = 'EU' AND (",
+				ExposedModuleErrInvalidRecordFilter(),
+			},
+		}
+	)
+
+	for _, tc := range tcc {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				req = require.New(t)
+				m   = &types.ExposedModule{RecordFilter: tc.filter}
+				err = (exposedModule{}).checkRecordFilter(m)
+			)
+
+			if tc.err != nil {
+				req.Error(err)
+				req.Equal(tc.err.Error(), err.Error())
+			} else {
+				req.NoError(err)
+			}
+
+			req.Equal(tc.out, m.RecordFilter)
+		})
+	}
+}
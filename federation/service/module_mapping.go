@@ -111,6 +111,10 @@ func (svc moduleMapping) Create(ctx context.Context, new *types.ModuleMapping) (
 			sm *types.SharedModule
 		)
 
+		if !isValidConflictPolicy(new.ConflictPolicy) {
+			return ModuleMappingErrInvalidConflictPolicy()
+		}
+
 		if _, err := svc.namespace.With(ctx).FindByID(new.ComposeNamespaceID); err != nil {
 			return ModuleMappingErrComposeNamespaceNotFound()
 		}
@@ -164,6 +168,10 @@ func (svc moduleMapping) Update(ctx context.Context, updated *types.ModuleMappin
 			sm *types.SharedModule
 		)
 
+		if !isValidConflictPolicy(updated.ConflictPolicy) {
+			return ModuleMappingErrInvalidConflictPolicy()
+		}
+
 		if _, err := svc.namespace.With(ctx).FindByID(updated.ComposeNamespaceID); err != nil {
 			return ModuleMappingErrComposeNamespaceNotFound()
 		}
@@ -212,3 +220,14 @@ func (svc moduleMapping) uniqueCheck(ctx context.Context, m *types.ModuleMapping
 
 	return err
 }
+
+func isValidConflictPolicy(p string) bool {
+	switch p {
+	case types.ModuleMappingConflictPolicyOrigin,
+		types.ModuleMappingConflictPolicyLastWriterWins,
+		types.ModuleMappingConflictPolicyVectorClock:
+		return true
+	}
+
+	return false
+}
@@ -528,6 +528,36 @@ func ModuleMappingErrNodeNotFound(mm ...*moduleMappingActionProps) *errors.Error
 	return e
 }
 
+// ModuleMappingErrInvalidConflictPolicy returns "federation:module_mapping.invalidConflictPolicy" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func ModuleMappingErrInvalidConflictPolicy(mm ...*moduleMappingActionProps) *errors.Error {
+	var p = &moduleMappingActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid conflict policy", nil),
+
+		errors.Meta("type", "invalidConflictPolicy"),
+		errors.Meta("resource", "federation:module_mapping"),
+
+		errors.Meta(moduleMappingPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
 // ModuleMappingErrModuleMappingExists returns "federation:module_mapping.moduleMappingExists" as *errors.Error
 //
 //
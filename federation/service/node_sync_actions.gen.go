@@ -318,6 +318,96 @@ func NodeSyncErrNodeNotFound(mm ...*nodeSyncActionProps) *errors.Error {
 	return e
 }
 
+// NodeSyncErrNotAllowedToPush returns "federation:node_sync.notAllowedToPush" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func NodeSyncErrNotAllowedToPush(mm ...*nodeSyncActionProps) *errors.Error {
+	var p = &nodeSyncActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("not allowed to push changes to this node", nil),
+
+		errors.Meta("type", "notAllowedToPush"),
+		errors.Meta("resource", "federation:node_sync"),
+
+		errors.Meta(nodeSyncPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// NodeSyncErrSharedModuleNotFound returns "federation:node_sync.sharedModuleNotFound" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func NodeSyncErrSharedModuleNotFound(mm ...*nodeSyncActionProps) *errors.Error {
+	var p = &nodeSyncActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("shared module does not exist", nil),
+
+		errors.Meta("type", "sharedModuleNotFound"),
+		errors.Meta("resource", "federation:node_sync"),
+
+		errors.Meta(nodeSyncPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// NodeSyncErrStructureNotSynced returns "federation:node_sync.structureNotSynced" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func NodeSyncErrStructureNotSynced(mm ...*nodeSyncActionProps) *errors.Error {
+	var p = &nodeSyncActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("shared module structure is not synced", nil),
+
+		errors.Meta("type", "structureNotSynced"),
+		errors.Meta("resource", "federation:node_sync"),
+
+		errors.Meta(nodeSyncPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
 // *********************************************************************************************************************
 // *********************************************************************************************************************
 
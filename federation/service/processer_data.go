@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	ct "github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/federation/types"
@@ -19,6 +21,7 @@ type (
 		NodeBaseURL         string
 		ModuleMappings      *types.ModuleFieldMappingSet
 		ModuleMappingValues *ct.RecordValueSet
+		ConflictPolicy      string
 		SyncService         *Sync
 		Node                *types.Node
 		User                *st.User
@@ -42,6 +45,16 @@ func (dp *dataProcesser) Process(ctx context.Context, payload []byte) (Processer
 		}, err
 	}
 
+	return dp.ProcessRecords(ctx, o)
+}
+
+// ProcessRecords persists already decoded exposed records
+//
+// Used by the periodic data sync and for the record changes
+// that are pushed to us by the origin node
+func (dp *dataProcesser) ProcessRecords(ctx context.Context, o []*decoder.ExposedRecord) (ProcesserResponse, error) {
+	processed := 0
+
 	if len(o) == 0 {
 		return dataProcesserResponse{
 			Processed: processed,
@@ -53,17 +66,39 @@ func (dp *dataProcesser) Process(ctx context.Context, payload []byte) (Processer
 	for _, er := range o {
 		var (
 			rec *ct.Record
+			ro  *types.RecordOrigin
 			err error
 		)
 
 		dp.SyncService.mapper.Merge(&er.Values, dp.ModuleMappingValues, dp.ModuleMappings)
 
-		if er.DeletedAt != nil {
-			// find the record
-			if rec, err = dp.findRecordByFederationID(ctx, er.ID, dp.ComposeModuleID, dp.ComposeNamespaceID); err != nil {
+		if er.DeletedAt == nil {
+			dp.resolveReferences(ctx, er)
+		}
+
+		if er.DeletedAt != nil || er.UpdatedAt != nil || er.OriginRecordID != 0 {
+			if rec, err = dp.findRecord(ctx, er); err != nil {
+				// could not find existing record
 				continue
 			}
+		}
+
+		if rec != nil {
+			if ro, err = dp.SyncService.LoadRecordOrigin(ctx, rec); err != nil {
+				continue
+			}
+
+			if er.OriginRecordID == 0 {
+				ro.NodeID = dp.Node.ID
+				ro.OriginRecordID = er.ID
+			}
 
+			if apply, err := dp.resolve(ctx, er, rec, ro); err != nil || !apply {
+				continue
+			}
+		}
+
+		if er.DeletedAt != nil {
 			// Handle edge cases where the data doesn't exist anymore
 			if rec != nil {
 				dp.SyncService.DeleteRecord(ctx, rec)
@@ -73,15 +108,8 @@ func (dp *dataProcesser) Process(ctx context.Context, payload []byte) (Processer
 			continue
 		}
 
-		if er.UpdatedAt != nil {
-			if rec, err = dp.findRecordByFederationID(ctx, er.ID, dp.ComposeModuleID, dp.ComposeNamespaceID); err != nil {
-				// could not find existing record
-				continue
-			}
-
-			if rec != nil {
-				rec.Values = *dp.ModuleMappingValues
-			}
+		if rec != nil {
+			rec.Values = *dp.ModuleMappingValues
 		}
 
 		// if the record was updated on origin, but we somehow do not have it
@@ -93,20 +121,36 @@ func (dp *dataProcesser) Process(ctx context.Context, payload []byte) (Processer
 				Values:      *dp.ModuleMappingValues,
 			}
 
+			ro = &types.RecordOrigin{
+				NodeID:         dp.Node.ID,
+				OriginRecordID: er.ID,
+				Clock:          types.VectorClock{},
+			}
+
 			AddFederationLabel(rec, "federation", dp.NodeBaseURL)
 			AddFederationLabel(rec, "federation_extrecord", fmt.Sprintf("%d", er.ID))
 		}
 
 		if rec.ID != 0 {
-			_, err = dp.SyncService.UpdateRecord(ctx, rec)
+			rec, err = dp.SyncService.UpdateRecord(ctx, rec)
 		} else {
-			_, err = dp.SyncService.CreateRecord(ctx, rec)
+			rec, err = dp.SyncService.CreateRecord(ctx, rec)
 		}
 
 		if err != nil {
 			continue
 		}
 
+		// keep the version of the record, so the same
+		// change is not applied again
+		if rec != nil {
+			ro.Clock.Merge(er.Clock)
+
+			if err = dp.SyncService.SaveRecordOrigin(ctx, rec, ro); err != nil {
+				continue
+			}
+		}
+
 		processed++
 	}
 
@@ -115,6 +159,186 @@ func (dp *dataProcesser) Process(ctx context.Context, payload []byte) (Processer
 	}, nil
 }
 
+// resolve decides if the received change is applied on the local record
+//
+// Changes that are already accounted for are skipped; concurrent
+// changes are handled as defined by the conflict policy of the mapping
+func (dp *dataProcesser) resolve(ctx context.Context, er *decoder.ExposedRecord, rec *ct.Record, ro *types.RecordOrigin) (bool, error) {
+	if er.Clock == nil {
+		// remote node does not track the versions of the
+		// records, only the time of the change can be compared
+		switch dp.ConflictPolicy {
+		case types.ModuleMappingConflictPolicyLastWriterWins:
+			return !recordChangedAt(rec).After(exposedRecordChangedAt(er)), nil
+		case types.ModuleMappingConflictPolicyVectorClock:
+			if er.DeletedAt != nil {
+				return true, nil
+			}
+
+			// change can not be ordered, it is left for manual resolution
+			return false, dp.queueConflict(ctx, er, rec)
+		}
+
+		return true, nil
+	}
+
+	switch er.Clock.Compare(ro.Clock) {
+	case types.VectorClockAfter:
+		return true, nil
+	case types.VectorClockConcurrent:
+		if er.DeletedAt != nil {
+			// record deletion can not be queued
+			return true, nil
+		}
+	default:
+		return false, nil
+	}
+
+	switch dp.ConflictPolicy {
+	case types.ModuleMappingConflictPolicyLastWriterWins:
+		if !recordChangedAt(rec).After(exposedRecordChangedAt(er)) {
+			return true, nil
+		}
+
+		// local version wins; record is updated so that
+		// the remote node gets it on the next sync
+		ro.Clock.Merge(er.Clock)
+
+		rec, err := dp.SyncService.UpdateRecord(ctx, rec)
+
+		if err != nil || rec == nil {
+			return false, err
+		}
+
+		return false, dp.SyncService.SaveRecordOrigin(ctx, rec, ro)
+
+	case types.ModuleMappingConflictPolicyVectorClock:
+		return false, dp.queueConflict(ctx, er, rec)
+	}
+
+	return true, nil
+}
+
+// queueConflict stores the received change for manual resolution
+func (dp *dataProcesser) queueConflict(ctx context.Context, er *decoder.ExposedRecord, rec *ct.Record) error {
+	clock := types.VectorClock{}
+	clock.Merge(er.Clock)
+
+	_, err := dp.SyncService.CreateRecordConflict(ctx, &types.RecordConflict{
+		NodeID:             dp.Node.ID,
+		ModuleID:           dp.ID,
+		RecordID:           rec.ID,
+		ComposeModuleID:    rec.ModuleID,
+		ComposeNamespaceID: rec.NamespaceID,
+		Values:             dp.ModuleMappingValues.Clone(),
+		Clock:              clock,
+	})
+
+	return err
+}
+
+// resolveReferences replaces the IDs in the mapped file, record and
+// user fields with the IDs of their local counterparts
+//
+// Files are transferred from the node, records and users need to be
+// synced already; references that can not be resolved are cleared
+func (dp *dataProcesser) resolveReferences(ctx context.Context, er *decoder.ExposedRecord) {
+	for _, v := range *dp.ModuleMappingValues {
+		var (
+			localID uint64
+			m, _    = dp.ModuleMappings.FindByName(v.Name, types.ModuleFieldMappingSetFindTypeDestination)
+		)
+
+		if m == nil || v.Value == "" {
+			continue
+		}
+
+		refID, _ := strconv.ParseUint(v.Value, 10, 64)
+
+		switch m.Destination.Kind {
+		case "File":
+			localID = dp.resolveAttachmentReference(ctx, v.Name, refID)
+		case "Record":
+			localID = dp.resolveRecordReference(ctx, refID)
+		case "User":
+			localID = dp.resolveUserReference(ctx, er.Users, refID)
+		default:
+			continue
+		}
+
+		v.Value = ""
+
+		if localID > 0 {
+			v.Value = strconv.FormatUint(localID, 10)
+		}
+	}
+}
+
+// resolveAttachmentReference transfers the referenced attachment from the node
+func (dp *dataProcesser) resolveAttachmentReference(ctx context.Context, fieldName string, refID uint64) uint64 {
+	if refID == 0 {
+		return 0
+	}
+
+	attachmentID, err := dp.SyncService.TransferAttachment(ctx, dp.Node, dp.ID, refID, dp.ComposeNamespaceID, dp.ComposeModuleID, fieldName)
+
+	if err != nil {
+		return 0
+	}
+
+	return attachmentID
+}
+
+// resolveRecordReference finds the local copy of the referenced record
+func (dp *dataProcesser) resolveRecordReference(ctx context.Context, refID uint64) uint64 {
+	if refID == 0 {
+		return 0
+	}
+
+	ro, err := dp.SyncService.FindRecordByOrigin(ctx, dp.Node.ID, refID)
+
+	if err != nil || ro == nil {
+		return 0
+	}
+
+	return ro.RecordID
+}
+
+// resolveUserReference finds the local user with the same
+// email as the referenced user on the node
+func (dp *dataProcesser) resolveUserReference(ctx context.Context, uu decoder.ExposedUserSet, refID uint64) uint64 {
+	for _, eu := range uu {
+		if eu.ID != refID {
+			continue
+		}
+
+		if u, err := dp.SyncService.FindUserByEmail(ctx, eu.Email); err == nil {
+			return u.ID
+		}
+	}
+
+	return 0
+}
+
+// findRecord finds the local record that the change was made on
+//
+// Records that were received from us are referenced directly,
+// all the others are found via federation label
+func (dp *dataProcesser) findRecord(ctx context.Context, er *decoder.ExposedRecord) (*ct.Record, error) {
+	if er.OriginRecordID == 0 {
+		return dp.findRecordByFederationID(ctx, er.ID, dp.ComposeModuleID, dp.ComposeNamespaceID)
+	}
+
+	rec, err := dp.SyncService.FindRecordByID(ctx, dp.ComposeNamespaceID, dp.ComposeModuleID, er.OriginRecordID)
+
+	if err != nil {
+		// record was removed in the meantime
+		return nil, nil
+	}
+
+	return rec, nil
+}
+
 // findRecordByFederationID finds any already existing records via
 // federation label
 func (dp *dataProcesser) findRecordByFederationID(ctx context.Context, recordID, moduleID, namespaceID uint64) (r *ct.Record, err error) {
@@ -131,3 +355,15 @@ func (dp *dataProcesser) findRecordByFederationID(ctx context.Context, recordID,
 
 	return
 }
+
+// exposedRecordChangedAt returns the time of the last change of the exposed record
+func exposedRecordChangedAt(er *decoder.ExposedRecord) time.Time {
+	switch {
+	case er.DeletedAt != nil:
+		return *er.DeletedAt
+	case er.UpdatedAt != nil:
+		return *er.UpdatedAt
+	default:
+		return er.CreatedAt
+	}
+}
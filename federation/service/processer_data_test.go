@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	cs "github.com/cortezaproject/corteza-server/compose/service"
 	ct "github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/pkg/decoder"
 	ss "github.com/cortezaproject/corteza-server/system/service"
 	st "github.com/cortezaproject/corteza-server/system/types"
 	"github.com/stretchr/testify/require"
@@ -22,6 +24,25 @@ type (
 	testSharedModuleService struct {
 		SharedModuleService
 	}
+	testRecordOriginService struct {
+		RecordOriginService
+	}
+	testAttachmentService struct {
+		AttachmentService
+	}
+	testReferenceRecordOriginService struct {
+		RecordOriginService
+	}
+	testReferenceAttachmentService struct {
+		AttachmentService
+	}
+	testReferenceUserService struct {
+		ss.UserService
+	}
+	testRecordConflictService struct {
+		RecordConflictService
+		created *types.RecordConflict
+	}
 	testRecordServicePersistSuccess struct {
 		cs.RecordService
 	}
@@ -65,6 +86,9 @@ func TestProcesserData_persist(t *testing.T) {
 					&Syncer{},
 					&Mapper{},
 					&testSharedModuleService{},
+					&testRecordOriginService{},
+					&testRecordConflictService{},
+					&testAttachmentService{},
 					&testRecordServicePersistSuccess{},
 					&testUserService{},
 					&testRoleService{}),
@@ -80,6 +104,9 @@ func TestProcesserData_persist(t *testing.T) {
 					&Syncer{},
 					&Mapper{},
 					&testSharedModuleService{},
+					&testRecordOriginService{},
+					&testRecordConflictService{},
+					&testAttachmentService{},
 					&testRecordServiceUpdateSuccess{},
 					&testUserService{},
 					&testRoleService{}),
@@ -95,6 +122,9 @@ func TestProcesserData_persist(t *testing.T) {
 					&Syncer{},
 					&Mapper{},
 					&testSharedModuleService{},
+					&testRecordOriginService{},
+					&testRecordConflictService{},
+					&testAttachmentService{},
 					&testRecordServiceDeleteSuccess{},
 					&testUserService{},
 					&testRoleService{}),
@@ -110,6 +140,9 @@ func TestProcesserData_persist(t *testing.T) {
 					&Syncer{},
 					&Mapper{},
 					&testSharedModuleService{},
+					&testRecordOriginService{},
+					&testRecordConflictService{},
+					&testAttachmentService{},
 					&testRecordServicePersistError{},
 					&testUserService{},
 					&testRoleService{}),
@@ -125,6 +158,9 @@ func TestProcesserData_persist(t *testing.T) {
 					&Syncer{},
 					&Mapper{},
 					&testSharedModuleService{},
+					&testRecordOriginService{},
+					&testRecordConflictService{},
+					&testAttachmentService{},
 					&testRecordServicePersistSuccess{},
 					&testUserService{},
 					&testRoleService{}),
@@ -140,6 +176,9 @@ func TestProcesserData_persist(t *testing.T) {
 					&Syncer{},
 					&Mapper{},
 					&testSharedModuleService{},
+					&testRecordOriginService{},
+					&testRecordConflictService{},
+					&testAttachmentService{},
 					&testRecordServicePersistSuccess{},
 					&testUserService{},
 					&testRoleService{}),
@@ -155,6 +194,9 @@ func TestProcesserData_persist(t *testing.T) {
 					&Syncer{},
 					&Mapper{},
 					&testSharedModuleService{},
+					&testRecordOriginService{},
+					&testRecordConflictService{},
+					&testAttachmentService{},
 					&testRecordServicePersistSuccess{},
 					&testUserService{},
 					&testRoleService{}),
@@ -197,6 +239,206 @@ func TestProcesserData_persist(t *testing.T) {
 	}
 }
 
+func TestProcesserData_resolve(t *testing.T) {
+	var (
+		earlier = time.Date(2020, 12, 5, 10, 10, 10, 0, time.UTC)
+		later   = time.Date(2020, 12, 6, 10, 10, 10, 0, time.UTC)
+
+		tcc = []struct {
+			name     string
+			policy   string
+			remote   types.VectorClock
+			local    types.VectorClock
+			changed  time.Time
+			apply    bool
+			conflict bool
+		}{
+			{
+				"apply change from remote node without clocks",
+				types.ModuleMappingConflictPolicyOrigin,
+				nil,
+				types.VectorClock{"local": 1},
+				later,
+				true,
+				false,
+			},
+			{
+				"skip older change from remote node without clocks",
+				types.ModuleMappingConflictPolicyLastWriterWins,
+				nil,
+				types.VectorClock{"local": 1},
+				later,
+				false,
+				false,
+			},
+			{
+				"apply newer change",
+				types.ModuleMappingConflictPolicyVectorClock,
+				types.VectorClock{"local": 1, "remote": 2},
+				types.VectorClock{"local": 1, "remote": 1},
+				later,
+				true,
+				false,
+			},
+			{
+				"skip already applied change",
+				types.ModuleMappingConflictPolicyOrigin,
+				types.VectorClock{"remote": 1},
+				types.VectorClock{"local": 1, "remote": 1},
+				earlier,
+				false,
+				false,
+			},
+			{
+				"overwrite concurrent change",
+				types.ModuleMappingConflictPolicyOrigin,
+				types.VectorClock{"remote": 2},
+				types.VectorClock{"local": 1, "remote": 1},
+				later,
+				true,
+				false,
+			},
+			{
+				"keep concurrent local change when newer",
+				types.ModuleMappingConflictPolicyLastWriterWins,
+				types.VectorClock{"remote": 2},
+				types.VectorClock{"local": 1, "remote": 1},
+				later,
+				false,
+				false,
+			},
+			{
+				"apply concurrent remote change when newer",
+				types.ModuleMappingConflictPolicyLastWriterWins,
+				types.VectorClock{"remote": 2},
+				types.VectorClock{"local": 1, "remote": 1},
+				earlier,
+				true,
+				false,
+			},
+			{
+				"queue concurrent change",
+				types.ModuleMappingConflictPolicyVectorClock,
+				types.VectorClock{"remote": 2},
+				types.VectorClock{"local": 1, "remote": 1},
+				earlier,
+				false,
+				true,
+			},
+			{
+				"queue change from remote node without clocks",
+				types.ModuleMappingConflictPolicyVectorClock,
+				nil,
+				types.VectorClock{"local": 1},
+				earlier,
+				false,
+				true,
+			},
+		}
+	)
+
+	for _, tc := range tcc {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				ctx = context.Background()
+				req = require.New(t)
+				rcs = &testRecordConflictService{}
+				ts  = earlier.Add(time.Hour)
+			)
+
+			dp := &dataProcesser{
+				ID:                  1,
+				ComposeModuleID:     1,
+				ComposeNamespaceID:  1,
+				ModuleMappingValues: &ct.RecordValueSet{&ct.RecordValue{Name: "Fb", Value: "foo"}},
+				ConflictPolicy:      tc.policy,
+				SyncService: NewSync(
+					&Syncer{},
+					&Mapper{},
+					&testSharedModuleService{},
+					&testRecordOriginService{},
+					rcs,
+					&testAttachmentService{},
+					&testRecordServiceUpdateSuccess{},
+					&testUserService{},
+					&testRoleService{}),
+				Node: &types.Node{ID: 2},
+			}
+
+			er := &decoder.ExposedRecord{ID: 3, Clock: tc.remote, UpdatedAt: &ts}
+			rec := &ct.Record{ID: 4, ModuleID: 1, NamespaceID: 1, UpdatedAt: &tc.changed}
+			ro := &types.RecordOrigin{RecordID: 4, Clock: tc.local}
+
+			apply, err := dp.resolve(ctx, er, rec, ro)
+
+			req.NoError(err)
+			req.Equal(tc.apply, apply)
+
+			if tc.conflict {
+				req.NotNil(rcs.created)
+				req.Equal(uint64(4), rcs.created.RecordID)
+				clock := types.VectorClock{}
+				clock.Merge(tc.remote)
+				req.Equal(clock, rcs.created.Clock)
+			} else {
+				req.Nil(rcs.created)
+			}
+		})
+	}
+}
+
+func TestProcesserData_resolveReferences(t *testing.T) {
+	var (
+		ctx = context.Background()
+		req = require.New(t)
+
+		mappings = types.ModuleFieldMappingSet{
+			{Origin: types.ModuleField{Kind: "File", Name: "Doc"}, Destination: types.ModuleField{Kind: "File", Name: "Doc"}},
+			{Origin: types.ModuleField{Kind: "Record", Name: "Account"}, Destination: types.ModuleField{Kind: "Record", Name: "Account"}},
+			{Origin: types.ModuleField{Kind: "Record", Name: "Contact"}, Destination: types.ModuleField{Kind: "Record", Name: "Contact"}},
+			{Origin: types.ModuleField{Kind: "User", Name: "Owner"}, Destination: types.ModuleField{Kind: "User", Name: "Owner"}},
+			{Origin: types.ModuleField{Kind: "String", Name: "Name"}, Destination: types.ModuleField{Kind: "String", Name: "Name"}},
+		}
+
+		values = ct.RecordValueSet{
+			&ct.RecordValue{Name: "Doc", Value: "1"},
+			&ct.RecordValue{Name: "Account", Value: "2"},
+			&ct.RecordValue{Name: "Contact", Value: "3"},
+			&ct.RecordValue{Name: "Owner", Value: "4"},
+			&ct.RecordValue{Name: "Name", Value: "5"},
+		}
+
+		dp = &dataProcesser{
+			ID:                  1,
+			ComposeModuleID:     1,
+			ComposeNamespaceID:  1,
+			ModuleMappings:      &mappings,
+			ModuleMappingValues: &values,
+			SyncService: NewSync(
+				&Syncer{},
+				&Mapper{},
+				&testSharedModuleService{},
+				&testReferenceRecordOriginService{},
+				&testRecordConflictService{},
+				&testReferenceAttachmentService{},
+				&testRecordServicePersistSuccess{},
+				&testReferenceUserService{},
+				&testRoleService{}),
+			Node: &types.Node{ID: 2},
+		}
+	)
+
+	dp.resolveReferences(ctx, &decoder.ExposedRecord{
+		Users: decoder.ExposedUserSet{&decoder.ExposedUser{ID: 4, Email: "user@example.tld"}},
+	})
+
+	req.Equal("100", values.FilterByName("Doc")[0].Value)
+	req.Equal("200", values.FilterByName("Account")[0].Value)
+	req.Equal("", values.FilterByName("Contact")[0].Value)
+	req.Equal("400", values.FilterByName("Owner")[0].Value)
+	req.Equal("5", values.FilterByName("Name")[0].Value)
+}
+
 // create success
 func (s testRecordServicePersistSuccess) Create(record *ct.Record) (*ct.Record, error) {
 	return nil, nil
@@ -244,3 +486,43 @@ func (s testRecordServicePersistError) Create(record *ct.Record) (*ct.Record, er
 func (s testRecordServicePersistError) With(_ context.Context) cs.RecordService {
 	return &testRecordServicePersistError{}
 }
+
+// record origins
+func (s testRecordOriginService) Load(_ context.Context, rec *ct.Record) (*types.RecordOrigin, error) {
+	return &types.RecordOrigin{RecordID: rec.ID, Clock: types.VectorClock{}}, nil
+}
+
+func (s testRecordOriginService) Save(_ context.Context, _ *ct.Record, _ *types.RecordOrigin) error {
+	return nil
+}
+
+// record conflicts
+func (s *testRecordConflictService) Create(_ context.Context, new *types.RecordConflict) (*types.RecordConflict, error) {
+	s.created = new
+	return new, nil
+}
+
+// references
+func (s testReferenceRecordOriginService) FindByOrigin(_ context.Context, nodeID, originRecordID uint64) (*types.RecordOrigin, error) {
+	if nodeID == 2 && originRecordID == 2 {
+		return &types.RecordOrigin{RecordID: 200, NodeID: nodeID, OriginRecordID: originRecordID}, nil
+	}
+
+	return nil, nil
+}
+
+func (s testReferenceAttachmentService) Transfer(_ context.Context, _ *types.Node, _, originAttachmentID, _, _ uint64, _ string) (uint64, error) {
+	return originAttachmentID * 100, nil
+}
+
+func (s testReferenceUserService) With(_ context.Context) ss.UserService {
+	return s
+}
+
+func (s testReferenceUserService) FindByEmail(email string) (*st.User, error) {
+	if email != "user@example.tld" {
+		return nil, errors.New("mocked error")
+	}
+
+	return &st.User{ID: 400, Email: email}, nil
+}
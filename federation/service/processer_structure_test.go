@@ -47,6 +47,9 @@ func TestProcesserStructure_persist(t *testing.T) {
 					&Syncer{},
 					&Mapper{},
 					&testStructureSharedModuleService{},
+					&testRecordOriginService{},
+					&testRecordConflictService{},
+					&testAttachmentService{},
 					&testRecordServicePersistSuccess{},
 					&testUserService{},
 					&testRoleService{}),
@@ -60,6 +63,9 @@ func TestProcesserStructure_persist(t *testing.T) {
 					&Syncer{},
 					&Mapper{},
 					&testStructureSharedModuleService{},
+					&testRecordOriginService{},
+					&testRecordConflictService{},
+					&testAttachmentService{},
 					&testRecordServicePersistSuccess{},
 					&testUserService{},
 					&testRoleService{}),
@@ -73,6 +79,9 @@ func TestProcesserStructure_persist(t *testing.T) {
 					&Syncer{},
 					&Mapper{},
 					&testStructureSharedModuleServiceError{},
+					&testRecordOriginService{},
+					&testRecordConflictService{},
+					&testAttachmentService{},
 					&testRecordServicePersistSuccess{},
 					&testUserService{},
 					&testRoleService{}),
@@ -86,6 +95,9 @@ func TestProcesserStructure_persist(t *testing.T) {
 					&Syncer{},
 					&Mapper{},
 					&testStructureSharedModuleServiceCreateNewModule{},
+					&testRecordOriginService{},
+					&testRecordConflictService{},
+					&testAttachmentService{},
 					&testRecordServicePersistSuccess{},
 					&testUserService{},
 					&testRoleService{}),
@@ -99,6 +111,9 @@ func TestProcesserStructure_persist(t *testing.T) {
 					&Syncer{},
 					&Mapper{},
 					&testStructureSharedModuleServiceCreateNewModuleErr{},
+					&testRecordOriginService{},
+					&testRecordConflictService{},
+					&testAttachmentService{},
 					&testRecordServicePersistSuccess{},
 					&testUserService{},
 					&testRoleService{}),
@@ -112,6 +127,9 @@ func TestProcesserStructure_persist(t *testing.T) {
 					&Syncer{},
 					&Mapper{},
 					&testStructureSharedModuleServiceUpdateModule{},
+					&testRecordOriginService{},
+					&testRecordConflictService{},
+					&testAttachmentService{},
 					&testRecordServicePersistSuccess{},
 					&testUserService{},
 					&testRoleService{}),
@@ -125,6 +143,9 @@ func TestProcesserStructure_persist(t *testing.T) {
 					&Syncer{},
 					&Mapper{},
 					&testStructureSharedModuleServiceUpdateModule{},
+					&testRecordOriginService{},
+					&testRecordConflictService{},
+					&testAttachmentService{},
 					&testRecordServicePersistSuccess{},
 					&testUserService{},
 					&testRoleService{}),
@@ -138,6 +159,9 @@ func TestProcesserStructure_persist(t *testing.T) {
 					&Syncer{},
 					&Mapper{},
 					&testStructureSharedModuleServiceUpdateModuleErr{},
+					&testRecordOriginService{},
+					&testRecordConflictService{},
+					&testAttachmentService{},
 					&testRecordServicePersistSuccess{},
 					&testUserService{},
 					&testRoleService{}),
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+
+	cs "github.com/cortezaproject/corteza-server/compose/service"
+	ct "github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/store"
+)
+
+type (
+	recordConflict struct {
+		store     store.Storer
+		ac        recordConflictAccessController
+		record    cs.RecordService
+		origin    RecordOriginService
+		actionlog actionlog.Recorder
+	}
+
+	recordConflictAccessController interface {
+		CanManageNode(ctx context.Context, r *types.Node) bool
+	}
+
+	RecordConflictService interface {
+		Find(ctx context.Context, filter types.RecordConflictFilter) (types.RecordConflictSet, types.RecordConflictFilter, error)
+		FindByID(ctx context.Context, nodeID, conflictID uint64) (*types.RecordConflict, error)
+		Create(ctx context.Context, new *types.RecordConflict) (*types.RecordConflict, error)
+		Resolve(ctx context.Context, nodeID, conflictID uint64, resolution string) (*types.RecordConflict, error)
+	}
+)
+
+func RecordConflict() RecordConflictService {
+	return &recordConflict{
+		store:     DefaultStore,
+		ac:        DefaultAccessControl,
+		record:    cs.DefaultRecord,
+		origin:    DefaultRecordOrigin,
+		actionlog: DefaultActionlog,
+	}
+}
+
+func (svc recordConflict) Find(ctx context.Context, filter types.RecordConflictFilter) (set types.RecordConflictSet, f types.RecordConflictFilter, err error) {
+	var (
+		aProps = &recordConflictActionProps{filter: &filter}
+	)
+
+	err = func() error {
+		if err = svc.checkNode(ctx, filter.NodeID); err != nil {
+			return err
+		}
+
+		if set, f, err = store.SearchFederationRecordConflicts(ctx, svc.store, filter); err != nil {
+			return err
+		}
+
+		return nil
+	}()
+
+	return set, f, svc.recordAction(ctx, aProps, RecordConflictActionSearch, err)
+}
+
+func (svc recordConflict) FindByID(ctx context.Context, nodeID, conflictID uint64) (c *types.RecordConflict, err error) {
+	var (
+		aProps = &recordConflictActionProps{}
+	)
+
+	err = func() error {
+		if c, err = svc.lookup(ctx, svc.store, nodeID, conflictID); err != nil {
+			return err
+		}
+
+		aProps.setConflict(c)
+
+		return nil
+	}()
+
+	return c, svc.recordAction(ctx, aProps, RecordConflictActionLookup, err)
+}
+
+// Create queues the change that could not be applied automatically
+//
+// Used by the data sync; access control is handled there
+func (svc recordConflict) Create(ctx context.Context, new *types.RecordConflict) (*types.RecordConflict, error) {
+	var (
+		aProps = &recordConflictActionProps{conflict: new}
+	)
+
+	new.ID = nextID()
+	new.CreatedAt = *now()
+
+	err := store.CreateFederationRecordConflict(ctx, svc.store, new)
+
+	return new, svc.recordAction(ctx, aProps, RecordConflictActionCreate, err)
+}
+
+// Resolve applies the chosen version of the record
+//
+// When the remote version is kept, the received values are stored on
+// the local record. In both cases the record is updated and the clocks
+// merged, so the resolved version takes precedence on the remote node
+func (svc recordConflict) Resolve(ctx context.Context, nodeID, conflictID uint64, resolution string) (c *types.RecordConflict, err error) {
+	var (
+		aProps = &recordConflictActionProps{}
+	)
+
+	err = store.Tx(ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+		var (
+			rec *ct.Record
+			ro  *types.RecordOrigin
+		)
+
+		if c, err = svc.lookup(ctx, s, nodeID, conflictID); err != nil {
+			return err
+		}
+
+		aProps.setConflict(c)
+
+		if c.IsResolved() {
+			return RecordConflictErrAlreadyResolved(aProps)
+		}
+
+		if resolution != types.RecordConflictResolutionLocal && resolution != types.RecordConflictResolutionRemote {
+			return RecordConflictErrInvalidResolution(aProps)
+		}
+
+		var (
+			records = svc.record.WithStore(s).With(ctx)
+			origins = svc.origin.WithStore(s)
+		)
+
+		if rec, err = records.FindByID(c.ComposeNamespaceID, c.ComposeModuleID, c.RecordID); err != nil {
+			return RecordConflictErrRecordNotFound(aProps)
+		}
+
+		if ro, err = origins.Load(ctx, rec); err != nil {
+			return err
+		}
+
+		if resolution == types.RecordConflictResolutionRemote {
+			rec.Values = c.Values
+		}
+
+		if rec, err = records.Update(rec); err != nil {
+			return err
+		}
+
+		ro.Clock.Merge(c.Clock)
+
+		if err = origins.Save(ctx, rec, ro); err != nil {
+			return err
+		}
+
+		c.Resolution = resolution
+		c.ResolvedAt = now()
+		c.ResolvedBy = auth.GetIdentityFromContext(ctx).Identity()
+
+		return store.UpdateFederationRecordConflict(ctx, s, c)
+	})
+
+	return c, svc.recordAction(ctx, aProps, RecordConflictActionResolve, err)
+}
+
+func (svc recordConflict) lookup(ctx context.Context, s store.Storer, nodeID, conflictID uint64) (*types.RecordConflict, error) {
+	if err := svc.checkNode(ctx, nodeID); err != nil {
+		return nil, err
+	}
+
+	c, err := store.LookupFederationRecordConflictByID(ctx, s, conflictID)
+
+	if err != nil || c.NodeID != nodeID {
+		return nil, RecordConflictErrNotFound()
+	}
+
+	return c, nil
+}
+
+func (svc recordConflict) checkNode(ctx context.Context, nodeID uint64) error {
+	n, err := store.LookupFederationNodeByID(ctx, svc.store, nodeID)
+
+	if err != nil {
+		return RecordConflictErrNodeNotFound()
+	}
+
+	if !svc.ac.CanManageNode(ctx, n) {
+		return RecordConflictErrNotAllowedToManage()
+	}
+
+	return nil
+}
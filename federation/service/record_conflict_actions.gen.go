@@ -0,0 +1,535 @@
+package service
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+// federation/service/record_conflict_actions.yaml
+
+import (
+	"context"
+	"fmt"
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"strings"
+	"time"
+)
+
+type (
+	recordConflictActionProps struct {
+		conflict *types.RecordConflict
+		filter   *types.RecordConflictFilter
+	}
+
+	recordConflictAction struct {
+		timestamp time.Time
+		resource  string
+		action    string
+		log       string
+		severity  actionlog.Severity
+
+		// prefix for error when action fails
+		errorMessage string
+
+		props *recordConflictActionProps
+	}
+
+	recordConflictLogMetaKey   struct{}
+	recordConflictPropsMetaKey struct{}
+)
+
+var (
+	// just a placeholder to cover template cases w/o fmt package use
+	_ = fmt.Println
+)
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Props methods
+// setConflict updates recordConflictActionProps's conflict
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *recordConflictActionProps) setConflict(conflict *types.RecordConflict) *recordConflictActionProps {
+	p.conflict = conflict
+	return p
+}
+
+// setFilter updates recordConflictActionProps's filter
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *recordConflictActionProps) setFilter(filter *types.RecordConflictFilter) *recordConflictActionProps {
+	p.filter = filter
+	return p
+}
+
+// Serialize converts recordConflictActionProps to actionlog.Meta
+//
+// This function is auto-generated.
+//
+func (p recordConflictActionProps) Serialize() actionlog.Meta {
+	var (
+		m = make(actionlog.Meta)
+	)
+
+	if p.conflict != nil {
+		m.Set("conflict.ID", p.conflict.ID, true)
+		m.Set("conflict.NodeID", p.conflict.NodeID, true)
+		m.Set("conflict.RecordID", p.conflict.RecordID, true)
+		m.Set("conflict.Resolution", p.conflict.Resolution, true)
+	}
+	if p.filter != nil {
+		m.Set("filter.NodeID", p.filter.NodeID, true)
+		m.Set("filter.RecordID", p.filter.RecordID, true)
+		m.Set("filter.sort", p.filter.Sort, true)
+		m.Set("filter.limit", p.filter.Limit, true)
+	}
+
+	return m
+}
+
+// tr translates string and replaces meta value placeholder with values
+//
+// This function is auto-generated.
+//
+func (p recordConflictActionProps) Format(in string, err error) string {
+	var (
+		pairs = []string{"{err}"}
+		// first non-empty string
+		fns = func(ii ...interface{}) string {
+			for _, i := range ii {
+				if s := fmt.Sprintf("%v", i); len(s) > 0 {
+					return s
+				}
+			}
+
+			return ""
+		}
+	)
+
+	if err != nil {
+		pairs = append(pairs, err.Error())
+	} else {
+		pairs = append(pairs, "nil")
+	}
+
+	if p.conflict != nil {
+		// replacement for "{conflict}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{conflict}",
+			fns(
+				p.conflict.ID,
+				p.conflict.NodeID,
+				p.conflict.RecordID,
+				p.conflict.Resolution,
+			),
+		)
+		pairs = append(pairs, "{conflict.ID}", fns(p.conflict.ID))
+		pairs = append(pairs, "{conflict.NodeID}", fns(p.conflict.NodeID))
+		pairs = append(pairs, "{conflict.RecordID}", fns(p.conflict.RecordID))
+		pairs = append(pairs, "{conflict.Resolution}", fns(p.conflict.Resolution))
+	}
+
+	if p.filter != nil {
+		// replacement for "{filter}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{filter}",
+			fns(
+				p.filter.NodeID,
+				p.filter.RecordID,
+				p.filter.Sort,
+				p.filter.Limit,
+			),
+		)
+		pairs = append(pairs, "{filter.NodeID}", fns(p.filter.NodeID))
+		pairs = append(pairs, "{filter.RecordID}", fns(p.filter.RecordID))
+		pairs = append(pairs, "{filter.sort}", fns(p.filter.Sort))
+		pairs = append(pairs, "{filter.limit}", fns(p.filter.Limit))
+	}
+	return strings.NewReplacer(pairs...).Replace(in)
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Action methods
+
+// String returns loggable description as string
+//
+// This function is auto-generated.
+//
+func (a *recordConflictAction) String() string {
+	var props = &recordConflictActionProps{}
+
+	if a.props != nil {
+		props = a.props
+	}
+
+	return props.Format(a.log, nil)
+}
+
+func (e *recordConflictAction) ToAction() *actionlog.Action {
+	return &actionlog.Action{
+		Resource:    e.resource,
+		Action:      e.action,
+		Severity:    e.severity,
+		Description: e.String(),
+		Meta:        e.props.Serialize(),
+	}
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Action constructors
+
+// RecordConflictActionSearch returns "federation:record_conflict.search" action
+//
+// This function is auto-generated.
+//
+func RecordConflictActionSearch(props ...*recordConflictActionProps) *recordConflictAction {
+	a := &recordConflictAction{
+		timestamp: time.Now(),
+		resource:  "federation:record_conflict",
+		action:    "search",
+		log:       "searched for record conflicts",
+		severity:  actionlog.Info,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordConflictActionLookup returns "federation:record_conflict.lookup" action
+//
+// This function is auto-generated.
+//
+func RecordConflictActionLookup(props ...*recordConflictActionProps) *recordConflictAction {
+	a := &recordConflictAction{
+		timestamp: time.Now(),
+		resource:  "federation:record_conflict",
+		action:    "lookup",
+		log:       "looked-up for a {conflict}",
+		severity:  actionlog.Info,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordConflictActionCreate returns "federation:record_conflict.create" action
+//
+// This function is auto-generated.
+//
+func RecordConflictActionCreate(props ...*recordConflictActionProps) *recordConflictAction {
+	a := &recordConflictAction{
+		timestamp: time.Now(),
+		resource:  "federation:record_conflict",
+		action:    "create",
+		log:       "queued {conflict}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// RecordConflictActionResolve returns "federation:record_conflict.resolve" action
+//
+// This function is auto-generated.
+//
+func RecordConflictActionResolve(props ...*recordConflictActionProps) *recordConflictAction {
+	a := &recordConflictAction{
+		timestamp: time.Now(),
+		resource:  "federation:record_conflict",
+		action:    "resolve",
+		log:       "resolved {conflict}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Error constructors
+
+// RecordConflictErrGeneric returns "federation:record_conflict.generic" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordConflictErrGeneric(mm ...*recordConflictActionProps) *errors.Error {
+	var p = &recordConflictActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("failed to complete request due to internal error", nil),
+
+		errors.Meta("type", "generic"),
+		errors.Meta("resource", "federation:record_conflict"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(recordConflictLogMetaKey{}, "{err}"),
+		errors.Meta(recordConflictPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordConflictErrNotFound returns "federation:record_conflict.notFound" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordConflictErrNotFound(mm ...*recordConflictActionProps) *errors.Error {
+	var p = &recordConflictActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("record conflict does not exist", nil),
+
+		errors.Meta("type", "notFound"),
+		errors.Meta("resource", "federation:record_conflict"),
+
+		errors.Meta(recordConflictPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordConflictErrNodeNotFound returns "federation:record_conflict.nodeNotFound" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordConflictErrNodeNotFound(mm ...*recordConflictActionProps) *errors.Error {
+	var p = &recordConflictActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("node does not exist", nil),
+
+		errors.Meta("type", "nodeNotFound"),
+		errors.Meta("resource", "federation:record_conflict"),
+
+		errors.Meta(recordConflictPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordConflictErrRecordNotFound returns "federation:record_conflict.recordNotFound" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordConflictErrRecordNotFound(mm ...*recordConflictActionProps) *errors.Error {
+	var p = &recordConflictActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("record does not exist", nil),
+
+		errors.Meta("type", "recordNotFound"),
+		errors.Meta("resource", "federation:record_conflict"),
+
+		errors.Meta(recordConflictPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordConflictErrAlreadyResolved returns "federation:record_conflict.alreadyResolved" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordConflictErrAlreadyResolved(mm ...*recordConflictActionProps) *errors.Error {
+	var p = &recordConflictActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("record conflict is already resolved", nil),
+
+		errors.Meta("type", "alreadyResolved"),
+		errors.Meta("resource", "federation:record_conflict"),
+
+		errors.Meta(recordConflictPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordConflictErrInvalidResolution returns "federation:record_conflict.invalidResolution" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordConflictErrInvalidResolution(mm ...*recordConflictActionProps) *errors.Error {
+	var p = &recordConflictActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid resolution, expecting local or remote", nil),
+
+		errors.Meta("type", "invalidResolution"),
+		errors.Meta("resource", "federation:record_conflict"),
+
+		errors.Meta(recordConflictPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RecordConflictErrNotAllowedToManage returns "federation:record_conflict.notAllowedToManage" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RecordConflictErrNotAllowedToManage(mm ...*recordConflictActionProps) *errors.Error {
+	var p = &recordConflictActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("not allowed to manage record conflicts of this node", nil),
+
+		errors.Meta("type", "notAllowedToManage"),
+		errors.Meta("resource", "federation:record_conflict"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(recordConflictLogMetaKey{}, "could not manage {conflict}; insufficient permissions"),
+		errors.Meta(recordConflictPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+
+// recordAction is a service helper function wraps function that can return error
+//
+// It will wrap unrecognized/internal errors with generic errors.
+//
+// This function is auto-generated.
+//
+func (svc recordConflict) recordAction(ctx context.Context, props *recordConflictActionProps, actionFn func(...*recordConflictActionProps) *recordConflictAction, err error) error {
+	if svc.actionlog == nil || actionFn == nil {
+		// action log disabled or no action fn passed, return error as-is
+		return err
+	} else if err == nil {
+		// action completed w/o error, record it
+		svc.actionlog.Record(ctx, actionFn(props).ToAction())
+		return nil
+	}
+
+	a := actionFn(props).ToAction()
+
+	// Extracting error information and recording it as action
+	a.Error = err.Error()
+
+	switch c := err.(type) {
+	case *errors.Error:
+		m := c.Meta()
+
+		a.Error = err.Error()
+		a.Severity = actionlog.Severity(m.AsInt("severity"))
+		a.Description = props.Format(m.AsString(recordConflictLogMetaKey{}), err)
+
+		if p, has := m[recordConflictPropsMetaKey{}]; has {
+			a.Meta = p.(*recordConflictActionProps).Serialize()
+		}
+
+		svc.actionlog.Record(ctx, a)
+	default:
+		svc.actionlog.Record(ctx, a)
+	}
+
+	// Original error is passed on
+	return err
+}
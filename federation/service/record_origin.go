@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	ct "github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/store"
+)
+
+type (
+	recordOrigin struct {
+		store store.Storer
+	}
+
+	RecordOriginService interface {
+		WithStore(s store.Storer) RecordOriginService
+
+		Load(ctx context.Context, rec *ct.Record) (*types.RecordOrigin, error)
+		Track(ctx context.Context, rec *ct.Record) error
+		Save(ctx context.Context, rec *ct.Record, ro *types.RecordOrigin) error
+		FindByOrigin(ctx context.Context, nodeID, originRecordID uint64) (*types.RecordOrigin, error)
+
+		Expose(ctx context.Context, em *types.ExposedModule, rec *ct.Record) error
+		Withdraw(ctx context.Context, em *types.ExposedModule, rec *ct.Record) (bool, error)
+	}
+)
+
+func RecordOrigin() RecordOriginService {
+	return &recordOrigin{
+		store: DefaultStore,
+	}
+}
+
+// WithStore returns copy of the service that uses the given store
+func (svc recordOrigin) WithStore(s store.Storer) RecordOriginService {
+	svc.store = s
+	return &svc
+}
+
+// Load returns the origin of the record
+//
+// Nothing is stored; when the record was changed locally since the
+// last tracked change (or was never tracked), the returned clock is
+// ticked for this node. Clock of the loaded record is never empty
+func (svc recordOrigin) Load(ctx context.Context, rec *ct.Record) (*types.RecordOrigin, error) {
+	ro, err := svc.lookup(ctx, rec)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if changedAt := recordChangedAt(rec); ro.SyncedAt == nil || changedAt.After(*ro.SyncedAt) {
+		ro.Clock.Tick(DefaultOptions.Host)
+		ro.SyncedAt = &changedAt
+	}
+
+	return ro, nil
+}
+
+// Track ticks and stores the clock of the locally changed record
+//
+// Change is tracked only once; records that were not
+// changed since the last tracked change are left as they are
+func (svc recordOrigin) Track(ctx context.Context, rec *ct.Record) error {
+	ro, err := svc.lookup(ctx, rec)
+
+	if err != nil {
+		return err
+	}
+
+	changedAt := recordChangedAt(rec)
+
+	if ro.SyncedAt != nil && !changedAt.After(*ro.SyncedAt) {
+		return nil
+	}
+
+	ro.Clock.Tick(DefaultOptions.Host)
+	ro.SyncedAt = &changedAt
+
+	return store.UpsertFederationRecordOrigin(ctx, svc.store, ro)
+}
+
+// Save stores the origin of the record
+//
+// Current state of the record is considered synced
+func (svc recordOrigin) Save(ctx context.Context, rec *ct.Record, ro *types.RecordOrigin) error {
+	changedAt := recordChangedAt(rec)
+
+	ro.RecordID = rec.ID
+	ro.SyncedAt = &changedAt
+
+	return store.UpsertFederationRecordOrigin(ctx, svc.store, ro)
+}
+
+// FindByOrigin returns the origin of the local copy of the record
+// from the given node, nil if the record was not received
+func (svc recordOrigin) FindByOrigin(ctx context.Context, nodeID, originRecordID uint64) (*types.RecordOrigin, error) {
+	ro, err := store.LookupFederationRecordOriginByNodeIDOriginRecordID(ctx, svc.store, nodeID, originRecordID)
+
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, nil
+	}
+
+	return ro, err
+}
+
+// Expose keeps track of the record that was sent with the exposed module
+func (svc recordOrigin) Expose(ctx context.Context, em *types.ExposedModule, rec *ct.Record) error {
+	_, err := store.LookupFederationExposedRecordByExposedModuleIDRecordID(ctx, svc.store, em.ID, rec.ID)
+
+	if !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+
+	return store.CreateFederationExposedRecord(ctx, svc.store, &types.ExposedRecord{
+		ExposedModuleID: em.ID,
+		RecordID:        rec.ID,
+		ExposedAt:       *now(),
+	})
+}
+
+// Withdraw stops tracking the record that is not exposed anymore
+//
+// Returns true when the record was sent with the exposed module before
+func (svc recordOrigin) Withdraw(ctx context.Context, em *types.ExposedModule, rec *ct.Record) (bool, error) {
+	_, err := store.LookupFederationExposedRecordByExposedModuleIDRecordID(ctx, svc.store, em.ID, rec.ID)
+
+	if errors.Is(err, store.ErrNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, store.DeleteFederationExposedRecordByExposedModuleIDRecordID(ctx, svc.store, em.ID, rec.ID)
+}
+
+// lookup returns the stored origin of the record or a new one with an empty clock
+func (svc recordOrigin) lookup(ctx context.Context, rec *ct.Record) (*types.RecordOrigin, error) {
+	ro, err := store.LookupFederationRecordOriginByRecordID(ctx, svc.store, rec.ID)
+
+	if errors.Is(err, store.ErrNotFound) {
+		return &types.RecordOrigin{RecordID: rec.ID, Clock: types.VectorClock{}}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ro.Clock == nil {
+		ro.Clock = types.VectorClock{}
+	}
+
+	return ro, nil
+}
+
+// recordChangedAt returns the time of the last change of the record
+func recordChangedAt(rec *ct.Record) time.Time {
+	switch {
+	case rec.DeletedAt != nil:
+		return *rec.DeletedAt
+	case rec.UpdatedAt != nil:
+		return *rec.UpdatedAt
+	default:
+		return rec.CreatedAt
+	}
+}
@@ -8,6 +8,7 @@ import (
 	cs "github.com/cortezaproject/corteza-server/compose/service"
 	"github.com/cortezaproject/corteza-server/pkg/actionlog"
 	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/pkg/id"
 	"github.com/cortezaproject/corteza-server/pkg/label"
 	"github.com/cortezaproject/corteza-server/pkg/options"
@@ -41,11 +42,15 @@ var (
 
 	DefaultActionlog actionlog.Recorder
 
-	DefaultNode          *node
-	DefaultNodeSync      NodeSyncService
-	DefaultExposedModule ExposedModuleService
-	DefaultSharedModule  SharedModuleService
-	DefaultModuleMapping ModuleMappingService
+	DefaultNode           *node
+	DefaultNodeSync       NodeSyncService
+	DefaultExposedModule  ExposedModuleService
+	DefaultSharedModule   SharedModuleService
+	DefaultModuleMapping  ModuleMappingService
+	DefaultRecordOrigin   RecordOriginService
+	DefaultRecordConflict RecordConflictService
+	DefaultAttachment     AttachmentService
+	DefaultSync           *Sync
 
 	// wrapper around time.Now() that will aid service testing
 	now = func() *time.Time {
@@ -91,23 +96,35 @@ func Initialize(ctx context.Context, log *zap.Logger, s store.Storer, c Config)
 	DefaultExposedModule = ExposedModule()
 	DefaultSharedModule = SharedModule()
 	DefaultModuleMapping = ModuleMapping()
+	DefaultRecordOrigin = RecordOrigin()
+	DefaultRecordConflict = RecordConflict()
+	DefaultAttachment = Attachment()
 
-	return
-}
-
-func Watchers(ctx context.Context) {
-	DefaultLogger.Info("Starting federation - warning, this is still an experimental feature")
-
-	syncService := NewSync(
+	DefaultSync = NewSync(
 		&Syncer{},
 		&Mapper{},
 		DefaultSharedModule,
+		DefaultRecordOrigin,
+		DefaultRecordConflict,
+		DefaultAttachment,
 		cs.DefaultRecord,
 		ss.DefaultUser,
 		ss.DefaultRole)
 
-	syncStructure := WorkerStructure(syncService, DefaultLogger)
-	syncData := WorkerData(syncService, DefaultLogger)
+	return
+}
+
+func Watchers(ctx context.Context) {
+	DefaultLogger.Info("Starting federation - warning, this is still an experimental feature")
+
+	syncStructure := WorkerStructure(DefaultSync, DefaultLogger)
+	syncData := WorkerData(DefaultSync, DefaultLogger)
+
+	if DefaultOptions.DataPushEnabled {
+		// data sync still runs and catches up
+		// on any changes that were not pushed
+		NotifierData(DefaultSync, DefaultLogger).Register(eventbus.Service())
+	}
 
 	go syncStructure.Watch(
 		ctx,
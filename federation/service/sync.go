@@ -5,11 +5,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 	"time"
 
 	cs "github.com/cortezaproject/corteza-server/compose/service"
 	ct "github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/decoder"
 	"github.com/cortezaproject/corteza-server/pkg/filter"
 	ss "github.com/cortezaproject/corteza-server/system/service"
 	st "github.com/cortezaproject/corteza-server/system/types"
@@ -17,23 +21,29 @@ import (
 
 type (
 	Sync struct {
-		syncer               *Syncer
-		mapper               *Mapper
-		sharedModuleService  SharedModuleService
-		composeRecordService cs.RecordService
-		systemUserService    ss.UserService
-		systemRoleService    ss.RoleService
+		syncer                *Syncer
+		mapper                *Mapper
+		sharedModuleService   SharedModuleService
+		recordOriginService   RecordOriginService
+		recordConflictService RecordConflictService
+		attachmentService     AttachmentService
+		composeRecordService  cs.RecordService
+		systemUserService     ss.UserService
+		systemRoleService     ss.RoleService
 	}
 )
 
-func NewSync(s *Syncer, m *Mapper, sm SharedModuleService, cs cs.RecordService, us ss.UserService, rs ss.RoleService) *Sync {
+func NewSync(s *Syncer, m *Mapper, sm SharedModuleService, ro RecordOriginService, rc RecordConflictService, as AttachmentService, cs cs.RecordService, us ss.UserService, rs ss.RoleService) *Sync {
 	return &Sync{
-		syncer:               s,
-		mapper:               m,
-		sharedModuleService:  sm,
-		composeRecordService: cs,
-		systemUserService:    us,
-		systemRoleService:    rs,
+		syncer:                s,
+		mapper:                m,
+		sharedModuleService:   sm,
+		recordOriginService:   ro,
+		recordConflictService: rc,
+		attachmentService:     as,
+		composeRecordService:  cs,
+		systemUserService:     us,
+		systemRoleService:     rs,
 	}
 }
 
@@ -65,6 +75,11 @@ func (s *Sync) ProcessPayload(ctx context.Context, payload []byte, out chan Url,
 	return s.syncer.Process(ctx, payload, out, url, processer)
 }
 
+// PushUrl passes the payload to be pushed to the syncer
+func (s *Sync) PushUrl(ctx context.Context, url string, payload []byte) error {
+	return s.syncer.Push(ctx, url, payload)
+}
+
 // QueueUrl passes the url to the syncer
 func (s *Sync) QueueUrl(url Url, out chan Url) {
 	s.syncer.Queue(url, out)
@@ -90,6 +105,46 @@ func (s *Sync) DeleteRecord(ctx context.Context, rec *ct.Record) error {
 	return s.composeRecordService.With(ctx).DeleteByID(rec.NamespaceID, rec.ModuleID, rec.ID)
 }
 
+// FindRecordByID wraps the compose Record service FindByID
+func (s *Sync) FindRecordByID(ctx context.Context, namespaceID, moduleID, recordID uint64) (*ct.Record, error) {
+	return s.composeRecordService.With(ctx).FindByID(namespaceID, moduleID, recordID)
+}
+
+// LoadRecordOrigin wraps the federation RecordOrigin service Load
+func (s *Sync) LoadRecordOrigin(ctx context.Context, rec *ct.Record) (*types.RecordOrigin, error) {
+	return s.recordOriginService.Load(ctx, rec)
+}
+
+// TrackRecordOrigin wraps the federation RecordOrigin service Track
+func (s *Sync) TrackRecordOrigin(ctx context.Context, rec *ct.Record) error {
+	return s.recordOriginService.Track(ctx, rec)
+}
+
+// SaveRecordOrigin wraps the federation RecordOrigin service Save
+func (s *Sync) SaveRecordOrigin(ctx context.Context, rec *ct.Record, ro *types.RecordOrigin) error {
+	return s.recordOriginService.Save(ctx, rec, ro)
+}
+
+// CreateRecordConflict wraps the federation RecordConflict service Create
+func (s *Sync) CreateRecordConflict(ctx context.Context, new *types.RecordConflict) (*types.RecordConflict, error) {
+	return s.recordConflictService.Create(ctx, new)
+}
+
+// FindRecordByOrigin finds the local copy of the record from the node
+func (s *Sync) FindRecordByOrigin(ctx context.Context, nodeID, originRecordID uint64) (*types.RecordOrigin, error) {
+	return s.recordOriginService.FindByOrigin(ctx, nodeID, originRecordID)
+}
+
+// TransferAttachment wraps the federation Attachment service Transfer
+func (s *Sync) TransferAttachment(ctx context.Context, n *types.Node, moduleID, originAttachmentID, namespaceID, composeModuleID uint64, fieldName string) (uint64, error) {
+	return s.attachmentService.Transfer(ctx, n, moduleID, originAttachmentID, namespaceID, composeModuleID, fieldName)
+}
+
+// FindUserByEmail wraps the system User service FindByEmail
+func (s *Sync) FindUserByEmail(ctx context.Context, email string) (*st.User, error) {
+	return s.systemUserService.With(ctx).FindByEmail(email)
+}
+
 // FindRecord find the record via federation label
 func (s *Sync) FindRecords(ctx context.Context, filter ct.RecordFilter) (set ct.RecordSet, err error) {
 	set, _, err = s.composeRecordService.With(ctx).Find(filter)
@@ -225,3 +280,276 @@ func (s *Sync) LoadUserWithRoles(ctx context.Context, nodeID uint64) (*st.User,
 
 	return u, nil
 }
+
+// GetExposedModules finds all the exposed modules that
+// share the given compose module
+func (s *Sync) GetExposedModules(ctx context.Context, composeModuleID uint64) (types.ExposedModuleSet, error) {
+	set, _, err := DefaultExposedModule.Find(ctx, types.ExposedModuleFilter{ComposeModuleID: composeModuleID})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return set, nil
+}
+
+// ProcessPushedRecords persists the record changes that were pushed
+// to us by the origin node
+//
+// Changes are processed the same way as the ones fetched by the data sync
+// worker; sync time is not updated so that the worker still catches up on
+// any changes that were not pushed
+func (s *Sync) ProcessPushedRecords(ctx context.Context, nodeID, externalFederationModuleID uint64, set decoder.ExposedRecordSet) (int, error) {
+	var (
+		n   *types.Node
+		u   *st.User
+		sm  *types.SharedModule
+		mm  *types.ModuleMapping
+		err error
+
+		invokerID = auth.GetIdentityFromContext(ctx).Identity()
+	)
+
+	ctx = auth.SetSuperUserContext(ctx)
+
+	if n, err = DefaultNode.FindByID(ctx, nodeID); err != nil {
+		return 0, err
+	}
+
+	if n.Status != types.NodeStatusPaired {
+		return 0, NodeSyncErrNotAllowedToPush()
+	}
+
+	// only the federation user of the paired node
+	// is allowed to push the changes
+	if u, err = s.LoadUserWithRoles(ctx, n.ID); err != nil {
+		return 0, err
+	}
+
+	if u.ID != invokerID {
+		return 0, NodeSyncErrNotAllowedToPush()
+	}
+
+	if sm, err = s.LookupSharedModule(ctx, &types.SharedModule{NodeID: n.ID, ExternalFederationModuleID: externalFederationModuleID}); err != nil {
+		return 0, err
+	}
+
+	if sm == nil {
+		return 0, NodeSyncErrSharedModuleNotFound()
+	}
+
+	if status, err := s.GetLastStructureSyncStatus(ctx, n.ID, sm.ExternalFederationModuleID); err != nil || status == types.NodeSyncStatusError {
+		return 0, NodeSyncErrStructureNotSynced()
+	}
+
+	if mm, err = s.GetModuleMappings(ctx, sm.ID); err != nil {
+		return 0, err
+	}
+
+	mappingValues, err := s.PrepareModuleMappings(ctx, mm)
+
+	if err != nil {
+		return 0, err
+	}
+
+	processer := &dataProcesser{
+		ID:                  sm.ExternalFederationModuleID,
+		ComposeModuleID:     mm.ComposeModuleID,
+		ComposeNamespaceID:  mm.ComposeNamespaceID,
+		NodeBaseURL:         n.BaseURL,
+		ModuleMappings:      &mm.FieldMapping,
+		ModuleMappingValues: &mappingValues,
+		ConflictPolicy:      mm.ConflictPolicy,
+		SyncService:         s,
+		User:                u,
+		Node:                n,
+	}
+
+	processed, err := processer.ProcessRecords(ctx, set)
+
+	return processed.(dataProcesserResponse).Processed, err
+}
+
+// ExposeRecords prepares the records for the node they are exposed to
+//
+// Fields that are not exposed are omitted and the version of the record
+// is attached. Records that were received from the node are sent back to
+// it only when changed locally; the ones received from any other
+// node are never passed on.
+//
+// Records that do not match the record filter of the exposed module
+// are omitted; the ones that were already sent to the node are
+// sent as deleted, so the node removes its copy
+func (s *Sync) ExposeRecords(ctx context.Context, node *types.Node, em *types.ExposedModule, set ct.RecordSet) (decoder.ExposedRecordSet, error) {
+	var (
+		out       = decoder.ExposedRecordSet{}
+		federated = make(map[uint64]bool)
+	)
+
+	matched, err := s.matchRecords(ctx, em, set)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rec := range set {
+		ro, err := s.LoadRecordOrigin(ctx, rec)
+
+		if err != nil {
+			return nil, err
+		}
+
+		er := &decoder.ExposedRecord{
+			ID:        rec.ID,
+			Clock:     ro.Clock,
+			CreatedAt: rec.CreatedAt,
+			UpdatedAt: rec.UpdatedAt,
+			DeletedAt: rec.DeletedAt,
+		}
+
+		switch {
+		case ro.NodeID != 0 && ro.NodeID == node.ID:
+			if ro.Clock[DefaultOptions.Host] == 0 {
+				continue
+			}
+
+			er.OriginRecordID = ro.OriginRecordID
+
+		case ro.NodeID != 0:
+			continue
+
+		default:
+			// records synced before the origins were tracked
+			if _, ok := federated[rec.CreatedBy]; !ok {
+				federated[rec.CreatedBy] = s.isFederatedUser(ctx, rec.CreatedBy)
+			}
+
+			if federated[rec.CreatedBy] {
+				continue
+			}
+		}
+
+		switch {
+		case rec.DeletedAt != nil:
+			if _, err = s.recordOriginService.Withdraw(ctx, em, rec); err != nil {
+				return nil, err
+			}
+
+		case !matched[rec.ID]:
+			withdrawn, err := s.recordOriginService.Withdraw(ctx, em, rec)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if !withdrawn {
+				continue
+			}
+
+			changedAt := recordChangedAt(rec)
+			er.DeletedAt = &changedAt
+
+		default:
+			if err = s.recordOriginService.Expose(ctx, em, rec); err != nil {
+				return nil, err
+			}
+
+			er.Values, _ = rec.Values.Filter(func(rv *ct.RecordValue) (bool, error) {
+				return em.Fields.HasField(rv.Name)
+			})
+
+			er.Users = s.exposeUsers(ctx, em, er.Values)
+		}
+
+		out = append(out, er)
+	}
+
+	return out, nil
+}
+
+// matchRecords checks which of the records are matched
+// by the record filter of the exposed module
+func (s *Sync) matchRecords(ctx context.Context, em *types.ExposedModule, set ct.RecordSet) (map[uint64]bool, error) {
+	var (
+		matched = make(map[uint64]bool, len(set))
+		ids     = make([]string, 0, len(set))
+	)
+
+	for _, rec := range set {
+		if em.RecordFilter == "" {
+			matched[rec.ID] = true
+			continue
+		}
+
+		if rec.DeletedAt == nil {
+			ids = append(ids, fmt.Sprintf("recordID = %d", rec.ID))
+		}
+	}
+
+	if len(ids) == 0 {
+		return matched, nil
+	}
+
+	rr, err := s.FindRecords(ctx, ct.RecordFilter{
+		NamespaceID: set[0].NamespaceID,
+		ModuleID:    set[0].ModuleID,
+		Query:       fmt.Sprintf("(%s) AND (%s)", em.RecordFilter, strings.Join(ids, " OR ")),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rec := range rr {
+		matched[rec.ID] = true
+	}
+
+	return matched, nil
+}
+
+// exposeUsers collects the users referenced by the user fields
+//
+// Users are matched by email on the receiving node
+func (s *Sync) exposeUsers(ctx context.Context, em *types.ExposedModule, vv ct.RecordValueSet) (out decoder.ExposedUserSet) {
+	ctx = auth.SetSuperUserContext(ctx)
+
+	for _, f := range em.Fields {
+		if f.Kind != "User" {
+			continue
+		}
+
+		for _, v := range vv.FilterByName(f.Name) {
+			userID, err := strconv.ParseUint(v.Value, 10, 64)
+
+			if err != nil || userID == 0 {
+				continue
+			}
+
+			u, err := s.systemUserService.With(ctx).FindByID(userID)
+
+			if err != nil || u.Email == "" {
+				continue
+			}
+
+			out = append(out, &decoder.ExposedUser{ID: u.ID, Email: u.Email})
+		}
+	}
+
+	return
+}
+
+// isFederatedUser checks if the user is the one that
+// persists the records received by the federation sync
+func (s *Sync) isFederatedUser(ctx context.Context, userID uint64) bool {
+	if auth.IsSuperUser(auth.NewIdentity(userID)) {
+		return true
+	}
+
+	u, err := s.systemUserService.With(ctx).FindByID(userID)
+
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(u.Handle, "federation_")
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ct "github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/decoder"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"go.uber.org/zap"
+)
+
+type (
+	syncNotifierData struct {
+		syncService *Sync
+		logger      *zap.Logger
+	}
+
+	eventRegistry interface {
+		Register(h eventbus.HandlerFn, ops ...eventbus.HandlerRegOp) uintptr
+	}
+
+	recordEvent interface {
+		Record() *ct.Record
+		OldRecord() *ct.Record
+	}
+
+	pushedRecordsPayload struct {
+		Records decoder.ExposedRecordSet `json:"records"`
+	}
+)
+
+func NotifierData(sync *Sync, logger *zap.Logger) *syncNotifierData {
+	return &syncNotifierData{
+		syncService: sync,
+		logger:      logger,
+	}
+}
+
+// Register hooks the notifier on the compose record after-events
+//
+// Paired nodes are notified about the changes as they happen,
+// so they do not need to wait for the next data sync
+func (n *syncNotifierData) Register(er eventRegistry) uintptr {
+	return er.Register(
+		n.handle,
+		eventbus.For("compose:record"),
+		eventbus.On("afterCreate", "afterUpdate", "afterDelete"),
+	)
+}
+
+func (n *syncNotifierData) handle(ctx context.Context, ev eventbus.Event) error {
+	re, ok := ev.(recordEvent)
+	if !ok {
+		return nil
+	}
+
+	rec := re.Record()
+	if rec == nil {
+		// record is passed as old record on delete
+		rec = re.OldRecord()
+	}
+
+	if rec == nil {
+		return nil
+	}
+
+	// changes made by the federation sync are left
+	// to the data sync of the other nodes
+	if auth.IsSuperUser(auth.GetIdentityFromContext(ctx)) {
+		return nil
+	}
+
+	// after events are handled synchronously; do not
+	// keep the invoker waiting for the remote nodes
+	go n.Notify(auth.SetSuperUserContext(context.Background()), rec)
+
+	return nil
+}
+
+// Notify pushes the record change to all the nodes
+// that the record's module is exposed to
+func (n *syncNotifierData) Notify(ctx context.Context, rec *ct.Record) {
+	set, err := n.syncService.GetExposedModules(ctx, rec.ModuleID)
+
+	if err != nil {
+		n.logger.Info("could not get exposed modules, skipping",
+			zap.Uint64("recordID", rec.ID),
+			zap.Error(err))
+
+		return
+	}
+
+	if len(set) == 0 {
+		return
+	}
+
+	// local change is accounted for in the version of the
+	// record before it is exposed to any of the nodes
+	if err = n.syncService.TrackRecordOrigin(ctx, rec); err != nil {
+		n.logger.Info("could not track record change, leaving it to data sync",
+			zap.Uint64("recordID", rec.ID),
+			zap.Error(err))
+	}
+
+	for _, em := range set {
+		z := []zap.Field{
+			zap.Uint64("nodeID", em.NodeID),
+			zap.Uint64("moduleID", em.ID),
+			zap.Uint64("recordID", rec.ID),
+		}
+
+		node, err := DefaultNode.FindByID(ctx, em.NodeID)
+
+		if err != nil || node.Status != types.NodeStatusPaired || node.AuthToken == "" {
+			n.logger.Info("node is not paired, skipping", z...)
+			continue
+		}
+
+		if !n.canRead(ctx, node, rec) {
+			continue
+		}
+
+		records, err := n.syncService.ExposeRecords(ctx, node, em, ct.RecordSet{rec})
+
+		if err != nil {
+			n.logger.Info("could not expose record, skipping", append(z, zap.Error(err))...)
+			continue
+		}
+
+		if len(records) == 0 {
+			continue
+		}
+
+		payload, err := json.Marshal(pushedRecordsPayload{Records: records})
+
+		if err != nil {
+			n.logger.Info("could not encode record, skipping", append(z, zap.Error(err))...)
+			continue
+		}
+
+		url := fmt.Sprintf("%s/nodes/%d/modules/%d/records/", node.BaseURL, node.SharedNodeID, em.ID)
+
+		// use the authToken from node pairing
+		err = n.syncService.PushUrl(context.WithValue(ctx, FederationUserToken, node.AuthToken), url, payload)
+
+		if err != nil {
+			n.logger.Info("could not push record change, leaving it to data sync", append(z, zap.Error(err))...)
+			continue
+		}
+
+		n.logger.Debug("pushed record change", z...)
+	}
+}
+
+// canRead checks if the federation user of the node can read the record
+//
+// Deleted records can not be read anymore; only the ID and the time of
+// deletion are pushed for those
+func (n *syncNotifierData) canRead(ctx context.Context, node *types.Node, rec *ct.Record) bool {
+	if rec.DeletedAt != nil {
+		return true
+	}
+
+	u, err := n.syncService.LoadUserWithRoles(ctx, node.ID)
+
+	if err != nil {
+		return false
+	}
+
+	ctx = auth.SetIdentityToContext(ctx, u)
+
+	_, err = n.syncService.composeRecordService.With(ctx).FindByID(rec.NamespaceID, rec.ModuleID, rec.ID)
+	return err == nil
+}
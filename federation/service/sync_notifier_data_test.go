@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ct "github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSyncNotifierData_expose(t *testing.T) {
+	var (
+		req  = require.New(t)
+		ctx  = context.Background()
+		ts   = time.Now()
+		node = &types.Node{ID: 42}
+
+		n = NotifierData(NewSync(
+			&Syncer{},
+			&Mapper{},
+			&testSharedModuleService{},
+			&testExposeRecordOriginService{
+				origins: map[uint64]*types.RecordOrigin{},
+				exposed: map[uint64]bool{},
+			},
+			&testRecordConflictService{},
+			&testAttachmentService{},
+			&testRecordServicePersistSuccess{},
+			&testExposeUserService{},
+			&testRoleService{}), zap.NewNop())
+
+		em = &types.ExposedModule{
+			ID: 1,
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "Name"},
+			},
+		}
+
+		rec = &ct.Record{
+			ID:        2,
+			CreatedBy: 1,
+			Values: ct.RecordValueSet{
+				&ct.RecordValue{Name: "Name", Value: "foo"},
+				&ct.RecordValue{Name: "Secret", Value: "bar"},
+			},
+		}
+	)
+
+	set, err := n.syncService.ExposeRecords(ctx, node, em, ct.RecordSet{rec})
+	req.NoError(err)
+	req.Len(set, 1)
+	req.Equal(uint64(2), set[0].ID)
+	req.Len(set[0].Values, 1)
+	req.Equal("Name", set[0].Values[0].Name)
+
+	rec.DeletedAt = &ts
+	set, err = n.syncService.ExposeRecords(ctx, node, em, ct.RecordSet{rec})
+	req.NoError(err)
+	req.Len(set, 1)
+	req.Equal(&ts, set[0].DeletedAt)
+	req.Empty(set[0].Values)
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cs "github.com/cortezaproject/corteza-server/compose/service"
+	ct "github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/federation/types"
+	ss "github.com/cortezaproject/corteza-server/system/service"
+	st "github.com/cortezaproject/corteza-server/system/types"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	testExposeRecordOriginService struct {
+		RecordOriginService
+		origins map[uint64]*types.RecordOrigin
+		exposed map[uint64]bool
+	}
+
+	testExposeRecordService struct {
+		cs.RecordService
+		matched ct.RecordSet
+	}
+
+	testExposeUserService struct {
+		ss.UserService
+	}
+)
+
+func TestSync_ExposeRecords(t *testing.T) {
+	var (
+		req  = require.New(t)
+		ctx  = context.Background()
+		ts   = time.Now()
+		node = &types.Node{ID: 42}
+
+		em = &types.ExposedModule{
+			ID: 1,
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "Name"},
+			},
+		}
+
+		values = ct.RecordValueSet{
+			&ct.RecordValue{Name: "Name", Value: "foo"},
+			&ct.RecordValue{Name: "Secret", Value: "bar"},
+		}
+
+		s = NewSync(
+			&Syncer{},
+			&Mapper{},
+			&testSharedModuleService{},
+			&testExposeRecordOriginService{
+				origins: map[uint64]*types.RecordOrigin{
+					// received from the node, changed locally
+					3: {RecordID: 3, NodeID: 42, OriginRecordID: 33, Clock: types.VectorClock{"": 1, "remote": 1}},
+					// received from the node, not changed
+					4: {RecordID: 4, NodeID: 42, OriginRecordID: 44, Clock: types.VectorClock{"remote": 1}},
+					// received from some other node
+					5: {RecordID: 5, NodeID: 7, OriginRecordID: 55, Clock: types.VectorClock{"": 1}},
+				},
+				exposed: map[uint64]bool{},
+			},
+			&testRecordConflictService{},
+			&testAttachmentService{},
+			&testRecordServicePersistSuccess{},
+			&testExposeUserService{},
+			&testRoleService{})
+	)
+
+	set, err := s.ExposeRecords(ctx, node, em, ct.RecordSet{
+		&ct.Record{ID: 2, CreatedBy: 1, Values: values},
+		&ct.Record{ID: 3, CreatedBy: 1, Values: values},
+		&ct.Record{ID: 4, CreatedBy: 1, Values: values},
+		&ct.Record{ID: 5, CreatedBy: 1, Values: values},
+		&ct.Record{ID: 6, CreatedBy: 1, DeletedAt: &ts, Values: values},
+	})
+
+	req.NoError(err)
+	req.Len(set, 3)
+
+	req.Equal(uint64(2), set[0].ID)
+	req.Zero(set[0].OriginRecordID)
+	req.Len(set[0].Values, 1)
+	req.Equal("Name", set[0].Values[0].Name)
+
+	req.Equal(uint64(3), set[1].ID)
+	req.Equal(uint64(33), set[1].OriginRecordID)
+	req.Equal(types.VectorClock{"": 1, "remote": 1}, set[1].Clock)
+
+	req.Equal(uint64(6), set[2].ID)
+	req.Equal(&ts, set[2].DeletedAt)
+	req.Empty(set[2].Values)
+}
+
+func TestSync_ExposeRecordsFiltered(t *testing.T) {
+	var (
+		req  = require.New(t)
+		ctx  = context.Background()
+		ts   = time.Now()
+		node = &types.Node{ID: 42}
+
+		em = &types.ExposedModule{
+			ID:           1,
+			RecordFilter: "Name = 'foo'",
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "Name"},
+			},
+		}
+
+		ros = &testExposeRecordOriginService{
+			origins: map[uint64]*types.RecordOrigin{},
+			exposed: map[uint64]bool{3: true, 4: true},
+		}
+
+		s = NewSync(
+			&Syncer{},
+			&Mapper{},
+			&testSharedModuleService{},
+			ros,
+			&testRecordConflictService{},
+			&testAttachmentService{},
+			&testExposeRecordService{matched: ct.RecordSet{&ct.Record{ID: 2}}},
+			&testExposeUserService{},
+			&testRoleService{})
+	)
+
+	set, err := s.ExposeRecords(ctx, node, em, ct.RecordSet{
+		// matching the filter
+		&ct.Record{ID: 2, CreatedBy: 1, UpdatedAt: &ts},
+		// was exposed, not matching anymore
+		&ct.Record{ID: 3, CreatedBy: 1, UpdatedAt: &ts},
+		// was exposed, deleted
+		&ct.Record{ID: 4, CreatedBy: 1, DeletedAt: &ts},
+		// never exposed, not matching
+		&ct.Record{ID: 5, CreatedBy: 1, UpdatedAt: &ts},
+	})
+
+	req.NoError(err)
+	req.Len(set, 3)
+
+	req.Equal(uint64(2), set[0].ID)
+	req.Nil(set[0].DeletedAt)
+
+	req.Equal(uint64(3), set[1].ID)
+	req.Equal(ts, *set[1].DeletedAt)
+	req.Empty(set[1].Values)
+
+	req.Equal(uint64(4), set[2].ID)
+	req.NotNil(set[2].DeletedAt)
+
+	req.Equal(map[uint64]bool{2: true}, ros.exposed)
+}
+
+func (s testExposeRecordOriginService) Load(_ context.Context, rec *ct.Record) (*types.RecordOrigin, error) {
+	if ro, ok := s.origins[rec.ID]; ok {
+		return ro, nil
+	}
+
+	return &types.RecordOrigin{RecordID: rec.ID, Clock: types.VectorClock{}}, nil
+}
+
+func (s testExposeUserService) With(_ context.Context) ss.UserService {
+	return s
+}
+
+func (s testExposeUserService) FindByID(ID uint64) (*st.User, error) {
+	return &st.User{ID: ID, Handle: "user"}, nil
+}
+
+func (s testExposeRecordOriginService) Expose(_ context.Context, _ *types.ExposedModule, rec *ct.Record) error {
+	s.exposed[rec.ID] = true
+	return nil
+}
+
+func (s testExposeRecordOriginService) Withdraw(_ context.Context, _ *types.ExposedModule, rec *ct.Record) (bool, error) {
+	exposed := s.exposed[rec.ID]
+	delete(s.exposed, rec.ID)
+	return exposed, nil
+}
+
+func (s testExposeRecordService) With(_ context.Context) cs.RecordService {
+	return s
+}
+
+func (s testExposeRecordService) Find(_ ct.RecordFilter) (ct.RecordSet, ct.RecordFilter, error) {
+	return s.matched, ct.RecordFilter{}, nil
+}
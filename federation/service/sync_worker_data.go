@@ -138,6 +138,7 @@ func (w *syncWorkerData) PrepareForNodes(ctx context.Context, urls chan Url) {
 				ComposeNamespaceID:  mappings.ComposeNamespaceID,
 				ModuleMappings:      &mappings.FieldMapping,
 				ModuleMappingValues: &mappingValues,
+				ConflictPolicy:      mappings.ConflictPolicy,
 				SyncService:         w.syncService,
 				User:                u,
 				Node:                n,
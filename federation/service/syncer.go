@@ -1,12 +1,16 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"mime"
 	"net/http"
+	neturl "net/url"
 	"time"
 
 	"github.com/cortezaproject/corteza-server/federation/types"
@@ -68,6 +72,93 @@ func (h *Syncer) Fetch(ctx context.Context, url string) (io.Reader, error) {
 	return resp.Body, nil
 }
 
+// FetchFile downloads the file from the given url
+//
+// Name of the file is read from the Content-Disposition header
+func (h *Syncer) FetchFile(ctx context.Context, url string) (name string, content []byte, err error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return
+	}
+
+	if authToken := ctx.Value(FederationUserToken); authToken != nil {
+		req.Header.Add("Authorization", `Bearer `+authToken.(string))
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		err = errors.New(fmt.Sprintf("invalid return status: %d", resp.StatusCode))
+		return
+	}
+
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		name, _ = neturl.QueryUnescape(params["filename"])
+	}
+
+	var (
+		body    io.Reader = resp.Body
+		maxSize           = int64(DefaultOptions.AttachmentMaxSize)
+	)
+
+	if maxSize > 0 {
+		if resp.ContentLength > maxSize {
+			err = fmt.Errorf("file size %d exceeds the limit of %d bytes", resp.ContentLength, maxSize)
+			return
+		}
+
+		// read one byte over the limit to detect oversized
+		// files that do not report their length
+		body = io.LimitReader(resp.Body, maxSize+1)
+	}
+
+	if content, err = ioutil.ReadAll(body); err != nil {
+		return
+	}
+
+	if maxSize > 0 && int64(len(content)) > maxSize {
+		content = nil
+		err = fmt.Errorf("file size exceeds the limit of %d bytes", maxSize)
+	}
+
+	return
+}
+
+// Push sends the payload to the given url
+//
+// Used when notifying the remote nodes about changes
+// on the exposed data
+func (h *Syncer) Push(ctx context.Context, url string, payload []byte) error {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if authToken := ctx.Value(FederationUserToken); authToken != nil {
+		req.Header.Add("Authorization", `Bearer `+authToken.(string))
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return errors.New(fmt.Sprintf("invalid return status: %d", resp.StatusCode))
+	}
+
+	return nil
+}
+
 func (h *Syncer) Process(ctx context.Context, payload []byte, out chan Url, url types.SyncerURI, processer Processer) (ProcesserResponse, error) {
 	aux, err := h.ParseHeader(ctx, payload)
 
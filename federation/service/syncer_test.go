@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/pkg/options"
 	"github.com/stretchr/testify/require"
 )
 
@@ -150,6 +151,103 @@ func TestSyncer_authTokenCorrectlySet(t *testing.T) {
 	req.NoError(err)
 }
 
+func TestSyncer_push(t *testing.T) {
+	var (
+		req             = require.New(t)
+		actualAuthToken = ""
+		actualMethod    = ""
+		actualBody      = ""
+	)
+
+	ctx := context.WithValue(
+		context.Background(),
+		FederationUserToken,
+		"TEST_JWT_TOKEN")
+
+	syncer := &Syncer{
+		client: *NewHttpClient(func(r *http.Request) *http.Response {
+			b, _ := ioutil.ReadAll(r.Body)
+
+			actualAuthToken = r.Header.Get("Authorization")
+			actualMethod = r.Method
+			actualBody = string(b)
+
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("OK")),
+				Header:     make(http.Header),
+			}
+		}),
+	}
+
+	err := syncer.Push(ctx, "http://example.ltd", []byte(`{"records":[]}`))
+
+	req.NoError(err)
+	req.Equal("Bearer TEST_JWT_TOKEN", actualAuthToken)
+	req.Equal("POST", actualMethod)
+	req.Equal(`{"records":[]}`, actualBody)
+}
+
+func TestSyncer_fetchFile(t *testing.T) {
+	var (
+		req             = require.New(t)
+		actualAuthToken = ""
+	)
+
+	ctx := context.WithValue(
+		context.Background(),
+		FederationUserToken,
+		"TEST_JWT_TOKEN")
+
+	syncer := &Syncer{
+		client: *NewHttpClient(func(r *http.Request) *http.Response {
+			actualAuthToken = r.Header.Get("Authorization")
+
+			h := make(http.Header)
+			h.Set("Content-Disposition", "attachment; filename=annual+report.pdf")
+
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("%PDF")),
+				Header:     h,
+			}
+		}),
+	}
+
+	name, content, err := syncer.FetchFile(ctx, "http://example.ltd")
+
+	req.NoError(err)
+	req.Equal("Bearer TEST_JWT_TOKEN", actualAuthToken)
+	req.Equal("annual report.pdf", name)
+	req.Equal("%PDF", string(content))
+}
+
+func TestSyncer_fetchFileTooLarge(t *testing.T) {
+	var (
+		req = require.New(t)
+		ctx = context.Background()
+	)
+
+	defer func(o options.FederationOpt) { DefaultOptions = o }(DefaultOptions)
+	DefaultOptions.AttachmentMaxSize = 3
+
+	syncer := &Syncer{
+		client: *NewHttpClient(func(r *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode:    200,
+				Body:          ioutil.NopCloser(bytes.NewBufferString("%PDF")),
+				Header:        make(http.Header),
+				ContentLength: -1,
+			}
+		}),
+	}
+
+	_, content, err := syncer.FetchFile(ctx, "http://example.ltd")
+
+	req.Error(err)
+	req.Nil(content)
+}
+
 func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
 	return f(req), nil
 }
@@ -0,0 +1,21 @@
+package types
+
+type (
+	// AttachmentOrigin links the local attachment
+	// to the attachment it was copied from
+	AttachmentOrigin struct {
+		AttachmentID uint64 `json:"attachmentID,string"`
+
+		// Node the attachment was received from
+		NodeID uint64 `json:"nodeID,string"`
+
+		// ID of the attachment on the origin node
+		OriginAttachmentID uint64 `json:"originAttachmentID,string"`
+	}
+
+	AttachmentOriginFilter struct {
+		NodeID uint64 `json:"nodeID,string"`
+
+		Check func(*AttachmentOrigin) (bool, error) `json:"-"`
+	}
+)
@@ -17,6 +17,11 @@ type (
 		Name               string         `json:"name"`
 		Fields             ModuleFieldSet `json:"fields"`
 
+		// RecordFilter limits the records that are exposed to the node
+		//
+		// Uses the same syntax as the compose record filter query
+		RecordFilter string `json:"recordFilter"`
+
 		CreatedAt time.Time  `json:"createdAt,omitempty"`
 		CreatedBy uint64     `json:"createdBy,string" `
 		UpdatedAt *time.Time `json:"updatedAt,omitempty"`
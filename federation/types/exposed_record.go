@@ -0,0 +1,24 @@
+package types
+
+import (
+	"time"
+)
+
+type (
+	// ExposedRecord tracks the records that were sent to the node
+	//
+	// Used to let the node know when the record is not
+	// exposed to it anymore
+	ExposedRecord struct {
+		ExposedModuleID uint64 `json:"moduleID,string"`
+		RecordID        uint64 `json:"recordID,string"`
+
+		ExposedAt time.Time `json:"exposedAt"`
+	}
+
+	ExposedRecordFilter struct {
+		ExposedModuleID uint64 `json:"moduleID,string"`
+
+		Check func(*ExposedRecord) (bool, error) `json:"-"`
+	}
+)
@@ -4,6 +4,18 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/filter"
 )
 
+const (
+	// Changes from origin always overwrite the local record
+	ModuleMappingConflictPolicyOrigin = ""
+
+	// The most recently changed version of the record wins
+	ModuleMappingConflictPolicyLastWriterWins = "lastWriterWins"
+
+	// Versions are compared by vector clocks;
+	// concurrent changes are queued for admins to resolve
+	ModuleMappingConflictPolicyVectorClock = "vectorClock"
+)
+
 type (
 	ModuleMapping struct {
 		NodeID             uint64                `json:"nodeID,string"`
@@ -11,6 +23,7 @@ type (
 		ComposeModuleID    uint64                `json:"composeModuleID,string"`
 		ComposeNamespaceID uint64                `json:"composeNamespaceID,string"`
 		FieldMapping       ModuleFieldMappingSet `json:"fields"`
+		ConflictPolicy     string                `json:"conflictPolicy"`
 	}
 
 	ModuleMappingFilter struct {
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"time"
+
+	ct "github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+)
+
+const (
+	RecordConflictResolutionLocal  = "local"
+	RecordConflictResolutionRemote = "remote"
+)
+
+type (
+	// RecordConflict holds the change received from the remote node
+	// that could not be applied on the local record automatically
+	RecordConflict struct {
+		ID uint64 `json:"conflictID,string"`
+
+		// Node that sent the change
+		NodeID uint64 `json:"nodeID,string"`
+
+		// Exposed module on the remote node the change was received from
+		ModuleID uint64 `json:"moduleID,string"`
+
+		// Local record that the change was made on
+		RecordID           uint64 `json:"recordID,string"`
+		ComposeModuleID    uint64 `json:"composeModuleID,string"`
+		ComposeNamespaceID uint64 `json:"composeNamespaceID,string"`
+
+		// Values and clock of the received change,
+		// already mapped to the local module fields
+		Values ct.RecordValueSet `json:"values"`
+		Clock  VectorClock       `json:"clock"`
+
+		Resolution string `json:"resolution,omitempty"`
+
+		CreatedAt  time.Time  `json:"createdAt,omitempty"`
+		ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+		ResolvedBy uint64     `json:"resolvedBy,string,omitempty"`
+	}
+
+	RecordConflictFilter struct {
+		NodeID   uint64 `json:"nodeID,string"`
+		RecordID uint64 `json:"recordID,string"`
+
+		// Include resolved conflicts
+		Resolved filter.State `json:"resolved"`
+
+		Check func(*RecordConflict) (bool, error) `json:"-"`
+
+		filter.Sorting
+		filter.Paging
+	}
+)
+
+func (c RecordConflict) IsResolved() bool {
+	return c.ResolvedAt != nil
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"time"
+)
+
+type (
+	// RecordOrigin tracks where the local compose record came from
+	// and the state of the record at the last sync
+	RecordOrigin struct {
+		RecordID uint64 `json:"recordID,string"`
+
+		// Node the record was received from,
+		// zero for the records that were created locally
+		NodeID uint64 `json:"nodeID,string"`
+
+		// ID of the record on the origin node
+		OriginRecordID uint64 `json:"originRecordID,string"`
+
+		Clock VectorClock `json:"clock"`
+
+		// Time of the last change of the record that
+		// is already accounted for in the clock
+		SyncedAt *time.Time `json:"syncedAt,omitempty"`
+	}
+
+	RecordOriginFilter struct {
+		NodeID uint64 `json:"nodeID,string"`
+
+		Check func(*RecordOrigin) (bool, error) `json:"-"`
+	}
+)
@@ -10,11 +10,21 @@ package types
 
 type (
 
+	// AttachmentOriginSet slice of AttachmentOrigin
+	//
+	// This type is auto-generated.
+	AttachmentOriginSet []*AttachmentOrigin
+
 	// ExposedModuleSet slice of ExposedModule
 	//
 	// This type is auto-generated.
 	ExposedModuleSet []*ExposedModule
 
+	// ExposedRecordSet slice of ExposedRecord
+	//
+	// This type is auto-generated.
+	ExposedRecordSet []*ExposedRecord
+
 	// ModuleMappingSet slice of ModuleMapping
 	//
 	// This type is auto-generated.
@@ -30,12 +40,52 @@ type (
 	// This type is auto-generated.
 	NodeSyncSet []*NodeSync
 
+	// RecordConflictSet slice of RecordConflict
+	//
+	// This type is auto-generated.
+	RecordConflictSet []*RecordConflict
+
+	// RecordOriginSet slice of RecordOrigin
+	//
+	// This type is auto-generated.
+	RecordOriginSet []*RecordOrigin
+
 	// SharedModuleSet slice of SharedModule
 	//
 	// This type is auto-generated.
 	SharedModuleSet []*SharedModule
 )
 
+// Walk iterates through every slice item and calls w(AttachmentOrigin) err
+//
+// This function is auto-generated.
+func (set AttachmentOriginSet) Walk(w func(*AttachmentOrigin) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(AttachmentOrigin) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set AttachmentOriginSet) Filter(f func(*AttachmentOrigin) (bool, error)) (out AttachmentOriginSet, err error) {
+	var ok bool
+	out = AttachmentOriginSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
 // Walk iterates through every slice item and calls w(ExposedModule) err
 //
 // This function is auto-generated.
@@ -92,6 +142,36 @@ func (set ExposedModuleSet) IDs() (IDs []uint64) {
 	return
 }
 
+// Walk iterates through every slice item and calls w(ExposedRecord) err
+//
+// This function is auto-generated.
+func (set ExposedRecordSet) Walk(w func(*ExposedRecord) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(ExposedRecord) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set ExposedRecordSet) Filter(f func(*ExposedRecord) (bool, error)) (out ExposedRecordSet, err error) {
+	var ok bool
+	out = ExposedRecordSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
 // Walk iterates through every slice item and calls w(ModuleMapping) err
 //
 // This function is auto-generated.
@@ -208,6 +288,92 @@ func (set NodeSyncSet) Filter(f func(*NodeSync) (bool, error)) (out NodeSyncSet,
 	return
 }
 
+// Walk iterates through every slice item and calls w(RecordConflict) err
+//
+// This function is auto-generated.
+func (set RecordConflictSet) Walk(w func(*RecordConflict) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(RecordConflict) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set RecordConflictSet) Filter(f func(*RecordConflict) (bool, error)) (out RecordConflictSet, err error) {
+	var ok bool
+	out = RecordConflictSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
+// FindByID finds items from slice by its ID property
+//
+// This function is auto-generated.
+func (set RecordConflictSet) FindByID(ID uint64) *RecordConflict {
+	for i := range set {
+		if set[i].ID == ID {
+			return set[i]
+		}
+	}
+
+	return nil
+}
+
+// IDs returns a slice of uint64s from all items in the set
+//
+// This function is auto-generated.
+func (set RecordConflictSet) IDs() (IDs []uint64) {
+	IDs = make([]uint64, len(set))
+
+	for i := range set {
+		IDs[i] = set[i].ID
+	}
+
+	return
+}
+
+// Walk iterates through every slice item and calls w(RecordOrigin) err
+//
+// This function is auto-generated.
+func (set RecordOriginSet) Walk(w func(*RecordOrigin) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(RecordOrigin) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set RecordOriginSet) Filter(f func(*RecordOrigin) (bool, error)) (out RecordOriginSet, err error) {
+	var ok bool
+	out = RecordOriginSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
 // Walk iterates through every slice item and calls w(SharedModule) err
 //
 // This function is auto-generated.
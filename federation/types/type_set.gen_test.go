@@ -14,6 +14,62 @@ import (
 	"testing"
 )
 
+func TestAttachmentOriginSetWalk(t *testing.T) {
+	var (
+		value = make(AttachmentOriginSet, 3)
+		req   = require.New(t)
+	)
+
+	// check walk with no errors
+	{
+		err := value.Walk(func(*AttachmentOrigin) error {
+			return nil
+		})
+		req.NoError(err)
+	}
+
+	// check walk with error
+	req.Error(value.Walk(func(*AttachmentOrigin) error { return fmt.Errorf("walk error") }))
+}
+
+func TestAttachmentOriginSetFilter(t *testing.T) {
+	var (
+		value = make(AttachmentOriginSet, 3)
+		req   = require.New(t)
+	)
+
+	// filter nothing
+	{
+		set, err := value.Filter(func(*AttachmentOrigin) (bool, error) {
+			return true, nil
+		})
+		req.NoError(err)
+		req.Equal(len(set), len(value))
+	}
+
+	// filter one item
+	{
+		found := false
+		set, err := value.Filter(func(*AttachmentOrigin) (bool, error) {
+			if !found {
+				found = true
+				return found, nil
+			}
+			return false, nil
+		})
+		req.NoError(err)
+		req.Len(set, 1)
+	}
+
+	// filter error
+	{
+		_, err := value.Filter(func(*AttachmentOrigin) (bool, error) {
+			return false, fmt.Errorf("filter error")
+		})
+		req.Error(err)
+	}
+}
+
 func TestExposedModuleSetWalk(t *testing.T) {
 	var (
 		value = make(ExposedModuleSet, 3)
@@ -104,6 +160,62 @@ func TestExposedModuleSetIDs(t *testing.T) {
 	}
 }
 
+func TestExposedRecordSetWalk(t *testing.T) {
+	var (
+		value = make(ExposedRecordSet, 3)
+		req   = require.New(t)
+	)
+
+	// check walk with no errors
+	{
+		err := value.Walk(func(*ExposedRecord) error {
+			return nil
+		})
+		req.NoError(err)
+	}
+
+	// check walk with error
+	req.Error(value.Walk(func(*ExposedRecord) error { return fmt.Errorf("walk error") }))
+}
+
+func TestExposedRecordSetFilter(t *testing.T) {
+	var (
+		value = make(ExposedRecordSet, 3)
+		req   = require.New(t)
+	)
+
+	// filter nothing
+	{
+		set, err := value.Filter(func(*ExposedRecord) (bool, error) {
+			return true, nil
+		})
+		req.NoError(err)
+		req.Equal(len(set), len(value))
+	}
+
+	// filter one item
+	{
+		found := false
+		set, err := value.Filter(func(*ExposedRecord) (bool, error) {
+			if !found {
+				found = true
+				return found, nil
+			}
+			return false, nil
+		})
+		req.NoError(err)
+		req.Len(set, 1)
+	}
+
+	// filter error
+	{
+		_, err := value.Filter(func(*ExposedRecord) (bool, error) {
+			return false, fmt.Errorf("filter error")
+		})
+		req.Error(err)
+	}
+}
+
 func TestModuleMappingSetWalk(t *testing.T) {
 	var (
 		value = make(ModuleMappingSet, 3)
@@ -306,6 +418,152 @@ func TestNodeSyncSetFilter(t *testing.T) {
 	}
 }
 
+func TestRecordConflictSetWalk(t *testing.T) {
+	var (
+		value = make(RecordConflictSet, 3)
+		req   = require.New(t)
+	)
+
+	// check walk with no errors
+	{
+		err := value.Walk(func(*RecordConflict) error {
+			return nil
+		})
+		req.NoError(err)
+	}
+
+	// check walk with error
+	req.Error(value.Walk(func(*RecordConflict) error { return fmt.Errorf("walk error") }))
+}
+
+func TestRecordConflictSetFilter(t *testing.T) {
+	var (
+		value = make(RecordConflictSet, 3)
+		req   = require.New(t)
+	)
+
+	// filter nothing
+	{
+		set, err := value.Filter(func(*RecordConflict) (bool, error) {
+			return true, nil
+		})
+		req.NoError(err)
+		req.Equal(len(set), len(value))
+	}
+
+	// filter one item
+	{
+		found := false
+		set, err := value.Filter(func(*RecordConflict) (bool, error) {
+			if !found {
+				found = true
+				return found, nil
+			}
+			return false, nil
+		})
+		req.NoError(err)
+		req.Len(set, 1)
+	}
+
+	// filter error
+	{
+		_, err := value.Filter(func(*RecordConflict) (bool, error) {
+			return false, fmt.Errorf("filter error")
+		})
+		req.Error(err)
+	}
+}
+
+func TestRecordConflictSetIDs(t *testing.T) {
+	var (
+		value = make(RecordConflictSet, 3)
+		req   = require.New(t)
+	)
+
+	// construct objects
+	value[0] = new(RecordConflict)
+	value[1] = new(RecordConflict)
+	value[2] = new(RecordConflict)
+	// set ids
+	value[0].ID = 1
+	value[1].ID = 2
+	value[2].ID = 3
+
+	// Find existing
+	{
+		val := value.FindByID(2)
+		req.Equal(uint64(2), val.ID)
+	}
+
+	// Find non-existing
+	{
+		val := value.FindByID(4)
+		req.Nil(val)
+	}
+
+	// List IDs from set
+	{
+		val := value.IDs()
+		req.Equal(len(val), len(value))
+	}
+}
+
+func TestRecordOriginSetWalk(t *testing.T) {
+	var (
+		value = make(RecordOriginSet, 3)
+		req   = require.New(t)
+	)
+
+	// check walk with no errors
+	{
+		err := value.Walk(func(*RecordOrigin) error {
+			return nil
+		})
+		req.NoError(err)
+	}
+
+	// check walk with error
+	req.Error(value.Walk(func(*RecordOrigin) error { return fmt.Errorf("walk error") }))
+}
+
+func TestRecordOriginSetFilter(t *testing.T) {
+	var (
+		value = make(RecordOriginSet, 3)
+		req   = require.New(t)
+	)
+
+	// filter nothing
+	{
+		set, err := value.Filter(func(*RecordOrigin) (bool, error) {
+			return true, nil
+		})
+		req.NoError(err)
+		req.Equal(len(set), len(value))
+	}
+
+	// filter one item
+	{
+		found := false
+		set, err := value.Filter(func(*RecordOrigin) (bool, error) {
+			if !found {
+				found = true
+				return found, nil
+			}
+			return false, nil
+		})
+		req.NoError(err)
+		req.Len(set, 1)
+	}
+
+	// filter error
+	{
+		_, err := value.Filter(func(*RecordOrigin) (bool, error) {
+			return false, fmt.Errorf("filter error")
+		})
+		req.Error(err)
+	}
+}
+
 func TestSharedModuleSetWalk(t *testing.T) {
 	var (
 		value = make(SharedModuleSet, 3)
@@ -0,0 +1,85 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+type (
+	// VectorClock tracks the number of changes that
+	// each of the nodes made on the record
+	//
+	// Nodes are keyed by their federation host
+	VectorClock map[string]uint64
+)
+
+const (
+	VectorClockEqual int = iota
+	VectorClockBefore
+	VectorClockAfter
+	VectorClockConcurrent
+)
+
+// Tick increments the counter of the node
+func (vc VectorClock) Tick(node string) {
+	vc[node]++
+}
+
+// Merge takes the highest counter of each of the nodes
+func (vc VectorClock) Merge(other VectorClock) {
+	for node, c := range other {
+		if c > vc[node] {
+			vc[node] = c
+		}
+	}
+}
+
+// Compare tells how the clock relates to the other clock
+//
+// Clocks are concurrent when each of them
+// holds changes that the other one does not know about
+func (vc VectorClock) Compare(other VectorClock) int {
+	var before, after bool
+
+	for node, c := range vc {
+		if c > other[node] {
+			after = true
+		}
+	}
+
+	for node, c := range other {
+		if c > vc[node] {
+			before = true
+		}
+	}
+
+	switch {
+	case before && after:
+		return VectorClockConcurrent
+	case before:
+		return VectorClockBefore
+	case after:
+		return VectorClockAfter
+	default:
+		return VectorClockEqual
+	}
+}
+
+func (vc VectorClock) Value() (driver.Value, error) {
+	return json.Marshal(vc)
+}
+
+func (vc *VectorClock) Scan(value interface{}) error {
+	switch value.(type) {
+	case nil:
+		*vc = VectorClock{}
+	case []uint8:
+		if err := json.Unmarshal(value.([]byte), vc); err != nil {
+			return errors.New(fmt.Sprintf("Can not scan '%v' into VectorClock", value))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVectorClock_Compare(t *testing.T) {
+	var (
+		tcc = []struct {
+			name   string
+			a      VectorClock
+			b      VectorClock
+			expect int
+		}{
+			{"empty", VectorClock{}, VectorClock{}, VectorClockEqual},
+			{"equal", VectorClock{"a": 1, "b": 2}, VectorClock{"a": 1, "b": 2}, VectorClockEqual},
+			{"before", VectorClock{"a": 1}, VectorClock{"a": 1, "b": 1}, VectorClockBefore},
+			{"after", VectorClock{"a": 2, "b": 1}, VectorClock{"a": 1, "b": 1}, VectorClockAfter},
+			{"concurrent", VectorClock{"a": 2, "b": 1}, VectorClock{"a": 1, "b": 2}, VectorClockConcurrent},
+		}
+	)
+
+	for _, tc := range tcc {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expect, tc.a.Compare(tc.b))
+		})
+	}
+}
+
+func TestVectorClock_TickMerge(t *testing.T) {
+	var (
+		req = require.New(t)
+		vc  = VectorClock{"a": 1}
+	)
+
+	vc.Tick("b")
+	req.Equal(VectorClock{"a": 1, "b": 1}, vc)
+
+	vc.Merge(VectorClock{"a": 3, "b": 0, "c": 1})
+	req.Equal(VectorClock{"a": 3, "b": 1, "c": 1}, vc)
+}
@@ -16,12 +16,31 @@ type (
 	}
 
 	accessControlRBACServicer interface {
-		Can([]uint64, rbac.Resource, rbac.Operation, ...rbac.CheckAccessFunc) bool
+		Can(context.Context, []uint64, rbac.Resource, rbac.Operation, ...rbac.CheckAccessFunc) bool
 		Grant(context.Context, rbac.Whitelist, ...*rbac.Rule) error
 		FindRulesByRoleID(roleID uint64) (rr rbac.RuleSet)
 	}
 )
 
+var (
+	// access of operations when no rule matches
+	accessDefaults = rbac.RegisterDefaults(rbac.Defaults{
+		types.MessagingRBACResource: {
+			"channel.public.create":  rbac.Allow,
+			"channel.private.create": rbac.Allow,
+			"channel.group.create":   rbac.Allow,
+		},
+		types.ChannelRBACResource: {
+			"leave":              rbac.Allow,
+			"message.reply":      rbac.Allow,
+			"message.embed":      rbac.Allow,
+			"message.update.own": rbac.Allow,
+			"message.delete.own": rbac.Allow,
+			"message.react":      rbac.Allow,
+		},
+	})
+)
+
 func AccessControl(perm accessControlRBACServicer) *accessControl {
 	return &accessControl{
 		permissions: perm,
@@ -61,15 +80,15 @@ func (svc accessControl) CanManageSettings(ctx context.Context) bool {
 }
 
 func (svc accessControl) CanCreatePublicChannel(ctx context.Context) bool {
-	return svc.can(ctx, types.MessagingRBACResource, "channel.public.create", rbac.Allowed)
+	return svc.can(ctx, types.MessagingRBACResource, "channel.public.create")
 }
 
 func (svc accessControl) CanCreatePrivateChannel(ctx context.Context) bool {
-	return svc.can(ctx, types.MessagingRBACResource, "channel.private.create", rbac.Allowed)
+	return svc.can(ctx, types.MessagingRBACResource, "channel.private.create")
 }
 
 func (svc accessControl) CanCreateGroupChannel(ctx context.Context) bool {
-	return svc.can(ctx, types.MessagingRBACResource, "channel.group.create", rbac.Allowed)
+	return svc.can(ctx, types.MessagingRBACResource, "channel.group.create")
 }
 
 func (svc accessControl) CanUpdateChannel(ctx context.Context, ch *types.Channel) bool {
@@ -85,7 +104,7 @@ func (svc accessControl) CanJoinChannel(ctx context.Context, ch *types.Channel)
 }
 
 func (svc accessControl) CanLeaveChannel(ctx context.Context, ch *types.Channel) bool {
-	return svc.can(ctx, ch.RBACResource(), "leave", rbac.Allowed)
+	return svc.can(ctx, ch.RBACResource(), "leave")
 }
 
 func (svc accessControl) CanArchiveChannel(ctx context.Context, ch *types.Channel) bool {
@@ -122,11 +141,11 @@ func (svc accessControl) CanSendMessage(ctx context.Context, ch *types.Channel)
 }
 
 func (svc accessControl) CanReplyMessage(ctx context.Context, ch *types.Channel) bool {
-	return svc.can(ctx, ch.RBACResource(), "message.reply", rbac.Allowed)
+	return svc.can(ctx, ch.RBACResource(), "message.reply")
 }
 
 func (svc accessControl) CanEmbedMessage(ctx context.Context, ch *types.Channel) bool {
-	return svc.can(ctx, ch.RBACResource(), "message.embed", rbac.Allowed)
+	return svc.can(ctx, ch.RBACResource(), "message.embed")
 }
 
 func (svc accessControl) CanAttachMessage(ctx context.Context, ch *types.Channel) bool {
@@ -134,7 +153,7 @@ func (svc accessControl) CanAttachMessage(ctx context.Context, ch *types.Channel
 }
 
 func (svc accessControl) CanUpdateOwnMessages(ctx context.Context, ch *types.Channel) bool {
-	return svc.can(ctx, ch.RBACResource(), "message.update.own", rbac.Allowed)
+	return svc.can(ctx, ch.RBACResource(), "message.update.own")
 }
 
 func (svc accessControl) CanUpdateMessages(ctx context.Context, ch *types.Channel) bool {
@@ -143,7 +162,7 @@ func (svc accessControl) CanUpdateMessages(ctx context.Context, ch *types.Channe
 
 func (svc accessControl) CanDeleteOwnMessages(ctx context.Context, ch *types.Channel) bool {
 	// @todo implement
-	return svc.can(ctx, ch.RBACResource(), "message.delete.own", rbac.Allowed)
+	return svc.can(ctx, ch.RBACResource(), "message.delete.own")
 }
 
 func (svc accessControl) CanDeleteMessages(ctx context.Context, ch *types.Channel) bool {
@@ -151,7 +170,7 @@ func (svc accessControl) CanDeleteMessages(ctx context.Context, ch *types.Channe
 }
 
 func (svc accessControl) CanReactMessage(ctx context.Context, ch *types.Channel) bool {
-	return svc.can(ctx, ch.RBACResource(), "message.react", rbac.Allowed)
+	return svc.can(ctx, ch.RBACResource(), "message.react")
 }
 
 func (svc accessControl) canJoinFallback(ctx context.Context, ch *types.Channel) func() rbac.Access {
@@ -216,7 +235,7 @@ func (svc accessControl) can(ctx context.Context, res rbac.Resource, op rbac.Ope
 		return true
 	}
 
-	return svc.permissions.Can(roles, res, op, ff...)
+	return svc.permissions.Can(ctx, roles, res, op, append(ff, accessDefaults.Fallback(res, op))...)
 }
 
 func (svc accessControl) Grant(ctx context.Context, rr ...*rbac.Rule) error {
@@ -224,6 +243,12 @@ func (svc accessControl) Grant(ctx context.Context, rr ...*rbac.Rule) error {
 		return AccessControlErrNotAllowedToSetPermissions()
 	}
 
+	for _, r := range rr {
+		if err := r.Conditions.Validate(); err != nil {
+			return AccessControlErrInvalidConditions(&accessControlActionProps{rule: r}).Wrap(err)
+		}
+	}
+
 	if err := svc.permissions.Grant(ctx, svc.Whitelist(), rr...); err != nil {
 		return AccessControlErrGeneric().Wrap(err)
 	}
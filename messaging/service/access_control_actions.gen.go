@@ -241,6 +241,36 @@ func AccessControlErrNotAllowedToSetPermissions(mm ...*accessControlActionProps)
 	return e
 }
 
+// AccessControlErrInvalidConditions returns "messaging:access_control.invalidConditions" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func AccessControlErrInvalidConditions(mm ...*accessControlActionProps) *errors.Error {
+	var p = &accessControlActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid conditions of rule '{rule.operation}' on '{rule.resource}'", nil),
+
+		errors.Meta("type", "invalidConditions"),
+		errors.Meta("resource", "messaging:access_control"),
+
+		errors.Meta(accessControlPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
 // *********************************************************************************************************************
 // *********************************************************************************************************************
 
@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"github.com/cortezaproject/corteza-server/messaging/types"
 	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 )
 
 // dummy placing to simplify import generation logic
@@ -392,6 +393,11 @@ func (res *messagingBase) Decode(results map[string][]byte) (err error) {
 	return
 }
 
+// restore decodes encoded arguments into struct props
+func (res *messagingBase) restore(args map[string][]byte) (err error) {
+	return
+}
+
 // ResourceType returns "messaging:channel"
 //
 // This function is auto-generated.
@@ -774,6 +780,23 @@ func (res *channelBase) Decode(results map[string][]byte) (err error) {
 	return
 }
 
+// restore decodes encoded arguments into struct props
+func (res *channelBase) restore(args map[string][]byte) (err error) {
+	if r, ok := args["channel"]; ok {
+		if err = json.Unmarshal(r, &res.channel); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["oldChannel"]; ok {
+		if err = json.Unmarshal(r, &res.oldChannel); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 // ResourceType returns "messaging:channel:member"
 //
 // This function is auto-generated.
@@ -1210,6 +1233,23 @@ func (res *channelMemberBase) Decode(results map[string][]byte) (err error) {
 	return
 }
 
+// restore decodes encoded arguments into struct props
+func (res *channelMemberBase) restore(args map[string][]byte) (err error) {
+	if r, ok := args["member"]; ok {
+		if err = json.Unmarshal(r, &res.member); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["channel"]; ok {
+		if err = json.Unmarshal(r, &res.channel); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 // ResourceType returns "messaging:command"
 //
 // This function is auto-generated.
@@ -1333,6 +1373,23 @@ func (res *commandBase) Decode(results map[string][]byte) (err error) {
 	return
 }
 
+// restore decodes encoded arguments into struct props
+func (res *commandBase) restore(args map[string][]byte) (err error) {
+	if r, ok := args["command"]; ok {
+		if err = json.Unmarshal(r, &res.command); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["channel"]; ok {
+		if err = json.Unmarshal(r, &res.channel); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 // ResourceType returns "messaging:message"
 //
 // This function is auto-generated.
@@ -1768,3 +1825,130 @@ func (res *messageBase) Decode(results map[string][]byte) (err error) {
 	}
 	return
 }
+
+// restore decodes encoded arguments into struct props
+func (res *messageBase) restore(args map[string][]byte) (err error) {
+	if r, ok := args["message"]; ok {
+		if err = json.Unmarshal(r, &res.message); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["oldMessage"]; ok {
+		if err = json.Unmarshal(r, &res.oldMessage); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["channel"]; ok {
+		if err = json.Unmarshal(r, &res.channel); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Restore creates event from the encoded arguments
+//
+// Restored events are immutable. Returns nil for unknown resource or event types.
+//
+// This function is auto-generated.
+func Restore(resourceType, eventType string, args map[string][]byte) (eventbus.Event, error) {
+	switch resourceType {
+	case "messaging":
+		res := &messagingBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "onManual":
+			return &messagingOnManual{messagingBase: res}, nil
+		case "onInterval":
+			return &messagingOnInterval{messagingBase: res}, nil
+		case "onTimestamp":
+			return &messagingOnTimestamp{messagingBase: res}, nil
+		}
+	case "messaging:channel":
+		res := &channelBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "onManual":
+			return &channelOnManual{channelBase: res}, nil
+		case "beforeCreate":
+			return &channelBeforeCreate{channelBase: res}, nil
+		case "beforeUpdate":
+			return &channelBeforeUpdate{channelBase: res}, nil
+		case "beforeDelete":
+			return &channelBeforeDelete{channelBase: res}, nil
+		case "afterCreate":
+			return &channelAfterCreate{channelBase: res}, nil
+		case "afterUpdate":
+			return &channelAfterUpdate{channelBase: res}, nil
+		case "afterDelete":
+			return &channelAfterDelete{channelBase: res}, nil
+		}
+	case "messaging:channel:member":
+		res := &channelMemberBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "beforeJoin":
+			return &channelMemberBeforeJoin{channelMemberBase: res}, nil
+		case "beforePart":
+			return &channelMemberBeforePart{channelMemberBase: res}, nil
+		case "beforeAdd":
+			return &channelMemberBeforeAdd{channelMemberBase: res}, nil
+		case "beforeRemove":
+			return &channelMemberBeforeRemove{channelMemberBase: res}, nil
+		case "afterJoin":
+			return &channelMemberAfterJoin{channelMemberBase: res}, nil
+		case "afterPart":
+			return &channelMemberAfterPart{channelMemberBase: res}, nil
+		case "afterAdd":
+			return &channelMemberAfterAdd{channelMemberBase: res}, nil
+		case "afterRemove":
+			return &channelMemberAfterRemove{channelMemberBase: res}, nil
+		}
+	case "messaging:command":
+		res := &commandBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "onInvoke":
+			return &commandOnInvoke{commandBase: res}, nil
+		}
+	case "messaging:message":
+		res := &messageBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "onManual":
+			return &messageOnManual{messageBase: res}, nil
+		case "beforeCreate":
+			return &messageBeforeCreate{messageBase: res}, nil
+		case "beforeUpdate":
+			return &messageBeforeUpdate{messageBase: res}, nil
+		case "beforeDelete":
+			return &messageBeforeDelete{messageBase: res}, nil
+		case "afterCreate":
+			return &messageAfterCreate{messageBase: res}, nil
+		case "afterUpdate":
+			return &messageAfterUpdate{messageBase: res}, nil
+		case "afterDelete":
+			return &messageAfterDelete{messageBase: res}, nil
+		}
+	}
+
+	return nil, nil
+}
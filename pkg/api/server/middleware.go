@@ -6,15 +6,19 @@ import (
 	"github.com/getsentry/sentry-go/http"
 	"github.com/go-chi/chi/middleware"
 	"go.uber.org/zap"
+	"net"
 	"net/http"
 	"os"
 	"runtime/debug"
 )
 
-func BaseMiddleware(isProduction bool, log *zap.Logger) []func(http.Handler) http.Handler {
+// BaseMiddleware returns middlewares used for all requests
+//
+// Client address is taken from the proxy headers only for requests made by one of the trusted proxies
+func BaseMiddleware(isProduction bool, log *zap.Logger, trustedProxies []*net.IPNet) []func(http.Handler) http.Handler {
 	return []func(http.Handler) http.Handler{
 		handleCORS,
-		middleware.RealIP,
+		realIP(trustedProxies),
 		api.RemoteAddrToContext,
 		middleware.RequestID,
 		api.DebugToContext(isProduction),
@@ -0,0 +1,266 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/options"
+	"github.com/cortezaproject/corteza-server/pkg/ratelimit"
+)
+
+type (
+	rateLimiter struct {
+		log     *zap.Logger
+		backend ratelimit.Backend
+
+		// only requests to the API are limited
+		baseURL string
+
+		user  ratelimit.Limit
+		token ratelimit.Limit
+		ip    ratelimit.Limit
+
+		// sorted by prefix length, longest first
+		groups []rateLimitGroup
+	}
+
+	rateLimitGroup struct {
+		prefix string
+		limit  ratelimit.Limit
+
+		// limit each IP address, even for authenticated requests
+		byIP bool
+	}
+)
+
+const (
+	// internal authentication endpoints (relative to API base URL)
+	rateLimitAuthPrefix = "/system/auth/internal/"
+)
+
+// RateLimit returns middleware that limits requests to the API
+//
+// Each request takes from user's (or IP's for unauthenticated requests)
+// and token's bucket and from the bucket of the first matching route group.
+func RateLimit(log *zap.Logger, opt options.RateLimitOpt, apiBaseUrl string, b ratelimit.Backend) (func(http.Handler) http.Handler, error) {
+	var (
+		rl = &rateLimiter{
+			log:     log.Named("ratelimit"),
+			backend: b,
+			baseURL: "/" + strings.Trim(apiBaseUrl, "/"),
+		}
+
+		err error
+	)
+
+	if rl.baseURL == "/" {
+		rl.baseURL = ""
+	}
+
+	if rl.user, err = ratelimit.ParseLimit(opt.User); err != nil {
+		return nil, err
+	}
+
+	if rl.token, err = ratelimit.ParseLimit(opt.Token); err != nil {
+		return nil, err
+	}
+
+	if rl.ip, err = ratelimit.ParseLimit(opt.IP); err != nil {
+		return nil, err
+	}
+
+	if rl.groups, err = parseRateLimitGroups(opt.Groups); err != nil {
+		return nil, err
+	}
+
+	if g, err := makeRateLimitGroup(rateLimitAuthPrefix, opt.Auth); err != nil {
+		return nil, err
+	} else if !g.limit.IsZero() {
+		g.byIP = true
+		rl.groups = append(rl.groups, g)
+	}
+
+	sort.SliceStable(rl.groups, func(i, j int) bool {
+		return len(rl.groups[i].prefix) > len(rl.groups[j].prefix)
+	})
+
+	return rl.Handler, nil
+}
+
+// parses comma separated list of <path-prefix>=<limit> definitions
+func parseRateLimitGroups(s string) (gg []rateLimitGroup, err error) {
+	for _, def := range strings.Split(s, ",") {
+		if def = strings.TrimSpace(def); def == "" {
+			continue
+		}
+
+		parts := strings.SplitN(def, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid rate limit group %q, expecting <path-prefix>=<limit>", def)
+		}
+
+		g, err := makeRateLimitGroup(parts[0], parts[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if !g.limit.IsZero() {
+			gg = append(gg, g)
+		}
+	}
+
+	return
+}
+
+func makeRateLimitGroup(prefix, limit string) (g rateLimitGroup, err error) {
+	g.prefix = "/" + strings.TrimLeft(strings.TrimSpace(prefix), "/")
+	g.limit, err = ratelimit.ParseLimit(limit)
+	return
+}
+
+func (rl *rateLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, rl.baseURL+"/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var (
+			ctx      = r.Context()
+			path     = strings.TrimPrefix(r.URL.Path, rl.baseURL)
+			identity = auth.GetIdentityFromContext(ctx)
+			ip       = remoteIP(r)
+
+			// key of the client (user or IP) for the limits that apply to both
+			client = "ip:" + ip
+
+			rr = make([]ratelimit.Result, 0, 3)
+
+			take = func(key string, l ratelimit.Limit) {
+				if l.IsZero() {
+					return
+				}
+
+				res, err := rl.backend.Take(ctx, key, l)
+				if err != nil {
+					// do not block requests when limits can not be checked
+					rl.log.Error("could not check rate limit", zap.String("key", key), zap.Error(err))
+					return
+				}
+
+				rr = append(rr, res)
+			}
+		)
+
+		if identity.Valid() {
+			client = "user:" + strconv.FormatUint(identity.Identity(), 10)
+			take(client, rl.user)
+		} else {
+			take(client, rl.ip)
+		}
+
+		if jwt := auth.GetJwtFromContext(ctx); jwt != "" {
+			take("token:"+hashToken(jwt), rl.token)
+		}
+
+		for _, g := range rl.groups {
+			if !strings.HasPrefix(path, g.prefix) {
+				continue
+			}
+
+			if g.byIP {
+				take("group:"+g.prefix+":ip:"+ip, g.limit)
+			} else {
+				take("group:"+g.prefix+":"+client, g.limit)
+			}
+
+			break
+		}
+
+		if len(rr) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		res := mostRestrictive(rr)
+		writeRateLimitHeaders(w, res)
+
+		if !res.Allowed {
+			rl.log.Debug("rate limit exceeded", zap.String("client", client), zap.String("path", path))
+			w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter, 1)))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"error": map[string]string{"message": "rate limit exceeded"},
+			})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// mostRestrictive returns denied result with the longest wait
+// or, when all are allowed, the one with the least remaining requests
+func mostRestrictive(rr []ratelimit.Result) (res ratelimit.Result) {
+	res = rr[0]
+	for _, r := range rr[1:] {
+		if r.Allowed != res.Allowed {
+			if !r.Allowed {
+				res = r
+			}
+
+			continue
+		}
+
+		if (!r.Allowed && r.RetryAfter > res.RetryAfter) || (r.Allowed && r.Remaining < res.Remaining) {
+			res = r
+		}
+	}
+
+	return
+}
+
+func writeRateLimitHeaders(w http.ResponseWriter, res ratelimit.Result) {
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset, 0)))
+}
+
+func ceilSeconds(d time.Duration, min int) int {
+	if s := int(math.Ceil(d.Seconds())); s > min {
+		return s
+	}
+
+	return min
+}
+
+// remoteIP returns IP address of the client without the port
+//
+// For requests made by trusted proxies, realIP middleware already replaced
+// remote address with the one from X-Forwarded-For or X-Real-IP header
+func remoteIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+
+	return r.RemoteAddr
+}
+
+// tokens are not kept in the (shared) backend
+func hashToken(jwt string) string {
+	sum := sha256.Sum256([]byte(jwt))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/options"
+	"github.com/cortezaproject/corteza-server/pkg/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		opt = options.RateLimitOpt{
+			User:   "3/1m",
+			IP:     "2/1m",
+			Auth:   "1/1m",
+			Groups: "/compose/=1/1m",
+		}
+
+		ok = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+		do = func(h http.Handler, path, ip string, userID uint64) *httptest.ResponseRecorder {
+			var (
+				w = httptest.NewRecorder()
+				r = httptest.NewRequest(http.MethodGet, path, nil)
+			)
+
+			r.RemoteAddr = ip + ":12345"
+			if userID > 0 {
+				r = r.WithContext(auth.SetIdentityToContext(r.Context(), auth.NewIdentity(userID)))
+			}
+
+			h.ServeHTTP(w, r)
+			return w
+		}
+	)
+
+	mw, err := RateLimit(zap.NewNop(), opt, "/api", ratelimit.Memory())
+	req.NoError(err)
+	h := mw(ok)
+
+	// unauthenticated requests are limited per IP
+	w := do(h, "/api/system/users/", "10.0.0.1", 0)
+	req.Equal(http.StatusOK, w.Code)
+	req.Equal("2", w.Header().Get("X-RateLimit-Limit"))
+	req.Equal("1", w.Header().Get("X-RateLimit-Remaining"))
+
+	req.Equal(http.StatusOK, do(h, "/api/system/users/", "10.0.0.1", 0).Code)
+
+	w = do(h, "/api/system/users/", "10.0.0.1", 0)
+	req.Equal(http.StatusTooManyRequests, w.Code)
+	req.Equal("30", w.Header().Get("Retry-After"))
+	req.Equal(http.StatusOK, do(h, "/api/system/users/", "10.0.0.2", 0).Code)
+
+	// authenticated requests are limited per user
+	w = do(h, "/api/system/users/", "10.0.0.1", 42)
+	req.Equal(http.StatusOK, w.Code)
+	req.Equal("3", w.Header().Get("X-RateLimit-Limit"))
+	req.Equal("2", w.Header().Get("X-RateLimit-Remaining"))
+
+	// route group limit is stricter than the user limit
+	req.Equal(http.StatusOK, do(h, "/api/compose/namespace/", "10.0.0.1", 43).Code)
+	req.Equal(http.StatusTooManyRequests, do(h, "/api/compose/namespace/", "10.0.0.1", 43).Code)
+	req.Equal(http.StatusOK, do(h, "/api/system/users/", "10.0.0.1", 43).Code)
+
+	// auth endpoints are limited per IP
+	req.Equal(http.StatusOK, do(h, "/api/system/auth/internal/login", "10.0.0.3", 0).Code)
+	req.Equal(http.StatusTooManyRequests, do(h, "/api/system/auth/internal/login", "10.0.0.3", 44).Code)
+
+	// only API requests are limited
+	for i := 0; i < 5; i++ {
+		req.Equal(http.StatusOK, do(h, "/webapp/index.html", "10.0.0.1", 0).Code)
+	}
+
+	_, err = RateLimit(zap.NewNop(), options.RateLimitOpt{Groups: "/compose/"}, "/api", ratelimit.Memory())
+	req.Error(err)
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+var (
+	xForwardedFor = http.CanonicalHeaderKey("X-Forwarded-For")
+	xRealIP       = http.CanonicalHeaderKey("X-Real-IP")
+)
+
+// ParseTrustedProxies parses comma separated list of IP addresses and CIDR ranges
+func ParseTrustedProxies(s string) (nn []*net.IPNet, err error) {
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+
+		if !strings.Contains(p, "/") {
+			if ip := net.ParseIP(p); ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy address %q", p)
+			} else if ip.To4() != nil {
+				p += "/32"
+			} else {
+				p += "/128"
+			}
+		}
+
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy range %q", p)
+		}
+
+		nn = append(nn, n)
+	}
+
+	return
+}
+
+// realIP replaces remote address of requests made by trusted proxies
+// with the client address from X-Forwarded-For or X-Real-IP header
+//
+// Headers of the requests from all other addresses are ignored as they
+// can be set by the client. Addresses in X-Forwarded-For are checked from
+// the last one (added by the closest proxy) and the first untrusted is used.
+func realIP(trusted []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isTrustedProxy(trusted, remoteIP(r)) {
+				if ip := forwardedIP(trusted, r); ip != "" {
+					r.RemoteAddr = ip
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func forwardedIP(trusted []*net.IPNet, r *http.Request) string {
+	if xff := r.Header.Get(xForwardedFor); xff != "" {
+		ips := strings.Split(xff, ",")
+		for i := len(ips) - 1; i >= 0; i-- {
+			ip := strings.TrimSpace(ips[i])
+			if net.ParseIP(ip) == nil {
+				return ""
+			}
+
+			if i == 0 || !isTrustedProxy(trusted, ip) {
+				return ip
+			}
+		}
+	}
+
+	if ip := strings.TrimSpace(r.Header.Get(xRealIP)); net.ParseIP(ip) != nil {
+		return ip
+	}
+
+	return ""
+}
+
+func isTrustedProxy(trusted []*net.IPNet, addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	req := require.New(t)
+
+	nn, err := ParseTrustedProxies(" 10.0.0.0/8, 127.0.0.1,::1 ,")
+	req.NoError(err)
+	req.Len(nn, 3)
+	req.Equal("127.0.0.1/32", nn[1].String())
+	req.Equal("::1/128", nn[2].String())
+
+	_, err = ParseTrustedProxies("10.0.0.0/8,foo")
+	req.Error(err)
+
+	_, err = ParseTrustedProxies("10.0.0.0/33")
+	req.Error(err)
+}
+
+func TestRealIP(t *testing.T) {
+	trusted, err := ParseTrustedProxies("10.0.0.0/8")
+	require.NoError(t, err)
+
+	var (
+		h = realIP(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.RemoteAddr))
+		}))
+
+		do = func(remoteAddr string, hh map[string]string) string {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = remoteAddr
+			for k, v := range hh {
+				r.Header.Set(k, v)
+			}
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			return w.Body.String()
+		}
+	)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		expected   string
+	}{
+		{
+			name:       "no headers",
+			remoteAddr: "10.0.0.1:1234",
+			expected:   "10.0.0.1:1234",
+		},
+		{
+			name:       "real ip from trusted proxy",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Real-IP": "203.0.113.1"},
+			expected:   "203.0.113.1",
+		},
+		{
+			name:       "forwarded for from trusted proxy",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "192.0.2.1, 203.0.113.1, 10.0.0.2"},
+			expected:   "203.0.113.1",
+		},
+		{
+			name:       "forwarded for with trusted proxies only",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "10.0.0.3, 10.0.0.2"},
+			expected:   "10.0.0.3",
+		},
+		{
+			name:       "invalid forwarded for",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "foo"},
+			expected:   "10.0.0.1:1234",
+		},
+		{
+			name:       "headers from untrusted address",
+			remoteAddr: "203.0.113.1:1234",
+			headers:    map[string]string{"X-Real-IP": "10.0.0.5", "X-Forwarded-For": "10.0.0.5"},
+			expected:   "203.0.113.1:1234",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, do(tt.remoteAddr, tt.headers))
+		})
+	}
+}
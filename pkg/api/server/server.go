@@ -21,6 +21,7 @@ type (
 		waitForOpt     options.WaitForOpt
 		environmentOpt options.EnvironmentOpt
 		endpoints      []func(r chi.Router)
+		middlewares    []func(http.Handler) http.Handler
 	}
 )
 
@@ -39,6 +40,11 @@ func (s *server) MountRoutes(mm ...func(chi.Router)) {
 	s.endpoints = append(s.endpoints, mm...)
 }
 
+// Use adds middlewares that are used after the request is authenticated
+func (s *server) Use(mm ...func(http.Handler) http.Handler) {
+	s.middlewares = append(s.middlewares, mm...)
+}
+
 func (s server) Serve(ctx context.Context) {
 	s.log.Info(
 		"starting HTTP server",
@@ -51,10 +57,16 @@ func (s server) Serve(ctx context.Context) {
 		return
 	}
 
+	trustedProxies, err := ParseTrustedProxies(s.httpOpt.TrustedProxies)
+	if err != nil {
+		s.log.Error("can not start server", zap.Error(err))
+		return
+	}
+
 	router := chi.NewRouter()
 
 	// Base middleware, CORS, RealIP, RequestID, context-logger
-	router.Use(BaseMiddleware(s.environmentOpt.IsProduction(), s.log)...)
+	router.Use(BaseMiddleware(s.environmentOpt.IsProduction(), s.log, trustedProxies)...)
 
 	router.Group(func(r chi.Router) {
 		s.bindMiscRoutes(r)
@@ -76,6 +88,8 @@ func (s server) Serve(ctx context.Context) {
 			auth.DefaultJwtHandler.HttpAuthenticator(),
 		)
 
+		r.Use(s.middlewares...)
+
 		for _, mountRoutes := range s.endpoints {
 			mountRoutes(r)
 		}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"net/http"
+	"time"
 )
 
 type (
@@ -12,6 +13,13 @@ type (
 		String() string
 	}
 
+	// RolesExpirer is implemented by identities with time-bound roles
+	//
+	// Tokens issued for such identities expire when roles change
+	RolesExpirer interface {
+		RolesValidUntil() *time.Time
+	}
+
 	TokenEncoder interface {
 		Encode(identity Identifiable) string
 	}
@@ -85,9 +85,18 @@ func (t *token) Decode(ts string) (Identifiable, error) {
 }
 
 func (t *token) Encode(identity Identifiable) string {
+	exp := time.Now().Add(time.Duration(t.expiry) * time.Minute)
+
+	// token must not outlive time-bound role memberships
+	if re, ok := identity.(RolesExpirer); ok {
+		if until := re.RolesValidUntil(); until != nil && until.Before(exp) {
+			exp = *until
+		}
+	}
+
 	claims := jwt.MapClaims{
 		"userID": strconv.FormatUint(identity.Identity(), 10),
-		"exp":    time.Now().Add(time.Duration(t.expiry) * time.Minute).Unix(),
+		"exp":    exp.Unix(),
 	}
 
 	if rr := identity.Roles(); len(rr) > 0 {
@@ -10,6 +10,7 @@ package {{ .Package }}
 
 import (
 	"encoding/json"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 {{- range .Imports }}
   {{ normalizeImport . }}
 {{- end }}
@@ -179,5 +180,44 @@ func (res *{{ camelCase .ResourceIdent "base" }}) Decode(results map[string][]by
 	return
 }
 
+// restore decodes encoded arguments into struct props
+func (res *{{ camelCase .ResourceIdent "base" }}) restore(args map[string][]byte) (err error) {
+	{{- range $prop := $r.Properties }}
+	{{- if not $prop.Internal }}
+	if r, ok := args["{{ $prop.Name }}"]; ok {
+		if err = json.Unmarshal(r, &res.{{ $prop.Name }}); err != nil {
+			return
+		}
+	}
+	{{ end -}}
+	{{ end }}
+	return
+}
 
 {{ end }}
+
+// Restore creates event from the encoded arguments
+//
+// Restored events are immutable. Returns nil for unknown resource or event types.
+//
+// This function is auto-generated.
+func Restore(resourceType, eventType string, args map[string][]byte) (eventbus.Event, error) {
+	switch resourceType {
+{{- range $r := $.Resources }}
+	case "{{ $r.ResourceString }}":
+		res := &{{ camelCase $r.ResourceIdent "base" }}{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+	{{- range $event := $r.Events }}
+		case "{{ $event }}":
+			return &{{ camelCase $r.ResourceIdent $event }}{ {{ camelCase $r.ResourceIdent "base" }}: res}, nil
+	{{- end }}
+		}
+{{- end }}
+	}
+
+	return nil, nil
+}
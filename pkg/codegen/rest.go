@@ -218,6 +218,8 @@ func (d *restEndpointParamDef) Parser(arg string) string {
 		return fmt.Sprintf("payload.Parse%s(%s), nil", export(d.Type), arg)
 	case "string", "[]string":
 		return fmt.Sprintf("%s, nil", arg)
+	case "*string":
+		return fmt.Sprintf("payload.ParseStringPtr(%s), nil", arg)
 	default:
 		return fmt.Sprintf("%s(%s), nil", d.Type, arg)
 	}
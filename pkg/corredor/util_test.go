@@ -46,6 +46,7 @@ func TestTriggerOptsMaking(t *testing.T) {
 			Constraints: []*TConstraint{
 				&TConstraint{Name: "some1", Op: "eq", Value: []string{"other"}},
 				&TConstraint{Name: "some2", Op: "eq", Value: []string{"other"}},
+				&TConstraint{Op: "expr", Value: []string{"record.values.amount > 10000"}},
 			},
 		}
 
@@ -55,7 +56,14 @@ func TestTriggerOptsMaking(t *testing.T) {
 
 	oo, err = triggerToHandlerOps(trg)
 	a.NoError(err)
-	a.Len(oo, 4) // 1x all resources, 1x all events, 2x constraints
+	a.Len(oo, 5) // 1x all resources, 1x all events, 3x constraints
+
+	_, err = triggerToHandlerOps(&Trigger{
+		ResourceTypes: []string{"r1"},
+		EventTypes:    []string{"afterUpdate"},
+		Constraints:   []*TConstraint{&TConstraint{Op: "expr", Value: []string{"record.values.amount >"}}},
+	})
+	a.Error(err, "expecting to fail on invalid expression")
 
 	oo, err = triggerToHandlerOps(&Trigger{ResourceTypes: []string{"bar"}})
 	a.Error(err, "expecting to fail on trigger w/o events")
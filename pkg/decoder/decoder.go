@@ -13,11 +13,31 @@ type (
 		ID     uint64               `json:"recordID,string"`
 		Values types.RecordValueSet `json:"values"`
 
+		// Version of the record
+		Clock ftypes.VectorClock `json:"clock,omitempty"`
+
+		// ID of the record on the receiving node, set when the
+		// record was received from that node in the first place
+		OriginRecordID uint64 `json:"originRecordID,string,omitempty"`
+
+		// Users referenced by the values, so they
+		// can be matched with the users on the receiving node
+		Users ExposedUserSet `json:"users,omitempty"`
+
 		CreatedAt time.Time  `json:"createdAt,omitempty"`
 		UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 		DeletedAt *time.Time `json:"deletedAt,omitempty"`
 	}
 
+	ExposedRecordSet []*ExposedRecord
+
+	ExposedUser struct {
+		ID    uint64 `json:"userID,string"`
+		Email string `json:"email"`
+	}
+
+	ExposedUserSet []*ExposedUser
+
 	// Bits for decoding structure sync
 	ModuleDocument struct {
 		Response ComposeModule
@@ -31,7 +51,7 @@ type (
 
 	ExposedRecordDocument struct {
 		Response struct {
-			Set []*ExposedRecord
+			Set ExposedRecordSet
 		}
 	}
 
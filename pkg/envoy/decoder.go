@@ -5,5 +5,12 @@ type (
 	DecoderOpts struct {
 		Name string
 		Path string
+
+		// Sheet name or position (1-based) to decode from spreadsheets;
+		// first sheet with any data when omitted
+		Sheet string
+
+		// HeaderRow position (1-based) in spreadsheets; detected when omitted
+		HeaderRow uint
 	}
 )
@@ -0,0 +1,170 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/cortezaproject/corteza-server/pkg/envoy"
+	"github.com/cortezaproject/corteza-server/pkg/envoy/resource"
+	"github.com/gabriel-vasile/mimetype"
+)
+
+type (
+	// wrapper struct for spreadsheet (xlsx, ods) related methods
+	decoder struct{}
+
+	// sheet holds decoded cell values of a single worksheet
+	//
+	// Values are already converted to their textual representation;
+	// numbers without formatting, dates in RFC3339
+	sheet struct {
+		name string
+		rows [][]string
+	}
+)
+
+const (
+	mimeXlsx = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	mimeOds  = "application/vnd.oasis.opendocument.spreadsheet"
+)
+
+// Decoder initializes and returns a fresh spreadsheet decoder
+//
+// Supports Office Open XML (xlsx) and OpenDocument (ods) spreadsheets
+func Decoder() *decoder {
+	return &decoder{}
+}
+
+// CanDecodeFile determines if the file can be determined by this decoder
+func (d *decoder) CanDecodeFile(f io.Reader) bool {
+	_, ext, err := mimetype.DetectReader(f)
+	if err != nil {
+		return false
+	}
+
+	return d.CanDecodeExt(ext)
+}
+
+func (d *decoder) CanDecodeMime(m string) bool {
+	return m == mimeXlsx || m == mimeOds
+}
+
+func (d *decoder) CanDecodeExt(ext string) bool {
+	pt := strings.Split(ext, ".")
+	switch strings.TrimSpace(pt[len(pt)-1]) {
+	case "xlsx", "ods":
+		return true
+	}
+
+	return false
+}
+
+// Decode decodes the selected sheet of the given spreadsheet into a generic resource dataset
+//
+// When sheet is not specified, first sheet with any data is used.
+// When header row is not specified, it is detected from the sheet content.
+func (d *decoder) Decode(ctx context.Context, r io.Reader, do *envoy.DecoderOpts) ([]resource.Interface, error) {
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open spreadsheet: %w", err)
+	}
+
+	var ss []*sheet
+	switch {
+	case zipFile(zr, "xl/workbook.xml") != nil:
+		ss, err = decodeXlsx(bytes.NewReader(buf))
+	case zipFile(zr, "content.xml") != nil:
+		ss, err = decodeOds(zr)
+	default:
+		return nil, fmt.Errorf("unsupported spreadsheet format")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := selectSheet(ss, do.Sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	sr := &reader{sheet: s.name}
+	for _, s := range ss {
+		sr.sheets = append(sr.sheets, s.name)
+	}
+
+	if err = sr.prepare(s.rows, do.HeaderRow); err != nil {
+		return nil, err
+	}
+
+	return []resource.Interface{resource.NewResourceDataset(do.Name, sr)}, nil
+}
+
+// selectSheet finds sheet by its name or position (1-based)
+func selectSheet(ss []*sheet, sel string) (*sheet, error) {
+	if len(ss) == 0 {
+		return nil, fmt.Errorf("spreadsheet has no sheets")
+	}
+
+	if sel = strings.TrimSpace(sel); sel == "" {
+		for _, s := range ss {
+			if len(s.rows) > 0 {
+				return s, nil
+			}
+		}
+
+		return ss[0], nil
+	}
+
+	for _, s := range ss {
+		if strings.EqualFold(s.name, sel) {
+			return s, nil
+		}
+	}
+
+	if i, err := strconv.Atoi(sel); err == nil && i > 0 && i <= len(ss) {
+		return ss[i-1], nil
+	}
+
+	return nil, fmt.Errorf("sheet %q not found", sel)
+}
+
+func zipFile(zr *zip.Reader, name string) *zip.File {
+	for _, f := range zr.File {
+		if f.Name == name {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// trimRow removes trailing empty cells
+func trimRow(row []string) []string {
+	for len(row) > 0 && row[len(row)-1] == "" {
+		row = row[:len(row)-1]
+	}
+
+	return row
+}
+
+// formatNumber returns number without any exponent or precision artifacts
+func formatNumber(v string) string {
+	f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil {
+		return v
+	}
+
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,197 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/360EntSecGroup-Skylar/excelize/v2"
+	"github.com/cortezaproject/corteza-server/pkg/envoy"
+	"github.com/cortezaproject/corteza-server/pkg/envoy/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeDocument(ctx context.Context, doc []byte, do *envoy.DecoderOpts) (*resource.ResourceDataset, error) {
+	ii, err := Decoder().Decode(ctx, bytes.NewReader(doc), do)
+	if err != nil {
+		return nil, err
+	}
+
+	rd, _ := ii[0].(*resource.ResourceDataset)
+	return rd, nil
+}
+
+func readAll(req *require.Assertions, ds *resource.ResourceDataset) []map[string]string {
+	var out []map[string]string
+	for {
+		n, err := ds.P.Next()
+		req.NoError(err)
+		if n == nil {
+			return out
+		}
+
+		out = append(out, n)
+	}
+}
+
+func makeXlsx(req *require.Assertions) []byte {
+	f := excelize.NewFile()
+	f.SetSheetName("Sheet1", "Summary")
+	f.NewSheet("Records")
+
+	req.NoError(f.SetCellValue("Summary", "A1", "nothing to see here"))
+
+	// title row, header detection should skip it
+	req.NoError(f.SetCellValue("Records", "A1", "Exported records"))
+	req.NoError(f.SetSheetRow("Records", "A2", &[]interface{}{"name", "amount", "created", "time", "active", ""}))
+	req.NoError(f.SetSheetRow("Records", "A3", &[]interface{}{"first", 1234.5, 43831, 0.5, true, "x"}))
+	// empty row in between
+	req.NoError(f.SetSheetRow("Records", "A5", &[]interface{}{"second", 0.1}))
+
+	date, err := f.NewStyle(`{"number_format": 14}`)
+	req.NoError(err)
+	clock, err := f.NewStyle(`{"custom_number_format": "[h]:mm"}`)
+	req.NoError(err)
+
+	req.NoError(f.SetCellStyle("Records", "C3", "C3", date))
+	req.NoError(f.SetCellStyle("Records", "D3", "D3", clock))
+
+	buf := &bytes.Buffer{}
+	req.NoError(f.Write(buf))
+	return buf.Bytes()
+}
+
+func makeOds(req *require.Assertions) []byte {
+	const content = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content
+  xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+  xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+  xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+  <office:body><office:spreadsheet>
+    <table:table table:name="Records">
+      <table:table-row>
+        <table:table-cell office:value-type="string"><text:p>name</text:p></table:table-cell>
+        <table:table-cell office:value-type="string"><text:p>amount</text:p></table:table-cell>
+        <table:table-cell office:value-type="string"><text:p>created</text:p></table:table-cell>
+        <table:table-cell office:value-type="string"><text:p>time</text:p></table:table-cell>
+        <table:table-cell table:number-columns-repeated="1020"/>
+      </table:table-row>
+      <table:table-row>
+        <table:table-cell office:value-type="string"><text:p>first<text:s text:c="2"/>value</text:p><text:p>line</text:p></table:table-cell>
+        <table:table-cell office:value-type="float" office:value="1234.5"><text:p>1,234.50</text:p></table:table-cell>
+        <table:table-cell office:value-type="date" office:date-value="2020-01-01"><text:p>01/01/20</text:p></table:table-cell>
+        <table:table-cell office:value-type="time" office:time-value="PT12H30M00S"><text:p>12:30 PM</text:p></table:table-cell>
+      </table:table-row>
+      <table:table-row table:number-rows-repeated="1048574">
+        <table:table-cell table:number-columns-repeated="1024"/>
+      </table:table-row>
+    </table:table>
+  </office:spreadsheet></office:body>
+</office:document-content>`
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	req.NoError(err)
+	_, err = w.Write([]byte(mimeOds))
+	req.NoError(err)
+
+	w, err = zw.Create("content.xml")
+	req.NoError(err)
+	_, err = w.Write([]byte(content))
+	req.NoError(err)
+
+	req.NoError(zw.Close())
+	return buf.Bytes()
+}
+
+func TestDecoder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("xlsx", func(t *testing.T) {
+		req := require.New(t)
+		doc := makeXlsx(req)
+
+		req.True(Decoder().CanDecodeFile(bytes.NewReader(doc)))
+
+		ds, err := decodeDocument(ctx, doc, &envoy.DecoderOpts{Name: "records", Sheet: "records"})
+		req.NoError(err)
+		req.Equal([]string{"name", "amount", "created", "time", "active", "F"}, ds.P.Fields())
+		req.Equal(uint64(2), ds.P.Count())
+
+		rr := readAll(req, ds)
+		req.Len(rr, 2)
+		req.Equal("first", rr[0]["name"])
+		req.Equal("1234.5", rr[0]["amount"])
+		req.Equal("2020-01-01T00:00:00Z", rr[0]["created"])
+		req.Equal("12:00:00", rr[0]["time"])
+		req.Equal("1", rr[0]["active"])
+		req.Equal("x", rr[0]["F"])
+		req.Equal("0.1", rr[1]["amount"])
+		req.Equal("", rr[1]["created"])
+	})
+
+	t.Run("xlsx sheet selection", func(t *testing.T) {
+		req := require.New(t)
+		doc := makeXlsx(req)
+
+		ds, err := decodeDocument(ctx, doc, &envoy.DecoderOpts{Name: "records"})
+		req.NoError(err)
+		req.Equal([]string{"nothing to see here"}, ds.P.Fields())
+
+		sr, _ := ds.P.(*reader)
+		req.NotNil(sr)
+		req.Equal([]string{"Summary", "Records"}, sr.Sheets())
+
+		ds, err = decodeDocument(ctx, doc, &envoy.DecoderOpts{Name: "records", Sheet: "2", HeaderRow: 3})
+		req.NoError(err)
+		req.Equal([]string{"first", "1234.5", "2020-01-01T00:00:00Z", "12:00:00", "1", "x"}, ds.P.Fields())
+		req.Equal(uint64(1), ds.P.Count())
+
+		_, err = decodeDocument(ctx, doc, &envoy.DecoderOpts{Name: "records", Sheet: "missing"})
+		req.Error(err)
+	})
+
+	t.Run("ods", func(t *testing.T) {
+		req := require.New(t)
+		doc := makeOds(req)
+
+		req.True(Decoder().CanDecodeFile(bytes.NewReader(doc)))
+
+		ds, err := decodeDocument(ctx, doc, &envoy.DecoderOpts{Name: "records"})
+		req.NoError(err)
+		req.Equal([]string{"name", "amount", "created", "time"}, ds.P.Fields())
+		req.Equal(uint64(1), ds.P.Count())
+
+		rr := readAll(req, ds)
+		req.Len(rr, 1)
+		req.Equal("first  value\nline", rr[0]["name"])
+		req.Equal("1234.5", rr[0]["amount"])
+		req.Equal("2020-01-01T00:00:00Z", rr[0]["created"])
+		req.Equal("12:30:00", rr[0]["time"])
+	})
+}
+
+func TestFormatSerialDate(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal("1900-01-01T00:00:00Z", formatSerialDate(1, false))
+	req.Equal("1900-03-01T00:00:00Z", formatSerialDate(61, false))
+	req.Equal("2020-01-01T18:00:00Z", formatSerialDate(43831.75, false))
+	req.Equal("1904-01-02T00:00:00Z", formatSerialDate(1, true))
+	req.Equal("06:00:00", formatSerialDate(0.25, false))
+}
+
+func TestIsDateFormat(t *testing.T) {
+	req := require.New(t)
+
+	req.True(isDateFormat("yyyy-mm-dd"))
+	req.True(isDateFormat("[$-409]d/m/yy h:mm AM/PM"))
+	req.True(isDateFormat("[h]:mm"))
+	req.False(isDateFormat("General"))
+	req.False(isDateFormat("#,##0.00 \"days\""))
+	req.False(isDateFormat("[Red]0.00"))
+	req.False(isDateFormat("0.00E+00"))
+}
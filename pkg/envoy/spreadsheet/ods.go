@@ -0,0 +1,227 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// ods table decoder state
+	//
+	// Empty rows & cells are repeated to the end of the sheet
+	// (number-rows-repeated=1048576) so they are kept pending
+	// and added only when followed by some content
+	odsTable struct {
+		sheet *sheet
+
+		row          []string
+		rowRepeat    int
+		pendingRows  int
+		pendingCells int
+
+		cell       *odsCell
+		cellRepeat int
+	}
+
+	odsCell struct {
+		attr map[string]string
+		text strings.Builder
+		para int
+		inP  bool
+	}
+)
+
+// decodeOds decodes all tables of the OpenDocument spreadsheet
+func decodeOds(zr *zip.Reader) ([]*sheet, error) {
+	f := zipFile(zr, "content.xml")
+	if f == nil {
+		return nil, fmt.Errorf("spreadsheet part content.xml not found")
+	}
+
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Close()
+
+	var (
+		ss []*sheet
+		t  *odsTable
+		d  = xml.NewDecoder(r)
+	)
+
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode spreadsheet part content.xml: %w", err)
+		}
+
+		switch el := tok.(type) {
+		case xml.StartElement:
+			switch el.Name.Local {
+			case "table":
+				t = &odsTable{sheet: &sheet{name: odsAttr(el, "name")}}
+				ss = append(ss, t.sheet)
+			case "table-row":
+				if t != nil {
+					t.row, t.rowRepeat, t.pendingCells = nil, odsRepeat(el, "number-rows-repeated"), 0
+				}
+			case "table-cell", "covered-table-cell":
+				if t != nil {
+					t.cell, t.cellRepeat = &odsCell{attr: make(map[string]string)}, odsRepeat(el, "number-columns-repeated")
+					for _, a := range el.Attr {
+						t.cell.attr[a.Name.Local] = a.Value
+					}
+				}
+			case "p":
+				if t != nil && t.cell != nil {
+					if t.cell.para > 0 {
+						t.cell.text.WriteByte('\n')
+					}
+					t.cell.para++
+					t.cell.inP = true
+				}
+			case "s":
+				if t != nil && t.cell != nil {
+					t.cell.text.WriteString(strings.Repeat(" ", odsRepeat(el, "c")))
+				}
+			case "tab":
+				if t != nil && t.cell != nil {
+					t.cell.text.WriteByte('\t')
+				}
+			case "line-break":
+				if t != nil && t.cell != nil {
+					t.cell.text.WriteByte('\n')
+				}
+			}
+
+		case xml.CharData:
+			if t != nil && t.cell != nil && t.cell.inP {
+				t.cell.text.Write(el)
+			}
+
+		case xml.EndElement:
+			if t == nil {
+				continue
+			}
+
+			switch el.Name.Local {
+			case "table":
+				t = nil
+			case "table-row":
+				t.endRow()
+			case "table-cell", "covered-table-cell":
+				t.endCell()
+			case "p":
+				if t.cell != nil {
+					t.cell.inP = false
+				}
+			}
+		}
+	}
+
+	return ss, nil
+}
+
+func (t *odsTable) endCell() {
+	if t.cell == nil {
+		return
+	}
+
+	v := t.cell.value()
+	t.cell = nil
+
+	if v == "" {
+		t.pendingCells += t.cellRepeat
+		return
+	}
+
+	for ; t.pendingCells > 0; t.pendingCells-- {
+		t.row = append(t.row, "")
+	}
+
+	for i := 0; i < t.cellRepeat; i++ {
+		t.row = append(t.row, v)
+	}
+}
+
+func (t *odsTable) endRow() {
+	if len(t.row) == 0 {
+		t.pendingRows += t.rowRepeat
+		return
+	}
+
+	for ; t.pendingRows > 0; t.pendingRows-- {
+		t.sheet.rows = append(t.sheet.rows, nil)
+	}
+
+	for i := 0; i < t.rowRepeat; i++ {
+		t.sheet.rows = append(t.sheet.rows, t.row)
+	}
+
+	t.row = nil
+}
+
+// value returns typed cell value
+func (c *odsCell) value() string {
+	switch c.attr["value-type"] {
+	case "float", "percentage", "currency":
+		return formatNumber(c.attr["value"])
+
+	case "date":
+		v := c.attr["date-value"]
+		if !strings.Contains(v, "T") {
+			v += "T00:00:00"
+		}
+
+		if t, err := time.Parse("2006-01-02T15:04:05", v); err == nil {
+			return t.Format(time.RFC3339)
+		}
+
+		return c.attr["date-value"]
+
+	case "time":
+		// ISO 8601 duration, PT12H30M00S
+		var (
+			h, m int
+			s    float64
+		)
+
+		if _, err := fmt.Sscanf(c.attr["time-value"], "PT%dH%dM%fS", &h, &m, &s); err == nil {
+			return fmt.Sprintf("%02d:%02d:%02d", h, m, int(s))
+		}
+
+		return c.attr["time-value"]
+
+	case "boolean":
+		return c.attr["boolean-value"]
+	}
+
+	return c.text.String()
+}
+
+func odsAttr(el xml.StartElement, name string) string {
+	for _, a := range el.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+func odsRepeat(el xml.StartElement, name string) int {
+	if n, err := strconv.Atoi(odsAttr(el, name)); err == nil && n > 0 {
+		return n
+	}
+
+	return 1
+}
@@ -0,0 +1,182 @@
+package spreadsheet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// spreadsheet dataset reader
+	reader struct {
+		sheets []string
+		sheet  string
+
+		header []string
+		rows   [][]string
+		pos    int
+	}
+)
+
+const (
+	// number of leading rows inspected when detecting the header
+	headerScanRows = 10
+)
+
+// prepare sets the header and data rows
+//
+// Header row is 1-based; 0 detects it from the content.
+// Empty rows are omitted from the dataset.
+func (sr *reader) prepare(rows [][]string, headerRow uint) error {
+	var h int
+
+	if headerRow > 0 {
+		h = int(headerRow) - 1
+		if h >= len(rows) {
+			return fmt.Errorf("header row %d out of range", headerRow)
+		}
+	} else if h = detectHeader(rows); h < 0 {
+		return fmt.Errorf("could not detect header row")
+	}
+
+	width := len(trimRow(rows[h]))
+	for _, row := range rows[h+1:] {
+		if row = trimRow(row); len(row) > 0 {
+			sr.rows = append(sr.rows, row)
+		}
+
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+
+	sr.header = makeHeader(rows[h], width)
+
+	return nil
+}
+
+// detectHeader returns index of the first row that looks like a header
+//
+// Leading rows (titles, notes) that are narrower than half of the widest
+// row are skipped. Header must not contain any numeric cells.
+func detectHeader(rows [][]string) int {
+	var (
+		max   int
+		width = func(row []string) (w int) {
+			for _, c := range row {
+				if strings.TrimSpace(c) != "" {
+					w++
+				}
+			}
+			return
+		}
+	)
+
+	for i := 0; i < len(rows) && i < headerScanRows; i++ {
+		if w := width(rows[i]); w > max {
+			max = w
+		}
+	}
+
+	if max == 0 {
+		return -1
+	}
+
+rows:
+	for i := 0; i < len(rows) && i < headerScanRows; i++ {
+		if width(rows[i])*2 < max {
+			continue
+		}
+
+		for _, c := range rows[i] {
+			if _, err := strconv.ParseFloat(strings.TrimSpace(c), 64); err == nil {
+				continue rows
+			}
+		}
+
+		return i
+	}
+
+	return -1
+}
+
+// makeHeader converts header row to a set of unique field names
+//
+// Header spans over all data columns; cells without a name
+// are named after their column (A, B, ..., AA, ...)
+func makeHeader(row []string, width int) []string {
+	var (
+		hh   = make([]string, 0, width)
+		seen = make(map[string]int)
+	)
+
+	for i := 0; i < width; i++ {
+		var c string
+		if i < len(row) {
+			c = strings.TrimSpace(row[i])
+		}
+
+		if c == "" {
+			c = columnName(i)
+		}
+
+		if seen[c]++; seen[c] > 1 {
+			c = fmt.Sprintf("%s_%d", c, seen[c])
+		}
+
+		hh = append(hh, c)
+	}
+
+	return hh
+}
+
+// columnName returns spreadsheet column name for the 0-based index
+func columnName(i int) (n string) {
+	for i++; i > 0; i = (i - 1) / 26 {
+		n = string(rune('A'+(i-1)%26)) + n
+	}
+
+	return
+}
+
+// Fields returns every available field in this dataset
+func (sr *reader) Fields() []string {
+	return sr.header
+}
+
+// Next returns the field: value mapping for the next row
+func (sr *reader) Next() (map[string]string, error) {
+	if sr.pos >= len(sr.rows) {
+		return nil, nil
+	}
+
+	var (
+		row = sr.rows[sr.pos]
+		mr  = make(map[string]string)
+	)
+
+	sr.pos++
+	for i, h := range sr.header {
+		if i < len(row) {
+			mr[h] = row[i]
+		} else {
+			mr[h] = ""
+		}
+	}
+
+	return mr, nil
+}
+
+func (sr *reader) Count() uint64 {
+	return uint64(len(sr.rows))
+}
+
+// Sheets returns names of all sheets in the spreadsheet
+func (sr *reader) Sheets() []string {
+	return sr.sheets
+}
+
+// Sheet returns name of the decoded sheet
+func (sr *reader) Sheet() string {
+	return sr.sheet
+}
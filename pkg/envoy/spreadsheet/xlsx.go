@@ -0,0 +1,191 @@
+package spreadsheet
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/360EntSecGroup-Skylar/excelize/v2"
+)
+
+// decodeXlsx decodes all worksheets of the Office Open XML workbook
+//
+// Cell values are read with the excelize row reader; number formats
+// are only used to tell dates apart from the other numbers
+func decodeXlsx(r io.Reader) ([]*sheet, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open spreadsheet: %w", err)
+	}
+
+	var (
+		date1904 = f.WorkBook != nil && f.WorkBook.WorkbookPr != nil && f.WorkBook.WorkbookPr.Date1904
+		dates    = xlsxDateStyles(f)
+		sheets   = f.GetSheetMap()
+		ss       = make([]*sheet, 0, len(sheets))
+	)
+
+	for i := 1; i <= len(sheets); i++ {
+		name := sheets[i]
+
+		rr, err := xlsxRows(f, name, dates, date1904)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode worksheet %q: %w", name, err)
+		}
+
+		ss = append(ss, &sheet{name: name, rows: rr})
+	}
+
+	return ss, nil
+}
+
+// xlsxDateStyles returns indexes of the cell styles that format a date or time
+//
+// Number formats of all styles are reset afterwards;
+// excelize returns raw (unformatted) cell values
+// for the cells without any number format
+func xlsxDateStyles(f *excelize.File) map[int]bool {
+	var (
+		dates  = make(map[int]bool)
+		custom = make(map[int]string)
+	)
+
+	if f.Styles == nil || f.Styles.CellXfs == nil {
+		return dates
+	}
+
+	if f.Styles.NumFmts != nil {
+		for _, nf := range f.Styles.NumFmts.NumFmt {
+			custom[nf.NumFmtID] = nf.FormatCode
+		}
+	}
+
+	for i := range f.Styles.CellXfs.Xf {
+		xf := &f.Styles.CellXfs.Xf[i]
+
+		if code, ok := custom[xf.NumFmtID]; ok {
+			dates[i] = isDateFormat(code)
+		} else {
+			dates[i] = isBuiltinDateFormat(xf.NumFmtID)
+		}
+
+		xf.NumFmtID = 0
+	}
+
+	return dates
+}
+
+// xlsxRows reads rows of the worksheet
+//
+// Row & cell positions are respected so that skipped cells stay empty
+func xlsxRows(f *excelize.File, name string, dates map[int]bool, date1904 bool) ([][]string, error) {
+	rows, err := f.Rows(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var rr [][]string
+	for r := 1; rows.Next(); r++ {
+		row, err := rows.Columns()
+		if err != nil {
+			return nil, err
+		}
+
+		for c, v := range row {
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+
+			axis, err := excelize.CoordinatesToCellName(c+1, r)
+			if err != nil {
+				return nil, err
+			}
+
+			if style, err := f.GetCellStyle(name, axis); err == nil && dates[style] {
+				row[c] = formatSerialDate(n, date1904)
+			}
+		}
+
+		rr = append(rr, trimRow(row))
+	}
+
+	return rr, rows.Error()
+}
+
+// formatSerialDate converts spreadsheet serial date into RFC3339 timestamp
+//
+// Serials without the day part are converted to time only
+func formatSerialDate(f float64, date1904 bool) string {
+	var (
+		base = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+		days = math.Floor(f)
+		ns   = math.Round((f-days)*86400) * float64(time.Second)
+	)
+
+	if date1904 {
+		base = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	} else if days < 61 {
+		// compensate for the nonexistent 29th Feb 1900
+		days++
+	}
+
+	if f < 1 && f >= 0 {
+		return base.Add(time.Duration(ns)).Format("15:04:05")
+	}
+
+	return base.AddDate(0, 0, int(days)).Add(time.Duration(ns)).Format(time.RFC3339)
+}
+
+// isBuiltinDateFormat checks if the builtin number format is a date or time format
+func isBuiltinDateFormat(id int) bool {
+	return (id >= 14 && id <= 22) || (id >= 27 && id <= 36) || (id >= 45 && id <= 47) || (id >= 50 && id <= 58)
+}
+
+// isDateFormat checks if the custom number format code formats a date or time
+//
+// Quoted literals, escaped characters and bracketed sections (colors, conditions)
+// are ignored; elapsed time sections ([h], [mm], [ss]) are considered a time
+func isDateFormat(code string) bool {
+	var (
+		quoted, bracket bool
+		section         strings.Builder
+	)
+
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		switch {
+		case quoted:
+			quoted = c != '"'
+		case bracket:
+			if c == ']' {
+				bracket = false
+				if s := strings.ToLower(section.String()); strings.Trim(s, "hms") == "" && s != "" {
+					return true
+				}
+			} else {
+				section.WriteByte(c)
+			}
+		case c == '"':
+			quoted = true
+		case c == '[':
+			bracket = true
+			section.Reset()
+		case c == '\\' || c == '_' || c == '*':
+			i++
+		case c == ';':
+			// only the first (positive numbers) section is relevant
+			return false
+		default:
+			switch c | 0x20 {
+			case 'y', 'm', 'd', 'h', 's':
+				return true
+			}
+		}
+	}
+
+	return false
+}
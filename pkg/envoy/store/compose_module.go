@@ -198,7 +198,7 @@ func (n *composeModuleState) Encode(ctx context.Context, s store.Storer, state *
 			return err
 		}
 
-		return nil
+		return store.UpgradeComposeRecordStorage(ctx, s, nil, res)
 	}
 
 	// Update existing module
@@ -215,6 +215,9 @@ func (n *composeModuleState) Encode(ctx context.Context, s store.Storer, state *
 		res.Fields = mergeComposeModuleFields(res.Fields, n.mod.Fields)
 	}
 
+	// records are not moved between storages on import
+	res.Storage = n.mod.Storage
+
 	err = store.UpdateComposeModule(ctx, s, res)
 	if err != nil {
 		return err
@@ -229,6 +232,11 @@ func (n *composeModuleState) Encode(ctx context.Context, s store.Storer, state *
 		return err
 	}
 
+	err = store.UpgradeComposeRecordStorage(ctx, s, n.mod, res)
+	if err != nil {
+		return err
+	}
+
 	n.res.Res = res
 	return nil
 }
@@ -276,6 +276,12 @@ func (n *composeRecordState) Encode(ctx context.Context, s store.Storer, state *
 			return dfr(rve)
 		}
 
+		if n.cfg.EncryptRecord != nil {
+			if rec, err = n.cfg.EncryptRecord(mod, rec); err != nil {
+				return dfr(err)
+			}
+		}
+
 		// Create a new record
 		if !exists {
 			err = store.CreateComposeRecord(ctx, s, mod, rec)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/envoy"
 	"github.com/cortezaproject/corteza-server/pkg/envoy/resource"
 	"github.com/cortezaproject/corteza-server/store"
@@ -36,6 +37,9 @@ type (
 		// If you return an error, the encoding will terminate.
 		// If you return nil (ignore the error), the encoding will continue.
 		DeferNok func(error) error
+		// EncryptRecord is called before the record is stored and returns the record
+		// with values of encrypted fields encrypted; values are stored as they are when not set
+		EncryptRecord func(*types.Module, *types.Record) (*types.Record, error)
 	}
 
 	// resourceState allows each conforming struct to be initialized and encoded
@@ -124,6 +124,7 @@ func mergeConfig(ec *EncoderConfig, rs *resource.EnvoyConfig) *EncoderConfig {
 	rr.Defer = ec.Defer
 	rr.DeferNok = ec.DeferNok
 	rr.DeferOk = ec.DeferOk
+	rr.EncryptRecord = ec.EncryptRecord
 	if rr.OnExisting == resource.Default {
 		rr.OnExisting = ec.OnExisting
 	}
@@ -3,6 +3,7 @@ package yaml
 import (
 	"testing"
 
+	"github.com/cortezaproject/corteza-server/pkg/rbac"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,4 +16,26 @@ func TestDocument_UnmarshalYAML(t *testing.T) {
 		req.NotNil(doc.rbac)
 		req.NotEmpty(doc.rbac)
 	})
+
+	t.Run("rules with conditions", func(t *testing.T) {
+		req := require.New(t)
+		doc, err := parseDocument("global_rbac_conditions")
+		req.NoError(err)
+		req.Len(doc.rbac, 2)
+
+		req.Nil(doc.rbac[0].res.Conditions)
+
+		r := doc.rbac[1].res
+		req.Equal(rbac.Operation("settings.manage"), r.Operation)
+		req.NotNil(r.Conditions)
+		req.Equal([]string{"10.0.0.0/8", "192.168.1.10"}, r.Conditions.IPRanges)
+		req.Equal("08:00", r.Conditions.TimeFrom)
+		req.Len(r.Conditions.Weekdays, 5)
+		req.Equal("Europe/Ljubljana", r.Conditions.Timezone)
+	})
+
+	t.Run("rules with invalid conditions", func(t *testing.T) {
+		_, err := parseDocument("global_rbac_conditions_invalid")
+		require.Error(t, err)
+	})
 }
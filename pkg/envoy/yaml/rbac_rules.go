@@ -63,6 +63,15 @@ func (rr rbacRuleSet) decodeRbac(a rbac.Access, rules *yaml.Node) (rbacRuleSet,
 				},
 				refRole: roleRef,
 			}
+
+			// Operation with conditions:
+			// - { operation: record.export, conditions: { ipRanges: [ 10.0.0.0/8 ] } }
+			if op.Kind == yaml.MappingNode {
+				if err := rule.decodeConditional(op); err != nil {
+					return err
+				}
+			}
+
 			rule.SetResource(res)
 			rr = append(rr, rule)
 			return nil
@@ -72,19 +81,47 @@ func (rr rbacRuleSet) decodeRbac(a rbac.Access, rules *yaml.Node) (rbacRuleSet,
 	err = eachMap(rules, func(role, ops *yaml.Node) error {
 		// If its a mapping node, keys represent resources
 		if ops.Kind == yaml.MappingNode {
-			err = eachMap(ops, func(res, ops *yaml.Node) error {
+			return eachMap(ops, func(res, ops *yaml.Node) error {
 				return parseOps(ops, role.Value, res.Value)
 			})
-		} else {
-			return parseOps(ops, role.Value, "")
 		}
 
-		return nil
+		return parseOps(ops, role.Value, "")
 	})
 
 	return rr, err
 }
 
+// decodeConditional decodes operation and its conditions
+func (r *rbacRule) decodeConditional(n *yaml.Node) error {
+	err := eachMap(n, func(k, v *yaml.Node) error {
+		switch k.Value {
+		case "operation", "op":
+			r.res.Operation = rbac.Operation(v.Value)
+
+		case "conditions":
+			r.res.Conditions = &rbac.Conditions{}
+			if err := v.Decode(r.res.Conditions); err != nil {
+				return err
+			}
+
+			return r.res.Conditions.Validate()
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nodeErr(n, "failed to decode rbac rule: %v", err)
+	}
+
+	if r.res.Operation == "" {
+		return nodeErr(n, "rbac rule operation not defined")
+	}
+
+	return nil
+}
+
 func (rr rbacRuleSet) bindResource(resI resource.Interface) rbacRuleSet {
 	ref := &resource.Ref{
 		ResourceType: resI.ResourceType(),
@@ -61,6 +61,8 @@ func ConstraintMaker(name, op string, vv ...string) (ConstraintMatcher, error) {
 		return MustMatch(name, vv...)
 	case "!~":
 		return MustNotMatch(name, vv...)
+	case "expr":
+		return MustEval(name, vv...)
 	default:
 		return nil, ErrUnsupportedOp
 	}
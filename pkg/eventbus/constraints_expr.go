@@ -0,0 +1,128 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/PaesslerAG/gval"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/expr"
+)
+
+type (
+	// mustEval constraint is matched by evaluating expressions
+	// against the event's arguments
+	//
+	// It matches when at least one of the expressions evaluates to true
+	mustEval struct {
+		name   string
+		values []string
+		evals  []gval.Evaluable
+	}
+
+	// EventConstraintMatcher is matched against the whole event
+	// instead of one of its values
+	EventConstraintMatcher interface {
+		ConstraintMatcher
+		MatchEvent(ev Event) bool
+	}
+
+	// ExprScoper is implemented by events that provide their own
+	// (or additional) variables for expression constraints
+	//
+	// Returned variables override encoded event arguments with the same name
+	ExprScoper interface {
+		ExprScope() map[string]interface{}
+	}
+
+	eventInvokerGettable interface {
+		Invoker() auth.Identifiable
+	}
+)
+
+func (c mustEval) Name() string     { return c.name }
+func (c mustEval) Values() []string { return c.values }
+
+// MustEval creates constraint from one or more expressions
+//
+// Expression has access to all event arguments (resource, old resource, ...)
+// and to invoker (invoker.userID, invoker.roles), for example:
+//   record.values.amount > 10000
+//   oldRecord.values.status == "Open" && record.values.status == "Closed"
+func MustEval(name string, vv ...string) (ConstraintMatcher, error) {
+	var (
+		m = &mustEval{
+			name:   name,
+			values: vv,
+			evals:  make([]gval.Evaluable, len(vv)),
+		}
+
+		parser = expr.Parser()
+		err    error
+	)
+
+	for i, v := range vv {
+		if m.evals[i], err = parser.NewEvaluable(v); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// Match evaluates expressions with the given value (available as "value")
+func (c mustEval) Match(value string) bool {
+	return c.eval(map[string]interface{}{"value": value})
+}
+
+// MatchEvent evaluates expressions with the event's arguments
+func (c mustEval) MatchEvent(ev Event) bool {
+	return c.eval(exprScope(ev))
+}
+
+func (c mustEval) eval(scope map[string]interface{}) bool {
+	for _, e := range c.evals {
+		// errors (missing variables, incompatible types) do not match
+		if ok, err := e.EvalBool(context.Background(), scope); err == nil && ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// exprScope decodes event arguments into variables for expression evaluation
+func exprScope(ev Event) map[string]interface{} {
+	var (
+		scope = make(map[string]interface{})
+	)
+
+	if enc, ok := ev.(eventEncoder); ok {
+		if args, err := enc.Encode(); err == nil {
+			for k, raw := range args {
+				var v interface{}
+				if json.Unmarshal(raw, &v) == nil {
+					scope[k] = v
+				}
+			}
+		}
+	}
+
+	if ig, ok := ev.(eventInvokerGettable); ok {
+		if i := ig.Invoker(); i != nil {
+			scope["invoker"] = map[string]interface{}{
+				"userID": i.Identity(),
+				"roles":  i.Roles(),
+			}
+		}
+	}
+
+	if s, ok := ev.(ExprScoper); ok {
+		for k, v := range s.ExprScope() {
+			scope[k] = v
+		}
+	}
+
+	return scope
+}
@@ -4,8 +4,25 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+)
+
+type (
+	mockScopedEvent struct {
+		mockEvent
+		scope map[string]interface{}
+	}
 )
 
+func (e mockScopedEvent) Invoker() auth.Identifiable {
+	return e.identity
+}
+
+func (e mockScopedEvent) ExprScope() map[string]interface{} {
+	return e.scope
+}
+
 func TestConstraintMaking(t *testing.T) {
 	var (
 		a   = assert.New(t)
@@ -96,3 +113,46 @@ func TestMustNotMatch(t *testing.T) {
 	a.False(c.Match("fooBAZ"))
 	a.True(c.Match("barBAZ"))
 }
+
+func TestMustEval(t *testing.T) {
+	var (
+		a = assert.New(t)
+
+		ev = &mockScopedEvent{
+			mockEvent: mockEvent{rType: "resource", eType: "afterUpdate", identity: auth.NewIdentity(42, 1, 2)},
+			scope: map[string]interface{}{
+				"resource":    map[string]interface{}{"amount": 12000, "status": "Closed"},
+				"oldResource": map[string]interface{}{"amount": 100, "status": "Open"},
+			},
+		}
+
+		cases = []struct {
+			expr  string
+			match bool
+		}{
+			{`resource.amount > 10000`, true},
+			{`resource.amount > 20000`, false},
+			{`oldResource.status == "Open" && resource.status == "Closed"`, true},
+			{`oldResource.status == "Closed"`, false},
+			{`invoker.userID == 42`, true},
+			{`missing.value > 0`, false},
+			{`resource.status`, false},
+		}
+	)
+
+	_, err := MustEval("", "resource.amount >")
+	a.Error(err)
+
+	for _, c := range cases {
+		m, err := ConstraintMaker("", "expr", c.expr)
+		a.NoError(err)
+		a.Implements((*EventConstraintMatcher)(nil), m)
+		a.Equal(c.match, m.(EventConstraintMatcher).MatchEvent(ev), c.expr)
+	}
+
+	// any of the expressions should match
+	a.True(MustMakeConstraint("", "expr", `resource.amount < 0`, `resource.amount > 0`).(EventConstraintMatcher).MatchEvent(ev))
+
+	// value is available when constraint is matched against a single value
+	a.True(MustMakeConstraint("", "expr", `value == "foo"`).Match("foo"))
+}
@@ -66,6 +66,9 @@ func (b *eventbus) WaitFor(ctx context.Context, ev Event) (err error) {
 	b.l.RLock()
 	defer b.l.RUnlock()
 
+	// invoker is needed when matching expression constraints
+	setInvoker(ctx, ev)
+
 	for _, t := range b.find(ev) {
 		err = func(ctx context.Context, t *handler) error {
 			b.wg.Add(1)
@@ -86,6 +89,8 @@ func (b *eventbus) WaitFor(ctx context.Context, ev Event) (err error) {
 func (b *eventbus) Dispatch(ctx context.Context, ev Event) {
 	b.l.RLock()
 	defer b.l.RUnlock()
+
+	setInvoker(ctx, ev)
 	for _, t := range b.find(ev) {
 		b.wg.Add(1)
 		go func(ctx context.Context, t *handler) {
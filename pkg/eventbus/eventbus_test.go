@@ -9,6 +9,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/atomic"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
 )
 
 func TestEventbusRegUnreg(t *testing.T) {
@@ -107,3 +109,34 @@ func TestEventFiring(t *testing.T) {
 	bus.wait()
 	a.Equal(int32(2), i.Load())
 }
+
+func TestEventFiringWithExprConstraint(t *testing.T) {
+	var (
+		a = assert.New(t)
+		i = &atomic.Int32{}
+
+		ev = func(amount int) Event {
+			return &mockScopedEvent{
+				mockEvent: mockEvent{rType: "resource", eType: "afterUpdate"},
+				scope:     map[string]interface{}{"resource": map[string]interface{}{"amount": amount}},
+			}
+		}
+
+		ctx = auth.SetIdentityToContext(context.Background(), auth.NewIdentity(42))
+		bus = New()
+	)
+
+	bus.Register(
+		func(ctx context.Context, ev Event) error { i.Inc(); return nil },
+		On("afterUpdate"),
+		For("resource"),
+		Constraint(MustMakeConstraint("", "expr", `resource.amount > 10000 && invoker.userID == 42`)),
+	)
+
+	a.NoError(bus.WaitFor(ctx, ev(100)))
+	a.Equal(int32(0), i.Load())
+	a.NoError(bus.WaitFor(ctx, ev(12000)))
+	a.Equal(int32(1), i.Load())
+	a.NoError(bus.WaitFor(context.Background(), ev(12000)))
+	a.Equal(int32(1), i.Load())
+}
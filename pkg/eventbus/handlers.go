@@ -47,6 +47,14 @@ func (t handler) Match(re Event) bool {
 	}
 
 	for _, c := range t.constraints {
+		if ec, ok := c.(EventConstraintMatcher); ok {
+			if !ec.MatchEvent(re) {
+				return false
+			}
+
+			continue
+		}
+
 		// Should match all constraints
 		if !re.Match(c) {
 			return false
@@ -59,11 +67,15 @@ func (t handler) Match(re Event) bool {
 func (t handler) Handle(ctx context.Context, ev Event) error {
 	defer sentry.Recover()
 
+	setInvoker(ctx, ev)
+	return t.handler(ctx, ev)
+}
+
+// setInvoker sets identity from the context as event's invoker
+func setInvoker(ctx context.Context, ev Event) {
 	if eis, ok := ev.(eventInvokerSettable); ok {
 		eis.SetInvoker(auth.GetIdentityFromContext(ctx))
 	}
-
-	return t.handler(ctx, ev)
 }
 
 func NewHandler(h HandlerFn, ops ...HandlerRegOp) *handler {
@@ -0,0 +1,186 @@
+package eventbus
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/pkg/id"
+)
+
+type (
+	// QueuedEvent is an encoded event, stored in the outbox
+	// and waiting to be delivered to the registered handlers
+	//
+	// Events that could not be delivered are moved to dead letters
+	QueuedEvent struct {
+		ID           uint64     `json:"eventID,string"`
+		ResourceType string     `json:"resourceType"`
+		EventType    string     `json:"eventType"`
+		Args         EventArgs  `json:"args"`
+		InvokerID    uint64     `json:"invokerID,string"`
+		Attempts     uint       `json:"attempts"`
+		LastError    string     `json:"lastError,omitempty"`
+		CreatedAt    time.Time  `json:"createdAt"`
+		ScheduledAt  time.Time  `json:"scheduledAt"`
+		FailedAt     *time.Time `json:"failedAt,omitempty"`
+	}
+
+	QueuedEventFilter struct {
+		ResourceType string `json:"resourceType"`
+		EventType    string `json:"eventType"`
+
+		// Only events that are scheduled for delivery before the given time
+		ScheduledBefore *time.Time `json:"scheduledBefore,omitempty"`
+
+		// Standard helpers for paging and sorting
+		filter.Sorting
+		filter.Paging
+	}
+
+	// EventArgs holds JSON encoded event arguments
+	EventArgs map[string]json.RawMessage
+
+	// RestoreFn creates event of the given type from the encoded arguments
+	//
+	// Returns nil for unknown resource or event type
+	RestoreFn func(resourceType, eventType string, args map[string][]byte) (Event, error)
+
+	eventEncoder interface {
+		Encode() (map[string][]byte, error)
+	}
+
+	// Deferred holds events that were not stored to the (disabled) outbox
+	//
+	// They are dispatched after the transaction is committed, see WaitFor
+	Deferred []Event
+
+	outboxCreator interface {
+		CreateEventbusOutboxEvent(ctx context.Context, rr ...*QueuedEvent) error
+	}
+)
+
+// Enqueue encodes and stores event to the outbox
+//
+// Use it inside the same transaction as the change the event is about;
+// event is delivered by the outbox worker after the transaction is committed.
+//
+// When outbox is disabled, event is deferred instead; dispatch deferred
+// events with WaitFor after the transaction is committed.
+func (d *Deferred) Enqueue(ctx context.Context, s outboxCreator, ev Event) error {
+	return d.EnqueueSealed(ctx, s, ev, ev)
+}
+
+// EnqueueSealed stores sealed event to the outbox or defers event when outbox is disabled
+//
+// Sealed event is a copy of the event with sensitive arguments protected (encrypted);
+// restore function used by the outbox worker is expected to unseal them.
+func (d *Deferred) EnqueueSealed(ctx context.Context, s outboxCreator, ev, sealed Event) error {
+	if !outboxEnabled() {
+		*d = append(*d, ev)
+		return nil
+	}
+
+	qe, err := NewQueuedEvent(sealed, auth.GetIdentityFromContext(ctx).Identity())
+	if err != nil {
+		return err
+	}
+
+	return s.CreateEventbusOutboxEvent(ctx, qe)
+}
+
+// WaitFor dispatches deferred events one by one and returns the first error
+func (d Deferred) WaitFor(ctx context.Context, b waiter) error {
+	for _, ev := range d {
+		if err := b.WaitFor(ctx, ev); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewQueuedEvent encodes event and returns it ready to be stored to the outbox
+func NewQueuedEvent(ev Event, invokerID uint64) (*QueuedEvent, error) {
+	enc, ok := ev.(eventEncoder)
+	if !ok {
+		return nil, fmt.Errorf("event %s on %s can not be encoded", ev.EventType(), ev.ResourceType())
+	}
+
+	raw, err := enc.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("could not encode event %s on %s: %w", ev.EventType(), ev.ResourceType(), err)
+	}
+
+	var (
+		now  = time.Now()
+		args = EventArgs{}
+	)
+
+	for k, v := range raw {
+		if k == "invoker" {
+			// invoker is set from the delivery context
+			continue
+		}
+
+		args[k] = v
+	}
+
+	return &QueuedEvent{
+		ID:           id.Next(),
+		ResourceType: ev.ResourceType(),
+		EventType:    ev.EventType(),
+		Args:         args,
+		InvokerID:    invokerID,
+		CreatedAt:    now,
+		ScheduledAt:  now,
+	}, nil
+}
+
+// Restore creates event from the encoded arguments using the first restore function
+// that recognizes event's resource and event type
+func (qe QueuedEvent) Restore(rr ...RestoreFn) (Event, error) {
+	var args = make(map[string][]byte, len(qe.Args))
+	for k, v := range qe.Args {
+		args[k] = v
+	}
+
+	for _, r := range rr {
+		if ev, err := r(qe.ResourceType, qe.EventType, args); err != nil || ev != nil {
+			return ev, err
+		}
+	}
+
+	return nil, fmt.Errorf("unknown event %s on %s", qe.EventType, qe.ResourceType)
+}
+
+func (aa *EventArgs) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*aa = EventArgs{}
+	case []uint8:
+		b := value.([]byte)
+		if err := json.Unmarshal(b, aa); err != nil {
+			return fmt.Errorf("can not scan '%v' into EventArgs: %w", string(b), err)
+		}
+	case string:
+		if err := json.Unmarshal([]byte(value.(string)), aa); err != nil {
+			return fmt.Errorf("can not scan '%v' into EventArgs: %w", value, err)
+		}
+	}
+
+	return nil
+}
+
+func (aa EventArgs) Value() (driver.Value, error) {
+	if aa == nil {
+		aa = EventArgs{}
+	}
+
+	return json.Marshal(aa)
+}
@@ -0,0 +1,270 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/options"
+	"github.com/cortezaproject/corteza-server/system/types"
+)
+
+type (
+	mockOutboxStore struct {
+		outbox      map[uint64]*QueuedEvent
+		deadLetters map[uint64]*QueuedEvent
+
+		// search ignores schedule, as if events were claimed after the search
+		stale bool
+	}
+
+	mockWaiter struct {
+		// number of calls that fail before the first success
+		fail  int
+		calls int
+
+		// identity from the context of the last call
+		identity auth.Identifiable
+	}
+
+	mockUserFinder map[uint64]*types.User
+)
+
+func (s *mockOutboxStore) SearchEventbusOutboxEvents(_ context.Context, f QueuedEventFilter) (set QueuedEventSet, _ QueuedEventFilter, _ error) {
+	for _, qe := range s.outbox {
+		if s.stale || f.ScheduledBefore == nil || !qe.ScheduledAt.After(*f.ScheduledBefore) {
+			c := *qe
+			set = append(set, &c)
+		}
+	}
+
+	return set, f, nil
+}
+
+func (s *mockOutboxStore) ClaimEventbusOutboxEvent(_ context.Context, qe *QueuedEvent) (bool, error) {
+	if ex, ok := s.outbox[qe.ID]; !ok || ex.ScheduledAt.After(time.Now()) {
+		return false, nil
+	}
+
+	s.outbox[qe.ID] = qe
+	return true, nil
+}
+
+func (s *mockOutboxStore) UpdateEventbusOutboxEvent(_ context.Context, rr ...*QueuedEvent) error {
+	for _, qe := range rr {
+		s.outbox[qe.ID] = qe
+	}
+	return nil
+}
+
+func (s *mockOutboxStore) DeleteEventbusOutboxEvent(_ context.Context, rr ...*QueuedEvent) error {
+	for _, qe := range rr {
+		delete(s.outbox, qe.ID)
+	}
+	return nil
+}
+
+func (s *mockOutboxStore) CreateEventbusDeadLetter(_ context.Context, rr ...*QueuedEvent) error {
+	for _, qe := range rr {
+		s.deadLetters[qe.ID] = qe
+	}
+	return nil
+}
+
+func (w *mockWaiter) WaitFor(ctx context.Context, _ Event) error {
+	w.identity = auth.GetIdentityFromContext(ctx)
+	if w.calls++; w.calls <= w.fail {
+		return fmt.Errorf("failed")
+	}
+
+	return nil
+}
+
+func (f mockUserFinder) FindByAny(_ context.Context, identifier interface{}) (*types.User, error) {
+	if u, ok := f[identifier.(uint64)]; ok {
+		return u, nil
+	}
+
+	return nil, fmt.Errorf("user not found")
+}
+
+func mockRestore(resourceType, eventType string, _ map[string][]byte) (Event, error) {
+	if resourceType != "resource" {
+		return nil, nil
+	}
+
+	return &mockEvent{rType: resourceType, eType: eventType}, nil
+}
+
+func TestOutbox_Deliver(t *testing.T) {
+	var (
+		ctx = context.Background()
+		opt = options.EventbusOpt{OutboxMaxAttempts: 3, OutboxBackoff: time.Minute}
+
+		// makes outbox with one event, scheduled for immediate delivery
+		setup = func(fail int, rType string) (*outbox, *mockOutboxStore, *mockWaiter) {
+			var (
+				s = &mockOutboxStore{
+					outbox: map[uint64]*QueuedEvent{
+						42: {ID: 42, ResourceType: rType, EventType: "afterUpdate", ScheduledAt: time.Now()},
+					},
+					deadLetters: map[uint64]*QueuedEvent{},
+				}
+
+				w = &mockWaiter{fail: fail}
+			)
+
+			return NewOutbox(zap.NewNop(), s, w, opt, mockRestore), s, w
+		}
+
+		// reschedules all events in the outbox for immediate delivery
+		retry = func(s *mockOutboxStore) {
+			for _, qe := range s.outbox {
+				qe.ScheduledAt = time.Now()
+			}
+		}
+	)
+
+	t.Run("delivered", func(t *testing.T) {
+		var (
+			req     = require.New(t)
+			o, s, w = setup(0, "resource")
+		)
+
+		req.NoError(o.Deliver(ctx))
+		req.Equal(1, w.calls)
+		req.Empty(s.outbox)
+		req.Empty(s.deadLetters)
+	})
+
+	t.Run("delivered on retry", func(t *testing.T) {
+		var (
+			req     = require.New(t)
+			o, s, w = setup(1, "resource")
+		)
+
+		req.NoError(o.Deliver(ctx))
+		req.Len(s.outbox, 1)
+		req.Equal(uint(1), s.outbox[42].Attempts)
+		req.Equal("failed", s.outbox[42].LastError)
+		req.True(s.outbox[42].ScheduledAt.After(time.Now()))
+
+		// not due yet
+		req.NoError(o.Deliver(ctx))
+		req.Equal(1, w.calls)
+
+		retry(s)
+		req.NoError(o.Deliver(ctx))
+		req.Equal(2, w.calls)
+		req.Empty(s.outbox)
+		req.Empty(s.deadLetters)
+	})
+
+	t.Run("moved to dead letters", func(t *testing.T) {
+		var (
+			req     = require.New(t)
+			o, s, w = setup(5, "resource")
+		)
+
+		for i := 0; i < opt.OutboxMaxAttempts; i++ {
+			retry(s)
+			req.NoError(o.Deliver(ctx))
+		}
+
+		req.Equal(opt.OutboxMaxAttempts, w.calls)
+		req.Empty(s.outbox)
+		req.Len(s.deadLetters, 1)
+		req.Equal(uint(opt.OutboxMaxAttempts), s.deadLetters[42].Attempts)
+		req.NotNil(s.deadLetters[42].FailedAt)
+	})
+
+	t.Run("invoker with roles", func(t *testing.T) {
+		var (
+			req     = require.New(t)
+			o, s, w = setup(0, "resource")
+			u       = &types.User{ID: 1}
+		)
+
+		u.SetRoles([]uint64{2, 3})
+		s.outbox[42].InvokerID = u.ID
+
+		req.NoError(o.Deliver(ctx))
+		req.Zero(w.calls, "not delivered without user finder")
+		req.Len(s.outbox, 1)
+
+		retry(s)
+		o.SetUserFinder(mockUserFinder{u.ID: u})
+		req.NoError(o.Deliver(ctx))
+		req.Equal(1, w.calls)
+		req.Equal(u.ID, w.identity.Identity())
+		req.Equal([]uint64{2, 3}, w.identity.Roles())
+		req.Empty(s.outbox)
+	})
+
+	t.Run("claimed by another worker", func(t *testing.T) {
+		var (
+			req     = require.New(t)
+			o, s, w = setup(0, "resource")
+		)
+
+		s.outbox[42].ScheduledAt = time.Now().Add(time.Minute)
+		s.stale = true
+
+		req.NoError(o.Deliver(ctx))
+		req.Zero(w.calls)
+		req.Len(s.outbox, 1)
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		var (
+			req     = require.New(t)
+			o, s, w = setup(0, "unknown")
+		)
+
+		req.NoError(o.Deliver(ctx))
+		req.Zero(w.calls)
+		req.Empty(s.outbox)
+		req.Len(s.deadLetters, 1)
+		req.Equal("unknown event afterUpdate on unknown", s.deadLetters[42].LastError)
+	})
+}
+
+func TestOutbox_backoff(t *testing.T) {
+	var (
+		req = require.New(t)
+		o   = &outbox{opt: options.EventbusOpt{OutboxBackoff: time.Second, OutboxMaxBackoff: time.Second * 5}}
+	)
+
+	req.Equal(time.Second, o.backoff(1))
+	req.Equal(time.Second*2, o.backoff(2))
+	req.Equal(time.Second*4, o.backoff(3))
+	req.Equal(time.Second*5, o.backoff(4))
+	req.Equal(time.Second*5, o.backoff(10))
+}
+
+func TestDeferred(t *testing.T) {
+	var (
+		req = require.New(t)
+		ctx = context.Background()
+		w   = &mockWaiter{}
+
+		d Deferred
+	)
+
+	// outbox is not configured; events are not stored
+	req.NoError(d.Enqueue(ctx, nil, &mockEvent{rType: "resource", eType: "afterCreate"}))
+	req.NoError(d.Enqueue(ctx, nil, &mockEvent{rType: "resource", eType: "afterUpdate"}))
+	req.Len(d, 2)
+
+	req.NoError(d.WaitFor(ctx, w))
+	req.Equal(2, w.calls)
+
+	w = &mockWaiter{fail: 1}
+	req.Error(d.WaitFor(ctx, w))
+	req.Equal(1, w.calls)
+}
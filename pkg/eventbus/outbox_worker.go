@@ -0,0 +1,236 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/options"
+	"github.com/cortezaproject/corteza-server/pkg/sentry"
+	"github.com/cortezaproject/corteza-server/system/types"
+)
+
+type (
+	// outbox delivers stored events to the event bus
+	//
+	// Delivery is at-least-once; when one of the handlers fails,
+	// all handlers are invoked again on the next attempt
+	outbox struct {
+		log       *zap.Logger
+		store     outboxStore
+		bus       waiter
+		opt       options.EventbusOpt
+		restorers []RestoreFn
+
+		// loads invoker (with roles) of the queued event
+		users userFinder
+	}
+
+	waiter interface {
+		WaitFor(ctx context.Context, ev Event) error
+	}
+
+	userFinder interface {
+		FindByAny(context.Context, interface{}) (*types.User, error)
+	}
+)
+
+const (
+	// max number of events delivered in one run
+	outboxBatchSize = 100
+
+	// claimed events are not delivered by other workers for this long;
+	// when worker stops in the middle of the delivery, event is delivered again
+	outboxLease = time.Minute * 10
+)
+
+var (
+	// Global outbox worker
+	gOutbox *outbox
+)
+
+// SetupOutbox configures global outbox worker
+func SetupOutbox(log *zap.Logger, s outboxStore, b waiter, opt options.EventbusOpt, rr ...RestoreFn) {
+	gOutbox = NewOutbox(log, s, b, opt, rr...)
+}
+
+// Outbox returns global outbox worker
+func Outbox() *outbox {
+	return gOutbox
+}
+
+// outboxEnabled returns true when events are delivered by the global outbox worker
+func outboxEnabled() bool {
+	return gOutbox != nil && gOutbox.opt.OutboxEnabled
+}
+
+func NewOutbox(log *zap.Logger, s outboxStore, b waiter, opt options.EventbusOpt, rr ...RestoreFn) *outbox {
+	if opt.OutboxInterval <= 0 {
+		opt.OutboxInterval = time.Second
+	}
+
+	if opt.OutboxMaxAttempts <= 0 {
+		opt.OutboxMaxAttempts = 1
+	}
+
+	return &outbox{
+		log:       log.Named("eventbus.outbox"),
+		store:     s,
+		bus:       b,
+		opt:       opt,
+		restorers: rr,
+	}
+}
+
+// SetUserFinder sets finder used to load invokers of the queued events
+func (o *outbox) SetUserFinder(uf userFinder) {
+	o.users = uf
+}
+
+// Start delivers due events on every interval until context is done
+func (o *outbox) Start(ctx context.Context) {
+	go func() {
+		defer sentry.Recover()
+
+		t := time.NewTicker(o.opt.OutboxInterval)
+		defer t.Stop()
+
+		o.log.Debug("started", zap.Duration("interval", o.opt.OutboxInterval))
+
+		for {
+			select {
+			case <-ctx.Done():
+				o.log.Debug("stopped")
+				return
+
+			case <-t.C:
+				if err := o.Deliver(ctx); err != nil {
+					o.log.Error("could not deliver events", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// Deliver dispatches all events that are due for delivery
+//
+// Each event is claimed before it is delivered so that multiple workers
+// (one per server instance) do not deliver the same event.
+// Events that fail are rescheduled or, after the last attempt, moved to dead letters.
+// Returned error is always a store error.
+func (o *outbox) Deliver(ctx context.Context) error {
+	for {
+		var (
+			now = time.Now()
+			f   = QueuedEventFilter{ScheduledBefore: &now}
+		)
+
+		f.Limit = outboxBatchSize
+
+		set, _, err := o.store.SearchEventbusOutboxEvents(ctx, f)
+		if err != nil {
+			return err
+		}
+
+		for _, qe := range set {
+			var claimed bool
+
+			qe.ScheduledAt = time.Now().Add(outboxLease)
+			if claimed, err = o.store.ClaimEventbusOutboxEvent(ctx, qe); err != nil {
+				return err
+			} else if !claimed {
+				// delivered by another worker
+				continue
+			}
+
+			if err = o.deliver(ctx, qe); err != nil {
+				return err
+			}
+		}
+
+		if len(set) < outboxBatchSize {
+			return nil
+		}
+	}
+}
+
+func (o *outbox) deliver(ctx context.Context, qe *QueuedEvent) error {
+	var (
+		log = o.log.With(
+			zap.Uint64("eventID", qe.ID),
+			zap.String("resourceType", qe.ResourceType),
+			zap.String("eventType", qe.EventType),
+		)
+
+		ev, err = qe.Restore(o.restorers...)
+	)
+
+	if err != nil {
+		// there is no point in retrying events we can not restore
+		qe.Attempts = uint(o.opt.OutboxMaxAttempts) - 1
+	} else {
+		var invCtx context.Context
+		if invCtx, err = o.invokerContext(ctx, qe.InvokerID); err == nil {
+			if err = o.bus.WaitFor(invCtx, ev); err == nil {
+				return o.store.DeleteEventbusOutboxEvent(ctx, qe)
+			}
+		}
+	}
+
+	qe.Attempts++
+	qe.LastError = err.Error()
+
+	if qe.Attempts < uint(o.opt.OutboxMaxAttempts) {
+		qe.ScheduledAt = time.Now().Add(o.backoff(qe.Attempts))
+		log.Warn("event delivery failed", zap.Error(err), zap.Time("retry", qe.ScheduledAt))
+		return o.store.UpdateEventbusOutboxEvent(ctx, qe)
+	}
+
+	log.Error("event delivery failed, moving to dead letters", zap.Error(err))
+
+	now := time.Now()
+	qe.FailedAt = &now
+
+	// dead letter is created first; in case of a failure in between
+	// we rather deliver event twice than lose it
+	if err = o.store.CreateEventbusDeadLetter(ctx, qe); err != nil {
+		return err
+	}
+
+	return o.store.DeleteEventbusOutboxEvent(ctx, qe)
+}
+
+// invokerContext sets invoker of the event with the current roles as identity
+func (o *outbox) invokerContext(ctx context.Context, invokerID uint64) (context.Context, error) {
+	if invokerID == 0 {
+		return ctx, nil
+	}
+
+	if o.users == nil {
+		return nil, fmt.Errorf("could not deliver event without configured user service")
+	}
+
+	invoker, err := o.users.FindByAny(auth.SetSuperUserContext(ctx), invokerID)
+	if err != nil {
+		return nil, fmt.Errorf("could not load invoker: %w", err)
+	}
+
+	return auth.SetIdentityToContext(ctx, invoker), nil
+}
+
+// backoff returns delay before the next attempt
+//
+// Delay doubles after each failed attempt and is capped with OutboxMaxBackoff
+func (o *outbox) backoff(attempts uint) time.Duration {
+	d := o.opt.OutboxBackoff
+	for i := uint(1); i < attempts; i++ {
+		if d *= 2; o.opt.OutboxMaxBackoff > 0 && d >= o.opt.OutboxMaxBackoff {
+			return o.opt.OutboxMaxBackoff
+		}
+	}
+
+	return d
+}
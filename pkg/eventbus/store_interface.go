@@ -0,0 +1,16 @@
+package eventbus
+
+import (
+	"context"
+)
+
+type (
+	// Store functions used by the outbox worker
+	outboxStore interface {
+		SearchEventbusOutboxEvents(ctx context.Context, f QueuedEventFilter) (QueuedEventSet, QueuedEventFilter, error)
+		ClaimEventbusOutboxEvent(ctx context.Context, qe *QueuedEvent) (bool, error)
+		UpdateEventbusOutboxEvent(ctx context.Context, rr ...*QueuedEvent) error
+		DeleteEventbusOutboxEvent(ctx context.Context, rr ...*QueuedEvent) error
+		CreateEventbusDeadLetter(ctx context.Context, rr ...*QueuedEvent) error
+	}
+)
@@ -0,0 +1,73 @@
+package eventbus
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+// pkg/eventbus/types.yaml
+
+type (
+
+	// QueuedEventSet slice of QueuedEvent
+	//
+	// This type is auto-generated.
+	QueuedEventSet []*QueuedEvent
+)
+
+// Walk iterates through every slice item and calls w(QueuedEvent) err
+//
+// This function is auto-generated.
+func (set QueuedEventSet) Walk(w func(*QueuedEvent) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(QueuedEvent) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set QueuedEventSet) Filter(f func(*QueuedEvent) (bool, error)) (out QueuedEventSet, err error) {
+	var ok bool
+	out = QueuedEventSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
+// FindByID finds items from slice by its ID property
+//
+// This function is auto-generated.
+func (set QueuedEventSet) FindByID(ID uint64) *QueuedEvent {
+	for i := range set {
+		if set[i].ID == ID {
+			return set[i]
+		}
+	}
+
+	return nil
+}
+
+// IDs returns a slice of uint64s from all items in the set
+//
+// This function is auto-generated.
+func (set QueuedEventSet) IDs() (IDs []uint64) {
+	IDs = make([]uint64, len(set))
+
+	for i := range set {
+		IDs[i] = set[i].ID
+	}
+
+	return
+}
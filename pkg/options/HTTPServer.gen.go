@@ -15,6 +15,7 @@ import (
 type (
 	HTTPServerOpt struct {
 		Addr                   string `env:"HTTP_ADDR"`
+		TrustedProxies         string `env:"HTTP_TRUSTED_PROXIES"`
 		LogRequest             bool   `env:"HTTP_LOG_REQUEST"`
 		LogResponse            bool   `env:"HTTP_LOG_RESPONSE"`
 		Tracing                bool   `env:"HTTP_ERROR_TRACING"`
@@ -39,6 +40,7 @@ type (
 func HTTPServer() (o *HTTPServerOpt) {
 	o = &HTTPServerOpt{
 		Addr:                   ":80",
+		TrustedProxies:         "127.0.0.0/8,::1/128,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16,fc00::/7",
 		LogRequest:             false,
 		LogResponse:            false,
 		Tracing:                false,
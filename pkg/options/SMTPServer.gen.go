@@ -0,0 +1,52 @@
+package options
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+// pkg/options/SMTPServer.yaml
+
+import (
+	"time"
+)
+
+type (
+	SMTPServerOpt struct {
+		Enabled        bool          `env:"SMTP_SERVER_ENABLED"`
+		Addr           string        `env:"SMTP_SERVER_ADDR"`
+		Hostname       string        `env:"SMTP_SERVER_HOSTNAME"`
+		AllowedDomains string        `env:"SMTP_SERVER_ALLOWED_DOMAINS"`
+		MaxMessageSize int           `env:"SMTP_SERVER_MAX_MESSAGE_SIZE"`
+		MaxRecipients  int           `env:"SMTP_SERVER_MAX_RECIPIENTS"`
+		Timeout        time.Duration `env:"SMTP_SERVER_TIMEOUT"`
+		TlsCertFile    string        `env:"SMTP_SERVER_TLS_CERT_FILE"`
+		TlsKeyFile     string        `env:"SMTP_SERVER_TLS_KEY_FILE"`
+	}
+)
+
+// SMTPServer initializes and returns a SMTPServerOpt with default values
+func SMTPServer() (o *SMTPServerOpt) {
+	o = &SMTPServerOpt{
+		Enabled:        false,
+		Addr:           ":2525",
+		Hostname:       "localhost",
+		MaxMessageSize: 10 << 20,
+		MaxRecipients:  50,
+		Timeout:        time.Minute,
+	}
+
+	fill(o)
+
+	// Function that allows access to custom logic inside the parent function.
+	// The custom logic in the other file should be like:
+	// func (o *SMTPServer) Defaults() {...}
+	func(o interface{}) {
+		if def, ok := o.(interface{ Defaults() }); ok {
+			def.Defaults()
+		}
+	}(o)
+
+	return
+}
@@ -14,8 +14,9 @@ import (
 
 type (
 	AuthOpt struct {
-		Secret string        `env:"AUTH_JWT_SECRET"`
-		Expiry time.Duration `env:"AUTH_JWT_EXPIRY"`
+		Secret                string        `env:"AUTH_JWT_SECRET"`
+		Expiry                time.Duration `env:"AUTH_JWT_EXPIRY"`
+		BreachedPasswordsFile string        `env:"AUTH_BREACHED_PASSWORDS_FILE"`
 	}
 )
 
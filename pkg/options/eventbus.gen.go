@@ -16,6 +16,11 @@ type (
 	EventbusOpt struct {
 		SchedulerEnabled  bool          `env:"EVENTBUS_SCHEDULER_ENABLED"`
 		SchedulerInterval time.Duration `env:"EVENTBUS_SCHEDULER_INTERVAL"`
+		OutboxEnabled     bool          `env:"EVENTBUS_OUTBOX_ENABLED"`
+		OutboxInterval    time.Duration `env:"EVENTBUS_OUTBOX_INTERVAL"`
+		OutboxMaxAttempts int           `env:"EVENTBUS_OUTBOX_MAX_ATTEMPTS"`
+		OutboxBackoff     time.Duration `env:"EVENTBUS_OUTBOX_BACKOFF"`
+		OutboxMaxBackoff  time.Duration `env:"EVENTBUS_OUTBOX_MAX_BACKOFF"`
 	}
 )
 
@@ -24,6 +29,11 @@ func Eventbus() (o *EventbusOpt) {
 	o = &EventbusOpt{
 		SchedulerEnabled:  true,
 		SchedulerInterval: time.Minute,
+		OutboxEnabled:     false,
+		OutboxInterval:    time.Second,
+		OutboxMaxAttempts: 5,
+		OutboxBackoff:     time.Second * 10,
+		OutboxMaxBackoff:  time.Hour,
 	}
 
 	fill(o)
@@ -21,6 +21,8 @@ type (
 		StructurePageSize        int           `env:"FEDERATION_SYNC_STRUCTURE_PAGE_SIZE"`
 		DataMonitorInterval      time.Duration `env:"FEDERATION_SYNC_DATA_MONITOR_INTERVAL"`
 		DataPageSize             int           `env:"FEDERATION_SYNC_DATA_PAGE_SIZE"`
+		DataPushEnabled          bool          `env:"FEDERATION_SYNC_DATA_PUSH_ENABLED"`
+		AttachmentMaxSize        int           `env:"FEDERATION_SYNC_ATTACHMENT_MAX_SIZE"`
 	}
 )
 
@@ -34,6 +36,8 @@ func Federation() (o *FederationOpt) {
 		StructurePageSize:        1,
 		DataMonitorInterval:      time.Second * 60,
 		DataPageSize:             100,
+		DataPushEnabled:          false,
+		AttachmentMaxSize:        100 << 20,
 	}
 
 	fill(o)
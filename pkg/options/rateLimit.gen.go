@@ -0,0 +1,45 @@
+package options
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+// pkg/options/rateLimit.yaml
+
+type (
+	RateLimitOpt struct {
+		Enabled bool   `env:"RATE_LIMIT_ENABLED"`
+		Backend string `env:"RATE_LIMIT_BACKEND"`
+		User    string `env:"RATE_LIMIT_USER"`
+		Token   string `env:"RATE_LIMIT_TOKEN"`
+		IP      string `env:"RATE_LIMIT_IP"`
+		Auth    string `env:"RATE_LIMIT_AUTH"`
+		Groups  string `env:"RATE_LIMIT_GROUPS"`
+	}
+)
+
+// RateLimit initializes and returns a RateLimitOpt with default values
+func RateLimit() (o *RateLimitOpt) {
+	o = &RateLimitOpt{
+		Enabled: false,
+		Backend: "memory",
+		User:    "1200/1m",
+		IP:      "600/1m",
+		Auth:    "10/1m",
+	}
+
+	fill(o)
+
+	// Function that allows access to custom logic inside the parent function.
+	// The custom logic in the other file should be like:
+	// func (o *RateLimit) Defaults() {...}
+	func(o interface{}) {
+		if def, ok := o.(interface{ Defaults() }); ok {
+			def.Defaults()
+		}
+	}(o)
+
+	return
+}
@@ -0,0 +1,35 @@
+package options
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+// pkg/options/secrets.yaml
+
+type (
+	SecretsOpt struct {
+		Key          string `env:"SECRETS_KEY"`
+		KeyFile      string `env:"SECRETS_KEY_FILE"`
+		PreviousKeys string `env:"SECRETS_PREVIOUS_KEYS"`
+	}
+)
+
+// Secrets initializes and returns a SecretsOpt with default values
+func Secrets() (o *SecretsOpt) {
+	o = &SecretsOpt{}
+
+	fill(o)
+
+	// Function that allows access to custom logic inside the parent function.
+	// The custom logic in the other file should be like:
+	// func (o *Secrets) Defaults() {...}
+	func(o interface{}) {
+		if def, ok := o.(interface{ Defaults() }); ok {
+			def.Defaults()
+		}
+	}(o)
+
+	return
+}
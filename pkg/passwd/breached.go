@@ -0,0 +1,97 @@
+package passwd
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+type (
+	// BreachedList holds SHA-1 hashes of known breached passwords
+	BreachedList struct {
+		hashes map[[sha1.Size]byte]struct{}
+	}
+)
+
+// LoadBreachedList reads list of breached passwords from file
+//
+// See ReadBreachedList for the format
+func LoadBreachedList(path string) (*BreachedList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open breached password list: %w", err)
+	}
+
+	defer f.Close()
+	return ReadBreachedList(f)
+}
+
+// ReadBreachedList reads list of breached passwords, one per line
+//
+// Line is either a plain password or SHA-1 hash of the password in hex,
+// optionally followed by colon and number of occurrences (as used by pwned passwords lists).
+// Empty lines and lines starting with # are ignored.
+func ReadBreachedList(r io.Reader) (*BreachedList, error) {
+	var (
+		l = &BreachedList{hashes: make(map[[sha1.Size]byte]struct{})}
+		s = bufio.NewScanner(r)
+	)
+
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if h, ok := parseHash(line); ok {
+			l.hashes[h] = struct{}{}
+		} else {
+			l.hashes[sha1.Sum([]byte(line))] = struct{}{}
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("could not read breached password list: %w", err)
+	}
+
+	return l, nil
+}
+
+// Contains returns true if password is on the list
+func (l *BreachedList) Contains(password string) bool {
+	if l == nil {
+		return false
+	}
+
+	_, has := l.hashes[sha1.Sum([]byte(password))]
+	return has
+}
+
+// Len returns number of passwords on the list
+func (l *BreachedList) Len() int {
+	if l == nil {
+		return 0
+	}
+
+	return len(l.hashes)
+}
+
+func parseHash(line string) (h [sha1.Size]byte, ok bool) {
+	if i := strings.IndexByte(line, ':'); i > -1 {
+		line = line[:i]
+	}
+
+	if len(line) != sha1.Size*2 {
+		return
+	}
+
+	if _, err := hex.Decode(h[:], []byte(line)); err != nil {
+		return
+	}
+
+	return h, true
+}
@@ -0,0 +1,32 @@
+package passwd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreachedList(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		// "password" as SHA-1 hash (with occurrences) and two plain passwords
+		list = `
+# common passwords
+5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493
+123456
+letmein
+`
+	)
+
+	l, err := ReadBreachedList(strings.NewReader(list))
+	req.NoError(err)
+	req.Equal(3, l.Len())
+	req.True(l.Contains("password"))
+	req.True(l.Contains("letmein"))
+	req.False(l.Contains("Sup3r-secret"))
+	req.False(l.Contains("# common passwords"))
+
+	req.False((*BreachedList)(nil).Contains("password"))
+}
@@ -0,0 +1,71 @@
+package passwd
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type (
+	// Policy defines requirements for (new) passwords
+	Policy struct {
+		MinLength uint
+
+		RequireUpper   bool
+		RequireLower   bool
+		RequireDigit   bool
+		RequireSpecial bool
+	}
+)
+
+// Check returns descriptions of all requirements the password does not meet
+func (p Policy) Check(password string) (unmet []string) {
+	var (
+		upper, lower, digit, special bool
+	)
+
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsSpace(r):
+			special = true
+		}
+	}
+
+	if uint(utf8.RuneCountInString(password)) < p.MinLength {
+		unmet = append(unmet, fmt.Sprintf("at least %d characters", p.MinLength))
+	}
+
+	if p.RequireUpper && !upper {
+		unmet = append(unmet, "an uppercase letter")
+	}
+
+	if p.RequireLower && !lower {
+		unmet = append(unmet, "a lowercase letter")
+	}
+
+	if p.RequireDigit && !digit {
+		unmet = append(unmet, "a digit")
+	}
+
+	if p.RequireSpecial && !special {
+		unmet = append(unmet, "a special character")
+	}
+
+	return
+}
+
+// Describe joins requirements into a readable list ("a, b and c")
+func Describe(rr []string) string {
+	if len(rr) < 2 {
+		return strings.Join(rr, "")
+	}
+
+	return strings.Join(rr[:len(rr)-1], ", ") + " and " + rr[len(rr)-1]
+}
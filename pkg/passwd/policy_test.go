@@ -0,0 +1,32 @@
+package passwd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_Check(t *testing.T) {
+	var (
+		req = require.New(t)
+		p   = Policy{MinLength: 8, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSpecial: true}
+	)
+
+	req.Empty(Policy{}.Check(""))
+	req.Empty(p.Check("Sup3r-secret"))
+	req.Equal([]string{"at least 8 characters", "a digit"}, p.Check("Short-"))
+	req.Equal([]string{"an uppercase letter", "a special character"}, p.Check("sup3rsecret"))
+
+	// length is counted in characters, not bytes
+	req.Empty(Policy{MinLength: 4}.Check("šđčž"))
+}
+
+func TestDescribe(t *testing.T) {
+	var (
+		req = require.New(t)
+	)
+
+	req.Equal("", Describe(nil))
+	req.Equal("a digit", Describe([]string{"a digit"}))
+	req.Equal("a, b and c", Describe([]string{"a", "b", "c"}))
+}
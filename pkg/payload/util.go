@@ -26,6 +26,11 @@ func Uint64stoa(uu []uint64) []string {
 	return ss
 }
 
+// ParseStringPtr returns pointer to a copy of the string
+func ParseStringPtr(s string) *string {
+	return &s
+}
+
 // ParseUint64 parses an string to uint64
 func ParseUint64(s string) uint64 {
 	if s == "" {
@@ -47,8 +52,11 @@ func ParseUint64s(ss []string) []uint64 {
 
 func ParseJSONTextWithErr(s string) (types.JSONText, error) {
 	result := &types.JSONText{}
-	err := fmt.Errorf("error parsing JSONText: %w", result.Scan(s))
-	return *result, err
+	if err := result.Scan(s); err != nil {
+		return nil, fmt.Errorf("error parsing JSONText: %w", err)
+	}
+
+	return *result, nil
 }
 
 func ParseISODateWithErr(s string) (time.Time, error) {
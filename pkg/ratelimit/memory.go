@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// memory backend keeps buckets in memory of the current process
+	memory struct {
+		l       sync.Mutex
+		buckets map[string]*memoryBucket
+		takes   int
+		now     func() time.Time
+	}
+
+	memoryBucket struct {
+		Bucket
+		limit Limit
+	}
+)
+
+const (
+	// full buckets are removed after every n-th take
+	cleanupInterval = 1000
+)
+
+// Memory returns backend that keeps buckets in memory
+//
+// Limits are not shared between multiple instances of the server
+func Memory() *memory {
+	return &memory{
+		buckets: make(map[string]*memoryBucket),
+		now:     time.Now,
+	}
+}
+
+func (m *memory) Take(_ context.Context, key string, l Limit) (Result, error) {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	var (
+		now   = m.now()
+		b, ok = m.buckets[key]
+	)
+
+	if !ok {
+		b = &memoryBucket{Bucket: Bucket{Key: key}}
+		m.buckets[key] = b
+	}
+
+	b.limit = l
+	r := b.take(l, now)
+
+	if m.takes++; m.takes >= cleanupInterval {
+		m.takes = 0
+		m.cleanup(now)
+	}
+
+	return r, nil
+}
+
+// removes all full buckets; there is no difference between full and missing bucket
+func (m *memory) cleanup(now time.Time) {
+	for key, b := range m.buckets {
+		if b.full(b.limit, now) {
+			delete(m.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// Limit allows given number of requests per period
+	//
+	// Limit is enforced with a token bucket; bucket holds
+	// (up to) Requests tokens and is refilled at the rate of Requests per Period.
+	Limit struct {
+		Requests int
+		Period   time.Duration
+	}
+
+	// Bucket holds state of one token bucket
+	Bucket struct {
+		Key       string    `json:"key"`
+		Tokens    float64   `json:"tokens"`
+		UpdatedAt time.Time `json:"updatedAt"`
+	}
+
+	BucketSet []*Bucket
+
+	BucketFilter struct {
+		// Only buckets that were not updated since
+		UpdatedBefore time.Time
+	}
+
+	// Result of a single take from the bucket
+	Result struct {
+		Allowed bool
+
+		// Bucket capacity
+		Limit int
+
+		// Number of requests left
+		Remaining int
+
+		// Time until bucket is full again
+		Reset time.Duration
+
+		// Time until next request is allowed; zero when request is allowed
+		RetryAfter time.Duration
+	}
+
+	// Backend keeps bucket state
+	Backend interface {
+		Take(ctx context.Context, key string, l Limit) (Result, error)
+	}
+)
+
+// ParseLimit parses limit definition in "<requests>/<period>" format (e.g. "600/1m")
+//
+// Empty string or zero requests results in no limit.
+func ParseLimit(s string) (l Limit, err error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return
+	}
+
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) != 2 {
+		return l, fmt.Errorf("invalid rate limit %q, expecting <requests>/<period>", s)
+	}
+
+	if l.Requests, err = strconv.Atoi(strings.TrimSpace(parts[0])); err != nil || l.Requests < 0 {
+		return l, fmt.Errorf("invalid number of requests in rate limit %q", s)
+	}
+
+	if l.Period, err = time.ParseDuration(strings.TrimSpace(parts[1])); err != nil || l.Period <= 0 {
+		return l, fmt.Errorf("invalid period in rate limit %q", s)
+	}
+
+	return l, nil
+}
+
+// IsZero returns true when limit does not restrict anything
+func (l Limit) IsZero() bool {
+	return l.Requests <= 0 || l.Period <= 0
+}
+
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Period)
+}
+
+// rate of refill in tokens per second
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// take refills the bucket and (when there is enough tokens) removes one token
+//
+// New bucket (zero UpdatedAt) is considered full
+func (b *Bucket) take(l Limit, now time.Time) Result {
+	var (
+		capacity = float64(l.Requests)
+		rate     = l.rate()
+	)
+
+	if b.UpdatedAt.IsZero() {
+		b.Tokens = capacity
+	} else if elapsed := now.Sub(b.UpdatedAt).Seconds(); elapsed > 0 {
+		b.Tokens = math.Min(capacity, b.Tokens+elapsed*rate)
+	}
+
+	b.UpdatedAt = now
+
+	r := Result{Limit: l.Requests}
+
+	if b.Tokens >= 1 {
+		b.Tokens--
+		r.Allowed = true
+	} else {
+		r.RetryAfter = seconds((1 - b.Tokens) / rate)
+	}
+
+	r.Remaining = int(b.Tokens)
+	r.Reset = seconds((capacity - b.Tokens) / rate)
+
+	return r
+}
+
+// full returns true if bucket is (or would be) refilled by now
+func (b Bucket) full(l Limit, now time.Time) bool {
+	return b.Tokens+now.Sub(b.UpdatedAt).Seconds()*l.rate() >= float64(l.Requests)
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
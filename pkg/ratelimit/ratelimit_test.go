@@ -0,0 +1,177 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+)
+
+type (
+	mockBucketStore struct {
+		buckets map[string]Bucket
+
+		// number of compare-and-swaps preceded by a concurrent take
+		concurrent int
+	}
+)
+
+func (s *mockBucketStore) LookupRatelimitBucketByKey(_ context.Context, key string) (*Bucket, error) {
+	if b, ok := s.buckets[key]; ok {
+		return &b, nil
+	}
+
+	return nil, errors.NotFound("not found")
+}
+
+func (s *mockBucketStore) CreateRatelimitBucket(_ context.Context, rr ...*Bucket) error {
+	for _, b := range rr {
+		if _, ok := s.buckets[b.Key]; ok {
+			return errors.DuplicateData("not unique")
+		}
+
+		s.buckets[b.Key] = *b
+	}
+
+	return nil
+}
+
+func (s *mockBucketStore) CompareAndSwapRatelimitBucket(_ context.Context, b, prev *Bucket) (bool, error) {
+	if s.concurrent > 0 {
+		s.concurrent--
+		c := s.buckets[b.Key]
+		c.Tokens--
+		s.buckets[b.Key] = c
+	}
+
+	if s.buckets[b.Key] != *prev {
+		return false, nil
+	}
+
+	s.buckets[b.Key] = *b
+	return true, nil
+}
+
+func (s *mockBucketStore) PurgeRatelimitBuckets(context.Context, BucketFilter) error {
+	return nil
+}
+
+func TestParseLimit(t *testing.T) {
+	var (
+		req = require.New(t)
+	)
+
+	l, err := ParseLimit("600/1m")
+	req.NoError(err)
+	req.Equal(Limit{Requests: 600, Period: time.Minute}, l)
+
+	l, err = ParseLimit("")
+	req.NoError(err)
+	req.True(l.IsZero())
+
+	l, err = ParseLimit("0/1s")
+	req.NoError(err)
+	req.True(l.IsZero())
+
+	_, err = ParseLimit("600")
+	req.Error(err)
+
+	_, err = ParseLimit("many/1m")
+	req.Error(err)
+
+	_, err = ParseLimit("600/minute")
+	req.Error(err)
+}
+
+func TestBucket_take(t *testing.T) {
+	var (
+		req = require.New(t)
+		now = time.Now()
+		l   = Limit{Requests: 2, Period: time.Second * 10}
+		b   = &Bucket{}
+	)
+
+	r := b.take(l, now)
+	req.True(r.Allowed)
+	req.Equal(1, r.Remaining)
+	req.Equal(2, r.Limit)
+
+	r = b.take(l, now)
+	req.True(r.Allowed)
+	req.Equal(0, r.Remaining)
+	req.Equal(time.Second*10, r.Reset)
+
+	r = b.take(l, now)
+	req.False(r.Allowed)
+	req.Equal(time.Second*5, r.RetryAfter)
+
+	// refilled by one token
+	r = b.take(l, now.Add(time.Second*5))
+	req.True(r.Allowed)
+	req.Equal(0, r.Remaining)
+
+	// never over the capacity
+	r = b.take(l, now.Add(time.Hour))
+	req.True(r.Allowed)
+	req.Equal(1, r.Remaining)
+}
+
+func TestMemory(t *testing.T) {
+	var (
+		req = require.New(t)
+		ctx = context.Background()
+		now = time.Now()
+		l   = Limit{Requests: 1, Period: time.Minute}
+		m   = Memory()
+	)
+
+	m.now = func() time.Time { return now }
+
+	r, err := m.Take(ctx, "a", l)
+	req.NoError(err)
+	req.True(r.Allowed)
+
+	r, _ = m.Take(ctx, "a", l)
+	req.False(r.Allowed)
+
+	r, _ = m.Take(ctx, "b", l)
+	req.True(r.Allowed, "buckets are kept per key")
+
+	now = now.Add(time.Minute)
+	m.cleanup(now)
+	req.Empty(m.buckets)
+
+	r, _ = m.Take(ctx, "a", l)
+	req.True(r.Allowed)
+}
+
+func TestStore(t *testing.T) {
+	var (
+		req = require.New(t)
+		ctx = context.Background()
+		now = time.Now()
+		l   = Limit{Requests: 2, Period: time.Minute}
+		ms  = &mockBucketStore{buckets: make(map[string]Bucket)}
+		s   = Store(ms)
+	)
+
+	s.now = func() time.Time { return now }
+
+	r, err := s.Take(ctx, "a", l)
+	req.NoError(err)
+	req.True(r.Allowed)
+	req.Equal(1, r.Remaining)
+
+	// bucket is taken from concurrently; take is retried
+	ms.concurrent = 1
+	r, err = s.Take(ctx, "a", l)
+	req.NoError(err)
+	req.False(r.Allowed, "concurrent take is counted")
+
+	ms.concurrent = storeTakeRetries
+	_, err = s.Take(ctx, "a", l)
+	req.Error(err)
+}
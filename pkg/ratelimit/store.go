@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+)
+
+type (
+	// store backend keeps buckets in the (shared) store
+	//
+	// Buckets are updated with compare-and-swap so that concurrent takes
+	// from multiple instances are all counted
+	storeBackend struct {
+		l         sync.Mutex
+		store     bucketStore
+		takes     int
+		maxPeriod time.Duration
+		now       func() time.Time
+	}
+)
+
+const (
+	// how many times take is retried when bucket was modified in the meantime
+	storeTakeRetries = 5
+)
+
+// Store returns backend that keeps buckets in the store
+// and shares limits between all instances of the server
+func Store(s bucketStore) *storeBackend {
+	return &storeBackend{
+		store: s,
+		now:   time.Now,
+	}
+}
+
+func (s *storeBackend) Take(ctx context.Context, key string, l Limit) (r Result, err error) {
+	var (
+		now   = s.now()
+		taken bool
+	)
+
+	for i := 0; i < storeTakeRetries && !taken; i++ {
+		if r, taken, err = s.tryTake(ctx, key, l, now); err != nil {
+			return
+		}
+	}
+
+	if !taken {
+		return r, fmt.Errorf("could not take from rate limit bucket %q", key)
+	}
+
+	return r, s.cleanup(ctx, l, now)
+}
+
+// tryTake takes from the bucket; false is returned when the bucket
+// was created or modified by a concurrent take
+func (s *storeBackend) tryTake(ctx context.Context, key string, l Limit, now time.Time) (Result, bool, error) {
+	b, err := s.store.LookupRatelimitBucketByKey(ctx, key)
+	if errors.IsNotFound(err) {
+		b = &Bucket{Key: key}
+		r := b.take(l, now)
+
+		if err = s.store.CreateRatelimitBucket(ctx, b); errors.IsDuplicateData(err) {
+			// created by a concurrent take
+			return r, false, nil
+		}
+
+		return r, err == nil, err
+	} else if err != nil {
+		return Result{}, false, err
+	}
+
+	var (
+		prev = *b
+		r    = b.take(l, now)
+	)
+
+	ok, err := s.store.CompareAndSwapRatelimitBucket(ctx, b, &prev)
+	return r, ok, err
+}
+
+// cleanup removes buckets that were not used for the longest period (they are full)
+// after every n-th take
+func (s *storeBackend) cleanup(ctx context.Context, l Limit, now time.Time) error {
+	s.l.Lock()
+	if l.Period > s.maxPeriod {
+		s.maxPeriod = l.Period
+	}
+
+	s.takes++
+	purge, maxPeriod := s.takes >= cleanupInterval, s.maxPeriod
+	if purge {
+		s.takes = 0
+	}
+	s.l.Unlock()
+
+	if !purge {
+		return nil
+	}
+
+	return s.store.PurgeRatelimitBuckets(ctx, BucketFilter{UpdatedBefore: now.Add(-maxPeriod)})
+}
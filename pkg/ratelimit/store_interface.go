@@ -0,0 +1,14 @@
+package ratelimit
+
+import (
+	"context"
+)
+
+type (
+	bucketStore interface {
+		LookupRatelimitBucketByKey(ctx context.Context, key string) (*Bucket, error)
+		CreateRatelimitBucket(ctx context.Context, rr ...*Bucket) error
+		CompareAndSwapRatelimitBucket(ctx context.Context, b, prev *Bucket) (bool, error)
+		PurgeRatelimitBuckets(ctx context.Context, f BucketFilter) error
+	}
+)
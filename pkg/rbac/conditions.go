@@ -0,0 +1,283 @@
+package rbac
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/api"
+)
+
+type (
+	// Conditions restrict rule to requests made in a certain context
+	//
+	// Allow rule with conditions is considered only when all of the conditions are met.
+	// Deny rule fails closed: it is considered unless one of the conditions is known
+	// not to be met; remote address is not known in background tasks and CLI.
+	Conditions struct {
+		// List of IP addresses or CIDR ranges (10.0.0.0/8) of the remote address
+		IPRanges []string `json:"ipRanges,omitempty" yaml:"ipRanges,omitempty"`
+
+		// Time window in 15:04 format; window that ends before it starts
+		// spans over midnight (22:00 - 06:00)
+		TimeFrom  string `json:"timeFrom,omitempty" yaml:"timeFrom,omitempty"`
+		TimeUntil string `json:"timeUntil,omitempty" yaml:"timeUntil,omitempty"`
+
+		// Days of the week (0 is Sunday); all days when empty
+		Weekdays []time.Weekday `json:"weekdays,omitempty" yaml:"weekdays,omitempty"`
+
+		// Timezone of the time window and weekdays; UTC when empty
+		Timezone string `json:"timezone,omitempty" yaml:"timezone,omitempty"`
+
+		// Session passed multi-factor authentication
+		//
+		// Not supported; there is no multi-factor authentication
+		// to verify it with, rules with this condition are rejected
+		MFA bool `json:"mfa,omitempty" yaml:"mfa,omitempty"`
+	}
+
+	// Env holds properties of the request that conditions are evaluated against
+	Env struct {
+		RemoteAddr string
+		Time       time.Time
+	}
+)
+
+const (
+	conditionTimeFormat = "15:04"
+)
+
+// EnvFromContext collects remote address of the request and current time
+//
+// Remote address is empty when context does not carry a request (background tasks, CLI)
+func EnvFromContext(ctx context.Context) *Env {
+	return &Env{
+		RemoteAddr: api.RemoteAddrFromContext(ctx),
+		Time:       time.Now(),
+	}
+}
+
+// IsEmpty returns true when there are no conditions set
+func (c *Conditions) IsEmpty() bool {
+	return c == nil || (len(c.IPRanges) == 0 &&
+		c.TimeFrom == "" &&
+		c.TimeUntil == "" &&
+		len(c.Weekdays) == 0 &&
+		!c.MFA)
+}
+
+// Validate checks format of IP ranges, time window and timezone
+func (c *Conditions) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if c.MFA {
+		return fmt.Errorf("multi-factor authentication condition is not supported")
+	}
+
+	for _, r := range c.IPRanges {
+		if _, err := parseIPRange(r); err != nil {
+			return err
+		}
+	}
+
+	if (c.TimeFrom == "") != (c.TimeUntil == "") {
+		return fmt.Errorf("time window needs both start and end")
+	}
+
+	for _, t := range []string{c.TimeFrom, c.TimeUntil} {
+		if _, err := parseConditionTime(t); err != nil {
+			return err
+		}
+	}
+
+	for _, d := range c.Weekdays {
+		if d < time.Sunday || d > time.Saturday {
+			return fmt.Errorf("invalid weekday %d", d)
+		}
+	}
+
+	if _, err := time.LoadLocation(c.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone %q", c.Timezone)
+	}
+
+	return nil
+}
+
+// Match checks if all of the conditions are met in the given environment
+//
+// Empty (or nil) conditions always match; non-empty never match nil environment.
+func (c *Conditions) Match(env *Env) bool {
+	if c.IsEmpty() {
+		return true
+	}
+
+	if env == nil || c.MFA {
+		return false
+	}
+
+	if len(c.IPRanges) > 0 && !c.matchIP(env.RemoteAddr) {
+		return false
+	}
+
+	return c.matchTime(env.Time)
+}
+
+// MatchDeny checks if conditions of the deny rule can be met in the given environment
+//
+// Unlike Match, it fails closed: conditions that can not be evaluated
+// (nil environment, unknown remote address) are considered met.
+func (c *Conditions) MatchDeny(env *Env) bool {
+	if c.IsEmpty() || env == nil {
+		return true
+	}
+
+	if len(c.IPRanges) > 0 && env.RemoteAddr != "" && !c.matchIP(env.RemoteAddr) {
+		return false
+	}
+
+	return c.matchTime(env.Time)
+}
+
+// Equals compares two sets of conditions
+func (c *Conditions) Equals(cmp *Conditions) bool {
+	if c.IsEmpty() || cmp.IsEmpty() {
+		return c.IsEmpty() == cmp.IsEmpty()
+	}
+
+	return reflect.DeepEqual(c, cmp)
+}
+
+func (c *Conditions) matchIP(addr string) bool {
+	if h, _, err := net.SplitHostPort(addr); err == nil {
+		addr = h
+	}
+
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, r := range c.IPRanges {
+		if n, err := parseIPRange(r); err == nil && n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Conditions) matchTime(t time.Time) bool {
+	if c.TimeFrom == "" && len(c.Weekdays) == 0 {
+		return true
+	}
+
+	if loc, err := time.LoadLocation(c.Timezone); err != nil {
+		return false
+	} else {
+		t = t.In(loc)
+	}
+
+	if len(c.Weekdays) > 0 {
+		var found bool
+		for _, d := range c.Weekdays {
+			found = found || d == t.Weekday()
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if c.TimeFrom == "" {
+		return true
+	}
+
+	var (
+		from, _  = parseConditionTime(c.TimeFrom)
+		until, _ = parseConditionTime(c.TimeUntil)
+		now      = t.Hour()*60 + t.Minute()
+	)
+
+	if from <= until {
+		return from <= now && now < until
+	}
+
+	// window spans over midnight
+	return now >= from || now < until
+}
+
+// parseIPRange parses CIDR range or single IP address
+func parseIPRange(r string) (*net.IPNet, error) {
+	if !strings.Contains(r, "/") {
+		ip := net.ParseIP(r)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP range %q", r)
+		}
+
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 8*net.IPv4len
+		}
+
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, n, err := net.ParseCIDR(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IP range %q", r)
+	}
+
+	return n, nil
+}
+
+// parseConditionTime returns minutes since midnight
+func parseConditionTime(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	t, err := time.Parse(conditionTimeFormat, s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expecting HH:MM", s)
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func (c *Conditions) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		return nil
+	case []uint8:
+		b := value.([]byte)
+		if len(b) == 0 {
+			return nil
+		}
+
+		if err := json.Unmarshal(b, c); err != nil {
+			return fmt.Errorf("can not scan '%v' into rbac.Conditions: %w", string(b), err)
+		}
+	case string:
+		if err := json.Unmarshal([]byte(value.(string)), c); err != nil {
+			return fmt.Errorf("can not scan '%v' into rbac.Conditions: %w", value, err)
+		}
+	}
+
+	return nil
+}
+
+func (c Conditions) Value() (driver.Value, error) {
+	if c.IsEmpty() {
+		return nil, nil
+	}
+
+	return json.Marshal(c)
+}
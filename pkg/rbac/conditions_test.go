@@ -0,0 +1,147 @@
+package rbac
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditions_Match(t *testing.T) {
+	var (
+		// Monday, 10:30 UTC
+		monday = time.Date(2021, 3, 1, 10, 30, 0, 0, time.UTC)
+		sunday = time.Date(2021, 3, 7, 10, 30, 0, 0, time.UTC)
+
+		tcc = []struct {
+			name  string
+			c     *Conditions
+			env   *Env
+			match bool
+		}{
+			{"nil conditions", nil, nil, true},
+			{"empty conditions", &Conditions{}, nil, true},
+			{"no environment", &Conditions{IPRanges: []string{"10.0.0.0/8"}}, nil, false},
+			{"mfa", &Conditions{MFA: true}, &Env{}, false},
+			{"ip in range", &Conditions{IPRanges: []string{"10.0.0.0/8"}}, &Env{RemoteAddr: "10.1.2.3"}, true},
+			{"ip with port in range", &Conditions{IPRanges: []string{"10.0.0.0/8"}}, &Env{RemoteAddr: "10.1.2.3:4321"}, true},
+			{"single ip", &Conditions{IPRanges: []string{"192.168.1.1", "10.0.0.0/8"}}, &Env{RemoteAddr: "192.168.1.1"}, true},
+			{"ip out of range", &Conditions{IPRanges: []string{"10.0.0.0/8"}}, &Env{RemoteAddr: "192.168.1.1"}, false},
+			{"unknown ip", &Conditions{IPRanges: []string{"10.0.0.0/8"}}, &Env{}, false},
+			{"in time window", &Conditions{TimeFrom: "08:00", TimeUntil: "17:00"}, &Env{Time: monday}, true},
+			{"out of time window", &Conditions{TimeFrom: "11:00", TimeUntil: "17:00"}, &Env{Time: monday}, false},
+			{"window over midnight", &Conditions{TimeFrom: "22:00", TimeUntil: "11:00"}, &Env{Time: monday}, true},
+			{"window in timezone", &Conditions{TimeFrom: "05:00", TimeUntil: "06:00", Timezone: "America/New_York"}, &Env{Time: monday}, true},
+			{"weekday", &Conditions{Weekdays: []time.Weekday{time.Monday}}, &Env{Time: monday}, true},
+			{"not a weekday", &Conditions{Weekdays: []time.Weekday{time.Monday}}, &Env{Time: sunday}, false},
+			{
+				"all conditions",
+				&Conditions{IPRanges: []string{"10.0.0.0/8"}, TimeFrom: "08:00", TimeUntil: "17:00", Weekdays: []time.Weekday{time.Monday}},
+				&Env{RemoteAddr: "10.0.0.1", Time: monday},
+				true,
+			},
+		}
+	)
+
+	for _, tc := range tcc {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.match, tc.c.Match(tc.env))
+		})
+	}
+}
+
+func TestConditions_MatchDeny(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		office = &Conditions{IPRanges: []string{"10.0.0.0/8"}}
+		hours  = &Conditions{TimeFrom: "11:00", TimeUntil: "17:00"}
+
+		// Monday, 10:30 UTC
+		monday = time.Date(2021, 3, 1, 10, 30, 0, 0, time.UTC)
+	)
+
+	req.True(office.MatchDeny(nil))
+	req.True(office.MatchDeny(&Env{}))
+	req.True(office.MatchDeny(&Env{RemoteAddr: "10.0.0.1"}))
+	req.False(office.MatchDeny(&Env{RemoteAddr: "192.168.1.1"}))
+	req.False(hours.MatchDeny(&Env{Time: monday}))
+	req.True((&Conditions{MFA: true}).MatchDeny(&Env{}))
+}
+
+func TestConditions_Validate(t *testing.T) {
+	var (
+		req = require.New(t)
+	)
+
+	req.NoError((*Conditions)(nil).Validate())
+	req.NoError((&Conditions{IPRanges: []string{"10.0.0.0/8", "::1"}, TimeFrom: "08:00", TimeUntil: "17:00"}).Validate())
+	req.Error((&Conditions{IPRanges: []string{"10.0.0.0/33"}}).Validate())
+	req.Error((&Conditions{TimeFrom: "08:00"}).Validate())
+	req.Error((&Conditions{TimeFrom: "8am", TimeUntil: "17:00"}).Validate())
+	req.Error((&Conditions{Weekdays: []time.Weekday{7}}).Validate())
+	req.Error((&Conditions{Timezone: "Mars/Olympus"}).Validate())
+	req.Error((&Conditions{MFA: true}).Validate())
+}
+
+func TestEnvFromContext(t *testing.T) {
+	var (
+		req = require.New(t)
+		env *Env
+
+		h = api.RemoteAddrToContext(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			env = EnvFromContext(r.Context())
+		}))
+	)
+
+	env = EnvFromContext(context.Background())
+	req.NotNil(env)
+	req.Empty(env.RemoteAddr)
+	req.False(env.Time.IsZero())
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	req.NotNil(env)
+	req.NotEmpty(env.RemoteAddr)
+}
+
+func TestRuleSet_CheckEnv(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		office = &Conditions{IPRanges: []string{"10.0.0.0/8"}}
+
+		rr = RuleSet{
+			AllowRule(role1, resThingWc, opRead).WithConditions(office),
+			DenyRule(role2, resThingWc, opRead).WithConditions(office),
+			AllowRule(role2, resThingWc, opRead),
+		}
+
+		inOffice  = &Env{RemoteAddr: "10.0.0.1"}
+		atHome    = &Env{RemoteAddr: "192.168.1.1"}
+		noContext = (*Env)(nil)
+	)
+
+	req.Equal(Allow, rr.CheckEnv(inOffice, resThing42, opRead, role1))
+	req.Equal(Inherit, rr.CheckEnv(atHome, resThing42, opRead, role1))
+	req.Equal(Inherit, rr.CheckEnv(noContext, resThing42, opRead, role1))
+	req.Equal(Inherit, rr.Check(resThing42, opRead, role1))
+
+	req.Equal(Deny, rr.CheckEnv(inOffice, resThing42, opRead, role2))
+	req.Equal(Allow, rr.CheckEnv(atHome, resThing42, opRead, role2))
+
+	// deny rules fail closed when conditions can not be evaluated
+	req.Equal(Deny, rr.CheckEnv(&Env{}, resThing42, opRead, role2))
+	req.Equal(Deny, rr.CheckEnv(noContext, resThing42, opRead, role2))
+	req.Equal(Deny, rr.Check(resThing42, opRead, role2))
+
+	// changed conditions must be flushed
+	rr.Clear()
+	rr = rr.Merge(AllowRule(role1, resThingWc, opRead))
+	_, dirty := rr.Dirty()
+	req.Len(dirty, 1)
+	req.Nil(dirty[0].Conditions)
+}
@@ -0,0 +1,300 @@
+package rbac
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cortezaproject/corteza-server/pkg/slice"
+)
+
+type (
+	// Explanation describes how Check resolved access for the given roles
+	//
+	// Steps follow the same order as Check: specific resource, wildcard resource,
+	// then both again for everyone role. Evaluation stops on the first step
+	// that resolves to allow or deny; when none does, access is resolved
+	// to the default of the operation.
+	Explanation struct {
+		Resource  Resource  `json:"resource"`
+		Operation Operation `json:"operation"`
+		Roles     []uint64  `json:"roles"`
+
+		Steps  []*ExplanationStep `json:"steps"`
+		Access Access             `json:"access"`
+		Reason string             `json:"reason"`
+
+		// Rules of dynamic roles (owners, creators...) that are not among the given roles
+		// but would be considered if user got the role for a specific resource
+		// and rules with conditions (IP range, time window...) that are considered
+		// only when request is made in a matching context
+		Conditional RuleSet `json:"conditional,omitempty"`
+	}
+
+	// ExplanationStep is one resource/roles combination checked
+	ExplanationStep struct {
+		Resource Resource `json:"resource"`
+		Wildcard bool     `json:"wildcard"`
+		Everyone bool     `json:"everyone"`
+		Roles    []uint64 `json:"roles"`
+
+		// Rules that matched resource, operation and one of the roles
+		Rules  RuleSet `json:"rules"`
+		Access Access  `json:"access"`
+	}
+
+	// AccessChange holds access of the user before and after rules are changed
+	AccessChange struct {
+		UserID uint64 `json:"userID,string"`
+		Before Access `json:"before"`
+		After  Access `json:"after"`
+	}
+
+	// WhatIfResult lists users with access changed by (hypothetical) rule changes
+	//
+	// Gained and Lost hold users that will (not) be explicitly allowed after the change,
+	// all other changes (deny <=> inherit) are under Changed.
+	WhatIfResult struct {
+		Resource  Resource  `json:"resource"`
+		Operation Operation `json:"operation"`
+
+		Gained  []*AccessChange `json:"gained"`
+		Lost    []*AccessChange `json:"lost"`
+		Changed []*AccessChange `json:"changed"`
+	}
+)
+
+var (
+	dynamicRoles = []uint64{
+		OwnersDynamicRoleID,
+		CreatorsDynamicRoleID,
+		UpdatersDynamicRoleID,
+		DeletersDynamicRoleID,
+		MembersDynamicRoleID,
+		AssigneesDynamicRoleID,
+	}
+)
+
+// IsDynamicRole returns true for roles that are assigned per resource
+func IsDynamicRole(roleID uint64) bool {
+	return slice.HasUint64(dynamicRoles, roleID)
+}
+
+// Explain walks the rules the same way as Check does and records every step
+//
+// Default access (see Default()) is used when no rule matches;
+// Inherit resolves to Deny, same as with Can()
+func (set RuleSet) Explain(res Resource, op Operation, def Access, roles ...uint64) (e *Explanation) {
+	e = &Explanation{
+		Resource:  res,
+		Operation: op,
+		Roles:     roles,
+		Steps:     []*ExplanationStep{},
+		Access:    Inherit,
+	}
+
+	if !res.IsValid() {
+		e.Access = Deny
+		e.Reason = "invalid resource"
+		return
+	}
+
+	if e.Roles == nil {
+		e.Roles = []uint64{}
+	}
+
+	e.Conditional = set.conditional(res, op, roles)
+
+	if len(roles) > 0 && e.explainResource(set, res, op, false, roles...) {
+		return
+	}
+
+	if e.explainResource(set, res, op, true, EveryoneRoleID) {
+		return
+	}
+
+	if e.Access = def; e.Access == Allow {
+		e.Reason = "no rule matched; allowed by default"
+	} else {
+		e.Access = Deny
+		e.Reason = "no rule matched; denied by default"
+	}
+
+	return
+}
+
+// explainResource mirrors checkResource, returns true when access is resolved
+func (e *Explanation) explainResource(set RuleSet, res Resource, op Operation, everyone bool, roles ...uint64) bool {
+	if e.explain(set, res, op, everyone, roles...) {
+		return true
+	}
+
+	if res.IsAppendable() {
+		return e.explain(set, res.AppendWildcard(), op, everyone, roles...)
+	}
+
+	return false
+}
+
+func (e *Explanation) explain(set RuleSet, res Resource, op Operation, everyone bool, roles ...uint64) bool {
+	s := &ExplanationStep{
+		Resource: res,
+		Wildcard: res.HasWildcard(),
+		Everyone: everyone,
+		Roles:    roles,
+		Rules:    set.matching(res, op, roles...),
+		Access:   set.check(nil, res, op, roles...),
+	}
+
+	e.Steps = append(e.Steps, s)
+
+	if s.Access == Inherit {
+		return false
+	}
+
+	e.Access = s.Access
+	e.Reason = s.reason()
+	return true
+}
+
+// reason explains access of the step
+func (s ExplanationStep) reason() string {
+	var (
+		decisive = s.Rules.ByAccess(s.Access)
+		roles    = make([]string, 0, len(decisive))
+		verb     = "allowed"
+		kind     = "specific"
+		who      = "role"
+	)
+
+	if s.Access == Deny {
+		// first deny wins
+		verb = "denied"
+		decisive = decisive[:1]
+	}
+
+	for _, r := range decisive {
+		roles = append(roles, fmt.Sprintf("%d", r.RoleID))
+	}
+
+	if s.Wildcard {
+		kind = "wildcard"
+	}
+
+	if s.Everyone {
+		who = "everyone role"
+	} else if len(roles) > 1 {
+		who = "roles"
+	}
+
+	return fmt.Sprintf(
+		"%s by %s %s on %s resource %s",
+		verb,
+		who,
+		strings.Join(roles, ", "),
+		kind,
+		s.Resource,
+	)
+}
+
+// matching returns rules without conditions that match resource, operation and one of the roles
+func (set RuleSet) matching(res Resource, op Operation, roles ...uint64) RuleSet {
+	out, _ := set.Filter(func(r *Rule) (bool, error) {
+		return r.Resource == res &&
+			r.Operation == op &&
+			r.Access != Inherit &&
+			r.Conditions.IsEmpty() &&
+			slice.HasUint64(roles, r.RoleID), nil
+	})
+
+	return out
+}
+
+// conditional returns rules of dynamic roles that are not among the given roles
+// and rules with conditions of the given roles
+func (set RuleSet) conditional(res Resource, op Operation, roles []uint64) RuleSet {
+	var (
+		rr = make([]uint64, 0, len(dynamicRoles))
+	)
+
+	for _, roleID := range dynamicRoles {
+		if !slice.HasUint64(roles, roleID) {
+			rr = append(rr, roleID)
+		}
+	}
+
+	out, _ := set.Filter(func(r *Rule) (bool, error) {
+		if r.Operation != op || r.Access == Inherit {
+			return false, nil
+		}
+
+		if r.Resource != res && !(res.IsAppendable() && r.Resource == res.AppendWildcard()) {
+			return false, nil
+		}
+
+		if slice.HasUint64(rr, r.RoleID) {
+			return true, nil
+		}
+
+		return !r.Conditions.IsEmpty() && (r.RoleID == EveryoneRoleID || slice.HasUint64(roles, r.RoleID)), nil
+	})
+
+	return out
+}
+
+// WhatIf compares access of users before and after changes are merged into the set
+//
+// Users are given as map of user ID and roles of the user; set is not modified.
+func (set RuleSet) WhatIf(changes RuleSet, res Resource, op Operation, users map[uint64][]uint64) *WhatIfResult {
+	var (
+		changed = set.clone().Merge(changes.clone()...)
+
+		out = &WhatIfResult{
+			Resource:  res,
+			Operation: op,
+			Gained:    []*AccessChange{},
+			Lost:      []*AccessChange{},
+			Changed:   []*AccessChange{},
+		}
+
+		userIDs = make([]uint64, 0, len(users))
+	)
+
+	for userID := range users {
+		userIDs = append(userIDs, userID)
+	}
+
+	sort.Slice(userIDs, func(i, j int) bool { return userIDs[i] < userIDs[j] })
+
+	for _, userID := range userIDs {
+		c := &AccessChange{
+			UserID: userID,
+			Before: set.Check(res, op, users[userID]...),
+			After:  changed.Check(res, op, users[userID]...),
+		}
+
+		switch {
+		case c.Before == c.After:
+			continue
+		case c.After == Allow:
+			out.Gained = append(out.Gained, c)
+		case c.Before == Allow:
+			out.Lost = append(out.Lost, c)
+		default:
+			out.Changed = append(out.Changed, c)
+		}
+	}
+
+	return out
+}
+
+// clone copies set and all the rules in it
+func (set RuleSet) clone() RuleSet {
+	out := make(RuleSet, len(set))
+	for i := range set {
+		c := *set[i]
+		out[i] = &c
+	}
+
+	return out
+}
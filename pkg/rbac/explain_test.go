@@ -0,0 +1,112 @@
+package rbac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleSet_Explain(t *testing.T) {
+	var (
+		rr = RuleSet{
+			AllowRule(role1, resThing42, opRead),
+			DenyRule(role1, resThing13, opWrite),
+			AllowRule(role2, resThing13, opWrite),
+			AllowRule(role2, resThingWc, opAccess),
+			DenyRule(EveryoneRoleID, resThingWc, opWrite),
+			AllowRule(OwnersDynamicRoleID, resThingWc, opRead),
+		}
+
+		sCases = []struct {
+			roles  []uint64
+			res    Resource
+			op     Operation
+			def    Access
+			access Access
+			steps  int
+			reason string
+		}{
+			{[]uint64{role1}, resThing42, opRead, Deny, Allow, 1, "allowed by role 10001 on specific resource some:answer:42"},
+			{[]uint64{role1}, resThing42, opWrite, Allow, Deny, 4, "denied by everyone role 1 on wildcard resource some:answer:*"},
+			{[]uint64{role1, role2}, resThing13, opWrite, Deny, Deny, 1, "denied by role 10001 on specific resource some:answer:13"},
+			{[]uint64{role2}, resThing42, opAccess, Deny, Allow, 2, "allowed by role 10002 on wildcard resource some:answer:*"},
+			{[]uint64{role2}, resThing13, opRead, Inherit, Deny, 4, "no rule matched; denied by default"},
+			{nil, resThing42, opRead, Allow, Allow, 2, "no rule matched; allowed by default"},
+			{[]uint64{role1}, Resource(""), opRead, Allow, Deny, 0, "invalid resource"},
+		}
+	)
+
+	for c, sc := range sCases {
+		t.Run(sc.reason, func(t *testing.T) {
+			var (
+				req = require.New(t)
+				e   = rr.Explain(sc.res, sc.op, sc.def, sc.roles...)
+			)
+
+			if check := rr.Check(sc.res, sc.op, sc.roles...); check != Inherit {
+				req.Equalf(check, e.Access, "explain test #%d does not match check", c)
+			}
+
+			req.Equal(sc.access, e.Access)
+			req.Len(e.Steps, sc.steps)
+			req.Equal(sc.reason, e.Reason)
+		})
+	}
+
+	t.Run("conditional rules of dynamic roles", func(t *testing.T) {
+		req := require.New(t)
+
+		e := rr.Explain(resThing13, opRead, Deny, role1)
+		req.Len(e.Conditional, 1)
+		req.Equal(OwnersDynamicRoleID, e.Conditional[0].RoleID)
+
+		e = rr.Explain(resThing13, opRead, Deny, role1, OwnersDynamicRoleID)
+		req.Len(e.Conditional, 0)
+		req.Equal(Allow, e.Access)
+	})
+}
+
+func TestDefault(t *testing.T) {
+	req := require.New(t)
+
+	dd := RegisterDefaults(Defaults{resThingWc.TrimID(): {opRead: Allow}})
+	req.Equal(Allow, Default(resThing42, opRead))
+	req.Equal(Deny, Default(resThing42, opWrite))
+	req.Equal(Allow, dd.Fallback(resThing42, opRead)())
+	req.Equal(Inherit, dd.Fallback(resThing42, opWrite)())
+}
+
+func TestRuleSet_WhatIf(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		rr = RuleSet{
+			AllowRule(role1, resThingWc, opRead),
+			DenyRule(role2, resThing42, opWrite),
+		}
+
+		users = map[uint64][]uint64{
+			1: {role1},
+			2: {role2},
+			3: {role1, role2},
+			4: {},
+		}
+	)
+
+	res := rr.WhatIf(RuleSet{DenyRule(role2, resThingWc, opRead)}, resThing42, opRead, users)
+	req.Len(res.Gained, 0)
+	req.Len(res.Lost, 1)
+	req.Equal(uint64(3), res.Lost[0].UserID)
+	req.Len(res.Changed, 1)
+	req.Equal(uint64(2), res.Changed[0].UserID)
+	req.Equal(Inherit, res.Changed[0].Before)
+	req.Equal(Deny, res.Changed[0].After)
+
+	res = rr.WhatIf(RuleSet{InheritRule(role2, resThing42, opWrite), AllowRule(EveryoneRoleID, resThingWc, opWrite)}, resThing42, opWrite, users)
+	req.Len(res.Gained, 4)
+	req.Len(res.Lost, 0)
+
+	// original set must stay intact
+	req.Len(rr, 2)
+	req.Equal(Deny, rr.Check(resThing42, opWrite, role2))
+}
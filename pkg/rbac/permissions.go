@@ -1,5 +1,10 @@
 package rbac
 
+import (
+	"strings"
+	"sync"
+)
+
 // General permission stuff, types, constants
 
 type (
@@ -17,12 +22,25 @@ type (
 		rules RuleSet
 	}
 
+	// Defaults holds access of operations on resources (types)
+	// that is used when no rule matches
+	Defaults map[Resource]map[Operation]Access
+
 	whitelistFlatten struct {
 		Resource  `json:"resource"`
 		Operation `json:"operation"`
 	}
 )
 
+var (
+	// Access of operations when no rule matches,
+	// see RegisterDefaults()
+	defaults = struct {
+		sync.RWMutex
+		index map[Resource]map[Operation]Access
+	}{index: map[Resource]map[Operation]Access{}}
+)
+
 const (
 	// EveryoneRoleID -- everyone
 	EveryoneRoleID uint64 = 1
@@ -84,7 +102,9 @@ func BoolToCheckFunc(isTrue bool) CheckAccessFunc {
 }
 
 func (a *Access) UnmarshalJSON(data []byte) error {
-	switch string(data) {
+	// Depending on the decoder (and string tag on the field)
+	// we can get quoted or unquoted value
+	switch strings.Trim(string(data), `"`) {
 	case "allow":
 		*a = Allow
 	case "deny":
@@ -107,6 +127,56 @@ func Denied() Access {
 	return Deny
 }
 
+// RegisterDefaults registers access of operations on the resources (types) when no rule matches
+//
+// Services declare defaults of the operations they check once and
+// use them as a fallback (see Defaults.Fallback) so that the access
+// is explained with the same default as it is checked with
+func RegisterDefaults(dd Defaults) Defaults {
+	defaults.Lock()
+	defer defaults.Unlock()
+
+	for r, oo := range dd {
+		r = r.TrimID()
+		if defaults.index[r] == nil {
+			defaults.index[r] = map[Operation]Access{}
+		}
+
+		for o, a := range oo {
+			defaults.index[r][o] = a
+		}
+	}
+
+	return dd
+}
+
+// Fallback returns default access of the operation on the resource as a check function
+//
+// Inherit is returned for operations without a default
+func (dd Defaults) Fallback(r Resource, op Operation) CheckAccessFunc {
+	return func() Access {
+		if a, ok := dd[r.TrimID()][op]; ok {
+			return a
+		}
+
+		return Inherit
+	}
+}
+
+// Default returns access of operation on the resource when no rule matches
+//
+// Deny is returned for all operations without a registered default
+func Default(r Resource, op Operation) Access {
+	defaults.RLock()
+	defer defaults.RUnlock()
+
+	if a, ok := defaults.index[r.TrimID()][op]; ok && a != Inherit {
+		return a
+	}
+
+	return Deny
+}
+
 func (wl *Whitelist) Set(r Resource, oo ...Operation) {
 	if wl.index == nil {
 		wl.index = map[Resource]map[Operation]bool{}
@@ -1,6 +1,7 @@
 package rbac
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 )
@@ -51,3 +52,18 @@ func TestDynamicRoles(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleAccessUnmarshal(t *testing.T) {
+	var rr RuleSet
+
+	err := json.Unmarshal([]byte(`[{"access":"allow"},{"access":"deny"},{"access":"inherit"}]`), &rr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, exp := range []Access{Allow, Deny, Inherit} {
+		if rr[i].Access != exp {
+			t.Errorf("rule #%d: expecting %s, got %s", i, exp, rr[i].Access)
+		}
+	}
+}
@@ -11,6 +11,9 @@ type (
 		Operation Operation `json:"operation"`
 		Access    Access    `json:"access,string"`
 
+		// Optional conditions; rule applies only when all of them are met
+		Conditions *Conditions `json:"conditions,omitempty"`
+
 		// Do we need to flush it to storage?
 		dirty bool
 	}
@@ -43,15 +46,21 @@ func (r Rule) Equals(cmp *Rule) bool {
 
 // AllowRule helper func to create allow rule
 func AllowRule(id uint64, r Resource, o Operation) *Rule {
-	return &Rule{id, r, o, Allow, false}
+	return &Rule{RoleID: id, Resource: r, Operation: o, Access: Allow}
 }
 
 // DenyRule helper func to create deny rule
 func DenyRule(id uint64, r Resource, o Operation) *Rule {
-	return &Rule{id, r, o, Deny, false}
+	return &Rule{RoleID: id, Resource: r, Operation: o, Access: Deny}
 }
 
 // InheritRule helper func to create inherit rule
 func InheritRule(id uint64, r Resource, o Operation) *Rule {
-	return &Rule{id, r, o, Inherit, false}
+	return &Rule{RoleID: id, Resource: r, Operation: o, Access: Inherit}
+}
+
+// WithConditions sets rule conditions and returns the rule
+func (r *Rule) WithConditions(c *Conditions) *Rule {
+	r.Conditions = c
+	return r
 }
@@ -8,19 +8,27 @@ package rbac
 //  - can this combination of roles perform an operation on any resource of the type (wildcard)
 //  - can anyone/everyone perform an operation on this specific resource
 //  - can anyone/everyone perform an operation on any resource of the type (wildcard)
+//
+// Allow rules with conditions are ignored and deny rules with conditions
+// are considered; see CheckEnv()
 func (set RuleSet) Check(res Resource, op Operation, roles ...uint64) (v Access) {
+	return set.CheckEnv(nil, res, op, roles...)
+}
 
+// CheckEnv verifies access the same way as Check
+// and considers rules with conditions met in the given environment
+func (set RuleSet) CheckEnv(env *Env, res Resource, op Operation, roles ...uint64) (v Access) {
 	if !res.IsValid() {
 		return Deny
 	}
 
 	if len(roles) > 0 {
-		if v = set.checkResource(res, op, roles...); v != Inherit {
+		if v = set.checkResource(env, res, op, roles...); v != Inherit {
 			return
 		}
 	}
 
-	if v = set.checkResource(res, op, EveryoneRoleID); v != Inherit {
+	if v = set.checkResource(env, res, op, EveryoneRoleID); v != Inherit {
 		return
 	}
 
@@ -28,14 +36,14 @@ func (set RuleSet) Check(res Resource, op Operation, roles ...uint64) (v Access)
 }
 
 // Check ability to perform an operation on a specific and wildcard resource
-func (set RuleSet) checkResource(res Resource, op Operation, roles ...uint64) (v Access) {
-	if v = set.check(res, op, roles...); v != Inherit {
+func (set RuleSet) checkResource(env *Env, res Resource, op Operation, roles ...uint64) (v Access) {
+	if v = set.check(env, res, op, roles...); v != Inherit {
 		return
 	}
 
 	if res.IsAppendable() {
 		// Is this a specific resource and can we turn it into a wild-carded resource?
-		if v = set.check(res.AppendWildcard(), op, roles...); v != Inherit {
+		if v = set.check(env, res.AppendWildcard(), op, roles...); v != Inherit {
 			return
 		}
 	}
@@ -54,7 +62,10 @@ func (set RuleSet) checkResource(res Resource, op Operation, roles ...uint64) (v
 //
 // Will return Allow when:
 //  - there is at least one rule with Allow value (and no Deny rules)
-func (set RuleSet) check(res Resource, op Operation, roles ...uint64) (v Access) {
+//
+// Rules with conditions that are not met in the environment are skipped,
+// see Conditions' Match() and MatchDeny()
+func (set RuleSet) check(env *Env, res Resource, op Operation, roles ...uint64) (v Access) {
 	v = Inherit
 
 	for i := range set {
@@ -70,6 +81,14 @@ func (set RuleSet) check(res Resource, op Operation, roles ...uint64) (v Access)
 				continue
 			}
 
+			if set[i].Access == Deny && !set[i].Conditions.MatchDeny(env) {
+				continue
+			}
+
+			if set[i].Access == Allow && !set[i].Conditions.Match(env) {
+				continue
+			}
+
 			v = set[i].Access // set to Allow
 
 			// Return on first Deny
@@ -48,7 +48,7 @@ func TestRuleSet_check(t *testing.T) {
 	)
 
 	for c, sc := range sCases {
-		v := rr.check(sc.res, sc.op, sc.roles...)
+		v := rr.check(nil, sc.res, sc.op, sc.roles...)
 		req.Equalf(sc.expected, v, "Check test #%d failed, expected %s, got %s", c, sc.expected, v)
 	}
 }
@@ -140,7 +140,7 @@ func TestRuleSet_checkResource(t *testing.T) {
 	)
 
 	for c, sc := range sCases {
-		v := sc.rr.checkResource(sc.res, sc.op, sc.roles...)
+		v := sc.rr.checkResource(nil, sc.res, sc.op, sc.roles...)
 		r.Equalf(sc.expected, v, "Check test #%d failed, expected %s, got %s", c, sc.expected, v)
 	}
 }
@@ -24,8 +24,9 @@ func (set RuleSet) Merge(rules ...*Rule) (out RuleSet) {
 			// Never go beyond the last old rule (olen)
 			for o = 0; o < olen; o++ {
 				if out[o].Equals(rule) {
-					out[o].dirty = out[o].Access != rule.Access
+					out[o].dirty = out[o].Access != rule.Access || !out[o].Conditions.Equals(rule.Conditions)
 					out[o].Access = rule.Access
+					out[o].Conditions = rule.Conditions
 
 					// only one rule can match so proceed with next new rule
 					continue newRules
@@ -27,7 +27,7 @@ type (
 	RuleFilter struct{}
 
 	Controller interface {
-		Can(roles []uint64, res Resource, op Operation, ff ...CheckAccessFunc) bool
+		Can(ctx context.Context, roles []uint64, res Resource, op Operation, ff ...CheckAccessFunc) bool
 		Check(res Resource, op Operation, roles ...uint64) (v Access)
 		Grant(ctx context.Context, wl Whitelist, rules ...*Rule) (err error)
 		Watch(ctx context.Context)
@@ -89,10 +89,12 @@ func NewService(logger *zap.Logger, s rbacRulesStore) (svc *service) {
 //
 // System user is always allowed to do everything
 //
+// Rule conditions are evaluated against remote address from the context and current time.
+//
 // When not explicitly allowed through rules or fallbacks, function will return FALSE.
-func (svc service) Can(roles []uint64, res Resource, op Operation, ff ...CheckAccessFunc) bool {
+func (svc service) Can(ctx context.Context, roles []uint64, res Resource, op Operation, ff ...CheckAccessFunc) bool {
 	// Checking rules
-	var v = svc.Check(res.RBACResource(), op, roles...)
+	var v = svc.checkEnv(EnvFromContext(ctx), res.RBACResource(), op, roles...)
 	if v != Inherit {
 		return v == Allow
 	}
@@ -119,6 +121,13 @@ func (svc service) Check(res Resource, op Operation, roles ...uint64) (v Access)
 	return svc.rules.Check(res, op, roles...)
 }
 
+func (svc service) checkEnv(env *Env, res Resource, op Operation, roles ...uint64) (v Access) {
+	svc.l.Lock()
+	defer svc.l.Unlock()
+
+	return svc.rules.CheckEnv(env, res, op, roles...)
+}
+
 // Grant appends and/or overwrites internal rules slice
 //
 // All rules with Inherit are removed
@@ -140,6 +149,10 @@ func (svc service) checkRules(wl Whitelist, rules ...*Rule) error {
 		if !wl.Check(r) {
 			return errors.Errorf("invalid rule: '%s' on '%s'", r.Operation, r.Resource)
 		}
+
+		if err := r.Conditions.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid conditions of rule '%s' on '%s'", r.Operation, r.Resource)
+		}
 	}
 
 	return nil
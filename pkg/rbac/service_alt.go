@@ -16,7 +16,7 @@ type (
 	}
 )
 
-func (ServiceAllowAll) Can([]uint64, Resource, Operation, ...CheckAccessFunc) bool {
+func (ServiceAllowAll) Can(context.Context, []uint64, Resource, Operation, ...CheckAccessFunc) bool {
 	return true
 }
 
@@ -32,7 +32,11 @@ func (ServiceAllowAll) FindRulesByRoleID(roleID uint64) (rr RuleSet) {
 	return
 }
 
-func (ServiceDenyAll) Can([]uint64, Resource, Operation, ...CheckAccessFunc) bool {
+func (ServiceAllowAll) Rules() (rr RuleSet) {
+	return
+}
+
+func (ServiceDenyAll) Can(context.Context, []uint64, Resource, Operation, ...CheckAccessFunc) bool {
 	return false
 }
 
@@ -48,6 +52,10 @@ func (ServiceDenyAll) FindRulesByRoleID(uint64) (rr RuleSet) {
 	return
 }
 
+func (ServiceDenyAll) Rules() (rr RuleSet) {
+	return
+}
+
 func (svc *TestService) ClearGrants() {
 	_ = svc.store.TruncateRbacRules(context.Background())
 	svc.rules = RuleSet{}
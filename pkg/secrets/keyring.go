@@ -0,0 +1,253 @@
+package secrets
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+type (
+	// Keyring encrypts values with the primary key and
+	// decrypts values encrypted with any of its keys
+	//
+	// Previous keys are kept on the keyring to decrypt values
+	// that were not yet re-encrypted with the new primary key
+	Keyring struct {
+		primary *key
+		keys    map[string]*key
+	}
+
+	key struct {
+		id   string
+		aead cipher.AEAD
+
+		// key for calculating blind indexes, derived from the encryption key
+		bidx []byte
+	}
+)
+
+const (
+	// KeySize is the size (in bytes) of the encryption keys (AES-256)
+	KeySize = 32
+
+	// prefix of encrypted values, followed by key ID and encoded nonce + ciphertext
+	encryptedPrefix = "enc:v1:"
+
+	// info used for deriving blind index keys
+	blindIndexInfo = "blind-index"
+)
+
+// NewKeyring creates keyring from raw keys
+//
+// First key is used for encryption, all keys for decryption
+func NewKeyring(kk ...[]byte) (*Keyring, error) {
+	if len(kk) == 0 {
+		return nil, fmt.Errorf("no encryption keys")
+	}
+
+	kr := &Keyring{keys: make(map[string]*key)}
+
+	for _, raw := range kk {
+		if len(raw) != KeySize {
+			return nil, fmt.Errorf("invalid encryption key size %d, expecting %d bytes", len(raw), KeySize)
+		}
+
+		block, err := aes.NewCipher(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+
+		sum := sha256.Sum256(raw)
+		k := &key{id: hex.EncodeToString(sum[:4]), aead: aead}
+
+		mac := hmac.New(sha256.New, raw)
+		mac.Write([]byte(blindIndexInfo))
+		k.bidx = mac.Sum(nil)
+
+		if kr.primary == nil {
+			kr.primary = k
+		}
+
+		kr.keys[k.id] = k
+	}
+
+	return kr, nil
+}
+
+// ParseKeys decodes base64 encoded keys
+//
+// Keys are separated by comma or new line;
+// empty lines and lines starting with # are ignored
+func ParseKeys(s string) (kk [][]byte, err error) {
+	s = strings.ReplaceAll(s, ",", "\n")
+
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		k, err := base64.StdEncoding.DecodeString(line)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode encryption key: %w", err)
+		}
+
+		kk = append(kk, k)
+	}
+
+	return kk, scanner.Err()
+}
+
+// Load creates keyring from base64 encoded primary key, key file and previous keys
+//
+// Key from the file is used as primary when primary key is not set;
+// all other keys in the file are considered previous keys.
+// Nil is returned when there are no keys.
+func Load(primary, keyFile, previous string) (*Keyring, error) {
+	var (
+		kk  [][]byte
+		src = primary
+	)
+
+	if keyFile != "" {
+		buf, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read encryption key file: %w", err)
+		}
+
+		src += "\n" + string(buf)
+	}
+
+	for _, s := range []string{src, previous} {
+		pk, err := ParseKeys(s)
+		if err != nil {
+			return nil, err
+		}
+
+		kk = append(kk, pk...)
+	}
+
+	if len(kk) == 0 {
+		return nil, nil
+	}
+
+	return NewKeyring(kk...)
+}
+
+// GenerateKey returns new random base64 encoded key
+func GenerateKey() (string, error) {
+	k := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, k); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(k), nil
+}
+
+// IsEncrypted returns true if value was encrypted by a keyring
+func IsEncrypted(s string) bool {
+	return strings.HasPrefix(s, encryptedPrefix)
+}
+
+// PrimaryKeyID returns ID of the key used for encryption
+func (kr *Keyring) PrimaryKeyID() string {
+	return kr.primary.id
+}
+
+// Encrypt encrypts the value with the primary key
+func (kr *Keyring) Encrypt(plain []byte) (string, error) {
+	var (
+		aead  = kr.primary.aead
+		nonce = make([]byte, aead.NonceSize())
+	)
+
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, plain, []byte(kr.primary.id))
+	return encryptedPrefix + kr.primary.id + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts the value with the key it was encrypted with
+func (kr *Keyring) Decrypt(s string) ([]byte, error) {
+	if !IsEncrypted(s) {
+		return nil, fmt.Errorf("value is not encrypted")
+	}
+
+	parts := strings.SplitN(s[len(encryptedPrefix):], ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("malformed encrypted value")
+	}
+
+	k, ok := kr.keys[parts[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown encryption key %s", parts[0])
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed encrypted value: %w", err)
+	}
+
+	ns := k.aead.NonceSize()
+	if len(sealed) < ns {
+		return nil, fmt.Errorf("malformed encrypted value")
+	}
+
+	plain, err := k.aead.Open(nil, sealed[:ns], sealed[ns:], []byte(k.id))
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt value: %w", err)
+	}
+
+	return plain, nil
+}
+
+// NeedsRotation returns true if value is not encrypted with the primary key
+func (kr *Keyring) NeedsRotation(s string) bool {
+	return !strings.HasPrefix(s, encryptedPrefix+kr.primary.id+":")
+}
+
+// BlindIndex returns keyed hash of the value calculated with the primary key
+//
+// Blind indexes of equal values are equal; they are stored
+// alongside encrypted values and used for exact-match lookups
+func (kr *Keyring) BlindIndex(plain []byte) string {
+	return kr.primary.blindIndex(plain)
+}
+
+// BlindIndexes returns blind indexes of the value calculated with all keys
+//
+// Primary key's index comes first. Values that were not yet re-encrypted
+// with the primary key are indexed with one of the previous keys.
+func (kr *Keyring) BlindIndexes(plain []byte) []string {
+	ii := []string{kr.primary.blindIndex(plain)}
+	for _, k := range kr.keys {
+		if k != kr.primary {
+			ii = append(ii, k.blindIndex(plain))
+		}
+	}
+
+	return ii
+}
+
+func (k *key) blindIndex(plain []byte) string {
+	mac := hmac.New(sha256.New, k.bidx)
+	mac.Write(plain)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,101 @@
+package secrets
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyring(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		k1 = bytes.Repeat([]byte{1}, KeySize)
+		k2 = bytes.Repeat([]byte{2}, KeySize)
+	)
+
+	old, err := NewKeyring(k1)
+	req.NoError(err)
+
+	enc, err := old.Encrypt([]byte("s3cr3t"))
+	req.NoError(err)
+	req.True(IsEncrypted(enc))
+	req.NotContains(enc, "s3cr3t")
+
+	plain, err := old.Decrypt(enc)
+	req.NoError(err)
+	req.Equal("s3cr3t", string(plain))
+
+	// new primary key, old one kept for decryption
+	kr, err := NewKeyring(k2, k1)
+	req.NoError(err)
+	req.True(kr.NeedsRotation(enc))
+
+	plain, err = kr.Decrypt(enc)
+	req.NoError(err)
+	req.Equal("s3cr3t", string(plain))
+
+	enc, err = kr.Encrypt(plain)
+	req.NoError(err)
+	req.False(kr.NeedsRotation(enc))
+
+	_, err = old.Decrypt(enc)
+	req.Error(err)
+
+	// tampered
+	_, err = kr.Decrypt(enc[:len(enc)-2] + "AA")
+	req.Error(err)
+
+	_, err = NewKeyring([]byte("short"))
+	req.Error(err)
+}
+
+func TestKeyring_BlindIndex(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		k1 = bytes.Repeat([]byte{1}, KeySize)
+		k2 = bytes.Repeat([]byte{2}, KeySize)
+	)
+
+	old, err := NewKeyring(k1)
+	req.NoError(err)
+
+	kr, err := NewKeyring(k2, k1)
+	req.NoError(err)
+
+	bidx := old.BlindIndex([]byte("s3cr3t"))
+	req.Len(bidx, 64)
+	req.Equal(bidx, old.BlindIndex([]byte("s3cr3t")))
+	req.NotEqual(bidx, old.BlindIndex([]byte("S3cr3t")))
+
+	// value indexed with the old key is found by the new keyring
+	req.NotEqual(bidx, kr.BlindIndex([]byte("s3cr3t")))
+	req.Equal([]string{kr.BlindIndex([]byte("s3cr3t")), bidx}, kr.BlindIndexes([]byte("s3cr3t")))
+}
+
+func TestLoad(t *testing.T) {
+	var (
+		req = require.New(t)
+	)
+
+	kr, err := Load("", "", "")
+	req.NoError(err)
+	req.Nil(kr)
+
+	k1, _ := GenerateKey()
+	k2, _ := GenerateKey()
+
+	kr, err = Load(k1, "", k2)
+	req.NoError(err)
+	req.Len(kr.keys, 2)
+
+	kk, err := ParseKeys(k1)
+	req.NoError(err)
+	primary, _ := NewKeyring(kk...)
+	req.Equal(primary.PrimaryKeyID(), kr.PrimaryKeyID())
+
+	_, err = Load("not base64!", "", "")
+	req.Error(err)
+}
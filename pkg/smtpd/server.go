@@ -0,0 +1,483 @@
+package smtpd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type (
+	// Envelope holds sender & recipients of the received message
+	// as they were given in the SMTP transaction
+	Envelope struct {
+		RemoteAddr string
+		Helo       string
+		From       string
+		To         []string
+	}
+
+	// Handler processes received message
+	//
+	// Returned error rejects the message
+	Handler func(ctx context.Context, env *Envelope, data io.Reader) error
+
+	// Server is a minimal, receive-only SMTP server (RFC 5321)
+	//
+	// It does not relay or queue messages; each message is passed
+	// to the handler while the client waits for the response
+	Server struct {
+		Addr     string
+		Hostname string
+
+		// Max message size in bytes, 0 for no limit
+		MaxMessageSize int
+
+		// Max number of recipients, 0 for no limit
+		MaxRecipients int
+
+		// Max time to wait for the command or data
+		Timeout time.Duration
+
+		// STARTTLS is offered when TLS config is set
+		TLSConfig *tls.Config
+
+		// AcceptRecipient filters recipient addresses, all are accepted when not set
+		AcceptRecipient func(addr string) bool
+
+		Handler Handler
+
+		Log *zap.Logger
+	}
+
+	session struct {
+		srv  *Server
+		conn net.Conn
+		tp   *textproto.Conn
+		lr   *io.LimitedReader
+		tls  bool
+		env  *Envelope
+		helo string
+	}
+)
+
+const (
+	// max length of the command line, including CRLF
+	maxLineLength = 1024
+)
+
+var (
+	errLineTooLong = errors.New("line too long")
+)
+
+// ListenAndServe listens on the configured address and serves the
+// connections until the context is canceled
+func (srv *Server) ListenAndServe(ctx context.Context) error {
+	l, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return err
+	}
+
+	return srv.Serve(ctx, l)
+}
+
+// Serve accepts connections on the listener until the context is canceled
+func (srv *Server) Serve(ctx context.Context, l net.Listener) error {
+	var (
+		wg = &sync.WaitGroup{}
+	)
+
+	if srv.Log == nil {
+		srv.Log = zap.NewNop()
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = l.Close()
+	}()
+
+	srv.Log.Info("SMTP server started", zap.String("addr", l.Addr().String()))
+	defer srv.Log.Info("SMTP server stopped")
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				wg.Wait()
+				return nil
+			}
+
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				srv.Log.Warn("failed to accept connection", zap.Error(err))
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			srv.newSession(conn).serve(ctx)
+		}()
+	}
+}
+
+func (srv *Server) newSession(conn net.Conn) *session {
+	s := &session{srv: srv}
+	s.setConn(conn)
+	return s
+}
+
+// setConn (re)initializes text protocol reader & writer for the connection
+func (s *session) setConn(conn net.Conn) {
+	s.conn = conn
+	s.lr = &io.LimitedReader{R: conn}
+	s.tp = &textproto.Conn{
+		Reader: *textproto.NewReader(bufio.NewReader(s.lr)),
+		Writer: *textproto.NewWriter(bufio.NewWriter(conn)),
+	}
+}
+
+func (s *session) serve(ctx context.Context) {
+	var (
+		log = s.srv.Log.With(zap.String("remoteAddr", s.conn.RemoteAddr().String()))
+	)
+
+	defer s.conn.Close()
+
+	if err := s.reply(220, "%s ESMTP ready", s.srv.Hostname); err != nil {
+		return
+	}
+
+	for {
+		line, err := s.readLine()
+		if err == errLineTooLong {
+			if s.reply(500, "line too long") != nil {
+				return
+			}
+
+			continue
+		} else if err != nil {
+			if err != io.EOF {
+				log.Debug("connection closed", zap.Error(err))
+			}
+
+			return
+		}
+
+		cmd, arg := line, ""
+		if i := strings.IndexByte(line, ' '); i > 0 {
+			cmd, arg = line[:i], strings.TrimSpace(line[i+1:])
+		}
+
+		if err = s.handle(ctx, log, strings.ToUpper(cmd), arg); err != nil {
+			if err != io.EOF {
+				log.Debug("connection closed", zap.Error(err))
+			}
+
+			return
+		}
+	}
+}
+
+// handle processes single command
+//
+// Returned error closes the connection
+func (s *session) handle(ctx context.Context, log *zap.Logger, cmd, arg string) error {
+	switch cmd {
+	case "HELO", "EHLO":
+		if arg == "" {
+			return s.reply(501, "domain or address required")
+		}
+
+		s.helo, s.env = arg, nil
+		if cmd == "HELO" {
+			return s.reply(250, "%s", s.srv.Hostname)
+		}
+
+		ext := []string{s.srv.Hostname, "8BITMIME"}
+		if s.srv.MaxMessageSize > 0 {
+			ext = append(ext, "SIZE "+strconv.Itoa(s.srv.MaxMessageSize))
+		}
+
+		if s.srv.TLSConfig != nil && !s.tls {
+			ext = append(ext, "STARTTLS")
+		}
+
+		return s.reply(250, "%s", strings.Join(ext, "\n"))
+
+	case "STARTTLS":
+		if s.srv.TLSConfig == nil || s.tls {
+			return s.reply(502, "command not implemented")
+		}
+
+		if err := s.reply(220, "ready to start TLS"); err != nil {
+			return err
+		}
+
+		tc := tls.Server(s.conn, s.srv.TLSConfig)
+		if err := tc.Handshake(); err != nil {
+			return fmt.Errorf("TLS handshake failed: %w", err)
+		}
+
+		// client must start over after TLS negotiation
+		s.setConn(tc)
+		s.tls, s.helo, s.env = true, "", nil
+		return nil
+
+	case "MAIL":
+		if s.helo == "" {
+			return s.reply(503, "send HELO/EHLO first")
+		}
+
+		if s.env != nil {
+			return s.reply(503, "nested MAIL command")
+		}
+
+		from, params, ok := parsePath(arg, "FROM:")
+		if !ok {
+			return s.reply(501, "syntax error in MAIL command")
+		}
+
+		if size, err := strconv.Atoi(params["SIZE"]); err == nil && s.srv.MaxMessageSize > 0 && size > s.srv.MaxMessageSize {
+			return s.reply(552, "message size exceeds fixed maximum message size")
+		}
+
+		s.env = &Envelope{RemoteAddr: s.conn.RemoteAddr().String(), Helo: s.helo, From: from}
+		return s.reply(250, "OK")
+
+	case "RCPT":
+		if s.env == nil {
+			return s.reply(503, "need MAIL command")
+		}
+
+		to, _, ok := parsePath(arg, "TO:")
+		if !ok || to == "" {
+			return s.reply(501, "syntax error in RCPT command")
+		}
+
+		if s.srv.MaxRecipients > 0 && len(s.env.To) >= s.srv.MaxRecipients {
+			return s.reply(452, "too many recipients")
+		}
+
+		if s.srv.AcceptRecipient != nil && !s.srv.AcceptRecipient(to) {
+			return s.reply(550, "mailbox unavailable")
+		}
+
+		s.env.To = append(s.env.To, to)
+		return s.reply(250, "OK")
+
+	case "DATA":
+		if s.env == nil || len(s.env.To) == 0 {
+			return s.reply(503, "need RCPT command")
+		}
+
+		return s.data(ctx, log)
+
+	case "RSET":
+		s.env = nil
+		return s.reply(250, "OK")
+
+	case "NOOP":
+		return s.reply(250, "OK")
+
+	case "VRFY":
+		return s.reply(252, "cannot verify user")
+
+	case "QUIT":
+		_ = s.reply(221, "bye")
+		return io.EOF
+	}
+
+	return s.reply(502, "command not implemented")
+}
+
+// data reads message and passes it to the handler
+func (s *session) data(ctx context.Context, log *zap.Logger) (err error) {
+	var (
+		env = s.env
+		buf = &bytes.Buffer{}
+		max = int64(s.srv.MaxMessageSize)
+	)
+
+	s.env = nil
+
+	if err = s.reply(354, "start mail input; end with <CRLF>.<CRLF>"); err != nil {
+		return
+	}
+
+	s.deadline()
+	if max > 0 {
+		// Limit the read from the connection to twice the message size
+		// (dot-stuffing, line endings) with some headroom; anything larger
+		// than that closes the connection
+		s.lr.N = 2*max + 64<<10
+	} else {
+		s.lr.N = 1<<63 - 1
+	}
+
+	// DotReader converts line endings to LF, header is prepended in the same way
+	fmt.Fprintf(buf, "Received: from %s (%s)\n\tby %s with ESMTP;\n\t%s\n",
+		env.Helo,
+		env.RemoteAddr,
+		s.srv.Hostname,
+		time.Now().Format(time.RFC1123Z),
+	)
+
+	var (
+		dr = s.tp.DotReader()
+		n  int64
+	)
+
+	if max > 0 {
+		n, err = io.Copy(buf, io.LimitReader(dr, max+1))
+	} else {
+		n, err = io.Copy(buf, dr)
+	}
+
+	if err != nil {
+		return
+	}
+
+	if max > 0 && n > max {
+		// consume the rest of the message
+		if _, err = io.Copy(ioutil.Discard, dr); err != nil {
+			return
+		}
+
+		return s.reply(552, "message size exceeds fixed maximum message size")
+	}
+
+	if err = s.srv.Handler(ctx, env, buf); err != nil {
+		log.Warn("failed to process message",
+			zap.String("from", env.From),
+			zap.Strings("to", env.To),
+			zap.Error(err),
+		)
+
+		return s.reply(554, "transaction failed")
+	}
+
+	log.Debug("message received",
+		zap.String("from", env.From),
+		zap.Strings("to", env.To),
+	)
+
+	return s.reply(250, "OK")
+}
+
+func (s *session) deadline() {
+	if s.srv.Timeout > 0 {
+		_ = s.conn.SetDeadline(time.Now().Add(s.srv.Timeout))
+	}
+}
+
+// readLine reads a single command line
+//
+// Lines longer than the limit are skipped
+func (s *session) readLine() (string, error) {
+	s.deadline()
+	s.lr.N = maxLineLength
+
+	line, err := s.tp.ReadLine()
+	if err == io.EOF && s.lr.N <= 0 {
+		return "", errLineTooLong
+	}
+
+	return line, err
+}
+
+func (s *session) reply(code int, format string, args ...interface{}) error {
+	s.deadline()
+	return s.tp.PrintfLine("%s", formatReply(code, fmt.Sprintf(format, args...)))
+}
+
+// formatReply formats (multiline) reply
+func formatReply(code int, msg string) string {
+	var (
+		lines = strings.Split(msg, "\n")
+		out   = make([]string, len(lines))
+	)
+
+	for i, l := range lines {
+		sep := "-"
+		if i == len(lines)-1 {
+			sep = " "
+		}
+
+		out[i] = strconv.Itoa(code) + sep + l
+	}
+
+	return strings.Join(out, "\r\n")
+}
+
+// parsePath parses reverse or forward path with parameters
+//
+// FROM:<user@example.tld> SIZE=1234
+func parsePath(arg, prefix string) (addr string, params map[string]string, ok bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return
+	}
+
+	arg = strings.TrimSpace(arg[len(prefix):])
+	if !strings.HasPrefix(arg, "<") {
+		return
+	}
+
+	end := strings.IndexByte(arg, '>')
+	if end < 0 {
+		return
+	}
+
+	addr, params, ok = arg[1:end], make(map[string]string), true
+
+	// strip source route (@a,@b:user@example.tld)
+	if i := strings.IndexByte(addr, ':'); i > 0 && strings.HasPrefix(addr, "@") {
+		addr = addr[i+1:]
+	}
+
+	for _, p := range strings.Fields(arg[end+1:]) {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) == 2 {
+			params[strings.ToUpper(kv[0])] = kv[1]
+		} else {
+			params[strings.ToUpper(kv[0])] = ""
+		}
+	}
+
+	return
+}
+
+// DomainFilter returns recipient filter that accepts addresses
+// of the given domains; all addresses are accepted when no domains are given
+func DomainFilter(domains ...string) func(string) bool {
+	dd := make(map[string]bool)
+	for _, d := range domains {
+		if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+			dd[d] = true
+		}
+	}
+
+	return func(addr string) bool {
+		if len(dd) == 0 {
+			return true
+		}
+
+		i := strings.LastIndexByte(addr, '@')
+		return i > 0 && dd[strings.ToLower(addr[i+1:])]
+	}
+}
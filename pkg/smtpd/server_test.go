@@ -0,0 +1,166 @@
+package smtpd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	received struct {
+		env  *Envelope
+		data string
+	}
+)
+
+func startServer(t *testing.T, srv *Server) (addr string, rcv chan *received) {
+	req := require.New(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	req.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	rcv = make(chan *received, 10)
+	srv.Hostname = "mx.example.tld"
+	srv.Timeout = time.Second * 5
+	srv.Handler = func(ctx context.Context, env *Envelope, data io.Reader) error {
+		bb, err := ioutil.ReadAll(data)
+		if err != nil {
+			return err
+		}
+
+		if strings.Contains(string(bb), "reject me") {
+			return errors.New("rejected")
+		}
+
+		rcv <- &received{env: env, data: string(bb)}
+		return nil
+	}
+
+	go srv.Serve(ctx, l)
+	return l.Addr().String(), rcv
+}
+
+func selfSignedCert(t *testing.T) tls.Certificate {
+	req := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	req.NoError(err)
+
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"mx.example.tld"},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	req.NoError(err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestServer(t *testing.T) {
+	const msg = "From: sender@example.tld\r\nTo: inbox@example.tld\r\nSubject: test\r\n\r\nHello\r\n.dot-stuffed line\r\n"
+
+	t.Run("receive", func(t *testing.T) {
+		req := require.New(t)
+		addr, rcv := startServer(t, &Server{})
+
+		req.NoError(smtp.SendMail(addr, nil, "sender@example.tld", []string{"inbox@example.tld", "other@example.tld"}, []byte(msg)))
+
+		r := <-rcv
+		req.Equal("sender@example.tld", r.env.From)
+		req.Equal([]string{"inbox@example.tld", "other@example.tld"}, r.env.To)
+		req.True(strings.HasPrefix(r.data, "Received: from localhost"))
+		req.Contains(r.data, "Subject: test\n\nHello\n.dot-stuffed line\n")
+	})
+
+	t.Run("starttls", func(t *testing.T) {
+		req := require.New(t)
+		addr, rcv := startServer(t, &Server{
+			TLSConfig: &tls.Config{Certificates: []tls.Certificate{selfSignedCert(t)}},
+		})
+
+		c, err := smtp.Dial(addr)
+		req.NoError(err)
+		defer c.Close()
+
+		ok, _ := c.Extension("STARTTLS")
+		req.True(ok)
+		req.NoError(c.StartTLS(&tls.Config{InsecureSkipVerify: true}))
+
+		ok, _ = c.Extension("STARTTLS")
+		req.False(ok)
+
+		req.NoError(c.Mail("sender@example.tld"))
+		req.NoError(c.Rcpt("inbox@example.tld"))
+		w, err := c.Data()
+		req.NoError(err)
+		_, err = w.Write([]byte(msg))
+		req.NoError(err)
+		req.NoError(w.Close())
+		req.NoError(c.Quit())
+
+		req.Equal("sender@example.tld", (<-rcv).env.From)
+	})
+
+	t.Run("recipient domains", func(t *testing.T) {
+		req := require.New(t)
+		addr, _ := startServer(t, &Server{AcceptRecipient: DomainFilter("example.tld", " Example.com ")})
+
+		c, err := smtp.Dial(addr)
+		req.NoError(err)
+		defer c.Close()
+
+		req.NoError(c.Mail("sender@example.org"))
+		req.NoError(c.Rcpt("inbox@EXAMPLE.com"))
+		req.Error(c.Rcpt("inbox@example.org"))
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		req := require.New(t)
+		addr, _ := startServer(t, &Server{MaxMessageSize: 64, MaxRecipients: 1})
+
+		c, err := smtp.Dial(addr)
+		req.NoError(err)
+		defer c.Close()
+
+		req.NoError(c.Mail("sender@example.tld"))
+		req.NoError(c.Rcpt("inbox@example.tld"))
+		req.Error(c.Rcpt("other@example.tld"))
+
+		w, err := c.Data()
+		req.NoError(err)
+		_, err = w.Write([]byte(strings.Repeat("x", 128) + "\r\n"))
+		req.NoError(err)
+		req.Error(w.Close())
+
+		// connection is still usable
+		req.NoError(c.Reset())
+		req.NoError(c.Noop())
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		req := require.New(t)
+		addr, _ := startServer(t, &Server{})
+
+		req.Error(smtp.SendMail(addr, nil, "sender@example.tld", []string{"inbox@example.tld"}, []byte("Subject: reject me\r\n\r\n")))
+	})
+}
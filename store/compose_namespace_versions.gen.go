@@ -0,0 +1,75 @@
+package store
+
+// This file is auto-generated.
+//
+// Template:    pkg/codegen/assets/store_base.gen.go.tpl
+// Definitions: store/compose_namespace_versions.yaml
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+
+import (
+	"context"
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+type (
+	ComposeNamespaceVersions interface {
+		SearchComposeNamespaceVersions(ctx context.Context, f types.NamespaceVersionFilter) (types.NamespaceVersionSet, types.NamespaceVersionFilter, error)
+		LookupComposeNamespaceVersionByID(ctx context.Context, id uint64) (*types.NamespaceVersion, error)
+
+		CreateComposeNamespaceVersion(ctx context.Context, rr ...*types.NamespaceVersion) error
+
+		UpdateComposeNamespaceVersion(ctx context.Context, rr ...*types.NamespaceVersion) error
+
+		UpsertComposeNamespaceVersion(ctx context.Context, rr ...*types.NamespaceVersion) error
+
+		DeleteComposeNamespaceVersion(ctx context.Context, rr ...*types.NamespaceVersion) error
+		DeleteComposeNamespaceVersionByID(ctx context.Context, ID uint64) error
+
+		TruncateComposeNamespaceVersions(ctx context.Context) error
+	}
+)
+
+var _ *types.NamespaceVersion
+var _ context.Context
+
+// SearchComposeNamespaceVersions returns all matching ComposeNamespaceVersions from store
+func SearchComposeNamespaceVersions(ctx context.Context, s ComposeNamespaceVersions, f types.NamespaceVersionFilter) (types.NamespaceVersionSet, types.NamespaceVersionFilter, error) {
+	return s.SearchComposeNamespaceVersions(ctx, f)
+}
+
+// LookupComposeNamespaceVersionByID searches for namespace version by ID
+func LookupComposeNamespaceVersionByID(ctx context.Context, s ComposeNamespaceVersions, id uint64) (*types.NamespaceVersion, error) {
+	return s.LookupComposeNamespaceVersionByID(ctx, id)
+}
+
+// CreateComposeNamespaceVersion creates one or more ComposeNamespaceVersions in store
+func CreateComposeNamespaceVersion(ctx context.Context, s ComposeNamespaceVersions, rr ...*types.NamespaceVersion) error {
+	return s.CreateComposeNamespaceVersion(ctx, rr...)
+}
+
+// UpdateComposeNamespaceVersion updates one or more (existing) ComposeNamespaceVersions in store
+func UpdateComposeNamespaceVersion(ctx context.Context, s ComposeNamespaceVersions, rr ...*types.NamespaceVersion) error {
+	return s.UpdateComposeNamespaceVersion(ctx, rr...)
+}
+
+// UpsertComposeNamespaceVersion creates new or updates existing one or more ComposeNamespaceVersions in store
+func UpsertComposeNamespaceVersion(ctx context.Context, s ComposeNamespaceVersions, rr ...*types.NamespaceVersion) error {
+	return s.UpsertComposeNamespaceVersion(ctx, rr...)
+}
+
+// DeleteComposeNamespaceVersion Deletes one or more ComposeNamespaceVersions from store
+func DeleteComposeNamespaceVersion(ctx context.Context, s ComposeNamespaceVersions, rr ...*types.NamespaceVersion) error {
+	return s.DeleteComposeNamespaceVersion(ctx, rr...)
+}
+
+// DeleteComposeNamespaceVersionByID Deletes ComposeNamespaceVersion from store
+func DeleteComposeNamespaceVersionByID(ctx context.Context, s ComposeNamespaceVersions, ID uint64) error {
+	return s.DeleteComposeNamespaceVersionByID(ctx, ID)
+}
+
+// TruncateComposeNamespaceVersions Deletes all ComposeNamespaceVersions from store
+func TruncateComposeNamespaceVersions(ctx context.Context, s ComposeNamespaceVersions) error {
+	return s.TruncateComposeNamespaceVersions(ctx)
+}
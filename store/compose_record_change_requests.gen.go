@@ -0,0 +1,84 @@
+package store
+
+// This file is auto-generated.
+//
+// Template:    pkg/codegen/assets/store_base.gen.go.tpl
+// Definitions: store/compose_record_change_requests.yaml
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+
+import (
+	"context"
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+type (
+	ComposeRecordChangeRequests interface {
+		SearchComposeRecordChangeRequests(ctx context.Context, f types.RecordChangeRequestFilter) (types.RecordChangeRequestSet, types.RecordChangeRequestFilter, error)
+		LookupComposeRecordChangeRequestByID(ctx context.Context, id uint64) (*types.RecordChangeRequest, error)
+
+		CreateComposeRecordChangeRequest(ctx context.Context, rr ...*types.RecordChangeRequest) error
+
+		UpdateComposeRecordChangeRequest(ctx context.Context, rr ...*types.RecordChangeRequest) error
+
+		UpsertComposeRecordChangeRequest(ctx context.Context, rr ...*types.RecordChangeRequest) error
+
+		DeleteComposeRecordChangeRequest(ctx context.Context, rr ...*types.RecordChangeRequest) error
+		DeleteComposeRecordChangeRequestByID(ctx context.Context, ID uint64) error
+
+		TruncateComposeRecordChangeRequests(ctx context.Context) error
+
+		// Additional custom functions
+
+		// CompareAndSwapComposeRecordChangeRequest (custom function)
+		CompareAndSwapComposeRecordChangeRequest(ctx context.Context, _cr *types.RecordChangeRequest, _prev *types.RecordChangeRequest) (bool, error)
+	}
+)
+
+var _ *types.RecordChangeRequest
+var _ context.Context
+
+// SearchComposeRecordChangeRequests returns all matching ComposeRecordChangeRequests from store
+func SearchComposeRecordChangeRequests(ctx context.Context, s ComposeRecordChangeRequests, f types.RecordChangeRequestFilter) (types.RecordChangeRequestSet, types.RecordChangeRequestFilter, error) {
+	return s.SearchComposeRecordChangeRequests(ctx, f)
+}
+
+// LookupComposeRecordChangeRequestByID searches for record change request by ID
+func LookupComposeRecordChangeRequestByID(ctx context.Context, s ComposeRecordChangeRequests, id uint64) (*types.RecordChangeRequest, error) {
+	return s.LookupComposeRecordChangeRequestByID(ctx, id)
+}
+
+// CreateComposeRecordChangeRequest creates one or more ComposeRecordChangeRequests in store
+func CreateComposeRecordChangeRequest(ctx context.Context, s ComposeRecordChangeRequests, rr ...*types.RecordChangeRequest) error {
+	return s.CreateComposeRecordChangeRequest(ctx, rr...)
+}
+
+// UpdateComposeRecordChangeRequest updates one or more (existing) ComposeRecordChangeRequests in store
+func UpdateComposeRecordChangeRequest(ctx context.Context, s ComposeRecordChangeRequests, rr ...*types.RecordChangeRequest) error {
+	return s.UpdateComposeRecordChangeRequest(ctx, rr...)
+}
+
+// UpsertComposeRecordChangeRequest creates new or updates existing one or more ComposeRecordChangeRequests in store
+func UpsertComposeRecordChangeRequest(ctx context.Context, s ComposeRecordChangeRequests, rr ...*types.RecordChangeRequest) error {
+	return s.UpsertComposeRecordChangeRequest(ctx, rr...)
+}
+
+// DeleteComposeRecordChangeRequest Deletes one or more ComposeRecordChangeRequests from store
+func DeleteComposeRecordChangeRequest(ctx context.Context, s ComposeRecordChangeRequests, rr ...*types.RecordChangeRequest) error {
+	return s.DeleteComposeRecordChangeRequest(ctx, rr...)
+}
+
+// DeleteComposeRecordChangeRequestByID Deletes ComposeRecordChangeRequest from store
+func DeleteComposeRecordChangeRequestByID(ctx context.Context, s ComposeRecordChangeRequests, ID uint64) error {
+	return s.DeleteComposeRecordChangeRequestByID(ctx, ID)
+}
+
+// TruncateComposeRecordChangeRequests Deletes all ComposeRecordChangeRequests from store
+func TruncateComposeRecordChangeRequests(ctx context.Context, s ComposeRecordChangeRequests) error {
+	return s.TruncateComposeRecordChangeRequests(ctx)
+}
+
+func CompareAndSwapComposeRecordChangeRequest(ctx context.Context, s ComposeRecordChangeRequests, _cr *types.RecordChangeRequest, _prev *types.RecordChangeRequest) (bool, error) {
+	return s.CompareAndSwapComposeRecordChangeRequest(ctx, _cr, _prev)
+}
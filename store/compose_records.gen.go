@@ -36,6 +36,15 @@ type (
 
 		// PartialComposeRecordValueUpdate (custom function)
 		PartialComposeRecordValueUpdate(ctx context.Context, _mod *types.Module, _values ...*types.RecordValue) error
+
+		// RenameComposeRecordValues (custom function)
+		RenameComposeRecordValues(ctx context.Context, _mod *types.Module, _oldName string, _newName string) error
+
+		// UpgradeComposeRecordStorage (custom function)
+		UpgradeComposeRecordStorage(ctx context.Context, _old *types.Module, _mod *types.Module) error
+
+		// MigrateComposeRecordStorage (custom function)
+		MigrateComposeRecordStorage(ctx context.Context, _mod *types.Module, _storage string) error
 	}
 )
 
@@ -90,3 +99,15 @@ func ComposeRecordReport(ctx context.Context, s ComposeRecords, _mod *types.Modu
 func PartialComposeRecordValueUpdate(ctx context.Context, s ComposeRecords, _mod *types.Module, _values ...*types.RecordValue) error {
 	return s.PartialComposeRecordValueUpdate(ctx, _mod, _values...)
 }
+
+func RenameComposeRecordValues(ctx context.Context, s ComposeRecords, _mod *types.Module, _oldName string, _newName string) error {
+	return s.RenameComposeRecordValues(ctx, _mod, _oldName, _newName)
+}
+
+func UpgradeComposeRecordStorage(ctx context.Context, s ComposeRecords, _old *types.Module, _mod *types.Module) error {
+	return s.UpgradeComposeRecordStorage(ctx, _old, _mod)
+}
+
+func MigrateComposeRecordStorage(ctx context.Context, s ComposeRecords, _mod *types.Module, _storage string) error {
+	return s.MigrateComposeRecordStorage(ctx, _mod, _storage)
+}
@@ -0,0 +1,68 @@
+package store
+
+// This file is auto-generated.
+//
+// Template:    pkg/codegen/assets/store_base.gen.go.tpl
+// Definitions: store/eventbus_dead_letters.yaml
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+
+import (
+	"context"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+)
+
+type (
+	EventbusDeadLetters interface {
+		SearchEventbusDeadLetters(ctx context.Context, f eventbus.QueuedEventFilter) (eventbus.QueuedEventSet, eventbus.QueuedEventFilter, error)
+		LookupEventbusDeadLetterByID(ctx context.Context, id uint64) (*eventbus.QueuedEvent, error)
+
+		CreateEventbusDeadLetter(ctx context.Context, rr ...*eventbus.QueuedEvent) error
+
+		UpdateEventbusDeadLetter(ctx context.Context, rr ...*eventbus.QueuedEvent) error
+
+		DeleteEventbusDeadLetter(ctx context.Context, rr ...*eventbus.QueuedEvent) error
+		DeleteEventbusDeadLetterByID(ctx context.Context, ID uint64) error
+
+		TruncateEventbusDeadLetters(ctx context.Context) error
+	}
+)
+
+var _ *eventbus.QueuedEvent
+var _ context.Context
+
+// SearchEventbusDeadLetters returns all matching EventbusDeadLetters from store
+func SearchEventbusDeadLetters(ctx context.Context, s EventbusDeadLetters, f eventbus.QueuedEventFilter) (eventbus.QueuedEventSet, eventbus.QueuedEventFilter, error) {
+	return s.SearchEventbusDeadLetters(ctx, f)
+}
+
+// LookupEventbusDeadLetterByID searches for dead letter by ID
+func LookupEventbusDeadLetterByID(ctx context.Context, s EventbusDeadLetters, id uint64) (*eventbus.QueuedEvent, error) {
+	return s.LookupEventbusDeadLetterByID(ctx, id)
+}
+
+// CreateEventbusDeadLetter creates one or more EventbusDeadLetters in store
+func CreateEventbusDeadLetter(ctx context.Context, s EventbusDeadLetters, rr ...*eventbus.QueuedEvent) error {
+	return s.CreateEventbusDeadLetter(ctx, rr...)
+}
+
+// UpdateEventbusDeadLetter updates one or more (existing) EventbusDeadLetters in store
+func UpdateEventbusDeadLetter(ctx context.Context, s EventbusDeadLetters, rr ...*eventbus.QueuedEvent) error {
+	return s.UpdateEventbusDeadLetter(ctx, rr...)
+}
+
+// DeleteEventbusDeadLetter Deletes one or more EventbusDeadLetters from store
+func DeleteEventbusDeadLetter(ctx context.Context, s EventbusDeadLetters, rr ...*eventbus.QueuedEvent) error {
+	return s.DeleteEventbusDeadLetter(ctx, rr...)
+}
+
+// DeleteEventbusDeadLetterByID Deletes EventbusDeadLetter from store
+func DeleteEventbusDeadLetterByID(ctx context.Context, s EventbusDeadLetters, ID uint64) error {
+	return s.DeleteEventbusDeadLetterByID(ctx, ID)
+}
+
+// TruncateEventbusDeadLetters Deletes all EventbusDeadLetters from store
+func TruncateEventbusDeadLetters(ctx context.Context, s EventbusDeadLetters) error {
+	return s.TruncateEventbusDeadLetters(ctx)
+}
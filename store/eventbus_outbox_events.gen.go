@@ -0,0 +1,77 @@
+package store
+
+// This file is auto-generated.
+//
+// Template:    pkg/codegen/assets/store_base.gen.go.tpl
+// Definitions: store/eventbus_outbox_events.yaml
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+
+import (
+	"context"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+)
+
+type (
+	EventbusOutboxEvents interface {
+		SearchEventbusOutboxEvents(ctx context.Context, f eventbus.QueuedEventFilter) (eventbus.QueuedEventSet, eventbus.QueuedEventFilter, error)
+		LookupEventbusOutboxEventByID(ctx context.Context, id uint64) (*eventbus.QueuedEvent, error)
+
+		CreateEventbusOutboxEvent(ctx context.Context, rr ...*eventbus.QueuedEvent) error
+
+		UpdateEventbusOutboxEvent(ctx context.Context, rr ...*eventbus.QueuedEvent) error
+
+		DeleteEventbusOutboxEvent(ctx context.Context, rr ...*eventbus.QueuedEvent) error
+		DeleteEventbusOutboxEventByID(ctx context.Context, ID uint64) error
+
+		TruncateEventbusOutboxEvents(ctx context.Context) error
+
+		// Additional custom functions
+
+		// ClaimEventbusOutboxEvent (custom function)
+		ClaimEventbusOutboxEvent(ctx context.Context, _qe *eventbus.QueuedEvent) (bool, error)
+	}
+)
+
+var _ *eventbus.QueuedEvent
+var _ context.Context
+
+// SearchEventbusOutboxEvents returns all matching EventbusOutboxEvents from store
+func SearchEventbusOutboxEvents(ctx context.Context, s EventbusOutboxEvents, f eventbus.QueuedEventFilter) (eventbus.QueuedEventSet, eventbus.QueuedEventFilter, error) {
+	return s.SearchEventbusOutboxEvents(ctx, f)
+}
+
+// LookupEventbusOutboxEventByID searches for queued event by ID
+func LookupEventbusOutboxEventByID(ctx context.Context, s EventbusOutboxEvents, id uint64) (*eventbus.QueuedEvent, error) {
+	return s.LookupEventbusOutboxEventByID(ctx, id)
+}
+
+// CreateEventbusOutboxEvent creates one or more EventbusOutboxEvents in store
+func CreateEventbusOutboxEvent(ctx context.Context, s EventbusOutboxEvents, rr ...*eventbus.QueuedEvent) error {
+	return s.CreateEventbusOutboxEvent(ctx, rr...)
+}
+
+// UpdateEventbusOutboxEvent updates one or more (existing) EventbusOutboxEvents in store
+func UpdateEventbusOutboxEvent(ctx context.Context, s EventbusOutboxEvents, rr ...*eventbus.QueuedEvent) error {
+	return s.UpdateEventbusOutboxEvent(ctx, rr...)
+}
+
+// DeleteEventbusOutboxEvent Deletes one or more EventbusOutboxEvents from store
+func DeleteEventbusOutboxEvent(ctx context.Context, s EventbusOutboxEvents, rr ...*eventbus.QueuedEvent) error {
+	return s.DeleteEventbusOutboxEvent(ctx, rr...)
+}
+
+// DeleteEventbusOutboxEventByID Deletes EventbusOutboxEvent from store
+func DeleteEventbusOutboxEventByID(ctx context.Context, s EventbusOutboxEvents, ID uint64) error {
+	return s.DeleteEventbusOutboxEventByID(ctx, ID)
+}
+
+// TruncateEventbusOutboxEvents Deletes all EventbusOutboxEvents from store
+func TruncateEventbusOutboxEvents(ctx context.Context, s EventbusOutboxEvents) error {
+	return s.TruncateEventbusOutboxEvents(ctx)
+}
+
+func ClaimEventbusOutboxEvent(ctx context.Context, s EventbusOutboxEvents, _qe *eventbus.QueuedEvent) (bool, error) {
+	return s.ClaimEventbusOutboxEvent(ctx, _qe)
+}
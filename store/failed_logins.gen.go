@@ -0,0 +1,78 @@
+package store
+
+// This file is auto-generated.
+//
+// Template:    pkg/codegen/assets/store_base.gen.go.tpl
+// Definitions: store/failed_logins.yaml
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+
+import (
+	"context"
+	"github.com/cortezaproject/corteza-server/system/types"
+)
+
+type (
+	FailedLogins interface {
+		LookupFailedLoginByKey(ctx context.Context, key string) (*types.FailedLogin, error)
+
+		CreateFailedLogin(ctx context.Context, rr ...*types.FailedLogin) error
+
+		UpdateFailedLogin(ctx context.Context, rr ...*types.FailedLogin) error
+
+		UpsertFailedLogin(ctx context.Context, rr ...*types.FailedLogin) error
+
+		DeleteFailedLogin(ctx context.Context, rr ...*types.FailedLogin) error
+		DeleteFailedLoginByKey(ctx context.Context, key string) error
+
+		TruncateFailedLogins(ctx context.Context) error
+
+		// Additional custom functions
+
+		// CompareAndSwapFailedLogin (custom function)
+		CompareAndSwapFailedLogin(ctx context.Context, _fl *types.FailedLogin, _failures uint) (bool, error)
+	}
+)
+
+var _ *types.FailedLogin
+var _ context.Context
+
+// LookupFailedLoginByKey searches for failed login attempts by key
+func LookupFailedLoginByKey(ctx context.Context, s FailedLogins, key string) (*types.FailedLogin, error) {
+	return s.LookupFailedLoginByKey(ctx, key)
+}
+
+// CreateFailedLogin creates one or more FailedLogins in store
+func CreateFailedLogin(ctx context.Context, s FailedLogins, rr ...*types.FailedLogin) error {
+	return s.CreateFailedLogin(ctx, rr...)
+}
+
+// UpdateFailedLogin updates one or more (existing) FailedLogins in store
+func UpdateFailedLogin(ctx context.Context, s FailedLogins, rr ...*types.FailedLogin) error {
+	return s.UpdateFailedLogin(ctx, rr...)
+}
+
+// UpsertFailedLogin creates new or updates existing one or more FailedLogins in store
+func UpsertFailedLogin(ctx context.Context, s FailedLogins, rr ...*types.FailedLogin) error {
+	return s.UpsertFailedLogin(ctx, rr...)
+}
+
+// DeleteFailedLogin Deletes one or more FailedLogins from store
+func DeleteFailedLogin(ctx context.Context, s FailedLogins, rr ...*types.FailedLogin) error {
+	return s.DeleteFailedLogin(ctx, rr...)
+}
+
+// DeleteFailedLoginByKey Deletes FailedLogin from store
+func DeleteFailedLoginByKey(ctx context.Context, s FailedLogins, key string) error {
+	return s.DeleteFailedLoginByKey(ctx, key)
+}
+
+// TruncateFailedLogins Deletes all FailedLogins from store
+func TruncateFailedLogins(ctx context.Context, s FailedLogins) error {
+	return s.TruncateFailedLogins(ctx)
+}
+
+func CompareAndSwapFailedLogin(ctx context.Context, s FailedLogins, _fl *types.FailedLogin, _failures uint) (bool, error) {
+	return s.CompareAndSwapFailedLogin(ctx, _fl, _failures)
+}
@@ -0,0 +1,77 @@
+package store
+
+// This file is auto-generated.
+//
+// Template:    pkg/codegen/assets/store_base.gen.go.tpl
+// Definitions: store/federation_attachment_origins.yaml
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+
+import (
+	"context"
+	"github.com/cortezaproject/corteza-server/federation/types"
+)
+
+type (
+	FederationAttachmentOrigins interface {
+		SearchFederationAttachmentOrigins(ctx context.Context, f types.AttachmentOriginFilter) (types.AttachmentOriginSet, types.AttachmentOriginFilter, error)
+		LookupFederationAttachmentOriginByNodeIDOriginAttachmentID(ctx context.Context, node_id uint64, origin_attachment_id uint64) (*types.AttachmentOrigin, error)
+
+		CreateFederationAttachmentOrigin(ctx context.Context, rr ...*types.AttachmentOrigin) error
+
+		UpdateFederationAttachmentOrigin(ctx context.Context, rr ...*types.AttachmentOrigin) error
+
+		UpsertFederationAttachmentOrigin(ctx context.Context, rr ...*types.AttachmentOrigin) error
+
+		DeleteFederationAttachmentOrigin(ctx context.Context, rr ...*types.AttachmentOrigin) error
+		DeleteFederationAttachmentOriginByAttachmentID(ctx context.Context, attachmentID uint64) error
+
+		TruncateFederationAttachmentOrigins(ctx context.Context) error
+	}
+)
+
+var _ *types.AttachmentOrigin
+var _ context.Context
+
+// SearchFederationAttachmentOrigins returns all matching FederationAttachmentOrigins from store
+func SearchFederationAttachmentOrigins(ctx context.Context, s FederationAttachmentOrigins, f types.AttachmentOriginFilter) (types.AttachmentOriginSet, types.AttachmentOriginFilter, error) {
+	return s.SearchFederationAttachmentOrigins(ctx, f)
+}
+
+// LookupFederationAttachmentOriginByNodeIDOriginAttachmentID searches for attachment origin by node and the attachment ID on that node
+//
+// It returns attachment origin
+func LookupFederationAttachmentOriginByNodeIDOriginAttachmentID(ctx context.Context, s FederationAttachmentOrigins, node_id uint64, origin_attachment_id uint64) (*types.AttachmentOrigin, error) {
+	return s.LookupFederationAttachmentOriginByNodeIDOriginAttachmentID(ctx, node_id, origin_attachment_id)
+}
+
+// CreateFederationAttachmentOrigin creates one or more FederationAttachmentOrigins in store
+func CreateFederationAttachmentOrigin(ctx context.Context, s FederationAttachmentOrigins, rr ...*types.AttachmentOrigin) error {
+	return s.CreateFederationAttachmentOrigin(ctx, rr...)
+}
+
+// UpdateFederationAttachmentOrigin updates one or more (existing) FederationAttachmentOrigins in store
+func UpdateFederationAttachmentOrigin(ctx context.Context, s FederationAttachmentOrigins, rr ...*types.AttachmentOrigin) error {
+	return s.UpdateFederationAttachmentOrigin(ctx, rr...)
+}
+
+// UpsertFederationAttachmentOrigin creates new or updates existing one or more FederationAttachmentOrigins in store
+func UpsertFederationAttachmentOrigin(ctx context.Context, s FederationAttachmentOrigins, rr ...*types.AttachmentOrigin) error {
+	return s.UpsertFederationAttachmentOrigin(ctx, rr...)
+}
+
+// DeleteFederationAttachmentOrigin Deletes one or more FederationAttachmentOrigins from store
+func DeleteFederationAttachmentOrigin(ctx context.Context, s FederationAttachmentOrigins, rr ...*types.AttachmentOrigin) error {
+	return s.DeleteFederationAttachmentOrigin(ctx, rr...)
+}
+
+// DeleteFederationAttachmentOriginByAttachmentID Deletes FederationAttachmentOrigin from store
+func DeleteFederationAttachmentOriginByAttachmentID(ctx context.Context, s FederationAttachmentOrigins, attachmentID uint64) error {
+	return s.DeleteFederationAttachmentOriginByAttachmentID(ctx, attachmentID)
+}
+
+// TruncateFederationAttachmentOrigins Deletes all FederationAttachmentOrigins from store
+func TruncateFederationAttachmentOrigins(ctx context.Context, s FederationAttachmentOrigins) error {
+	return s.TruncateFederationAttachmentOrigins(ctx)
+}
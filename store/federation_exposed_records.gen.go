@@ -0,0 +1,77 @@
+package store
+
+// This file is auto-generated.
+//
+// Template:    pkg/codegen/assets/store_base.gen.go.tpl
+// Definitions: store/federation_exposed_records.yaml
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+
+import (
+	"context"
+	"github.com/cortezaproject/corteza-server/federation/types"
+)
+
+type (
+	FederationExposedRecords interface {
+		SearchFederationExposedRecords(ctx context.Context, f types.ExposedRecordFilter) (types.ExposedRecordSet, types.ExposedRecordFilter, error)
+		LookupFederationExposedRecordByExposedModuleIDRecordID(ctx context.Context, exposed_module_id uint64, record_id uint64) (*types.ExposedRecord, error)
+
+		CreateFederationExposedRecord(ctx context.Context, rr ...*types.ExposedRecord) error
+
+		UpdateFederationExposedRecord(ctx context.Context, rr ...*types.ExposedRecord) error
+
+		UpsertFederationExposedRecord(ctx context.Context, rr ...*types.ExposedRecord) error
+
+		DeleteFederationExposedRecord(ctx context.Context, rr ...*types.ExposedRecord) error
+		DeleteFederationExposedRecordByExposedModuleIDRecordID(ctx context.Context, exposedModuleID uint64, recordID uint64) error
+
+		TruncateFederationExposedRecords(ctx context.Context) error
+	}
+)
+
+var _ *types.ExposedRecord
+var _ context.Context
+
+// SearchFederationExposedRecords returns all matching FederationExposedRecords from store
+func SearchFederationExposedRecords(ctx context.Context, s FederationExposedRecords, f types.ExposedRecordFilter) (types.ExposedRecordSet, types.ExposedRecordFilter, error) {
+	return s.SearchFederationExposedRecords(ctx, f)
+}
+
+// LookupFederationExposedRecordByExposedModuleIDRecordID searches for the record that was exposed with the given module
+//
+// It returns exposed record
+func LookupFederationExposedRecordByExposedModuleIDRecordID(ctx context.Context, s FederationExposedRecords, exposed_module_id uint64, record_id uint64) (*types.ExposedRecord, error) {
+	return s.LookupFederationExposedRecordByExposedModuleIDRecordID(ctx, exposed_module_id, record_id)
+}
+
+// CreateFederationExposedRecord creates one or more FederationExposedRecords in store
+func CreateFederationExposedRecord(ctx context.Context, s FederationExposedRecords, rr ...*types.ExposedRecord) error {
+	return s.CreateFederationExposedRecord(ctx, rr...)
+}
+
+// UpdateFederationExposedRecord updates one or more (existing) FederationExposedRecords in store
+func UpdateFederationExposedRecord(ctx context.Context, s FederationExposedRecords, rr ...*types.ExposedRecord) error {
+	return s.UpdateFederationExposedRecord(ctx, rr...)
+}
+
+// UpsertFederationExposedRecord creates new or updates existing one or more FederationExposedRecords in store
+func UpsertFederationExposedRecord(ctx context.Context, s FederationExposedRecords, rr ...*types.ExposedRecord) error {
+	return s.UpsertFederationExposedRecord(ctx, rr...)
+}
+
+// DeleteFederationExposedRecord Deletes one or more FederationExposedRecords from store
+func DeleteFederationExposedRecord(ctx context.Context, s FederationExposedRecords, rr ...*types.ExposedRecord) error {
+	return s.DeleteFederationExposedRecord(ctx, rr...)
+}
+
+// DeleteFederationExposedRecordByExposedModuleIDRecordID Deletes FederationExposedRecord from store
+func DeleteFederationExposedRecordByExposedModuleIDRecordID(ctx context.Context, s FederationExposedRecords, exposedModuleID uint64, recordID uint64) error {
+	return s.DeleteFederationExposedRecordByExposedModuleIDRecordID(ctx, exposedModuleID, recordID)
+}
+
+// TruncateFederationExposedRecords Deletes all FederationExposedRecords from store
+func TruncateFederationExposedRecords(ctx context.Context, s FederationExposedRecords) error {
+	return s.TruncateFederationExposedRecords(ctx)
+}
@@ -0,0 +1,77 @@
+package store
+
+// This file is auto-generated.
+//
+// Template:    pkg/codegen/assets/store_base.gen.go.tpl
+// Definitions: store/federation_record_conflicts.yaml
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+
+import (
+	"context"
+	"github.com/cortezaproject/corteza-server/federation/types"
+)
+
+type (
+	FederationRecordConflicts interface {
+		SearchFederationRecordConflicts(ctx context.Context, f types.RecordConflictFilter) (types.RecordConflictSet, types.RecordConflictFilter, error)
+		LookupFederationRecordConflictByID(ctx context.Context, id uint64) (*types.RecordConflict, error)
+
+		CreateFederationRecordConflict(ctx context.Context, rr ...*types.RecordConflict) error
+
+		UpdateFederationRecordConflict(ctx context.Context, rr ...*types.RecordConflict) error
+
+		UpsertFederationRecordConflict(ctx context.Context, rr ...*types.RecordConflict) error
+
+		DeleteFederationRecordConflict(ctx context.Context, rr ...*types.RecordConflict) error
+		DeleteFederationRecordConflictByID(ctx context.Context, ID uint64) error
+
+		TruncateFederationRecordConflicts(ctx context.Context) error
+	}
+)
+
+var _ *types.RecordConflict
+var _ context.Context
+
+// SearchFederationRecordConflicts returns all matching FederationRecordConflicts from store
+func SearchFederationRecordConflicts(ctx context.Context, s FederationRecordConflicts, f types.RecordConflictFilter) (types.RecordConflictSet, types.RecordConflictFilter, error) {
+	return s.SearchFederationRecordConflicts(ctx, f)
+}
+
+// LookupFederationRecordConflictByID searches for record conflict by ID
+//
+// It returns record conflict
+func LookupFederationRecordConflictByID(ctx context.Context, s FederationRecordConflicts, id uint64) (*types.RecordConflict, error) {
+	return s.LookupFederationRecordConflictByID(ctx, id)
+}
+
+// CreateFederationRecordConflict creates one or more FederationRecordConflicts in store
+func CreateFederationRecordConflict(ctx context.Context, s FederationRecordConflicts, rr ...*types.RecordConflict) error {
+	return s.CreateFederationRecordConflict(ctx, rr...)
+}
+
+// UpdateFederationRecordConflict updates one or more (existing) FederationRecordConflicts in store
+func UpdateFederationRecordConflict(ctx context.Context, s FederationRecordConflicts, rr ...*types.RecordConflict) error {
+	return s.UpdateFederationRecordConflict(ctx, rr...)
+}
+
+// UpsertFederationRecordConflict creates new or updates existing one or more FederationRecordConflicts in store
+func UpsertFederationRecordConflict(ctx context.Context, s FederationRecordConflicts, rr ...*types.RecordConflict) error {
+	return s.UpsertFederationRecordConflict(ctx, rr...)
+}
+
+// DeleteFederationRecordConflict Deletes one or more FederationRecordConflicts from store
+func DeleteFederationRecordConflict(ctx context.Context, s FederationRecordConflicts, rr ...*types.RecordConflict) error {
+	return s.DeleteFederationRecordConflict(ctx, rr...)
+}
+
+// DeleteFederationRecordConflictByID Deletes FederationRecordConflict from store
+func DeleteFederationRecordConflictByID(ctx context.Context, s FederationRecordConflicts, ID uint64) error {
+	return s.DeleteFederationRecordConflictByID(ctx, ID)
+}
+
+// TruncateFederationRecordConflicts Deletes all FederationRecordConflicts from store
+func TruncateFederationRecordConflicts(ctx context.Context, s FederationRecordConflicts) error {
+	return s.TruncateFederationRecordConflicts(ctx)
+}
@@ -0,0 +1,85 @@
+package store
+
+// This file is auto-generated.
+//
+// Template:    pkg/codegen/assets/store_base.gen.go.tpl
+// Definitions: store/federation_record_origins.yaml
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+
+import (
+	"context"
+	"github.com/cortezaproject/corteza-server/federation/types"
+)
+
+type (
+	FederationRecordOrigins interface {
+		SearchFederationRecordOrigins(ctx context.Context, f types.RecordOriginFilter) (types.RecordOriginSet, types.RecordOriginFilter, error)
+		LookupFederationRecordOriginByRecordID(ctx context.Context, record_id uint64) (*types.RecordOrigin, error)
+		LookupFederationRecordOriginByNodeIDOriginRecordID(ctx context.Context, node_id uint64, origin_record_id uint64) (*types.RecordOrigin, error)
+
+		CreateFederationRecordOrigin(ctx context.Context, rr ...*types.RecordOrigin) error
+
+		UpdateFederationRecordOrigin(ctx context.Context, rr ...*types.RecordOrigin) error
+
+		UpsertFederationRecordOrigin(ctx context.Context, rr ...*types.RecordOrigin) error
+
+		DeleteFederationRecordOrigin(ctx context.Context, rr ...*types.RecordOrigin) error
+		DeleteFederationRecordOriginByRecordID(ctx context.Context, recordID uint64) error
+
+		TruncateFederationRecordOrigins(ctx context.Context) error
+	}
+)
+
+var _ *types.RecordOrigin
+var _ context.Context
+
+// SearchFederationRecordOrigins returns all matching FederationRecordOrigins from store
+func SearchFederationRecordOrigins(ctx context.Context, s FederationRecordOrigins, f types.RecordOriginFilter) (types.RecordOriginSet, types.RecordOriginFilter, error) {
+	return s.SearchFederationRecordOrigins(ctx, f)
+}
+
+// LookupFederationRecordOriginByRecordID searches for record origin by local record ID
+//
+// It returns record origin
+func LookupFederationRecordOriginByRecordID(ctx context.Context, s FederationRecordOrigins, record_id uint64) (*types.RecordOrigin, error) {
+	return s.LookupFederationRecordOriginByRecordID(ctx, record_id)
+}
+
+// LookupFederationRecordOriginByNodeIDOriginRecordID searches for record origin by node and the record ID on that node
+//
+// It returns record origin
+func LookupFederationRecordOriginByNodeIDOriginRecordID(ctx context.Context, s FederationRecordOrigins, node_id uint64, origin_record_id uint64) (*types.RecordOrigin, error) {
+	return s.LookupFederationRecordOriginByNodeIDOriginRecordID(ctx, node_id, origin_record_id)
+}
+
+// CreateFederationRecordOrigin creates one or more FederationRecordOrigins in store
+func CreateFederationRecordOrigin(ctx context.Context, s FederationRecordOrigins, rr ...*types.RecordOrigin) error {
+	return s.CreateFederationRecordOrigin(ctx, rr...)
+}
+
+// UpdateFederationRecordOrigin updates one or more (existing) FederationRecordOrigins in store
+func UpdateFederationRecordOrigin(ctx context.Context, s FederationRecordOrigins, rr ...*types.RecordOrigin) error {
+	return s.UpdateFederationRecordOrigin(ctx, rr...)
+}
+
+// UpsertFederationRecordOrigin creates new or updates existing one or more FederationRecordOrigins in store
+func UpsertFederationRecordOrigin(ctx context.Context, s FederationRecordOrigins, rr ...*types.RecordOrigin) error {
+	return s.UpsertFederationRecordOrigin(ctx, rr...)
+}
+
+// DeleteFederationRecordOrigin Deletes one or more FederationRecordOrigins from store
+func DeleteFederationRecordOrigin(ctx context.Context, s FederationRecordOrigins, rr ...*types.RecordOrigin) error {
+	return s.DeleteFederationRecordOrigin(ctx, rr...)
+}
+
+// DeleteFederationRecordOriginByRecordID Deletes FederationRecordOrigin from store
+func DeleteFederationRecordOriginByRecordID(ctx context.Context, s FederationRecordOrigins, recordID uint64) error {
+	return s.DeleteFederationRecordOriginByRecordID(ctx, recordID)
+}
+
+// TruncateFederationRecordOrigins Deletes all FederationRecordOrigins from store
+func TruncateFederationRecordOrigins(ctx context.Context, s FederationRecordOrigins) error {
+	return s.TruncateFederationRecordOrigins(ctx)
+}
@@ -11,15 +11,24 @@ package store
 //  - store/compose_charts.yaml
 //  - store/compose_module_fields.yaml
 //  - store/compose_modules.yaml
+//  - store/compose_namespace_versions.yaml
 //  - store/compose_namespaces.yaml
 //  - store/compose_pages.yaml
+//  - store/compose_record_change_requests.yaml
 //  - store/compose_record_values.yaml
 //  - store/compose_records.yaml
 //  - store/credentials.yaml
+//  - store/eventbus_dead_letters.yaml
+//  - store/eventbus_outbox_events.yaml
+//  - store/failed_logins.yaml
+//  - store/federation_attachment_origins.yaml
 //  - store/federation_exposed_modules.yaml
+//  - store/federation_exposed_records.yaml
 //  - store/federation_module_mappings.yaml
 //  - store/federation_nodes.yaml
 //  - store/federation_nodes_sync.yaml
+//  - store/federation_record_conflicts.yaml
+//  - store/federation_record_origins.yaml
 //  - store/federation_shared_modules.yaml
 //  - store/labels.yaml
 //  - store/messaging_attachments.yaml
@@ -30,11 +39,13 @@ package store
 //  - store/messaging_message_attachments.yaml
 //  - store/messaging_messages.yaml
 //  - store/messaging_unread.yaml
+//  - store/ratelimit_buckets.yaml
 //  - store/rbac_rules.yaml
 //  - store/reminders.yaml
 //  - store/role_members.yaml
 //  - store/roles.yaml
 //  - store/settings.yaml
+//  - store/templates.yaml
 //  - store/users.yaml
 //
 // Changes to this file may cause incorrect behavior and will be lost if
@@ -51,15 +62,24 @@ type (
 		ComposeCharts
 		ComposeModuleFields
 		ComposeModules
+		ComposeNamespaceVersions
 		ComposeNamespaces
 		ComposePages
+		ComposeRecordChangeRequests
 		ComposeRecordValues
 		ComposeRecords
 		Credentials
+		EventbusDeadLetters
+		EventbusOutboxEvents
+		FailedLogins
+		FederationAttachmentOrigins
 		FederationExposedModules
+		FederationExposedRecords
 		FederationModuleMappings
 		FederationNodes
 		FederationNodesSyncs
+		FederationRecordConflicts
+		FederationRecordOrigins
 		FederationSharedModules
 		Labels
 		MessagingAttachments
@@ -70,11 +90,13 @@ type (
 		MessagingMessageAttachments
 		MessagingMessages
 		MessagingUnreads
+		RatelimitBuckets
 		RbacRules
 		Reminders
 		RoleMembers
 		Roles
 		Settings
+		Templates
 		Users
 	}
 )
@@ -44,6 +44,7 @@ func Connect(ctx context.Context, dsn string) (store.Storer, error) {
 	cfg.UpsertBuilder = UpsertBuilder
 	cfg.CastModuleFieldToColumnType = fieldToColumnTypeCaster
 	cfg.SqlSortHandler = SqlSortHandler
+	cfg.SchemaUpgrader = schemaUpgrader
 
 	if s.Store, err = rdbms.Connect(ctx, cfg); err != nil {
 		return nil, err
@@ -53,6 +53,8 @@ func NewUpgrader(log *zap.Logger, store *Store) *upgrader {
 			return "DATETIME"
 		case ddl.ColumnTypeBoolean:
 			return "TINYINT(1)"
+		case ddl.ColumnTypeNumeric:
+			return "DECIMAL(65,30)"
 		default:
 			return ddl.GenColumnType(ct)
 		}
@@ -61,6 +63,13 @@ func NewUpgrader(log *zap.Logger, store *Store) *upgrader {
 	return u
 }
 
+// schemaUpgrader returns upgrader for the (transaction) store
+//
+// Used by the rdbms store for the tables that are managed on runtime
+func schemaUpgrader(log *zap.Logger, s *rdbms.Store) rdbms.SchemaUpgrader {
+	return NewUpgrader(log, &Store{Store: s})
+}
+
 // Before runs before all tables are upgraded
 func (u upgrader) Before(ctx context.Context) error {
 	err := func() error {
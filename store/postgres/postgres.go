@@ -42,6 +42,7 @@ func Connect(ctx context.Context, dsn string) (store.Storer, error) {
 	cfg.ErrorHandler = errorHandler
 	cfg.SqlFunctionHandler = sqlFunctionHandler
 	cfg.CastModuleFieldToColumnType = fieldToColumnTypeCaster
+	cfg.SchemaUpgrader = schemaUpgrader
 
 	if s.Store, err = rdbms.Connect(ctx, cfg); err != nil {
 		return nil, err
@@ -31,6 +31,13 @@ func NewUpgrader(log *zap.Logger, store *Store) *upgrader {
 	return g
 }
 
+// schemaUpgrader returns upgrader for the (transaction) store
+//
+// Used by the rdbms store for the tables that are managed on runtime
+func schemaUpgrader(log *zap.Logger, s *rdbms.Store) rdbms.SchemaUpgrader {
+	return NewUpgrader(log, &Store{Store: s})
+}
+
 // Before runs before all tables are upgraded
 func (u upgrader) Before(ctx context.Context) error {
 	return rdbms.GenericUpgrades(u.log, u).Before(ctx)
@@ -0,0 +1,85 @@
+package store
+
+// This file is auto-generated.
+//
+// Template:    pkg/codegen/assets/store_base.gen.go.tpl
+// Definitions: store/ratelimit_buckets.yaml
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+
+import (
+	"context"
+	"github.com/cortezaproject/corteza-server/pkg/ratelimit"
+)
+
+type (
+	RatelimitBuckets interface {
+		LookupRatelimitBucketByKey(ctx context.Context, key string) (*ratelimit.Bucket, error)
+
+		CreateRatelimitBucket(ctx context.Context, rr ...*ratelimit.Bucket) error
+
+		UpdateRatelimitBucket(ctx context.Context, rr ...*ratelimit.Bucket) error
+
+		UpsertRatelimitBucket(ctx context.Context, rr ...*ratelimit.Bucket) error
+
+		DeleteRatelimitBucket(ctx context.Context, rr ...*ratelimit.Bucket) error
+		DeleteRatelimitBucketByKey(ctx context.Context, key string) error
+
+		TruncateRatelimitBuckets(ctx context.Context) error
+
+		// Additional custom functions
+
+		// PurgeRatelimitBuckets (custom function)
+		PurgeRatelimitBuckets(ctx context.Context, _f ratelimit.BucketFilter) error
+
+		// CompareAndSwapRatelimitBucket (custom function)
+		CompareAndSwapRatelimitBucket(ctx context.Context, _b *ratelimit.Bucket, _prev *ratelimit.Bucket) (bool, error)
+	}
+)
+
+var _ *ratelimit.Bucket
+var _ context.Context
+
+// LookupRatelimitBucketByKey searches for rate limit bucket by key
+func LookupRatelimitBucketByKey(ctx context.Context, s RatelimitBuckets, key string) (*ratelimit.Bucket, error) {
+	return s.LookupRatelimitBucketByKey(ctx, key)
+}
+
+// CreateRatelimitBucket creates one or more RatelimitBuckets in store
+func CreateRatelimitBucket(ctx context.Context, s RatelimitBuckets, rr ...*ratelimit.Bucket) error {
+	return s.CreateRatelimitBucket(ctx, rr...)
+}
+
+// UpdateRatelimitBucket updates one or more (existing) RatelimitBuckets in store
+func UpdateRatelimitBucket(ctx context.Context, s RatelimitBuckets, rr ...*ratelimit.Bucket) error {
+	return s.UpdateRatelimitBucket(ctx, rr...)
+}
+
+// UpsertRatelimitBucket creates new or updates existing one or more RatelimitBuckets in store
+func UpsertRatelimitBucket(ctx context.Context, s RatelimitBuckets, rr ...*ratelimit.Bucket) error {
+	return s.UpsertRatelimitBucket(ctx, rr...)
+}
+
+// DeleteRatelimitBucket Deletes one or more RatelimitBuckets from store
+func DeleteRatelimitBucket(ctx context.Context, s RatelimitBuckets, rr ...*ratelimit.Bucket) error {
+	return s.DeleteRatelimitBucket(ctx, rr...)
+}
+
+// DeleteRatelimitBucketByKey Deletes RatelimitBucket from store
+func DeleteRatelimitBucketByKey(ctx context.Context, s RatelimitBuckets, key string) error {
+	return s.DeleteRatelimitBucketByKey(ctx, key)
+}
+
+// TruncateRatelimitBuckets Deletes all RatelimitBuckets from store
+func TruncateRatelimitBuckets(ctx context.Context, s RatelimitBuckets) error {
+	return s.TruncateRatelimitBuckets(ctx)
+}
+
+func PurgeRatelimitBuckets(ctx context.Context, s RatelimitBuckets, _f ratelimit.BucketFilter) error {
+	return s.PurgeRatelimitBuckets(ctx, _f)
+}
+
+func CompareAndSwapRatelimitBucket(ctx context.Context, s RatelimitBuckets, _b *ratelimit.Bucket, _prev *ratelimit.Bucket) (bool, error) {
+	return s.CompareAndSwapRatelimitBucket(ctx, _b, _prev)
+}
@@ -456,6 +456,9 @@ func (s Store) internalComposeModuleRowScanner(row rowScanner) (res *types.Modul
 			&res.Name,
 			&res.Meta,
 			&res.NamespaceID,
+			&res.Storage,
+			&res.Workflow,
+			&res.Approval,
 			&res.CreatedAt,
 			&res.UpdatedAt,
 			&res.DeletedAt,
@@ -503,6 +506,9 @@ func (Store) composeModuleColumns(aa ...string) []string {
 		alias + "name",
 		alias + "meta",
 		alias + "rel_namespace",
+		alias + "storage",
+		alias + "workflow",
+		alias + "approval",
 		alias + "created_at",
 		alias + "updated_at",
 		alias + "deleted_at",
@@ -536,6 +542,9 @@ func (s Store) internalComposeModuleEncoder(res *types.Module) store.Payload {
 		"name":          res.Name,
 		"meta":          res.Meta,
 		"rel_namespace": res.NamespaceID,
+		"storage":       res.Storage,
+		"workflow":      res.Workflow,
+		"approval":      res.Approval,
 		"created_at":    res.CreatedAt,
 		"updated_at":    res.UpdatedAt,
 		"deleted_at":    res.DeletedAt,
@@ -0,0 +1,598 @@
+package rdbms
+
+// This file is an auto-generated file
+//
+// Template:    pkg/codegen/assets/store_rdbms.gen.go.tpl
+// Definitions: store/compose_namespace_versions.yaml
+//
+// Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated.
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/store/rdbms/builders"
+)
+
+var _ = errors.Is
+
+// SearchComposeNamespaceVersions returns all matching rows
+//
+// This function calls convertComposeNamespaceVersionFilter with the given
+// types.NamespaceVersionFilter and expects to receive a working squirrel.SelectBuilder
+func (s Store) SearchComposeNamespaceVersions(ctx context.Context, f types.NamespaceVersionFilter) (types.NamespaceVersionSet, types.NamespaceVersionFilter, error) {
+	var (
+		err error
+		set []*types.NamespaceVersion
+		q   squirrel.SelectBuilder
+	)
+
+	return set, f, func() error {
+		q, err = s.convertComposeNamespaceVersionFilter(f)
+		if err != nil {
+			return err
+		}
+
+		// Paging enabled
+		// {search: {enablePaging:true}}
+		// Cleanup unwanted cursor values (only relevant is f.PageCursor, next&prev are reset and returned)
+		f.PrevPage, f.NextPage = nil, nil
+
+		if f.PageCursor != nil {
+			// Page cursor exists so we need to validate it against used sort
+			// To cover the case when paging cursor is set but sorting is empty, we collect the sorting instructions
+			// from the cursor.
+			// This (extracted sorting info) is then returned as part of response
+			if f.Sort, err = f.PageCursor.Sort(f.Sort); err != nil {
+				return err
+			}
+		}
+
+		// Make sure results are always sorted at least by primary keys
+		if f.Sort.Get("id") == nil {
+			f.Sort = append(f.Sort, &filter.SortExpr{
+				Column:     "id",
+				Descending: f.Sort.LastDescending(),
+			})
+		}
+
+		// Cloned sorting instructions for the actual sorting
+		// Original are passed to the fetchFullPageOfUsers fn used for cursor creation so it MUST keep the initial
+		// direction information
+		sort := f.Sort.Clone()
+
+		// When cursor for a previous page is used it's marked as reversed
+		// This tells us to flip the descending flag on all used sort keys
+		if f.PageCursor != nil && f.PageCursor.ROrder {
+			sort.Reverse()
+		}
+
+		// Apply sorting expr from filter to query
+		if q, err = setOrderBy(q, sort, s.sortableComposeNamespaceVersionColumns()); err != nil {
+			return err
+		}
+
+		set, f.PrevPage, f.NextPage, err = s.fetchFullPageOfComposeNamespaceVersions(
+			ctx,
+			q, f.Sort, f.PageCursor,
+			f.Limit,
+			f.Check,
+			func(cur *filter.PagingCursor) squirrel.Sqlizer {
+				return builders.CursorCondition(cur, nil)
+			},
+		)
+
+		if err != nil {
+			return err
+		}
+
+		f.PageCursor = nil
+		return nil
+	}()
+}
+
+// fetchFullPageOfComposeNamespaceVersions collects all requested results.
+//
+// Function applies:
+//  - cursor conditions (where ...)
+//  - limit
+//
+// Main responsibility of this function is to perform additional sequential queries in case when not enough results
+// are collected due to failed check on a specific row (by check fn).
+//
+// Function then moves cursor to the last item fetched
+func (s Store) fetchFullPageOfComposeNamespaceVersions(
+	ctx context.Context,
+	q squirrel.SelectBuilder,
+	sort filter.SortExprSet,
+	cursor *filter.PagingCursor,
+	reqItems uint,
+	check func(*types.NamespaceVersion) (bool, error),
+	cursorCond func(*filter.PagingCursor) squirrel.Sqlizer,
+) (set []*types.NamespaceVersion, prev, next *filter.PagingCursor, err error) {
+	var (
+		aux []*types.NamespaceVersion
+
+		// When cursor for a previous page is used it's marked as reversed
+		// This tells us to flip the descending flag on all used sort keys
+		reversedOrder = cursor != nil && cursor.ROrder
+
+		// copy of the select builder
+		tryQuery squirrel.SelectBuilder
+
+		// Copy no. of required items to limit
+		// Limit will change when doing subsequent queries to fill
+		// the set with all required items
+		limit = reqItems
+
+		// cursor to prev. page is only calculated when cursor is used
+		hasPrev = cursor != nil
+
+		// next cursor is calculated when there are more pages to come
+		hasNext bool
+	)
+
+	set = make([]*types.NamespaceVersion, 0, DefaultSliceCapacity)
+
+	for try := 0; try < MaxRefetches; try++ {
+		if cursor != nil {
+			tryQuery = q.Where(cursorCond(cursor))
+		} else {
+			tryQuery = q
+		}
+
+		if limit > 0 {
+			// fetching + 1 so we know if there are more items
+			// we can fetch (next-page cursor)
+			tryQuery = tryQuery.Limit(uint64(limit + 1))
+		}
+
+		if aux, err = s.QueryComposeNamespaceVersions(ctx, tryQuery, check); err != nil {
+			return nil, nil, nil, err
+		}
+
+		if len(aux) == 0 {
+			// nothing fetched
+			break
+		}
+
+		// append fetched items
+		set = append(set, aux...)
+
+		if reqItems == 0 {
+			// no max requested items specified, break out
+			break
+		}
+
+		collected := uint(len(set))
+
+		if reqItems > collected {
+			// not enough items fetched, try again with adjusted limit
+			limit = reqItems - collected
+
+			if limit < MinEnsureFetchLimit {
+				// In case limit is set very low and we've missed records in the first fetch,
+				// make sure next fetch limit is a bit higher
+				limit = MinEnsureFetchLimit
+			}
+
+			// Update cursor so that it points to the last item fetched
+			cursor = s.collectComposeNamespaceVersionCursorValues(set[collected-1], sort...)
+
+			// Copy reverse flag from sorting
+			cursor.LThen = sort.Reversed()
+			continue
+		}
+
+		if reqItems < collected {
+			set = set[:reqItems]
+			hasNext = true
+		}
+
+		break
+	}
+
+	collected := len(set)
+
+	if collected == 0 {
+		return nil, nil, nil, nil
+	}
+
+	if reversedOrder {
+		// Fetched set needs to be reversed because we've forced a descending order to get the previous page
+		for i, j := 0, collected-1; i < j; i, j = i+1, j-1 {
+			set[i], set[j] = set[j], set[i]
+		}
+
+		// when in reverse-order rules on what cursor to return change
+		hasPrev, hasNext = hasNext, hasPrev
+	}
+
+	if hasPrev {
+		prev = s.collectComposeNamespaceVersionCursorValues(set[0], sort...)
+		prev.ROrder = true
+		prev.LThen = !sort.Reversed()
+	}
+
+	if hasNext {
+		next = s.collectComposeNamespaceVersionCursorValues(set[collected-1], sort...)
+		next.LThen = sort.Reversed()
+	}
+
+	return set, prev, next, nil
+}
+
+// QueryComposeNamespaceVersions queries the database, converts and checks each row and
+// returns collected set
+//
+// Fn also returns total number of fetched items and last fetched item so that the caller can construct cursor
+// for next page of results
+func (s Store) QueryComposeNamespaceVersions(
+	ctx context.Context,
+	q squirrel.Sqlizer,
+	check func(*types.NamespaceVersion) (bool, error),
+) ([]*types.NamespaceVersion, error) {
+	var (
+		set = make([]*types.NamespaceVersion, 0, DefaultSliceCapacity)
+		res *types.NamespaceVersion
+
+		// Query rows with
+		rows, err = s.Query(ctx, q)
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		if err = rows.Err(); err == nil {
+			res, err = s.internalComposeNamespaceVersionRowScanner(rows)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// check fn set, call it and see if it passed the test
+		// if not, skip the item
+		if check != nil {
+			if chk, err := check(res); err != nil {
+				return nil, err
+			} else if !chk {
+				continue
+			}
+		}
+
+		set = append(set, res)
+	}
+
+	return set, rows.Err()
+}
+
+// LookupComposeNamespaceVersionByID searches for namespace version by ID
+func (s Store) LookupComposeNamespaceVersionByID(ctx context.Context, id uint64) (*types.NamespaceVersion, error) {
+	return s.execLookupComposeNamespaceVersion(ctx, squirrel.Eq{
+		s.preprocessColumn("cnv.id", ""): store.PreprocessValue(id, ""),
+	})
+}
+
+// CreateComposeNamespaceVersion creates one or more rows in compose_namespace_version table
+func (s Store) CreateComposeNamespaceVersion(ctx context.Context, rr ...*types.NamespaceVersion) (err error) {
+	for _, res := range rr {
+		err = s.checkComposeNamespaceVersionConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execCreateComposeNamespaceVersions(ctx, s.internalComposeNamespaceVersionEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpdateComposeNamespaceVersion updates one or more existing rows in compose_namespace_version
+func (s Store) UpdateComposeNamespaceVersion(ctx context.Context, rr ...*types.NamespaceVersion) error {
+	return s.partialComposeNamespaceVersionUpdate(ctx, nil, rr...)
+}
+
+// partialComposeNamespaceVersionUpdate updates one or more existing rows in compose_namespace_version
+func (s Store) partialComposeNamespaceVersionUpdate(ctx context.Context, onlyColumns []string, rr ...*types.NamespaceVersion) (err error) {
+	for _, res := range rr {
+		err = s.checkComposeNamespaceVersionConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpdateComposeNamespaceVersions(
+			ctx,
+			squirrel.Eq{
+				s.preprocessColumn("cnv.id", ""): store.PreprocessValue(res.ID, ""),
+			},
+			s.internalComposeNamespaceVersionEncoder(res).Skip("id").Only(onlyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpsertComposeNamespaceVersion updates one or more existing rows in compose_namespace_version
+func (s Store) UpsertComposeNamespaceVersion(ctx context.Context, rr ...*types.NamespaceVersion) (err error) {
+	for _, res := range rr {
+		err = s.checkComposeNamespaceVersionConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpsertComposeNamespaceVersions(ctx, s.internalComposeNamespaceVersionEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteComposeNamespaceVersion Deletes one or more rows from compose_namespace_version table
+func (s Store) DeleteComposeNamespaceVersion(ctx context.Context, rr ...*types.NamespaceVersion) (err error) {
+	for _, res := range rr {
+
+		err = s.execDeleteComposeNamespaceVersions(ctx, squirrel.Eq{
+			s.preprocessColumn("cnv.id", ""): store.PreprocessValue(res.ID, ""),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteComposeNamespaceVersionByID Deletes row from the compose_namespace_version table
+func (s Store) DeleteComposeNamespaceVersionByID(ctx context.Context, ID uint64) error {
+	return s.execDeleteComposeNamespaceVersions(ctx, squirrel.Eq{
+		s.preprocessColumn("cnv.id", ""): store.PreprocessValue(ID, ""),
+	})
+}
+
+// TruncateComposeNamespaceVersions Deletes all rows from the compose_namespace_version table
+func (s Store) TruncateComposeNamespaceVersions(ctx context.Context) error {
+	return s.Truncate(ctx, s.composeNamespaceVersionTable())
+}
+
+// execLookupComposeNamespaceVersion prepares ComposeNamespaceVersion query and executes it,
+// returning types.NamespaceVersion (or error)
+func (s Store) execLookupComposeNamespaceVersion(ctx context.Context, cnd squirrel.Sqlizer) (res *types.NamespaceVersion, err error) {
+	var (
+		row rowScanner
+	)
+
+	row, err = s.QueryRow(ctx, s.composeNamespaceVersionsSelectBuilder().Where(cnd))
+	if err != nil {
+		return
+	}
+
+	res, err = s.internalComposeNamespaceVersionRowScanner(row)
+	if err != nil {
+		return
+	}
+
+	return res, nil
+}
+
+// execCreateComposeNamespaceVersions updates all matched (by cnd) rows in compose_namespace_version with given data
+func (s Store) execCreateComposeNamespaceVersions(ctx context.Context, payload store.Payload) error {
+	return s.Exec(ctx, s.InsertBuilder(s.composeNamespaceVersionTable()).SetMap(payload))
+}
+
+// execUpdateComposeNamespaceVersions updates all matched (by cnd) rows in compose_namespace_version with given data
+func (s Store) execUpdateComposeNamespaceVersions(ctx context.Context, cnd squirrel.Sqlizer, set store.Payload) error {
+	return s.Exec(ctx, s.UpdateBuilder(s.composeNamespaceVersionTable("cnv")).Where(cnd).SetMap(set))
+}
+
+// execUpsertComposeNamespaceVersions inserts new or updates matching (by-primary-key) rows in compose_namespace_version with given data
+func (s Store) execUpsertComposeNamespaceVersions(ctx context.Context, set store.Payload) error {
+	upsert, err := s.config.UpsertBuilder(
+		s.config,
+		s.composeNamespaceVersionTable(),
+		set,
+		s.preprocessColumn("id", ""),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return s.Exec(ctx, upsert)
+}
+
+// execDeleteComposeNamespaceVersions Deletes all matched (by cnd) rows in compose_namespace_version with given data
+func (s Store) execDeleteComposeNamespaceVersions(ctx context.Context, cnd squirrel.Sqlizer) error {
+	return s.Exec(ctx, s.DeleteBuilder(s.composeNamespaceVersionTable("cnv")).Where(cnd))
+}
+
+func (s Store) internalComposeNamespaceVersionRowScanner(row rowScanner) (res *types.NamespaceVersion, err error) {
+	res = &types.NamespaceVersion{}
+
+	if _, has := s.config.RowScanners["composeNamespaceVersion"]; has {
+		scanner := s.config.RowScanners["composeNamespaceVersion"].(func(_ rowScanner, _ *types.NamespaceVersion) error)
+		err = scanner(row, res)
+	} else {
+		err = row.Scan(
+			&res.ID,
+			&res.NamespaceID,
+			&res.Status,
+			&res.Comment,
+			&res.Config,
+			&res.Base,
+			&res.CreatedAt,
+			&res.CreatedBy,
+			&res.UpdatedAt,
+			&res.PublishedAt,
+			&res.PublishedBy,
+		)
+	}
+
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound.Stack(1)
+	}
+
+	if err != nil {
+		return nil, errors.Store("could not scan composeNamespaceVersion db row").Wrap(err)
+	} else {
+		return res, nil
+	}
+}
+
+// QueryComposeNamespaceVersions returns squirrel.SelectBuilder with set table and all columns
+func (s Store) composeNamespaceVersionsSelectBuilder() squirrel.SelectBuilder {
+	return s.SelectBuilder(s.composeNamespaceVersionTable("cnv"), s.composeNamespaceVersionColumns("cnv")...)
+}
+
+// composeNamespaceVersionTable name of the db table
+func (Store) composeNamespaceVersionTable(aa ...string) string {
+	var alias string
+	if len(aa) > 0 {
+		alias = " AS " + aa[0]
+	}
+
+	return "compose_namespace_version" + alias
+}
+
+// ComposeNamespaceVersionColumns returns all defined table columns
+//
+// With optional string arg, all columns are returned aliased
+func (Store) composeNamespaceVersionColumns(aa ...string) []string {
+	var alias string
+	if len(aa) > 0 {
+		alias = aa[0] + "."
+	}
+
+	return []string{
+		alias + "id",
+		alias + "rel_namespace",
+		alias + "status",
+		alias + "comment",
+		alias + "config",
+		alias + "base",
+		alias + "created_at",
+		alias + "created_by",
+		alias + "updated_at",
+		alias + "published_at",
+		alias + "published_by",
+	}
+}
+
+// {true true false true true true}
+
+// sortableComposeNamespaceVersionColumns returns all ComposeNamespaceVersion columns flagged as sortable
+//
+// With optional string arg, all columns are returned aliased
+func (Store) sortableComposeNamespaceVersionColumns() map[string]string {
+	return map[string]string{
+		"id": "id", "created_at": "created_at",
+		"createdat":    "created_at",
+		"updated_at":   "updated_at",
+		"updatedat":    "updated_at",
+		"published_at": "published_at",
+		"publishedat":  "published_at",
+	}
+}
+
+// internalComposeNamespaceVersionEncoder encodes fields from types.NamespaceVersion to store.Payload (map)
+//
+// Encoding is done by using generic approach or by calling encodeComposeNamespaceVersion
+// func when rdbms.customEncoder=true
+func (s Store) internalComposeNamespaceVersionEncoder(res *types.NamespaceVersion) store.Payload {
+	return store.Payload{
+		"id":            res.ID,
+		"rel_namespace": res.NamespaceID,
+		"status":        res.Status,
+		"comment":       res.Comment,
+		"config":        res.Config,
+		"base":          res.Base,
+		"created_at":    res.CreatedAt,
+		"created_by":    res.CreatedBy,
+		"updated_at":    res.UpdatedAt,
+		"published_at":  res.PublishedAt,
+		"published_by":  res.PublishedBy,
+	}
+}
+
+// collectComposeNamespaceVersionCursorValues collects values from the given resource that and sets them to the cursor
+// to be used for pagination
+//
+// Values that are collected must come from sortable, unique or primary columns/fields
+// At least one of the collected columns must be flagged as unique, otherwise fn appends primary keys at the end
+//
+// Known issue:
+//   when collecting cursor values for query that sorts by unique column with partial index (ie: unique handle on
+//   undeleted items)
+func (s Store) collectComposeNamespaceVersionCursorValues(res *types.NamespaceVersion, cc ...*filter.SortExpr) *filter.PagingCursor {
+	var (
+		cursor = &filter.PagingCursor{}
+
+		hasUnique bool
+
+		// All known primary key columns
+
+		pkId bool
+
+		collect = func(cc ...*filter.SortExpr) {
+			for _, c := range cc {
+				switch c.Column {
+				case "id":
+					cursor.Set(c.Column, res.ID, c.Descending)
+
+					pkId = true
+				case "created_at":
+					cursor.Set(c.Column, res.CreatedAt, c.Descending)
+
+				case "updated_at":
+					cursor.Set(c.Column, res.UpdatedAt, c.Descending)
+
+				case "published_at":
+					cursor.Set(c.Column, res.PublishedAt, c.Descending)
+
+				}
+			}
+		}
+	)
+
+	collect(cc...)
+	if !hasUnique || !(pkId && true) {
+		collect(&filter.SortExpr{Column: "id", Descending: false})
+	}
+
+	return cursor
+}
+
+// checkComposeNamespaceVersionConstraints performs lookups (on valid) resource to check if any of the values on unique fields
+// already exists in the store
+//
+// Using built-in constraint checking would be more performant but unfortunately we can not rely
+// on the full support (MySQL does not support conditional indexes)
+func (s *Store) checkComposeNamespaceVersionConstraints(ctx context.Context, res *types.NamespaceVersion) error {
+	// Consider resource valid when all fields in unique constraint check lookups
+	// have valid (non-empty) value
+	//
+	// Only string and uint64 are supported for now
+	// feel free to add additional types if needed
+	var valid = true
+
+	if !valid {
+		return nil
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package rdbms
+
+import (
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+func (s Store) convertComposeNamespaceVersionFilter(f types.NamespaceVersionFilter) (query squirrel.SelectBuilder, err error) {
+	query = s.composeNamespaceVersionsSelectBuilder()
+
+	if f.NamespaceID > 0 {
+		query = query.Where(squirrel.Eq{"cnv.rel_namespace": f.NamespaceID})
+	}
+
+	if f.Status != "" {
+		query = query.Where(squirrel.Eq{"cnv.status": f.Status})
+	}
+
+	return
+}
@@ -0,0 +1,604 @@
+package rdbms
+
+// This file is an auto-generated file
+//
+// Template:    pkg/codegen/assets/store_rdbms.gen.go.tpl
+// Definitions: store/compose_record_change_requests.yaml
+//
+// Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated.
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/store/rdbms/builders"
+)
+
+var _ = errors.Is
+
+// SearchComposeRecordChangeRequests returns all matching rows
+//
+// This function calls convertComposeRecordChangeRequestFilter with the given
+// types.RecordChangeRequestFilter and expects to receive a working squirrel.SelectBuilder
+func (s Store) SearchComposeRecordChangeRequests(ctx context.Context, f types.RecordChangeRequestFilter) (types.RecordChangeRequestSet, types.RecordChangeRequestFilter, error) {
+	var (
+		err error
+		set []*types.RecordChangeRequest
+		q   squirrel.SelectBuilder
+	)
+
+	return set, f, func() error {
+		q, err = s.convertComposeRecordChangeRequestFilter(f)
+		if err != nil {
+			return err
+		}
+
+		// Paging enabled
+		// {search: {enablePaging:true}}
+		// Cleanup unwanted cursor values (only relevant is f.PageCursor, next&prev are reset and returned)
+		f.PrevPage, f.NextPage = nil, nil
+
+		if f.PageCursor != nil {
+			// Page cursor exists so we need to validate it against used sort
+			// To cover the case when paging cursor is set but sorting is empty, we collect the sorting instructions
+			// from the cursor.
+			// This (extracted sorting info) is then returned as part of response
+			if f.Sort, err = f.PageCursor.Sort(f.Sort); err != nil {
+				return err
+			}
+		}
+
+		// Make sure results are always sorted at least by primary keys
+		if f.Sort.Get("id") == nil {
+			f.Sort = append(f.Sort, &filter.SortExpr{
+				Column:     "id",
+				Descending: f.Sort.LastDescending(),
+			})
+		}
+
+		// Cloned sorting instructions for the actual sorting
+		// Original are passed to the fetchFullPageOfUsers fn used for cursor creation so it MUST keep the initial
+		// direction information
+		sort := f.Sort.Clone()
+
+		// When cursor for a previous page is used it's marked as reversed
+		// This tells us to flip the descending flag on all used sort keys
+		if f.PageCursor != nil && f.PageCursor.ROrder {
+			sort.Reverse()
+		}
+
+		// Apply sorting expr from filter to query
+		if q, err = setOrderBy(q, sort, s.sortableComposeRecordChangeRequestColumns()); err != nil {
+			return err
+		}
+
+		set, f.PrevPage, f.NextPage, err = s.fetchFullPageOfComposeRecordChangeRequests(
+			ctx,
+			q, f.Sort, f.PageCursor,
+			f.Limit,
+			f.Check,
+			func(cur *filter.PagingCursor) squirrel.Sqlizer {
+				return builders.CursorCondition(cur, nil)
+			},
+		)
+
+		if err != nil {
+			return err
+		}
+
+		f.PageCursor = nil
+		return nil
+	}()
+}
+
+// fetchFullPageOfComposeRecordChangeRequests collects all requested results.
+//
+// Function applies:
+//  - cursor conditions (where ...)
+//  - limit
+//
+// Main responsibility of this function is to perform additional sequential queries in case when not enough results
+// are collected due to failed check on a specific row (by check fn).
+//
+// Function then moves cursor to the last item fetched
+func (s Store) fetchFullPageOfComposeRecordChangeRequests(
+	ctx context.Context,
+	q squirrel.SelectBuilder,
+	sort filter.SortExprSet,
+	cursor *filter.PagingCursor,
+	reqItems uint,
+	check func(*types.RecordChangeRequest) (bool, error),
+	cursorCond func(*filter.PagingCursor) squirrel.Sqlizer,
+) (set []*types.RecordChangeRequest, prev, next *filter.PagingCursor, err error) {
+	var (
+		aux []*types.RecordChangeRequest
+
+		// When cursor for a previous page is used it's marked as reversed
+		// This tells us to flip the descending flag on all used sort keys
+		reversedOrder = cursor != nil && cursor.ROrder
+
+		// copy of the select builder
+		tryQuery squirrel.SelectBuilder
+
+		// Copy no. of required items to limit
+		// Limit will change when doing subsequent queries to fill
+		// the set with all required items
+		limit = reqItems
+
+		// cursor to prev. page is only calculated when cursor is used
+		hasPrev = cursor != nil
+
+		// next cursor is calculated when there are more pages to come
+		hasNext bool
+	)
+
+	set = make([]*types.RecordChangeRequest, 0, DefaultSliceCapacity)
+
+	for try := 0; try < MaxRefetches; try++ {
+		if cursor != nil {
+			tryQuery = q.Where(cursorCond(cursor))
+		} else {
+			tryQuery = q
+		}
+
+		if limit > 0 {
+			// fetching + 1 so we know if there are more items
+			// we can fetch (next-page cursor)
+			tryQuery = tryQuery.Limit(uint64(limit + 1))
+		}
+
+		if aux, err = s.QueryComposeRecordChangeRequests(ctx, tryQuery, check); err != nil {
+			return nil, nil, nil, err
+		}
+
+		if len(aux) == 0 {
+			// nothing fetched
+			break
+		}
+
+		// append fetched items
+		set = append(set, aux...)
+
+		if reqItems == 0 {
+			// no max requested items specified, break out
+			break
+		}
+
+		collected := uint(len(set))
+
+		if reqItems > collected {
+			// not enough items fetched, try again with adjusted limit
+			limit = reqItems - collected
+
+			if limit < MinEnsureFetchLimit {
+				// In case limit is set very low and we've missed records in the first fetch,
+				// make sure next fetch limit is a bit higher
+				limit = MinEnsureFetchLimit
+			}
+
+			// Update cursor so that it points to the last item fetched
+			cursor = s.collectComposeRecordChangeRequestCursorValues(set[collected-1], sort...)
+
+			// Copy reverse flag from sorting
+			cursor.LThen = sort.Reversed()
+			continue
+		}
+
+		if reqItems < collected {
+			set = set[:reqItems]
+			hasNext = true
+		}
+
+		break
+	}
+
+	collected := len(set)
+
+	if collected == 0 {
+		return nil, nil, nil, nil
+	}
+
+	if reversedOrder {
+		// Fetched set needs to be reversed because we've forced a descending order to get the previous page
+		for i, j := 0, collected-1; i < j; i, j = i+1, j-1 {
+			set[i], set[j] = set[j], set[i]
+		}
+
+		// when in reverse-order rules on what cursor to return change
+		hasPrev, hasNext = hasNext, hasPrev
+	}
+
+	if hasPrev {
+		prev = s.collectComposeRecordChangeRequestCursorValues(set[0], sort...)
+		prev.ROrder = true
+		prev.LThen = !sort.Reversed()
+	}
+
+	if hasNext {
+		next = s.collectComposeRecordChangeRequestCursorValues(set[collected-1], sort...)
+		next.LThen = sort.Reversed()
+	}
+
+	return set, prev, next, nil
+}
+
+// QueryComposeRecordChangeRequests queries the database, converts and checks each row and
+// returns collected set
+//
+// Fn also returns total number of fetched items and last fetched item so that the caller can construct cursor
+// for next page of results
+func (s Store) QueryComposeRecordChangeRequests(
+	ctx context.Context,
+	q squirrel.Sqlizer,
+	check func(*types.RecordChangeRequest) (bool, error),
+) ([]*types.RecordChangeRequest, error) {
+	var (
+		set = make([]*types.RecordChangeRequest, 0, DefaultSliceCapacity)
+		res *types.RecordChangeRequest
+
+		// Query rows with
+		rows, err = s.Query(ctx, q)
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		if err = rows.Err(); err == nil {
+			res, err = s.internalComposeRecordChangeRequestRowScanner(rows)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// check fn set, call it and see if it passed the test
+		// if not, skip the item
+		if check != nil {
+			if chk, err := check(res); err != nil {
+				return nil, err
+			} else if !chk {
+				continue
+			}
+		}
+
+		set = append(set, res)
+	}
+
+	return set, rows.Err()
+}
+
+// LookupComposeRecordChangeRequestByID searches for record change request by ID
+func (s Store) LookupComposeRecordChangeRequestByID(ctx context.Context, id uint64) (*types.RecordChangeRequest, error) {
+	return s.execLookupComposeRecordChangeRequest(ctx, squirrel.Eq{
+		s.preprocessColumn("crcr.id", ""): store.PreprocessValue(id, ""),
+	})
+}
+
+// CreateComposeRecordChangeRequest creates one or more rows in compose_record_change_request table
+func (s Store) CreateComposeRecordChangeRequest(ctx context.Context, rr ...*types.RecordChangeRequest) (err error) {
+	for _, res := range rr {
+		err = s.checkComposeRecordChangeRequestConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execCreateComposeRecordChangeRequests(ctx, s.internalComposeRecordChangeRequestEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpdateComposeRecordChangeRequest updates one or more existing rows in compose_record_change_request
+func (s Store) UpdateComposeRecordChangeRequest(ctx context.Context, rr ...*types.RecordChangeRequest) error {
+	return s.partialComposeRecordChangeRequestUpdate(ctx, nil, rr...)
+}
+
+// partialComposeRecordChangeRequestUpdate updates one or more existing rows in compose_record_change_request
+func (s Store) partialComposeRecordChangeRequestUpdate(ctx context.Context, onlyColumns []string, rr ...*types.RecordChangeRequest) (err error) {
+	for _, res := range rr {
+		err = s.checkComposeRecordChangeRequestConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpdateComposeRecordChangeRequests(
+			ctx,
+			squirrel.Eq{
+				s.preprocessColumn("crcr.id", ""): store.PreprocessValue(res.ID, ""),
+			},
+			s.internalComposeRecordChangeRequestEncoder(res).Skip("id").Only(onlyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpsertComposeRecordChangeRequest updates one or more existing rows in compose_record_change_request
+func (s Store) UpsertComposeRecordChangeRequest(ctx context.Context, rr ...*types.RecordChangeRequest) (err error) {
+	for _, res := range rr {
+		err = s.checkComposeRecordChangeRequestConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpsertComposeRecordChangeRequests(ctx, s.internalComposeRecordChangeRequestEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteComposeRecordChangeRequest Deletes one or more rows from compose_record_change_request table
+func (s Store) DeleteComposeRecordChangeRequest(ctx context.Context, rr ...*types.RecordChangeRequest) (err error) {
+	for _, res := range rr {
+
+		err = s.execDeleteComposeRecordChangeRequests(ctx, squirrel.Eq{
+			s.preprocessColumn("crcr.id", ""): store.PreprocessValue(res.ID, ""),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteComposeRecordChangeRequestByID Deletes row from the compose_record_change_request table
+func (s Store) DeleteComposeRecordChangeRequestByID(ctx context.Context, ID uint64) error {
+	return s.execDeleteComposeRecordChangeRequests(ctx, squirrel.Eq{
+		s.preprocessColumn("crcr.id", ""): store.PreprocessValue(ID, ""),
+	})
+}
+
+// TruncateComposeRecordChangeRequests Deletes all rows from the compose_record_change_request table
+func (s Store) TruncateComposeRecordChangeRequests(ctx context.Context) error {
+	return s.Truncate(ctx, s.composeRecordChangeRequestTable())
+}
+
+// execLookupComposeRecordChangeRequest prepares ComposeRecordChangeRequest query and executes it,
+// returning types.RecordChangeRequest (or error)
+func (s Store) execLookupComposeRecordChangeRequest(ctx context.Context, cnd squirrel.Sqlizer) (res *types.RecordChangeRequest, err error) {
+	var (
+		row rowScanner
+	)
+
+	row, err = s.QueryRow(ctx, s.composeRecordChangeRequestsSelectBuilder().Where(cnd))
+	if err != nil {
+		return
+	}
+
+	res, err = s.internalComposeRecordChangeRequestRowScanner(row)
+	if err != nil {
+		return
+	}
+
+	return res, nil
+}
+
+// execCreateComposeRecordChangeRequests updates all matched (by cnd) rows in compose_record_change_request with given data
+func (s Store) execCreateComposeRecordChangeRequests(ctx context.Context, payload store.Payload) error {
+	return s.Exec(ctx, s.InsertBuilder(s.composeRecordChangeRequestTable()).SetMap(payload))
+}
+
+// execUpdateComposeRecordChangeRequests updates all matched (by cnd) rows in compose_record_change_request with given data
+func (s Store) execUpdateComposeRecordChangeRequests(ctx context.Context, cnd squirrel.Sqlizer, set store.Payload) error {
+	return s.Exec(ctx, s.UpdateBuilder(s.composeRecordChangeRequestTable("crcr")).Where(cnd).SetMap(set))
+}
+
+// execUpsertComposeRecordChangeRequests inserts new or updates matching (by-primary-key) rows in compose_record_change_request with given data
+func (s Store) execUpsertComposeRecordChangeRequests(ctx context.Context, set store.Payload) error {
+	upsert, err := s.config.UpsertBuilder(
+		s.config,
+		s.composeRecordChangeRequestTable(),
+		set,
+		s.preprocessColumn("id", ""),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return s.Exec(ctx, upsert)
+}
+
+// execDeleteComposeRecordChangeRequests Deletes all matched (by cnd) rows in compose_record_change_request with given data
+func (s Store) execDeleteComposeRecordChangeRequests(ctx context.Context, cnd squirrel.Sqlizer) error {
+	return s.Exec(ctx, s.DeleteBuilder(s.composeRecordChangeRequestTable("crcr")).Where(cnd))
+}
+
+func (s Store) internalComposeRecordChangeRequestRowScanner(row rowScanner) (res *types.RecordChangeRequest, err error) {
+	res = &types.RecordChangeRequest{}
+
+	if _, has := s.config.RowScanners["composeRecordChangeRequest"]; has {
+		scanner := s.config.RowScanners["composeRecordChangeRequest"].(func(_ rowScanner, _ *types.RecordChangeRequest) error)
+		err = scanner(row, res)
+	} else {
+		err = row.Scan(
+			&res.ID,
+			&res.NamespaceID,
+			&res.ModuleID,
+			&res.RecordID,
+			&res.Status,
+			&res.Step,
+			&res.Change,
+			&res.Decisions,
+			&res.CreatedAt,
+			&res.CreatedBy,
+			&res.UpdatedAt,
+			&res.CompletedAt,
+			&res.CompletedBy,
+		)
+	}
+
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound.Stack(1)
+	}
+
+	if err != nil {
+		return nil, errors.Store("could not scan composeRecordChangeRequest db row").Wrap(err)
+	} else {
+		return res, nil
+	}
+}
+
+// QueryComposeRecordChangeRequests returns squirrel.SelectBuilder with set table and all columns
+func (s Store) composeRecordChangeRequestsSelectBuilder() squirrel.SelectBuilder {
+	return s.SelectBuilder(s.composeRecordChangeRequestTable("crcr"), s.composeRecordChangeRequestColumns("crcr")...)
+}
+
+// composeRecordChangeRequestTable name of the db table
+func (Store) composeRecordChangeRequestTable(aa ...string) string {
+	var alias string
+	if len(aa) > 0 {
+		alias = " AS " + aa[0]
+	}
+
+	return "compose_record_change_request" + alias
+}
+
+// ComposeRecordChangeRequestColumns returns all defined table columns
+//
+// With optional string arg, all columns are returned aliased
+func (Store) composeRecordChangeRequestColumns(aa ...string) []string {
+	var alias string
+	if len(aa) > 0 {
+		alias = aa[0] + "."
+	}
+
+	return []string{
+		alias + "id",
+		alias + "rel_namespace",
+		alias + "rel_module",
+		alias + "rel_record",
+		alias + "status",
+		alias + "step",
+		alias + "proposed_change",
+		alias + "decisions",
+		alias + "created_at",
+		alias + "created_by",
+		alias + "updated_at",
+		alias + "completed_at",
+		alias + "completed_by",
+	}
+}
+
+// {true true false true true true}
+
+// sortableComposeRecordChangeRequestColumns returns all ComposeRecordChangeRequest columns flagged as sortable
+//
+// With optional string arg, all columns are returned aliased
+func (Store) sortableComposeRecordChangeRequestColumns() map[string]string {
+	return map[string]string{
+		"id": "id", "created_at": "created_at",
+		"createdat":    "created_at",
+		"updated_at":   "updated_at",
+		"updatedat":    "updated_at",
+		"completed_at": "completed_at",
+		"completedat":  "completed_at",
+	}
+}
+
+// internalComposeRecordChangeRequestEncoder encodes fields from types.RecordChangeRequest to store.Payload (map)
+//
+// Encoding is done by using generic approach or by calling encodeComposeRecordChangeRequest
+// func when rdbms.customEncoder=true
+func (s Store) internalComposeRecordChangeRequestEncoder(res *types.RecordChangeRequest) store.Payload {
+	return store.Payload{
+		"id":              res.ID,
+		"rel_namespace":   res.NamespaceID,
+		"rel_module":      res.ModuleID,
+		"rel_record":      res.RecordID,
+		"status":          res.Status,
+		"step":            res.Step,
+		"proposed_change": res.Change,
+		"decisions":       res.Decisions,
+		"created_at":      res.CreatedAt,
+		"created_by":      res.CreatedBy,
+		"updated_at":      res.UpdatedAt,
+		"completed_at":    res.CompletedAt,
+		"completed_by":    res.CompletedBy,
+	}
+}
+
+// collectComposeRecordChangeRequestCursorValues collects values from the given resource that and sets them to the cursor
+// to be used for pagination
+//
+// Values that are collected must come from sortable, unique or primary columns/fields
+// At least one of the collected columns must be flagged as unique, otherwise fn appends primary keys at the end
+//
+// Known issue:
+//   when collecting cursor values for query that sorts by unique column with partial index (ie: unique handle on
+//   undeleted items)
+func (s Store) collectComposeRecordChangeRequestCursorValues(res *types.RecordChangeRequest, cc ...*filter.SortExpr) *filter.PagingCursor {
+	var (
+		cursor = &filter.PagingCursor{}
+
+		hasUnique bool
+
+		// All known primary key columns
+
+		pkId bool
+
+		collect = func(cc ...*filter.SortExpr) {
+			for _, c := range cc {
+				switch c.Column {
+				case "id":
+					cursor.Set(c.Column, res.ID, c.Descending)
+
+					pkId = true
+				case "created_at":
+					cursor.Set(c.Column, res.CreatedAt, c.Descending)
+
+				case "updated_at":
+					cursor.Set(c.Column, res.UpdatedAt, c.Descending)
+
+				case "completed_at":
+					cursor.Set(c.Column, res.CompletedAt, c.Descending)
+
+				}
+			}
+		}
+	)
+
+	collect(cc...)
+	if !hasUnique || !(pkId && true) {
+		collect(&filter.SortExpr{Column: "id", Descending: false})
+	}
+
+	return cursor
+}
+
+// checkComposeRecordChangeRequestConstraints performs lookups (on valid) resource to check if any of the values on unique fields
+// already exists in the store
+//
+// Using built-in constraint checking would be more performant but unfortunately we can not rely
+// on the full support (MySQL does not support conditional indexes)
+func (s *Store) checkComposeRecordChangeRequestConstraints(ctx context.Context, res *types.RecordChangeRequest) error {
+	// Consider resource valid when all fields in unique constraint check lookups
+	// have valid (non-empty) value
+	//
+	// Only string and uint64 are supported for now
+	// feel free to add additional types if needed
+	var valid = true
+
+	if !valid {
+		return nil
+	}
+
+	return nil
+}
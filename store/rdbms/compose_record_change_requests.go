@@ -0,0 +1,58 @@
+package rdbms
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/store"
+)
+
+func (s Store) convertComposeRecordChangeRequestFilter(f types.RecordChangeRequestFilter) (query squirrel.SelectBuilder, err error) {
+	query = s.composeRecordChangeRequestsSelectBuilder()
+
+	if f.NamespaceID > 0 {
+		query = query.Where(squirrel.Eq{"crcr.rel_namespace": f.NamespaceID})
+	}
+
+	if f.ModuleID > 0 {
+		query = query.Where(squirrel.Eq{"crcr.rel_module": f.ModuleID})
+	}
+
+	if f.RecordID > 0 {
+		query = query.Where(squirrel.Eq{"crcr.rel_record": f.RecordID})
+	}
+
+	if f.Status != "" {
+		query = query.Where(squirrel.Eq{"crcr.status": f.Status})
+	}
+
+	if f.CreatedBy > 0 {
+		query = query.Where(squirrel.Eq{"crcr.created_by": f.CreatedBy})
+	}
+
+	return
+}
+
+// CompareAndSwapComposeRecordChangeRequest updates record change request only if
+// status and time of the last update in the store still match the previous ones
+//
+// False is returned when change request was modified (or removed) in the meantime
+func (s Store) CompareAndSwapComposeRecordChangeRequest(ctx context.Context, cr, prev *types.RecordChangeRequest) (bool, error) {
+	query, args, err := s.UpdateBuilder(s.composeRecordChangeRequestTable()).
+		SetMap(s.internalComposeRecordChangeRequestEncoder(cr)).
+		Where(squirrel.Eq{"id": prev.ID, "status": prev.Status, "updated_at": prev.UpdatedAt}).
+		ToSql()
+
+	if err != nil {
+		return false, err
+	}
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, store.HandleError(err, s.config.ErrorHandler)
+	}
+
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
@@ -39,6 +39,8 @@ type (
 		Query(context.Context, squirrel.Sqlizer) (*sql.Rows, error)
 		SqlFunctionHandler(f ql.Function) (ql.ASTNode, error)
 		FieldToColumnTypeCaster(f ModuleFieldTypeDetector, i ql.Ident) (ql.Ident, error)
+		composeRecordFieldJoin(m *types.Module, f *types.ModuleField) string
+		composeRecordFieldExpr(m *types.Module, f *types.ModuleField) (string, error)
 	}
 )
 
@@ -132,8 +134,6 @@ func (b *recordReportBuilder) stdFilterFuncHandler(f ql.Function) (ql.ASTNode, e
 
 func (b *recordReportBuilder) Build() (sb squirrel.SelectBuilder, err error) {
 	var (
-		joinTpl = "compose_record_value AS rv_%s ON (rv_%s.record_id = crd.id AND rv_%s.name = '%s' AND rv_%s.deleted_at IS NULL)"
-
 		report = b.store.SelectBuilder("compose_record AS crd").
 			Column(squirrel.Alias(squirrel.Expr("COUNT(*)"), "count")).
 			Where("crd.deleted_at IS NULL").
@@ -167,11 +167,25 @@ func (b *recordReportBuilder) Build() (sb squirrel.SelectBuilder, err error) {
 			return i, fmt.Errorf("invalid field name: %q", i.Value)
 		}
 
-		if !alreadyJoined(i.Value) {
-			report = report.LeftJoin(strings.ReplaceAll(joinTpl, "%s", i.Value))
+		var (
+			f    = b.module.Fields.FindByName(i.Value)
+			join = b.store.composeRecordFieldJoin(b.module, f)
+		)
+
+		if f.IsEncrypted() {
+			return i, fmt.Errorf("encrypted field %q can not be used in reports", f.Name)
+		}
+
+		if !alreadyJoined(join) {
+			report = report.LeftJoin(join)
+		}
+
+		if isComposeRecordColumn(b.module, f) {
+			expr, err := b.store.composeRecordFieldExpr(b.module, f)
+			return ql.Ident{Value: expr}, err
 		}
 
-		return b.store.FieldToColumnTypeCaster(b.module.Fields.FindByName(i.Value), i)
+		return b.store.FieldToColumnTypeCaster(f, i)
 	}
 
 	var columns ql.Columns
@@ -0,0 +1,653 @@
+package rdbms
+
+// Dedicated compose record storage
+//
+// Modules with dedicated storage keep values of their single-value fields in
+// a table of their own (compose_record_<moduleID>), one typed column per field.
+// Record itself (compose_record) and values of multi-value and encrypted fields
+// (compose_record_value) are stored the same way as for any other module.
+//
+// Table is joined 1:1 to the record table when filtering or sorting by the
+// field values, instead of joining the record value table once per field.
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/store/rdbms/ddl"
+)
+
+const (
+	composeRecordDedicatedAlias = "crx"
+
+	// number of records processed at once when
+	// values are moved between the storages
+	composeRecordTransferBatch = 500
+)
+
+var (
+	composeRecordColumnReplacer = strings.NewReplacer("-", "_", ".", "_")
+)
+
+// composeRecordDedicatedTable returns name of the module's record table
+func composeRecordDedicatedTable(m *types.Module) string {
+	return fmt.Sprintf("compose_record_%d", m.ID)
+}
+
+// composeRecordColumn returns name of the field's column in the dedicated table
+//
+// Field names are handles; characters that can not be used
+// in unquoted identifiers are replaced
+func composeRecordColumn(f *types.ModuleField) string {
+	return "f_" + strings.ToLower(composeRecordColumnReplacer.Replace(f.Name))
+}
+
+// isComposeRecordColumn checks if values of the field are stored in the dedicated table
+//
+// Encrypted values are kept in the record value table with their blind indexes
+func isComposeRecordColumn(m *types.Module, f *types.ModuleField) bool {
+	return m != nil && m.HasDedicatedStorage() && f != nil && !f.Multi && !f.IsEncrypted() && f.DeletedAt == nil
+}
+
+// composeRecordColumnFields returns all fields with values stored in the dedicated table
+func composeRecordColumnFields(m *types.Module) (ff types.ModuleFieldSet) {
+	for _, f := range m.Fields {
+		if isComposeRecordColumn(m, f) {
+			ff = append(ff, f)
+		}
+	}
+
+	return
+}
+
+// composeRecordColumnDef returns column definition for the field
+//
+// DateTime values are kept in the internal (ISO 8601) format
+// that sorts and compares correctly as text
+func composeRecordColumnDef(f *types.ModuleField) *ddl.Column {
+	var col = &ddl.Column{
+		Name:   composeRecordColumn(f),
+		Type:   ddl.ColumnType{Type: ddl.ColumnTypeText, Length: -1},
+		IsNull: true,
+	}
+
+	switch {
+	case f.IsRef():
+		col.Type.Type = ddl.ColumnTypeIdentifier
+	case f.IsBoolean():
+		col.Type.Type = ddl.ColumnTypeBoolean
+	case f.IsNumeric():
+		col.Type.Type = ddl.ColumnTypeNumeric
+	}
+
+	return col
+}
+
+// composeRecordStorageTable returns definition of the module's dedicated table
+func composeRecordStorageTable(m *types.Module) (*ddl.Table, error) {
+	var (
+		t = ddl.TableDef(
+			composeRecordDedicatedTable(m),
+			ddl.ColumnDef("record_id", ddl.ColumnTypeIdentifier),
+			ddl.PrimaryKey(ddl.IColumn("record_id")),
+		)
+
+		used = map[string]string{}
+	)
+
+	for _, f := range composeRecordColumnFields(m) {
+		col := composeRecordColumnDef(f)
+
+		if name, has := used[col.Name]; has {
+			return nil, fmt.Errorf("fields %q and %q can not be stored in the same column", name, f.Name)
+		}
+
+		used[col.Name] = f.Name
+		t.Columns = append(t.Columns, col)
+	}
+
+	return t, nil
+}
+
+// encodeComposeRecordColumnValue converts record value to the column value
+//
+// Missing and empty values are stored as NULL
+func encodeComposeRecordColumnValue(f *types.ModuleField, v *types.RecordValue) interface{} {
+	if v == nil || (v.Value == "" && v.Ref == 0) {
+		return nil
+	}
+
+	switch {
+	case f.IsRef():
+		if v.Ref > 0 {
+			return v.Ref
+		}
+
+		if ref, _ := strconv.ParseUint(v.Value, 10, 64); ref > 0 {
+			return ref
+		}
+
+		return nil
+
+	case f.IsBoolean():
+		b, _ := strconv.ParseBool(v.Value)
+		return b
+
+	case f.IsNumeric():
+		if _, err := strconv.ParseFloat(v.Value, 64); err != nil {
+			return nil
+		}
+
+		return v.Value
+
+	default:
+		return v.Value
+	}
+}
+
+// decodeComposeRecordColumnValue converts the column value to the record value
+//
+// Different drivers return different types for the same column type;
+// values are converted to the same format that is used in the record value table
+func decodeComposeRecordColumnValue(f *types.ModuleField, raw interface{}) *types.RecordValue {
+	var (
+		v = &types.RecordValue{Name: f.Name}
+	)
+
+	switch c := raw.(type) {
+	case nil:
+		return nil
+	case []byte:
+		v.Value = string(c)
+	case string:
+		v.Value = c
+	case bool:
+		v.Value = strconv.FormatBool(c)
+	case int64:
+		v.Value = strconv.FormatInt(c, 10)
+	case uint64:
+		v.Value = strconv.FormatUint(c, 10)
+	case float64:
+		v.Value = strconv.FormatFloat(c, 'f', -1, 64)
+	case time.Time:
+		v.Value = c.UTC().Format(time.RFC3339)
+	default:
+		v.Value = fmt.Sprintf("%v", c)
+	}
+
+	switch {
+	case f.IsRef():
+		v.Ref, _ = strconv.ParseUint(v.Value, 10, 64)
+
+	case f.IsBoolean():
+		if b, _ := strconv.ParseBool(v.Value); b {
+			v.Value = "1"
+		} else {
+			v.Value = "0"
+		}
+
+	case f.IsNumeric():
+		// some drivers return decimals with trailing zeros
+		if strings.Contains(v.Value, ".") {
+			v.Value = strings.TrimRight(strings.TrimRight(v.Value, "0"), ".")
+		}
+	}
+
+	return v
+}
+
+// composeRecordColumnsPayload encodes record values for the dedicated table
+func composeRecordColumnsPayload(m *types.Module, vv types.RecordValueSet) store.Payload {
+	var (
+		p = store.Payload{}
+	)
+
+	for _, f := range composeRecordColumnFields(m) {
+		p[composeRecordColumn(f)] = encodeComposeRecordColumnValue(f, vv.Get(f.Name, 0))
+	}
+
+	return p
+}
+
+// composeRecordValuesOutsideColumns returns values that are
+// not stored in the dedicated table
+func composeRecordValuesOutsideColumns(m *types.Module, vv types.RecordValueSet) (out types.RecordValueSet) {
+	if !m.HasDedicatedStorage() {
+		return vv
+	}
+
+	for _, v := range vv {
+		if !isComposeRecordColumn(m, m.Fields.FindByName(v.Name)) {
+			out = append(out, v)
+		}
+	}
+
+	return
+}
+
+// composeRecordFieldJoin returns the join that makes field value available to the query
+func (s Store) composeRecordFieldJoin(m *types.Module, f *types.ModuleField) string {
+	if isComposeRecordColumn(m, f) {
+		return fmt.Sprintf("%s AS %s ON (%[2]s.record_id = crd.id)", composeRecordDedicatedTable(m), composeRecordDedicatedAlias)
+	}
+
+	join := composeRecordValueJoinTpl
+	join = strings.ReplaceAll(join, "{alias}", composeRecordValueAliasPfx+f.Name)
+	join = strings.ReplaceAll(join, "{field}", f.Name)
+	return join
+}
+
+// composeRecordFieldExpr returns the field value expression
+//
+// Field must be joined with composeRecordFieldJoin; encrypted values
+// can not be ordered or aggregated and have no expression
+func (s Store) composeRecordFieldExpr(m *types.Module, f *types.ModuleField) (string, error) {
+	if f.IsEncrypted() {
+		return "", fmt.Errorf("can not sort by encrypted field %q", f.Name)
+	}
+
+	if !isComposeRecordColumn(m, f) {
+		return s.config.CastModuleFieldToColumnType(f, f.Name)
+	}
+
+	col := composeRecordDedicatedAlias + "." + composeRecordColumn(f)
+
+	if f.IsBoolean() {
+		// same as the type casters for the record value table do
+		return fmt.Sprintf("(CASE WHEN %s THEN 1 ELSE 0 END)", col), nil
+	}
+
+	return col, nil
+}
+
+// createComposeRecordColumns inserts record's row into the dedicated table
+func (s Store) createComposeRecordColumns(ctx context.Context, m *types.Module, r *types.Record) error {
+	p := composeRecordColumnsPayload(m, r.Values.GetClean())
+	p["record_id"] = r.ID
+
+	return s.Exec(ctx, s.InsertBuilder(composeRecordDedicatedTable(m)).SetMap(p))
+}
+
+// updateComposeRecordColumns updates record's row in the dedicated table
+func (s Store) updateComposeRecordColumns(ctx context.Context, m *types.Module, id uint64, p store.Payload) error {
+	if len(p) == 0 {
+		return nil
+	}
+
+	return s.Exec(ctx, s.UpdateBuilder(composeRecordDedicatedTable(m)).Where(squirrel.Eq{"record_id": id}).SetMap(p))
+}
+
+// loadComposeRecordColumns loads values from the dedicated table
+// and prepends them to the record values
+func (s Store) loadComposeRecordColumns(ctx context.Context, m *types.Module, set ...*types.Record) error {
+	var (
+		ff   = composeRecordColumnFields(m)
+		cols = []string{"record_id"}
+
+		index = make(map[uint64]*types.Record, len(set))
+		ids   = make([]uint64, 0, len(set))
+	)
+
+	for _, r := range set {
+		// lookups can be made with module other than the record's
+		if r.ModuleID == m.ID {
+			index[r.ID] = r
+			ids = append(ids, r.ID)
+		}
+	}
+
+	if len(ff) == 0 || len(ids) == 0 {
+		return nil
+	}
+
+	for _, f := range ff {
+		cols = append(cols, composeRecordColumn(f))
+	}
+
+	rows, err := s.Query(ctx, s.SelectBuilder(composeRecordDedicatedTable(m), cols...).Where(squirrel.Eq{"record_id": ids}))
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id  uint64
+			raw = make([]interface{}, len(ff))
+			dst = make([]interface{}, len(ff)+1)
+		)
+
+		dst[0] = &id
+		for i := range raw {
+			dst[i+1] = &raw[i]
+		}
+
+		if err = rows.Scan(dst...); err != nil {
+			return err
+		}
+
+		r := index[id]
+		if r == nil {
+			continue
+		}
+
+		vv := make(types.RecordValueSet, 0, len(ff)+len(r.Values))
+		for i, f := range ff {
+			if v := decodeComposeRecordColumnValue(f, raw[i]); v != nil {
+				v.RecordID = r.ID
+				v.DeletedAt = r.DeletedAt
+				vv = append(vv, v)
+			}
+		}
+
+		r.Values = append(vv, r.Values...)
+	}
+
+	return rows.Err()
+}
+
+// schemaUpgrader returns implementation specific upgrader
+// that works on the same connection (or transaction) as the store
+func (s Store) schemaUpgrader(ctx context.Context) (SchemaUpgrader, error) {
+	if s.config.SchemaUpgrader == nil {
+		return nil, fmt.Errorf("store does not support schema changes")
+	}
+
+	return s.config.SchemaUpgrader(s.log(ctx), &s), nil
+}
+
+// UpgradeComposeRecordStorage brings module's dedicated record table in line with the module fields
+//
+// Table is created when missing. Columns are added, renamed and removed according to the changes of the
+// fields (compared to the old module). Values of the fields that switch between single and multiple values
+// are moved between the dedicated table and the record value table.
+//
+// Changing the kind of the field replaces its column; values of the field are not preserved
+func (s Store) UpgradeComposeRecordStorage(ctx context.Context, old, m *types.Module) (err error) {
+	if m == nil || !m.HasDedicatedStorage() {
+		return nil
+	}
+
+	var (
+		u SchemaUpgrader
+		t *ddl.Table
+
+		// fields that are moved from the record value table to the columns and vice versa
+		toColumns, toValues types.ModuleFieldSet
+
+		oldColumnFields types.ModuleFieldSet
+	)
+
+	if u, err = s.schemaUpgrader(ctx); err != nil {
+		return
+	}
+
+	if t, err = composeRecordStorageTable(m); err != nil {
+		return
+	}
+
+	if old == nil || !old.HasDedicatedStorage() {
+		if err = u.CreateTable(ctx, t); err != nil {
+			return
+		}
+
+		// in case the table was already there
+		for _, col := range t.Columns[1:] {
+			if _, err = u.AddColumn(ctx, t.Name, col); err != nil {
+				return
+			}
+		}
+
+		return nil
+	}
+
+	oldColumnFields = composeRecordColumnFields(old)
+
+	// Remove columns of the removed fields and of the
+	// fields with a different column type
+	for _, of := range oldColumnFields {
+		nf := findComposeRecordField(m.Fields, of)
+
+		switch {
+		case nf != nil && nf.DeletedAt == nil && !isComposeRecordColumn(m, nf):
+			// column is removed after values are moved to the record value table
+			toValues = append(toValues, of)
+			continue
+
+		case nf != nil && isComposeRecordColumn(m, nf) && composeRecordColumnDef(of).Type.Type == composeRecordColumnDef(nf).Type.Type:
+			continue
+		}
+
+		if _, err = u.DropColumn(ctx, t.Name, composeRecordColumn(of)); err != nil {
+			return
+		}
+	}
+
+	for _, nf := range composeRecordColumnFields(m) {
+		of := findComposeRecordField(oldColumnFields, nf)
+
+		if of != nil && composeRecordColumnDef(of).Type.Type == composeRecordColumnDef(nf).Type.Type {
+			if _, err = u.RenameColumn(ctx, t.Name, composeRecordColumn(of), composeRecordColumn(nf)); err != nil {
+				return
+			}
+
+			continue
+		}
+
+		if _, err = u.AddColumn(ctx, t.Name, composeRecordColumnDef(nf)); err != nil {
+			return
+		}
+
+		if of = findComposeRecordField(old.Fields, nf); of != nil && of.DeletedAt == nil && !isComposeRecordColumn(old, of) {
+			toColumns = append(toColumns, nf)
+		}
+	}
+
+	if len(toColumns) > 0 {
+		if err = s.transferComposeRecordValues(ctx, old, m, toColumns); err != nil {
+			return
+		}
+	}
+
+	if len(toValues) > 0 {
+		if err = s.transferComposeRecordValues(ctx, old, m, toValues); err != nil {
+			return
+		}
+
+		for _, f := range toValues {
+			if _, err = u.DropColumn(ctx, t.Name, composeRecordColumn(f)); err != nil {
+				return
+			}
+		}
+	}
+
+	return nil
+}
+
+// MigrateComposeRecordStorage moves records of the module to the given storage
+//
+// Module is not updated; caller is expected to store the new storage setting
+func (s Store) MigrateComposeRecordStorage(ctx context.Context, m *types.Module, storage string) (err error) {
+	if m.Storage == storage {
+		return nil
+	}
+
+	var (
+		u  SchemaUpgrader
+		t  *ddl.Table
+		to = m.Clone()
+	)
+
+	to.Storage = storage
+
+	if u, err = s.schemaUpgrader(ctx); err != nil {
+		return
+	}
+
+	switch storage {
+	case types.ModuleStorageDedicated:
+		if t, err = composeRecordStorageTable(to); err != nil {
+			return
+		}
+
+		if err = u.CreateTable(ctx, t); err != nil {
+			return
+		}
+
+		// remove leftovers of any previous (failed) migration
+		if err = s.Truncate(ctx, t.Name); err != nil {
+			return
+		}
+
+		ins := s.InsertBuilder(t.Name).
+			Columns("record_id").
+			Select(s.SelectBuilder(s.composeRecordTable(), "id").Where(squirrel.Eq{"module_id": m.ID}))
+
+		if err = s.Exec(ctx, ins); err != nil {
+			return
+		}
+
+		return s.transferComposeRecordValues(ctx, m, to, composeRecordColumnFields(to))
+
+	case types.ModuleStorageDefault:
+		if err = s.transferComposeRecordValues(ctx, m, to, composeRecordColumnFields(m)); err != nil {
+			return
+		}
+
+		_, err = u.DropTable(ctx, composeRecordDedicatedTable(m))
+		return
+
+	default:
+		return fmt.Errorf("unknown module storage %q", storage)
+	}
+}
+
+// transferComposeRecordValues moves values of the given fields for all module records
+//
+// Values are loaded with the storage layout of the "from" module and
+// written with the layout of the "to" module. Moved values are removed from the
+// record value table; removing the columns is left to the caller.
+func (s Store) transferComposeRecordValues(ctx context.Context, from, to *types.Module, ff types.ModuleFieldSet) error {
+	var (
+		lastID uint64
+
+		// module used for loading; only values of the
+		// transferred fields are needed
+		load = from.Clone()
+
+		// pairs of fields as they are in both modules
+		pairs = make([][2]*types.ModuleField, 0, len(ff))
+
+		// values that are removed from the record value table
+		moved []string
+	)
+
+	load.Fields = nil
+	for _, f := range ff {
+		var (
+			src = findComposeRecordField(from.Fields, f)
+			dst = findComposeRecordField(to.Fields, f)
+		)
+
+		if src == nil || dst == nil {
+			continue
+		}
+
+		load.Fields = append(load.Fields, src)
+		pairs = append(pairs, [2]*types.ModuleField{src, dst})
+
+		if !isComposeRecordColumn(from, src) && isComposeRecordColumn(to, dst) {
+			moved = append(moved, src.Name)
+		}
+	}
+
+	for {
+		q := s.composeRecordsSelectBuilder().
+			Where(squirrel.Eq{"crd.module_id": from.ID}).
+			Where(squirrel.Gt{"crd.id": lastID}).
+			OrderBy("crd.id").
+			Limit(composeRecordTransferBatch)
+
+		set, err := s.QueryComposeRecords(ctx, load, q, nil)
+		if err != nil {
+			return err
+		}
+
+		if len(set) == 0 {
+			return nil
+		}
+
+		for _, r := range set {
+			var (
+				cols = store.Payload{}
+				vv   types.RecordValueSet
+			)
+
+			for _, p := range pairs {
+				if isComposeRecordColumn(to, p[1]) {
+					cols[composeRecordColumn(p[1])] = encodeComposeRecordColumnValue(p[1], r.Values.Get(p[0].Name, 0))
+					continue
+				}
+
+				if !isComposeRecordColumn(from, p[0]) {
+					// values in the record value table are already where they should be
+					continue
+				}
+
+				for _, v := range r.Values.FilterByName(p[0].Name) {
+					v = v.Clone()
+					v.Name = p[1].Name
+					v.RecordID = r.ID
+					v.DeletedAt = r.DeletedAt
+					vv = append(vv, v)
+				}
+			}
+
+			if err = s.updateComposeRecordColumns(ctx, to, r.ID, cols); err != nil {
+				return err
+			}
+
+			if len(vv) > 0 {
+				if err = s.createComposeRecordValue(ctx, nil, vv...); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(moved) > 0 {
+			err = s.execDeleteComposeRecordValues(ctx, squirrel.Eq{
+				"record_id": types.RecordSet(set).IDs(),
+				"name":      moved,
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(set) < composeRecordTransferBatch {
+			return nil
+		}
+
+		lastID = set[len(set)-1].ID
+	}
+}
+
+// findComposeRecordField finds the field in the set by ID and falls back to the name
+//
+// Fields keep their IDs when renamed
+func findComposeRecordField(set types.ModuleFieldSet, f *types.ModuleField) *types.ModuleField {
+	if f.ID > 0 {
+		if e := set.FindByID(f.ID); e != nil {
+			return e
+		}
+	}
+
+	return set.FindByName(f.Name)
+}
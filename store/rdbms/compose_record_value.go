@@ -7,6 +7,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/store"
 )
 
 func (s Store) convertComposeRecordValueFilter(_ *types.Module, f types.RecordValueFilter) (query squirrel.SelectBuilder, err error) {
@@ -18,6 +19,10 @@ func (s Store) convertComposeRecordValueFilter(_ *types.Module, f types.RecordVa
 }
 
 func (s Store) ComposeRecordValueRefLookup(ctx context.Context, m *types.Module, field string, ref uint64) (uint64, error) {
+	if f := m.Fields.FindByName(field); isComposeRecordColumn(m, f) {
+		return s.composeRecordColumnRefLookup(ctx, m, f, ref)
+	}
+
 	q := s.composeRecordValuesSelectBuilder().
 		Join(s.composeRecordTable("crd"), "crv.record_id = crd.id").
 		Where(squirrel.Eq{
@@ -45,11 +50,67 @@ func (s Store) ComposeRecordValueRefLookup(ctx context.Context, m *types.Module,
 	return recordID, nil
 }
 
+// composeRecordColumnRefLookup looks up the reference in the dedicated record table
+func (s Store) composeRecordColumnRefLookup(ctx context.Context, m *types.Module, f *types.ModuleField, ref uint64) (uint64, error) {
+	q := s.SelectBuilder(composeRecordDedicatedTable(m)+" AS "+composeRecordDedicatedAlias, composeRecordDedicatedAlias+".record_id").
+		Join(s.composeRecordTable("crd") + " ON (crd.id = " + composeRecordDedicatedAlias + ".record_id)").
+		Where(squirrel.Eq{
+			composeRecordDedicatedAlias + "." + composeRecordColumn(f): ref,
+			"crd.deleted_at": nil,
+		}).
+		Limit(1)
+
+	row, err := s.QueryRow(ctx, q)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	var recordID uint64
+	if err = row.Scan(&recordID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return recordID, nil
+}
+
+// RenameComposeRecordValues renames values of all module records
+//
+// Values in the dedicated record table are renamed with the column
+func (s Store) RenameComposeRecordValues(ctx context.Context, m *types.Module, oldName, newName string) error {
+	return s.execUpdateComposeRecordValues(
+		ctx,
+		squirrel.And{
+			squirrel.Eq{"name": oldName},
+			squirrel.Expr("record_id IN (SELECT id FROM compose_record WHERE module_id = ?)", m.ID),
+		},
+		store.Payload{"name": newName},
+	)
+}
+
 // PartialComposeRecordValueUpdate updates specific record values across multiple records
 func (s Store) PartialComposeRecordValueUpdate(ctx context.Context, m *types.Module, vv ...*types.RecordValue) (err error) {
 	{
-		// handle standard record-value storage
 		for _, v := range vv {
+			// handle dedicated record storage
+			//
+			// Columns hold values of deleted records too;
+			// value is removed from the column by clearing it
+			if f := m.Fields.FindByName(v.Name); isComposeRecordColumn(m, f) {
+				p := store.Payload{composeRecordColumn(f): encodeComposeRecordColumnValue(f, v)}
+				if err = s.updateComposeRecordColumns(ctx, m, v.RecordID, p); err != nil {
+					return
+				}
+
+				continue
+			}
+
+			// handle standard record-value storage
 			if err = s.execUpsertComposeRecordValues(ctx, s.internalComposeRecordValueEncoder(v)); err != nil {
 				return
 			}
@@ -235,6 +235,7 @@ func (s Store) internalComposeRecordValueRowScanner(_mod *types.Module, row rowS
 			&res.Place,
 			&res.Value,
 			&res.Ref,
+			&res.BlindIndex,
 			&res.DeletedAt,
 		)
 	}
@@ -280,6 +281,7 @@ func (Store) composeRecordValueColumns(aa ...string) []string {
 		alias + "place",
 		alias + "value",
 		alias + "ref",
+		alias + "blind_index",
 		alias + "deleted_at",
 	}
 }
@@ -292,12 +294,13 @@ func (Store) composeRecordValueColumns(aa ...string) []string {
 // func when rdbms.customEncoder=true
 func (s Store) internalComposeRecordValueEncoder(res *types.RecordValue) store.Payload {
 	return store.Payload{
-		"record_id":  res.RecordID,
-		"name":       res.Name,
-		"place":      res.Place,
-		"value":      res.Value,
-		"ref":        res.Ref,
-		"deleted_at": res.DeletedAt,
+		"record_id":   res.RecordID,
+		"name":        res.Name,
+		"place":       res.Place,
+		"value":       res.Value,
+		"ref":         res.Ref,
+		"blind_index": res.BlindIndex,
+		"deleted_at":  res.DeletedAt,
 	}
 }
 
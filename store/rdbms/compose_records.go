@@ -18,7 +18,7 @@ const (
 	composeRecordValueJoinTpl  = "compose_record_value AS {alias} ON ({alias}.record_id = crd.id AND {alias}.name = '{field}' AND {alias}.deleted_at IS NULL)"
 )
 
-func buildComposeRecordsCursor(cfg *Config, m *types.Module) func(cur *filter.PagingCursor) squirrel.Sqlizer {
+func buildComposeRecordsCursor(s Store, m *types.Module) func(cur *filter.PagingCursor) squirrel.Sqlizer {
 	return func(cur *filter.PagingCursor) squirrel.Sqlizer {
 		return builders.CursorCondition(cur, func(key string) (string, error) {
 			if col, is := isRealRecordCol(key); is {
@@ -30,15 +30,13 @@ func buildComposeRecordsCursor(cfg *Config, m *types.Module) func(cur *filter.Pa
 				return "", fmt.Errorf("unknown module field %q used in a cursor", key)
 			}
 
-			return cfg.CastModuleFieldToColumnType(f, f.Name)
+			return s.composeRecordFieldExpr(m, f)
 		})
 	}
 }
 
 // @todo support for partitioned records (records are partitioned into multiple record tables
 //       in this case, values are no longer separated into record_value (key-value) table but encoded as JSON
-// @todo support for partitioned record with (optional) physical columns for record values
-//       physical columns are part of module-field configuration
 
 // SearchComposeRecords returns all matching ComposeRecords from store
 func (s Store) SearchComposeRecords(ctx context.Context, m *types.Module, f types.RecordFilter) (types.RecordSet, types.RecordFilter, error) {
@@ -97,7 +95,7 @@ func (s Store) SearchComposeRecords(ctx context.Context, m *types.Module, f type
 			ctx, m, q,
 			f.Sort, f.PageCursor, f.Limit,
 			f.Check,
-			buildComposeRecordsCursor(s.config, m),
+			buildComposeRecordsCursor(s, m),
 		)
 
 		if err != nil {
@@ -113,7 +111,7 @@ func (s Store) SearchComposeRecords(ctx context.Context, m *types.Module, f type
 			if f.Limit > 0 && uint(len(set)) == f.Limit {
 				// Build page navigation ONLY when limit is set and
 				// there are less items fetched then requested limit
-				if nav, err := s.composeRecordsPageNavigation(ctx, m, q, f, f.Sort, buildComposeRecordsCursor(s.config, m)); err != nil {
+				if nav, err := s.composeRecordsPageNavigation(ctx, m, q, f, f.Sort, buildComposeRecordsCursor(s, m)); err != nil {
 					return err
 				} else {
 					f.Total = nav.Total
@@ -259,8 +257,8 @@ func (s Store) composeRecordsPageNavigation(
 
 // LookupComposeRecordByID searches for compose record by ID
 // It returns compose record even if deleted
-func (s Store) LookupComposeRecordByID(ctx context.Context, _ *types.Module, id uint64) (res *types.Record, err error) {
-	res, err = s.lookupComposeRecordByID(ctx, nil, id)
+func (s Store) LookupComposeRecordByID(ctx context.Context, m *types.Module, id uint64) (res *types.Record, err error) {
+	res, err = s.lookupComposeRecordByID(ctx, m, id)
 	if err != nil {
 		return
 	}
@@ -284,7 +282,13 @@ func (s Store) CreateComposeRecord(ctx context.Context, m *types.Module, rr ...*
 		// Make sure all record-values are linked to the record
 		res.Values.SetRecordID(res.ID)
 
-		err = s.createComposeRecordValue(ctx, nil, res.Values...)
+		if m.HasDedicatedStorage() {
+			if err = s.createComposeRecordColumns(ctx, m, res); err != nil {
+				return
+			}
+		}
+
+		err = s.createComposeRecordValue(ctx, nil, composeRecordValuesOutsideColumns(m, res.Values)...)
 		if err != nil {
 			return
 		}
@@ -324,7 +328,17 @@ func (s Store) UpdateComposeRecord(ctx context.Context, m *types.Module, rr ...*
 			// Make sure all record-values are linked to the record
 			res.Values.SetRecordID(res.ID)
 
-			err = s.createComposeRecordValue(ctx, nil, res.Values.GetClean()...)
+			err = s.createComposeRecordValue(ctx, nil, composeRecordValuesOutsideColumns(m, res.Values.GetClean())...)
+			if err != nil {
+				return
+			}
+
+			if m.HasDedicatedStorage() {
+				err = s.updateComposeRecordColumns(ctx, m, res.ID, composeRecordColumnsPayload(m, res.Values.GetClean()))
+				if err != nil {
+					return
+				}
+			}
 		}
 	}
 
@@ -373,7 +387,7 @@ func (s Store) UpsertComposeRecord(ctx context.Context, m *types.Module, rr ...*
 }
 
 // DeleteComposeRecordByID Deletes ComposeRecord from store
-func (s Store) DeleteComposeRecordByID(ctx context.Context, _ *types.Module, ID uint64) (err error) {
+func (s Store) DeleteComposeRecordByID(ctx context.Context, m *types.Module, ID uint64) (err error) {
 	err = s.deleteComposeRecordByID(ctx, nil, ID)
 	if err != nil {
 		return
@@ -384,11 +398,18 @@ func (s Store) DeleteComposeRecordByID(ctx context.Context, _ *types.Module, ID
 		return
 	}
 
+	if m != nil && m.HasDedicatedStorage() {
+		err = s.Exec(ctx, s.DeleteBuilder(composeRecordDedicatedTable(m)).Where(squirrel.Eq{"record_id": ID}))
+		if err != nil {
+			return
+		}
+	}
+
 	return
 }
 
 // TruncateComposeRecords Deletes all ComposeRecords from store
-func (s Store) TruncateComposeRecords(ctx context.Context, _ *types.Module) (err error) {
+func (s Store) TruncateComposeRecords(ctx context.Context, m *types.Module) (err error) {
 	err = s.truncateComposeRecords(ctx, nil)
 	if err != nil {
 		return
@@ -399,6 +420,13 @@ func (s Store) TruncateComposeRecords(ctx context.Context, _ *types.Module) (err
 		return
 	}
 
+	if m != nil && m.HasDedicatedStorage() {
+		err = s.Truncate(ctx, composeRecordDedicatedTable(m))
+		if err != nil {
+			return
+		}
+	}
+
 	return
 }
 
@@ -429,6 +457,10 @@ func (s Store) convertComposeRecordFilter(m *types.Module, f types.RecordFilter)
 	}
 
 	var (
+		// blind index columns of the encrypted fields used in the query
+		blindIndexCols = map[string]bool{}
+		blindIndexes   = f.BlindIndexes
+
 		joinedFields  = []string{}
 		alreadyJoined = func(f string) bool {
 			for _, a := range joinedFields {
@@ -452,14 +484,32 @@ func (s Store) convertComposeRecordFilter(m *types.Module, f types.RecordFilter)
 				return i, fmt.Errorf("unknown field %q", i.Value)
 			}
 
-			if !alreadyJoined(i.Value) {
-				join := composeRecordValueJoinTpl
-				join = strings.ReplaceAll(join, "{alias}", composeRecordValueAliasPfx+i.Value)
-				join = strings.ReplaceAll(join, "{field}", i.Value)
+			var (
+				f    = m.Fields.FindByName(i.Value)
+				join = s.composeRecordFieldJoin(m, f)
+			)
+
+			// all fields with dedicated columns share the same join
+			if !alreadyJoined(join) {
 				query = query.LeftJoin(join)
 			}
 
-			return s.FieldToColumnTypeCaster(m.Fields.FindByName(i.Value), i)
+			if f.IsEncrypted() {
+				if blindIndexes == nil {
+					return i, fmt.Errorf("encrypted field %q can not be used in the filter", f.Name)
+				}
+
+				col := composeRecordValueAliasPfx + f.Name + ".blind_index"
+				blindIndexCols[col] = true
+				return ql.Ident{Value: col}, nil
+			}
+
+			if isComposeRecordColumn(m, f) {
+				expr, err := s.composeRecordFieldExpr(m, f)
+				return ql.Ident{Value: expr}, err
+			}
+
+			return s.FieldToColumnTypeCaster(f, i)
 		}
 	)
 
@@ -491,6 +541,8 @@ func (s Store) convertComposeRecordFilter(m *types.Module, f types.RecordFilter)
 
 		if fn, err = fp.ParseExpression(f.Query); err != nil {
 			return
+		} else if fn, err = composeRecordBlindIndexFilter(fn, blindIndexCols, blindIndexes); err != nil {
+			return
 		} else if filterSql, filterArgs, err := fn.ToSql(); err != nil {
 			return query, err
 		} else {
@@ -531,6 +583,139 @@ func (s Store) convertComposeRecordFilter(m *types.Module, f types.RecordFilter)
 	return
 }
 
+// composeRecordBlindIndexFilter replaces values that encrypted fields are compared to with their blind indexes
+//
+// Encrypted fields can only be matched exactly (=, !=, <>) against
+// strings and numbers or checked for NULL (IS, IS NOT)
+func composeRecordBlindIndexFilter(n ql.ASTNode, cols map[string]bool, blindIndexes func(string) []string) (ql.ASTNode, error) {
+	var (
+		err error
+
+		isCol = func(n ql.ASTNode) bool {
+			i, ok := n.(ql.Ident)
+			return ok && cols[i.Value]
+		}
+
+		isCmp = func(n ql.ASTNode) bool {
+			o, ok := n.(ql.Operator)
+			if !ok {
+				return false
+			}
+
+			switch strings.ToUpper(o.Kind) {
+			case "=", "!=", "<>", "IS", "IS NOT":
+				return true
+			}
+
+			return false
+		}
+	)
+
+	if len(cols) == 0 {
+		return n, nil
+	}
+
+	switch n := n.(type) {
+	case ql.Ident:
+		if cols[n.Value] {
+			return nil, fmt.Errorf("encrypted fields can only be compared to values")
+		}
+
+	case ql.Function:
+		for a := range n.Arguments {
+			if n.Arguments[a], err = composeRecordBlindIndexFilter(n.Arguments[a], cols, blindIndexes); err != nil {
+				return nil, err
+			}
+		}
+
+		return n, nil
+
+	case ql.ASTNodes:
+		out := make(ql.ASTNodes, 0, len(n))
+
+		for i := 0; i < len(n); i++ {
+			var (
+				col, val ql.ASTNode
+			)
+
+			switch {
+			case isCol(n[i]) && i+2 < len(n):
+				col, val = n[i], n[i+2]
+			case i+2 < len(n) && isCmp(n[i+1]) && isCol(n[i+2]):
+				col, val = n[i+2], n[i]
+			case isCol(n[i]):
+				return nil, fmt.Errorf("encrypted fields can only be compared to values")
+			default:
+				sub, err := composeRecordBlindIndexFilter(n[i], cols, blindIndexes)
+				if err != nil {
+					return nil, err
+				}
+
+				out = append(out, sub)
+				continue
+			}
+
+			cmp, err := composeRecordBlindIndexCompare(col, n[i+1], val, blindIndexes)
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, cmp)
+			i += 2
+		}
+
+		return out, nil
+	}
+
+	return n, nil
+}
+
+// composeRecordBlindIndexCompare compares blind index column with all blind indexes of the value
+func composeRecordBlindIndexCompare(col, op, val ql.ASTNode, blindIndexes func(string) []string) (ql.ASTNode, error) {
+	var (
+		raw string
+
+		join string
+
+		o, _ = op.(ql.Operator)
+	)
+
+	switch v := val.(type) {
+	case ql.LNull:
+		if kind := strings.ToUpper(o.Kind); kind == "IS" || kind == "IS NOT" {
+			return ql.ASTNodes{col, op, val}, nil
+		}
+
+		return nil, fmt.Errorf("encrypted fields can only be checked for NULL with IS or IS NOT")
+	case ql.LString:
+		raw = v.Value
+	case ql.LNumber:
+		raw = v.Value
+	default:
+		return nil, fmt.Errorf("encrypted fields can only be compared to strings and numbers")
+	}
+
+	switch o.Kind {
+	case "=":
+		join = "OR"
+	case "!=", "<>":
+		join = "AND"
+	default:
+		return nil, fmt.Errorf("encrypted fields can only be matched exactly")
+	}
+
+	out := ql.ASTNodes{}
+	for i, bidx := range blindIndexes(raw) {
+		if i > 0 {
+			out = append(out, ql.Operator{Kind: join})
+		}
+
+		out = append(out, col, o, ql.LString{Value: bidx})
+	}
+
+	return out, nil
+}
+
 func (s Store) convertComposeRecordCursor(m *types.Module, from *filter.PagingCursor) (to *filter.PagingCursor) {
 	if from != nil {
 		to = &filter.PagingCursor{ROrder: from.ROrder, LThen: from.LThen}
@@ -539,7 +724,7 @@ func (s Store) convertComposeRecordCursor(m *types.Module, from *filter.PagingCu
 			if col, has := s.sortableComposeRecordColumns()[strings.ToLower(key)]; has {
 				key = col
 			} else if f := m.Fields.FindByName(key); f != nil {
-				key, _ = s.config.CastModuleFieldToColumnType(f, key)
+				key, _ = s.composeRecordFieldExpr(m, f)
 			} else {
 				return
 			}
@@ -569,9 +754,13 @@ func (s Store) composeRecordPostLoadProcessor(ctx context.Context, m *types.Modu
 		for r := range set {
 			set[r].Values = rvs.FilterByRecordID(set[r].ID)
 		}
+
+		if m != nil && m.HasDedicatedStorage() {
+			err = s.loadComposeRecordColumns(ctx, m, set...)
+		}
 	}
 
-	return nil
+	return
 }
 
 func (s Store) composeRecordsSorter(m *types.Module, q squirrel.SelectBuilder, sort filter.SortExprSet) (squirrel.SelectBuilder, error) {
@@ -585,7 +774,7 @@ func (s Store) composeRecordsSorter(m *types.Module, q squirrel.SelectBuilder, s
 		if col, has := sortable[strings.ToLower(c.Column)]; has {
 			sqlSort[i] = col
 		} else if f := m.Fields.FindByName(c.Column); f != nil {
-			sqlSort[i], err = s.config.CastModuleFieldToColumnType(f, c.Column)
+			sqlSort[i], err = s.composeRecordFieldExpr(m, f)
 		} else {
 			err = fmt.Errorf("could not sort by unknown column: %s", c.Column)
 		}
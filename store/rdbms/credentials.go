@@ -2,12 +2,19 @@ package rdbms
 
 import (
 	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
 	"github.com/cortezaproject/corteza-server/system/types"
 )
 
 func (s Store) convertCredentialsFilter(f types.CredentialsFilter) (query squirrel.SelectBuilder, err error) {
 	query = s.credentialsSelectBuilder()
 
+	// Deleted credentials are returned unless only deleted are requested;
+	// callers skip them when checking credentials (see Credentials.Valid)
+	if f.Deleted == filter.StateExclusive {
+		query = filter.StateCondition(query, "crd.deleted_at", f.Deleted)
+	}
+
 	if f.Kind != "" {
 		query = query.Where(squirrel.Eq{"crd.kind": f.Kind})
 	}
@@ -71,6 +71,7 @@ const (
 	ColumnTypeInteger
 	ColumnTypeJson
 	ColumnTypeBoolean
+	ColumnTypeNumeric
 )
 
 func TableDef(name string, mm ...tableManipulator) *Table {
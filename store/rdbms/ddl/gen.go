@@ -158,6 +158,8 @@ func GenColumnType(ct *ColumnType) string {
 		return "INTEGER"
 	case ColumnTypeBoolean:
 		return "BOOLEAN"
+	case ColumnTypeNumeric:
+		return "NUMERIC"
 	default:
 		panic(fmt.Sprintf("unhandled column type: %d ", ct.Type))
 	}
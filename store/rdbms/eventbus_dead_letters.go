@@ -0,0 +1,10 @@
+package rdbms
+
+import (
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+)
+
+func (s Store) convertEventbusDeadLetterFilter(f eventbus.QueuedEventFilter) (query squirrel.SelectBuilder, err error) {
+	return convertEventbusQueuedEventFilter(s.eventbusDeadLettersSelectBuilder(), "ebd", f), nil
+}
@@ -0,0 +1,552 @@
+package rdbms
+
+// This file is an auto-generated file
+//
+// Template:    pkg/codegen/assets/store_rdbms.gen.go.tpl
+// Definitions: store/eventbus_outbox_events.yaml
+//
+// Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated.
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/store/rdbms/builders"
+)
+
+var _ = errors.Is
+
+// SearchEventbusOutboxEvents returns all matching rows
+//
+// This function calls convertEventbusOutboxEventFilter with the given
+// eventbus.QueuedEventFilter and expects to receive a working squirrel.SelectBuilder
+func (s Store) SearchEventbusOutboxEvents(ctx context.Context, f eventbus.QueuedEventFilter) (eventbus.QueuedEventSet, eventbus.QueuedEventFilter, error) {
+	var (
+		err error
+		set []*eventbus.QueuedEvent
+		q   squirrel.SelectBuilder
+	)
+
+	return set, f, func() error {
+		q, err = s.convertEventbusOutboxEventFilter(f)
+		if err != nil {
+			return err
+		}
+
+		// Paging enabled
+		// {search: {enablePaging:true}}
+		// Cleanup unwanted cursor values (only relevant is f.PageCursor, next&prev are reset and returned)
+		f.PrevPage, f.NextPage = nil, nil
+
+		if f.PageCursor != nil {
+			// Page cursor exists so we need to validate it against used sort
+			// To cover the case when paging cursor is set but sorting is empty, we collect the sorting instructions
+			// from the cursor.
+			// This (extracted sorting info) is then returned as part of response
+			if f.Sort, err = f.PageCursor.Sort(f.Sort); err != nil {
+				return err
+			}
+		}
+
+		// Make sure results are always sorted at least by primary keys
+		if f.Sort.Get("id") == nil {
+			f.Sort = append(f.Sort, &filter.SortExpr{
+				Column:     "id",
+				Descending: f.Sort.LastDescending(),
+			})
+		}
+
+		// Cloned sorting instructions for the actual sorting
+		// Original are passed to the fetchFullPageOfUsers fn used for cursor creation so it MUST keep the initial
+		// direction information
+		sort := f.Sort.Clone()
+
+		// When cursor for a previous page is used it's marked as reversed
+		// This tells us to flip the descending flag on all used sort keys
+		if f.PageCursor != nil && f.PageCursor.ROrder {
+			sort.Reverse()
+		}
+
+		// Apply sorting expr from filter to query
+		if q, err = setOrderBy(q, sort, s.sortableEventbusOutboxEventColumns()); err != nil {
+			return err
+		}
+
+		set, f.PrevPage, f.NextPage, err = s.fetchFullPageOfEventbusOutboxEvents(
+			ctx,
+			q, f.Sort, f.PageCursor,
+			f.Limit,
+			nil,
+			func(cur *filter.PagingCursor) squirrel.Sqlizer {
+				return builders.CursorCondition(cur, nil)
+			},
+		)
+
+		if err != nil {
+			return err
+		}
+
+		f.PageCursor = nil
+		return nil
+	}()
+}
+
+// fetchFullPageOfEventbusOutboxEvents collects all requested results.
+//
+// Function applies:
+//  - cursor conditions (where ...)
+//  - limit
+//
+// Main responsibility of this function is to perform additional sequential queries in case when not enough results
+// are collected due to failed check on a specific row (by check fn).
+//
+// Function then moves cursor to the last item fetched
+func (s Store) fetchFullPageOfEventbusOutboxEvents(
+	ctx context.Context,
+	q squirrel.SelectBuilder,
+	sort filter.SortExprSet,
+	cursor *filter.PagingCursor,
+	reqItems uint,
+	check func(*eventbus.QueuedEvent) (bool, error),
+	cursorCond func(*filter.PagingCursor) squirrel.Sqlizer,
+) (set []*eventbus.QueuedEvent, prev, next *filter.PagingCursor, err error) {
+	var (
+		aux []*eventbus.QueuedEvent
+
+		// When cursor for a previous page is used it's marked as reversed
+		// This tells us to flip the descending flag on all used sort keys
+		reversedOrder = cursor != nil && cursor.ROrder
+
+		// copy of the select builder
+		tryQuery squirrel.SelectBuilder
+
+		// Copy no. of required items to limit
+		// Limit will change when doing subsequent queries to fill
+		// the set with all required items
+		limit = reqItems
+
+		// cursor to prev. page is only calculated when cursor is used
+		hasPrev = cursor != nil
+
+		// next cursor is calculated when there are more pages to come
+		hasNext bool
+	)
+
+	set = make([]*eventbus.QueuedEvent, 0, DefaultSliceCapacity)
+
+	for try := 0; try < MaxRefetches; try++ {
+		if cursor != nil {
+			tryQuery = q.Where(cursorCond(cursor))
+		} else {
+			tryQuery = q
+		}
+
+		if limit > 0 {
+			// fetching + 1 so we know if there are more items
+			// we can fetch (next-page cursor)
+			tryQuery = tryQuery.Limit(uint64(limit + 1))
+		}
+
+		if aux, err = s.QueryEventbusOutboxEvents(ctx, tryQuery, check); err != nil {
+			return nil, nil, nil, err
+		}
+
+		if len(aux) == 0 {
+			// nothing fetched
+			break
+		}
+
+		// append fetched items
+		set = append(set, aux...)
+
+		if reqItems == 0 {
+			// no max requested items specified, break out
+			break
+		}
+
+		collected := uint(len(set))
+
+		if reqItems > collected {
+			// not enough items fetched, try again with adjusted limit
+			limit = reqItems - collected
+
+			if limit < MinEnsureFetchLimit {
+				// In case limit is set very low and we've missed records in the first fetch,
+				// make sure next fetch limit is a bit higher
+				limit = MinEnsureFetchLimit
+			}
+
+			// Update cursor so that it points to the last item fetched
+			cursor = s.collectEventbusOutboxEventCursorValues(set[collected-1], sort...)
+
+			// Copy reverse flag from sorting
+			cursor.LThen = sort.Reversed()
+			continue
+		}
+
+		if reqItems < collected {
+			set = set[:reqItems]
+			hasNext = true
+		}
+
+		break
+	}
+
+	collected := len(set)
+
+	if collected == 0 {
+		return nil, nil, nil, nil
+	}
+
+	if reversedOrder {
+		// Fetched set needs to be reversed because we've forced a descending order to get the previous page
+		for i, j := 0, collected-1; i < j; i, j = i+1, j-1 {
+			set[i], set[j] = set[j], set[i]
+		}
+
+		// when in reverse-order rules on what cursor to return change
+		hasPrev, hasNext = hasNext, hasPrev
+	}
+
+	if hasPrev {
+		prev = s.collectEventbusOutboxEventCursorValues(set[0], sort...)
+		prev.ROrder = true
+		prev.LThen = !sort.Reversed()
+	}
+
+	if hasNext {
+		next = s.collectEventbusOutboxEventCursorValues(set[collected-1], sort...)
+		next.LThen = sort.Reversed()
+	}
+
+	return set, prev, next, nil
+}
+
+// QueryEventbusOutboxEvents queries the database, converts and checks each row and
+// returns collected set
+//
+// Fn also returns total number of fetched items and last fetched item so that the caller can construct cursor
+// for next page of results
+func (s Store) QueryEventbusOutboxEvents(
+	ctx context.Context,
+	q squirrel.Sqlizer,
+	check func(*eventbus.QueuedEvent) (bool, error),
+) ([]*eventbus.QueuedEvent, error) {
+	var (
+		set = make([]*eventbus.QueuedEvent, 0, DefaultSliceCapacity)
+		res *eventbus.QueuedEvent
+
+		// Query rows with
+		rows, err = s.Query(ctx, q)
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		if err = rows.Err(); err == nil {
+			res, err = s.internalEventbusOutboxEventRowScanner(rows)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		set = append(set, res)
+	}
+
+	return set, rows.Err()
+}
+
+// LookupEventbusOutboxEventByID searches for queued event by ID
+func (s Store) LookupEventbusOutboxEventByID(ctx context.Context, id uint64) (*eventbus.QueuedEvent, error) {
+	return s.execLookupEventbusOutboxEvent(ctx, squirrel.Eq{
+		s.preprocessColumn("ebo.id", ""): store.PreprocessValue(id, ""),
+	})
+}
+
+// CreateEventbusOutboxEvent creates one or more rows in eventbus_outbox table
+func (s Store) CreateEventbusOutboxEvent(ctx context.Context, rr ...*eventbus.QueuedEvent) (err error) {
+	for _, res := range rr {
+		err = s.checkEventbusOutboxEventConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execCreateEventbusOutboxEvents(ctx, s.internalEventbusOutboxEventEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpdateEventbusOutboxEvent updates one or more existing rows in eventbus_outbox
+func (s Store) UpdateEventbusOutboxEvent(ctx context.Context, rr ...*eventbus.QueuedEvent) error {
+	return s.partialEventbusOutboxEventUpdate(ctx, nil, rr...)
+}
+
+// partialEventbusOutboxEventUpdate updates one or more existing rows in eventbus_outbox
+func (s Store) partialEventbusOutboxEventUpdate(ctx context.Context, onlyColumns []string, rr ...*eventbus.QueuedEvent) (err error) {
+	for _, res := range rr {
+		err = s.checkEventbusOutboxEventConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpdateEventbusOutboxEvents(
+			ctx,
+			squirrel.Eq{
+				s.preprocessColumn("ebo.id", ""): store.PreprocessValue(res.ID, ""),
+			},
+			s.internalEventbusOutboxEventEncoder(res).Skip("id").Only(onlyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// DeleteEventbusOutboxEvent Deletes one or more rows from eventbus_outbox table
+func (s Store) DeleteEventbusOutboxEvent(ctx context.Context, rr ...*eventbus.QueuedEvent) (err error) {
+	for _, res := range rr {
+
+		err = s.execDeleteEventbusOutboxEvents(ctx, squirrel.Eq{
+			s.preprocessColumn("ebo.id", ""): store.PreprocessValue(res.ID, ""),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteEventbusOutboxEventByID Deletes row from the eventbus_outbox table
+func (s Store) DeleteEventbusOutboxEventByID(ctx context.Context, ID uint64) error {
+	return s.execDeleteEventbusOutboxEvents(ctx, squirrel.Eq{
+		s.preprocessColumn("ebo.id", ""): store.PreprocessValue(ID, ""),
+	})
+}
+
+// TruncateEventbusOutboxEvents Deletes all rows from the eventbus_outbox table
+func (s Store) TruncateEventbusOutboxEvents(ctx context.Context) error {
+	return s.Truncate(ctx, s.eventbusOutboxEventTable())
+}
+
+// execLookupEventbusOutboxEvent prepares EventbusOutboxEvent query and executes it,
+// returning eventbus.QueuedEvent (or error)
+func (s Store) execLookupEventbusOutboxEvent(ctx context.Context, cnd squirrel.Sqlizer) (res *eventbus.QueuedEvent, err error) {
+	var (
+		row rowScanner
+	)
+
+	row, err = s.QueryRow(ctx, s.eventbusOutboxEventsSelectBuilder().Where(cnd))
+	if err != nil {
+		return
+	}
+
+	res, err = s.internalEventbusOutboxEventRowScanner(row)
+	if err != nil {
+		return
+	}
+
+	return res, nil
+}
+
+// execCreateEventbusOutboxEvents updates all matched (by cnd) rows in eventbus_outbox with given data
+func (s Store) execCreateEventbusOutboxEvents(ctx context.Context, payload store.Payload) error {
+	return s.Exec(ctx, s.InsertBuilder(s.eventbusOutboxEventTable()).SetMap(payload))
+}
+
+// execUpdateEventbusOutboxEvents updates all matched (by cnd) rows in eventbus_outbox with given data
+func (s Store) execUpdateEventbusOutboxEvents(ctx context.Context, cnd squirrel.Sqlizer, set store.Payload) error {
+	return s.Exec(ctx, s.UpdateBuilder(s.eventbusOutboxEventTable("ebo")).Where(cnd).SetMap(set))
+}
+
+// execDeleteEventbusOutboxEvents Deletes all matched (by cnd) rows in eventbus_outbox with given data
+func (s Store) execDeleteEventbusOutboxEvents(ctx context.Context, cnd squirrel.Sqlizer) error {
+	return s.Exec(ctx, s.DeleteBuilder(s.eventbusOutboxEventTable("ebo")).Where(cnd))
+}
+
+func (s Store) internalEventbusOutboxEventRowScanner(row rowScanner) (res *eventbus.QueuedEvent, err error) {
+	res = &eventbus.QueuedEvent{}
+
+	if _, has := s.config.RowScanners["eventbusOutboxEvent"]; has {
+		scanner := s.config.RowScanners["eventbusOutboxEvent"].(func(_ rowScanner, _ *eventbus.QueuedEvent) error)
+		err = scanner(row, res)
+	} else {
+		err = row.Scan(
+			&res.ID,
+			&res.ResourceType,
+			&res.EventType,
+			&res.Args,
+			&res.InvokerID,
+			&res.Attempts,
+			&res.LastError,
+			&res.CreatedAt,
+			&res.ScheduledAt,
+			&res.FailedAt,
+		)
+	}
+
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound.Stack(1)
+	}
+
+	if err != nil {
+		return nil, errors.Store("could not scan eventbusOutboxEvent db row").Wrap(err)
+	} else {
+		return res, nil
+	}
+}
+
+// QueryEventbusOutboxEvents returns squirrel.SelectBuilder with set table and all columns
+func (s Store) eventbusOutboxEventsSelectBuilder() squirrel.SelectBuilder {
+	return s.SelectBuilder(s.eventbusOutboxEventTable("ebo"), s.eventbusOutboxEventColumns("ebo")...)
+}
+
+// eventbusOutboxEventTable name of the db table
+func (Store) eventbusOutboxEventTable(aa ...string) string {
+	var alias string
+	if len(aa) > 0 {
+		alias = " AS " + aa[0]
+	}
+
+	return "eventbus_outbox" + alias
+}
+
+// EventbusOutboxEventColumns returns all defined table columns
+//
+// With optional string arg, all columns are returned aliased
+func (Store) eventbusOutboxEventColumns(aa ...string) []string {
+	var alias string
+	if len(aa) > 0 {
+		alias = aa[0] + "."
+	}
+
+	return []string{
+		alias + "id",
+		alias + "resource_type",
+		alias + "event_type",
+		alias + "args",
+		alias + "rel_invoker",
+		alias + "attempts",
+		alias + "last_error",
+		alias + "created_at",
+		alias + "scheduled_at",
+		alias + "failed_at",
+	}
+}
+
+// {true true false true true false}
+
+// sortableEventbusOutboxEventColumns returns all EventbusOutboxEvent columns flagged as sortable
+//
+// With optional string arg, all columns are returned aliased
+func (Store) sortableEventbusOutboxEventColumns() map[string]string {
+	return map[string]string{
+		"id": "id", "created_at": "created_at",
+		"createdat":    "created_at",
+		"scheduled_at": "scheduled_at",
+		"scheduledat":  "scheduled_at",
+		"failed_at":    "failed_at",
+		"failedat":     "failed_at",
+	}
+}
+
+// internalEventbusOutboxEventEncoder encodes fields from eventbus.QueuedEvent to store.Payload (map)
+//
+// Encoding is done by using generic approach or by calling encodeEventbusOutboxEvent
+// func when rdbms.customEncoder=true
+func (s Store) internalEventbusOutboxEventEncoder(res *eventbus.QueuedEvent) store.Payload {
+	return store.Payload{
+		"id":            res.ID,
+		"resource_type": res.ResourceType,
+		"event_type":    res.EventType,
+		"args":          res.Args,
+		"rel_invoker":   res.InvokerID,
+		"attempts":      res.Attempts,
+		"last_error":    res.LastError,
+		"created_at":    res.CreatedAt,
+		"scheduled_at":  res.ScheduledAt,
+		"failed_at":     res.FailedAt,
+	}
+}
+
+// collectEventbusOutboxEventCursorValues collects values from the given resource that and sets them to the cursor
+// to be used for pagination
+//
+// Values that are collected must come from sortable, unique or primary columns/fields
+// At least one of the collected columns must be flagged as unique, otherwise fn appends primary keys at the end
+//
+// Known issue:
+//   when collecting cursor values for query that sorts by unique column with partial index (ie: unique handle on
+//   undeleted items)
+func (s Store) collectEventbusOutboxEventCursorValues(res *eventbus.QueuedEvent, cc ...*filter.SortExpr) *filter.PagingCursor {
+	var (
+		cursor = &filter.PagingCursor{}
+
+		hasUnique bool
+
+		// All known primary key columns
+
+		pkId bool
+
+		collect = func(cc ...*filter.SortExpr) {
+			for _, c := range cc {
+				switch c.Column {
+				case "id":
+					cursor.Set(c.Column, res.ID, c.Descending)
+
+					pkId = true
+				case "created_at":
+					cursor.Set(c.Column, res.CreatedAt, c.Descending)
+
+				case "scheduled_at":
+					cursor.Set(c.Column, res.ScheduledAt, c.Descending)
+
+				case "failed_at":
+					cursor.Set(c.Column, res.FailedAt, c.Descending)
+
+				}
+			}
+		}
+	)
+
+	collect(cc...)
+	if !hasUnique || !(pkId && true) {
+		collect(&filter.SortExpr{Column: "id", Descending: false})
+	}
+
+	return cursor
+}
+
+// checkEventbusOutboxEventConstraints performs lookups (on valid) resource to check if any of the values on unique fields
+// already exists in the store
+//
+// Using built-in constraint checking would be more performant but unfortunately we can not rely
+// on the full support (MySQL does not support conditional indexes)
+func (s *Store) checkEventbusOutboxEventConstraints(ctx context.Context, res *eventbus.QueuedEvent) error {
+	// Consider resource valid when all fields in unique constraint check lookups
+	// have valid (non-empty) value
+	//
+	// Only string and uint64 are supported for now
+	// feel free to add additional types if needed
+	var valid = true
+
+	if !valid {
+		return nil
+	}
+
+	return nil
+}
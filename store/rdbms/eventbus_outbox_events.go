@@ -0,0 +1,55 @@
+package rdbms
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/store"
+)
+
+// ClaimEventbusOutboxEvent reschedules event that is due for delivery to the event's ScheduledAt
+//
+// Event is claimed with a conditional update; false is returned when event
+// is no longer due (claimed by another worker or already rescheduled)
+func (s Store) ClaimEventbusOutboxEvent(ctx context.Context, qe *eventbus.QueuedEvent) (bool, error) {
+	query, args, err := s.UpdateBuilder(s.eventbusOutboxEventTable()).
+		Set("scheduled_at", qe.ScheduledAt).
+		Where(squirrel.Eq{"id": qe.ID}).
+		Where(squirrel.LtOrEq{"scheduled_at": time.Now()}).
+		ToSql()
+
+	if err != nil {
+		return false, err
+	}
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, store.HandleError(err, s.config.ErrorHandler)
+	}
+
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (s Store) convertEventbusOutboxEventFilter(f eventbus.QueuedEventFilter) (query squirrel.SelectBuilder, err error) {
+	return convertEventbusQueuedEventFilter(s.eventbusOutboxEventsSelectBuilder(), "ebo", f), nil
+}
+
+// convertEventbusQueuedEventFilter applies filter on outbox or dead letters query
+func convertEventbusQueuedEventFilter(query squirrel.SelectBuilder, alias string, f eventbus.QueuedEventFilter) squirrel.SelectBuilder {
+	if f.ResourceType != "" {
+		query = query.Where(squirrel.Eq{alias + ".resource_type": f.ResourceType})
+	}
+
+	if f.EventType != "" {
+		query = query.Where(squirrel.Eq{alias + ".event_type": f.EventType})
+	}
+
+	if f.ScheduledBefore != nil {
+		query = query.Where(squirrel.LtOrEq{alias + ".scheduled_at": f.ScheduledBefore})
+	}
+
+	return query
+}
@@ -0,0 +1,297 @@
+package rdbms
+
+// This file is an auto-generated file
+//
+// Template:    pkg/codegen/assets/store_rdbms.gen.go.tpl
+// Definitions: store/failed_logins.yaml
+//
+// Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated.
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/system/types"
+)
+
+var _ = errors.Is
+
+// QueryFailedLogins queries the database, converts and checks each row and
+// returns collected set
+//
+// Fn also returns total number of fetched items and last fetched item so that the caller can construct cursor
+// for next page of results
+func (s Store) QueryFailedLogins(
+	ctx context.Context,
+	q squirrel.Sqlizer,
+	check func(*types.FailedLogin) (bool, error),
+) ([]*types.FailedLogin, error) {
+	var (
+		set = make([]*types.FailedLogin, 0, DefaultSliceCapacity)
+		res *types.FailedLogin
+
+		// Query rows with
+		rows, err = s.Query(ctx, q)
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		if err = rows.Err(); err == nil {
+			res, err = s.internalFailedLoginRowScanner(rows)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		set = append(set, res)
+	}
+
+	return set, rows.Err()
+}
+
+// LookupFailedLoginByKey searches for failed login attempts by key
+func (s Store) LookupFailedLoginByKey(ctx context.Context, key string) (*types.FailedLogin, error) {
+	return s.execLookupFailedLogin(ctx, squirrel.Eq{
+		s.preprocessColumn("fl.login_key", ""): store.PreprocessValue(key, ""),
+	})
+}
+
+// CreateFailedLogin creates one or more rows in failed_logins table
+func (s Store) CreateFailedLogin(ctx context.Context, rr ...*types.FailedLogin) (err error) {
+	for _, res := range rr {
+		err = s.checkFailedLoginConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execCreateFailedLogins(ctx, s.internalFailedLoginEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpdateFailedLogin updates one or more existing rows in failed_logins
+func (s Store) UpdateFailedLogin(ctx context.Context, rr ...*types.FailedLogin) error {
+	return s.partialFailedLoginUpdate(ctx, nil, rr...)
+}
+
+// partialFailedLoginUpdate updates one or more existing rows in failed_logins
+func (s Store) partialFailedLoginUpdate(ctx context.Context, onlyColumns []string, rr ...*types.FailedLogin) (err error) {
+	for _, res := range rr {
+		err = s.checkFailedLoginConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpdateFailedLogins(
+			ctx,
+			squirrel.Eq{
+				s.preprocessColumn("fl.login_key", ""): store.PreprocessValue(res.Key, ""),
+			},
+			s.internalFailedLoginEncoder(res).Skip("login_key").Only(onlyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpsertFailedLogin updates one or more existing rows in failed_logins
+func (s Store) UpsertFailedLogin(ctx context.Context, rr ...*types.FailedLogin) (err error) {
+	for _, res := range rr {
+		err = s.checkFailedLoginConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpsertFailedLogins(ctx, s.internalFailedLoginEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteFailedLogin Deletes one or more rows from failed_logins table
+func (s Store) DeleteFailedLogin(ctx context.Context, rr ...*types.FailedLogin) (err error) {
+	for _, res := range rr {
+
+		err = s.execDeleteFailedLogins(ctx, squirrel.Eq{
+			s.preprocessColumn("fl.login_key", ""): store.PreprocessValue(res.Key, ""),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteFailedLoginByKey Deletes row from the failed_logins table
+func (s Store) DeleteFailedLoginByKey(ctx context.Context, key string) error {
+	return s.execDeleteFailedLogins(ctx, squirrel.Eq{
+		s.preprocessColumn("fl.login_key", ""): store.PreprocessValue(key, ""),
+	})
+}
+
+// TruncateFailedLogins Deletes all rows from the failed_logins table
+func (s Store) TruncateFailedLogins(ctx context.Context) error {
+	return s.Truncate(ctx, s.failedLoginTable())
+}
+
+// execLookupFailedLogin prepares FailedLogin query and executes it,
+// returning types.FailedLogin (or error)
+func (s Store) execLookupFailedLogin(ctx context.Context, cnd squirrel.Sqlizer) (res *types.FailedLogin, err error) {
+	var (
+		row rowScanner
+	)
+
+	row, err = s.QueryRow(ctx, s.failedLoginsSelectBuilder().Where(cnd))
+	if err != nil {
+		return
+	}
+
+	res, err = s.internalFailedLoginRowScanner(row)
+	if err != nil {
+		return
+	}
+
+	return res, nil
+}
+
+// execCreateFailedLogins updates all matched (by cnd) rows in failed_logins with given data
+func (s Store) execCreateFailedLogins(ctx context.Context, payload store.Payload) error {
+	return s.Exec(ctx, s.InsertBuilder(s.failedLoginTable()).SetMap(payload))
+}
+
+// execUpdateFailedLogins updates all matched (by cnd) rows in failed_logins with given data
+func (s Store) execUpdateFailedLogins(ctx context.Context, cnd squirrel.Sqlizer, set store.Payload) error {
+	return s.Exec(ctx, s.UpdateBuilder(s.failedLoginTable("fl")).Where(cnd).SetMap(set))
+}
+
+// execUpsertFailedLogins inserts new or updates matching (by-primary-key) rows in failed_logins with given data
+func (s Store) execUpsertFailedLogins(ctx context.Context, set store.Payload) error {
+	upsert, err := s.config.UpsertBuilder(
+		s.config,
+		s.failedLoginTable(),
+		set,
+		s.preprocessColumn("login_key", ""),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return s.Exec(ctx, upsert)
+}
+
+// execDeleteFailedLogins Deletes all matched (by cnd) rows in failed_logins with given data
+func (s Store) execDeleteFailedLogins(ctx context.Context, cnd squirrel.Sqlizer) error {
+	return s.Exec(ctx, s.DeleteBuilder(s.failedLoginTable("fl")).Where(cnd))
+}
+
+func (s Store) internalFailedLoginRowScanner(row rowScanner) (res *types.FailedLogin, err error) {
+	res = &types.FailedLogin{}
+
+	if _, has := s.config.RowScanners["failedLogin"]; has {
+		scanner := s.config.RowScanners["failedLogin"].(func(_ rowScanner, _ *types.FailedLogin) error)
+		err = scanner(row, res)
+	} else {
+		err = row.Scan(
+			&res.Key,
+			&res.Failures,
+			&res.LastFailedAt,
+			&res.LockedAt,
+		)
+	}
+
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound.Stack(1)
+	}
+
+	if err != nil {
+		return nil, errors.Store("could not scan failedLogin db row").Wrap(err)
+	} else {
+		return res, nil
+	}
+}
+
+// QueryFailedLogins returns squirrel.SelectBuilder with set table and all columns
+func (s Store) failedLoginsSelectBuilder() squirrel.SelectBuilder {
+	return s.SelectBuilder(s.failedLoginTable("fl"), s.failedLoginColumns("fl")...)
+}
+
+// failedLoginTable name of the db table
+func (Store) failedLoginTable(aa ...string) string {
+	var alias string
+	if len(aa) > 0 {
+		alias = " AS " + aa[0]
+	}
+
+	return "failed_logins" + alias
+}
+
+// FailedLoginColumns returns all defined table columns
+//
+// With optional string arg, all columns are returned aliased
+func (Store) failedLoginColumns(aa ...string) []string {
+	var alias string
+	if len(aa) > 0 {
+		alias = aa[0] + "."
+	}
+
+	return []string{
+		alias + "login_key",
+		alias + "failures",
+		alias + "last_failed_at",
+		alias + "locked_at",
+	}
+}
+
+// {false true false false false false}
+
+// internalFailedLoginEncoder encodes fields from types.FailedLogin to store.Payload (map)
+//
+// Encoding is done by using generic approach or by calling encodeFailedLogin
+// func when rdbms.customEncoder=true
+func (s Store) internalFailedLoginEncoder(res *types.FailedLogin) store.Payload {
+	return store.Payload{
+		"login_key":      res.Key,
+		"failures":       res.Failures,
+		"last_failed_at": res.LastFailedAt,
+		"locked_at":      res.LockedAt,
+	}
+}
+
+// checkFailedLoginConstraints performs lookups (on valid) resource to check if any of the values on unique fields
+// already exists in the store
+//
+// Using built-in constraint checking would be more performant but unfortunately we can not rely
+// on the full support (MySQL does not support conditional indexes)
+func (s *Store) checkFailedLoginConstraints(ctx context.Context, res *types.FailedLogin) error {
+	// Consider resource valid when all fields in unique constraint check lookups
+	// have valid (non-empty) value
+	//
+	// Only string and uint64 are supported for now
+	// feel free to add additional types if needed
+	var valid = true
+
+	if !valid {
+		return nil
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package rdbms
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/system/types"
+)
+
+// CompareAndSwapFailedLogin updates failed login only if the number of failures
+// in the store still matches the given number
+//
+// False is returned when failed login was modified (or removed) in the meantime
+func (s Store) CompareAndSwapFailedLogin(ctx context.Context, fl *types.FailedLogin, failures uint) (bool, error) {
+	query, args, err := s.UpdateBuilder(s.failedLoginTable()).
+		SetMap(s.internalFailedLoginEncoder(fl)).
+		Where(squirrel.Eq{"login_key": fl.Key, "failures": failures}).
+		ToSql()
+
+	if err != nil {
+		return false, err
+	}
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, store.HandleError(err, s.config.ErrorHandler)
+	}
+
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
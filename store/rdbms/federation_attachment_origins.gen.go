@@ -0,0 +1,319 @@
+package rdbms
+
+// This file is an auto-generated file
+//
+// Template:    pkg/codegen/assets/store_rdbms.gen.go.tpl
+// Definitions: store/federation_attachment_origins.yaml
+//
+// Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated.
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/store"
+)
+
+var _ = errors.Is
+
+// SearchFederationAttachmentOrigins returns all matching rows
+//
+// This function calls convertFederationAttachmentOriginFilter with the given
+// types.AttachmentOriginFilter and expects to receive a working squirrel.SelectBuilder
+func (s Store) SearchFederationAttachmentOrigins(ctx context.Context, f types.AttachmentOriginFilter) (types.AttachmentOriginSet, types.AttachmentOriginFilter, error) {
+	var (
+		err error
+		set []*types.AttachmentOrigin
+		q   squirrel.SelectBuilder
+	)
+
+	return set, f, func() error {
+		q, err = s.convertFederationAttachmentOriginFilter(f)
+		if err != nil {
+			return err
+		}
+
+		set, err = s.QueryFederationAttachmentOrigins(ctx, q, nil)
+		return err
+	}()
+}
+
+// QueryFederationAttachmentOrigins queries the database, converts and checks each row and
+// returns collected set
+//
+// Fn also returns total number of fetched items and last fetched item so that the caller can construct cursor
+// for next page of results
+func (s Store) QueryFederationAttachmentOrigins(
+	ctx context.Context,
+	q squirrel.Sqlizer,
+	check func(*types.AttachmentOrigin) (bool, error),
+) ([]*types.AttachmentOrigin, error) {
+	var (
+		set = make([]*types.AttachmentOrigin, 0, DefaultSliceCapacity)
+		res *types.AttachmentOrigin
+
+		// Query rows with
+		rows, err = s.Query(ctx, q)
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		if err = rows.Err(); err == nil {
+			res, err = s.internalFederationAttachmentOriginRowScanner(rows)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		set = append(set, res)
+	}
+
+	return set, rows.Err()
+}
+
+// LookupFederationAttachmentOriginByNodeIDOriginAttachmentID searches for attachment origin by node and the attachment ID on that node
+//
+// It returns attachment origin
+func (s Store) LookupFederationAttachmentOriginByNodeIDOriginAttachmentID(ctx context.Context, node_id uint64, origin_attachment_id uint64) (*types.AttachmentOrigin, error) {
+	return s.execLookupFederationAttachmentOrigin(ctx, squirrel.Eq{
+		s.preprocessColumn("fdao.rel_node", ""):        store.PreprocessValue(node_id, ""),
+		s.preprocessColumn("fdao.xref_attachment", ""): store.PreprocessValue(origin_attachment_id, ""),
+	})
+}
+
+// CreateFederationAttachmentOrigin creates one or more rows in federation_attachment_origin table
+func (s Store) CreateFederationAttachmentOrigin(ctx context.Context, rr ...*types.AttachmentOrigin) (err error) {
+	for _, res := range rr {
+		err = s.checkFederationAttachmentOriginConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execCreateFederationAttachmentOrigins(ctx, s.internalFederationAttachmentOriginEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpdateFederationAttachmentOrigin updates one or more existing rows in federation_attachment_origin
+func (s Store) UpdateFederationAttachmentOrigin(ctx context.Context, rr ...*types.AttachmentOrigin) error {
+	return s.partialFederationAttachmentOriginUpdate(ctx, nil, rr...)
+}
+
+// partialFederationAttachmentOriginUpdate updates one or more existing rows in federation_attachment_origin
+func (s Store) partialFederationAttachmentOriginUpdate(ctx context.Context, onlyColumns []string, rr ...*types.AttachmentOrigin) (err error) {
+	for _, res := range rr {
+		err = s.checkFederationAttachmentOriginConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpdateFederationAttachmentOrigins(
+			ctx,
+			squirrel.Eq{
+				s.preprocessColumn("fdao.rel_attachment", ""): store.PreprocessValue(res.AttachmentID, ""),
+			},
+			s.internalFederationAttachmentOriginEncoder(res).Skip("rel_attachment").Only(onlyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpsertFederationAttachmentOrigin updates one or more existing rows in federation_attachment_origin
+func (s Store) UpsertFederationAttachmentOrigin(ctx context.Context, rr ...*types.AttachmentOrigin) (err error) {
+	for _, res := range rr {
+		err = s.checkFederationAttachmentOriginConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpsertFederationAttachmentOrigins(ctx, s.internalFederationAttachmentOriginEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteFederationAttachmentOrigin Deletes one or more rows from federation_attachment_origin table
+func (s Store) DeleteFederationAttachmentOrigin(ctx context.Context, rr ...*types.AttachmentOrigin) (err error) {
+	for _, res := range rr {
+
+		err = s.execDeleteFederationAttachmentOrigins(ctx, squirrel.Eq{
+			s.preprocessColumn("fdao.rel_attachment", ""): store.PreprocessValue(res.AttachmentID, ""),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteFederationAttachmentOriginByAttachmentID Deletes row from the federation_attachment_origin table
+func (s Store) DeleteFederationAttachmentOriginByAttachmentID(ctx context.Context, attachmentID uint64) error {
+	return s.execDeleteFederationAttachmentOrigins(ctx, squirrel.Eq{
+		s.preprocessColumn("fdao.rel_attachment", ""): store.PreprocessValue(attachmentID, ""),
+	})
+}
+
+// TruncateFederationAttachmentOrigins Deletes all rows from the federation_attachment_origin table
+func (s Store) TruncateFederationAttachmentOrigins(ctx context.Context) error {
+	return s.Truncate(ctx, s.federationAttachmentOriginTable())
+}
+
+// execLookupFederationAttachmentOrigin prepares FederationAttachmentOrigin query and executes it,
+// returning types.AttachmentOrigin (or error)
+func (s Store) execLookupFederationAttachmentOrigin(ctx context.Context, cnd squirrel.Sqlizer) (res *types.AttachmentOrigin, err error) {
+	var (
+		row rowScanner
+	)
+
+	row, err = s.QueryRow(ctx, s.federationAttachmentOriginsSelectBuilder().Where(cnd))
+	if err != nil {
+		return
+	}
+
+	res, err = s.internalFederationAttachmentOriginRowScanner(row)
+	if err != nil {
+		return
+	}
+
+	return res, nil
+}
+
+// execCreateFederationAttachmentOrigins updates all matched (by cnd) rows in federation_attachment_origin with given data
+func (s Store) execCreateFederationAttachmentOrigins(ctx context.Context, payload store.Payload) error {
+	return s.Exec(ctx, s.InsertBuilder(s.federationAttachmentOriginTable()).SetMap(payload))
+}
+
+// execUpdateFederationAttachmentOrigins updates all matched (by cnd) rows in federation_attachment_origin with given data
+func (s Store) execUpdateFederationAttachmentOrigins(ctx context.Context, cnd squirrel.Sqlizer, set store.Payload) error {
+	return s.Exec(ctx, s.UpdateBuilder(s.federationAttachmentOriginTable("fdao")).Where(cnd).SetMap(set))
+}
+
+// execUpsertFederationAttachmentOrigins inserts new or updates matching (by-primary-key) rows in federation_attachment_origin with given data
+func (s Store) execUpsertFederationAttachmentOrigins(ctx context.Context, set store.Payload) error {
+	upsert, err := s.config.UpsertBuilder(
+		s.config,
+		s.federationAttachmentOriginTable(),
+		set,
+		s.preprocessColumn("rel_attachment", ""),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return s.Exec(ctx, upsert)
+}
+
+// execDeleteFederationAttachmentOrigins Deletes all matched (by cnd) rows in federation_attachment_origin with given data
+func (s Store) execDeleteFederationAttachmentOrigins(ctx context.Context, cnd squirrel.Sqlizer) error {
+	return s.Exec(ctx, s.DeleteBuilder(s.federationAttachmentOriginTable("fdao")).Where(cnd))
+}
+
+func (s Store) internalFederationAttachmentOriginRowScanner(row rowScanner) (res *types.AttachmentOrigin, err error) {
+	res = &types.AttachmentOrigin{}
+
+	if _, has := s.config.RowScanners["federationAttachmentOrigin"]; has {
+		scanner := s.config.RowScanners["federationAttachmentOrigin"].(func(_ rowScanner, _ *types.AttachmentOrigin) error)
+		err = scanner(row, res)
+	} else {
+		err = row.Scan(
+			&res.AttachmentID,
+			&res.NodeID,
+			&res.OriginAttachmentID,
+		)
+	}
+
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound.Stack(1)
+	}
+
+	if err != nil {
+		return nil, errors.Store("could not scan federationAttachmentOrigin db row").Wrap(err)
+	} else {
+		return res, nil
+	}
+}
+
+// QueryFederationAttachmentOrigins returns squirrel.SelectBuilder with set table and all columns
+func (s Store) federationAttachmentOriginsSelectBuilder() squirrel.SelectBuilder {
+	return s.SelectBuilder(s.federationAttachmentOriginTable("fdao"), s.federationAttachmentOriginColumns("fdao")...)
+}
+
+// federationAttachmentOriginTable name of the db table
+func (Store) federationAttachmentOriginTable(aa ...string) string {
+	var alias string
+	if len(aa) > 0 {
+		alias = " AS " + aa[0]
+	}
+
+	return "federation_attachment_origin" + alias
+}
+
+// FederationAttachmentOriginColumns returns all defined table columns
+//
+// With optional string arg, all columns are returned aliased
+func (Store) federationAttachmentOriginColumns(aa ...string) []string {
+	var alias string
+	if len(aa) > 0 {
+		alias = aa[0] + "."
+	}
+
+	return []string{
+		alias + "rel_attachment",
+		alias + "rel_node",
+		alias + "xref_attachment",
+	}
+}
+
+// {true true false false false false}
+
+// internalFederationAttachmentOriginEncoder encodes fields from types.AttachmentOrigin to store.Payload (map)
+//
+// Encoding is done by using generic approach or by calling encodeFederationAttachmentOrigin
+// func when rdbms.customEncoder=true
+func (s Store) internalFederationAttachmentOriginEncoder(res *types.AttachmentOrigin) store.Payload {
+	return store.Payload{
+		"rel_attachment":  res.AttachmentID,
+		"rel_node":        res.NodeID,
+		"xref_attachment": res.OriginAttachmentID,
+	}
+}
+
+// checkFederationAttachmentOriginConstraints performs lookups (on valid) resource to check if any of the values on unique fields
+// already exists in the store
+//
+// Using built-in constraint checking would be more performant but unfortunately we can not rely
+// on the full support (MySQL does not support conditional indexes)
+func (s *Store) checkFederationAttachmentOriginConstraints(ctx context.Context, res *types.AttachmentOrigin) error {
+	// Consider resource valid when all fields in unique constraint check lookups
+	// have valid (non-empty) value
+	//
+	// Only string and uint64 are supported for now
+	// feel free to add additional types if needed
+	var valid = true
+
+	if !valid {
+		return nil
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package rdbms
+
+import (
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/federation/types"
+)
+
+func (s Store) convertFederationAttachmentOriginFilter(f types.AttachmentOriginFilter) (query squirrel.SelectBuilder, err error) {
+	query = s.federationAttachmentOriginsSelectBuilder()
+
+	if f.NodeID > 0 {
+		query = query.Where("fdao.rel_node = ?", f.NodeID)
+	}
+
+	return
+}
@@ -438,6 +438,7 @@ func (s Store) internalFederationExposedModuleRowScanner(row rowScanner) (res *t
 			&res.ComposeModuleID,
 			&res.ComposeNamespaceID,
 			&res.Fields,
+			&res.RecordFilter,
 			&res.CreatedBy,
 			&res.UpdatedBy,
 			&res.DeletedBy,
@@ -490,6 +491,7 @@ func (Store) federationExposedModuleColumns(aa ...string) []string {
 		alias + "rel_compose_module",
 		alias + "rel_compose_namespace",
 		alias + "fields",
+		alias + "record_filter",
 		alias + "created_by",
 		alias + "updated_by",
 		alias + "deleted_by",
@@ -523,6 +525,7 @@ func (s Store) internalFederationExposedModuleEncoder(res *types.ExposedModule)
 		"rel_compose_module":    res.ComposeModuleID,
 		"rel_compose_namespace": res.ComposeNamespaceID,
 		"fields":                res.Fields,
+		"record_filter":         res.RecordFilter,
 		"created_by":            res.CreatedBy,
 		"updated_by":            res.UpdatedBy,
 		"deleted_by":            res.DeletedBy,
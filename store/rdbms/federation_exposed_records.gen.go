@@ -0,0 +1,321 @@
+package rdbms
+
+// This file is an auto-generated file
+//
+// Template:    pkg/codegen/assets/store_rdbms.gen.go.tpl
+// Definitions: store/federation_exposed_records.yaml
+//
+// Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated.
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/store"
+)
+
+var _ = errors.Is
+
+// SearchFederationExposedRecords returns all matching rows
+//
+// This function calls convertFederationExposedRecordFilter with the given
+// types.ExposedRecordFilter and expects to receive a working squirrel.SelectBuilder
+func (s Store) SearchFederationExposedRecords(ctx context.Context, f types.ExposedRecordFilter) (types.ExposedRecordSet, types.ExposedRecordFilter, error) {
+	var (
+		err error
+		set []*types.ExposedRecord
+		q   squirrel.SelectBuilder
+	)
+
+	return set, f, func() error {
+		q, err = s.convertFederationExposedRecordFilter(f)
+		if err != nil {
+			return err
+		}
+
+		set, err = s.QueryFederationExposedRecords(ctx, q, nil)
+		return err
+	}()
+}
+
+// QueryFederationExposedRecords queries the database, converts and checks each row and
+// returns collected set
+//
+// Fn also returns total number of fetched items and last fetched item so that the caller can construct cursor
+// for next page of results
+func (s Store) QueryFederationExposedRecords(
+	ctx context.Context,
+	q squirrel.Sqlizer,
+	check func(*types.ExposedRecord) (bool, error),
+) ([]*types.ExposedRecord, error) {
+	var (
+		set = make([]*types.ExposedRecord, 0, DefaultSliceCapacity)
+		res *types.ExposedRecord
+
+		// Query rows with
+		rows, err = s.Query(ctx, q)
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		if err = rows.Err(); err == nil {
+			res, err = s.internalFederationExposedRecordRowScanner(rows)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		set = append(set, res)
+	}
+
+	return set, rows.Err()
+}
+
+// LookupFederationExposedRecordByExposedModuleIDRecordID searches for the record that was exposed with the given module
+//
+// It returns exposed record
+func (s Store) LookupFederationExposedRecordByExposedModuleIDRecordID(ctx context.Context, exposed_module_id uint64, record_id uint64) (*types.ExposedRecord, error) {
+	return s.execLookupFederationExposedRecord(ctx, squirrel.Eq{
+		s.preprocessColumn("fder.rel_exposed_module", ""): store.PreprocessValue(exposed_module_id, ""),
+		s.preprocessColumn("fder.rel_record", ""):         store.PreprocessValue(record_id, ""),
+	})
+}
+
+// CreateFederationExposedRecord creates one or more rows in federation_exposed_record table
+func (s Store) CreateFederationExposedRecord(ctx context.Context, rr ...*types.ExposedRecord) (err error) {
+	for _, res := range rr {
+		err = s.checkFederationExposedRecordConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execCreateFederationExposedRecords(ctx, s.internalFederationExposedRecordEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpdateFederationExposedRecord updates one or more existing rows in federation_exposed_record
+func (s Store) UpdateFederationExposedRecord(ctx context.Context, rr ...*types.ExposedRecord) error {
+	return s.partialFederationExposedRecordUpdate(ctx, nil, rr...)
+}
+
+// partialFederationExposedRecordUpdate updates one or more existing rows in federation_exposed_record
+func (s Store) partialFederationExposedRecordUpdate(ctx context.Context, onlyColumns []string, rr ...*types.ExposedRecord) (err error) {
+	for _, res := range rr {
+		err = s.checkFederationExposedRecordConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpdateFederationExposedRecords(
+			ctx,
+			squirrel.Eq{
+				s.preprocessColumn("fder.rel_exposed_module", ""): store.PreprocessValue(res.ExposedModuleID, ""), s.preprocessColumn("fder.rel_record", ""): store.PreprocessValue(res.RecordID, ""),
+			},
+			s.internalFederationExposedRecordEncoder(res).Skip("rel_exposed_module", "rel_record").Only(onlyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpsertFederationExposedRecord updates one or more existing rows in federation_exposed_record
+func (s Store) UpsertFederationExposedRecord(ctx context.Context, rr ...*types.ExposedRecord) (err error) {
+	for _, res := range rr {
+		err = s.checkFederationExposedRecordConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpsertFederationExposedRecords(ctx, s.internalFederationExposedRecordEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteFederationExposedRecord Deletes one or more rows from federation_exposed_record table
+func (s Store) DeleteFederationExposedRecord(ctx context.Context, rr ...*types.ExposedRecord) (err error) {
+	for _, res := range rr {
+
+		err = s.execDeleteFederationExposedRecords(ctx, squirrel.Eq{
+			s.preprocessColumn("fder.rel_exposed_module", ""): store.PreprocessValue(res.ExposedModuleID, ""), s.preprocessColumn("fder.rel_record", ""): store.PreprocessValue(res.RecordID, ""),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteFederationExposedRecordByExposedModuleIDRecordID Deletes row from the federation_exposed_record table
+func (s Store) DeleteFederationExposedRecordByExposedModuleIDRecordID(ctx context.Context, exposedModuleID uint64, recordID uint64) error {
+	return s.execDeleteFederationExposedRecords(ctx, squirrel.Eq{
+		s.preprocessColumn("fder.rel_exposed_module", ""): store.PreprocessValue(exposedModuleID, ""),
+		s.preprocessColumn("fder.rel_record", ""):         store.PreprocessValue(recordID, ""),
+	})
+}
+
+// TruncateFederationExposedRecords Deletes all rows from the federation_exposed_record table
+func (s Store) TruncateFederationExposedRecords(ctx context.Context) error {
+	return s.Truncate(ctx, s.federationExposedRecordTable())
+}
+
+// execLookupFederationExposedRecord prepares FederationExposedRecord query and executes it,
+// returning types.ExposedRecord (or error)
+func (s Store) execLookupFederationExposedRecord(ctx context.Context, cnd squirrel.Sqlizer) (res *types.ExposedRecord, err error) {
+	var (
+		row rowScanner
+	)
+
+	row, err = s.QueryRow(ctx, s.federationExposedRecordsSelectBuilder().Where(cnd))
+	if err != nil {
+		return
+	}
+
+	res, err = s.internalFederationExposedRecordRowScanner(row)
+	if err != nil {
+		return
+	}
+
+	return res, nil
+}
+
+// execCreateFederationExposedRecords updates all matched (by cnd) rows in federation_exposed_record with given data
+func (s Store) execCreateFederationExposedRecords(ctx context.Context, payload store.Payload) error {
+	return s.Exec(ctx, s.InsertBuilder(s.federationExposedRecordTable()).SetMap(payload))
+}
+
+// execUpdateFederationExposedRecords updates all matched (by cnd) rows in federation_exposed_record with given data
+func (s Store) execUpdateFederationExposedRecords(ctx context.Context, cnd squirrel.Sqlizer, set store.Payload) error {
+	return s.Exec(ctx, s.UpdateBuilder(s.federationExposedRecordTable("fder")).Where(cnd).SetMap(set))
+}
+
+// execUpsertFederationExposedRecords inserts new or updates matching (by-primary-key) rows in federation_exposed_record with given data
+func (s Store) execUpsertFederationExposedRecords(ctx context.Context, set store.Payload) error {
+	upsert, err := s.config.UpsertBuilder(
+		s.config,
+		s.federationExposedRecordTable(),
+		set,
+		s.preprocessColumn("rel_exposed_module", ""),
+		s.preprocessColumn("rel_record", ""),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return s.Exec(ctx, upsert)
+}
+
+// execDeleteFederationExposedRecords Deletes all matched (by cnd) rows in federation_exposed_record with given data
+func (s Store) execDeleteFederationExposedRecords(ctx context.Context, cnd squirrel.Sqlizer) error {
+	return s.Exec(ctx, s.DeleteBuilder(s.federationExposedRecordTable("fder")).Where(cnd))
+}
+
+func (s Store) internalFederationExposedRecordRowScanner(row rowScanner) (res *types.ExposedRecord, err error) {
+	res = &types.ExposedRecord{}
+
+	if _, has := s.config.RowScanners["federationExposedRecord"]; has {
+		scanner := s.config.RowScanners["federationExposedRecord"].(func(_ rowScanner, _ *types.ExposedRecord) error)
+		err = scanner(row, res)
+	} else {
+		err = row.Scan(
+			&res.ExposedModuleID,
+			&res.RecordID,
+			&res.ExposedAt,
+		)
+	}
+
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound.Stack(1)
+	}
+
+	if err != nil {
+		return nil, errors.Store("could not scan federationExposedRecord db row").Wrap(err)
+	} else {
+		return res, nil
+	}
+}
+
+// QueryFederationExposedRecords returns squirrel.SelectBuilder with set table and all columns
+func (s Store) federationExposedRecordsSelectBuilder() squirrel.SelectBuilder {
+	return s.SelectBuilder(s.federationExposedRecordTable("fder"), s.federationExposedRecordColumns("fder")...)
+}
+
+// federationExposedRecordTable name of the db table
+func (Store) federationExposedRecordTable(aa ...string) string {
+	var alias string
+	if len(aa) > 0 {
+		alias = " AS " + aa[0]
+	}
+
+	return "federation_exposed_record" + alias
+}
+
+// FederationExposedRecordColumns returns all defined table columns
+//
+// With optional string arg, all columns are returned aliased
+func (Store) federationExposedRecordColumns(aa ...string) []string {
+	var alias string
+	if len(aa) > 0 {
+		alias = aa[0] + "."
+	}
+
+	return []string{
+		alias + "rel_exposed_module",
+		alias + "rel_record",
+		alias + "exposed_at",
+	}
+}
+
+// {true true false false false false}
+
+// internalFederationExposedRecordEncoder encodes fields from types.ExposedRecord to store.Payload (map)
+//
+// Encoding is done by using generic approach or by calling encodeFederationExposedRecord
+// func when rdbms.customEncoder=true
+func (s Store) internalFederationExposedRecordEncoder(res *types.ExposedRecord) store.Payload {
+	return store.Payload{
+		"rel_exposed_module": res.ExposedModuleID,
+		"rel_record":         res.RecordID,
+		"exposed_at":         res.ExposedAt,
+	}
+}
+
+// checkFederationExposedRecordConstraints performs lookups (on valid) resource to check if any of the values on unique fields
+// already exists in the store
+//
+// Using built-in constraint checking would be more performant but unfortunately we can not rely
+// on the full support (MySQL does not support conditional indexes)
+func (s *Store) checkFederationExposedRecordConstraints(ctx context.Context, res *types.ExposedRecord) error {
+	// Consider resource valid when all fields in unique constraint check lookups
+	// have valid (non-empty) value
+	//
+	// Only string and uint64 are supported for now
+	// feel free to add additional types if needed
+	var valid = true
+
+	if !valid {
+		return nil
+	}
+
+	return nil
+}
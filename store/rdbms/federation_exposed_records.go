@@ -0,0 +1,16 @@
+package rdbms
+
+import (
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/federation/types"
+)
+
+func (s Store) convertFederationExposedRecordFilter(f types.ExposedRecordFilter) (query squirrel.SelectBuilder, err error) {
+	query = s.federationExposedRecordsSelectBuilder()
+
+	if f.ExposedModuleID > 0 {
+		query = query.Where("fder.rel_exposed_module = ?", f.ExposedModuleID)
+	}
+
+	return
+}
@@ -462,6 +462,7 @@ func (s Store) internalFederationModuleMappingRowScanner(row rowScanner) (res *t
 			&res.ComposeModuleID,
 			&res.ComposeNamespaceID,
 			&res.FieldMapping,
+			&res.ConflictPolicy,
 		)
 	}
 
@@ -505,6 +506,7 @@ func (Store) federationModuleMappingColumns(aa ...string) []string {
 		alias + "rel_compose_module",
 		alias + "rel_compose_namespace",
 		alias + "field_mapping",
+		alias + "conflict_policy",
 	}
 }
 
@@ -534,6 +536,7 @@ func (s Store) internalFederationModuleMappingEncoder(res *types.ModuleMapping)
 		"rel_compose_module":    res.ComposeModuleID,
 		"rel_compose_namespace": res.ComposeNamespaceID,
 		"field_mapping":         res.FieldMapping,
+		"conflict_policy":       res.ConflictPolicy,
 	}
 }
 
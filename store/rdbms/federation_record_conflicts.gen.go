@@ -0,0 +1,598 @@
+package rdbms
+
+// This file is an auto-generated file
+//
+// Template:    pkg/codegen/assets/store_rdbms.gen.go.tpl
+// Definitions: store/federation_record_conflicts.yaml
+//
+// Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated.
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/store/rdbms/builders"
+)
+
+var _ = errors.Is
+
+// SearchFederationRecordConflicts returns all matching rows
+//
+// This function calls convertFederationRecordConflictFilter with the given
+// types.RecordConflictFilter and expects to receive a working squirrel.SelectBuilder
+func (s Store) SearchFederationRecordConflicts(ctx context.Context, f types.RecordConflictFilter) (types.RecordConflictSet, types.RecordConflictFilter, error) {
+	var (
+		err error
+		set []*types.RecordConflict
+		q   squirrel.SelectBuilder
+	)
+
+	return set, f, func() error {
+		q, err = s.convertFederationRecordConflictFilter(f)
+		if err != nil {
+			return err
+		}
+
+		// Paging enabled
+		// {search: {enablePaging:true}}
+		// Cleanup unwanted cursor values (only relevant is f.PageCursor, next&prev are reset and returned)
+		f.PrevPage, f.NextPage = nil, nil
+
+		if f.PageCursor != nil {
+			// Page cursor exists so we need to validate it against used sort
+			// To cover the case when paging cursor is set but sorting is empty, we collect the sorting instructions
+			// from the cursor.
+			// This (extracted sorting info) is then returned as part of response
+			if f.Sort, err = f.PageCursor.Sort(f.Sort); err != nil {
+				return err
+			}
+		}
+
+		// Make sure results are always sorted at least by primary keys
+		if f.Sort.Get("id") == nil {
+			f.Sort = append(f.Sort, &filter.SortExpr{
+				Column:     "id",
+				Descending: f.Sort.LastDescending(),
+			})
+		}
+
+		// Cloned sorting instructions for the actual sorting
+		// Original are passed to the fetchFullPageOfUsers fn used for cursor creation so it MUST keep the initial
+		// direction information
+		sort := f.Sort.Clone()
+
+		// When cursor for a previous page is used it's marked as reversed
+		// This tells us to flip the descending flag on all used sort keys
+		if f.PageCursor != nil && f.PageCursor.ROrder {
+			sort.Reverse()
+		}
+
+		// Apply sorting expr from filter to query
+		if q, err = setOrderBy(q, sort, s.sortableFederationRecordConflictColumns()); err != nil {
+			return err
+		}
+
+		set, f.PrevPage, f.NextPage, err = s.fetchFullPageOfFederationRecordConflicts(
+			ctx,
+			q, f.Sort, f.PageCursor,
+			f.Limit,
+			f.Check,
+			func(cur *filter.PagingCursor) squirrel.Sqlizer {
+				return builders.CursorCondition(cur, nil)
+			},
+		)
+
+		if err != nil {
+			return err
+		}
+
+		f.PageCursor = nil
+		return nil
+	}()
+}
+
+// fetchFullPageOfFederationRecordConflicts collects all requested results.
+//
+// Function applies:
+//  - cursor conditions (where ...)
+//  - limit
+//
+// Main responsibility of this function is to perform additional sequential queries in case when not enough results
+// are collected due to failed check on a specific row (by check fn).
+//
+// Function then moves cursor to the last item fetched
+func (s Store) fetchFullPageOfFederationRecordConflicts(
+	ctx context.Context,
+	q squirrel.SelectBuilder,
+	sort filter.SortExprSet,
+	cursor *filter.PagingCursor,
+	reqItems uint,
+	check func(*types.RecordConflict) (bool, error),
+	cursorCond func(*filter.PagingCursor) squirrel.Sqlizer,
+) (set []*types.RecordConflict, prev, next *filter.PagingCursor, err error) {
+	var (
+		aux []*types.RecordConflict
+
+		// When cursor for a previous page is used it's marked as reversed
+		// This tells us to flip the descending flag on all used sort keys
+		reversedOrder = cursor != nil && cursor.ROrder
+
+		// copy of the select builder
+		tryQuery squirrel.SelectBuilder
+
+		// Copy no. of required items to limit
+		// Limit will change when doing subsequent queries to fill
+		// the set with all required items
+		limit = reqItems
+
+		// cursor to prev. page is only calculated when cursor is used
+		hasPrev = cursor != nil
+
+		// next cursor is calculated when there are more pages to come
+		hasNext bool
+	)
+
+	set = make([]*types.RecordConflict, 0, DefaultSliceCapacity)
+
+	for try := 0; try < MaxRefetches; try++ {
+		if cursor != nil {
+			tryQuery = q.Where(cursorCond(cursor))
+		} else {
+			tryQuery = q
+		}
+
+		if limit > 0 {
+			// fetching + 1 so we know if there are more items
+			// we can fetch (next-page cursor)
+			tryQuery = tryQuery.Limit(uint64(limit + 1))
+		}
+
+		if aux, err = s.QueryFederationRecordConflicts(ctx, tryQuery, check); err != nil {
+			return nil, nil, nil, err
+		}
+
+		if len(aux) == 0 {
+			// nothing fetched
+			break
+		}
+
+		// append fetched items
+		set = append(set, aux...)
+
+		if reqItems == 0 {
+			// no max requested items specified, break out
+			break
+		}
+
+		collected := uint(len(set))
+
+		if reqItems > collected {
+			// not enough items fetched, try again with adjusted limit
+			limit = reqItems - collected
+
+			if limit < MinEnsureFetchLimit {
+				// In case limit is set very low and we've missed records in the first fetch,
+				// make sure next fetch limit is a bit higher
+				limit = MinEnsureFetchLimit
+			}
+
+			// Update cursor so that it points to the last item fetched
+			cursor = s.collectFederationRecordConflictCursorValues(set[collected-1], sort...)
+
+			// Copy reverse flag from sorting
+			cursor.LThen = sort.Reversed()
+			continue
+		}
+
+		if reqItems < collected {
+			set = set[:reqItems]
+			hasNext = true
+		}
+
+		break
+	}
+
+	collected := len(set)
+
+	if collected == 0 {
+		return nil, nil, nil, nil
+	}
+
+	if reversedOrder {
+		// Fetched set needs to be reversed because we've forced a descending order to get the previous page
+		for i, j := 0, collected-1; i < j; i, j = i+1, j-1 {
+			set[i], set[j] = set[j], set[i]
+		}
+
+		// when in reverse-order rules on what cursor to return change
+		hasPrev, hasNext = hasNext, hasPrev
+	}
+
+	if hasPrev {
+		prev = s.collectFederationRecordConflictCursorValues(set[0], sort...)
+		prev.ROrder = true
+		prev.LThen = !sort.Reversed()
+	}
+
+	if hasNext {
+		next = s.collectFederationRecordConflictCursorValues(set[collected-1], sort...)
+		next.LThen = sort.Reversed()
+	}
+
+	return set, prev, next, nil
+}
+
+// QueryFederationRecordConflicts queries the database, converts and checks each row and
+// returns collected set
+//
+// Fn also returns total number of fetched items and last fetched item so that the caller can construct cursor
+// for next page of results
+func (s Store) QueryFederationRecordConflicts(
+	ctx context.Context,
+	q squirrel.Sqlizer,
+	check func(*types.RecordConflict) (bool, error),
+) ([]*types.RecordConflict, error) {
+	var (
+		set = make([]*types.RecordConflict, 0, DefaultSliceCapacity)
+		res *types.RecordConflict
+
+		// Query rows with
+		rows, err = s.Query(ctx, q)
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		if err = rows.Err(); err == nil {
+			res, err = s.internalFederationRecordConflictRowScanner(rows)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// check fn set, call it and see if it passed the test
+		// if not, skip the item
+		if check != nil {
+			if chk, err := check(res); err != nil {
+				return nil, err
+			} else if !chk {
+				continue
+			}
+		}
+
+		set = append(set, res)
+	}
+
+	return set, rows.Err()
+}
+
+// LookupFederationRecordConflictByID searches for record conflict by ID
+//
+// It returns record conflict
+func (s Store) LookupFederationRecordConflictByID(ctx context.Context, id uint64) (*types.RecordConflict, error) {
+	return s.execLookupFederationRecordConflict(ctx, squirrel.Eq{
+		s.preprocessColumn("fdrc.id", ""): store.PreprocessValue(id, ""),
+	})
+}
+
+// CreateFederationRecordConflict creates one or more rows in federation_record_conflict table
+func (s Store) CreateFederationRecordConflict(ctx context.Context, rr ...*types.RecordConflict) (err error) {
+	for _, res := range rr {
+		err = s.checkFederationRecordConflictConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execCreateFederationRecordConflicts(ctx, s.internalFederationRecordConflictEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpdateFederationRecordConflict updates one or more existing rows in federation_record_conflict
+func (s Store) UpdateFederationRecordConflict(ctx context.Context, rr ...*types.RecordConflict) error {
+	return s.partialFederationRecordConflictUpdate(ctx, nil, rr...)
+}
+
+// partialFederationRecordConflictUpdate updates one or more existing rows in federation_record_conflict
+func (s Store) partialFederationRecordConflictUpdate(ctx context.Context, onlyColumns []string, rr ...*types.RecordConflict) (err error) {
+	for _, res := range rr {
+		err = s.checkFederationRecordConflictConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpdateFederationRecordConflicts(
+			ctx,
+			squirrel.Eq{
+				s.preprocessColumn("fdrc.id", ""): store.PreprocessValue(res.ID, ""),
+			},
+			s.internalFederationRecordConflictEncoder(res).Skip("id").Only(onlyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpsertFederationRecordConflict updates one or more existing rows in federation_record_conflict
+func (s Store) UpsertFederationRecordConflict(ctx context.Context, rr ...*types.RecordConflict) (err error) {
+	for _, res := range rr {
+		err = s.checkFederationRecordConflictConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpsertFederationRecordConflicts(ctx, s.internalFederationRecordConflictEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteFederationRecordConflict Deletes one or more rows from federation_record_conflict table
+func (s Store) DeleteFederationRecordConflict(ctx context.Context, rr ...*types.RecordConflict) (err error) {
+	for _, res := range rr {
+
+		err = s.execDeleteFederationRecordConflicts(ctx, squirrel.Eq{
+			s.preprocessColumn("fdrc.id", ""): store.PreprocessValue(res.ID, ""),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteFederationRecordConflictByID Deletes row from the federation_record_conflict table
+func (s Store) DeleteFederationRecordConflictByID(ctx context.Context, ID uint64) error {
+	return s.execDeleteFederationRecordConflicts(ctx, squirrel.Eq{
+		s.preprocessColumn("fdrc.id", ""): store.PreprocessValue(ID, ""),
+	})
+}
+
+// TruncateFederationRecordConflicts Deletes all rows from the federation_record_conflict table
+func (s Store) TruncateFederationRecordConflicts(ctx context.Context) error {
+	return s.Truncate(ctx, s.federationRecordConflictTable())
+}
+
+// execLookupFederationRecordConflict prepares FederationRecordConflict query and executes it,
+// returning types.RecordConflict (or error)
+func (s Store) execLookupFederationRecordConflict(ctx context.Context, cnd squirrel.Sqlizer) (res *types.RecordConflict, err error) {
+	var (
+		row rowScanner
+	)
+
+	row, err = s.QueryRow(ctx, s.federationRecordConflictsSelectBuilder().Where(cnd))
+	if err != nil {
+		return
+	}
+
+	res, err = s.internalFederationRecordConflictRowScanner(row)
+	if err != nil {
+		return
+	}
+
+	return res, nil
+}
+
+// execCreateFederationRecordConflicts updates all matched (by cnd) rows in federation_record_conflict with given data
+func (s Store) execCreateFederationRecordConflicts(ctx context.Context, payload store.Payload) error {
+	return s.Exec(ctx, s.InsertBuilder(s.federationRecordConflictTable()).SetMap(payload))
+}
+
+// execUpdateFederationRecordConflicts updates all matched (by cnd) rows in federation_record_conflict with given data
+func (s Store) execUpdateFederationRecordConflicts(ctx context.Context, cnd squirrel.Sqlizer, set store.Payload) error {
+	return s.Exec(ctx, s.UpdateBuilder(s.federationRecordConflictTable("fdrc")).Where(cnd).SetMap(set))
+}
+
+// execUpsertFederationRecordConflicts inserts new or updates matching (by-primary-key) rows in federation_record_conflict with given data
+func (s Store) execUpsertFederationRecordConflicts(ctx context.Context, set store.Payload) error {
+	upsert, err := s.config.UpsertBuilder(
+		s.config,
+		s.federationRecordConflictTable(),
+		set,
+		s.preprocessColumn("id", ""),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return s.Exec(ctx, upsert)
+}
+
+// execDeleteFederationRecordConflicts Deletes all matched (by cnd) rows in federation_record_conflict with given data
+func (s Store) execDeleteFederationRecordConflicts(ctx context.Context, cnd squirrel.Sqlizer) error {
+	return s.Exec(ctx, s.DeleteBuilder(s.federationRecordConflictTable("fdrc")).Where(cnd))
+}
+
+func (s Store) internalFederationRecordConflictRowScanner(row rowScanner) (res *types.RecordConflict, err error) {
+	res = &types.RecordConflict{}
+
+	if _, has := s.config.RowScanners["federationRecordConflict"]; has {
+		scanner := s.config.RowScanners["federationRecordConflict"].(func(_ rowScanner, _ *types.RecordConflict) error)
+		err = scanner(row, res)
+	} else {
+		err = row.Scan(
+			&res.ID,
+			&res.NodeID,
+			&res.ModuleID,
+			&res.RecordID,
+			&res.ComposeModuleID,
+			&res.ComposeNamespaceID,
+			&res.Values,
+			&res.Clock,
+			&res.Resolution,
+			&res.CreatedAt,
+			&res.ResolvedAt,
+			&res.ResolvedBy,
+		)
+	}
+
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound.Stack(1)
+	}
+
+	if err != nil {
+		return nil, errors.Store("could not scan federationRecordConflict db row").Wrap(err)
+	} else {
+		return res, nil
+	}
+}
+
+// QueryFederationRecordConflicts returns squirrel.SelectBuilder with set table and all columns
+func (s Store) federationRecordConflictsSelectBuilder() squirrel.SelectBuilder {
+	return s.SelectBuilder(s.federationRecordConflictTable("fdrc"), s.federationRecordConflictColumns("fdrc")...)
+}
+
+// federationRecordConflictTable name of the db table
+func (Store) federationRecordConflictTable(aa ...string) string {
+	var alias string
+	if len(aa) > 0 {
+		alias = " AS " + aa[0]
+	}
+
+	return "federation_record_conflict" + alias
+}
+
+// FederationRecordConflictColumns returns all defined table columns
+//
+// With optional string arg, all columns are returned aliased
+func (Store) federationRecordConflictColumns(aa ...string) []string {
+	var alias string
+	if len(aa) > 0 {
+		alias = aa[0] + "."
+	}
+
+	return []string{
+		alias + "id",
+		alias + "rel_node",
+		alias + "rel_module",
+		alias + "rel_record",
+		alias + "rel_compose_module",
+		alias + "rel_compose_namespace",
+		alias + "record_values",
+		alias + "clock",
+		alias + "resolution",
+		alias + "created_at",
+		alias + "resolved_at",
+		alias + "resolved_by",
+	}
+}
+
+// {true true false true true true}
+
+// sortableFederationRecordConflictColumns returns all FederationRecordConflict columns flagged as sortable
+//
+// With optional string arg, all columns are returned aliased
+func (Store) sortableFederationRecordConflictColumns() map[string]string {
+	return map[string]string{
+		"id": "id", "created_at": "created_at",
+		"createdat":   "created_at",
+		"resolved_at": "resolved_at",
+		"resolvedat":  "resolved_at",
+	}
+}
+
+// internalFederationRecordConflictEncoder encodes fields from types.RecordConflict to store.Payload (map)
+//
+// Encoding is done by using generic approach or by calling encodeFederationRecordConflict
+// func when rdbms.customEncoder=true
+func (s Store) internalFederationRecordConflictEncoder(res *types.RecordConflict) store.Payload {
+	return store.Payload{
+		"id":                    res.ID,
+		"rel_node":              res.NodeID,
+		"rel_module":            res.ModuleID,
+		"rel_record":            res.RecordID,
+		"rel_compose_module":    res.ComposeModuleID,
+		"rel_compose_namespace": res.ComposeNamespaceID,
+		"record_values":         res.Values,
+		"clock":                 res.Clock,
+		"resolution":            res.Resolution,
+		"created_at":            res.CreatedAt,
+		"resolved_at":           res.ResolvedAt,
+		"resolved_by":           res.ResolvedBy,
+	}
+}
+
+// collectFederationRecordConflictCursorValues collects values from the given resource that and sets them to the cursor
+// to be used for pagination
+//
+// Values that are collected must come from sortable, unique or primary columns/fields
+// At least one of the collected columns must be flagged as unique, otherwise fn appends primary keys at the end
+//
+// Known issue:
+//   when collecting cursor values for query that sorts by unique column with partial index (ie: unique handle on
+//   undeleted items)
+func (s Store) collectFederationRecordConflictCursorValues(res *types.RecordConflict, cc ...*filter.SortExpr) *filter.PagingCursor {
+	var (
+		cursor = &filter.PagingCursor{}
+
+		hasUnique bool
+
+		// All known primary key columns
+
+		pkId bool
+
+		collect = func(cc ...*filter.SortExpr) {
+			for _, c := range cc {
+				switch c.Column {
+				case "id":
+					cursor.Set(c.Column, res.ID, c.Descending)
+
+					pkId = true
+				case "created_at":
+					cursor.Set(c.Column, res.CreatedAt, c.Descending)
+
+				case "resolved_at":
+					cursor.Set(c.Column, res.ResolvedAt, c.Descending)
+
+				}
+			}
+		}
+	)
+
+	collect(cc...)
+	if !hasUnique || !(pkId && true) {
+		collect(&filter.SortExpr{Column: "id", Descending: false})
+	}
+
+	return cursor
+}
+
+// checkFederationRecordConflictConstraints performs lookups (on valid) resource to check if any of the values on unique fields
+// already exists in the store
+//
+// Using built-in constraint checking would be more performant but unfortunately we can not rely
+// on the full support (MySQL does not support conditional indexes)
+func (s *Store) checkFederationRecordConflictConstraints(ctx context.Context, res *types.RecordConflict) error {
+	// Consider resource valid when all fields in unique constraint check lookups
+	// have valid (non-empty) value
+	//
+	// Only string and uint64 are supported for now
+	// feel free to add additional types if needed
+	var valid = true
+
+	if !valid {
+		return nil
+	}
+
+	return nil
+}
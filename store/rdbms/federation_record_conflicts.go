@@ -0,0 +1,23 @@
+package rdbms
+
+import (
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+)
+
+func (s Store) convertFederationRecordConflictFilter(f types.RecordConflictFilter) (query squirrel.SelectBuilder, err error) {
+	query = s.federationRecordConflictsSelectBuilder()
+
+	query = filter.StateCondition(query, "fdrc.resolved_at", f.Resolved)
+
+	if f.NodeID > 0 {
+		query = query.Where("fdrc.rel_node = ?", f.NodeID)
+	}
+
+	if f.RecordID > 0 {
+		query = query.Where("fdrc.rel_record = ?", f.RecordID)
+	}
+
+	return
+}
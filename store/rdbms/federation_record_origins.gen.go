@@ -0,0 +1,334 @@
+package rdbms
+
+// This file is an auto-generated file
+//
+// Template:    pkg/codegen/assets/store_rdbms.gen.go.tpl
+// Definitions: store/federation_record_origins.yaml
+//
+// Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated.
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/store"
+)
+
+var _ = errors.Is
+
+// SearchFederationRecordOrigins returns all matching rows
+//
+// This function calls convertFederationRecordOriginFilter with the given
+// types.RecordOriginFilter and expects to receive a working squirrel.SelectBuilder
+func (s Store) SearchFederationRecordOrigins(ctx context.Context, f types.RecordOriginFilter) (types.RecordOriginSet, types.RecordOriginFilter, error) {
+	var (
+		err error
+		set []*types.RecordOrigin
+		q   squirrel.SelectBuilder
+	)
+
+	return set, f, func() error {
+		q, err = s.convertFederationRecordOriginFilter(f)
+		if err != nil {
+			return err
+		}
+
+		set, err = s.QueryFederationRecordOrigins(ctx, q, nil)
+		return err
+	}()
+}
+
+// QueryFederationRecordOrigins queries the database, converts and checks each row and
+// returns collected set
+//
+// Fn also returns total number of fetched items and last fetched item so that the caller can construct cursor
+// for next page of results
+func (s Store) QueryFederationRecordOrigins(
+	ctx context.Context,
+	q squirrel.Sqlizer,
+	check func(*types.RecordOrigin) (bool, error),
+) ([]*types.RecordOrigin, error) {
+	var (
+		set = make([]*types.RecordOrigin, 0, DefaultSliceCapacity)
+		res *types.RecordOrigin
+
+		// Query rows with
+		rows, err = s.Query(ctx, q)
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		if err = rows.Err(); err == nil {
+			res, err = s.internalFederationRecordOriginRowScanner(rows)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		set = append(set, res)
+	}
+
+	return set, rows.Err()
+}
+
+// LookupFederationRecordOriginByRecordID searches for record origin by local record ID
+//
+// It returns record origin
+func (s Store) LookupFederationRecordOriginByRecordID(ctx context.Context, record_id uint64) (*types.RecordOrigin, error) {
+	return s.execLookupFederationRecordOrigin(ctx, squirrel.Eq{
+		s.preprocessColumn("fdro.rel_record", ""): store.PreprocessValue(record_id, ""),
+	})
+}
+
+// LookupFederationRecordOriginByNodeIDOriginRecordID searches for record origin by node and the record ID on that node
+//
+// It returns record origin
+func (s Store) LookupFederationRecordOriginByNodeIDOriginRecordID(ctx context.Context, node_id uint64, origin_record_id uint64) (*types.RecordOrigin, error) {
+	return s.execLookupFederationRecordOrigin(ctx, squirrel.Eq{
+		s.preprocessColumn("fdro.rel_node", ""):    store.PreprocessValue(node_id, ""),
+		s.preprocessColumn("fdro.xref_record", ""): store.PreprocessValue(origin_record_id, ""),
+	})
+}
+
+// CreateFederationRecordOrigin creates one or more rows in federation_record_origin table
+func (s Store) CreateFederationRecordOrigin(ctx context.Context, rr ...*types.RecordOrigin) (err error) {
+	for _, res := range rr {
+		err = s.checkFederationRecordOriginConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execCreateFederationRecordOrigins(ctx, s.internalFederationRecordOriginEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpdateFederationRecordOrigin updates one or more existing rows in federation_record_origin
+func (s Store) UpdateFederationRecordOrigin(ctx context.Context, rr ...*types.RecordOrigin) error {
+	return s.partialFederationRecordOriginUpdate(ctx, nil, rr...)
+}
+
+// partialFederationRecordOriginUpdate updates one or more existing rows in federation_record_origin
+func (s Store) partialFederationRecordOriginUpdate(ctx context.Context, onlyColumns []string, rr ...*types.RecordOrigin) (err error) {
+	for _, res := range rr {
+		err = s.checkFederationRecordOriginConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpdateFederationRecordOrigins(
+			ctx,
+			squirrel.Eq{
+				s.preprocessColumn("fdro.rel_record", ""): store.PreprocessValue(res.RecordID, ""),
+			},
+			s.internalFederationRecordOriginEncoder(res).Skip("rel_record").Only(onlyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpsertFederationRecordOrigin updates one or more existing rows in federation_record_origin
+func (s Store) UpsertFederationRecordOrigin(ctx context.Context, rr ...*types.RecordOrigin) (err error) {
+	for _, res := range rr {
+		err = s.checkFederationRecordOriginConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpsertFederationRecordOrigins(ctx, s.internalFederationRecordOriginEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteFederationRecordOrigin Deletes one or more rows from federation_record_origin table
+func (s Store) DeleteFederationRecordOrigin(ctx context.Context, rr ...*types.RecordOrigin) (err error) {
+	for _, res := range rr {
+
+		err = s.execDeleteFederationRecordOrigins(ctx, squirrel.Eq{
+			s.preprocessColumn("fdro.rel_record", ""): store.PreprocessValue(res.RecordID, ""),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteFederationRecordOriginByRecordID Deletes row from the federation_record_origin table
+func (s Store) DeleteFederationRecordOriginByRecordID(ctx context.Context, recordID uint64) error {
+	return s.execDeleteFederationRecordOrigins(ctx, squirrel.Eq{
+		s.preprocessColumn("fdro.rel_record", ""): store.PreprocessValue(recordID, ""),
+	})
+}
+
+// TruncateFederationRecordOrigins Deletes all rows from the federation_record_origin table
+func (s Store) TruncateFederationRecordOrigins(ctx context.Context) error {
+	return s.Truncate(ctx, s.federationRecordOriginTable())
+}
+
+// execLookupFederationRecordOrigin prepares FederationRecordOrigin query and executes it,
+// returning types.RecordOrigin (or error)
+func (s Store) execLookupFederationRecordOrigin(ctx context.Context, cnd squirrel.Sqlizer) (res *types.RecordOrigin, err error) {
+	var (
+		row rowScanner
+	)
+
+	row, err = s.QueryRow(ctx, s.federationRecordOriginsSelectBuilder().Where(cnd))
+	if err != nil {
+		return
+	}
+
+	res, err = s.internalFederationRecordOriginRowScanner(row)
+	if err != nil {
+		return
+	}
+
+	return res, nil
+}
+
+// execCreateFederationRecordOrigins updates all matched (by cnd) rows in federation_record_origin with given data
+func (s Store) execCreateFederationRecordOrigins(ctx context.Context, payload store.Payload) error {
+	return s.Exec(ctx, s.InsertBuilder(s.federationRecordOriginTable()).SetMap(payload))
+}
+
+// execUpdateFederationRecordOrigins updates all matched (by cnd) rows in federation_record_origin with given data
+func (s Store) execUpdateFederationRecordOrigins(ctx context.Context, cnd squirrel.Sqlizer, set store.Payload) error {
+	return s.Exec(ctx, s.UpdateBuilder(s.federationRecordOriginTable("fdro")).Where(cnd).SetMap(set))
+}
+
+// execUpsertFederationRecordOrigins inserts new or updates matching (by-primary-key) rows in federation_record_origin with given data
+func (s Store) execUpsertFederationRecordOrigins(ctx context.Context, set store.Payload) error {
+	upsert, err := s.config.UpsertBuilder(
+		s.config,
+		s.federationRecordOriginTable(),
+		set,
+		s.preprocessColumn("rel_record", ""),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return s.Exec(ctx, upsert)
+}
+
+// execDeleteFederationRecordOrigins Deletes all matched (by cnd) rows in federation_record_origin with given data
+func (s Store) execDeleteFederationRecordOrigins(ctx context.Context, cnd squirrel.Sqlizer) error {
+	return s.Exec(ctx, s.DeleteBuilder(s.federationRecordOriginTable("fdro")).Where(cnd))
+}
+
+func (s Store) internalFederationRecordOriginRowScanner(row rowScanner) (res *types.RecordOrigin, err error) {
+	res = &types.RecordOrigin{}
+
+	if _, has := s.config.RowScanners["federationRecordOrigin"]; has {
+		scanner := s.config.RowScanners["federationRecordOrigin"].(func(_ rowScanner, _ *types.RecordOrigin) error)
+		err = scanner(row, res)
+	} else {
+		err = row.Scan(
+			&res.RecordID,
+			&res.NodeID,
+			&res.OriginRecordID,
+			&res.Clock,
+			&res.SyncedAt,
+		)
+	}
+
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound.Stack(1)
+	}
+
+	if err != nil {
+		return nil, errors.Store("could not scan federationRecordOrigin db row").Wrap(err)
+	} else {
+		return res, nil
+	}
+}
+
+// QueryFederationRecordOrigins returns squirrel.SelectBuilder with set table and all columns
+func (s Store) federationRecordOriginsSelectBuilder() squirrel.SelectBuilder {
+	return s.SelectBuilder(s.federationRecordOriginTable("fdro"), s.federationRecordOriginColumns("fdro")...)
+}
+
+// federationRecordOriginTable name of the db table
+func (Store) federationRecordOriginTable(aa ...string) string {
+	var alias string
+	if len(aa) > 0 {
+		alias = " AS " + aa[0]
+	}
+
+	return "federation_record_origin" + alias
+}
+
+// FederationRecordOriginColumns returns all defined table columns
+//
+// With optional string arg, all columns are returned aliased
+func (Store) federationRecordOriginColumns(aa ...string) []string {
+	var alias string
+	if len(aa) > 0 {
+		alias = aa[0] + "."
+	}
+
+	return []string{
+		alias + "rel_record",
+		alias + "rel_node",
+		alias + "xref_record",
+		alias + "clock",
+		alias + "synced_at",
+	}
+}
+
+// {true true false false false false}
+
+// internalFederationRecordOriginEncoder encodes fields from types.RecordOrigin to store.Payload (map)
+//
+// Encoding is done by using generic approach or by calling encodeFederationRecordOrigin
+// func when rdbms.customEncoder=true
+func (s Store) internalFederationRecordOriginEncoder(res *types.RecordOrigin) store.Payload {
+	return store.Payload{
+		"rel_record":  res.RecordID,
+		"rel_node":    res.NodeID,
+		"xref_record": res.OriginRecordID,
+		"clock":       res.Clock,
+		"synced_at":   res.SyncedAt,
+	}
+}
+
+// checkFederationRecordOriginConstraints performs lookups (on valid) resource to check if any of the values on unique fields
+// already exists in the store
+//
+// Using built-in constraint checking would be more performant but unfortunately we can not rely
+// on the full support (MySQL does not support conditional indexes)
+func (s *Store) checkFederationRecordOriginConstraints(ctx context.Context, res *types.RecordOrigin) error {
+	// Consider resource valid when all fields in unique constraint check lookups
+	// have valid (non-empty) value
+	//
+	// Only string and uint64 are supported for now
+	// feel free to add additional types if needed
+	var valid = true
+
+	if !valid {
+		return nil
+	}
+
+	return nil
+}
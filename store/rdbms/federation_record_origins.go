@@ -0,0 +1,16 @@
+package rdbms
+
+import (
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/federation/types"
+)
+
+func (s Store) convertFederationRecordOriginFilter(f types.RecordOriginFilter) (query squirrel.SelectBuilder, err error) {
+	query = s.federationRecordOriginsSelectBuilder()
+
+	if f.NodeID > 0 {
+		query = query.Where("fdro.rel_node = ?", f.NodeID)
+	}
+
+	return
+}
@@ -54,6 +54,7 @@ func (g genericUpgrades) Upgrade(ctx context.Context, t *ddl.Table) error {
 	case "rbac_rules":
 		return g.all(ctx,
 			g.MergePermissionRulesTables,
+			g.AlterRbacRulesAddConditions,
 		)
 	case "actionlog":
 		return g.all(ctx,
@@ -64,14 +65,29 @@ func (g genericUpgrades) Upgrade(ctx context.Context, t *ddl.Table) error {
 			g.AlterUsersDropOrganisation,
 			g.AlterUsersDropRelatedUser,
 		)
+	case "roles":
+		return g.all(ctx,
+			g.AlterRolesAddParent,
+		)
+	case "role_members":
+		return g.all(ctx,
+			g.AlterRoleMembersAddValidity,
+		)
 	case "compose_module":
 		return g.all(ctx,
 			g.AlterComposeModuleRenameJsonToMeta,
+			g.AlterComposeModuleAddStorage,
+			g.AlterComposeModuleAddWorkflow,
+			g.AlterComposeModuleAddApproval,
 		)
 	case "compose_module_field":
 		return g.all(ctx,
 			g.AlterComposeModuleFieldAddExpresions,
 		)
+	case "compose_record_value":
+		return g.all(ctx,
+			g.AlterComposeRecordValueAddBlindIndex,
+		)
 	case "messaging_channel":
 		return g.all(ctx,
 			g.AlterMessagingChannelsDropOrganisation,
@@ -80,6 +96,14 @@ func (g genericUpgrades) Upgrade(ctx context.Context, t *ddl.Table) error {
 		return g.all(ctx,
 			g.AlterMessageAttachmentsRenameOwner,
 		)
+	case "federation_module_exposed":
+		return g.all(ctx,
+			g.AlterFederationModuleExposedAddRecordFilter,
+		)
+	case "federation_module_mapping":
+		return g.all(ctx,
+			g.AlterFederationModuleMappingAddConflictPolicy,
+		)
 		//case "compose_attachment_binds":
 		//	return g.all(ctx,
 		//		g.MigrateComposeAttachmentsToBindsTable,
@@ -359,11 +383,94 @@ func (g genericUpgrades) RenameTable(ctx context.Context, old, new string) error
 //	return nil
 //}
 
+func (g genericUpgrades) AlterRbacRulesAddConditions(ctx context.Context) (err error) {
+	var (
+		col = &ddl.Column{
+			Name:   "conditions",
+			Type:   ddl.ColumnType{Type: ddl.ColumnTypeJson},
+			IsNull: true,
+		}
+	)
+
+	_, err = g.u.AddColumn(ctx, "rbac_rules", col)
+	return
+}
+
+func (g genericUpgrades) AlterRolesAddParent(ctx context.Context) (err error) {
+	var (
+		col = &ddl.Column{
+			Name:         "rel_parent",
+			Type:         ddl.ColumnType{Type: ddl.ColumnTypeIdentifier},
+			IsNull:       false,
+			DefaultValue: "0",
+		}
+	)
+
+	_, err = g.u.AddColumn(ctx, "roles", col)
+	return
+}
+
+func (g genericUpgrades) AlterRoleMembersAddValidity(ctx context.Context) (err error) {
+	for _, name := range []string{"valid_from", "valid_until"} {
+		col := &ddl.Column{
+			Name:   name,
+			Type:   ddl.ColumnType{Type: ddl.ColumnTypeTimestamp},
+			IsNull: true,
+		}
+
+		if _, err = g.u.AddColumn(ctx, "role_members", col); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 func (g genericUpgrades) AlterComposeModuleRenameJsonToMeta(ctx context.Context) error {
 	_, err := g.u.RenameColumn(ctx, "compose_module", "json", "meta")
 	return err
 }
 
+func (g genericUpgrades) AlterComposeModuleAddStorage(ctx context.Context) (err error) {
+	var (
+		col = &ddl.Column{
+			Name:         "storage",
+			Type:         ddl.ColumnType{Type: ddl.ColumnTypeVarchar, Length: 32},
+			IsNull:       false,
+			DefaultValue: "''",
+		}
+	)
+
+	_, err = g.u.AddColumn(ctx, "compose_module", col)
+	return
+}
+
+func (g genericUpgrades) AlterComposeModuleAddWorkflow(ctx context.Context) (err error) {
+	var (
+		col = &ddl.Column{
+			Name:   "workflow",
+			Type:   ddl.ColumnType{Type: ddl.ColumnTypeJson},
+			IsNull: true,
+		}
+	)
+
+	_, err = g.u.AddColumn(ctx, "compose_module", col)
+	return
+}
+
+func (g genericUpgrades) AlterComposeModuleAddApproval(ctx context.Context) (err error) {
+	var (
+		col = &ddl.Column{
+			Name:   "approval",
+			Type:   ddl.ColumnType{Type: ddl.ColumnTypeJson},
+			IsNull: true,
+		}
+	)
+
+	_, err = g.u.AddColumn(ctx, "compose_module", col)
+	return
+}
+
 func (g genericUpgrades) AlterComposeModuleFieldAddExpresions(ctx context.Context) (err error) {
 	var (
 		col = &ddl.Column{
@@ -378,6 +485,48 @@ func (g genericUpgrades) AlterComposeModuleFieldAddExpresions(ctx context.Contex
 	return
 }
 
+func (g genericUpgrades) AlterComposeRecordValueAddBlindIndex(ctx context.Context) (err error) {
+	var (
+		col = &ddl.Column{
+			Name:         "blind_index",
+			Type:         ddl.ColumnType{Type: ddl.ColumnTypeVarchar, Length: 64},
+			IsNull:       false,
+			DefaultValue: "''",
+		}
+	)
+
+	_, err = g.u.AddColumn(ctx, "compose_record_value", col)
+	return
+}
+
+func (g genericUpgrades) AlterFederationModuleMappingAddConflictPolicy(ctx context.Context) (err error) {
+	var (
+		col = &ddl.Column{
+			Name:         "conflict_policy",
+			Type:         ddl.ColumnType{Type: ddl.ColumnTypeVarchar, Length: 32},
+			IsNull:       false,
+			DefaultValue: "''",
+		}
+	)
+
+	_, err = g.u.AddColumn(ctx, "federation_module_mapping", col)
+	return
+}
+
+func (g genericUpgrades) AlterFederationModuleExposedAddRecordFilter(ctx context.Context) (err error) {
+	var (
+		col = &ddl.Column{
+			Name:         "record_filter",
+			Type:         ddl.ColumnType{Type: ddl.ColumnTypeText},
+			IsNull:       false,
+			DefaultValue: "''",
+		}
+	)
+
+	_, err = g.u.AddColumn(ctx, "federation_module_exposed", col)
+	return
+}
+
 func (g genericUpgrades) AlterMessageAttachmentsRenameOwner(ctx context.Context) error {
 	_, err := g.u.RenameColumn(ctx, "messaging_attachment", "rel_user", "rel_owner")
 	return err
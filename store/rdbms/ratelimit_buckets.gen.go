@@ -0,0 +1,294 @@
+package rdbms
+
+// This file is an auto-generated file
+//
+// Template:    pkg/codegen/assets/store_rdbms.gen.go.tpl
+// Definitions: store/ratelimit_buckets.yaml
+//
+// Changes to this file may cause incorrect behavior
+// and will be lost if the code is regenerated.
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/pkg/ratelimit"
+	"github.com/cortezaproject/corteza-server/store"
+)
+
+var _ = errors.Is
+
+// QueryRatelimitBuckets queries the database, converts and checks each row and
+// returns collected set
+//
+// Fn also returns total number of fetched items and last fetched item so that the caller can construct cursor
+// for next page of results
+func (s Store) QueryRatelimitBuckets(
+	ctx context.Context,
+	q squirrel.Sqlizer,
+	check func(*ratelimit.Bucket) (bool, error),
+) ([]*ratelimit.Bucket, error) {
+	var (
+		set = make([]*ratelimit.Bucket, 0, DefaultSliceCapacity)
+		res *ratelimit.Bucket
+
+		// Query rows with
+		rows, err = s.Query(ctx, q)
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		if err = rows.Err(); err == nil {
+			res, err = s.internalRatelimitBucketRowScanner(rows)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		set = append(set, res)
+	}
+
+	return set, rows.Err()
+}
+
+// LookupRatelimitBucketByKey searches for rate limit bucket by key
+func (s Store) LookupRatelimitBucketByKey(ctx context.Context, key string) (*ratelimit.Bucket, error) {
+	return s.execLookupRatelimitBucket(ctx, squirrel.Eq{
+		s.preprocessColumn("rlb.bucket_key", ""): store.PreprocessValue(key, ""),
+	})
+}
+
+// CreateRatelimitBucket creates one or more rows in ratelimit_bucket table
+func (s Store) CreateRatelimitBucket(ctx context.Context, rr ...*ratelimit.Bucket) (err error) {
+	for _, res := range rr {
+		err = s.checkRatelimitBucketConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execCreateRatelimitBuckets(ctx, s.internalRatelimitBucketEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpdateRatelimitBucket updates one or more existing rows in ratelimit_bucket
+func (s Store) UpdateRatelimitBucket(ctx context.Context, rr ...*ratelimit.Bucket) error {
+	return s.partialRatelimitBucketUpdate(ctx, nil, rr...)
+}
+
+// partialRatelimitBucketUpdate updates one or more existing rows in ratelimit_bucket
+func (s Store) partialRatelimitBucketUpdate(ctx context.Context, onlyColumns []string, rr ...*ratelimit.Bucket) (err error) {
+	for _, res := range rr {
+		err = s.checkRatelimitBucketConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpdateRatelimitBuckets(
+			ctx,
+			squirrel.Eq{
+				s.preprocessColumn("rlb.bucket_key", ""): store.PreprocessValue(res.Key, ""),
+			},
+			s.internalRatelimitBucketEncoder(res).Skip("bucket_key").Only(onlyColumns...))
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// UpsertRatelimitBucket updates one or more existing rows in ratelimit_bucket
+func (s Store) UpsertRatelimitBucket(ctx context.Context, rr ...*ratelimit.Bucket) (err error) {
+	for _, res := range rr {
+		err = s.checkRatelimitBucketConstraints(ctx, res)
+		if err != nil {
+			return err
+		}
+
+		err = s.execUpsertRatelimitBuckets(ctx, s.internalRatelimitBucketEncoder(res))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteRatelimitBucket Deletes one or more rows from ratelimit_bucket table
+func (s Store) DeleteRatelimitBucket(ctx context.Context, rr ...*ratelimit.Bucket) (err error) {
+	for _, res := range rr {
+
+		err = s.execDeleteRatelimitBuckets(ctx, squirrel.Eq{
+			s.preprocessColumn("rlb.bucket_key", ""): store.PreprocessValue(res.Key, ""),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteRatelimitBucketByKey Deletes row from the ratelimit_bucket table
+func (s Store) DeleteRatelimitBucketByKey(ctx context.Context, key string) error {
+	return s.execDeleteRatelimitBuckets(ctx, squirrel.Eq{
+		s.preprocessColumn("rlb.bucket_key", ""): store.PreprocessValue(key, ""),
+	})
+}
+
+// TruncateRatelimitBuckets Deletes all rows from the ratelimit_bucket table
+func (s Store) TruncateRatelimitBuckets(ctx context.Context) error {
+	return s.Truncate(ctx, s.ratelimitBucketTable())
+}
+
+// execLookupRatelimitBucket prepares RatelimitBucket query and executes it,
+// returning ratelimit.Bucket (or error)
+func (s Store) execLookupRatelimitBucket(ctx context.Context, cnd squirrel.Sqlizer) (res *ratelimit.Bucket, err error) {
+	var (
+		row rowScanner
+	)
+
+	row, err = s.QueryRow(ctx, s.ratelimitBucketsSelectBuilder().Where(cnd))
+	if err != nil {
+		return
+	}
+
+	res, err = s.internalRatelimitBucketRowScanner(row)
+	if err != nil {
+		return
+	}
+
+	return res, nil
+}
+
+// execCreateRatelimitBuckets updates all matched (by cnd) rows in ratelimit_bucket with given data
+func (s Store) execCreateRatelimitBuckets(ctx context.Context, payload store.Payload) error {
+	return s.Exec(ctx, s.InsertBuilder(s.ratelimitBucketTable()).SetMap(payload))
+}
+
+// execUpdateRatelimitBuckets updates all matched (by cnd) rows in ratelimit_bucket with given data
+func (s Store) execUpdateRatelimitBuckets(ctx context.Context, cnd squirrel.Sqlizer, set store.Payload) error {
+	return s.Exec(ctx, s.UpdateBuilder(s.ratelimitBucketTable("rlb")).Where(cnd).SetMap(set))
+}
+
+// execUpsertRatelimitBuckets inserts new or updates matching (by-primary-key) rows in ratelimit_bucket with given data
+func (s Store) execUpsertRatelimitBuckets(ctx context.Context, set store.Payload) error {
+	upsert, err := s.config.UpsertBuilder(
+		s.config,
+		s.ratelimitBucketTable(),
+		set,
+		s.preprocessColumn("bucket_key", ""),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return s.Exec(ctx, upsert)
+}
+
+// execDeleteRatelimitBuckets Deletes all matched (by cnd) rows in ratelimit_bucket with given data
+func (s Store) execDeleteRatelimitBuckets(ctx context.Context, cnd squirrel.Sqlizer) error {
+	return s.Exec(ctx, s.DeleteBuilder(s.ratelimitBucketTable("rlb")).Where(cnd))
+}
+
+func (s Store) internalRatelimitBucketRowScanner(row rowScanner) (res *ratelimit.Bucket, err error) {
+	res = &ratelimit.Bucket{}
+
+	if _, has := s.config.RowScanners["ratelimitBucket"]; has {
+		scanner := s.config.RowScanners["ratelimitBucket"].(func(_ rowScanner, _ *ratelimit.Bucket) error)
+		err = scanner(row, res)
+	} else {
+		err = row.Scan(
+			&res.Key,
+			&res.Tokens,
+			&res.UpdatedAt,
+		)
+	}
+
+	if err == sql.ErrNoRows {
+		return nil, store.ErrNotFound.Stack(1)
+	}
+
+	if err != nil {
+		return nil, errors.Store("could not scan ratelimitBucket db row").Wrap(err)
+	} else {
+		return res, nil
+	}
+}
+
+// QueryRatelimitBuckets returns squirrel.SelectBuilder with set table and all columns
+func (s Store) ratelimitBucketsSelectBuilder() squirrel.SelectBuilder {
+	return s.SelectBuilder(s.ratelimitBucketTable("rlb"), s.ratelimitBucketColumns("rlb")...)
+}
+
+// ratelimitBucketTable name of the db table
+func (Store) ratelimitBucketTable(aa ...string) string {
+	var alias string
+	if len(aa) > 0 {
+		alias = " AS " + aa[0]
+	}
+
+	return "ratelimit_bucket" + alias
+}
+
+// RatelimitBucketColumns returns all defined table columns
+//
+// With optional string arg, all columns are returned aliased
+func (Store) ratelimitBucketColumns(aa ...string) []string {
+	var alias string
+	if len(aa) > 0 {
+		alias = aa[0] + "."
+	}
+
+	return []string{
+		alias + "bucket_key",
+		alias + "tokens",
+		alias + "updated_at",
+	}
+}
+
+// {false true false false false false}
+
+// internalRatelimitBucketEncoder encodes fields from ratelimit.Bucket to store.Payload (map)
+//
+// Encoding is done by using generic approach or by calling encodeRatelimitBucket
+// func when rdbms.customEncoder=true
+func (s Store) internalRatelimitBucketEncoder(res *ratelimit.Bucket) store.Payload {
+	return store.Payload{
+		"bucket_key": res.Key,
+		"tokens":     res.Tokens,
+		"updated_at": res.UpdatedAt,
+	}
+}
+
+// checkRatelimitBucketConstraints performs lookups (on valid) resource to check if any of the values on unique fields
+// already exists in the store
+//
+// Using built-in constraint checking would be more performant but unfortunately we can not rely
+// on the full support (MySQL does not support conditional indexes)
+func (s *Store) checkRatelimitBucketConstraints(ctx context.Context, res *ratelimit.Bucket) error {
+	// Consider resource valid when all fields in unique constraint check lookups
+	// have valid (non-empty) value
+	//
+	// Only string and uint64 are supported for now
+	// feel free to add additional types if needed
+	var valid = true
+
+	if !valid {
+		return nil
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package rdbms
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/pkg/ratelimit"
+	"github.com/cortezaproject/corteza-server/store"
+)
+
+// PurgeRatelimitBuckets removes buckets that were not updated since the given time
+func (s Store) PurgeRatelimitBuckets(ctx context.Context, f ratelimit.BucketFilter) error {
+	return s.execDeleteRatelimitBuckets(ctx, squirrel.Lt{"rlb.updated_at": f.UpdatedBefore})
+}
+
+// CompareAndSwapRatelimitBucket updates bucket only if its tokens and update time
+// in the store still match the previous state
+//
+// False is returned when bucket was modified (or removed) in the meantime
+func (s Store) CompareAndSwapRatelimitBucket(ctx context.Context, b, prev *ratelimit.Bucket) (bool, error) {
+	query, args, err := s.UpdateBuilder(s.ratelimitBucketTable()).
+		SetMap(s.internalRatelimitBucketEncoder(b)).
+		Where(squirrel.Eq{"bucket_key": prev.Key, "tokens": prev.Tokens, "updated_at": prev.UpdatedAt}).
+		ToSql()
+
+	if err != nil {
+		return false, err
+	}
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, store.HandleError(err, s.config.ErrorHandler)
+	}
+
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
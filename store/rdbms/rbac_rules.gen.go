@@ -231,6 +231,7 @@ func (s Store) internalRbacRuleRowScanner(row rowScanner) (res *rbac.Rule, err e
 			&res.Resource,
 			&res.Operation,
 			&res.Access,
+			&res.Conditions,
 		)
 	}
 
@@ -274,6 +275,7 @@ func (Store) rbacRuleColumns(aa ...string) []string {
 		alias + "resource",
 		alias + "operation",
 		alias + "access",
+		alias + "conditions",
 	}
 }
 
@@ -285,10 +287,11 @@ func (Store) rbacRuleColumns(aa ...string) []string {
 // func when rdbms.customEncoder=true
 func (s Store) internalRbacRuleEncoder(res *rbac.Rule) store.Payload {
 	return store.Payload{
-		"rel_role":  res.RoleID,
-		"resource":  res.Resource,
-		"operation": res.Operation,
-		"access":    res.Access,
+		"rel_role":   res.RoleID,
+		"resource":   res.Resource,
+		"operation":  res.Operation,
+		"access":     res.Access,
+		"conditions": res.Conditions,
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/cortezaproject/corteza-server/pkg/ql"
 	"github.com/cortezaproject/corteza-server/store"
+	"go.uber.org/zap"
 	"net/url"
 	"regexp"
 	"strconv"
@@ -114,6 +115,12 @@ type (
 		SqlSortHandler func(exp string, desc bool) string
 
 		CastModuleFieldToColumnType func(ModuleFieldTypeDetector, string) (string, error)
+
+		// SchemaUpgrader returns implementation specific schema upgrader
+		//
+		// Used for tables that are managed on runtime, like the
+		// dedicated compose record tables
+		SchemaUpgrader func(*zap.Logger, *Store) SchemaUpgrader
 	}
 )
 
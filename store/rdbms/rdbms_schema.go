@@ -16,6 +16,12 @@ type (
 		CreateTable(context.Context, *Table) error
 		After(context.Context) error
 	}
+
+	// SchemaUpgrader provides procedures to manage tables on runtime
+	SchemaUpgrader interface {
+		upgrader
+		CreateTable(context.Context, *Table) error
+	}
 )
 
 const (
@@ -49,12 +55,17 @@ func (s Schema) Tables() []*Table {
 	return []*Table{
 		s.Users(),
 		s.Credentials(),
+		s.FailedLogins(),
 		s.Roles(),
 		s.RoleMembers(),
 		s.Applications(),
 		s.Reminders(),
+		s.Templates(),
 		s.Attachments(),
 		s.ActionLog(),
+		s.EventbusOutbox(),
+		s.EventbusDeadLetter(),
+		s.RatelimitBucket(),
 		s.RbacRules(),
 		s.Settings(),
 		s.Labels(),
@@ -63,6 +74,8 @@ func (s Schema) Tables() []*Table {
 		s.ComposeModule(),
 		s.ComposeModuleField(),
 		s.ComposeNamespace(),
+		s.ComposeNamespaceVersion(),
+		s.ComposeRecordChangeRequest(),
 		s.ComposePage(),
 		s.ComposeRecord(),
 		s.ComposeRecordValue(),
@@ -79,6 +92,10 @@ func (s Schema) Tables() []*Table {
 		s.FederationModuleMapping(),
 		s.FederationNodes(),
 		s.FederationNodesSync(),
+		s.FederationRecordOrigin(),
+		s.FederationRecordConflict(),
+		s.FederationAttachmentOrigin(),
+		s.FederationExposedRecord(),
 	}
 }
 
@@ -117,11 +134,23 @@ func (Schema) Credentials() *Table {
 	)
 }
 
+func (Schema) FailedLogins() *Table {
+	return TableDef(`failed_logins`,
+		ColumnDef("login_key", ColumnTypeVarchar, ColumnTypeLength(resourceLength)),
+		ColumnDef("failures", ColumnTypeInteger, DefaultValue("0")),
+		ColumnDef("last_failed_at", ColumnTypeTimestamp),
+		ColumnDef("locked_at", ColumnTypeTimestamp, Null),
+
+		PrimaryKey(IColumn("login_key")),
+	)
+}
+
 func (Schema) Roles() *Table {
 	return TableDef(`roles`,
 		ID,
 		ColumnDef("name", ColumnTypeText),
 		ColumnDef("handle", ColumnTypeVarchar, ColumnTypeLength(handleLength)),
+		ColumnDef("rel_parent", ColumnTypeIdentifier, DefaultValue("0")),
 		ColumnDef("archived_at", ColumnTypeTimestamp, Null),
 		CUDTimestamps,
 
@@ -133,6 +162,8 @@ func (Schema) RoleMembers() *Table {
 	return TableDef(`role_members`,
 		ColumnDef("rel_role", ColumnTypeIdentifier),
 		ColumnDef("rel_user", ColumnTypeIdentifier),
+		ColumnDef("valid_from", ColumnTypeTimestamp, Null),
+		ColumnDef("valid_until", ColumnTypeTimestamp, Null),
 
 		AddIndex("unique_membership", IColumn("rel_role", "rel_user")),
 	)
@@ -149,6 +180,22 @@ func (Schema) Applications() *Table {
 	)
 }
 
+func (Schema) Templates() *Table {
+	return TableDef("templates",
+		ID,
+		ColumnDef("handle", ColumnTypeVarchar, ColumnTypeLength(handleLength)),
+		ColumnDef("language", ColumnTypeVarchar, ColumnTypeLength(32)),
+		ColumnDef("type", ColumnTypeVarchar, ColumnTypeLength(64)),
+		ColumnDef("is_partial", ColumnTypeBoolean, DefaultValue("false")),
+		ColumnDef("meta", ColumnTypeJson),
+		ColumnDef("template", ColumnTypeText),
+		ColumnDef("rel_owner", ColumnTypeIdentifier),
+		CUDTimestamps,
+
+		AddIndex("handle_language", IColumn("handle", "language")),
+	)
+}
+
 func (Schema) Reminders() *Table {
 	return TableDef("reminders",
 		ID,
@@ -204,12 +251,54 @@ func (Schema) ActionLog() *Table {
 	)
 }
 
+func (Schema) EventbusOutbox() *Table {
+	return TableDef("eventbus_outbox",
+		eventbusQueuedEventColumns,
+		AddIndex("scheduled_at", IColumn("scheduled_at")),
+	)
+}
+
+func (Schema) EventbusDeadLetter() *Table {
+	return TableDef("eventbus_dead_letter",
+		eventbusQueuedEventColumns,
+		AddIndex("failed_at", IColumn("failed_at")),
+	)
+}
+
+func (Schema) RatelimitBucket() *Table {
+	return TableDef("ratelimit_bucket",
+		ColumnDef("bucket_key", ColumnTypeVarchar, ColumnTypeLength(resourceLength)),
+		ColumnDef("tokens", ColumnTypeNumeric),
+		ColumnDef("updated_at", ColumnTypeTimestamp),
+
+		PrimaryKey(IColumn("bucket_key")),
+		AddIndex("updated_at", IColumn("updated_at")),
+	)
+}
+
+// Outbox and dead letters share the same structure
+func eventbusQueuedEventColumns(t *Table) {
+	t.Apply(
+		ID,
+		ColumnDef("resource_type", ColumnTypeVarchar, ColumnTypeLength(resourceLength)),
+		ColumnDef("event_type", ColumnTypeVarchar, ColumnTypeLength(64)),
+		ColumnDef("args", ColumnTypeJson),
+		ColumnDef("rel_invoker", ColumnTypeIdentifier, DefaultValue("0")),
+		ColumnDef("attempts", ColumnTypeInteger, DefaultValue("0")),
+		ColumnDef("last_error", ColumnTypeText),
+		ColumnDef("created_at", ColumnTypeTimestamp),
+		ColumnDef("scheduled_at", ColumnTypeTimestamp),
+		ColumnDef("failed_at", ColumnTypeTimestamp, Null),
+	)
+}
+
 func (Schema) RbacRules() *Table {
 	return TableDef("rbac_rules",
 		ColumnDef("rel_role", ColumnTypeIdentifier),
 		ColumnDef("resource", ColumnTypeVarchar, ColumnTypeLength(resourceLength)),
 		ColumnDef("operation", ColumnTypeVarchar, ColumnTypeLength(50)),
 		ColumnDef("access", ColumnTypeInteger),
+		ColumnDef("conditions", ColumnTypeJson, Null),
 
 		PrimaryKey(IColumn("rel_role", "resource", "operation")),
 	)
@@ -250,6 +339,7 @@ func (Schema) ComposeAttachment() *Table {
 		ColumnDef("preview_url", ColumnTypeText),
 		ColumnDef("name", ColumnTypeText),
 		ColumnDef("meta", ColumnTypeJson),
+		ColumnDef("storage", ColumnTypeVarchar, ColumnTypeLength(32), DefaultValue("''")),
 		CUDTimestamps,
 
 		AddIndex("namespace", IColumn("rel_namespace")),
@@ -305,6 +395,9 @@ func (Schema) ComposeModule() *Table {
 		ColumnDef("handle", ColumnTypeVarchar, ColumnTypeLength(handleLength)),
 		ColumnDef("name", ColumnTypeText),
 		ColumnDef("meta", ColumnTypeJson),
+		ColumnDef("storage", ColumnTypeVarchar, ColumnTypeLength(32), DefaultValue("''")),
+		ColumnDef("workflow", ColumnTypeJson, Null),
+		ColumnDef("approval", ColumnTypeJson, Null),
 		CUDTimestamps,
 
 		AddIndex("namespace", IColumn("rel_namespace")),
@@ -349,6 +442,45 @@ func (Schema) ComposeNamespace() *Table {
 	)
 }
 
+func (Schema) ComposeNamespaceVersion() *Table {
+	return TableDef("compose_namespace_version",
+		ID,
+		ColumnDef("rel_namespace", ColumnTypeIdentifier),
+		ColumnDef("status", ColumnTypeVarchar, ColumnTypeLength(16)),
+		ColumnDef("comment", ColumnTypeText),
+		ColumnDef("config", ColumnTypeJson),
+		ColumnDef("base", ColumnTypeVarchar, ColumnTypeLength(64), DefaultValue("''")),
+		ColumnDef("created_at", ColumnTypeTimestamp),
+		ColumnDef("created_by", ColumnTypeIdentifier),
+		ColumnDef("updated_at", ColumnTypeTimestamp, Null),
+		ColumnDef("published_at", ColumnTypeTimestamp, Null),
+		ColumnDef("published_by", ColumnTypeIdentifier, DefaultValue("0")),
+
+		AddIndex("namespace", IColumn("rel_namespace")),
+	)
+}
+
+func (Schema) ComposeRecordChangeRequest() *Table {
+	return TableDef("compose_record_change_request",
+		ID,
+		ColumnDef("rel_namespace", ColumnTypeIdentifier),
+		ColumnDef("rel_module", ColumnTypeIdentifier),
+		ColumnDef("rel_record", ColumnTypeIdentifier),
+		ColumnDef("status", ColumnTypeVarchar, ColumnTypeLength(16)),
+		ColumnDef("step", ColumnTypeInteger, DefaultValue("0")),
+		ColumnDef("proposed_change", ColumnTypeJson),
+		ColumnDef("decisions", ColumnTypeJson),
+		ColumnDef("created_at", ColumnTypeTimestamp),
+		ColumnDef("created_by", ColumnTypeIdentifier),
+		ColumnDef("updated_at", ColumnTypeTimestamp, Null),
+		ColumnDef("completed_at", ColumnTypeTimestamp, Null),
+		ColumnDef("completed_by", ColumnTypeIdentifier, DefaultValue("0")),
+
+		AddIndex("namespace", IColumn("rel_namespace")),
+		AddIndex("record", IColumn("rel_record")),
+	)
+}
+
 func (Schema) ComposePage() *Table {
 	return TableDef("compose_page",
 		ID,
@@ -391,11 +523,13 @@ func (Schema) ComposeRecordValue() *Table {
 		ColumnDef("name", ColumnTypeVarchar, ColumnTypeLength(64)),
 		ColumnDef("value", ColumnTypeText, ColumnTypeFlag("mysqlLongText", true)),
 		ColumnDef("ref", ColumnTypeIdentifier),
+		ColumnDef("blind_index", ColumnTypeVarchar, ColumnTypeLength(64), DefaultValue("''")),
 		ColumnDef("place", ColumnTypeInteger),
 		ColumnDef("deleted_at", ColumnTypeTimestamp, Null),
 
 		PrimaryKey(IColumn("record_id", "name", "place")),
 		AddIndex("ref", IColumn("ref"), IWhere("ref > 0")),
+		AddIndex("blind_index", IColumn("name", "blind_index"), IWhere("blind_index <> ''")),
 	)
 }
 
@@ -516,6 +650,7 @@ func (Schema) FederationModuleExposed() *Table {
 		ColumnDef("rel_compose_module", ColumnTypeIdentifier),
 		ColumnDef("rel_compose_namespace", ColumnTypeIdentifier),
 		ColumnDef("fields", ColumnTypeText),
+		ColumnDef("record_filter", ColumnTypeText),
 		CUDTimestamps,
 		CUDUsers,
 
@@ -529,6 +664,7 @@ func (Schema) FederationModuleMapping() *Table {
 		ColumnDef("rel_compose_module", ColumnTypeIdentifier),
 		ColumnDef("rel_compose_namespace", ColumnTypeIdentifier),
 		ColumnDef("field_mapping", ColumnTypeText),
+		ColumnDef("conflict_policy", ColumnTypeVarchar, ColumnTypeLength(32), DefaultValue("''")),
 
 		AddIndex("unique_module_compose_module", IColumn("rel_federation_module", "rel_compose_module", "rel_compose_namespace")),
 	)
@@ -559,3 +695,55 @@ func (Schema) FederationNodesSync() *Table {
 		ColumnDef("time_action", ColumnTypeTimestamp),
 	)
 }
+
+func (Schema) FederationRecordOrigin() *Table {
+	return TableDef("federation_record_origin",
+		ColumnDef("rel_record", ColumnTypeIdentifier),
+		ColumnDef("rel_node", ColumnTypeIdentifier),
+		ColumnDef("xref_record", ColumnTypeIdentifier),
+		ColumnDef("clock", ColumnTypeJson),
+		ColumnDef("synced_at", ColumnTypeTimestamp, Null),
+		PrimaryKey(IColumn("rel_record")),
+
+		AddIndex("node_origin", IColumn("rel_node", "xref_record")),
+	)
+}
+
+func (Schema) FederationAttachmentOrigin() *Table {
+	return TableDef("federation_attachment_origin",
+		ColumnDef("rel_attachment", ColumnTypeIdentifier),
+		ColumnDef("rel_node", ColumnTypeIdentifier),
+		ColumnDef("xref_attachment", ColumnTypeIdentifier),
+		PrimaryKey(IColumn("rel_attachment")),
+
+		AddIndex("unique_node_origin", IColumn("rel_node", "xref_attachment")),
+	)
+}
+
+func (Schema) FederationExposedRecord() *Table {
+	return TableDef("federation_exposed_record",
+		ColumnDef("rel_exposed_module", ColumnTypeIdentifier),
+		ColumnDef("rel_record", ColumnTypeIdentifier),
+		ColumnDef("exposed_at", ColumnTypeTimestamp),
+		PrimaryKey(IColumn("rel_exposed_module", "rel_record")),
+	)
+}
+
+func (Schema) FederationRecordConflict() *Table {
+	return TableDef("federation_record_conflict",
+		ID,
+		ColumnDef("rel_node", ColumnTypeIdentifier),
+		ColumnDef("rel_module", ColumnTypeIdentifier),
+		ColumnDef("rel_record", ColumnTypeIdentifier),
+		ColumnDef("rel_compose_module", ColumnTypeIdentifier),
+		ColumnDef("rel_compose_namespace", ColumnTypeIdentifier),
+		ColumnDef("record_values", ColumnTypeJson),
+		ColumnDef("clock", ColumnTypeJson),
+		ColumnDef("resolution", ColumnTypeVarchar, ColumnTypeLength(32)),
+		ColumnDef("created_at", ColumnTypeTimestamp),
+		ColumnDef("resolved_at", ColumnTypeTimestamp, Null),
+		ColumnDef("resolved_by", ColumnTypeIdentifier, DefaultValue("0")),
+
+		AddIndex("node_record", IColumn("rel_node", "rel_record"), IWhere("resolved_at IS NULL")),
+	)
+}
@@ -230,6 +230,8 @@ func (s Store) internalRoleMemberRowScanner(row rowScanner) (res *types.RoleMemb
 		err = row.Scan(
 			&res.UserID,
 			&res.RoleID,
+			&res.ValidFrom,
+			&res.ValidUntil,
 		)
 	}
 
@@ -271,6 +273,8 @@ func (Store) roleMemberColumns(aa ...string) []string {
 	return []string{
 		alias + "rel_user",
 		alias + "rel_role",
+		alias + "valid_from",
+		alias + "valid_until",
 	}
 }
 
@@ -282,8 +286,10 @@ func (Store) roleMemberColumns(aa ...string) []string {
 // func when rdbms.customEncoder=true
 func (s Store) internalRoleMemberEncoder(res *types.RoleMember) store.Payload {
 	return store.Payload{
-		"rel_user": res.UserID,
-		"rel_role": res.RoleID,
+		"rel_user":    res.UserID,
+		"rel_role":    res.RoleID,
+		"valid_from":  res.ValidFrom,
+		"valid_until": res.ValidUntil,
 	}
 }
 
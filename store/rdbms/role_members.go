@@ -1,10 +1,19 @@
 package rdbms
 
 import (
+	"time"
+
 	"github.com/Masterminds/squirrel"
 	"github.com/cortezaproject/corteza-server/system/types"
 )
 
+const (
+	// roleMemberValidCnd filters out expired and not-yet-valid memberships
+	//
+	// Expects current time twice as query arguments
+	roleMemberValidCnd = "(valid_from IS NULL OR valid_from <= ?) AND (valid_until IS NULL OR valid_until > ?)"
+)
+
 func (s Store) convertRoleMemberFilter(f types.RoleMemberFilter) (query squirrel.SelectBuilder, err error) {
 	query = s.roleMembersSelectBuilder()
 
@@ -16,5 +25,10 @@ func (s Store) convertRoleMemberFilter(f types.RoleMemberFilter) (query squirrel
 		query = query.Where(squirrel.Eq{"rm.rel_user": f.UserID})
 	}
 
+	if f.ValidOnly {
+		n := time.Now().UTC()
+		query = query.Where(squirrel.Expr(roleMemberValidCnd, n, n))
+	}
+
 	return
 }
@@ -458,6 +458,7 @@ func (s Store) internalRoleRowScanner(row rowScanner) (res *types.Role, err erro
 			&res.ID,
 			&res.Name,
 			&res.Handle,
+			&res.ParentID,
 			&res.CreatedAt,
 			&res.UpdatedAt,
 			&res.ArchivedAt,
@@ -504,6 +505,7 @@ func (Store) roleColumns(aa ...string) []string {
 		alias + "id",
 		alias + "name",
 		alias + "handle",
+		alias + "rel_parent",
 		alias + "created_at",
 		alias + "updated_at",
 		alias + "archived_at",
@@ -538,6 +540,7 @@ func (s Store) internalRoleEncoder(res *types.Role) store.Payload {
 		"id":          res.ID,
 		"name":        res.Name,
 		"handle":      res.Handle,
+		"rel_parent":  res.ParentID,
 		"created_at":  res.CreatedAt,
 		"updated_at":  res.UpdatedAt,
 		"archived_at": res.ArchivedAt,
@@ -2,6 +2,8 @@ package rdbms
 
 import (
 	"context"
+	"time"
+
 	"github.com/Masterminds/squirrel"
 	"github.com/cortezaproject/corteza-server/pkg/filter"
 	"github.com/cortezaproject/corteza-server/system/types"
@@ -22,7 +24,9 @@ func (s Store) convertRoleFilter(f types.RoleFilter) (query squirrel.SelectBuild
 	}
 
 	if f.MemberID > 0 {
-		query = query.Where(squirrel.Expr("rl.ID IN (SELECT rel_role FROM role_members AS m WHERE m.rel_user = ?)", f.MemberID))
+		// expired and not-yet-valid memberships are not considered
+		n := time.Now().UTC()
+		query = query.Where(squirrel.Expr("rl.ID IN (SELECT rel_role FROM role_members AS m WHERE m.rel_user = ? AND "+roleMemberValidCnd+")", f.MemberID, n, n))
 	}
 
 	if f.Query != "" {
@@ -0,0 +1,49 @@
+package rdbms
+
+import (
+	"github.com/Masterminds/squirrel"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/system/types"
+)
+
+func (s Store) convertTemplateFilter(f types.TemplateFilter) (query squirrel.SelectBuilder, err error) {
+	query = s.templatesSelectBuilder()
+
+	query = filter.StateCondition(query, "tpl.deleted_at", f.Deleted)
+
+	switch f.Partial {
+	case filter.StateExcluded:
+		query = query.Where(squirrel.Eq{"tpl.is_partial": false})
+	case filter.StateExclusive:
+		query = query.Where(squirrel.Eq{"tpl.is_partial": true})
+	}
+
+	if len(f.TemplateID) > 0 {
+		query = query.Where(squirrel.Eq{"tpl.id": f.TemplateID})
+	}
+
+	if f.Query != "" {
+		qs := f.Query + "%"
+		query = query.Where(squirrel.Or{
+			squirrel.Like{"tpl.handle": qs},
+		})
+	}
+
+	if f.Handle != "" {
+		query = query.Where(squirrel.Eq{"tpl.handle": f.Handle})
+	}
+
+	if f.Language != "" {
+		query = query.Where(squirrel.Eq{"tpl.language": f.Language})
+	}
+
+	if f.Type != "" {
+		query = query.Where(squirrel.Eq{"tpl.type": f.Type})
+	}
+
+	if f.OwnerID > 0 {
+		query = query.Where(squirrel.Eq{"tpl.rel_owner": f.OwnerID})
+	}
+
+	return
+}
@@ -3,6 +3,8 @@ package rdbms
 import (
 	"context"
 	"fmt"
+	"time"
+
 	"github.com/Masterminds/squirrel"
 	"github.com/cortezaproject/corteza-server/pkg/filter"
 	"github.com/cortezaproject/corteza-server/system/types"
@@ -23,11 +25,15 @@ func (s Store) convertUserFilter(f types.UserFilter) (query squirrel.SelectBuild
 	}
 
 	if len(f.RoleID) > 0 {
-		or := squirrel.Or{}
+		var (
+			or = squirrel.Or{}
+			n  = time.Now().UTC()
+		)
+
 		// Due to lack of support for more exotic expressions (slice of values inside subquery)
 		// we'll use set of OR expressions as a workaround
 		for _, roleID := range f.RoleID {
-			or = append(or, squirrel.Expr("usr.ID IN (SELECT rel_user FROM role_members WHERE rel_role = ?)", roleID))
+			or = append(or, squirrel.Expr("usr.ID IN (SELECT rel_user FROM role_members WHERE rel_role = ? AND "+roleMemberValidCnd+")", roleID, n, n))
 		}
 
 		query = query.Where(or)
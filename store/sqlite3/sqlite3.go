@@ -46,6 +46,7 @@ func Connect(ctx context.Context, dsn string) (store.Storer, error) {
 	cfg.TxDisabled = true
 	cfg.SqlFunctionHandler = sqlFunctionHandler
 	cfg.CastModuleFieldToColumnType = fieldToColumnTypeCaster
+	cfg.SchemaUpgrader = schemaUpgrader
 
 	if s.Store, err = rdbms.Connect(ctx, cfg); err != nil {
 		return nil, err
@@ -10,6 +10,8 @@ import (
 	"github.com/cortezaproject/corteza-server/store/rdbms"
 	"github.com/cortezaproject/corteza-server/store/rdbms/ddl"
 	"go.uber.org/zap"
+	"regexp"
+	"strings"
 )
 
 type (
@@ -39,6 +41,13 @@ func NewUpgrader(log *zap.Logger, store *Store) *upgrader {
 	return g
 }
 
+// schemaUpgrader returns upgrader for the (transaction) store
+//
+// Used by the rdbms store for the tables that are managed on runtime
+func schemaUpgrader(log *zap.Logger, s *rdbms.Store) rdbms.SchemaUpgrader {
+	return NewUpgrader(log, &Store{Store: s})
+}
+
 // Before runs before all tables are upgraded
 func (u upgrader) Before(ctx context.Context) error {
 	return rdbms.GenericUpgrades(u.log, u).Before(ctx)
@@ -148,6 +157,9 @@ func (u upgrader) AddColumn(ctx context.Context, table string, col *ddl.Column)
 }
 
 // DropColumn drops column from table
+//
+// SQLite does not support dropping columns (prior to 3.35)
+// so the table is rebuilt without it
 func (u upgrader) DropColumn(ctx context.Context, table, column string) (dropped bool, err error) {
 	err = func() error {
 		var columns ddl.Columns
@@ -159,7 +171,7 @@ func (u upgrader) DropColumn(ctx context.Context, table, column string) (dropped
 			return nil
 		}
 
-		if err = u.Exec(ctx, u.ddl.DropColumn(table, column)); err != nil {
+		if err = u.rebuildTableWithout(ctx, table, column); err != nil {
 			return err
 		}
 
@@ -220,6 +232,91 @@ func (u upgrader) AddPrimaryKey(ctx context.Context, table string, ind *ddl.Inde
 	return false, fmt.Errorf("adding primary keys on sqlite tables is not implemented")
 }
 
+// rebuildTableWithout copies table without the given column
+//
+// Table's indexes that do not cover the column are recreated
+func (u upgrader) rebuildTableWithout(ctx context.Context, table, column string) error {
+	type (
+		col struct {
+			CID          int            `db:"cid"`
+			Name         string         `db:"name"`
+			NotNull      bool           `db:"notnull"`
+			PrimaryKey   int            `db:"pk"`
+			DefaultValue sql.NullString `db:"dflt_value"`
+			Type         string         `db:"type"`
+		}
+	)
+
+	var (
+		cols    []*col
+		indexes []string
+
+		tmp = table + "_rebuild"
+
+		defs, names, pk []string
+	)
+
+	if err := u.s.DB().SelectContext(ctx, &cols, fmt.Sprintf(`PRAGMA TABLE_INFO(%q)`, table)); err != nil {
+		return err
+	}
+
+	err := u.s.DB().SelectContext(ctx, &indexes, "SELECT sql FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND sql IS NOT NULL", table)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range cols {
+		if c.Name == column {
+			continue
+		}
+
+		def := c.Name + " " + c.Type
+		if c.NotNull {
+			def += " NOT NULL"
+		}
+
+		if c.DefaultValue.Valid {
+			def += " DEFAULT " + c.DefaultValue.String
+		}
+
+		if c.PrimaryKey > 0 {
+			pk = append(pk, c.Name)
+		}
+
+		defs = append(defs, def)
+		names = append(names, c.Name)
+	}
+
+	if len(pk) > 0 {
+		defs = append(defs, "PRIMARY KEY ("+strings.Join(pk, ", ")+")")
+	}
+
+	var (
+		columnRef = regexp.MustCompile(`\b` + regexp.QuoteMeta(column) + `\b`)
+
+		ss = []string{
+			fmt.Sprintf("CREATE TABLE %s (%s)", tmp, strings.Join(defs, ", ")),
+			fmt.Sprintf("INSERT INTO %s (%s) SELECT %[2]s FROM %s", tmp, strings.Join(names, ", "), table),
+			fmt.Sprintf("DROP TABLE %s", table),
+			fmt.Sprintf("ALTER TABLE %s RENAME TO %s", tmp, table),
+		}
+	)
+
+	for _, i := range indexes {
+		if !columnRef.MatchString(i) {
+			ss = append(ss, i)
+		}
+	}
+
+	for _, s := range ss {
+		if err = u.Exec(ctx, s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // loads and returns all tables columns
 func (u upgrader) getColumns(ctx context.Context, table string) (out ddl.Columns, err error) {
 	type (
@@ -227,7 +324,7 @@ func (u upgrader) getColumns(ctx context.Context, table string) (out ddl.Columns
 			CID          int            `db:"cid"`
 			Name         string         `db:"name"`
 			NotNull      bool           `db:"notnull"`
-			PrimaryKey   bool           `db:"pk"`
+			PrimaryKey   int            `db:"pk"`
 			DefaultValue sql.NullString `db:"dflt_value"`
 			Type         string         `db:"type"`
 		}
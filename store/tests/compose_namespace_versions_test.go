@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/id"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/stretchr/testify/require"
+)
+
+func testComposeNamespaceVersions(t *testing.T, s store.ComposeNamespaceVersions) {
+	var (
+		ctx = context.Background()
+		req = require.New(t)
+
+		namespaceID = id.Next()
+
+		makeNew = func(status types.NamespaceVersionStatus) *types.NamespaceVersion {
+			// minimum data set for new namespace version
+			return &types.NamespaceVersion{
+				ID:          id.Next(),
+				NamespaceID: namespaceID,
+				Status:      status,
+				CreatedAt:   time.Now(),
+				Config: types.NamespaceConfig{
+					Modules: types.ModuleSet{&types.Module{ID: id.Next(), Handle: "mod"}},
+				},
+			}
+		}
+
+		truncAndCreate = func(t *testing.T) (*require.Assertions, *types.NamespaceVersion) {
+			req := require.New(t)
+			req.NoError(s.TruncateComposeNamespaceVersions(ctx))
+			res := makeNew(types.NamespaceVersionDraft)
+			req.NoError(s.CreateComposeNamespaceVersion(ctx, res))
+			return req, res
+		}
+	)
+
+	t.Run("create", func(t *testing.T) {
+		req.NoError(s.CreateComposeNamespaceVersion(ctx, makeNew(types.NamespaceVersionDraft)))
+	})
+
+	t.Run("lookup by ID", func(t *testing.T) {
+		req, v := truncAndCreate(t)
+		fetched, err := s.LookupComposeNamespaceVersionByID(ctx, v.ID)
+		req.NoError(err)
+		req.Equal(v.NamespaceID, fetched.NamespaceID)
+		req.Equal(types.NamespaceVersionDraft, fetched.Status)
+		req.Len(fetched.Config.Modules, 1)
+		req.Equal("mod", fetched.Config.Modules[0].Handle)
+		req.Nil(fetched.PublishedAt)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		req, v := truncAndCreate(t)
+		v.Status = types.NamespaceVersionPublished
+		v.PublishedAt = &v.CreatedAt
+
+		req.NoError(s.UpdateComposeNamespaceVersion(ctx, v))
+
+		updated, err := s.LookupComposeNamespaceVersionByID(ctx, v.ID)
+		req.NoError(err)
+		req.Equal(types.NamespaceVersionPublished, updated.Status)
+		req.NotNil(updated.PublishedAt)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		req, v := truncAndCreate(t)
+		req.NoError(s.DeleteComposeNamespaceVersionByID(ctx, v.ID))
+		_, err := s.LookupComposeNamespaceVersionByID(ctx, v.ID)
+		req.EqualError(err, store.ErrNotFound.Error())
+	})
+
+	t.Run("search", func(t *testing.T) {
+		req, _ := truncAndCreate(t)
+		req.NoError(s.CreateComposeNamespaceVersion(ctx, makeNew(types.NamespaceVersionPublished), makeNew(types.NamespaceVersionPublished)))
+
+		set, _, err := s.SearchComposeNamespaceVersions(ctx, types.NamespaceVersionFilter{NamespaceID: namespaceID})
+		req.NoError(err)
+		req.Len(set, 3)
+
+		set, _, err = s.SearchComposeNamespaceVersions(ctx, types.NamespaceVersionFilter{NamespaceID: namespaceID, Status: types.NamespaceVersionDraft})
+		req.NoError(err)
+		req.Len(set, 1)
+
+		set, _, err = s.SearchComposeNamespaceVersions(ctx, types.NamespaceVersionFilter{NamespaceID: id.Next()})
+		req.NoError(err)
+		req.Len(set, 0)
+	})
+}
@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/id"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/stretchr/testify/require"
+)
+
+func testComposeRecordChangeRequests(t *testing.T, s store.ComposeRecordChangeRequests) {
+	var (
+		ctx = context.Background()
+		req = require.New(t)
+
+		namespaceID = id.Next()
+		recordID    = id.Next()
+
+		makeNew = func(status types.RecordChangeRequestStatus) *types.RecordChangeRequest {
+			// minimum data set for new change request
+			return &types.RecordChangeRequest{
+				ID:          id.Next(),
+				NamespaceID: namespaceID,
+				ModuleID:    id.Next(),
+				RecordID:    recordID,
+				Status:      status,
+				CreatedAt:   time.Now(),
+				Change: types.RecordChange{
+					Fields: []string{"discount"},
+					Values: types.RecordValueSet{&types.RecordValue{Name: "discount", Value: "20"}},
+				},
+			}
+		}
+
+		truncAndCreate = func(t *testing.T) (*require.Assertions, *types.RecordChangeRequest) {
+			req := require.New(t)
+			req.NoError(s.TruncateComposeRecordChangeRequests(ctx))
+			res := makeNew(types.RecordChangeRequestPending)
+			req.NoError(s.CreateComposeRecordChangeRequest(ctx, res))
+			return req, res
+		}
+	)
+
+	t.Run("create", func(t *testing.T) {
+		req.NoError(s.CreateComposeRecordChangeRequest(ctx, makeNew(types.RecordChangeRequestPending)))
+	})
+
+	t.Run("lookup by ID", func(t *testing.T) {
+		req, cr := truncAndCreate(t)
+		fetched, err := s.LookupComposeRecordChangeRequestByID(ctx, cr.ID)
+		req.NoError(err)
+		req.Equal(cr.RecordID, fetched.RecordID)
+		req.Equal(types.RecordChangeRequestPending, fetched.Status)
+		req.Equal([]string{"discount"}, fetched.Change.Fields)
+		req.Len(fetched.Change.Values, 1)
+		req.Equal("20", fetched.Change.Values[0].Value)
+		req.Len(fetched.Decisions, 0)
+		req.Nil(fetched.CompletedAt)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		req, cr := truncAndCreate(t)
+		cr.Status = types.RecordChangeRequestApproved
+		cr.Step = 1
+		cr.CompletedAt = &cr.CreatedAt
+		cr.Decisions = types.RecordChangeDecisionSet{
+			&types.RecordChangeDecision{UserID: id.Next(), Approved: true, Comment: "ok", CreatedAt: cr.CreatedAt},
+		}
+
+		req.NoError(s.UpdateComposeRecordChangeRequest(ctx, cr))
+
+		updated, err := s.LookupComposeRecordChangeRequestByID(ctx, cr.ID)
+		req.NoError(err)
+		req.Equal(types.RecordChangeRequestApproved, updated.Status)
+		req.Equal(1, updated.Step)
+		req.NotNil(updated.CompletedAt)
+		req.Len(updated.Decisions, 1)
+		req.Equal("ok", updated.Decisions[0].Comment)
+	})
+
+	t.Run("compare and swap", func(t *testing.T) {
+		req, cr := truncAndCreate(t)
+
+		prev, err := s.LookupComposeRecordChangeRequestByID(ctx, cr.ID)
+		req.NoError(err)
+
+		upd := *prev
+		upd.Status = types.RecordChangeRequestApproved
+		upd.UpdatedAt = &cr.CreatedAt
+
+		ok, err := s.CompareAndSwapComposeRecordChangeRequest(ctx, &upd, prev)
+		req.NoError(err)
+		req.True(ok)
+
+		// previous state is no longer in the store
+		ok, err = s.CompareAndSwapComposeRecordChangeRequest(ctx, &upd, prev)
+		req.NoError(err)
+		req.False(ok)
+
+		updated, err := s.LookupComposeRecordChangeRequestByID(ctx, cr.ID)
+		req.NoError(err)
+		req.Equal(types.RecordChangeRequestApproved, updated.Status)
+		req.NotNil(updated.UpdatedAt)
+
+		upd = *updated
+		upd.Status = types.RecordChangeRequestCanceled
+
+		ok, err = s.CompareAndSwapComposeRecordChangeRequest(ctx, &upd, updated)
+		req.NoError(err)
+		req.True(ok)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		req, cr := truncAndCreate(t)
+		req.NoError(s.DeleteComposeRecordChangeRequestByID(ctx, cr.ID))
+		_, err := s.LookupComposeRecordChangeRequestByID(ctx, cr.ID)
+		req.EqualError(err, store.ErrNotFound.Error())
+	})
+
+	t.Run("search", func(t *testing.T) {
+		req, _ := truncAndCreate(t)
+		req.NoError(s.CreateComposeRecordChangeRequest(ctx, makeNew(types.RecordChangeRequestApproved), makeNew(types.RecordChangeRequestRejected)))
+
+		set, _, err := s.SearchComposeRecordChangeRequests(ctx, types.RecordChangeRequestFilter{NamespaceID: namespaceID})
+		req.NoError(err)
+		req.Len(set, 3)
+
+		set, _, err = s.SearchComposeRecordChangeRequests(ctx, types.RecordChangeRequestFilter{RecordID: recordID, Status: types.RecordChangeRequestPending})
+		req.NoError(err)
+		req.Len(set, 1)
+
+		set, _, err = s.SearchComposeRecordChangeRequests(ctx, types.RecordChangeRequestFilter{NamespaceID: id.Next()})
+		req.NoError(err)
+		req.Len(set, 0)
+	})
+}
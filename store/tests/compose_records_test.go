@@ -766,4 +766,204 @@ func testComposeRecords(t *testing.T, s store.ComposeRecords) {
 		req.Equal("1st,1;2nd,22;3rd,3", stringifyValues(set, "str1", "num1"))
 
 	})
+
+	t.Run("dedicated storage", func(t *testing.T) {
+		var (
+			req = require.New(t)
+			err error
+			set types.RecordSet
+			rec *types.Record
+
+			dmod = &types.Module{
+				ID:          id.Next(),
+				NamespaceID: mod.NamespaceID,
+				Name:        "testComposeRecordsDedicated",
+				Storage:     types.ModuleStorageDedicated,
+				CreatedAt:   time.Now(),
+				Fields: types.ModuleFieldSet{
+					&types.ModuleField{ID: id.Next(), Kind: "String", Name: "str1"},
+					&types.ModuleField{ID: id.Next(), Kind: "Number", Name: "num1"},
+					&types.ModuleField{ID: id.Next(), Kind: "Bool", Name: "bool1"},
+					&types.ModuleField{ID: id.Next(), Kind: "Record", Name: "ref1"},
+					&types.ModuleField{ID: id.Next(), Kind: "String", Name: "multi1", Multi: true},
+				},
+			}
+
+			makeDedicated = func(vv ...*types.RecordValue) *types.Record {
+				r := makeNew(vv...)
+				r.ModuleID = dmod.ID
+				return r
+			}
+
+			search = func(f types.RecordFilter) types.RecordSet {
+				set, _, err := s.SearchComposeRecords(ctx, dmod, f)
+				req.NoError(err)
+				return set
+			}
+		)
+
+		req.NoError(s.UpgradeComposeRecordStorage(ctx, nil, dmod))
+		req.NoError(s.TruncateComposeRecords(ctx, dmod))
+
+		// make sure the table is removed
+		defer s.MigrateComposeRecordStorage(ctx, dmod, types.ModuleStorageDefault)
+
+		rr := types.RecordSet{
+			makeDedicated(
+				&types.RecordValue{Name: "str1", Value: "a"},
+				&types.RecordValue{Name: "num1", Value: "1.5"},
+				&types.RecordValue{Name: "bool1", Value: "1"},
+				&types.RecordValue{Name: "ref1", Value: "42", Ref: 42},
+				&types.RecordValue{Name: "multi1", Value: "m1", Place: 0},
+				&types.RecordValue{Name: "multi1", Value: "m2", Place: 1},
+			),
+			makeDedicated(
+				&types.RecordValue{Name: "str1", Value: "b"},
+				&types.RecordValue{Name: "num1", Value: "2"},
+				&types.RecordValue{Name: "bool1", Value: "0"},
+			),
+			makeDedicated(
+				&types.RecordValue{Name: "str1", Value: "c"},
+				&types.RecordValue{Name: "num1", Value: "3"},
+				&types.RecordValue{Name: "bool1", Value: "1"},
+			),
+		}
+
+		req.NoError(s.CreateComposeRecord(ctx, dmod, rr...))
+
+		rec, err = s.LookupComposeRecordByID(ctx, dmod, rr[0].ID)
+		req.NoError(err)
+		req.Len(rec.Values, 6)
+		req.Equal("a", rec.Values.Get("str1", 0).Value)
+		req.Equal("1.5", rec.Values.Get("num1", 0).Value)
+		req.Equal("1", rec.Values.Get("bool1", 0).Value)
+		req.Equal(uint64(42), rec.Values.Get("ref1", 0).Ref)
+		req.Equal("m2", rec.Values.Get("multi1", 1).Value)
+
+		set = search(types.RecordFilter{Query: "num1 > 1 AND bool1 = 1", Sorting: filter.Sorting{Sort: filter.SortExprSet{{Column: "str1", Descending: true}}}})
+		req.Equal("c,3;a,1.5", stringifyValues(set, "str1", "num1"))
+
+		set = search(types.RecordFilter{Query: "multi1 = 'm2' OR str1 = 'b'", Sorting: filter.Sorting{Sort: filter.SortExprSet{{Column: "num1"}}}})
+		req.Equal("a;b", stringifyValues(set, "str1"))
+
+		rr[1].Values = types.RecordValueSet{
+			&types.RecordValue{RecordID: rr[1].ID, Name: "str1", Value: "bb"},
+			&types.RecordValue{RecordID: rr[1].ID, Name: "multi1", Value: "m3"},
+		}
+		req.NoError(s.UpdateComposeRecord(ctx, dmod, rr[1]))
+		req.NoError(s.PartialComposeRecordValueUpdate(ctx, dmod, &types.RecordValue{RecordID: rr[2].ID, Name: "num1", Value: "33"}))
+
+		set = search(types.RecordFilter{Sorting: filter.Sorting{Sort: filter.SortExprSet{{Column: "str1"}}}})
+		req.Equal("a,1.5,m1;bb,<NIL>,m3;c,33,<NIL>", stringifyValues(set, "str1", "num1", "multi1"))
+
+		t.Run("field changes", func(t *testing.T) {
+			req := require.New(t)
+			old := dmod.Clone()
+
+			// rename, remove, switch single/multi value
+			dmod.Fields[0].Name = "str9"
+			dmod.Fields[1].DeletedAt = now()
+			dmod.Fields[4].Multi = false
+			dmod.Fields = append(dmod.Fields, &types.ModuleField{ID: id.Next(), Kind: "String", Name: "new1"})
+
+			req.NoError(s.UpgradeComposeRecordStorage(ctx, old, dmod))
+
+			set = search(types.RecordFilter{Query: "multi1 = 'm3'"})
+			req.Equal("bb,m3,<NIL>", stringifyValues(set, "str9", "multi1", "new1"))
+
+			set = search(types.RecordFilter{Sorting: filter.Sorting{Sort: filter.SortExprSet{{Column: "str9"}}}})
+			req.Equal("a,m1;bb,m3;c,<NIL>", stringifyValues(set, "str9", "multi1"))
+		})
+
+		t.Run("migrate", func(t *testing.T) {
+			req := require.New(t)
+
+			req.NoError(s.MigrateComposeRecordStorage(ctx, dmod, types.ModuleStorageDefault))
+			dmod.Storage = types.ModuleStorageDefault
+
+			set = search(types.RecordFilter{Query: "bool1 = 1", Sorting: filter.Sorting{Sort: filter.SortExprSet{{Column: "str9"}}}})
+			req.Equal("a,m1;c,<NIL>", stringifyValues(set, "str9", "multi1"))
+
+			req.NoError(s.MigrateComposeRecordStorage(ctx, dmod, types.ModuleStorageDedicated))
+			dmod.Storage = types.ModuleStorageDedicated
+
+			set = search(types.RecordFilter{Query: "bool1 = 1", Sorting: filter.Sorting{Sort: filter.SortExprSet{{Column: "str9"}}}})
+			req.Equal("a,m1;c,<NIL>", stringifyValues(set, "str9", "multi1"))
+		})
+
+		req.NoError(s.DeleteComposeRecordByID(ctx, dmod, rr[0].ID))
+		req.Len(search(types.RecordFilter{}), 2)
+	})
+
+	t.Run("encrypted values", func(t *testing.T) {
+		var (
+			req = require.New(t)
+			err error
+			set types.RecordSet
+
+			emod = &types.Module{
+				ID:          id.Next(),
+				NamespaceID: mod.NamespaceID,
+				Name:        "testComposeRecordsEncrypted",
+				CreatedAt:   time.Now(),
+				Fields: types.ModuleFieldSet{
+					&types.ModuleField{Kind: "String", Name: "str1"},
+					&types.ModuleField{Kind: "String", Name: "enc1", Options: types.ModuleFieldOptions{"encrypted": true}},
+				},
+			}
+
+			// fake blind index; values are compared to the stored
+			// indexes of the current and of the previous key
+			blindIndexes = func(v string) []string { return []string{"bi:" + v, "old:" + v} }
+
+			makeEncrypted = func(str, enc, bidx string) *types.Record {
+				r := makeNew(
+					&types.RecordValue{Name: "str1", Value: str},
+					&types.RecordValue{Name: "enc1", Value: "enc:" + enc, BlindIndex: bidx},
+				)
+				r.ModuleID = emod.ID
+				return r
+			}
+
+			search = func(q string) (types.RecordSet, error) {
+				set, _, err := s.SearchComposeRecords(ctx, emod, types.RecordFilter{
+					Query:        q,
+					BlindIndexes: blindIndexes,
+					Sorting:      filter.Sorting{Sort: filter.SortExprSet{{Column: "str1"}}},
+				})
+				return set, err
+			}
+		)
+
+		req.NoError(s.TruncateComposeRecords(ctx, emod))
+		req.NoError(s.CreateComposeRecord(ctx, emod,
+			makeEncrypted("a", "x", "bi:x"),
+			makeEncrypted("b", "y", "bi:y"),
+			makeEncrypted("c", "x", "old:x"),
+		))
+
+		set, err = search("enc1 = 'x'")
+		req.NoError(err)
+		req.Equal("a;c", stringifyValues(set, "str1"))
+		req.Equal("bi:x", set[0].Values.Get("enc1", 0).BlindIndex)
+
+		set, err = search("'y' = enc1 OR str1 = 'c'")
+		req.NoError(err)
+		req.Equal("b;c", stringifyValues(set, "str1"))
+
+		set, err = search("enc1 != 'x' AND enc1 IS NOT NULL")
+		req.NoError(err)
+		req.Equal("b", stringifyValues(set, "str1"))
+
+		for _, q := range []string{"enc1 LIKE 'x%'", "enc1 > 'x'", "enc1 = str1", "LOWER(enc1) = 'x'"} {
+			_, err = search(q)
+			req.Error(err, q)
+		}
+
+		_, _, err = s.SearchComposeRecords(ctx, emod, types.RecordFilter{Query: "enc1 = 'x'"})
+		req.Error(err)
+
+		_, _, err = s.SearchComposeRecords(ctx, emod, types.RecordFilter{Sorting: filter.Sorting{Sort: filter.SortExprSet{{Column: "enc1"}}}})
+		req.Error(err)
+	})
 }
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"context"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
 	"github.com/cortezaproject/corteza-server/pkg/id"
 	"github.com/cortezaproject/corteza-server/pkg/rand"
 	"github.com/cortezaproject/corteza-server/store"
@@ -122,26 +123,23 @@ func testCredentials(t *testing.T, s store.Credentials) {
 			req.Len(set, 1)
 		})
 
-		// Not sure why this doesnt work
-		// t.Run("by state", func(t *testing.T) {
-		// 	t.Run("deleted", func(t *testing.T) {
-		// 		req, prefill := truncAndFill(t, 5)
-		// 		time := time.Now()
-		// 		prefill[0].DeletedAt = &time
-		// 		req.NoError(s.DeleteCredentialsByID(ctx, prefill[0].ID))
-
-		// 		set, _, err := s.SearchCredentials(ctx, types.CredentialsFilter{Deleted: filter.StateExcluded})
-		// 		req.NoError(err)
-		// 		req.Len(set, 4)
-
-		// 		set, _, err = s.SearchCredentials(ctx, types.CredentialsFilter{Deleted: filter.StateInclusive})
-		// 		req.NoError(err)
-		// 		req.Len(set, 5)
-
-		// 		set, _, err = s.SearchCredentials(ctx, types.CredentialsFilter{Deleted: filter.StateExclusive})
-		// 		req.NoError(err)
-		// 		req.Len(set, 1)
-		// 	})
-		// })
+		t.Run("by state", func(t *testing.T) {
+			req, prefill := truncAndFill(t, 5)
+			prefill[0].DeletedAt = &prefill[0].CreatedAt
+			req.NoError(s.UpdateCredentials(ctx, prefill[0]))
+
+			// deleted credentials are not excluded by default
+			set, _, err := s.SearchCredentials(ctx, types.CredentialsFilter{})
+			req.NoError(err)
+			req.Len(set, 5)
+
+			set, _, err = s.SearchCredentials(ctx, types.CredentialsFilter{Deleted: filter.StateInclusive})
+			req.NoError(err)
+			req.Len(set, 5)
+
+			set, _, err = s.SearchCredentials(ctx, types.CredentialsFilter{Deleted: filter.StateExclusive})
+			req.NoError(err)
+			req.Len(set, 1)
+		})
 	})
 }
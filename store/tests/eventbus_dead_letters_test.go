@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/stretchr/testify/require"
+)
+
+func testEventbusDeadLetters(t *testing.T, s store.EventbusDeadLetters) {
+	var (
+		ctx = context.Background()
+
+		truncAndCreate = func(t *testing.T) (*require.Assertions, *eventbus.QueuedEvent) {
+			var (
+				req = require.New(t)
+				res = makeNewQueuedEvent("afterDelete", time.Now())
+				now = time.Now()
+			)
+
+			res.Attempts = 5
+			res.LastError = "script failed"
+			res.FailedAt = &now
+
+			req.NoError(s.TruncateEventbusDeadLetters(ctx))
+			req.NoError(s.CreateEventbusDeadLetter(ctx, res))
+			return req, res
+		}
+	)
+
+	t.Run("lookup by ID", func(t *testing.T) {
+		req, qe := truncAndCreate(t)
+		fetched, err := s.LookupEventbusDeadLetterByID(ctx, qe.ID)
+		req.NoError(err)
+		req.Equal("afterDelete", fetched.EventType)
+		req.Equal(uint(5), fetched.Attempts)
+		req.Equal("script failed", fetched.LastError)
+		req.NotNil(fetched.FailedAt)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		req, qe := truncAndCreate(t)
+		req.NoError(s.DeleteEventbusDeadLetterByID(ctx, qe.ID))
+		_, err := s.LookupEventbusDeadLetterByID(ctx, qe.ID)
+		req.EqualError(err, store.ErrNotFound.Error())
+	})
+
+	t.Run("search", func(t *testing.T) {
+		req, _ := truncAndCreate(t)
+
+		set, _, err := s.SearchEventbusDeadLetters(ctx, eventbus.QueuedEventFilter{ResourceType: "compose:record"})
+		req.NoError(err)
+		req.Len(set, 1)
+
+		set, _, err = s.SearchEventbusDeadLetters(ctx, eventbus.QueuedEventFilter{ResourceType: "system:user"})
+		req.NoError(err)
+		req.Len(set, 0)
+	})
+}
@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/id"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/stretchr/testify/require"
+)
+
+func makeNewQueuedEvent(eventType string, scheduledAt time.Time) *eventbus.QueuedEvent {
+	return &eventbus.QueuedEvent{
+		ID:           id.Next(),
+		ResourceType: "compose:record",
+		EventType:    eventType,
+		Args:         eventbus.EventArgs{"record": json.RawMessage(`{"recordID":"42"}`)},
+		InvokerID:    id.Next(),
+		CreatedAt:    time.Now(),
+		ScheduledAt:  scheduledAt,
+	}
+}
+
+func testEventbusOutboxEvents(t *testing.T, s store.EventbusOutboxEvents) {
+	var (
+		ctx = context.Background()
+
+		truncAndCreate = func(t *testing.T) (*require.Assertions, *eventbus.QueuedEvent) {
+			req := require.New(t)
+			req.NoError(s.TruncateEventbusOutboxEvents(ctx))
+			res := makeNewQueuedEvent("afterCreate", time.Now())
+			req.NoError(s.CreateEventbusOutboxEvent(ctx, res))
+			return req, res
+		}
+	)
+
+	t.Run("create", func(t *testing.T) {
+		req := require.New(t)
+		req.NoError(s.CreateEventbusOutboxEvent(ctx, makeNewQueuedEvent("afterCreate", time.Now())))
+	})
+
+	t.Run("lookup by ID", func(t *testing.T) {
+		req, qe := truncAndCreate(t)
+		fetched, err := s.LookupEventbusOutboxEventByID(ctx, qe.ID)
+		req.NoError(err)
+		req.Equal(qe.ResourceType, fetched.ResourceType)
+		req.Equal(qe.EventType, fetched.EventType)
+		req.Equal(qe.InvokerID, fetched.InvokerID)
+		req.JSONEq(`{"recordID":"42"}`, string(fetched.Args["record"]))
+		req.Nil(fetched.FailedAt)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		req, qe := truncAndCreate(t)
+		qe.Attempts = 2
+		qe.LastError = "corredor unavailable"
+		req.NoError(s.UpdateEventbusOutboxEvent(ctx, qe))
+
+		updated, err := s.LookupEventbusOutboxEventByID(ctx, qe.ID)
+		req.NoError(err)
+		req.Equal(uint(2), updated.Attempts)
+		req.Equal("corredor unavailable", updated.LastError)
+	})
+
+	t.Run("claim", func(t *testing.T) {
+		req, qe := truncAndCreate(t)
+		qe.ScheduledAt = time.Now().Add(time.Hour)
+
+		claimed, err := s.ClaimEventbusOutboxEvent(ctx, qe)
+		req.NoError(err)
+		req.True(claimed)
+
+		// not due anymore
+		claimed, err = s.ClaimEventbusOutboxEvent(ctx, qe)
+		req.NoError(err)
+		req.False(claimed)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		req, qe := truncAndCreate(t)
+		req.NoError(s.DeleteEventbusOutboxEvent(ctx, qe))
+		_, err := s.LookupEventbusOutboxEventByID(ctx, qe.ID)
+		req.EqualError(err, store.ErrNotFound.Error())
+	})
+
+	t.Run("search", func(t *testing.T) {
+		var (
+			req = require.New(t)
+			now = time.Now()
+		)
+
+		req.NoError(s.TruncateEventbusOutboxEvents(ctx))
+		req.NoError(s.CreateEventbusOutboxEvent(ctx,
+			makeNewQueuedEvent("afterCreate", now.Add(-time.Minute)),
+			makeNewQueuedEvent("afterUpdate", now.Add(-time.Minute)),
+			makeNewQueuedEvent("afterUpdate", now.Add(time.Hour)),
+		))
+
+		set, _, err := s.SearchEventbusOutboxEvents(ctx, eventbus.QueuedEventFilter{})
+		req.NoError(err)
+		req.Len(set, 3)
+
+		set, _, err = s.SearchEventbusOutboxEvents(ctx, eventbus.QueuedEventFilter{EventType: "afterUpdate"})
+		req.NoError(err)
+		req.Len(set, 2)
+
+		set, _, err = s.SearchEventbusOutboxEvents(ctx, eventbus.QueuedEventFilter{ScheduledBefore: &now})
+		req.NoError(err)
+		req.Len(set, 2)
+	})
+}
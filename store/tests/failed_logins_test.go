@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/system/types"
+	"github.com/stretchr/testify/require"
+)
+
+func testFailedLogins(t *testing.T, s store.FailedLogins) {
+	var (
+		ctx = context.Background()
+
+		makeNew = func(key string) *types.FailedLogin {
+			return &types.FailedLogin{
+				Key:          key,
+				Failures:     3,
+				LastFailedAt: time.Now(),
+			}
+		}
+
+		truncAndCreate = func(t *testing.T) (*require.Assertions, *types.FailedLogin) {
+			req := require.New(t)
+			req.NoError(s.TruncateFailedLogins(ctx))
+			res := makeNew("user:42")
+			req.NoError(s.CreateFailedLogin(ctx, res))
+			return req, res
+		}
+	)
+
+	t.Run("lookup by key", func(t *testing.T) {
+		req, fl := truncAndCreate(t)
+		fetched, err := s.LookupFailedLoginByKey(ctx, fl.Key)
+		req.NoError(err)
+		req.Equal(fl.Key, fetched.Key)
+		req.Equal(uint(3), fetched.Failures)
+		req.Nil(fetched.LockedAt)
+	})
+
+	t.Run("upsert", func(t *testing.T) {
+		req, fl := truncAndCreate(t)
+		now := time.Now()
+		fl.Failures = 10
+		fl.LockedAt = &now
+		req.NoError(s.UpsertFailedLogin(ctx, fl, makeNew("ip:10.0.0.1")))
+
+		fetched, err := s.LookupFailedLoginByKey(ctx, fl.Key)
+		req.NoError(err)
+		req.Equal(uint(10), fetched.Failures)
+		req.NotNil(fetched.LockedAt)
+
+		_, err = s.LookupFailedLoginByKey(ctx, "ip:10.0.0.1")
+		req.NoError(err)
+	})
+
+	t.Run("compare and swap", func(t *testing.T) {
+		req, fl := truncAndCreate(t)
+		fl.Failures = 4
+
+		ok, err := s.CompareAndSwapFailedLogin(ctx, fl, 3)
+		req.NoError(err)
+		req.True(ok)
+
+		// failures were modified in the meantime
+		fl.Failures = 5
+		ok, err = s.CompareAndSwapFailedLogin(ctx, fl, 3)
+		req.NoError(err)
+		req.False(ok)
+
+		fetched, err := s.LookupFailedLoginByKey(ctx, fl.Key)
+		req.NoError(err)
+		req.Equal(uint(4), fetched.Failures)
+	})
+
+	t.Run("delete by key", func(t *testing.T) {
+		req, fl := truncAndCreate(t)
+		req.NoError(s.DeleteFailedLoginByKey(ctx, fl.Key))
+
+		_, err := s.LookupFailedLoginByKey(ctx, fl.Key)
+		req.EqualError(err, store.ErrNotFound.Error())
+	})
+}
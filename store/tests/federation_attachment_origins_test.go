@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/stretchr/testify/require"
+)
+
+func testFederationAttachmentOrigins(t *testing.T, s store.FederationAttachmentOrigins) {
+	var (
+		ctx = context.Background()
+
+		makeNew = func(attachmentID, originAttachmentID uint64) *types.AttachmentOrigin {
+			return &types.AttachmentOrigin{
+				AttachmentID:       attachmentID,
+				NodeID:             1,
+				OriginAttachmentID: originAttachmentID,
+			}
+		}
+	)
+
+	t.Run("create", func(t *testing.T) {
+		req := require.New(t)
+		req.NoError(s.TruncateFederationAttachmentOrigins(ctx))
+		req.NoError(s.CreateFederationAttachmentOrigin(ctx, makeNew(42, 2)))
+	})
+
+	t.Run("lookup by node and origin attachment ID", func(t *testing.T) {
+		req := require.New(t)
+		req.NoError(s.TruncateFederationAttachmentOrigins(ctx))
+		req.NoError(s.CreateFederationAttachmentOrigin(ctx, makeNew(42, 2), makeNew(43, 3)))
+
+		fetched, err := s.LookupFederationAttachmentOriginByNodeIDOriginAttachmentID(ctx, 1, 3)
+		req.NoError(err)
+		req.Equal(uint64(43), fetched.AttachmentID)
+
+		_, err = s.LookupFederationAttachmentOriginByNodeIDOriginAttachmentID(ctx, 2, 3)
+		req.EqualError(err, store.ErrNotFound.Error())
+	})
+}
@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/stretchr/testify/require"
+)
+
+func testFederationExposedRecords(t *testing.T, s store.FederationExposedRecords) {
+	var (
+		ctx = context.Background()
+
+		makeNew = func(exposedModuleID, recordID uint64) *types.ExposedRecord {
+			return &types.ExposedRecord{
+				ExposedModuleID: exposedModuleID,
+				RecordID:        recordID,
+				ExposedAt:       *now(),
+			}
+		}
+	)
+
+	t.Run("create", func(t *testing.T) {
+		req := require.New(t)
+		req.NoError(s.TruncateFederationExposedRecords(ctx))
+		req.NoError(s.CreateFederationExposedRecord(ctx, makeNew(1, 42)))
+	})
+
+	t.Run("lookup by exposed module and record ID", func(t *testing.T) {
+		req := require.New(t)
+		req.NoError(s.TruncateFederationExposedRecords(ctx))
+		req.NoError(s.CreateFederationExposedRecord(ctx, makeNew(1, 42), makeNew(2, 42)))
+
+		fetched, err := s.LookupFederationExposedRecordByExposedModuleIDRecordID(ctx, 2, 42)
+		req.NoError(err)
+		req.Equal(uint64(2), fetched.ExposedModuleID)
+
+		_, err = s.LookupFederationExposedRecordByExposedModuleIDRecordID(ctx, 3, 42)
+		req.EqualError(err, store.ErrNotFound.Error())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		req := require.New(t)
+		req.NoError(s.TruncateFederationExposedRecords(ctx))
+		req.NoError(s.CreateFederationExposedRecord(ctx, makeNew(1, 42), makeNew(2, 42)))
+		req.NoError(s.DeleteFederationExposedRecordByExposedModuleIDRecordID(ctx, 1, 42))
+
+		set, _, err := s.SearchFederationExposedRecords(ctx, types.ExposedRecordFilter{})
+		req.NoError(err)
+		req.Len(set, 1)
+	})
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	ct "github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/pkg/id"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/stretchr/testify/require"
+)
+
+func testFederationRecordConflicts(t *testing.T, s store.FederationRecordConflicts) {
+	var (
+		ctx = context.Background()
+
+		makeNew = func(recordID uint64) *types.RecordConflict {
+			return &types.RecordConflict{
+				ID:       id.Next(),
+				NodeID:   1,
+				ModuleID: 2,
+				RecordID: recordID,
+				Values: ct.RecordValueSet{
+					&ct.RecordValue{Name: "name", Value: "value"},
+				},
+				Clock:     types.VectorClock{"origin": 2, "local": 1},
+				CreatedAt: *now(),
+			}
+		}
+	)
+
+	t.Run("create", func(t *testing.T) {
+		req := require.New(t)
+		req.NoError(s.TruncateFederationRecordConflicts(ctx))
+		req.NoError(s.CreateFederationRecordConflict(ctx, makeNew(42)))
+	})
+
+	t.Run("lookup by ID", func(t *testing.T) {
+		req := require.New(t)
+		req.NoError(s.TruncateFederationRecordConflicts(ctx))
+
+		rc := makeNew(42)
+		req.NoError(s.CreateFederationRecordConflict(ctx, rc))
+
+		fetched, err := s.LookupFederationRecordConflictByID(ctx, rc.ID)
+		req.NoError(err)
+		req.Equal(rc.RecordID, fetched.RecordID)
+		req.Equal(rc.Clock, fetched.Clock)
+		req.Len(fetched.Values, 1)
+	})
+
+	t.Run("search", func(t *testing.T) {
+		req := require.New(t)
+		req.NoError(s.TruncateFederationRecordConflicts(ctx))
+
+		resolved := makeNew(43)
+		resolved.ResolvedAt = now()
+		resolved.Resolution = types.RecordConflictResolutionLocal
+
+		req.NoError(s.CreateFederationRecordConflict(ctx, makeNew(42), resolved))
+
+		set, _, err := s.SearchFederationRecordConflicts(ctx, types.RecordConflictFilter{})
+		req.NoError(err)
+		req.Len(set, 1)
+
+		set, _, err = s.SearchFederationRecordConflicts(ctx, types.RecordConflictFilter{Resolved: filter.StateInclusive})
+		req.NoError(err)
+		req.Len(set, 2)
+
+		set, _, err = s.SearchFederationRecordConflicts(ctx, types.RecordConflictFilter{RecordID: 43, Resolved: filter.StateInclusive})
+		req.NoError(err)
+		req.Len(set, 1)
+	})
+}
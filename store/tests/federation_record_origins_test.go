@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cortezaproject/corteza-server/federation/types"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/stretchr/testify/require"
+)
+
+func testFederationRecordOrigins(t *testing.T, s store.FederationRecordOrigins) {
+	var (
+		ctx = context.Background()
+
+		makeNew = func(recordID uint64) *types.RecordOrigin {
+			return &types.RecordOrigin{
+				RecordID:       recordID,
+				NodeID:         1,
+				OriginRecordID: 2,
+				Clock:          types.VectorClock{"origin": 1},
+				SyncedAt:       now(),
+			}
+		}
+	)
+
+	t.Run("create", func(t *testing.T) {
+		req := require.New(t)
+		req.NoError(s.TruncateFederationRecordOrigins(ctx))
+		req.NoError(s.CreateFederationRecordOrigin(ctx, makeNew(42)))
+	})
+
+	t.Run("lookup by record ID", func(t *testing.T) {
+		req := require.New(t)
+		req.NoError(s.TruncateFederationRecordOrigins(ctx))
+
+		ro := makeNew(42)
+		req.NoError(s.CreateFederationRecordOrigin(ctx, ro))
+
+		fetched, err := s.LookupFederationRecordOriginByRecordID(ctx, 42)
+		req.NoError(err)
+		req.Equal(ro.OriginRecordID, fetched.OriginRecordID)
+		req.Equal(ro.Clock, fetched.Clock)
+	})
+
+	t.Run("lookup by node and origin record ID", func(t *testing.T) {
+		req := require.New(t)
+		req.NoError(s.TruncateFederationRecordOrigins(ctx))
+
+		ro := makeNew(42)
+		req.NoError(s.CreateFederationRecordOrigin(ctx, ro))
+
+		fetched, err := s.LookupFederationRecordOriginByNodeIDOriginRecordID(ctx, ro.NodeID, ro.OriginRecordID)
+		req.NoError(err)
+		req.Equal(ro.RecordID, fetched.RecordID)
+	})
+
+	t.Run("upsert", func(t *testing.T) {
+		req := require.New(t)
+		req.NoError(s.TruncateFederationRecordOrigins(ctx))
+
+		ro := makeNew(42)
+		req.NoError(s.UpsertFederationRecordOrigin(ctx, ro))
+
+		ro.Clock.Tick("local")
+		req.NoError(s.UpsertFederationRecordOrigin(ctx, ro))
+
+		fetched, err := s.LookupFederationRecordOriginByRecordID(ctx, 42)
+		req.NoError(err)
+		req.Equal(types.VectorClock{"origin": 1, "local": 1}, fetched.Clock)
+	})
+}
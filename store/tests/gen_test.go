@@ -11,13 +11,22 @@ package tests
 //  - store/compose_charts.yaml
 //  - store/compose_module_fields.yaml
 //  - store/compose_modules.yaml
+//  - store/compose_namespace_versions.yaml
 //  - store/compose_namespaces.yaml
 //  - store/compose_pages.yaml
+//  - store/compose_record_change_requests.yaml
 //  - store/credentials.yaml
+//  - store/eventbus_dead_letters.yaml
+//  - store/eventbus_outbox_events.yaml
+//  - store/failed_logins.yaml
+//  - store/federation_attachment_origins.yaml
 //  - store/federation_exposed_modules.yaml
+//  - store/federation_exposed_records.yaml
 //  - store/federation_module_mappings.yaml
 //  - store/federation_nodes.yaml
 //  - store/federation_nodes_sync.yaml
+//  - store/federation_record_conflicts.yaml
+//  - store/federation_record_origins.yaml
 //  - store/federation_shared_modules.yaml
 //  - store/labels.yaml
 //  - store/messaging_attachments.yaml
@@ -28,11 +37,13 @@ package tests
 //  - store/messaging_message_attachments.yaml
 //  - store/messaging_messages.yaml
 //  - store/messaging_unread.yaml
+//  - store/ratelimit_buckets.yaml
 //  - store/rbac_rules.yaml
 //  - store/reminders.yaml
 //  - store/role_members.yaml
 //  - store/roles.yaml
 //  - store/settings.yaml
+//  - store/templates.yaml
 //  - store/users.yaml
 
 //
@@ -81,6 +92,11 @@ func testAllGenerated(t *testing.T, s store.Storer) {
 		testComposeModules(t, s)
 	})
 
+	// Run generated tests for ComposeNamespaceVersions
+	t.Run("ComposeNamespaceVersions", func(t *testing.T) {
+		testComposeNamespaceVersions(t, s)
+	})
+
 	// Run generated tests for ComposeNamespaces
 	t.Run("ComposeNamespaces", func(t *testing.T) {
 		testComposeNamespaces(t, s)
@@ -91,6 +107,11 @@ func testAllGenerated(t *testing.T, s store.Storer) {
 		testComposePages(t, s)
 	})
 
+	// Run generated tests for ComposeRecordChangeRequests
+	t.Run("ComposeRecordChangeRequests", func(t *testing.T) {
+		testComposeRecordChangeRequests(t, s)
+	})
+
 	// Run generated tests for ComposeRecordValues
 	t.Run("ComposeRecordValues", func(t *testing.T) {
 		testComposeRecordValues(t, s)
@@ -106,11 +127,36 @@ func testAllGenerated(t *testing.T, s store.Storer) {
 		testCredentials(t, s)
 	})
 
+	// Run generated tests for EventbusDeadLetters
+	t.Run("EventbusDeadLetters", func(t *testing.T) {
+		testEventbusDeadLetters(t, s)
+	})
+
+	// Run generated tests for EventbusOutboxEvents
+	t.Run("EventbusOutboxEvents", func(t *testing.T) {
+		testEventbusOutboxEvents(t, s)
+	})
+
+	// Run generated tests for FailedLogins
+	t.Run("FailedLogins", func(t *testing.T) {
+		testFailedLogins(t, s)
+	})
+
+	// Run generated tests for FederationAttachmentOrigins
+	t.Run("FederationAttachmentOrigins", func(t *testing.T) {
+		testFederationAttachmentOrigins(t, s)
+	})
+
 	// Run generated tests for FederationExposedModules
 	t.Run("FederationExposedModules", func(t *testing.T) {
 		testFederationExposedModules(t, s)
 	})
 
+	// Run generated tests for FederationExposedRecords
+	t.Run("FederationExposedRecords", func(t *testing.T) {
+		testFederationExposedRecords(t, s)
+	})
+
 	// Run generated tests for FederationModuleMappings
 	t.Run("FederationModuleMappings", func(t *testing.T) {
 		testFederationModuleMappings(t, s)
@@ -126,6 +172,16 @@ func testAllGenerated(t *testing.T, s store.Storer) {
 		testFederationNodesSync(t, s)
 	})
 
+	// Run generated tests for FederationRecordConflicts
+	t.Run("FederationRecordConflicts", func(t *testing.T) {
+		testFederationRecordConflicts(t, s)
+	})
+
+	// Run generated tests for FederationRecordOrigins
+	t.Run("FederationRecordOrigins", func(t *testing.T) {
+		testFederationRecordOrigins(t, s)
+	})
+
 	// Run generated tests for FederationSharedModules
 	t.Run("FederationSharedModules", func(t *testing.T) {
 		testFederationSharedModules(t, s)
@@ -176,6 +232,11 @@ func testAllGenerated(t *testing.T, s store.Storer) {
 		testMessagingUnread(t, s)
 	})
 
+	// Run generated tests for RatelimitBuckets
+	t.Run("RatelimitBuckets", func(t *testing.T) {
+		testRatelimitBuckets(t, s)
+	})
+
 	// Run generated tests for RbacRules
 	t.Run("RbacRules", func(t *testing.T) {
 		testRbacRules(t, s)
@@ -201,6 +262,11 @@ func testAllGenerated(t *testing.T, s store.Storer) {
 		testSettings(t, s)
 	})
 
+	// Run generated tests for Templates
+	t.Run("Templates", func(t *testing.T) {
+		testTemplates(t, s)
+	})
+
 	// Run generated tests for Users
 	t.Run("Users", func(t *testing.T) {
 		testUsers(t, s)
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/ratelimit"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/stretchr/testify/require"
+)
+
+func testRatelimitBuckets(t *testing.T, s store.RatelimitBuckets) {
+	var (
+		ctx = context.Background()
+
+		makeNew = func(key string, updatedAt time.Time) *ratelimit.Bucket {
+			return &ratelimit.Bucket{
+				Key:       key,
+				Tokens:    4.5,
+				UpdatedAt: updatedAt,
+			}
+		}
+
+		truncAndCreate = func(t *testing.T) (*require.Assertions, *ratelimit.Bucket) {
+			req := require.New(t)
+			req.NoError(s.TruncateRatelimitBuckets(ctx))
+			res := makeNew("user:42", time.Now())
+			req.NoError(s.CreateRatelimitBucket(ctx, res))
+			return req, res
+		}
+	)
+
+	t.Run("lookup by key", func(t *testing.T) {
+		req, b := truncAndCreate(t)
+		fetched, err := s.LookupRatelimitBucketByKey(ctx, b.Key)
+		req.NoError(err)
+		req.Equal(b.Key, fetched.Key)
+		req.InDelta(4.5, fetched.Tokens, 0.001)
+	})
+
+	t.Run("upsert", func(t *testing.T) {
+		req, b := truncAndCreate(t)
+		b.Tokens = 1
+		req.NoError(s.UpsertRatelimitBucket(ctx, b, makeNew("ip:10.0.0.1", time.Now())))
+
+		fetched, err := s.LookupRatelimitBucketByKey(ctx, b.Key)
+		req.NoError(err)
+		req.InDelta(1, fetched.Tokens, 0.001)
+
+		_, err = s.LookupRatelimitBucketByKey(ctx, "ip:10.0.0.1")
+		req.NoError(err)
+	})
+
+	t.Run("compare and swap", func(t *testing.T) {
+		req, b := truncAndCreate(t)
+
+		prev, err := s.LookupRatelimitBucketByKey(ctx, b.Key)
+		req.NoError(err)
+
+		upd := *prev
+		upd.Tokens = 3.5
+		upd.UpdatedAt = prev.UpdatedAt.Add(time.Second)
+
+		ok, err := s.CompareAndSwapRatelimitBucket(ctx, &upd, prev)
+		req.NoError(err)
+		req.True(ok)
+
+		// previous state is no longer in the store
+		ok, err = s.CompareAndSwapRatelimitBucket(ctx, &upd, prev)
+		req.NoError(err)
+		req.False(ok)
+
+		fetched, err := s.LookupRatelimitBucketByKey(ctx, b.Key)
+		req.NoError(err)
+		req.InDelta(3.5, fetched.Tokens, 0.001)
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		req, b := truncAndCreate(t)
+		req.NoError(s.CreateRatelimitBucket(ctx, makeNew("ip:10.0.0.1", time.Now().Add(-time.Hour))))
+		req.NoError(s.PurgeRatelimitBuckets(ctx, ratelimit.BucketFilter{UpdatedBefore: time.Now().Add(-time.Minute)}))
+
+		_, err := s.LookupRatelimitBucketByKey(ctx, "ip:10.0.0.1")
+		req.EqualError(err, store.ErrNotFound.Error())
+
+		_, err = s.LookupRatelimitBucketByKey(ctx, b.Key)
+		req.NoError(err)
+	})
+}
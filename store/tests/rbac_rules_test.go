@@ -54,4 +54,27 @@ func testRbacRules(t *testing.T, s store.RbacRules) {
 		req.NoError(err)
 		req.Len(set, 2)
 	})
+
+	t.Run("conditions", func(t *testing.T) {
+		req := require.New(t)
+		req.NoError(s.TruncateRbacRules(ctx))
+		req.NoError(s.CreateRbacRule(ctx,
+			rbac.AllowRule(42, "res1", "op1"),
+			rbac.AllowRule(42, "res2", "op2").WithConditions(&rbac.Conditions{IPRanges: []string{"10.0.0.0/8"}, MFA: true}),
+		))
+
+		set, _, err := s.SearchRbacRules(ctx, rbac.RuleFilter{})
+		req.NoError(err)
+		req.Len(set, 2)
+
+		for _, r := range set {
+			if r.Resource == "res1" {
+				req.Nil(r.Conditions)
+			} else {
+				req.NotNil(r.Conditions)
+				req.Equal([]string{"10.0.0.0/8"}, r.Conditions.IPRanges)
+				req.True(r.Conditions.MFA)
+			}
+		}
+	})
 }
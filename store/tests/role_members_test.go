@@ -8,6 +8,7 @@ import (
 	_ "github.com/joho/godotenv/autoload"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func testRoleMembers(t *testing.T, s store.RoleMembers) {
@@ -80,6 +81,35 @@ func testRoleMembers(t *testing.T, s store.RoleMembers) {
 	// 	})
 	// })
 
+	t.Run("search valid only", func(t *testing.T) {
+		var (
+			req  = require.New(t)
+			past = time.Now().Add(-time.Hour).UTC()
+			next = time.Now().Add(time.Hour).UTC()
+
+			permanent = makeNew()
+			expired   = makeNew()
+			upcoming  = makeNew()
+			current   = makeNew()
+		)
+
+		expired.ValidUntil = &past
+		upcoming.ValidFrom = &next
+		current.ValidFrom = &past
+		current.ValidUntil = &next
+
+		req.NoError(s.TruncateRoleMembers(ctx))
+		req.NoError(s.CreateRoleMember(ctx, permanent, expired, upcoming, current))
+
+		set, _, err := s.SearchRoleMembers(ctx, types.RoleMemberFilter{})
+		req.NoError(err)
+		req.Len(set, 4)
+
+		set, _, err = s.SearchRoleMembers(ctx, types.RoleMemberFilter{ValidOnly: true})
+		req.NoError(err)
+		req.Len(set, 2)
+	})
+
 	t.Run("delete", func(t *testing.T) {
 		t.Run("by role member", func(t *testing.T) {
 			req, roleMember := truncAndCreate(t)
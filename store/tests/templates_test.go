@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/pkg/id"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/system/types"
+	"github.com/stretchr/testify/require"
+)
+
+func testTemplates(t *testing.T, s store.Templates) {
+	var (
+		ctx = context.Background()
+		req = require.New(t)
+
+		makeNew = func(handle, lang string) *types.Template {
+			// minimum data set for new template
+			return &types.Template{
+				ID:        id.Next(),
+				CreatedAt: time.Now(),
+				Handle:    handle,
+				Language:  lang,
+				Type:      types.TemplateTypeHTML,
+				Template:  "<p>{{.name}}</p>",
+				Meta:      types.TemplateMeta{Subject: "Hello {{.name}}"},
+			}
+		}
+
+		truncAndCreate = func(t *testing.T) (*require.Assertions, *types.Template) {
+			req := require.New(t)
+			req.NoError(s.TruncateTemplates(ctx))
+			res := makeNew("welcome", "en")
+			req.NoError(s.CreateTemplate(ctx, res))
+			return req, res
+		}
+	)
+
+	t.Run("create", func(t *testing.T) {
+		req.NoError(s.CreateTemplate(ctx, makeNew("TemplateCRUD", "en")))
+	})
+
+	t.Run("create with duplicate handle and language", func(t *testing.T) {
+		req, tpl := truncAndCreate(t)
+		req.EqualError(s.CreateTemplate(ctx, makeNew(tpl.Handle, tpl.Language)), store.ErrNotUnique.Error())
+		req.NoError(s.CreateTemplate(ctx, makeNew(tpl.Handle, "de")))
+	})
+
+	t.Run("lookup by ID", func(t *testing.T) {
+		req, tpl := truncAndCreate(t)
+		fetched, err := s.LookupTemplateByID(ctx, tpl.ID)
+		req.NoError(err)
+		req.Equal(tpl.Handle, fetched.Handle)
+		req.Equal(tpl.Template, fetched.Template)
+		req.Equal(tpl.Meta.Subject, fetched.Meta.Subject)
+		req.Nil(fetched.UpdatedAt)
+		req.Nil(fetched.DeletedAt)
+	})
+
+	t.Run("lookup by handle and language", func(t *testing.T) {
+		req, tpl := truncAndCreate(t)
+		fetched, err := s.LookupTemplateByHandleLanguage(ctx, tpl.Handle, tpl.Language)
+		req.NoError(err)
+		req.Equal(tpl.ID, fetched.ID)
+
+		_, err = s.LookupTemplateByHandleLanguage(ctx, tpl.Handle, "de")
+		req.EqualError(err, store.ErrNotFound.Error())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		req, tpl := truncAndCreate(t)
+		tpl.Template = "<p>updated</p>"
+		tpl.Partial = true
+
+		req.NoError(s.UpdateTemplate(ctx, tpl))
+
+		updated, err := s.LookupTemplateByID(ctx, tpl.ID)
+		req.NoError(err)
+		req.Equal(tpl.Template, updated.Template)
+		req.True(updated.Partial)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		req, tpl := truncAndCreate(t)
+		req.NoError(s.DeleteTemplateByID(ctx, tpl.ID))
+		_, err := s.LookupTemplateByID(ctx, tpl.ID)
+		req.EqualError(err, store.ErrNotFound.Error())
+	})
+
+	t.Run("search", func(t *testing.T) {
+		prefill := []*types.Template{
+			makeNew("one", "en"),
+			makeNew("one", "de"),
+			makeNew("two", "en"),
+			makeNew("header", "en"),
+			makeNew("deleted", "en"),
+		}
+
+		prefill[3].Partial = true
+		prefill[4].DeletedAt = &prefill[4].CreatedAt
+
+		req.NoError(s.TruncateTemplates(ctx))
+		req.NoError(s.CreateTemplate(ctx, prefill...))
+
+		// valid, non-partial templates
+		set, _, err := s.SearchTemplates(ctx, types.TemplateFilter{})
+		req.NoError(err)
+		req.Len(set, 3)
+
+		set, _, err = s.SearchTemplates(ctx, types.TemplateFilter{Partial: filter.StateExclusive})
+		req.NoError(err)
+		req.Len(set, 1)
+
+		set, _, err = s.SearchTemplates(ctx, types.TemplateFilter{Partial: filter.StateInclusive, Deleted: filter.StateInclusive})
+		req.NoError(err)
+		req.Len(set, 5)
+
+		set, _, err = s.SearchTemplates(ctx, types.TemplateFilter{Handle: "one"})
+		req.NoError(err)
+		req.Len(set, 2)
+
+		set, _, err = s.SearchTemplates(ctx, types.TemplateFilter{Language: "de"})
+		req.NoError(err)
+		req.Len(set, 1)
+	})
+}
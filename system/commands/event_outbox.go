@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/cli"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/system/service"
+	"github.com/spf13/cobra"
+)
+
+func EventOutbox(app serviceInitializer) *cobra.Command {
+	var (
+		flagResourceType string
+		flagEventType    string
+		flagDeadLetters  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "event-outbox",
+		Short: "Event outbox and dead letter management",
+	}
+
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List events waiting for delivery or dead letters",
+		PreRunE: commandPreRunInitService(app),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx = auth.SetSuperUserContext(cli.Context())
+				f   = eventbus.QueuedEventFilter{ResourceType: flagResourceType, EventType: flagEventType}
+
+				set eventbus.QueuedEventSet
+				err error
+			)
+
+			f.Sort = filter.SortExprSet{&filter.SortExpr{Column: "id"}}
+
+			if flagDeadLetters {
+				set, _, err = service.DefaultEventOutbox.SearchDeadLetters(ctx, f)
+			} else {
+				set, _, err = service.DefaultEventOutbox.SearchOutbox(ctx, f)
+			}
+
+			cli.HandleError(err)
+
+			fmt.Fprintf(cmd.OutOrStdout(), "                  ID Attempts Scheduled            Resource                  Event              Last error\n")
+			for _, qe := range set {
+				fmt.Fprintf(
+					cmd.OutOrStdout(),
+					"%20d %8d %-20s %-25s %-18s %s\n",
+					qe.ID,
+					qe.Attempts,
+					qe.ScheduledAt.Format("2006-01-02 15:04:05"),
+					qe.ResourceType,
+					qe.EventType,
+					qe.LastError,
+				)
+			}
+		},
+	}
+
+	listCmd.Flags().BoolVar(&flagDeadLetters, "dead-letters", false, "List events that could not be delivered")
+	listCmd.Flags().StringVar(&flagResourceType, "resource-type", "", "Show only events of the given resource type")
+	listCmd.Flags().StringVar(&flagEventType, "event-type", "", "Show only events of the given event type")
+
+	replayCmd := &cobra.Command{
+		Use:     "replay [event-ID]",
+		Short:   "Move dead letter back to the outbox",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: commandPreRunInitService(app),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx = auth.SetSuperUserContext(cli.Context())
+			)
+
+			ID, err := strconv.ParseUint(args[0], 10, 64)
+			cli.HandleError(err)
+
+			_, err = service.DefaultEventOutbox.Replay(ctx, ID)
+			cli.HandleError(err)
+
+			cmd.Printf("Event %d scheduled for delivery\n", ID)
+		},
+	}
+
+	purgeCmd := &cobra.Command{
+		Use:     "purge",
+		Short:   "Remove dead letters",
+		PreRunE: commandPreRunInitService(app),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx = auth.SetSuperUserContext(cli.Context())
+				f   = eventbus.QueuedEventFilter{ResourceType: flagResourceType, EventType: flagEventType}
+			)
+
+			n, err := service.DefaultEventOutbox.Purge(ctx, f)
+			cli.HandleError(err)
+
+			cmd.Printf("%d dead letter(s) removed\n", n)
+		},
+	}
+
+	purgeCmd.Flags().StringVar(&flagResourceType, "resource-type", "", "Remove only dead letters of the given resource type")
+	purgeCmd.Flags().StringVar(&flagEventType, "event-type", "", "Remove only dead letters of the given event type")
+
+	cmd.AddCommand(
+		listCmd,
+		replayCmd,
+		purgeCmd,
+	)
+
+	return cmd
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	cmpsvc "github.com/cortezaproject/corteza-server/compose/service"
 	cmptyp "github.com/cortezaproject/corteza-server/compose/types"
@@ -13,6 +14,7 @@ import (
 	"gopkg.in/yaml.v2"
 	"os"
 	"sort"
+	"strconv"
 )
 
 // Temporary solution, highly unstable, will change in the future!
@@ -57,7 +59,11 @@ func RBAC(app serviceInitializer) *cobra.Command {
 		Long:  "Check and manipulates permissions",
 	}
 
-	cmd.AddCommand(rbacCheck(app))
+	cmd.AddCommand(
+		rbacCheck(app),
+		rbacExplain(app),
+		rbacWhatIf(app),
+	)
 
 	//cmd.Flags().String("namespace", "", "Import into namespace (by ID or string)")
 
@@ -111,6 +117,180 @@ func rbacCheck(app serviceInitializer) *cobra.Command {
 	}
 }
 
+func rbacExplain(app serviceInitializer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "explain [user ID, email or handle] [resource] [operation]",
+		Short:   "Explain how access of the user to perform an operation on a resource is resolved",
+		Args:    cobra.ExactArgs(3),
+		PreRunE: commandPreRunInitService(app),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx = auth.SetSuperUserContext(cli.Context())
+
+				dynamic []uint64
+			)
+
+			u, err := syssvc.DefaultUser.FindByAny(ctx, args[0])
+			cli.HandleError(err)
+
+			names, err := cmd.Flags().GetStringSlice("dynamic")
+			cli.HandleError(err)
+			for _, name := range names {
+				roleID, ok := rbacDynamicRoles[name]
+				if !ok {
+					// role assigned through record's user field
+					roleID, err = strconv.ParseUint(name, 10, 64)
+					if err != nil {
+						cli.HandleError(fmt.Errorf("unknown dynamic role %q", name))
+					}
+				}
+
+				dynamic = append(dynamic, roleID)
+			}
+
+			rr, _, err := syssvc.DefaultRole.With(ctx).Find(systyp.RoleFilter{})
+			cli.HandleError(err)
+
+			e, err := syssvc.DefaultAccessControl.Explain(ctx, u.ID, rbac.Resource(args[1]), rbac.Operation(args[2]), dynamic...)
+			cli.HandleError(err)
+
+			fmt.Printf("Explaining %q on %q for user [%d] %s\n", e.Operation, e.Resource, u.ID, u.Email)
+			fmt.Printf("Roles (%d):\n", len(e.Roles))
+			for _, roleID := range e.Roles {
+				fmt.Printf("  - %s\n", rbacRoleLabel(roleID, rr))
+			}
+
+			fmt.Println("Steps:")
+			for i, s := range e.Steps {
+				var (
+					who  = "roles"
+					kind = "specific"
+				)
+
+				if s.Everyone {
+					who = "everyone"
+				}
+
+				if s.Wildcard {
+					kind = "wildcard"
+				}
+
+				fmt.Printf("  %d. %s on %s resource %s: %s\n", i+1, who, kind, s.Resource, s.Access)
+				for _, r := range s.Rules {
+					fmt.Printf("       %-5s %s\n", r.Access, rbacRoleLabel(r.RoleID, rr))
+				}
+			}
+
+			fmt.Printf("Result: %s (%s)\n", e.Access, e.Reason)
+
+			if len(e.Conditional) > 0 {
+				fmt.Println("Rules of dynamic roles user does not have:")
+				for _, r := range e.Conditional {
+					fmt.Printf("  - %-5s %s on %s\n", r.Access, rbacRoleLabel(r.RoleID, rr), r.Resource)
+				}
+			}
+		},
+	}
+
+	cmd.Flags().StringSlice("dynamic", nil, "Dynamic roles user has on the resource (owners, creators, updaters, deleters, members, assignees or role ID)")
+
+	return cmd
+}
+
+func rbacWhatIf(app serviceInitializer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "what-if [file with rule changes (JSON)]",
+		Short:   "Report users that would gain or lose access with the rule changes",
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: commandPreRunInitService(app),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx = auth.SetSuperUserContext(cli.Context())
+				fh  *os.File
+				err error
+
+				changes = rbac.RuleSet{}
+			)
+
+			if len(args) > 0 {
+				fh, err = os.Open(args[0])
+				cli.HandleError(err)
+				defer fh.Close()
+			} else {
+				fh = os.Stdin
+			}
+
+			cli.HandleError(json.NewDecoder(fh).Decode(&changes))
+
+			res, err := cmd.Flags().GetString("resource")
+			cli.HandleError(err)
+
+			uu, _, err := syssvc.DefaultUser.With(ctx).Find(systyp.UserFilter{})
+			cli.HandleError(err)
+
+			results, err := syssvc.DefaultAccessControl.WhatIf(ctx, changes, rbac.Resource(res))
+			cli.HandleError(err)
+
+			for _, r := range results {
+				fmt.Printf("=> %q on %q\n", r.Operation, r.Resource)
+				printAccessChanges("gained", r.Gained, uu)
+				printAccessChanges("lost", r.Lost, uu)
+				printAccessChanges("changed", r.Changed, uu)
+			}
+		},
+	}
+
+	cmd.Flags().String("resource", "", "Compare access on a specific resource instead of resources of the rules")
+
+	return cmd
+}
+
+var (
+	rbacDynamicRoles = map[string]uint64{
+		"owners":    rbac.OwnersDynamicRoleID,
+		"creators":  rbac.CreatorsDynamicRoleID,
+		"updaters":  rbac.UpdatersDynamicRoleID,
+		"deleters":  rbac.DeletersDynamicRoleID,
+		"members":   rbac.MembersDynamicRoleID,
+		"assignees": rbac.AssigneesDynamicRoleID,
+	}
+)
+
+func rbacRoleLabel(roleID uint64, rr systyp.RoleSet) string {
+	if roleID == rbac.EveryoneRoleID {
+		return fmt.Sprintf("[%d] everyone", roleID)
+	}
+
+	for name, dynamicRoleID := range rbacDynamicRoles {
+		if dynamicRoleID == roleID {
+			return fmt.Sprintf("[%d] %s (dynamic)", roleID, name)
+		}
+	}
+
+	if r := rr.FindByID(roleID); r != nil {
+		return fmt.Sprintf("[%d] %s", roleID, r.Handle)
+	}
+
+	return fmt.Sprintf("[%d]", roleID)
+}
+
+func printAccessChanges(label string, cc []*rbac.AccessChange, uu systyp.UserSet) {
+	if len(cc) == 0 {
+		fmt.Printf("   no users %s access\n", label)
+		return
+	}
+
+	fmt.Printf("   users %s access (%d):\n", label, len(cc))
+	for _, c := range cc {
+		var email string
+		if u := uu.FindByID(c.UserID); u != nil {
+			email = u.Email
+		}
+
+		fmt.Printf("    - [%d] %-30s %s => %s\n", c.UserID, email, c.Before, c.After)
+	}
+}
+
 //func (rr rbacRules) Merge(new rbacRules) rbacRules {
 //	var out = rr
 //
@@ -13,7 +13,7 @@ import (
 	"github.com/cortezaproject/corteza-server/system/service"
 )
 
-func Settings() *cobra.Command {
+func Settings(app serviceInitializer) *cobra.Command {
 	var (
 		cmd = &cobra.Command{
 			Use:   "settings",
@@ -22,8 +22,9 @@ func Settings() *cobra.Command {
 	)
 
 	list := &cobra.Command{
-		Use:   "list",
-		Short: "List all",
+		Use:     "list",
+		Short:   "List all",
+		PreRunE: commandPreRunInitService(app),
 		Run: func(cmd *cobra.Command, args []string) {
 			var (
 				ctx = auth.SetSuperUserContext(cli.Context())
@@ -52,8 +53,9 @@ func Settings() *cobra.Command {
 	get := &cobra.Command{
 		Use: "get [key to get, ...]",
 
-		Short: "Get value (raw JSON) for a specific key",
-		Args:  cobra.ExactArgs(1),
+		Short:   "Get value (raw JSON) for a specific key",
+		PreRunE: commandPreRunInitService(app),
+		Args:    cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			var (
 				ctx = auth.SetSuperUserContext(cli.Context())
@@ -68,9 +70,10 @@ func Settings() *cobra.Command {
 	}
 
 	set := &cobra.Command{
-		Use:   "set [key to set] [value]",
-		Short: "Set value (raw JSON or string) for a specific key",
-		Args:  cobra.ExactArgs(2),
+		Use:     "set [key to set] [value]",
+		Short:   "Set value (raw JSON or string) for a specific key",
+		PreRunE: commandPreRunInitService(app),
+		Args:    cobra.ExactArgs(2),
 		Run: func(cmd *cobra.Command, args []string) {
 			var (
 				err error
@@ -104,9 +107,10 @@ func Settings() *cobra.Command {
 	set.Flags().BoolP("as-string", "s", false, "Treat input value as string (to avoid wrapping in quites)")
 
 	imp := &cobra.Command{
-		Use:   "import [file]",
-		Short: "Import settings as JSON from stdin or file",
-		Args:  cobra.MaximumNArgs(1),
+		Use:     "import [file]",
+		Short:   "Import settings as JSON from stdin or file",
+		PreRunE: commandPreRunInitService(app),
+		Args:    cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			var (
 				ctx = auth.SetSuperUserContext(cli.Context())
@@ -143,9 +147,10 @@ func Settings() *cobra.Command {
 	}
 
 	exp := &cobra.Command{
-		Use:   "export [file]",
-		Short: "Import settings as JSON to stdout or file",
-		Args:  cobra.MaximumNArgs(1),
+		Use:     "export [file]",
+		Short:   "Import settings as JSON to stdout or file",
+		PreRunE: commandPreRunInitService(app),
+		Args:    cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			var (
 				ctx = auth.SetSuperUserContext(cli.Context())
@@ -175,9 +180,10 @@ func Settings() *cobra.Command {
 	}
 
 	del := &cobra.Command{
-		Use:   "delete [keys, ...]",
-		Short: "Set value (raw JSON) for a specific key (or by prefix)",
-		Args:  cobra.MinimumNArgs(0),
+		Use:     "delete [keys, ...]",
+		Short:   "Set value (raw JSON) for a specific key (or by prefix)",
+		PreRunE: commandPreRunInitService(app),
+		Args:    cobra.MinimumNArgs(0),
 		Run: func(cmd *cobra.Command, args []string) {
 			var (
 				ctx   = auth.SetSuperUserContext(cli.Context())
@@ -205,6 +211,23 @@ func Settings() *cobra.Command {
 
 	del.Flags().String("prefix", "", "SettingsFilter settings by prefix")
 
+	rotateKey := &cobra.Command{
+		Use:     "rotate-key",
+		Short:   "Re-encrypt secret settings with the current encryption key",
+		Long:    "Re-encrypts secret settings that were encrypted with one of the previous keys (SECRETS_PREVIOUS_KEYS) and encrypts the unencrypted ones.",
+		PreRunE: commandPreRunInitService(app),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx = auth.SetSuperUserContext(cli.Context())
+			)
+
+			rotated, err := service.DefaultSettings.RotateSecrets(ctx)
+			cli.HandleError(err)
+
+			cmd.Printf("%d secret setting(s) re-encrypted\n", rotated)
+		},
+	}
+
 	cmd.AddCommand(
 		list,
 		get,
@@ -212,6 +235,7 @@ func Settings() *cobra.Command {
 		del,
 		imp,
 		exp,
+		rotateKey,
 	)
 
 	return cmd
@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/cli"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/system/service"
+	"github.com/cortezaproject/corteza-server/system/types"
+	"github.com/spf13/cobra"
+)
+
+func Templates(app serviceInitializer) *cobra.Command {
+	var (
+		flagLanguage string
+		flagType     string
+		flagPartial  bool
+		flagSubject  string
+		flagVars     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "templates",
+		Short: "Template management",
+	}
+
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List templates",
+		PreRunE: commandPreRunInitService(app),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx = auth.SetSuperUserContext(cli.Context())
+				tf  = types.TemplateFilter{Language: flagLanguage, Partial: filter.StateInclusive}
+			)
+
+			tf.Sort = filter.SortExprSet{&filter.SortExpr{Column: "handle"}, &filter.SortExpr{Column: "language"}}
+
+			tt, _, err := service.DefaultTemplate.Search(ctx, tf)
+			cli.HandleError(err)
+
+			fmt.Fprintf(cmd.OutOrStdout(), "                  ID Language Type       Partial Handle\n")
+			for _, t := range tt {
+				fmt.Fprintf(
+					cmd.OutOrStdout(),
+					"%20d %-8s %-10s %-7v %s\n",
+					t.ID,
+					t.Language,
+					t.Type,
+					t.Partial,
+					t.Handle,
+				)
+			}
+		},
+	}
+
+	listCmd.Flags().StringVar(&flagLanguage, "language", "", "Show only templates in the given language")
+
+	importCmd := &cobra.Command{
+		Use:     "import [handle] [file]",
+		Short:   "Create or update template from file",
+		Args:    cobra.ExactArgs(2),
+		PreRunE: commandPreRunInitService(app),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx = auth.SetSuperUserContext(cli.Context())
+				tpl = &types.Template{
+					Handle:   args[0],
+					Language: types.NormalizeLanguage(flagLanguage),
+					Type:     types.TemplateType(flagType),
+					Partial:  flagPartial,
+				}
+			)
+
+			src, err := ioutil.ReadFile(args[1])
+			cli.HandleError(err)
+			tpl.Template = string(src)
+
+			ex, err := store.LookupTemplateByHandleLanguage(ctx, service.DefaultStore, tpl.Handle, tpl.Language)
+			if err != nil && !errors.IsNotFound(err) {
+				cli.HandleError(err)
+			}
+
+			if ex != nil {
+				tpl.ID = ex.ID
+				tpl.Meta = ex.Meta
+				if flagSubject != "" {
+					tpl.Meta.Subject = flagSubject
+				}
+
+				tpl, err = service.DefaultTemplate.Update(ctx, tpl)
+			} else {
+				tpl.Meta.Subject = flagSubject
+				tpl, err = service.DefaultTemplate.Create(ctx, tpl)
+			}
+
+			cli.HandleError(err)
+			cmd.Printf("Template %q (%s) imported with ID %d\n", tpl.Handle, tpl.Language, tpl.ID)
+		},
+	}
+
+	importCmd.Flags().StringVar(&flagLanguage, "language", "", "Template language")
+	importCmd.Flags().StringVar(&flagType, "type", string(types.TemplateTypeHTML), "Template type (text/html, text/plain)")
+	importCmd.Flags().BoolVar(&flagPartial, "partial", false, "Template can only be included into other templates")
+	importCmd.Flags().StringVar(&flagSubject, "subject", "", "Subject template")
+
+	renderCmd := &cobra.Command{
+		Use:     "render [handle]",
+		Short:   "Render template",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: commandPreRunInitService(app),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx  = auth.SetSuperUserContext(cli.Context())
+				vars = make(map[string]interface{})
+			)
+
+			if flagVars != "" {
+				cli.HandleError(json.Unmarshal([]byte(flagVars), &vars))
+			}
+
+			out, err := service.DefaultTemplate.Render(ctx, args[0], flagLanguage, vars)
+			cli.HandleError(err)
+
+			if out.Subject != "" {
+				cmd.Printf("Subject: %s\n\n", out.Subject)
+			}
+
+			cmd.Println(out.Content)
+		},
+	}
+
+	renderCmd.Flags().StringVar(&flagLanguage, "language", "", "Template language")
+	renderCmd.Flags().StringVar(&flagVars, "vars", "", "Template variables (JSON object)")
+
+	deleteCmd := &cobra.Command{
+		Use:     "delete [template-ID]",
+		Short:   "Delete template",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: commandPreRunInitService(app),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx = auth.SetSuperUserContext(cli.Context())
+			)
+
+			ID, err := strconv.ParseUint(args[0], 10, 64)
+			cli.HandleError(err)
+			cli.HandleError(service.DefaultTemplate.Delete(ctx, ID))
+
+			cmd.Printf("Template %d deleted\n", ID)
+		},
+	}
+
+	cmd.AddCommand(
+		listCmd,
+		importCmd,
+		renderCmd,
+		deleteCmd,
+	)
+
+	return cmd
+}
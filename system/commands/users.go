@@ -168,10 +168,36 @@ func Users(app serviceInitializer) *cobra.Command {
 		},
 	}
 
+	unlockCmd := &cobra.Command{
+		Use:     "unlock [email]",
+		Short:   "Unlock user locked after too many failed login attempts",
+		Args:    cobra.MinimumNArgs(1),
+		PreRunE: commandPreRunInitService(app),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx = auth.SetSuperUserContext(cli.Context())
+
+				user *types.User
+				err  error
+			)
+
+			if user, err = service.DefaultUser.With(ctx).FindByEmail(args[0]); err != nil {
+				cli.HandleError(err)
+			}
+
+			if err = service.DefaultUser.With(ctx).Unlock(user.ID); err != nil {
+				cli.HandleError(err)
+			}
+
+			cmd.Printf("User %s unlocked\n", user.Email)
+		},
+	}
+
 	cmd.AddCommand(
 		listCmd,
 		addCmd,
 		pwdCmd,
+		unlockCmd,
 	)
 
 	return cmd
@@ -34,6 +34,7 @@ type (
 		InternalLogin(ctx context.Context, email string, password string) (*types.User, error)
 		SetPassword(ctx context.Context, userID uint64, AuthActionPassword string) error
 		ChangePassword(ctx context.Context, userID uint64, oldPassword, AuthActionPassword string) error
+		ChangeExpiredPassword(ctx context.Context, email, oldPassword, newPassword string) (*types.User, error)
 		LoadRoleMemberships(ctx context.Context, user *types.User) error
 		ValidateEmailConfirmationToken(ctx context.Context, token string) (user *types.User, err error)
 		ExchangePasswordResetToken(ctx context.Context, token string) (user *types.User, exchangedToken string, err error)
@@ -137,6 +138,15 @@ func (ctrl *AuthInternal) ChangePassword(ctx context.Context, r *request.AuthInt
 	}
 }
 
+func (ctrl *AuthInternal) ChangeExpiredPassword(ctx context.Context, r *request.AuthInternalChangeExpiredPassword) (interface{}, error) {
+	u, err := ctrl.authSvc.ChangeExpiredPassword(ctx, r.Email, r.OldPassword, r.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctrl.authInternalValidUserResponse(ctx, u)
+}
+
 func (ctrl AuthInternal) authInternalValidUserResponse(ctx context.Context, u *types.User) (*authInternalValidUserResponse, error) {
 	if err := ctrl.authSvc.LoadRoleMemberships(ctx, u); err != nil {
 		return nil, err
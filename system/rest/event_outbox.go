@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/system/rest/request"
+	"github.com/cortezaproject/corteza-server/system/service"
+)
+
+type (
+	EventOutbox struct {
+		svc service.EventOutboxService
+	}
+
+	eventOutboxSetPayload struct {
+		Filter eventbus.QueuedEventFilter `json:"filter"`
+		Set    eventbus.QueuedEventSet    `json:"set"`
+	}
+
+	eventOutboxPurgePayload struct {
+		Count int `json:"count"`
+	}
+)
+
+func (EventOutbox) New() *EventOutbox {
+	return &EventOutbox{
+		svc: service.DefaultEventOutbox,
+	}
+}
+
+func (ctrl *EventOutbox) List(ctx context.Context, r *request.EventOutboxList) (interface{}, error) {
+	f, err := makeQueuedEventFilter(r.ResourceType, r.EventType, r.Limit, r.PageCursor, r.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	set, f, err := ctrl.svc.SearchOutbox(ctx, f)
+	return ctrl.makeFilterPayload(set, f, err)
+}
+
+func (ctrl *EventOutbox) ListDeadLetters(ctx context.Context, r *request.EventOutboxListDeadLetters) (interface{}, error) {
+	f, err := makeQueuedEventFilter(r.ResourceType, r.EventType, r.Limit, r.PageCursor, r.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	set, f, err := ctrl.svc.SearchDeadLetters(ctx, f)
+	return ctrl.makeFilterPayload(set, f, err)
+}
+
+func (ctrl *EventOutbox) Replay(ctx context.Context, r *request.EventOutboxReplay) (interface{}, error) {
+	return ctrl.svc.Replay(ctx, r.EventID)
+}
+
+func (ctrl *EventOutbox) Purge(ctx context.Context, r *request.EventOutboxPurge) (interface{}, error) {
+	n, err := ctrl.svc.Purge(ctx, eventbus.QueuedEventFilter{
+		ResourceType: r.ResourceType,
+		EventType:    r.EventType,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventOutboxPurgePayload{Count: n}, nil
+}
+
+func (ctrl EventOutbox) makeFilterPayload(set eventbus.QueuedEventSet, f eventbus.QueuedEventFilter, err error) (*eventOutboxSetPayload, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	if len(set) == 0 {
+		set = make([]*eventbus.QueuedEvent, 0)
+	}
+
+	return &eventOutboxSetPayload{Filter: f, Set: set}, nil
+}
+
+func makeQueuedEventFilter(resourceType, eventType string, limit uint, cursor, sort string) (f eventbus.QueuedEventFilter, err error) {
+	f = eventbus.QueuedEventFilter{
+		ResourceType: resourceType,
+		EventType:    eventType,
+	}
+
+	if f.Paging, err = filter.NewPaging(limit, cursor); err != nil {
+		return
+	}
+
+	if f.Sorting, err = filter.NewSorting(sort); err != nil {
+		return
+	}
+
+	return
+}
@@ -26,6 +26,7 @@ type (
 		ResetPassword(context.Context, *request.AuthInternalResetPassword) (interface{}, error)
 		ConfirmEmail(context.Context, *request.AuthInternalConfirmEmail) (interface{}, error)
 		ChangePassword(context.Context, *request.AuthInternalChangePassword) (interface{}, error)
+		ChangeExpiredPassword(context.Context, *request.AuthInternalChangeExpiredPassword) (interface{}, error)
 	}
 
 	// HTTP API interface
@@ -37,6 +38,7 @@ type (
 		ResetPassword              func(http.ResponseWriter, *http.Request)
 		ConfirmEmail               func(http.ResponseWriter, *http.Request)
 		ChangePassword             func(http.ResponseWriter, *http.Request)
+		ChangeExpiredPassword      func(http.ResponseWriter, *http.Request)
 	}
 )
 
@@ -152,6 +154,22 @@ func NewAuthInternal(h AuthInternalAPI) *AuthInternal {
 				return
 			}
 
+			api.Send(w, r, value)
+		},
+		ChangeExpiredPassword: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewAuthInternalChangeExpiredPassword()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.ChangeExpiredPassword(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
 			api.Send(w, r, value)
 		},
 	}
@@ -167,5 +185,6 @@ func (h AuthInternal) MountRoutes(r chi.Router, middlewares ...func(http.Handler
 		r.Post("/auth/internal/reset-password", h.ResetPassword)
 		r.Post("/auth/internal/confirm-email", h.ConfirmEmail)
 		r.Post("/auth/internal/change-password", h.ChangePassword)
+		r.Post("/auth/internal/change-expired-password", h.ChangeExpiredPassword)
 	})
 }
@@ -0,0 +1,114 @@
+package handlers
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+//
+
+import (
+	"context"
+	"github.com/cortezaproject/corteza-server/pkg/api"
+	"github.com/cortezaproject/corteza-server/system/rest/request"
+	"github.com/go-chi/chi"
+	"net/http"
+)
+
+type (
+	// Internal API interface
+	EventOutboxAPI interface {
+		List(context.Context, *request.EventOutboxList) (interface{}, error)
+		ListDeadLetters(context.Context, *request.EventOutboxListDeadLetters) (interface{}, error)
+		Replay(context.Context, *request.EventOutboxReplay) (interface{}, error)
+		Purge(context.Context, *request.EventOutboxPurge) (interface{}, error)
+	}
+
+	// HTTP API interface
+	EventOutbox struct {
+		List            func(http.ResponseWriter, *http.Request)
+		ListDeadLetters func(http.ResponseWriter, *http.Request)
+		Replay          func(http.ResponseWriter, *http.Request)
+		Purge           func(http.ResponseWriter, *http.Request)
+	}
+)
+
+func NewEventOutbox(h EventOutboxAPI) *EventOutbox {
+	return &EventOutbox{
+		List: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewEventOutboxList()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.List(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		ListDeadLetters: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewEventOutboxListDeadLetters()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.ListDeadLetters(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		Replay: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewEventOutboxReplay()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.Replay(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		Purge: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewEventOutboxPurge()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.Purge(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+	}
+}
+
+func (h EventOutbox) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares...)
+		r.Get("/event-outbox/", h.List)
+		r.Get("/event-outbox/dead-letters", h.ListDeadLetters)
+		r.Post("/event-outbox/dead-letters/{eventID}/replay", h.Replay)
+		r.Delete("/event-outbox/dead-letters", h.Purge)
+	})
+}
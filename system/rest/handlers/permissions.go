@@ -21,6 +21,8 @@ type (
 	PermissionsAPI interface {
 		List(context.Context, *request.PermissionsList) (interface{}, error)
 		Effective(context.Context, *request.PermissionsEffective) (interface{}, error)
+		Explain(context.Context, *request.PermissionsExplain) (interface{}, error)
+		WhatIf(context.Context, *request.PermissionsWhatIf) (interface{}, error)
 		Read(context.Context, *request.PermissionsRead) (interface{}, error)
 		Delete(context.Context, *request.PermissionsDelete) (interface{}, error)
 		Update(context.Context, *request.PermissionsUpdate) (interface{}, error)
@@ -30,6 +32,8 @@ type (
 	Permissions struct {
 		List      func(http.ResponseWriter, *http.Request)
 		Effective func(http.ResponseWriter, *http.Request)
+		Explain   func(http.ResponseWriter, *http.Request)
+		WhatIf    func(http.ResponseWriter, *http.Request)
 		Read      func(http.ResponseWriter, *http.Request)
 		Delete    func(http.ResponseWriter, *http.Request)
 		Update    func(http.ResponseWriter, *http.Request)
@@ -70,6 +74,38 @@ func NewPermissions(h PermissionsAPI) *Permissions {
 
 			api.Send(w, r, value)
 		},
+		Explain: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewPermissionsExplain()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.Explain(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
+		WhatIf: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewPermissionsWhatIf()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.WhatIf(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
 		Read: func(w http.ResponseWriter, r *http.Request) {
 			defer r.Body.Close()
 			params := request.NewPermissionsRead()
@@ -126,6 +162,8 @@ func (h Permissions) MountRoutes(r chi.Router, middlewares ...func(http.Handler)
 		r.Use(middlewares...)
 		r.Get("/permissions/", h.List)
 		r.Get("/permissions/effective", h.Effective)
+		r.Get("/permissions/explain", h.Explain)
+		r.Post("/permissions/what-if", h.WhatIf)
 		r.Get("/permissions/{roleID}/rules", h.Read)
 		r.Delete("/permissions/{roleID}/rules", h.Delete)
 		r.Patch("/permissions/{roleID}/rules", h.Update)
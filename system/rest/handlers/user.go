@@ -26,6 +26,7 @@ type (
 		Delete(context.Context, *request.UserDelete) (interface{}, error)
 		Suspend(context.Context, *request.UserSuspend) (interface{}, error)
 		Unsuspend(context.Context, *request.UserUnsuspend) (interface{}, error)
+		Unlock(context.Context, *request.UserUnlock) (interface{}, error)
 		Undelete(context.Context, *request.UserUndelete) (interface{}, error)
 		SetPassword(context.Context, *request.UserSetPassword) (interface{}, error)
 		MembershipList(context.Context, *request.UserMembershipList) (interface{}, error)
@@ -43,6 +44,7 @@ type (
 		Delete           func(http.ResponseWriter, *http.Request)
 		Suspend          func(http.ResponseWriter, *http.Request)
 		Unsuspend        func(http.ResponseWriter, *http.Request)
+		Unlock           func(http.ResponseWriter, *http.Request)
 		Undelete         func(http.ResponseWriter, *http.Request)
 		SetPassword      func(http.ResponseWriter, *http.Request)
 		MembershipList   func(http.ResponseWriter, *http.Request)
@@ -166,6 +168,22 @@ func NewUser(h UserAPI) *User {
 
 			api.Send(w, r, value)
 		},
+		Unlock: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewUserUnlock()
+			if err := params.Fill(r); err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			value, err := h.Unlock(r.Context(), params)
+			if err != nil {
+				api.Send(w, r, err)
+				return
+			}
+
+			api.Send(w, r, value)
+		},
 		Undelete: func(w http.ResponseWriter, r *http.Request) {
 			defer r.Body.Close()
 			params := request.NewUserUndelete()
@@ -275,6 +293,7 @@ func (h User) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http.H
 		r.Delete("/users/{userID}", h.Delete)
 		r.Post("/users/{userID}/suspend", h.Suspend)
 		r.Post("/users/{userID}/unsuspend", h.Unsuspend)
+		r.Post("/users/{userID}/unlock", h.Unlock)
 		r.Post("/users/{userID}/undelete", h.Undelete)
 		r.Post("/users/{userID}/password", h.SetPassword)
 		r.Get("/users/{userID}/membership", h.MembershipList)
@@ -3,6 +3,7 @@ package rest
 import (
 	"context"
 	"github.com/cortezaproject/corteza-server/pkg/api"
+	"github.com/cortezaproject/corteza-server/pkg/payload"
 
 	"github.com/cortezaproject/corteza-server/pkg/rbac"
 	"github.com/cortezaproject/corteza-server/system/rest/request"
@@ -19,6 +20,8 @@ type (
 		Whitelist() rbac.Whitelist
 		FindRulesByRoleID(context.Context, uint64) (rbac.RuleSet, error)
 		Grant(ctx context.Context, rr ...*rbac.Rule) error
+		Explain(context.Context, uint64, rbac.Resource, rbac.Operation, ...uint64) (*rbac.Explanation, error)
+		WhatIf(context.Context, rbac.RuleSet, rbac.Resource) ([]*rbac.WhatIfResult, error)
 	}
 )
 
@@ -36,6 +39,20 @@ func (ctrl Permissions) List(ctx context.Context, r *request.PermissionsList) (i
 	return ctrl.ac.Whitelist().Flatten(), nil
 }
 
+func (ctrl Permissions) Explain(ctx context.Context, r *request.PermissionsExplain) (interface{}, error) {
+	return ctrl.ac.Explain(
+		ctx,
+		r.UserID,
+		rbac.Resource(r.Resource),
+		rbac.Operation(r.Operation),
+		payload.ParseUint64s(r.DynamicRoles)...,
+	)
+}
+
+func (ctrl Permissions) WhatIf(ctx context.Context, r *request.PermissionsWhatIf) (interface{}, error) {
+	return ctrl.ac.WhatIf(ctx, r.Rules, rbac.Resource(r.Resource))
+}
+
 func (ctrl Permissions) Read(ctx context.Context, r *request.PermissionsRead) (interface{}, error) {
 	return ctrl.ac.FindRulesByRoleID(ctx, r.RoleID)
 }
@@ -112,6 +112,23 @@ type (
 		// New password
 		NewPassword string
 	}
+
+	AuthInternalChangeExpiredPassword struct {
+		// Email POST parameter
+		//
+		// Email
+		Email string
+
+		// OldPassword POST parameter
+		//
+		// Old password
+		OldPassword string
+
+		// NewPassword POST parameter
+		//
+		// New password
+		NewPassword string
+	}
 )
 
 // NewAuthInternalLogin request
@@ -534,3 +551,75 @@ func (r *AuthInternalChangePassword) Fill(req *http.Request) (err error) {
 
 	return err
 }
+
+// NewAuthInternalChangeExpiredPassword request
+func NewAuthInternalChangeExpiredPassword() *AuthInternalChangeExpiredPassword {
+	return &AuthInternalChangeExpiredPassword{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r AuthInternalChangeExpiredPassword) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"email": r.Email,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r AuthInternalChangeExpiredPassword) GetEmail() string {
+	return r.Email
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r AuthInternalChangeExpiredPassword) GetOldPassword() string {
+	return r.OldPassword
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r AuthInternalChangeExpiredPassword) GetNewPassword() string {
+	return r.NewPassword
+}
+
+// Fill processes request and fills internal variables
+func (r *AuthInternalChangeExpiredPassword) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		if err = req.ParseForm(); err != nil {
+			return err
+		}
+
+		// POST params
+
+		if val, ok := req.Form["email"]; ok && len(val) > 0 {
+			r.Email, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["oldPassword"]; ok && len(val) > 0 {
+			r.OldPassword, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["newPassword"]; ok && len(val) > 0 {
+			r.NewPassword, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,392 @@
+package request
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+//
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/cortezaproject/corteza-server/pkg/payload"
+	"github.com/go-chi/chi"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// dummy vars to prevent
+// unused imports complain
+var (
+	_ = chi.URLParam
+	_ = multipart.ErrMessageTooLarge
+	_ = payload.ParseUint64s
+)
+
+type (
+	// Internal API interface
+	EventOutboxList struct {
+		// ResourceType GET parameter
+		//
+		// Filter by resource type
+		ResourceType string
+
+		// EventType GET parameter
+		//
+		// Filter by event type
+		EventType string
+
+		// Limit GET parameter
+		//
+		// Limit
+		Limit uint
+
+		// PageCursor GET parameter
+		//
+		// Page cursor
+		PageCursor string
+
+		// Sort GET parameter
+		//
+		// Sort items
+		Sort string
+	}
+
+	EventOutboxListDeadLetters struct {
+		// ResourceType GET parameter
+		//
+		// Filter by resource type
+		ResourceType string
+
+		// EventType GET parameter
+		//
+		// Filter by event type
+		EventType string
+
+		// Limit GET parameter
+		//
+		// Limit
+		Limit uint
+
+		// PageCursor GET parameter
+		//
+		// Page cursor
+		PageCursor string
+
+		// Sort GET parameter
+		//
+		// Sort items
+		Sort string
+	}
+
+	EventOutboxReplay struct {
+		// EventID PATH parameter
+		//
+		// Event ID
+		EventID uint64 `json:",string"`
+	}
+
+	EventOutboxPurge struct {
+		// ResourceType GET parameter
+		//
+		// Remove only dead letters of resource type
+		ResourceType string
+
+		// EventType GET parameter
+		//
+		// Remove only dead letters of event type
+		EventType string
+	}
+)
+
+// NewEventOutboxList request
+func NewEventOutboxList() *EventOutboxList {
+	return &EventOutboxList{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxList) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"resourceType": r.ResourceType,
+		"eventType":    r.EventType,
+		"limit":        r.Limit,
+		"pageCursor":   r.PageCursor,
+		"sort":         r.Sort,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxList) GetResourceType() string {
+	return r.ResourceType
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxList) GetEventType() string {
+	return r.EventType
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxList) GetLimit() uint {
+	return r.Limit
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxList) GetPageCursor() string {
+	return r.PageCursor
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxList) GetSort() string {
+	return r.Sort
+}
+
+// Fill processes request and fills internal variables
+func (r *EventOutboxList) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		// GET params
+		tmp := req.URL.Query()
+
+		if val, ok := tmp["resourceType"]; ok && len(val) > 0 {
+			r.ResourceType, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["eventType"]; ok && len(val) > 0 {
+			r.EventType, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["limit"]; ok && len(val) > 0 {
+			r.Limit, err = payload.ParseUint(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["pageCursor"]; ok && len(val) > 0 {
+			r.PageCursor, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["sort"]; ok && len(val) > 0 {
+			r.Sort, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return err
+}
+
+// NewEventOutboxListDeadLetters request
+func NewEventOutboxListDeadLetters() *EventOutboxListDeadLetters {
+	return &EventOutboxListDeadLetters{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxListDeadLetters) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"resourceType": r.ResourceType,
+		"eventType":    r.EventType,
+		"limit":        r.Limit,
+		"pageCursor":   r.PageCursor,
+		"sort":         r.Sort,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxListDeadLetters) GetResourceType() string {
+	return r.ResourceType
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxListDeadLetters) GetEventType() string {
+	return r.EventType
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxListDeadLetters) GetLimit() uint {
+	return r.Limit
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxListDeadLetters) GetPageCursor() string {
+	return r.PageCursor
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxListDeadLetters) GetSort() string {
+	return r.Sort
+}
+
+// Fill processes request and fills internal variables
+func (r *EventOutboxListDeadLetters) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		// GET params
+		tmp := req.URL.Query()
+
+		if val, ok := tmp["resourceType"]; ok && len(val) > 0 {
+			r.ResourceType, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["eventType"]; ok && len(val) > 0 {
+			r.EventType, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["limit"]; ok && len(val) > 0 {
+			r.Limit, err = payload.ParseUint(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["pageCursor"]; ok && len(val) > 0 {
+			r.PageCursor, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["sort"]; ok && len(val) > 0 {
+			r.Sort, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return err
+}
+
+// NewEventOutboxReplay request
+func NewEventOutboxReplay() *EventOutboxReplay {
+	return &EventOutboxReplay{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxReplay) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"eventID": r.EventID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxReplay) GetEventID() uint64 {
+	return r.EventID
+}
+
+// Fill processes request and fills internal variables
+func (r *EventOutboxReplay) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "eventID")
+		r.EventID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewEventOutboxPurge request
+func NewEventOutboxPurge() *EventOutboxPurge {
+	return &EventOutboxPurge{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxPurge) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"resourceType": r.ResourceType,
+		"eventType":    r.EventType,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxPurge) GetResourceType() string {
+	return r.ResourceType
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r EventOutboxPurge) GetEventType() string {
+	return r.EventType
+}
+
+// Fill processes request and fills internal variables
+func (r *EventOutboxPurge) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		// GET params
+		tmp := req.URL.Query()
+
+		if val, ok := tmp["resourceType"]; ok && len(val) > 0 {
+			r.ResourceType, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["eventType"]; ok && len(val) > 0 {
+			r.EventType, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return err
+}
@@ -40,6 +40,40 @@ type (
 		Resource string
 	}
 
+	PermissionsExplain struct {
+		// UserID GET parameter
+		//
+		// User ID
+		UserID uint64 `json:",string"`
+
+		// Resource GET parameter
+		//
+		// Resource
+		Resource string
+
+		// Operation GET parameter
+		//
+		// Operation
+		Operation string
+
+		// DynamicRoles GET parameter
+		//
+		// Dynamic roles (owners, creators...) user has on the resource
+		DynamicRoles []string
+	}
+
+	PermissionsWhatIf struct {
+		// Rules POST parameter
+		//
+		// List of permission rules to change
+		Rules rbac.RuleSet
+
+		// Resource POST parameter
+		//
+		// Compare access on a specific resource instead of resources of the rules
+		Resource string
+	}
+
 	PermissionsRead struct {
 		// RoleID PATH parameter
 		//
@@ -138,6 +172,153 @@ func (r *PermissionsEffective) Fill(req *http.Request) (err error) {
 	return err
 }
 
+// NewPermissionsExplain request
+func NewPermissionsExplain() *PermissionsExplain {
+	return &PermissionsExplain{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r PermissionsExplain) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"userID":       r.UserID,
+		"resource":     r.Resource,
+		"operation":    r.Operation,
+		"dynamicRoles": r.DynamicRoles,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r PermissionsExplain) GetUserID() uint64 {
+	return r.UserID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r PermissionsExplain) GetResource() string {
+	return r.Resource
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r PermissionsExplain) GetOperation() string {
+	return r.Operation
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r PermissionsExplain) GetDynamicRoles() []string {
+	return r.DynamicRoles
+}
+
+// Fill processes request and fills internal variables
+func (r *PermissionsExplain) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		// GET params
+		tmp := req.URL.Query()
+
+		if val, ok := tmp["userID"]; ok && len(val) > 0 {
+			r.UserID, err = payload.ParseUint64(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["resource"]; ok && len(val) > 0 {
+			r.Resource, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["operation"]; ok && len(val) > 0 {
+			r.Operation, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["dynamicRoles[]"]; ok {
+			r.DynamicRoles, err = val, nil
+			if err != nil {
+				return err
+			}
+		} else if val, ok := tmp["dynamicRoles"]; ok {
+			r.DynamicRoles, err = val, nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return err
+}
+
+// NewPermissionsWhatIf request
+func NewPermissionsWhatIf() *PermissionsWhatIf {
+	return &PermissionsWhatIf{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r PermissionsWhatIf) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"rules":    r.Rules,
+		"resource": r.Resource,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r PermissionsWhatIf) GetRules() rbac.RuleSet {
+	return r.Rules
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r PermissionsWhatIf) GetResource() string {
+	return r.Resource
+}
+
+// Fill processes request and fills internal variables
+func (r *PermissionsWhatIf) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		if err = req.ParseForm(); err != nil {
+			return err
+		}
+
+		// POST params
+
+		//if val, ok := req.Form["rules[]"]; ok && len(val) > 0  {
+		//    r.Rules, err = rbac.RuleSet(val), nil
+		//    if err != nil {
+		//        return err
+		//    }
+		//}
+
+		if val, ok := req.Form["resource"]; ok && len(val) > 0 {
+			r.Resource, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return err
+}
+
 // NewPermissionsRead request
 func NewPermissionsRead() *PermissionsRead {
 	return &PermissionsRead{}
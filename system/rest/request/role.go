@@ -18,6 +18,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // dummy vars to prevent
@@ -78,6 +79,11 @@ type (
 		// Handle for Role
 		Handle string
 
+		// ParentID POST parameter
+		//
+		// Parent role ID; role inherits rules of the parent
+		ParentID uint64 `json:",string"`
+
 		// Members POST parameter
 		//
 		// Role member IDs
@@ -105,6 +111,11 @@ type (
 		// Handle for Role
 		Handle string
 
+		// ParentID POST parameter
+		//
+		// Parent role ID; role inherits rules of the parent
+		ParentID uint64 `json:",string"`
+
 		// Members POST parameter
 		//
 		// Role member IDs
@@ -192,6 +203,16 @@ type (
 		//
 		// User ID
 		UserID uint64 `json:",string"`
+
+		// ValidFrom POST parameter
+		//
+		// Membership is valid from
+		ValidFrom *time.Time
+
+		// ValidUntil POST parameter
+		//
+		// Membership expires at
+		ValidUntil *time.Time
 	}
 
 	RoleMemberRemove struct {
@@ -349,10 +370,11 @@ func NewRoleCreate() *RoleCreate {
 // Auditable returns all auditable/loggable parameters
 func (r RoleCreate) Auditable() map[string]interface{} {
 	return map[string]interface{}{
-		"name":    r.Name,
-		"handle":  r.Handle,
-		"members": r.Members,
-		"labels":  r.Labels,
+		"name":     r.Name,
+		"handle":   r.Handle,
+		"parentID": r.ParentID,
+		"members":  r.Members,
+		"labels":   r.Labels,
 	}
 }
 
@@ -366,6 +388,11 @@ func (r RoleCreate) GetHandle() string {
 	return r.Handle
 }
 
+// Auditable returns all auditable/loggable parameters
+func (r RoleCreate) GetParentID() uint64 {
+	return r.ParentID
+}
+
 // Auditable returns all auditable/loggable parameters
 func (r RoleCreate) GetMembers() []string {
 	return r.Members
@@ -410,6 +437,13 @@ func (r *RoleCreate) Fill(req *http.Request) (err error) {
 			}
 		}
 
+		if val, ok := req.Form["parentID"]; ok && len(val) > 0 {
+			r.ParentID, err = payload.ParseUint64(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+
 		//if val, ok := req.Form["members[]"]; ok && len(val) > 0  {
 		//    r.Members, err = val, nil
 		//    if err != nil {
@@ -441,11 +475,12 @@ func NewRoleUpdate() *RoleUpdate {
 // Auditable returns all auditable/loggable parameters
 func (r RoleUpdate) Auditable() map[string]interface{} {
 	return map[string]interface{}{
-		"roleID":  r.RoleID,
-		"name":    r.Name,
-		"handle":  r.Handle,
-		"members": r.Members,
-		"labels":  r.Labels,
+		"roleID":   r.RoleID,
+		"name":     r.Name,
+		"handle":   r.Handle,
+		"parentID": r.ParentID,
+		"members":  r.Members,
+		"labels":   r.Labels,
 	}
 }
 
@@ -464,6 +499,11 @@ func (r RoleUpdate) GetHandle() string {
 	return r.Handle
 }
 
+// Auditable returns all auditable/loggable parameters
+func (r RoleUpdate) GetParentID() uint64 {
+	return r.ParentID
+}
+
 // Auditable returns all auditable/loggable parameters
 func (r RoleUpdate) GetMembers() []string {
 	return r.Members
@@ -508,6 +548,13 @@ func (r *RoleUpdate) Fill(req *http.Request) (err error) {
 			}
 		}
 
+		if val, ok := req.Form["parentID"]; ok && len(val) > 0 {
+			r.ParentID, err = payload.ParseUint64(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+
 		//if val, ok := req.Form["members[]"]; ok && len(val) > 0  {
 		//    r.Members, err = val, nil
 		//    if err != nil {
@@ -953,8 +1000,10 @@ func NewRoleMemberAdd() *RoleMemberAdd {
 // Auditable returns all auditable/loggable parameters
 func (r RoleMemberAdd) Auditable() map[string]interface{} {
 	return map[string]interface{}{
-		"roleID": r.RoleID,
-		"userID": r.UserID,
+		"roleID":     r.RoleID,
+		"userID":     r.UserID,
+		"validFrom":  r.ValidFrom,
+		"validUntil": r.ValidUntil,
 	}
 }
 
@@ -968,6 +1017,16 @@ func (r RoleMemberAdd) GetUserID() uint64 {
 	return r.UserID
 }
 
+// Auditable returns all auditable/loggable parameters
+func (r RoleMemberAdd) GetValidFrom() *time.Time {
+	return r.ValidFrom
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RoleMemberAdd) GetValidUntil() *time.Time {
+	return r.ValidUntil
+}
+
 // Fill processes request and fills internal variables
 func (r *RoleMemberAdd) Fill(req *http.Request) (err error) {
 	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
@@ -981,6 +1040,28 @@ func (r *RoleMemberAdd) Fill(req *http.Request) (err error) {
 		}
 	}
 
+	{
+		if err = req.ParseForm(); err != nil {
+			return err
+		}
+
+		// POST params
+
+		if val, ok := req.Form["validFrom"]; ok && len(val) > 0 {
+			r.ValidFrom, err = payload.ParseISODatePtrWithErr(val[0])
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["validUntil"]; ok && len(val) > 0 {
+			r.ValidUntil, err = payload.ParseISODatePtrWithErr(val[0])
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	{
 		var val string
 		// path params
@@ -0,0 +1,864 @@
+package request
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+//
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/cortezaproject/corteza-server/pkg/payload"
+	"github.com/cortezaproject/corteza-server/system/types"
+	"github.com/go-chi/chi"
+	sqlxTypes "github.com/jmoiron/sqlx/types"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// dummy vars to prevent
+// unused imports complain
+var (
+	_ = chi.URLParam
+	_ = multipart.ErrMessageTooLarge
+	_ = payload.ParseUint64s
+)
+
+type (
+	// Internal API interface
+	TemplateList struct {
+		// Handle GET parameter
+		//
+		// Template handle
+		Handle string
+
+		// Language GET parameter
+		//
+		// Template language
+		Language string
+
+		// Type GET parameter
+		//
+		// Template type (text/html, text/plain)
+		Type types.TemplateType
+
+		// Query GET parameter
+		//
+		// Filter templates by handle
+		Query string
+
+		// Partial GET parameter
+		//
+		// Exclude (0, default), include (1) or return only (2) partials
+		Partial uint
+
+		// Deleted GET parameter
+		//
+		// Exclude (0, default), include (1) or return only (2) deleted templates
+		Deleted uint
+
+		// Limit GET parameter
+		//
+		// Limit
+		Limit uint
+
+		// PageCursor GET parameter
+		//
+		// Page cursor
+		PageCursor string
+
+		// Sort GET parameter
+		//
+		// Sort items
+		Sort string
+	}
+
+	TemplateCreate struct {
+		// Handle POST parameter
+		//
+		// Template handle
+		Handle string
+
+		// Language POST parameter
+		//
+		// Template language
+		Language string
+
+		// Type POST parameter
+		//
+		// Template type (text/html, text/plain)
+		Type types.TemplateType
+
+		// Partial POST parameter
+		//
+		// Template can only be included into other templates
+		Partial bool
+
+		// Meta POST parameter
+		//
+		// Template meta (short, description, subject, sample)
+		Meta sqlxTypes.JSONText
+
+		// Template POST parameter
+		//
+		// Template source
+		Template string
+	}
+
+	TemplateUpdate struct {
+		// TemplateID PATH parameter
+		//
+		// Template ID
+		TemplateID uint64 `json:",string"`
+
+		// Handle POST parameter
+		//
+		// Template handle
+		Handle string
+
+		// Language POST parameter
+		//
+		// Template language
+		Language string
+
+		// Type POST parameter
+		//
+		// Template type (text/html, text/plain)
+		Type types.TemplateType
+
+		// Partial POST parameter
+		//
+		// Template can only be included into other templates
+		Partial bool
+
+		// Meta POST parameter
+		//
+		// Template meta (short, description, subject, sample)
+		Meta sqlxTypes.JSONText
+
+		// Template POST parameter
+		//
+		// Template source
+		Template string
+	}
+
+	TemplateRead struct {
+		// TemplateID PATH parameter
+		//
+		// Template ID
+		TemplateID uint64 `json:",string"`
+	}
+
+	TemplateDelete struct {
+		// TemplateID PATH parameter
+		//
+		// Template ID
+		TemplateID uint64 `json:",string"`
+	}
+
+	TemplateUndelete struct {
+		// TemplateID PATH parameter
+		//
+		// Template ID
+		TemplateID uint64 `json:",string"`
+	}
+
+	TemplatePreview struct {
+		// TemplateID PATH parameter
+		//
+		// Template ID
+		TemplateID uint64 `json:",string"`
+
+		// Variables POST parameter
+		//
+		// Variables (sample variables from template meta are used if omitted)
+		Variables sqlxTypes.JSONText
+	}
+
+	TemplateRender struct {
+		// Handle PATH parameter
+		//
+		// Template handle
+		Handle string
+
+		// Language POST parameter
+		//
+		// Language (defaults to preferred language of the current user)
+		Language string
+
+		// Variables POST parameter
+		//
+		// Variables
+		Variables sqlxTypes.JSONText
+	}
+)
+
+// NewTemplateList request
+func NewTemplateList() *TemplateList {
+	return &TemplateList{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateList) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"handle":     r.Handle,
+		"language":   r.Language,
+		"type":       r.Type,
+		"query":      r.Query,
+		"partial":    r.Partial,
+		"deleted":    r.Deleted,
+		"limit":      r.Limit,
+		"pageCursor": r.PageCursor,
+		"sort":       r.Sort,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateList) GetHandle() string {
+	return r.Handle
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateList) GetLanguage() string {
+	return r.Language
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateList) GetType() types.TemplateType {
+	return r.Type
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateList) GetQuery() string {
+	return r.Query
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateList) GetPartial() uint {
+	return r.Partial
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateList) GetDeleted() uint {
+	return r.Deleted
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateList) GetLimit() uint {
+	return r.Limit
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateList) GetPageCursor() string {
+	return r.PageCursor
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateList) GetSort() string {
+	return r.Sort
+}
+
+// Fill processes request and fills internal variables
+func (r *TemplateList) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		// GET params
+		tmp := req.URL.Query()
+
+		if val, ok := tmp["handle"]; ok && len(val) > 0 {
+			r.Handle, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["language"]; ok && len(val) > 0 {
+			r.Language, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["type"]; ok && len(val) > 0 {
+			r.Type, err = types.TemplateType(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["query"]; ok && len(val) > 0 {
+			r.Query, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["partial"]; ok && len(val) > 0 {
+			r.Partial, err = payload.ParseUint(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["deleted"]; ok && len(val) > 0 {
+			r.Deleted, err = payload.ParseUint(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["limit"]; ok && len(val) > 0 {
+			r.Limit, err = payload.ParseUint(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["pageCursor"]; ok && len(val) > 0 {
+			r.PageCursor, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+		if val, ok := tmp["sort"]; ok && len(val) > 0 {
+			r.Sort, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return err
+}
+
+// NewTemplateCreate request
+func NewTemplateCreate() *TemplateCreate {
+	return &TemplateCreate{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateCreate) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"handle":   r.Handle,
+		"language": r.Language,
+		"type":     r.Type,
+		"partial":  r.Partial,
+		"meta":     r.Meta,
+		"template": r.Template,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateCreate) GetHandle() string {
+	return r.Handle
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateCreate) GetLanguage() string {
+	return r.Language
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateCreate) GetType() types.TemplateType {
+	return r.Type
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateCreate) GetPartial() bool {
+	return r.Partial
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateCreate) GetMeta() sqlxTypes.JSONText {
+	return r.Meta
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateCreate) GetTemplate() string {
+	return r.Template
+}
+
+// Fill processes request and fills internal variables
+func (r *TemplateCreate) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		if err = req.ParseForm(); err != nil {
+			return err
+		}
+
+		// POST params
+
+		if val, ok := req.Form["handle"]; ok && len(val) > 0 {
+			r.Handle, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["language"]; ok && len(val) > 0 {
+			r.Language, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["type"]; ok && len(val) > 0 {
+			r.Type, err = types.TemplateType(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["partial"]; ok && len(val) > 0 {
+			r.Partial, err = payload.ParseBool(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["meta"]; ok && len(val) > 0 {
+			r.Meta, err = payload.ParseJSONTextWithErr(val[0])
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["template"]; ok && len(val) > 0 {
+			r.Template, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return err
+}
+
+// NewTemplateUpdate request
+func NewTemplateUpdate() *TemplateUpdate {
+	return &TemplateUpdate{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateUpdate) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"templateID": r.TemplateID,
+		"handle":     r.Handle,
+		"language":   r.Language,
+		"type":       r.Type,
+		"partial":    r.Partial,
+		"meta":       r.Meta,
+		"template":   r.Template,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateUpdate) GetTemplateID() uint64 {
+	return r.TemplateID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateUpdate) GetHandle() string {
+	return r.Handle
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateUpdate) GetLanguage() string {
+	return r.Language
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateUpdate) GetType() types.TemplateType {
+	return r.Type
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateUpdate) GetPartial() bool {
+	return r.Partial
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateUpdate) GetMeta() sqlxTypes.JSONText {
+	return r.Meta
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateUpdate) GetTemplate() string {
+	return r.Template
+}
+
+// Fill processes request and fills internal variables
+func (r *TemplateUpdate) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		if err = req.ParseForm(); err != nil {
+			return err
+		}
+
+		// POST params
+
+		if val, ok := req.Form["handle"]; ok && len(val) > 0 {
+			r.Handle, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["language"]; ok && len(val) > 0 {
+			r.Language, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["type"]; ok && len(val) > 0 {
+			r.Type, err = types.TemplateType(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["partial"]; ok && len(val) > 0 {
+			r.Partial, err = payload.ParseBool(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["meta"]; ok && len(val) > 0 {
+			r.Meta, err = payload.ParseJSONTextWithErr(val[0])
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["template"]; ok && len(val) > 0 {
+			r.Template, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "templateID")
+		r.TemplateID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewTemplateRead request
+func NewTemplateRead() *TemplateRead {
+	return &TemplateRead{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateRead) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"templateID": r.TemplateID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateRead) GetTemplateID() uint64 {
+	return r.TemplateID
+}
+
+// Fill processes request and fills internal variables
+func (r *TemplateRead) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "templateID")
+		r.TemplateID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewTemplateDelete request
+func NewTemplateDelete() *TemplateDelete {
+	return &TemplateDelete{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateDelete) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"templateID": r.TemplateID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateDelete) GetTemplateID() uint64 {
+	return r.TemplateID
+}
+
+// Fill processes request and fills internal variables
+func (r *TemplateDelete) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "templateID")
+		r.TemplateID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewTemplateUndelete request
+func NewTemplateUndelete() *TemplateUndelete {
+	return &TemplateUndelete{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateUndelete) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"templateID": r.TemplateID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateUndelete) GetTemplateID() uint64 {
+	return r.TemplateID
+}
+
+// Fill processes request and fills internal variables
+func (r *TemplateUndelete) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "templateID")
+		r.TemplateID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewTemplatePreview request
+func NewTemplatePreview() *TemplatePreview {
+	return &TemplatePreview{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplatePreview) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"templateID": r.TemplateID,
+		"variables":  r.Variables,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplatePreview) GetTemplateID() uint64 {
+	return r.TemplateID
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplatePreview) GetVariables() sqlxTypes.JSONText {
+	return r.Variables
+}
+
+// Fill processes request and fills internal variables
+func (r *TemplatePreview) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		if err = req.ParseForm(); err != nil {
+			return err
+		}
+
+		// POST params
+
+		if val, ok := req.Form["variables"]; ok && len(val) > 0 {
+			r.Variables, err = payload.ParseJSONTextWithErr(val[0])
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "templateID")
+		r.TemplateID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
+// NewTemplateRender request
+func NewTemplateRender() *TemplateRender {
+	return &TemplateRender{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateRender) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"handle":    r.Handle,
+		"language":  r.Language,
+		"variables": r.Variables,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateRender) GetHandle() string {
+	return r.Handle
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateRender) GetLanguage() string {
+	return r.Language
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r TemplateRender) GetVariables() sqlxTypes.JSONText {
+	return r.Variables
+}
+
+// Fill processes request and fills internal variables
+func (r *TemplateRender) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		if err = req.ParseForm(); err != nil {
+			return err
+		}
+
+		// POST params
+
+		if val, ok := req.Form["language"]; ok && len(val) > 0 {
+			r.Language, err = val[0], nil
+			if err != nil {
+				return err
+			}
+		}
+
+		if val, ok := req.Form["variables"]; ok && len(val) > 0 {
+			r.Variables, err = payload.ParseJSONTextWithErr(val[0])
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "handle")
+		r.Handle, err = val, nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
@@ -161,6 +161,11 @@ type (
 		// Kind (normal, bot)
 		Kind types.UserKind
 
+		// PreferredLanguage POST parameter
+		//
+		// Preferred language for notifications (kept unchanged when omitted)
+		PreferredLanguage *string
+
 		// Labels POST parameter
 		//
 		// Labels
@@ -195,6 +200,13 @@ type (
 		UserID uint64 `json:",string"`
 	}
 
+	UserUnlock struct {
+		// UserID PATH parameter
+		//
+		// User ID
+		UserID uint64 `json:",string"`
+	}
+
 	UserUndelete struct {
 		// UserID PATH parameter
 		//
@@ -599,12 +611,13 @@ func NewUserUpdate() *UserUpdate {
 // Auditable returns all auditable/loggable parameters
 func (r UserUpdate) Auditable() map[string]interface{} {
 	return map[string]interface{}{
-		"userID": r.UserID,
-		"email":  r.Email,
-		"name":   r.Name,
-		"handle": r.Handle,
-		"kind":   r.Kind,
-		"labels": r.Labels,
+		"userID":            r.UserID,
+		"email":             r.Email,
+		"name":              r.Name,
+		"handle":            r.Handle,
+		"kind":              r.Kind,
+		"preferredLanguage": r.PreferredLanguage,
+		"labels":            r.Labels,
 	}
 }
 
@@ -633,6 +646,11 @@ func (r UserUpdate) GetKind() types.UserKind {
 	return r.Kind
 }
 
+// Auditable returns all auditable/loggable parameters
+func (r UserUpdate) GetPreferredLanguage() *string {
+	return r.PreferredLanguage
+}
+
 // Auditable returns all auditable/loggable parameters
 func (r UserUpdate) GetLabels() map[string]string {
 	return r.Labels
@@ -686,6 +704,13 @@ func (r *UserUpdate) Fill(req *http.Request) (err error) {
 			}
 		}
 
+		if val, ok := req.Form["preferredLanguage"]; ok && len(val) > 0 {
+			r.PreferredLanguage, err = payload.ParseStringPtr(val[0]), nil
+			if err != nil {
+				return err
+			}
+		}
+
 		if val, ok := req.Form["labels[]"]; ok {
 			r.Labels, err = label.ParseStrings(val)
 			if err != nil {
@@ -894,6 +919,51 @@ func (r *UserUnsuspend) Fill(req *http.Request) (err error) {
 	return err
 }
 
+// NewUserUnlock request
+func NewUserUnlock() *UserUnlock {
+	return &UserUnlock{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r UserUnlock) Auditable() map[string]interface{} {
+	return map[string]interface{}{
+		"userID": r.UserID,
+	}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r UserUnlock) GetUserID() uint64 {
+	return r.UserID
+}
+
+// Fill processes request and fills internal variables
+func (r *UserUnlock) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return fmt.Errorf("error parsing http request body: %w", err)
+		}
+	}
+
+	{
+		var val string
+		// path params
+
+		val = chi.URLParam(req, "userID")
+		r.UserID, err = payload.ParseUint64(val), nil
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return err
+}
+
 // NewUserUndelete request
 func NewUserUndelete() *UserUndelete {
 	return &UserUndelete{}
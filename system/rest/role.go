@@ -82,9 +82,10 @@ func (ctrl Role) Create(ctx context.Context, r *request.RoleCreate) (interface{}
 	var (
 		err  error
 		role = &types.Role{
-			Name:   r.Name,
-			Handle: r.Handle,
-			Labels: r.Labels,
+			Name:     r.Name,
+			Handle:   r.Handle,
+			ParentID: r.ParentID,
+			Labels:   r.Labels,
 		}
 	)
 
@@ -106,10 +107,11 @@ func (ctrl Role) Update(ctx context.Context, r *request.RoleUpdate) (interface{}
 	var (
 		err  error
 		role = &types.Role{
-			ID:     r.RoleID,
-			Name:   r.Name,
-			Handle: r.Handle,
-			Labels: r.Labels,
+			ID:       r.RoleID,
+			Name:     r.Name,
+			Handle:   r.Handle,
+			ParentID: r.ParentID,
+			Labels:   r.Labels,
 		}
 	)
 
@@ -180,7 +182,7 @@ func (ctrl Role) MemberList(ctx context.Context, r *request.RoleMemberList) (int
 }
 
 func (ctrl Role) MemberAdd(ctx context.Context, r *request.RoleMemberAdd) (interface{}, error) {
-	return api.OK(), ctrl.role.With(ctx).MemberAdd(r.RoleID, r.UserID)
+	return api.OK(), ctrl.role.With(ctx).MemberAddTimeBound(r.RoleID, r.UserID, r.ValidFrom, r.ValidUntil)
 }
 
 func (ctrl Role) MemberRemove(ctx context.Context, r *request.RoleMemberRemove) (interface{}, error) {
@@ -0,0 +1,197 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/cortezaproject/corteza-server/pkg/api"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/system/rest/request"
+	"github.com/cortezaproject/corteza-server/system/service"
+	"github.com/cortezaproject/corteza-server/system/types"
+	sqlxTypes "github.com/jmoiron/sqlx/types"
+)
+
+type (
+	Template struct {
+		template templateService
+		ac       templateAccessController
+	}
+
+	templateService interface {
+		LookupByID(ctx context.Context, ID uint64) (*types.Template, error)
+		Search(ctx context.Context, f types.TemplateFilter) (types.TemplateSet, types.TemplateFilter, error)
+		Create(ctx context.Context, new *types.Template) (*types.Template, error)
+		Update(ctx context.Context, upd *types.Template) (*types.Template, error)
+		Delete(ctx context.Context, ID uint64) error
+		Undelete(ctx context.Context, ID uint64) error
+
+		Render(ctx context.Context, handle, lang string, vars interface{}) (*types.RenderedTemplate, error)
+		Preview(ctx context.Context, ID uint64, vars map[string]interface{}) (*types.RenderedTemplate, error)
+	}
+
+	templateAccessController interface {
+		CanGrant(context.Context) bool
+
+		CanUpdateTemplate(context.Context, *types.Template) bool
+		CanDeleteTemplate(context.Context, *types.Template) bool
+	}
+
+	templatePayload struct {
+		*types.Template
+
+		CanGrant          bool `json:"canGrant"`
+		CanUpdateTemplate bool `json:"canUpdateTemplate"`
+		CanDeleteTemplate bool `json:"canDeleteTemplate"`
+	}
+
+	templateSetPayload struct {
+		Filter types.TemplateFilter `json:"filter"`
+		Set    []*templatePayload   `json:"set"`
+	}
+)
+
+func (Template) New() *Template {
+	return &Template{
+		template: service.DefaultTemplate,
+		ac:       service.DefaultAccessControl,
+	}
+}
+
+func (ctrl *Template) List(ctx context.Context, r *request.TemplateList) (interface{}, error) {
+	var (
+		err error
+		f   = types.TemplateFilter{
+			Handle:   r.Handle,
+			Language: r.Language,
+			Type:     r.Type,
+			Query:    r.Query,
+
+			Partial: filter.State(r.Partial),
+			Deleted: filter.State(r.Deleted),
+		}
+	)
+
+	if f.Paging, err = filter.NewPaging(r.Limit, r.PageCursor); err != nil {
+		return nil, err
+	}
+
+	if f.Sorting, err = filter.NewSorting(r.Sort); err != nil {
+		return nil, err
+	}
+
+	set, filter, err := ctrl.template.Search(ctx, f)
+	return ctrl.makeFilterPayload(ctx, set, filter, err)
+}
+
+func (ctrl *Template) Create(ctx context.Context, r *request.TemplateCreate) (interface{}, error) {
+	var (
+		err error
+		tpl = &types.Template{
+			Handle:   r.Handle,
+			Language: r.Language,
+			Type:     r.Type,
+			Partial:  r.Partial,
+			Template: r.Template,
+		}
+	)
+
+	if err = unmarshalTemplateMeta(r.Meta, &tpl.Meta); err != nil {
+		return nil, err
+	}
+
+	tpl, err = ctrl.template.Create(ctx, tpl)
+	return ctrl.makePayload(ctx, tpl, err)
+}
+
+func (ctrl *Template) Update(ctx context.Context, r *request.TemplateUpdate) (interface{}, error) {
+	var (
+		err error
+		tpl = &types.Template{
+			ID:       r.TemplateID,
+			Handle:   r.Handle,
+			Language: r.Language,
+			Type:     r.Type,
+			Partial:  r.Partial,
+			Template: r.Template,
+		}
+	)
+
+	if err = unmarshalTemplateMeta(r.Meta, &tpl.Meta); err != nil {
+		return nil, err
+	}
+
+	tpl, err = ctrl.template.Update(ctx, tpl)
+	return ctrl.makePayload(ctx, tpl, err)
+}
+
+func (ctrl *Template) Read(ctx context.Context, r *request.TemplateRead) (interface{}, error) {
+	tpl, err := ctrl.template.LookupByID(ctx, r.TemplateID)
+	return ctrl.makePayload(ctx, tpl, err)
+}
+
+func (ctrl *Template) Delete(ctx context.Context, r *request.TemplateDelete) (interface{}, error) {
+	return api.OK(), ctrl.template.Delete(ctx, r.TemplateID)
+}
+
+func (ctrl *Template) Undelete(ctx context.Context, r *request.TemplateUndelete) (interface{}, error) {
+	return api.OK(), ctrl.template.Undelete(ctx, r.TemplateID)
+}
+
+func (ctrl *Template) Preview(ctx context.Context, r *request.TemplatePreview) (interface{}, error) {
+	vars := make(map[string]interface{})
+	if len(r.Variables) > 0 {
+		if err := r.Variables.Unmarshal(&vars); err != nil {
+			return nil, err
+		}
+	}
+
+	return ctrl.template.Preview(ctx, r.TemplateID, vars)
+}
+
+func (ctrl *Template) Render(ctx context.Context, r *request.TemplateRender) (interface{}, error) {
+	vars := make(map[string]interface{})
+	if len(r.Variables) > 0 {
+		if err := r.Variables.Unmarshal(&vars); err != nil {
+			return nil, err
+		}
+	}
+
+	return ctrl.template.Render(ctx, r.Handle, r.Language, vars)
+}
+
+func (ctrl Template) makePayload(ctx context.Context, tpl *types.Template, err error) (*templatePayload, error) {
+	if err != nil || tpl == nil {
+		return nil, err
+	}
+
+	return &templatePayload{
+		Template: tpl,
+
+		CanGrant: ctrl.ac.CanGrant(ctx),
+
+		CanUpdateTemplate: ctrl.ac.CanUpdateTemplate(ctx, tpl),
+		CanDeleteTemplate: ctrl.ac.CanDeleteTemplate(ctx, tpl),
+	}, nil
+}
+
+func (ctrl Template) makeFilterPayload(ctx context.Context, tt types.TemplateSet, f types.TemplateFilter, err error) (*templateSetPayload, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	tsp := &templateSetPayload{Filter: f, Set: make([]*templatePayload, len(tt))}
+
+	for i := range tt {
+		tsp.Set[i], _ = ctrl.makePayload(ctx, tt[i], nil)
+	}
+
+	return tsp, nil
+}
+
+func unmarshalTemplateMeta(raw sqlxTypes.JSONText, meta *types.TemplateMeta) error {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	return raw.Unmarshal(meta)
+}
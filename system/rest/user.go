@@ -93,6 +93,10 @@ func (ctrl User) Update(ctx context.Context, r *request.UserUpdate) (interface{}
 		Labels: r.Labels,
 	}
 
+	if r.PreferredLanguage != nil {
+		user.Meta = &types.UserMeta{PreferredLanguage: *r.PreferredLanguage}
+	}
+
 	return ctrl.user.With(ctx).Update(user)
 }
 
@@ -112,6 +116,10 @@ func (ctrl User) Unsuspend(ctx context.Context, r *request.UserUnsuspend) (inter
 	return api.OK(), ctrl.user.With(ctx).Unsuspend(r.UserID)
 }
 
+func (ctrl User) Unlock(ctx context.Context, r *request.UserUnlock) (interface{}, error) {
+	return api.OK(), ctrl.user.With(ctx).Unlock(r.UserID)
+}
+
 func (ctrl User) Undelete(ctx context.Context, r *request.UserUndelete) (interface{}, error) {
 	return api.OK(), ctrl.user.With(ctx).Undelete(r.UserID)
 }
@@ -5,8 +5,10 @@ import (
 
 	"github.com/cortezaproject/corteza-server/pkg/actionlog"
 	internalAuth "github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/store"
 
 	"github.com/cortezaproject/corteza-server/pkg/rbac"
+	"github.com/cortezaproject/corteza-server/pkg/slice"
 	"github.com/cortezaproject/corteza-server/system/types"
 )
 
@@ -14,12 +16,14 @@ type (
 	accessControl struct {
 		permissions accessControlRBACServicer
 		actionlog   actionlog.Recorder
+		store       store.Storer
 	}
 
 	accessControlRBACServicer interface {
-		Can([]uint64, rbac.Resource, rbac.Operation, ...rbac.CheckAccessFunc) bool
+		Can(context.Context, []uint64, rbac.Resource, rbac.Operation, ...rbac.CheckAccessFunc) bool
 		Grant(context.Context, rbac.Whitelist, ...*rbac.Rule) error
 		FindRulesByRoleID(roleID uint64) (rr rbac.RuleSet)
+		Rules() (rr rbac.RuleSet)
 	}
 
 	RBACResource interface {
@@ -27,10 +31,21 @@ type (
 	}
 )
 
+var (
+	// access of operations when no rule matches
+	accessDefaults = rbac.RegisterDefaults(rbac.Defaults{
+		types.RoleRBACResource:        {"read": rbac.Allow},
+		types.ApplicationRBACResource: {"read": rbac.Allow},
+		types.TemplateRBACResource:    {"render": rbac.Allow},
+		types.UserRBACResource:        {"impersonate": rbac.Deny},
+	})
+)
+
 func AccessControl(perm accessControlRBACServicer) *accessControl {
 	return &accessControl{
 		permissions: perm,
 		actionlog:   DefaultActionlog,
+		store:       DefaultStore,
 	}
 }
 
@@ -44,6 +59,8 @@ func (svc accessControl) Effective(ctx context.Context) (ee rbac.EffectiveSet) {
 	ee.Push(types.SystemRBACResource, "settings.manage", svc.CanManageSettings(ctx))
 	ee.Push(types.SystemRBACResource, "application.create", svc.CanCreateApplication(ctx))
 	ee.Push(types.SystemRBACResource, "role.create", svc.CanCreateRole(ctx))
+	ee.Push(types.SystemRBACResource, "template.create", svc.CanCreateTemplate(ctx))
+	ee.Push(types.SystemRBACResource, "event-outbox.manage", svc.CanManageEventOutbox(ctx))
 
 	return
 }
@@ -76,12 +93,20 @@ func (svc accessControl) CanCreateApplication(ctx context.Context) bool {
 	return svc.can(ctx, types.SystemRBACResource, "application.create")
 }
 
+func (svc accessControl) CanCreateTemplate(ctx context.Context) bool {
+	return svc.can(ctx, types.SystemRBACResource, "template.create")
+}
+
+func (svc accessControl) CanManageEventOutbox(ctx context.Context) bool {
+	return svc.can(ctx, types.SystemRBACResource, "event-outbox.manage")
+}
+
 func (svc accessControl) CanAssignReminder(ctx context.Context) bool {
 	return svc.can(ctx, types.SystemRBACResource, "reminder.assign")
 }
 
 func (svc accessControl) CanReadRole(ctx context.Context, rl *types.Role) bool {
-	return svc.can(ctx, rl.RBACResource(), "read", rbac.Allowed)
+	return svc.can(ctx, rl.RBACResource(), "read")
 }
 
 func (svc accessControl) CanUpdateRole(ctx context.Context, rl *types.Role) bool {
@@ -108,7 +133,7 @@ func (svc accessControl) CanManageRoleMembers(ctx context.Context, rl *types.Rol
 }
 
 func (svc accessControl) CanReadApplication(ctx context.Context, app *types.Application) bool {
-	return svc.can(ctx, app.RBACResource(), "read", rbac.Allowed)
+	return svc.can(ctx, app.RBACResource(), "read")
 }
 
 func (svc accessControl) CanUpdateApplication(ctx context.Context, app *types.Application) bool {
@@ -119,6 +144,22 @@ func (svc accessControl) CanDeleteApplication(ctx context.Context, app *types.Ap
 	return svc.can(ctx, app.RBACResource(), "delete")
 }
 
+func (svc accessControl) CanReadTemplate(ctx context.Context, tpl *types.Template) bool {
+	return svc.can(ctx, tpl.RBACResource(), "read")
+}
+
+func (svc accessControl) CanUpdateTemplate(ctx context.Context, tpl *types.Template) bool {
+	return svc.can(ctx, tpl.RBACResource(), "update")
+}
+
+func (svc accessControl) CanDeleteTemplate(ctx context.Context, tpl *types.Template) bool {
+	return svc.can(ctx, tpl.RBACResource(), "delete")
+}
+
+func (svc accessControl) CanRenderTemplate(ctx context.Context, tpl *types.Template) bool {
+	return svc.can(ctx, tpl.RBACResource(), "render")
+}
+
 func (svc accessControl) CanReadUser(ctx context.Context, u *types.User) bool {
 	return svc.can(ctx, u.RBACResource(), "read")
 }
@@ -140,7 +181,7 @@ func (svc accessControl) CanDeleteUser(ctx context.Context, u *types.User) bool
 }
 
 func (svc accessControl) CanImpersonateUser(ctx context.Context, u *types.User) bool {
-	return svc.can(ctx, u.RBACResource(), "impersonate", rbac.Denied)
+	return svc.can(ctx, u.RBACResource(), "impersonate")
 }
 
 func (svc accessControl) CanUnmaskEmail(ctx context.Context, u *types.User) bool {
@@ -174,7 +215,7 @@ func (svc accessControl) can(ctx context.Context, res rbac.Resource, op rbac.Ope
 		return true
 	}
 
-	return svc.permissions.Can(roles, res.RBACResource(), op, ff...)
+	return svc.permissions.Can(ctx, roles, res.RBACResource(), op, append(ff, accessDefaults.Fallback(res.RBACResource(), op))...)
 }
 
 func (svc accessControl) Grant(ctx context.Context, rr ...*rbac.Rule) error {
@@ -182,6 +223,12 @@ func (svc accessControl) Grant(ctx context.Context, rr ...*rbac.Rule) error {
 		return AccessControlErrNotAllowedToSetPermissions()
 	}
 
+	for _, r := range rr {
+		if err := r.Conditions.Validate(); err != nil {
+			return AccessControlErrInvalidConditions(&accessControlActionProps{rule: r}).Wrap(err)
+		}
+	}
+
 	if err := svc.permissions.Grant(ctx, svc.Whitelist(), rr...); err != nil {
 		return AccessControlErrGeneric().Wrap(err)
 	}
@@ -213,6 +260,175 @@ func (svc accessControl) FindRulesByRoleID(ctx context.Context, roleID uint64) (
 	return svc.permissions.FindRulesByRoleID(roleID), nil
 }
 
+// Explain describes how access of the user to perform an operation on a resource is resolved
+//
+// Dynamic roles (owners, creators, roles from record's user fields...) depend
+// on the specific resource and can not be resolved here; pass the ones user has on the resource.
+func (svc accessControl) Explain(ctx context.Context, userID uint64, res rbac.Resource, op rbac.Operation, dynamic ...uint64) (*rbac.Explanation, error) {
+	if !svc.CanGrant(ctx) {
+		return nil, AccessControlErrNotAllowedToSetPermissions()
+	}
+
+	if !res.IsValid() || op == "" {
+		return nil, AccessControlErrInvalidRule(&accessControlActionProps{rule: &rbac.Rule{Resource: res, Operation: op}})
+	}
+
+	for _, roleID := range dynamic {
+		if rbac.IsDynamicRole(roleID) {
+			continue
+		}
+
+		// any other role can be assigned dynamically through record's user fields
+		if _, err := store.LookupRoleByID(ctx, svc.store, roleID); err != nil {
+			return nil, AccessControlErrInvalidDynamicRole()
+		}
+	}
+
+	if _, err := store.LookupUserByID(ctx, svc.store, userID); err != nil {
+		return nil, UserErrNotFound()
+	}
+
+	rr, err := membershipRoles(ctx, svc.store, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.permissions.Rules().Explain(res, op, rbac.Default(res, op), append(rr.IDs(), dynamic...)...), nil
+}
+
+// WhatIf reports users that would gain or lose access if the rules were changed
+//
+// Access is compared on resource & operation of each of the changed rules;
+// when resource is given, it is used instead (for example, a specific module
+// when changing rules on all modules).
+//
+// Only users with the roles of the changed rules (or all, when everyone role is changed)
+// are compared. Changes of dynamic roles do not affect any of the users as they
+// depend on the specific resource.
+func (svc accessControl) WhatIf(ctx context.Context, changes rbac.RuleSet, res rbac.Resource) (out []*rbac.WhatIfResult, err error) {
+	if !svc.CanGrant(ctx) {
+		return nil, AccessControlErrNotAllowedToSetPermissions()
+	}
+
+	if res != "" && !res.IsValid() {
+		return nil, AccessControlErrInvalidRule(&accessControlActionProps{rule: &rbac.Rule{Resource: res}})
+	}
+
+	var (
+		users map[uint64][]uint64
+		pairs = make([]*rbac.Rule, 0, len(changes))
+	)
+
+	for _, c := range changes {
+		if !c.Resource.IsValid() || c.Operation == "" {
+			return nil, AccessControlErrInvalidRule(&accessControlActionProps{rule: c})
+		}
+
+		p := &rbac.Rule{Resource: c.Resource, Operation: c.Operation}
+		if res != "" {
+			p.Resource = res
+		}
+
+		if !hasRule(pairs, p) {
+			pairs = append(pairs, p)
+		}
+	}
+
+	if users, err = svc.affectedUsers(ctx, changes.Roles()); err != nil {
+		return nil, err
+	}
+
+	rules := svc.permissions.Rules()
+	out = make([]*rbac.WhatIfResult, 0, len(pairs))
+	for _, p := range pairs {
+		out = append(out, rules.WhatIf(changes, p.Resource, p.Operation, users))
+	}
+
+	return out, nil
+}
+
+// affectedUsers returns valid users (and their roles) that are members of any of the roles
+//
+// Members of roles that inherit (directly or not) from any of the given roles are also affected.
+func (svc accessControl) affectedUsers(ctx context.Context, roles []uint64) (map[uint64][]uint64, error) {
+	var (
+		f        = types.UserFilter{}
+		out      = make(map[uint64][]uint64)
+		members  = make(map[uint64][]uint64)
+		affected = make([]uint64, 0, len(roles))
+		all      bool
+	)
+
+	for _, roleID := range roles {
+		if roleID == rbac.EveryoneRoleID {
+			all = true
+		} else if !rbac.IsDynamicRole(roleID) {
+			affected = append(affected, roleID)
+		}
+	}
+
+	if !all && len(affected) == 0 {
+		return out, nil
+	}
+
+	// memberships of archived & deleted roles are ignored
+	rr, _, err := store.SearchRoles(ctx, svc.store, types.RoleFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	mm, _, err := store.SearchRoleMembers(ctx, svc.store, types.RoleMemberFilter{ValidOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range mm {
+		if rr.FindByID(m.RoleID) != nil {
+			members[m.UserID] = append(members[m.UserID], m.RoleID)
+		}
+	}
+
+	for userID := range members {
+		members[userID] = rr.WithAncestors(members[userID]...)
+
+		if all {
+			continue
+		}
+
+		for _, roleID := range affected {
+			if slice.HasUint64(members[userID], roleID) {
+				f.UserID = append(f.UserID, userID)
+				break
+			}
+		}
+	}
+
+	if !all && len(f.UserID) == 0 {
+		return out, nil
+	}
+
+	uu, _, err := store.SearchUsers(ctx, svc.store, f)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range uu {
+		out[u.ID] = members[u.ID]
+	}
+
+	return out, nil
+}
+
+func hasRule(rr []*rbac.Rule, r *rbac.Rule) bool {
+	for i := range rr {
+		if rr[i].Equals(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (svc accessControl) Whitelist() rbac.Whitelist {
 	var wl = rbac.Whitelist{}
 
@@ -225,7 +441,17 @@ func (svc accessControl) Whitelist() rbac.Whitelist {
 		"role.create",
 		"user.create",
 		"application.create",
+		"template.create",
 		"reminder.assign",
+		"event-outbox.manage",
+	)
+
+	wl.Set(
+		types.TemplateRBACResource,
+		"read",
+		"update",
+		"delete",
+		"render",
 	)
 
 	wl.Set(
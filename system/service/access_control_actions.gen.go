@@ -241,6 +241,96 @@ func AccessControlErrNotAllowedToSetPermissions(mm ...*accessControlActionProps)
 	return e
 }
 
+// AccessControlErrInvalidConditions returns "system:access_control.invalidConditions" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func AccessControlErrInvalidConditions(mm ...*accessControlActionProps) *errors.Error {
+	var p = &accessControlActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid conditions of rule '{rule.operation}' on '{rule.resource}'", nil),
+
+		errors.Meta("type", "invalidConditions"),
+		errors.Meta("resource", "system:access_control"),
+
+		errors.Meta(accessControlPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// AccessControlErrInvalidRule returns "system:access_control.invalidRule" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func AccessControlErrInvalidRule(mm ...*accessControlActionProps) *errors.Error {
+	var p = &accessControlActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid rule: '{rule.operation}' on '{rule.resource}'", nil),
+
+		errors.Meta("type", "invalidRule"),
+		errors.Meta("resource", "system:access_control"),
+
+		errors.Meta(accessControlPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// AccessControlErrInvalidDynamicRole returns "system:access_control.invalidDynamicRole" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func AccessControlErrInvalidDynamicRole(mm ...*accessControlActionProps) *errors.Error {
+	var p = &accessControlActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid dynamic role", nil),
+
+		errors.Meta("type", "invalidDynamicRole"),
+		errors.Meta("resource", "system:access_control"),
+
+		errors.Meta(accessControlPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
 // *********************************************************************************************************************
 // *********************************************************************************************************************
 
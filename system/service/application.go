@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/pkg/filter"
 	"github.com/cortezaproject/corteza-server/pkg/label"
 	"github.com/cortezaproject/corteza-server/store"
@@ -115,6 +116,8 @@ func (svc *application) Search(ctx context.Context, af types.ApplicationFilter)
 func (svc *application) Create(ctx context.Context, new *types.Application) (app *types.Application, err error) {
 	var (
 		aaProps = &applicationActionProps{new: new}
+
+		deferred eventbus.Deferred
 	)
 
 	err = func() (err error) {
@@ -134,17 +137,25 @@ func (svc *application) Create(ctx context.Context, new *types.Application) (app
 			new.Unify = &types.ApplicationUnify{}
 		}
 
-		if err = store.CreateApplication(ctx, svc.store, new); err != nil {
-			return
-		}
+		err = store.Tx(ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+			if err = store.CreateApplication(ctx, s, new); err != nil {
+				return
+			}
+
+			if err = label.Create(ctx, s, new); err != nil {
+				return
+			}
+
+			return deferred.Enqueue(ctx, s, event.ApplicationAfterCreate(new, nil))
+		})
 
-		if err = label.Create(ctx, svc.store, new); err != nil {
+		if err != nil {
 			return
 		}
 
-		app = new
+		_ = deferred.WaitFor(ctx, svc.eventbus)
 
-		_ = svc.eventbus.WaitFor(ctx, event.ApplicationAfterCreate(new, nil))
+		app = new
 		return nil
 	}()
 
@@ -154,6 +165,8 @@ func (svc *application) Create(ctx context.Context, new *types.Application) (app
 func (svc *application) Update(ctx context.Context, upd *types.Application) (app *types.Application, err error) {
 	var (
 		aaProps = &applicationActionProps{update: upd}
+
+		deferred eventbus.Deferred
 	)
 
 	err = func() (err error) {
@@ -184,18 +197,26 @@ func (svc *application) Update(ctx context.Context, upd *types.Application) (app
 			app.Unify = upd.Unify
 		}
 
-		if err = store.UpdateApplication(ctx, svc.store, app); err != nil {
-			return err
-		}
+		err = store.Tx(ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+			if err = store.UpdateApplication(ctx, s, app); err != nil {
+				return err
+			}
 
-		if label.Changed(app.Labels, upd.Labels) {
-			if err = label.Update(ctx, svc.store, upd); err != nil {
-				return
+			if label.Changed(app.Labels, upd.Labels) {
+				if err = label.Update(ctx, s, upd); err != nil {
+					return
+				}
+				app.Labels = upd.Labels
 			}
-			app.Labels = upd.Labels
+
+			return deferred.Enqueue(ctx, s, event.ApplicationAfterUpdate(upd, app))
+		})
+
+		if err != nil {
+			return
 		}
 
-		_ = svc.eventbus.WaitFor(ctx, event.ApplicationAfterUpdate(upd, app))
+		_ = deferred.WaitFor(ctx, svc.eventbus)
 		return nil
 	}()
 
@@ -206,6 +227,8 @@ func (svc *application) Delete(ctx context.Context, ID uint64) (err error) {
 	var (
 		aaProps = &applicationActionProps{}
 		app     *types.Application
+
+		deferred eventbus.Deferred
 	)
 
 	err = func() (err error) {
@@ -228,11 +251,19 @@ func (svc *application) Delete(ctx context.Context, ID uint64) (err error) {
 		}
 
 		app.DeletedAt = now()
-		if err = store.UpdateApplication(ctx, svc.store, app); err != nil {
+		err = store.Tx(ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+			if err = store.UpdateApplication(ctx, s, app); err != nil {
+				return
+			}
+
+			return deferred.Enqueue(ctx, s, event.ApplicationAfterDelete(nil, app))
+		})
+
+		if err != nil {
 			return
 		}
 
-		_ = svc.eventbus.WaitFor(ctx, event.ApplicationAfterDelete(nil, app))
+		_ = deferred.WaitFor(ctx, svc.eventbus)
 		return nil
 	}()
 
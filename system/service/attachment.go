@@ -14,6 +14,7 @@ import (
 	"image"
 	"image/gif"
 	"io"
+	"mime"
 	"net/http"
 	"path"
 	"strings"
@@ -43,6 +44,7 @@ type (
 		FindByID(ID uint64) (*types.Attachment, error)
 		Find(filter types.AttachmentFilter) (types.AttachmentSet, types.AttachmentFilter, error)
 		CreateSettingsAttachment(name string, size int64, fh io.ReadSeeker, labels map[string]string) (*types.Attachment, error)
+		CreateMailAttachment(part *types.MailMessagePart, labels map[string]string) (*types.Attachment, error)
 		OpenOriginal(att *types.Attachment) (io.ReadSeeker, error)
 		OpenPreview(att *types.Attachment) (io.ReadSeeker, error)
 		DeleteByID(ID uint64) error
@@ -178,6 +180,44 @@ func (svc attachment) CreateSettingsAttachment(name string, size int64, fh io.Re
 	return att, svc.recordAction(svc.ctx, aaProps, AttachmentActionCreate, err)
 }
 
+// CreateMailAttachment stores part (attachment, inline image) of the received mail message
+//
+// Parts without a filename are named after their content ID or type.
+// Messages are received by the sink; attachment is owned by the invoker
+// when there is one
+func (svc attachment) CreateMailAttachment(part *types.MailMessagePart, labels map[string]string) (att *types.Attachment, err error) {
+	var (
+		aaProps       = &attachmentActionProps{}
+		currentUserID = intAuth.GetIdentityFromContext(svc.ctx).Identity()
+	)
+
+	err = func() (err error) {
+		name := strings.TrimSpace(part.Filename)
+		if name == "" {
+			name = part.ContentID
+			if ee, _ := mime.ExtensionsByType(part.ContentType); len(ee) > 0 {
+				name += ee[0]
+			}
+		}
+
+		att = &types.Attachment{
+			OwnerID: currentUserID,
+			Name:    name,
+			Kind:    types.AttachmentKindMail,
+		}
+
+		aaProps.setAttachment(att)
+
+		if labels != nil {
+			att.Meta.Labels = labels
+		}
+
+		return svc.create(name, part.Size(), part.Open(), att)
+	}()
+
+	return att, svc.recordAction(svc.ctx, aaProps, AttachmentActionCreate, err)
+}
+
 func (svc attachment) create(name string, size int64, fh io.ReadSeeker, att *types.Attachment) (err error) {
 	var (
 		aaProps = &attachmentActionProps{}
@@ -8,6 +8,7 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/errors"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/pkg/handle"
+	"github.com/cortezaproject/corteza-server/pkg/passwd"
 	"github.com/cortezaproject/corteza-server/pkg/rand"
 	"github.com/cortezaproject/corteza-server/pkg/rbac"
 	"github.com/cortezaproject/corteza-server/store"
@@ -31,6 +32,9 @@ type (
 		settings      *types.AppSettings
 		notifications AuthNotificationService
 
+		// list of breached passwords, used by the password policy
+		breached *passwd.BreachedList
+
 		providerValidator func(string) error
 	}
 
@@ -69,6 +73,7 @@ func Auth() *auth {
 		subscription:  CurrentSubscription,
 		settings:      CurrentSettings,
 		notifications: DefaultAuthNotification,
+		breached:      BreachedPasswords,
 
 		actionlog: DefaultActionlog,
 		store:     DefaultStore,
@@ -332,6 +337,10 @@ func (svc auth) InternalSignUp(ctx context.Context, input *types.User, password
 		//
 		// return nil,nil
 
+		if err = svc.checkPasswordPolicy(ctx, 0, password, aam); err != nil {
+			return err
+		}
+
 		if err = svc.CanRegister(ctx); err != nil {
 			return err
 		}
@@ -400,6 +409,9 @@ func (svc auth) InternalSignUp(ctx context.Context, input *types.User, password
 // InternalLogin verifies username/password combination in the internal credentials table
 //
 // Expects plain text password as an input
+//
+// Failed attempts are tracked per user and per IP address; after repeated failures
+// logins are delayed and eventually user's account is locked (see lockout settings)
 func (svc auth) InternalLogin(ctx context.Context, email string, password string) (u *types.User, err error) {
 	var (
 		authProvider = &types.AuthProvider{Provider: credentialsTypePassword}
@@ -412,49 +424,134 @@ func (svc auth) InternalLogin(ctx context.Context, email string, password string
 	)
 
 	err = func() error {
-		if !svc.settings.Auth.Internal.Enabled {
-			return AuthErrInteralLoginDisabledByConfig()
+		var c *types.Credentials
+		if u, c, err = svc.verifyPassword(ctx, email, password, aam); err != nil {
+			return err
 		}
 
-		if !reEmail.MatchString(email) {
-			return AuthErrInvalidEmailFormat()
-		}
+		// Update audit meta with found user
+		ctx = internalAuth.SetIdentityToContext(ctx, u)
 
-		if len(password) == 0 {
-			return AuthErrInvalidCredentials()
+		if svc.passwordExpired(c) {
+			return AuthErrPasswordExpired(aam)
 		}
 
-		var (
-			cc types.CredentialsSet
-		)
+		return svc.procLogin(ctx, svc.store, u, c, authProvider)
+	}()
 
-		u, err = store.LookupUserByEmail(ctx, svc.store, email)
-		if errors.IsNotFound(err) {
-			return AuthErrInvalidCredentials(aam)
-		} else if err != nil {
+	return u, svc.recordAction(ctx, aam, AuthActionAuthenticate, err)
+}
+
+// ChangeExpiredPassword verifies username/password combination, sets new password and logs user in
+//
+// Used when InternalLogin fails because the password expired
+func (svc auth) ChangeExpiredPassword(ctx context.Context, email, oldPassword, newPassword string) (u *types.User, err error) {
+	var (
+		authProvider = &types.AuthProvider{Provider: credentialsTypePassword}
+
+		aam = &authActionProps{
+			email:       email,
+			credentials: &types.Credentials{Kind: credentialsTypePassword},
+			user:        u,
+		}
+	)
+
+	err = func() error {
+		if u, _, err = svc.verifyPassword(ctx, email, oldPassword, aam); err != nil {
 			return err
 		}
 
-		// Update audit meta with found user
 		ctx = internalAuth.SetIdentityToContext(ctx, u)
 
-		cc, _, err = store.SearchCredentials(ctx, svc.store, types.CredentialsFilter{OwnerID: u.ID, Kind: credentialsTypePassword})
-		if err != nil {
+		if err = svc.checkPasswordPolicy(ctx, u.ID, newPassword, aam); err != nil {
 			return err
 		}
 
-		c := cc.CompareHashAndPassword(password)
-		if c == nil {
-			return AuthErrInvalidCredentials(aam)
+		if err = svc.SetPasswordCredentials(ctx, u.ID, newPassword); err != nil {
+			return err
 		}
 
-		aam.setCredentials(c)
-		return svc.procLogin(ctx, svc.store, u, c, authProvider)
+		_ = svc.recordAction(ctx, aam, AuthActionChangePassword, nil)
+
+		return svc.procLogin(ctx, svc.store, u, nil, authProvider)
 	}()
 
 	return u, svc.recordAction(ctx, aam, AuthActionAuthenticate, err)
 }
 
+// verifyPassword finds user by email and checks the password
+//
+// It refuses to check the password when user or client's IP address is locked
+// or delayed due to previous failures; each failure is recorded
+func (svc auth) verifyPassword(ctx context.Context, email, password string, aam *authActionProps) (u *types.User, c *types.Credentials, err error) {
+	if !svc.settings.Auth.Internal.Enabled {
+		return nil, nil, AuthErrInteralLoginDisabledByConfig()
+	}
+
+	if !reEmail.MatchString(email) {
+		return nil, nil, AuthErrInvalidEmailFormat()
+	}
+
+	if len(password) == 0 {
+		return nil, nil, AuthErrInvalidCredentials()
+	}
+
+	var (
+		cc types.CredentialsSet
+
+		ipKey   = failedLoginIPKey(ctx)
+		userKey string
+
+		// records failure and returns invalid-credentials error
+		failed = func() error {
+			if err := svc.recordFailedLogin(ctx, ipKey); err != nil {
+				return err
+			}
+
+			if err := svc.recordFailedLogin(ctx, userKey); err != nil {
+				return err
+			}
+
+			return AuthErrInvalidCredentials(aam)
+		}
+	)
+
+	if err = svc.checkFailedLogins(ctx, ipKey, aam); err != nil {
+		return nil, nil, err
+	}
+
+	u, err = store.LookupUserByEmail(ctx, svc.store, email)
+	if errors.IsNotFound(err) {
+		return nil, nil, failed()
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	aam.setUser(u)
+	userKey = failedLoginUserKey(u.ID)
+
+	if err = svc.checkFailedLogins(ctx, userKey, aam); err != nil {
+		return nil, nil, err
+	}
+
+	cc, _, err = store.SearchCredentials(ctx, svc.store, types.CredentialsFilter{OwnerID: u.ID, Kind: credentialsTypePassword})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c = cc.CompareHashAndPassword(password); c == nil {
+		return nil, nil, failed()
+	}
+
+	aam.setCredentials(c)
+
+	if err = svc.resetFailedLogins(ctx, ipKey, userKey); err != nil {
+		return nil, nil, err
+	}
+
+	return u, c, nil
+}
+
 // checkPassword returns true if given (encrypted) password matches any of the credentials
 func (svc auth) checkPassword(password string, cc types.CredentialsSet) bool {
 	return cc.CompareHashAndPassword(password) != nil
@@ -478,15 +575,15 @@ func (svc auth) SetPassword(ctx context.Context, userID uint64, password string)
 			return AuthErrInteralLoginDisabledByConfig(aam)
 		}
 
-		if !svc.CheckPasswordStrength(password) {
-			return AuthErrPasswordNotSecure(aam)
-		}
-
 		u, err = store.LookupUserByID(ctx, svc.store, userID)
 		if errors.IsNotFound(err) {
 			return AuthErrPasswordChangeFailedForUnknownUser(aam)
 		}
 
+		if err = svc.checkPasswordPolicy(ctx, userID, password, aam); err != nil {
+			return err
+		}
+
 		if err != svc.SetPasswordCredentials(ctx, userID, password) {
 			return err
 		}
@@ -541,10 +638,6 @@ func (svc auth) ChangePassword(ctx context.Context, userID uint64, oldPassword,
 			return AuthErrPasswordNotSecure(aam)
 		}
 
-		if !svc.CheckPasswordStrength(AuthActionPassword) {
-			return AuthErrPasswordNotSecure(aam)
-		}
-
 		u, err = store.LookupUserByID(ctx, svc.store, userID)
 		if errors.IsNotFound(err) {
 			return AuthErrPasswordChangeFailedForUnknownUser(aam)
@@ -559,6 +652,10 @@ func (svc auth) ChangePassword(ctx context.Context, userID uint64, oldPassword,
 			return AuthErrPasswodResetFailedOldPasswordCheckFailed(aam)
 		}
 
+		if err = svc.checkPasswordPolicy(ctx, userID, AuthActionPassword, aam); err != nil {
+			return err
+		}
+
 		if err != svc.SetPasswordCredentials(ctx, userID, AuthActionPassword) {
 			return err
 		}
@@ -720,6 +817,11 @@ func (svc auth) ExchangePasswordResetToken(ctx context.Context, token string) (u
 		aam.setUser(u)
 		ctx = internalAuth.SetIdentityToContext(ctx, u)
 
+		// user proved ownership of the email address, lift the lock
+		if err = svc.Unlock(ctx, u.ID); err != nil {
+			return err
+		}
+
 		t, err = svc.createUserToken(ctx, u, credentialsTypeResetPasswordTokenExchanged)
 		if err != nil {
 			u = nil
@@ -735,7 +837,7 @@ func (svc auth) ExchangePasswordResetToken(ctx context.Context, token string) (u
 
 func (svc auth) sendEmailAddressConfirmationToken(ctx context.Context, u *types.User) (err error) {
 	var (
-		notificationLang = "en"
+		notificationLang = u.PreferredLanguage()
 		token            string
 
 		aam = &authActionProps{
@@ -806,7 +908,7 @@ func (svc auth) CanRegister(ctx context.Context) error {
 
 func (svc auth) sendPasswordResetToken(ctx context.Context, u *types.User) (err error) {
 	var (
-		notificationLang = "en"
+		notificationLang = u.PreferredLanguage()
 	)
 
 	token, err := svc.createUserToken(ctx, u, credentialsTypeResetPasswordToken)
@@ -985,11 +1087,5 @@ func (svc auth) autoPromote(ctx context.Context, u *types.User) (err error) {
 //
 // @todo move this to role service
 func (svc auth) LoadRoleMemberships(ctx context.Context, u *types.User) error {
-	rr, _, err := store.SearchRoles(ctx, svc.store, types.RoleFilter{MemberID: u.ID})
-	if err != nil {
-		return err
-	}
-
-	u.SetRoles(rr.IDs())
-	return nil
+	return loadMemberships(ctx, svc.store, u)
 }
@@ -20,11 +20,12 @@ import (
 
 type (
 	authActionProps struct {
-		email       string
-		provider    string
-		credentials *types.Credentials
-		role        *types.Role
-		user        *types.User
+		email        string
+		provider     string
+		credentials  *types.Credentials
+		role         *types.Role
+		user         *types.User
+		requirements string
 	}
 
 	authAction struct {
@@ -107,6 +108,17 @@ func (p *authActionProps) setUser(user *types.User) *authActionProps {
 	return p
 }
 
+// setRequirements updates authActionProps's requirements
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *authActionProps) setRequirements(requirements string) *authActionProps {
+	p.requirements = requirements
+	return p
+}
+
 // Serialize converts authActionProps to actionlog.Meta
 //
 // This function is auto-generated.
@@ -136,6 +148,7 @@ func (p authActionProps) Serialize() actionlog.Meta {
 		m.Set("user.suspendedAt", p.user.SuspendedAt, true)
 		m.Set("user.deletedAt", p.user.DeletedAt, true)
 	}
+	m.Set("requirements", p.requirements, true)
 
 	return m
 }
@@ -220,6 +233,7 @@ func (p authActionProps) Format(in string, err error) string {
 		pairs = append(pairs, "{user.suspendedAt}", fns(p.user.SuspendedAt))
 		pairs = append(pairs, "{user.deletedAt}", fns(p.user.DeletedAt))
 	}
+	pairs = append(pairs, "{requirements}", fns(p.requirements))
 	return strings.NewReplacer(pairs...).Replace(in)
 }
 
@@ -1013,6 +1027,192 @@ func AuthErrPasswordNotSecure(mm ...*authActionProps) *errors.Error {
 	return e
 }
 
+// AuthErrPasswordPolicyNotMet returns "system:auth.passwordPolicyNotMet" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func AuthErrPasswordPolicyNotMet(mm ...*authActionProps) *errors.Error {
+	var p = &authActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("password does not meet the requirements; it must contain {requirements}", nil),
+
+		errors.Meta("type", "passwordPolicyNotMet"),
+		errors.Meta("resource", "system:auth"),
+
+		errors.Meta(authPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// AuthErrPasswordReused returns "system:auth.passwordReused" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func AuthErrPasswordReused(mm ...*authActionProps) *errors.Error {
+	var p = &authActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("password was used recently; choose a different password", nil),
+
+		errors.Meta("type", "passwordReused"),
+		errors.Meta("resource", "system:auth"),
+
+		errors.Meta(authPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// AuthErrPasswordBreached returns "system:auth.passwordBreached" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func AuthErrPasswordBreached(mm ...*authActionProps) *errors.Error {
+	var p = &authActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("password was found in a list of breached passwords; choose a different password", nil),
+
+		errors.Meta("type", "passwordBreached"),
+		errors.Meta("resource", "system:auth"),
+
+		errors.Meta(authPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// AuthErrPasswordExpired returns "system:auth.passwordExpired" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func AuthErrPasswordExpired(mm ...*authActionProps) *errors.Error {
+	var p = &authActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("password expired; change your password to continue", nil),
+
+		errors.Meta("type", "passwordExpired"),
+		errors.Meta("resource", "system:auth"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(authLogMetaKey{}, "{email} failed to authenticate with expired password"),
+		errors.Meta(authPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// AuthErrTooManyFailedAttempts returns "system:auth.tooManyFailedAttempts" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func AuthErrTooManyFailedAttempts(mm ...*authActionProps) *errors.Error {
+	var p = &authActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("too many failed login attempts; try again later", nil),
+
+		errors.Meta("type", "tooManyFailedAttempts"),
+		errors.Meta("resource", "system:auth"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(authLogMetaKey{}, "{email} failed to authenticate; too many failed attempts"),
+		errors.Meta(authPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// AuthErrAccountLocked returns "system:auth.accountLocked" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func AuthErrAccountLocked(mm ...*authActionProps) *errors.Error {
+	var p = &authActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("account is locked due to too many failed login attempts", nil),
+
+		errors.Meta("type", "accountLocked"),
+		errors.Meta("resource", "system:auth"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(authLogMetaKey{}, "{email} failed to authenticate; account is locked"),
+		errors.Meta(authPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
 // AuthErrExternalDisabledByConfig returns "system:auth.externalDisabledByConfig" as *errors.Error
 //
 //
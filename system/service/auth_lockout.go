@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/api"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/pkg/passwd"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/system/types"
+)
+
+const (
+	failedLoginKeyUser = "user:"
+	failedLoginKeyIP   = "ip:"
+
+	// consecutive failures are forgotten after a day without failed attempts
+	failedLoginWindow = time.Hour * 24
+
+	// delay after the first failure over the threshold, doubled on each next failure
+	failedLoginDelayBase = time.Second
+	failedLoginDelayMax  = time.Minute * 15
+
+	// how many times failure is re-counted on concurrent attempts
+	failedLoginRetries = 5
+)
+
+// loginDelay returns how long client has to wait after
+// the last of the consecutive failures before next login attempt
+//
+// Delay grows exponentially once the number of failures reaches the threshold;
+// zero threshold disables delays
+func loginDelay(failures, after uint) time.Duration {
+	if after == 0 || failures < after {
+		return 0
+	}
+
+	var (
+		d = failedLoginDelayBase
+	)
+
+	for i := after; i < failures && d < failedLoginDelayMax; i++ {
+		d *= 2
+	}
+
+	if d > failedLoginDelayMax {
+		return failedLoginDelayMax
+	}
+
+	return d
+}
+
+func failedLoginUserKey(userID uint64) string {
+	return failedLoginKeyUser + strconv.FormatUint(userID, 10)
+}
+
+// failedLoginIPKey returns key for remote address from context (without the port)
+//
+// Empty string is returned when there is no remote address
+func failedLoginIPKey(ctx context.Context) string {
+	addr := api.RemoteAddrFromContext(ctx)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+
+	if addr == "" {
+		return ""
+	}
+
+	return failedLoginKeyIP + addr
+}
+
+func (svc auth) lockoutDuration() time.Duration {
+	return time.Duration(svc.settings.Auth.Internal.Lockout.Duration) * time.Minute
+}
+
+// failedLoginExpired returns true if tracked failures should be ignored;
+// because there were no recent failures or because the lock expired
+func (svc auth) failedLoginExpired(fl *types.FailedLogin, now time.Time) bool {
+	if fl.LockedAt != nil {
+		return !fl.Locked(svc.lockoutDuration(), now)
+	}
+
+	return fl.LastFailedAt.Add(failedLoginWindow).Before(now)
+}
+
+// checkFailedLogins returns an error when login for the given key is locked
+// or when the client did not wait long enough after the last failure
+func (svc auth) checkFailedLogins(ctx context.Context, key string, aam *authActionProps) error {
+	if key == "" {
+		return nil
+	}
+
+	fl, err := store.LookupFailedLoginByKey(ctx, svc.store, key)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	n := *now()
+	if svc.failedLoginExpired(fl, n) {
+		return nil
+	}
+
+	if fl.LockedAt != nil {
+		return AuthErrAccountLocked(aam)
+	}
+
+	if fl.LastFailedAt.Add(loginDelay(fl.Failures, svc.settings.Auth.Internal.Lockout.DelayAfter)).After(n) {
+		return AuthErrTooManyFailedAttempts(aam)
+	}
+
+	return nil
+}
+
+// recordFailedLogin increases number of consecutive failures for the given key
+//
+// Only users can be locked; IP addresses are just delayed
+//
+// Failures are counted with compare-and-swap so that concurrent attempts
+// are all counted; update is retried when failures were modified in the meantime
+func (svc auth) recordFailedLogin(ctx context.Context, key string) (err error) {
+	if key == "" {
+		return nil
+	}
+
+	var recorded bool
+	for i := 0; i < failedLoginRetries && !recorded; i++ {
+		if recorded, err = svc.tryRecordFailedLogin(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	if !recorded {
+		return fmt.Errorf("could not record failed login attempt for %q", key)
+	}
+
+	return nil
+}
+
+func (svc auth) tryRecordFailedLogin(ctx context.Context, key string) (bool, error) {
+	var (
+		n           = *now()
+		maxAttempts = svc.settings.Auth.Internal.Lockout.MaxAttempts
+		exists      = true
+	)
+
+	fl, err := store.LookupFailedLoginByKey(ctx, svc.store, key)
+	if errors.IsNotFound(err) {
+		fl, exists = &types.FailedLogin{Key: key}, false
+	} else if err != nil {
+		return false, err
+	}
+
+	failures := fl.Failures
+	if exists && svc.failedLoginExpired(fl, n) {
+		fl.Failures = 0
+		fl.LockedAt = nil
+	}
+
+	fl.Failures++
+	fl.LastFailedAt = n
+
+	if strings.HasPrefix(key, failedLoginKeyUser) && maxAttempts > 0 && fl.Failures >= maxAttempts && fl.LockedAt == nil {
+		fl.LockedAt = &n
+	}
+
+	if exists {
+		return store.CompareAndSwapFailedLogin(ctx, svc.store, fl, failures)
+	}
+
+	// duplicate means failed login was created by a concurrent attempt
+	if err = store.CreateFailedLogin(ctx, svc.store, fl); errors.IsDuplicateData(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// resetFailedLogins removes tracked failures after successful login
+func (svc auth) resetFailedLogins(ctx context.Context, kk ...string) error {
+	for _, key := range kk {
+		if key == "" {
+			continue
+		}
+
+		if err := store.DeleteFailedLoginByKey(ctx, svc.store, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Unlock removes lock and all tracked failed login attempts of a user
+func (svc auth) Unlock(ctx context.Context, userID uint64) error {
+	return svc.resetFailedLogins(ctx, failedLoginUserKey(userID))
+}
+
+// passwordExpired returns true if password credentials are older than allowed by the password policy
+func (svc auth) passwordExpired(c *types.Credentials) bool {
+	maxAge := svc.settings.Auth.Internal.PasswordPolicy.MaxAge
+	if maxAge == 0 || c == nil {
+		return false
+	}
+
+	return c.CreatedAt.Add(time.Duration(maxAge) * time.Hour * 24).Before(*now())
+}
+
+// CheckPasswordPolicy checks new password against the password policy from settings
+//
+// Previous passwords are checked only for existing users (userID > 0)
+func (svc auth) CheckPasswordPolicy(ctx context.Context, userID uint64, password string) error {
+	return svc.checkPasswordPolicy(ctx, userID, password, &authActionProps{})
+}
+
+func (svc auth) checkPasswordPolicy(ctx context.Context, userID uint64, password string, aam *authActionProps) error {
+	var (
+		pp = svc.settings.Auth.Internal.PasswordPolicy
+
+		policy = passwd.Policy{
+			MinLength:      pp.MinLength,
+			RequireUpper:   pp.RequireUpper,
+			RequireLower:   pp.RequireLower,
+			RequireDigit:   pp.RequireDigit,
+			RequireSpecial: pp.RequireSpecial,
+		}
+	)
+
+	if !svc.CheckPasswordStrength(password) {
+		return AuthErrPasswordNotSecure(aam)
+	}
+
+	if unmet := policy.Check(password); len(unmet) > 0 {
+		return AuthErrPasswordPolicyNotMet(aam.setRequirements(passwd.Describe(unmet)))
+	}
+
+	if pp.CheckBreached && svc.breached.Contains(password) {
+		return AuthErrPasswordBreached(aam)
+	}
+
+	if pp.History > 0 && userID > 0 {
+		f := types.CredentialsFilter{OwnerID: userID, Kind: credentialsTypePassword}
+		f.Deleted = filter.StateInclusive
+
+		cc, _, err := store.SearchCredentials(ctx, svc.store, f)
+		if err != nil {
+			return err
+		}
+
+		if cc.CompareHashAndPasswordHistory(password, pp.History) {
+			return AuthErrPasswordReused(aam)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/pkg/passwd"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/system/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_loginDelay(t *testing.T) {
+	var (
+		req = require.New(t)
+	)
+
+	req.Zero(loginDelay(10, 0))
+	req.Zero(loginDelay(2, 3))
+	req.Equal(time.Second, loginDelay(3, 3))
+	req.Equal(time.Second*2, loginDelay(4, 3))
+	req.Equal(time.Second*8, loginDelay(6, 3))
+	req.Equal(failedLoginDelayMax, loginDelay(30, 3))
+	req.Equal(failedLoginDelayMax, loginDelay(1000, 3))
+}
+
+func TestAuth_InternalLoginLockout(t *testing.T) {
+	var (
+		req = require.New(t)
+		ctx = context.Background()
+
+		validPass = "this is a valid password !! 42"
+		validUser = &types.User{Email: "valid@test.cortezaproject.org", ID: nextID(), CreatedAt: *now(), EmailConfirmed: true}
+
+		svc = makeMockAuthService()
+
+		login = func(password string) error {
+			_, err := svc.InternalLogin(ctx, validUser.Email, password)
+			return err
+		}
+
+		// moves last failure back in time so that delay does not apply
+		rewind = func() {
+			fl, err := store.LookupFailedLoginByKey(ctx, svc.store, failedLoginUserKey(validUser.ID))
+			req.NoError(err)
+			fl.LastFailedAt = fl.LastFailedAt.Add(-time.Hour)
+			req.NoError(store.UpdateFailedLogin(ctx, svc.store, fl))
+		}
+	)
+
+	svc.settings.Auth.Internal.Enabled = true
+	svc.settings.Auth.Internal.Lockout.DelayAfter = 2
+	svc.settings.Auth.Internal.Lockout.MaxAttempts = 3
+
+	req.NoError(svc.store.TruncateUsers(ctx))
+	req.NoError(svc.store.TruncateCredentials(ctx))
+	req.NoError(svc.store.TruncateFailedLogins(ctx))
+	req.NoError(store.CreateUser(ctx, svc.store, validUser))
+	req.NoError(svc.SetPasswordCredentials(ctx, validUser.ID, validPass))
+
+	req.EqualError(login("invalid password"), "invalid username and password combination")
+	req.EqualError(login("invalid password"), "invalid username and password combination")
+
+	// delayed after the 2nd failure, even with valid password
+	req.EqualError(login(validPass), "too many failed login attempts; try again later")
+
+	rewind()
+	req.EqualError(login("invalid password"), "invalid username and password combination")
+
+	// locked after the 3rd failure
+	rewind()
+	req.EqualError(login(validPass), "account is locked due to too many failed login attempts")
+
+	req.NoError(svc.Unlock(ctx, validUser.ID))
+	req.NoError(login(validPass))
+
+	// successful login resets the counter
+	_, err := store.LookupFailedLoginByKey(ctx, svc.store, failedLoginUserKey(validUser.ID))
+	req.True(errors.IsNotFound(err))
+}
+
+func TestAuth_InternalLoginExpiredPassword(t *testing.T) {
+	var (
+		req = require.New(t)
+		ctx = context.Background()
+
+		oldPass   = "this is a valid password !! 42"
+		newPass   = "this is a new valid password !! 42"
+		validUser = &types.User{Email: "valid@test.cortezaproject.org", ID: nextID(), CreatedAt: *now(), EmailConfirmed: true}
+
+		svc = makeMockAuthService()
+	)
+
+	svc.settings.Auth.Internal.Enabled = true
+	svc.settings.Auth.Internal.PasswordPolicy.MaxAge = 30
+
+	req.NoError(svc.store.TruncateUsers(ctx))
+	req.NoError(svc.store.TruncateCredentials(ctx))
+	req.NoError(store.CreateUser(ctx, svc.store, validUser))
+	req.NoError(svc.SetPasswordCredentials(ctx, validUser.ID, oldPass))
+
+	cc, _, err := store.SearchCredentials(ctx, svc.store, types.CredentialsFilter{OwnerID: validUser.ID})
+	req.NoError(err)
+	req.Len(cc, 1)
+	cc[0].CreatedAt = cc[0].CreatedAt.Add(-time.Hour * 24 * 31)
+	req.NoError(store.UpdateCredentials(ctx, svc.store, cc[0]))
+
+	_, err = svc.InternalLogin(ctx, validUser.Email, oldPass)
+	req.EqualError(err, "password expired; change your password to continue")
+
+	_, err = svc.ChangeExpiredPassword(ctx, validUser.Email, oldPass, oldPass+"?")
+	req.NoError(err)
+
+	_, err = svc.InternalLogin(ctx, validUser.Email, oldPass+"?")
+	req.NoError(err)
+
+	_, err = svc.ChangeExpiredPassword(ctx, validUser.Email, oldPass, newPass)
+	req.EqualError(err, "invalid username and password combination")
+}
+
+func TestAuth_checkPasswordPolicy(t *testing.T) {
+	var (
+		req = require.New(t)
+		ctx = context.Background()
+
+		userID = nextID()
+		svc    = makeMockAuthService()
+		pp     = &svc.settings.Auth.Internal.PasswordPolicy
+
+		err error
+	)
+
+	pp.MinLength = 10
+	pp.RequireDigit = true
+	pp.CheckBreached = true
+	pp.History = 2
+
+	svc.breached, err = passwd.ReadBreachedList(strings.NewReader("password1234\n"))
+	req.NoError(err)
+
+	req.NoError(svc.store.TruncateCredentials(ctx))
+	req.NoError(svc.SetPasswordCredentials(ctx, userID, "previous password 1"))
+	req.NoError(svc.SetPasswordCredentials(ctx, userID, "previous password 2"))
+	req.NoError(svc.SetPasswordCredentials(ctx, userID, "previous password 3"))
+
+	req.EqualError(svc.CheckPasswordPolicy(ctx, userID, "1234"), "provided password is not secure; use longer password with more non-alphanumeric character")
+	req.EqualError(svc.CheckPasswordPolicy(ctx, userID, "short-42"), "password does not meet the requirements; it must contain at least 10 characters")
+	req.EqualError(svc.CheckPasswordPolicy(ctx, userID, "no-digits-here"), "password does not meet the requirements; it must contain a digit")
+	req.EqualError(svc.CheckPasswordPolicy(ctx, userID, "password1234"), "password was found in a list of breached passwords; choose a different password")
+	req.EqualError(svc.CheckPasswordPolicy(ctx, userID, "previous password 3"), "password was used recently; choose a different password")
+	req.EqualError(svc.CheckPasswordPolicy(ctx, userID, "previous password 2"), "password was used recently; choose a different password")
+
+	// only the last 2 passwords are kept in history
+	req.NoError(svc.CheckPasswordPolicy(ctx, userID, "previous password 1"))
+
+	// history is not checked for new users
+	req.NoError(svc.CheckPasswordPolicy(ctx, 0, "previous password 3"))
+}
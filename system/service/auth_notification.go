@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	htmlTemplate "html/template"
+
+	"github.com/cortezaproject/corteza-server/pkg/errors"
 	"github.com/cortezaproject/corteza-server/system/types"
-	"html/template"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -17,8 +19,13 @@ import (
 
 type (
 	authNotification struct {
-		logger   *zap.Logger
-		settings *types.AppSettings
+		logger    *zap.Logger
+		settings  *types.AppSettings
+		templates authNotificationRenderer
+	}
+
+	authNotificationRenderer interface {
+		render(ctx context.Context, handle, lang string, vars interface{}) (*types.RenderedTemplate, error)
 	}
 
 	AuthNotificationService interface {
@@ -30,18 +37,28 @@ type (
 		EmailAddress   string
 		URL            string
 		BaseURL        string
-		Logo           template.URL
+		Logo           htmlTemplate.URL
 		SignatureName  string
 		SignatureEmail string
-		EmailHeaderEn  template.HTML
-		EmailFooterEn  template.HTML
+		EmailHeaderEn  htmlTemplate.HTML
+		EmailFooterEn  htmlTemplate.HTML
 	}
 )
 
-func AuthNotification(s *types.AppSettings) AuthNotificationService {
+const (
+	// Handles of the stored templates used for auth notifications
+	//
+	// Templates configured in settings are used when
+	// there is no stored template with the handle
+	AuthTemplateEmailConfirmation = "auth_email_confirmation"
+	AuthTemplatePasswordReset     = "auth_password_reset"
+)
+
+func AuthNotification(s *types.AppSettings, t authNotificationRenderer) AuthNotificationService {
 	return &authNotification{
-		logger:   DefaultLogger.Named("auth-notification"),
-		settings: s,
+		logger:    DefaultLogger.Named("auth-notification"),
+		settings:  s,
+		templates: t,
 	}
 }
 
@@ -50,14 +67,14 @@ func (svc authNotification) log(ctx context.Context, fields ...zapcore.Field) *z
 }
 
 func (svc authNotification) EmailConfirmation(ctx context.Context, lang string, emailAddress string, token string) error {
-	return svc.send(ctx, "email-confirmation", lang, authNotificationPayload{
+	return svc.send(ctx, AuthTemplateEmailConfirmation, lang, authNotificationPayload{
 		EmailAddress: emailAddress,
 		URL:          svc.settings.Auth.Frontend.Url.EmailConfirmation + token,
 	})
 }
 
 func (svc authNotification) PasswordReset(ctx context.Context, lang string, emailAddress string, token string) error {
-	return svc.send(ctx, "password-reset", lang, authNotificationPayload{
+	return svc.send(ctx, AuthTemplatePasswordReset, lang, authNotificationPayload{
 		EmailAddress: emailAddress,
 		URL:          svc.settings.Auth.Frontend.Url.PasswordReset + token,
 	})
@@ -79,71 +96,104 @@ func (svc authNotification) newMail() *gomail.Message {
 
 func (svc authNotification) send(ctx context.Context, name, lang string, payload authNotificationPayload) error {
 	var (
-		err error
-		tmp string
 		ntf = svc.newMail()
 	)
 
-	payload.Logo = template.URL(svc.settings.General.Mail.Logo)
+	payload.Logo = htmlTemplate.URL(svc.settings.General.Mail.Logo)
 	payload.BaseURL = svc.settings.Auth.Frontend.Url.Base
 	payload.SignatureName = svc.settings.Auth.Mail.FromName
 	payload.SignatureEmail = svc.settings.Auth.Mail.FromAddress
 
-	// @todo translations
+	out, err := svc.renderStored(ctx, name, lang, payload)
+	if errors.Is(err, TemplateErrNotFound()) {
+		out, err = svc.renderSettings(name, payload)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	ntf.SetAddressHeader("To", payload.EmailAddress, "")
+	ntf.SetHeader("Subject", out.Subject)
+	ntf.SetBody(string(out.Type), out.Content)
+
+	svc.log(ctx).Debug(
+		"sending auth notification",
+		zap.String("name", name),
+		zap.String("language", out.Language),
+		zap.String("email", payload.EmailAddress),
+	)
+
+	return mail.Send(ntf)
+}
+
+// renderStored renders notification from the stored template
+func (svc authNotification) renderStored(ctx context.Context, name, lang string, payload authNotificationPayload) (*types.RenderedTemplate, error) {
+	if svc.templates == nil {
+		return nil, TemplateErrNotFound()
+	}
+
+	out, err := svc.templates.render(ctx, name, lang, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if out.Subject == "" {
+		return nil, fmt.Errorf("template %s has no subject", name)
+	}
+
+	return out, nil
+}
+
+// renderSettings renders notification from the templates configured in settings
+func (svc authNotification) renderSettings(name string, payload authNotificationPayload) (out *types.RenderedTemplate, err error) {
+	var (
+		tmp string
+	)
+
+	out = &types.RenderedTemplate{Handle: name, Type: types.TemplateTypeHTML}
+
 	if tmp, err = svc.render(svc.settings.General.Mail.Header, payload); err != nil {
-		return fmt.Errorf("failed to render svc.settings.General.Mail.Header: %w", err)
+		return nil, fmt.Errorf("failed to render svc.settings.General.Mail.Header: %w", err)
 	}
-	payload.EmailHeaderEn = template.HTML(tmp)
+	payload.EmailHeaderEn = htmlTemplate.HTML(tmp)
 	if tmp, err = svc.render(svc.settings.General.Mail.Footer, payload); err != nil {
-		return fmt.Errorf("failed to render svc.settings.General.Mail.Footer: %w", err)
+		return nil, fmt.Errorf("failed to render svc.settings.General.Mail.Footer: %w", err)
 	}
-	payload.EmailFooterEn = template.HTML(tmp)
+	payload.EmailFooterEn = htmlTemplate.HTML(tmp)
 
-	ntf.SetAddressHeader("To", payload.EmailAddress, "")
-	// @todo translations
 	switch name {
-	case "email-confirmation":
-		if tmp, err = svc.render(svc.settings.Auth.Mail.EmailConfirmation.Subject, payload); err != nil {
-			return fmt.Errorf("failed to render svc.settings.Auth.Mail.EmailConfirmation.Subject: %w", err)
+	case AuthTemplateEmailConfirmation:
+		if out.Subject, err = svc.render(svc.settings.Auth.Mail.EmailConfirmation.Subject, payload); err != nil {
+			return nil, fmt.Errorf("failed to render svc.settings.Auth.Mail.EmailConfirmation.Subject: %w", err)
 		}
-		ntf.SetHeader("Subject", tmp)
-		if tmp, err = svc.render(svc.settings.Auth.Mail.EmailConfirmation.Body, payload); err != nil {
-			return fmt.Errorf("failed to render svc.settings.Auth.Mail.EmailConfirmation.Body: %w", err)
+		if out.Content, err = svc.render(svc.settings.Auth.Mail.EmailConfirmation.Body, payload); err != nil {
+			return nil, fmt.Errorf("failed to render svc.settings.Auth.Mail.EmailConfirmation.Body: %w", err)
 		}
-		ntf.SetBody("text/html", tmp)
 
-	case "password-reset":
-		if tmp, err = svc.render(svc.settings.Auth.Mail.PasswordReset.Subject, payload); err != nil {
-			return fmt.Errorf("failed to render svc.settings.Auth.Mail.PasswordReset.Subject: %w", err)
+	case AuthTemplatePasswordReset:
+		if out.Subject, err = svc.render(svc.settings.Auth.Mail.PasswordReset.Subject, payload); err != nil {
+			return nil, fmt.Errorf("failed to render svc.settings.Auth.Mail.PasswordReset.Subject: %w", err)
 		}
-		ntf.SetHeader("Subject", tmp)
-		if tmp, err = svc.render(svc.settings.Auth.Mail.PasswordReset.Body, payload); err != nil {
-			return fmt.Errorf("failed to render svc.settings.Auth.Mail.PasswordReset.Body: %w", err)
+		if out.Content, err = svc.render(svc.settings.Auth.Mail.PasswordReset.Body, payload); err != nil {
+			return nil, fmt.Errorf("failed to render svc.settings.Auth.Mail.PasswordReset.Body: %w", err)
 		}
-		ntf.SetBody("text/html", tmp)
 
 	default:
-		return fmt.Errorf("unknown notification email template %q", name)
+		return nil, fmt.Errorf("unknown notification email template %q", name)
 	}
 
-	svc.log(ctx).Debug(
-		"sending auth notification",
-		zap.String("name", name),
-		zap.String("language", lang),
-		zap.String("email", payload.EmailAddress),
-	)
-
-	return mail.Send(ntf)
+	return out, nil
 }
 
 func (svc authNotification) render(source string, payload interface{}) (string, error) {
 	var (
 		err error
-		tpl *template.Template
+		tpl *htmlTemplate.Template
 		buf = bytes.Buffer{}
 	)
 
-	tpl, err = template.New("").Parse(source)
+	tpl, err = htmlTemplate.New("").Parse(source)
 	if err != nil {
 		return "", fmt.Errorf("could not parse template: %w", err)
 	}
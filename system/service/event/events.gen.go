@@ -11,6 +11,7 @@ package event
 import (
 	"encoding/json"
 	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/system/types"
 )
 
@@ -490,6 +491,11 @@ func (res *systemBase) Decode(results map[string][]byte) (err error) {
 	return
 }
 
+// restore decodes encoded arguments into struct props
+func (res *systemBase) restore(args map[string][]byte) (err error) {
+	return
+}
+
 // ResourceType returns "system:application"
 //
 // This function is auto-generated.
@@ -872,6 +878,23 @@ func (res *applicationBase) Decode(results map[string][]byte) (err error) {
 	return
 }
 
+// restore decodes encoded arguments into struct props
+func (res *applicationBase) restore(args map[string][]byte) (err error) {
+	if r, ok := args["application"]; ok {
+		if err = json.Unmarshal(r, &res.application); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["oldApplication"]; ok {
+		if err = json.Unmarshal(r, &res.oldApplication); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 // ResourceType returns "system:auth"
 //
 // This function is auto-generated.
@@ -1144,6 +1167,23 @@ func (res *authBase) Decode(results map[string][]byte) (err error) {
 	return
 }
 
+// restore decodes encoded arguments into struct props
+func (res *authBase) restore(args map[string][]byte) (err error) {
+	if r, ok := args["user"]; ok {
+		if err = json.Unmarshal(r, &res.user); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["provider"]; ok {
+		if err = json.Unmarshal(r, &res.provider); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 // ResourceType returns "system:mail"
 //
 // This function is auto-generated.
@@ -1337,6 +1377,17 @@ func (res *mailBase) Decode(results map[string][]byte) (err error) {
 	return
 }
 
+// restore decodes encoded arguments into struct props
+func (res *mailBase) restore(args map[string][]byte) (err error) {
+	if r, ok := args["message"]; ok {
+		if err = json.Unmarshal(r, &res.message); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 // ResourceType returns "system:role"
 //
 // This function is auto-generated.
@@ -1719,6 +1770,23 @@ func (res *roleBase) Decode(results map[string][]byte) (err error) {
 	return
 }
 
+// restore decodes encoded arguments into struct props
+func (res *roleBase) restore(args map[string][]byte) (err error) {
+	if r, ok := args["role"]; ok {
+		if err = json.Unmarshal(r, &res.role); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["oldRole"]; ok {
+		if err = json.Unmarshal(r, &res.oldRole); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 // ResourceType returns "system:role:member"
 //
 // This function is auto-generated.
@@ -1991,6 +2059,23 @@ func (res *roleMemberBase) Decode(results map[string][]byte) (err error) {
 	return
 }
 
+// restore decodes encoded arguments into struct props
+func (res *roleMemberBase) restore(args map[string][]byte) (err error) {
+	if r, ok := args["user"]; ok {
+		if err = json.Unmarshal(r, &res.user); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["role"]; ok {
+		if err = json.Unmarshal(r, &res.role); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 // ResourceType returns "system:sink"
 //
 // This function is auto-generated.
@@ -2127,6 +2212,23 @@ func (res *sinkBase) Decode(results map[string][]byte) (err error) {
 	return
 }
 
+// restore decodes encoded arguments into struct props
+func (res *sinkBase) restore(args map[string][]byte) (err error) {
+	if r, ok := args["response"]; ok {
+		if err = json.Unmarshal(r, &res.response); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["request"]; ok {
+		if err = json.Unmarshal(r, &res.request); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
 // ResourceType returns "system:user"
 //
 // This function is auto-generated.
@@ -2508,3 +2610,168 @@ func (res *userBase) Decode(results map[string][]byte) (err error) {
 	}
 	return
 }
+
+// restore decodes encoded arguments into struct props
+func (res *userBase) restore(args map[string][]byte) (err error) {
+	if r, ok := args["user"]; ok {
+		if err = json.Unmarshal(r, &res.user); err != nil {
+			return
+		}
+	}
+
+	if r, ok := args["oldUser"]; ok {
+		if err = json.Unmarshal(r, &res.oldUser); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Restore creates event from the encoded arguments
+//
+// Restored events are immutable. Returns nil for unknown resource or event types.
+//
+// This function is auto-generated.
+func Restore(resourceType, eventType string, args map[string][]byte) (eventbus.Event, error) {
+	switch resourceType {
+	case "system":
+		res := &systemBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "onManual":
+			return &systemOnManual{systemBase: res}, nil
+		case "onInterval":
+			return &systemOnInterval{systemBase: res}, nil
+		case "onTimestamp":
+			return &systemOnTimestamp{systemBase: res}, nil
+		}
+	case "system:application":
+		res := &applicationBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "onManual":
+			return &applicationOnManual{applicationBase: res}, nil
+		case "beforeCreate":
+			return &applicationBeforeCreate{applicationBase: res}, nil
+		case "beforeUpdate":
+			return &applicationBeforeUpdate{applicationBase: res}, nil
+		case "beforeDelete":
+			return &applicationBeforeDelete{applicationBase: res}, nil
+		case "afterCreate":
+			return &applicationAfterCreate{applicationBase: res}, nil
+		case "afterUpdate":
+			return &applicationAfterUpdate{applicationBase: res}, nil
+		case "afterDelete":
+			return &applicationAfterDelete{applicationBase: res}, nil
+		}
+	case "system:auth":
+		res := &authBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "beforeLogin":
+			return &authBeforeLogin{authBase: res}, nil
+		case "beforeSignup":
+			return &authBeforeSignup{authBase: res}, nil
+		case "afterLogin":
+			return &authAfterLogin{authBase: res}, nil
+		case "afterSignup":
+			return &authAfterSignup{authBase: res}, nil
+		}
+	case "system:mail":
+		res := &mailBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "onManual":
+			return &mailOnManual{mailBase: res}, nil
+		case "onReceive":
+			return &mailOnReceive{mailBase: res}, nil
+		case "onSend":
+			return &mailOnSend{mailBase: res}, nil
+		}
+	case "system:role":
+		res := &roleBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "onManual":
+			return &roleOnManual{roleBase: res}, nil
+		case "beforeCreate":
+			return &roleBeforeCreate{roleBase: res}, nil
+		case "beforeUpdate":
+			return &roleBeforeUpdate{roleBase: res}, nil
+		case "beforeDelete":
+			return &roleBeforeDelete{roleBase: res}, nil
+		case "afterCreate":
+			return &roleAfterCreate{roleBase: res}, nil
+		case "afterUpdate":
+			return &roleAfterUpdate{roleBase: res}, nil
+		case "afterDelete":
+			return &roleAfterDelete{roleBase: res}, nil
+		}
+	case "system:role:member":
+		res := &roleMemberBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "beforeAdd":
+			return &roleMemberBeforeAdd{roleMemberBase: res}, nil
+		case "beforeRemove":
+			return &roleMemberBeforeRemove{roleMemberBase: res}, nil
+		case "afterAdd":
+			return &roleMemberAfterAdd{roleMemberBase: res}, nil
+		case "afterRemove":
+			return &roleMemberAfterRemove{roleMemberBase: res}, nil
+		}
+	case "system:sink":
+		res := &sinkBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "onRequest":
+			return &sinkOnRequest{sinkBase: res}, nil
+		}
+	case "system:user":
+		res := &userBase{immutable: true}
+		if err := res.restore(args); err != nil {
+			return nil, err
+		}
+
+		switch eventType {
+		case "onManual":
+			return &userOnManual{userBase: res}, nil
+		case "beforeCreate":
+			return &userBeforeCreate{userBase: res}, nil
+		case "beforeUpdate":
+			return &userBeforeUpdate{userBase: res}, nil
+		case "beforeDelete":
+			return &userBeforeDelete{userBase: res}, nil
+		case "afterCreate":
+			return &userAfterCreate{userBase: res}, nil
+		case "afterUpdate":
+			return &userAfterUpdate{userBase: res}, nil
+		case "afterDelete":
+			return &userAfterDelete{userBase: res}, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/store"
+)
+
+type (
+	eventOutbox struct {
+		ac        eventOutboxAccessController
+		actionlog actionlog.Recorder
+		store     store.Storer
+	}
+
+	eventOutboxAccessController interface {
+		CanManageEventOutbox(context.Context) bool
+	}
+
+	EventOutboxService interface {
+		SearchOutbox(ctx context.Context, f eventbus.QueuedEventFilter) (eventbus.QueuedEventSet, eventbus.QueuedEventFilter, error)
+		SearchDeadLetters(ctx context.Context, f eventbus.QueuedEventFilter) (eventbus.QueuedEventSet, eventbus.QueuedEventFilter, error)
+		Replay(ctx context.Context, eventID uint64) (*eventbus.QueuedEvent, error)
+		Purge(ctx context.Context, f eventbus.QueuedEventFilter) (int, error)
+	}
+)
+
+// EventOutbox manages events waiting for delivery and dead letters
+// (events that could not be delivered)
+func EventOutbox(s store.Storer, ac eventOutboxAccessController, al actionlog.Recorder) *eventOutbox {
+	return &eventOutbox{
+		store:     s,
+		ac:        ac,
+		actionlog: al,
+	}
+}
+
+// SearchOutbox returns events waiting for delivery
+func (svc eventOutbox) SearchOutbox(ctx context.Context, f eventbus.QueuedEventFilter) (set eventbus.QueuedEventSet, _ eventbus.QueuedEventFilter, err error) {
+	var (
+		aProps = &eventOutboxActionProps{filter: &f}
+	)
+
+	err = func() error {
+		if !svc.ac.CanManageEventOutbox(ctx) {
+			return EventOutboxErrNotAllowedToManage()
+		}
+
+		set, f, err = store.SearchEventbusOutboxEvents(ctx, svc.store, f)
+		return err
+	}()
+
+	return set, f, svc.recordAction(ctx, aProps, EventOutboxActionSearchOutbox, err)
+}
+
+// SearchDeadLetters returns events that could not be delivered
+func (svc eventOutbox) SearchDeadLetters(ctx context.Context, f eventbus.QueuedEventFilter) (set eventbus.QueuedEventSet, _ eventbus.QueuedEventFilter, err error) {
+	var (
+		aProps = &eventOutboxActionProps{filter: &f}
+	)
+
+	err = func() error {
+		if !svc.ac.CanManageEventOutbox(ctx) {
+			return EventOutboxErrNotAllowedToManage()
+		}
+
+		set, f, err = store.SearchEventbusDeadLetters(ctx, svc.store, f)
+		return err
+	}()
+
+	return set, f, svc.recordAction(ctx, aProps, EventOutboxActionSearchDeadLetters, err)
+}
+
+// Replay moves dead letter back to the outbox where it is scheduled for immediate delivery
+func (svc eventOutbox) Replay(ctx context.Context, eventID uint64) (qe *eventbus.QueuedEvent, err error) {
+	var (
+		aProps = &eventOutboxActionProps{event: &eventbus.QueuedEvent{ID: eventID}}
+	)
+
+	err = store.Tx(ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+		if !svc.ac.CanManageEventOutbox(ctx) {
+			return EventOutboxErrNotAllowedToManage()
+		}
+
+		if eventID == 0 {
+			return EventOutboxErrInvalidID()
+		}
+
+		if qe, err = store.LookupEventbusDeadLetterByID(ctx, s, eventID); errors.IsNotFound(err) {
+			return EventOutboxErrNotFound()
+		} else if err != nil {
+			return
+		}
+
+		aProps.setEvent(qe)
+
+		if err = store.DeleteEventbusDeadLetter(ctx, s, qe); err != nil {
+			return
+		}
+
+		qe.Attempts = 0
+		qe.LastError = ""
+		qe.FailedAt = nil
+		qe.ScheduledAt = *now()
+
+		return store.CreateEventbusOutboxEvent(ctx, s, qe)
+	})
+
+	return qe, svc.recordAction(ctx, aProps, EventOutboxActionReplay, err)
+}
+
+// Purge removes all dead letters that match the filter
+func (svc eventOutbox) Purge(ctx context.Context, f eventbus.QueuedEventFilter) (n int, err error) {
+	var (
+		aProps = &eventOutboxActionProps{filter: &f}
+	)
+
+	err = store.Tx(ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+		if !svc.ac.CanManageEventOutbox(ctx) {
+			return EventOutboxErrNotAllowedToManage()
+		}
+
+		set, _, err := store.SearchEventbusDeadLetters(ctx, s, eventbus.QueuedEventFilter{
+			ResourceType: f.ResourceType,
+			EventType:    f.EventType,
+		})
+
+		if err != nil {
+			return
+		}
+
+		if err = store.DeleteEventbusDeadLetter(ctx, s, set...); err != nil {
+			return
+		}
+
+		n = len(set)
+		aProps.setCount(n)
+		return nil
+	})
+
+	return n, svc.recordAction(ctx, aProps, EventOutboxActionPurge, err)
+}
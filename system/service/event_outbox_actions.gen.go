@@ -0,0 +1,450 @@
+package service
+
+// This file is auto-generated.
+//
+// Changes to this file may cause incorrect behavior and will be lost if
+// the code is regenerated.
+//
+// Definitions file that controls how this file is generated:
+// system/service/event_outbox_actions.yaml
+
+import (
+	"context"
+	"fmt"
+	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"strings"
+	"time"
+)
+
+type (
+	eventOutboxActionProps struct {
+		event  *eventbus.QueuedEvent
+		filter *eventbus.QueuedEventFilter
+		count  int
+	}
+
+	eventOutboxAction struct {
+		timestamp time.Time
+		resource  string
+		action    string
+		log       string
+		severity  actionlog.Severity
+
+		// prefix for error when action fails
+		errorMessage string
+
+		props *eventOutboxActionProps
+	}
+
+	eventOutboxLogMetaKey   struct{}
+	eventOutboxPropsMetaKey struct{}
+)
+
+var (
+	// just a placeholder to cover template cases w/o fmt package use
+	_ = fmt.Println
+)
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Props methods
+// setEvent updates eventOutboxActionProps's event
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *eventOutboxActionProps) setEvent(event *eventbus.QueuedEvent) *eventOutboxActionProps {
+	p.event = event
+	return p
+}
+
+// setFilter updates eventOutboxActionProps's filter
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *eventOutboxActionProps) setFilter(filter *eventbus.QueuedEventFilter) *eventOutboxActionProps {
+	p.filter = filter
+	return p
+}
+
+// setCount updates eventOutboxActionProps's count
+//
+// Allows method chaining
+//
+// This function is auto-generated.
+//
+func (p *eventOutboxActionProps) setCount(count int) *eventOutboxActionProps {
+	p.count = count
+	return p
+}
+
+// Serialize converts eventOutboxActionProps to actionlog.Meta
+//
+// This function is auto-generated.
+//
+func (p eventOutboxActionProps) Serialize() actionlog.Meta {
+	var (
+		m = make(actionlog.Meta)
+	)
+
+	if p.event != nil {
+		m.Set("event.ID", p.event.ID, true)
+		m.Set("event.resourceType", p.event.ResourceType, true)
+		m.Set("event.eventType", p.event.EventType, true)
+	}
+	if p.filter != nil {
+		m.Set("filter.resourceType", p.filter.ResourceType, true)
+		m.Set("filter.eventType", p.filter.EventType, true)
+	}
+	m.Set("count", p.count, true)
+
+	return m
+}
+
+// tr translates string and replaces meta value placeholder with values
+//
+// This function is auto-generated.
+//
+func (p eventOutboxActionProps) Format(in string, err error) string {
+	var (
+		pairs = []string{"{err}"}
+		// first non-empty string
+		fns = func(ii ...interface{}) string {
+			for _, i := range ii {
+				if s := fmt.Sprintf("%v", i); len(s) > 0 {
+					return s
+				}
+			}
+
+			return ""
+		}
+	)
+
+	if err != nil {
+		pairs = append(pairs, err.Error())
+	} else {
+		pairs = append(pairs, "nil")
+	}
+
+	if p.event != nil {
+		// replacement for "{event}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{event}",
+			fns(
+				p.event.ID,
+				p.event.ResourceType,
+				p.event.EventType,
+			),
+		)
+		pairs = append(pairs, "{event.ID}", fns(p.event.ID))
+		pairs = append(pairs, "{event.resourceType}", fns(p.event.ResourceType))
+		pairs = append(pairs, "{event.eventType}", fns(p.event.EventType))
+	}
+
+	if p.filter != nil {
+		// replacement for "{filter}" (in order how fields are defined)
+		pairs = append(
+			pairs,
+			"{filter}",
+			fns(
+				p.filter.ResourceType,
+				p.filter.EventType,
+			),
+		)
+		pairs = append(pairs, "{filter.resourceType}", fns(p.filter.ResourceType))
+		pairs = append(pairs, "{filter.eventType}", fns(p.filter.EventType))
+	}
+	pairs = append(pairs, "{count}", fns(p.count))
+	return strings.NewReplacer(pairs...).Replace(in)
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Action methods
+
+// String returns loggable description as string
+//
+// This function is auto-generated.
+//
+func (a *eventOutboxAction) String() string {
+	var props = &eventOutboxActionProps{}
+
+	if a.props != nil {
+		props = a.props
+	}
+
+	return props.Format(a.log, nil)
+}
+
+func (e *eventOutboxAction) ToAction() *actionlog.Action {
+	return &actionlog.Action{
+		Resource:    e.resource,
+		Action:      e.action,
+		Severity:    e.severity,
+		Description: e.String(),
+		Meta:        e.props.Serialize(),
+	}
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Action constructors
+
+// EventOutboxActionSearchOutbox returns "system:event-outbox.searchOutbox" action
+//
+// This function is auto-generated.
+//
+func EventOutboxActionSearchOutbox(props ...*eventOutboxActionProps) *eventOutboxAction {
+	a := &eventOutboxAction{
+		timestamp: time.Now(),
+		resource:  "system:event-outbox",
+		action:    "searchOutbox",
+		log:       "searched for events in the outbox",
+		severity:  actionlog.Info,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// EventOutboxActionSearchDeadLetters returns "system:event-outbox.searchDeadLetters" action
+//
+// This function is auto-generated.
+//
+func EventOutboxActionSearchDeadLetters(props ...*eventOutboxActionProps) *eventOutboxAction {
+	a := &eventOutboxAction{
+		timestamp: time.Now(),
+		resource:  "system:event-outbox",
+		action:    "searchDeadLetters",
+		log:       "searched for dead letters",
+		severity:  actionlog.Info,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// EventOutboxActionReplay returns "system:event-outbox.replay" action
+//
+// This function is auto-generated.
+//
+func EventOutboxActionReplay(props ...*eventOutboxActionProps) *eventOutboxAction {
+	a := &eventOutboxAction{
+		timestamp: time.Now(),
+		resource:  "system:event-outbox",
+		action:    "replay",
+		log:       "replayed dead letter {event}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// EventOutboxActionPurge returns "system:event-outbox.purge" action
+//
+// This function is auto-generated.
+//
+func EventOutboxActionPurge(props ...*eventOutboxActionProps) *eventOutboxAction {
+	a := &eventOutboxAction{
+		timestamp: time.Now(),
+		resource:  "system:event-outbox",
+		action:    "purge",
+		log:       "purged {count} dead letters",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+// Error constructors
+
+// EventOutboxErrGeneric returns "system:event-outbox.generic" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func EventOutboxErrGeneric(mm ...*eventOutboxActionProps) *errors.Error {
+	var p = &eventOutboxActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("failed to complete request due to internal error", nil),
+
+		errors.Meta("type", "generic"),
+		errors.Meta("resource", "system:event-outbox"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(eventOutboxLogMetaKey{}, "{err}"),
+		errors.Meta(eventOutboxPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// EventOutboxErrNotFound returns "system:event-outbox.notFound" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func EventOutboxErrNotFound(mm ...*eventOutboxActionProps) *errors.Error {
+	var p = &eventOutboxActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("dead letter not found", nil),
+
+		errors.Meta("type", "notFound"),
+		errors.Meta("resource", "system:event-outbox"),
+
+		errors.Meta(eventOutboxPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// EventOutboxErrInvalidID returns "system:event-outbox.invalidID" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func EventOutboxErrInvalidID(mm ...*eventOutboxActionProps) *errors.Error {
+	var p = &eventOutboxActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid ID", nil),
+
+		errors.Meta("type", "invalidID"),
+		errors.Meta("resource", "system:event-outbox"),
+
+		errors.Meta(eventOutboxPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// EventOutboxErrNotAllowedToManage returns "system:event-outbox.notAllowedToManage" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func EventOutboxErrNotAllowedToManage(mm ...*eventOutboxActionProps) *errors.Error {
+	var p = &eventOutboxActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("not allowed to manage event outbox", nil),
+
+		errors.Meta("type", "notAllowedToManage"),
+		errors.Meta("resource", "system:event-outbox"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(eventOutboxLogMetaKey{}, "failed to manage event outbox; insufficient permissions"),
+		errors.Meta(eventOutboxPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// *********************************************************************************************************************
+// *********************************************************************************************************************
+
+// recordAction is a service helper function wraps function that can return error
+//
+// It will wrap unrecognized/internal errors with generic errors.
+//
+// This function is auto-generated.
+//
+func (svc eventOutbox) recordAction(ctx context.Context, props *eventOutboxActionProps, actionFn func(...*eventOutboxActionProps) *eventOutboxAction, err error) error {
+	if svc.actionlog == nil || actionFn == nil {
+		// action log disabled or no action fn passed, return error as-is
+		return err
+	} else if err == nil {
+		// action completed w/o error, record it
+		svc.actionlog.Record(ctx, actionFn(props).ToAction())
+		return nil
+	}
+
+	a := actionFn(props).ToAction()
+
+	// Extracting error information and recording it as action
+	a.Error = err.Error()
+
+	switch c := err.(type) {
+	case *errors.Error:
+		m := c.Meta()
+
+		a.Error = err.Error()
+		a.Severity = actionlog.Severity(m.AsInt("severity"))
+		a.Description = props.Format(m.AsString(eventOutboxLogMetaKey{}), err)
+
+		if p, has := m[eventOutboxPropsMetaKey{}]; has {
+			a.Meta = p.(*eventOutboxActionProps).Serialize()
+		}
+
+		svc.actionlog.Record(ctx, a)
+	default:
+		svc.actionlog.Record(ctx, a)
+	}
+
+	// Original error is passed on
+	return err
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"strconv"
+	"time"
 
 	"github.com/cortezaproject/corteza-server/pkg/actionlog"
 	"github.com/cortezaproject/corteza-server/pkg/errors"
@@ -59,6 +60,7 @@ type (
 		Membership(userID uint64) (types.RoleMemberSet, error)
 		MemberList(roleID uint64) (types.RoleMemberSet, error)
 		MemberAdd(roleID, userID uint64) error
+		MemberAddTimeBound(roleID, userID uint64, validFrom, validUntil *time.Time) error
 		MemberRemove(roleID, userID uint64) error
 	}
 )
@@ -246,6 +248,8 @@ func (svc role) proc(r *types.Role, err error) (*types.Role, error) {
 func (svc role) Create(new *types.Role) (r *types.Role, err error) {
 	var (
 		raProps = &roleActionProps{new: new}
+
+		deferred eventbus.Deferred
 	)
 
 	err = func() (err error) {
@@ -265,20 +269,32 @@ func (svc role) Create(new *types.Role) (r *types.Role, err error) {
 			return
 		}
 
+		if err = svc.checkParent(new); err != nil {
+			return
+		}
+
 		new.ID = nextID()
 		new.CreatedAt = *now()
 
-		if err = store.CreateRole(svc.ctx, svc.store, new); err != nil {
-			return
-		}
+		err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+			if err = store.CreateRole(ctx, s, new); err != nil {
+				return
+			}
+
+			if err = label.Create(ctx, s, new); err != nil {
+				return
+			}
 
-		if err = label.Create(svc.ctx, svc.store, new); err != nil {
+			return deferred.Enqueue(ctx, s, event.RoleAfterCreate(new, new))
+		})
+
+		if err != nil {
 			return
 		}
 
-		r = new
+		_ = deferred.WaitFor(svc.ctx, svc.eventbus)
 
-		_ = svc.eventbus.WaitFor(svc.ctx, event.RoleAfterCreate(new, r))
+		r = new
 		return
 	}()
 
@@ -289,6 +305,8 @@ func (svc role) Create(new *types.Role) (r *types.Role, err error) {
 func (svc role) Update(upd *types.Role) (r *types.Role, err error) {
 	var (
 		raProps = &roleActionProps{update: upd}
+
+		deferred eventbus.Deferred
 	)
 
 	err = func() (err error) {
@@ -318,25 +336,37 @@ func (svc role) Update(upd *types.Role) (r *types.Role, err error) {
 			return
 		}
 
+		if err = svc.checkParent(upd); err != nil {
+			return
+		}
+
 		r.Handle = upd.Handle
 		r.Name = upd.Name
+		r.ParentID = upd.ParentID
 		r.UpdatedAt = now()
 
 		// Assign changed values
-		if err = store.UpdateRole(svc.ctx, svc.store, r); err != nil {
-			return err
-		}
+		err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+			if err = store.UpdateRole(ctx, s, r); err != nil {
+				return err
+			}
 
-		if label.Changed(r.Labels, upd.Labels) {
-			if err = label.Update(svc.ctx, svc.store, upd); err != nil {
-				return
+			if label.Changed(r.Labels, upd.Labels) {
+				if err = label.Update(ctx, s, upd); err != nil {
+					return
+				}
+
+				r.Labels = upd.Labels
 			}
 
-			r.Labels = upd.Labels
-		}
+			return deferred.Enqueue(ctx, s, event.RoleAfterUpdate(upd, r))
+		})
 
-		_ = svc.eventbus.WaitFor(svc.ctx, event.RoleAfterUpdate(upd, r))
+		if err != nil {
+			return
+		}
 
+		_ = deferred.WaitFor(svc.ctx, svc.eventbus)
 		return nil
 	}()
 
@@ -365,10 +395,43 @@ func (svc role) UniqueCheck(r *types.Role) (err error) {
 	return nil
 }
 
+// checkParent verifies that parent role exists and that
+// role does not (indirectly) inherit from itself
+func (svc role) checkParent(r *types.Role) error {
+	var (
+		visited = map[uint64]bool{r.ID: true}
+	)
+
+	for parentID := r.ParentID; parentID > 0; {
+		if visited[parentID] {
+			return RoleErrParentCycle()
+		}
+
+		visited[parentID] = true
+
+		p, err := store.LookupRoleByID(svc.ctx, svc.store, parentID)
+		if errors.IsNotFound(err) {
+			return RoleErrInvalidParent()
+		} else if err != nil {
+			return err
+		}
+
+		if parentID == r.ParentID && p.DeletedAt != nil {
+			return RoleErrInvalidParent()
+		}
+
+		parentID = p.ParentID
+	}
+
+	return nil
+}
+
 func (svc role) Delete(roleID uint64) (err error) {
 	var (
 		r       *types.Role
 		raProps = &roleActionProps{role: &types.Role{ID: roleID}}
+
+		deferred eventbus.Deferred
 	)
 
 	err = func() (err error) {
@@ -388,13 +451,20 @@ func (svc role) Delete(roleID uint64) (err error) {
 
 		r.DeletedAt = now()
 
-		if err = store.UpdateRole(svc.ctx, svc.store, r); err != nil {
+		err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+			if err = store.UpdateRole(ctx, s, r); err != nil {
+				return
+			}
+
+			return deferred.Enqueue(ctx, s, event.RoleAfterDelete(nil, r))
+		})
+
+		if err != nil {
 			return
 		}
 
-		_ = svc.eventbus.WaitFor(svc.ctx, event.RoleAfterDelete(nil, r))
-
-		return
+		_ = deferred.WaitFor(svc.ctx, svc.eventbus)
+		return nil
 	}()
 
 	return svc.recordAction(svc.ctx, raProps, RoleActionDelete, err)
@@ -521,6 +591,14 @@ func (svc role) MemberList(roleID uint64) (mm types.RoleMemberSet, err error) {
 
 // MemberAdd adds member (user) to a role
 func (svc role) MemberAdd(roleID, memberID uint64) (err error) {
+	return svc.MemberAddTimeBound(roleID, memberID, nil, nil)
+}
+
+// MemberAddTimeBound adds member (user) to a role for a limited time
+//
+// Membership is valid from/until given time; nil means no restriction.
+// Adding existing member updates validity of the membership.
+func (svc role) MemberAddTimeBound(roleID, memberID uint64, validFrom, validUntil *time.Time) (err error) {
 	var (
 		r *types.Role
 		m *types.User
@@ -529,6 +607,8 @@ func (svc role) MemberAdd(roleID, memberID uint64) (err error) {
 			role:   &types.Role{ID: roleID},
 			member: &types.User{ID: memberID},
 		}
+
+		deferred eventbus.Deferred
 	)
 
 	err = func() (err error) {
@@ -536,6 +616,10 @@ func (svc role) MemberAdd(roleID, memberID uint64) (err error) {
 			return RoleErrInvalidID()
 		}
 
+		if validFrom != nil && validUntil != nil && !validUntil.After(*validFrom) {
+			return RoleErrInvalidMembershipValidity()
+		}
+
 		if r, err = svc.findByID(roleID); err != nil {
 			return
 		}
@@ -556,11 +640,26 @@ func (svc role) MemberAdd(roleID, memberID uint64) (err error) {
 			return RoleErrNotAllowedToManageMembers()
 		}
 
-		if err = store.CreateRoleMember(svc.ctx, svc.store, &types.RoleMember{RoleID: r.ID, UserID: m.ID}); err != nil {
+		rm := &types.RoleMember{
+			RoleID:     r.ID,
+			UserID:     m.ID,
+			ValidFrom:  utcTime(validFrom),
+			ValidUntil: utcTime(validUntil),
+		}
+
+		err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+			if err = store.UpsertRoleMember(ctx, s, rm); err != nil {
+				return
+			}
+
+			return deferred.Enqueue(ctx, s, event.RoleMemberAfterAdd(m, r))
+		})
+
+		if err != nil {
 			return
 		}
 
-		_ = svc.eventbus.WaitFor(svc.ctx, event.RoleMemberAfterAdd(m, r))
+		_ = deferred.WaitFor(svc.ctx, svc.eventbus)
 		return nil
 	}()
 
@@ -576,6 +675,8 @@ func (svc role) MemberRemove(roleID, memberID uint64) (err error) {
 			role:   &types.Role{ID: roleID},
 			member: &types.User{ID: memberID},
 		}
+
+		deferred eventbus.Deferred
 	)
 
 	err = func() (err error) {
@@ -603,17 +704,89 @@ func (svc role) MemberRemove(roleID, memberID uint64) (err error) {
 			return RoleErrNotAllowedToManageMembers()
 		}
 
-		if err = store.DeleteRoleMember(svc.ctx, svc.store, &types.RoleMember{RoleID: r.ID, UserID: m.ID}); err != nil {
+		err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+			if err = store.DeleteRoleMember(ctx, s, &types.RoleMember{RoleID: r.ID, UserID: m.ID}); err != nil {
+				return
+			}
+
+			return deferred.Enqueue(ctx, s, event.RoleMemberAfterRemove(m, r))
+		})
+
+		if err != nil {
 			return
 		}
 
-		_ = svc.eventbus.WaitFor(svc.ctx, event.RoleMemberAfterRemove(m, r))
+		_ = deferred.WaitFor(svc.ctx, svc.eventbus)
 		return nil
 	}()
 
 	return svc.recordAction(svc.ctx, raProps, RoleActionMemberRemove, err)
 }
 
+// membershipRoles returns roles user is currently member of
+// and all roles inherited through parent roles
+//
+// Expired and not-yet-valid memberships are ignored.
+func membershipRoles(ctx context.Context, s store.Roles, userID uint64) (types.RoleSet, error) {
+	mm, _, err := store.SearchRoles(ctx, s, types.RoleFilter{MemberID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	inherits := false
+	for _, r := range mm {
+		inherits = inherits || r.ParentID > 0
+	}
+
+	if !inherits {
+		return mm, nil
+	}
+
+	// load all (valid) roles to walk through the hierarchy
+	all, _, err := store.SearchRoles(ctx, s, types.RoleFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	out := mm
+	for _, r := range mm {
+		for _, a := range all.Ancestors(r.ID) {
+			if out.FindByID(a.ID) == nil {
+				out = append(out, a)
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// loadMemberships sets current roles of the user
+// and time when those roles change
+func loadMemberships(ctx context.Context, s store.Storer, u *types.User) error {
+	rr, err := membershipRoles(ctx, s, u.ID)
+	if err != nil {
+		return err
+	}
+
+	mm, _, err := store.SearchRoleMembers(ctx, s, types.RoleMemberFilter{UserID: u.ID})
+	if err != nil {
+		return err
+	}
+
+	u.SetRoles(rr.IDs())
+	u.SetRolesValidUntil(mm.NextChange(*now()))
+	return nil
+}
+
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	u := t.UTC()
+	return &u
+}
+
 // toLabeledRoles converts to []label.LabeledResource
 //
 // This function is auto-generated.
@@ -734,6 +734,98 @@ func RoleErrInvalidHandle(mm ...*roleActionProps) *errors.Error {
 	return e
 }
 
+// RoleErrInvalidParent returns "system:role.invalidParent" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RoleErrInvalidParent(mm ...*roleActionProps) *errors.Error {
+	var p = &roleActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("invalid parent role", nil),
+
+		errors.Meta("type", "invalidParent"),
+		errors.Meta("resource", "system:role"),
+
+		errors.Meta(rolePropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RoleErrParentCycle returns "system:role.parentCycle" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RoleErrParentCycle(mm ...*roleActionProps) *errors.Error {
+	var p = &roleActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("parent role would create a cycle", nil),
+
+		errors.Meta("type", "parentCycle"),
+		errors.Meta("resource", "system:role"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(roleLogMetaKey{}, "failed to set parent of {role.handle}; role would inherit from itself"),
+		errors.Meta(rolePropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
+// RoleErrInvalidMembershipValidity returns "system:role.invalidMembershipValidity" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func RoleErrInvalidMembershipValidity(mm ...*roleActionProps) *errors.Error {
+	var p = &roleActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("membership must be valid until after it is valid from", nil),
+
+		errors.Meta("type", "invalidMembershipValidity"),
+		errors.Meta("resource", "system:role"),
+
+		errors.Meta(rolePropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
 // RoleErrNotAllowedToRead returns "system:role.notAllowedToRead" as *errors.Error
 //
 //
@@ -15,7 +15,9 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/objstore/minio"
 	"github.com/cortezaproject/corteza-server/pkg/objstore/plain"
 	"github.com/cortezaproject/corteza-server/pkg/options"
+	"github.com/cortezaproject/corteza-server/pkg/passwd"
 	"github.com/cortezaproject/corteza-server/pkg/rbac"
+	"github.com/cortezaproject/corteza-server/pkg/secrets"
 	"github.com/cortezaproject/corteza-server/store"
 	"github.com/cortezaproject/corteza-server/system/types"
 	"go.uber.org/zap"
@@ -30,6 +32,10 @@ type (
 	Config struct {
 		ActionLog options.ActionLogOpt
 		Storage   options.ObjectStoreOpt
+		Auth      options.AuthOpt
+
+		// Encrypts secrets; nil when encryption key is not set
+		Keyring *secrets.Keyring
 	}
 
 	permitChecker interface {
@@ -76,6 +82,10 @@ var (
 	// CurrentSettings represents current system settings
 	CurrentSettings = &types.AppSettings{}
 
+	// BreachedPasswords holds list of known breached passwords
+	// that are rejected by the password policy
+	BreachedPasswords *passwd.BreachedList
+
 	DefaultActionlog actionlog.Recorder
 
 	DefaultSink *sink
@@ -86,6 +96,8 @@ var (
 	DefaultApplication *application
 	DefaultReminder    ReminderService
 	DefaultAttachment  AttachmentService
+	DefaultTemplate    TemplateService
+	DefaultEventOutbox EventOutboxService
 
 	DefaultStatistics *statistics
 
@@ -128,7 +140,7 @@ func Initialize(ctx context.Context, log *zap.Logger, s store.Storer, c Config)
 
 	DefaultAccessControl = AccessControl(rbac.Global())
 
-	DefaultSettings = Settings(DefaultStore, DefaultLogger, DefaultAccessControl, CurrentSettings)
+	DefaultSettings = Settings(DefaultStore, DefaultLogger, DefaultAccessControl, c.Keyring, CurrentSettings)
 
 	if DefaultObjectStore == nil {
 		const svcPath = "system"
@@ -167,15 +179,29 @@ func Initialize(ctx context.Context, log *zap.Logger, s store.Storer, c Config)
 
 	hcd.Add(objstore.Healthcheck(DefaultObjectStore), "ObjectStore/System")
 
-	DefaultAuthNotification = AuthNotification(CurrentSettings)
+	tpl := Template(DefaultStore, DefaultAccessControl, DefaultActionlog)
+	DefaultTemplate = tpl
+
+	if c.Auth.BreachedPasswordsFile != "" {
+		if BreachedPasswords, err = passwd.LoadBreachedList(c.Auth.BreachedPasswordsFile); err != nil {
+			return err
+		}
+
+		log.Info("breached password list loaded",
+			zap.String("path", c.Auth.BreachedPasswordsFile),
+			zap.Int("count", BreachedPasswords.Len()))
+	}
+
+	DefaultAuthNotification = AuthNotification(CurrentSettings, tpl)
 	DefaultAuth = Auth()
 	DefaultUser = User(ctx)
 	DefaultRole = Role(ctx)
 	DefaultApplication = Application(DefaultStore, DefaultAccessControl, DefaultActionlog, eventbus.Service())
 	DefaultReminder = Reminder(ctx)
+	DefaultAttachment = Attachment(DefaultObjectStore)
 	DefaultSink = Sink()
 	DefaultStatistics = Statistics()
-	DefaultAttachment = Attachment(DefaultObjectStore)
+	DefaultEventOutbox = EventOutbox(DefaultStore, DefaultAccessControl, DefaultActionlog)
 
 	return
 }
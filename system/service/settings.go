@@ -7,6 +7,7 @@ import (
 
 	"github.com/cortezaproject/corteza-server/pkg/errors"
 	"github.com/cortezaproject/corteza-server/pkg/logger"
+	"github.com/cortezaproject/corteza-server/pkg/secrets"
 	"github.com/cortezaproject/corteza-server/store"
 	"github.com/cortezaproject/corteza-server/system/types"
 	"go.uber.org/zap"
@@ -19,6 +20,9 @@ type (
 		accessControl accessController
 		logger        *zap.Logger
 
+		// Encrypts secret settings; nil when encryption key is not set
+		keyring *secrets.Keyring
+
 		// Holds reference to the "current" settings that
 		// are used by the services
 		current interface{}
@@ -33,13 +37,15 @@ type (
 var (
 	ErrNoReadPermission   = fmt.Errorf("not allowed to read settings")
 	ErrNoManagePermission = fmt.Errorf("not allowed to manage settings")
+	ErrNoEncryptionKey    = fmt.Errorf("secrets encryption key not set")
 )
 
-func Settings(s store.Settings, log *zap.Logger, ac accessController, current interface{}) *settings {
+func Settings(s store.Settings, log *zap.Logger, ac accessController, kr *secrets.Keyring, current interface{}) *settings {
 	svc := &settings{
 		store:         s,
 		accessControl: ac,
 		logger:        log.Named("settings"),
+		keyring:       kr,
 		current:       current,
 	}
 
@@ -50,15 +56,36 @@ func (svc settings) log(ctx context.Context, fields ...zapcore.Field) *zap.Logge
 	return logger.AddRequestID(ctx, svc.logger).With(fields...)
 }
 
+// FindByPrefix returns settings with values of secret settings masked
 func (svc settings) FindByPrefix(ctx context.Context, pp ...string) (types.SettingValueSet, error) {
 	if !svc.accessControl.CanReadSettings(ctx) {
 		return nil, ErrNoReadPermission
 	}
 
-	return svc.findByPrefix(ctx, pp...)
+	vv, err := svc.search(ctx, pp...)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = vv.Walk(func(v *types.SettingValue) error {
+		v.Mask()
+		return nil
+	})
+
+	return vv, nil
 }
 
+// findByPrefix returns settings with decrypted values of secret settings
 func (svc settings) findByPrefix(ctx context.Context, pp ...string) (types.SettingValueSet, error) {
+	vv, err := svc.search(ctx, pp...)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.decrypt(ctx, vv), nil
+}
+
+func (svc settings) search(ctx context.Context, pp ...string) (types.SettingValueSet, error) {
 	var (
 		f = types.SettingsFilter{
 			Prefix: strings.Join(pp, "."),
@@ -80,6 +107,10 @@ func (svc settings) Get(ctx context.Context, name string, ownedBy uint64) (out *
 		return nil, err
 	}
 
+	if out != nil {
+		out.Mask()
+	}
+
 	return out, nil
 }
 
@@ -105,18 +136,37 @@ func (svc settings) Set(ctx context.Context, v *types.SettingValue) (err error)
 		return ErrNoManagePermission
 	}
 
-	var current *types.SettingValue
+	if v.IsMasked() {
+		// masked secret was sent back, keep the current value
+		return nil
+	}
+
+	var (
+		current *types.SettingValue
+		enc     types.SettingValueSet
+	)
+
 	current, err = store.LookupSettingByNameOwnedBy(ctx, svc.store, v.Name, v.OwnedBy)
 	if errors.IsNotFound(err) {
 		v.UpdatedAt = *now()
-		err = store.CreateSetting(ctx, svc.store, v)
+		if enc, err = svc.encrypt(types.SettingValueSet{v}); err != nil {
+			return err
+		}
+
+		err = store.CreateSetting(ctx, svc.store, enc...)
 	} else if err != nil {
 		return err
+	} else if dec := svc.decrypt(ctx, types.SettingValueSet{current}); len(dec) > 0 {
+		current = dec[0]
 	}
 
-	if !current.Eq(v) {
+	if current != nil && !current.Eq(v) {
 		v.UpdatedAt = *now()
-		err = store.UpdateSetting(ctx, svc.store, v)
+		if enc, err = svc.encrypt(types.SettingValueSet{v}); err != nil {
+			return err
+		}
+
+		err = store.UpdateSetting(ctx, svc.store, enc...)
 	}
 
 	if err != nil || current.Eq(v) {
@@ -133,9 +183,14 @@ func (svc settings) BulkSet(ctx context.Context, vv types.SettingValueSet) (err
 		return ErrNoManagePermission
 	}
 
+	// masked secrets were sent back, keep their current values
+	vv, _ = vv.Filter(func(v *types.SettingValue) (bool, error) {
+		return !v.IsMasked(), nil
+	})
+
 	// Load current settings and get changed values
 	var current, old, new types.SettingValueSet
-	if current, err = svc.FindByPrefix(ctx); err != nil {
+	if current, err = svc.findByPrefix(ctx); err != nil {
 		return
 	} else {
 		vv = current.Changed(vv)
@@ -148,6 +203,14 @@ func (svc settings) BulkSet(ctx context.Context, vv types.SettingValueSet) (err
 		new = current.New(vv)
 	}
 
+	if old, err = svc.encrypt(old); err != nil {
+		return
+	}
+
+	if new, err = svc.encrypt(new); err != nil {
+		return
+	}
+
 	if err = store.UpdateSetting(ctx, svc.store, old...); err != nil {
 		return
 	}
@@ -163,10 +226,16 @@ func (svc settings) BulkSet(ctx context.Context, vv types.SettingValueSet) (err
 
 func (svc settings) logChange(ctx context.Context, vv types.SettingValueSet) {
 	for _, v := range vv {
+		var (
+			masked = *v
+		)
+
+		masked.Mask()
+
 		svc.log(ctx,
 			zap.String("name", v.Name),
 			zap.Uint64("owned-by", v.OwnedBy),
-			zap.Stringer("value", v.Value)).
+			zap.Stringer("value", masked.Value)).
 			WithOptions(zap.AddCallerSkip(1)).
 			Debug("setting value updated")
 	}
@@ -197,3 +266,113 @@ func (svc settings) Delete(ctx context.Context, name string, ownedBy uint64) err
 
 	return svc.updateCurrent(ctx, vv)
 }
+
+// RotateSecrets (re)encrypts all secret settings with the primary encryption key
+//
+// Secrets that are stored unencrypted (set before encryption key was configured
+// or provisioned directly to the store) are encrypted as well.
+func (svc settings) RotateSecrets(ctx context.Context) (rotated int, err error) {
+	if !svc.accessControl.CanManageSettings(ctx) {
+		return 0, ErrNoManagePermission
+	}
+
+	if svc.keyring == nil {
+		return 0, ErrNoEncryptionKey
+	}
+
+	vv, err := svc.search(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, v := range vv {
+		if !types.IsSecretSetting(v.Name) {
+			continue
+		}
+
+		raw := v.String()
+		if secrets.IsEncrypted(raw) {
+			if !svc.keyring.NeedsRotation(raw) {
+				continue
+			}
+
+			plain, err := svc.keyring.Decrypt(raw)
+			if err != nil {
+				return rotated, fmt.Errorf("could not decrypt setting %s: %w", v.Name, err)
+			}
+
+			v.Value = plain
+		}
+
+		enc, err := svc.encrypt(types.SettingValueSet{v})
+		if err != nil {
+			return rotated, err
+		}
+
+		if err = store.UpdateSetting(ctx, svc.store, enc...); err != nil {
+			return rotated, err
+		}
+
+		rotated++
+	}
+
+	svc.log(ctx, zap.Int("rotated", rotated)).Info("secret settings re-encrypted")
+	return rotated, nil
+}
+
+// encrypt returns copy of the set with values of secret settings encrypted
+//
+// Values are stored unencrypted when there is no encryption key
+func (svc settings) encrypt(vv types.SettingValueSet) (out types.SettingValueSet, err error) {
+	out = make(types.SettingValueSet, len(vv))
+	for i, v := range vv {
+		out[i] = v
+		if svc.keyring == nil || !types.IsSecretSetting(v.Name) {
+			continue
+		}
+
+		var (
+			enc = *v
+			s   string
+		)
+
+		if s, err = svc.keyring.Encrypt(v.Value); err != nil {
+			return
+		}
+
+		if err = enc.SetValue(s); err != nil {
+			return
+		}
+
+		out[i] = &enc
+	}
+
+	return
+}
+
+// decrypt replaces encrypted values of secret settings with decrypted ones
+//
+// Settings that can not be decrypted are left out
+func (svc settings) decrypt(ctx context.Context, vv types.SettingValueSet) (out types.SettingValueSet) {
+	out = make(types.SettingValueSet, 0, len(vv))
+	for _, v := range vv {
+		if raw := v.String(); types.IsSecretSetting(v.Name) && secrets.IsEncrypted(raw) {
+			if svc.keyring == nil {
+				svc.log(ctx, zap.String("name", v.Name)).Error("could not decrypt setting", zap.Error(ErrNoEncryptionKey))
+				continue
+			}
+
+			plain, err := svc.keyring.Decrypt(raw)
+			if err != nil {
+				svc.log(ctx, zap.String("name", v.Name)).Error("could not decrypt setting", zap.Error(err))
+				continue
+			}
+
+			v.Value = plain
+		}
+
+		out = append(out, v)
+	}
+
+	return
+}
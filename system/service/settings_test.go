@@ -0,0 +1,107 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cortezaproject/corteza-server/pkg/secrets"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/store/sqlite3"
+	"github.com/cortezaproject/corteza-server/system/types"
+	sqlTypes "github.com/jmoiron/sqlx/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type (
+	mockSettingsAccessControl struct{}
+)
+
+func (mockSettingsAccessControl) CanReadSettings(context.Context) bool   { return true }
+func (mockSettingsAccessControl) CanManageSettings(context.Context) bool { return true }
+
+func TestSettings_secrets(t *testing.T) {
+	var (
+		req = require.New(t)
+		ctx = context.Background()
+
+		secretName = "auth.external.providers.github.secret"
+
+		current = &types.AppSettings{}
+
+		k1 = bytes.Repeat([]byte{1}, secrets.KeySize)
+		k2 = bytes.Repeat([]byte{2}, secrets.KeySize)
+
+		raw = func(s store.Storer) string {
+			v, err := store.LookupSettingByNameOwnedBy(ctx, s, secretName, 0)
+			req.NoError(err)
+			return v.String()
+		}
+	)
+
+	mem, err := sqlite3.ConnectInMemory(ctx)
+	req.NoError(err)
+	req.NoError(store.Upgrade(ctx, zap.NewNop(), mem))
+
+	kr, err := secrets.NewKeyring(k1)
+	req.NoError(err)
+
+	svc := Settings(mem, zap.NewNop(), mockSettingsAccessControl{}, kr, current)
+
+	req.NoError(svc.BulkSet(ctx, types.SettingValueSet{
+		{Name: secretName, Value: sqlTypes.JSONText(`"s3cr3t"`)},
+		{Name: "auth.external.providers.github.key", Value: sqlTypes.JSONText(`"key"`)},
+	}))
+
+	// encrypted at rest
+	req.True(secrets.IsEncrypted(raw(mem)))
+
+	// decrypted for the consuming subsystems
+	req.NoError(svc.UpdateCurrent(ctx))
+	req.Equal("s3cr3t", current.Auth.External.Providers.FindByHandle("github").Secret)
+	req.Equal("key", current.Auth.External.Providers.FindByHandle("github").Key)
+
+	// masked for everyone else
+	vv, err := svc.FindByPrefix(ctx, "auth.external")
+	req.NoError(err)
+	req.Equal(types.SecretSettingMask, vv.First(secretName).String())
+	req.True(vv.First(secretName).Secret)
+	req.Equal("key", vv.First("auth.external.providers.github.key").String())
+
+	v, err := svc.Get(ctx, secretName, 0)
+	req.NoError(err)
+	req.Equal(types.SecretSettingMask, v.String())
+
+	// masked values that are sent back do not change the secret
+	req.NoError(svc.BulkSet(ctx, vv))
+	req.NoError(svc.Set(ctx, vv.First(secretName)))
+	req.NoError(svc.UpdateCurrent(ctx))
+	req.Equal("s3cr3t", current.Auth.External.Providers.FindByHandle("github").Secret)
+
+	// rotation to the new key
+	kr, err = secrets.NewKeyring(k2, k1)
+	req.NoError(err)
+	svc = Settings(mem, zap.NewNop(), mockSettingsAccessControl{}, kr, current)
+	req.True(kr.NeedsRotation(raw(mem)))
+
+	rotated, err := svc.RotateSecrets(ctx)
+	req.NoError(err)
+	req.Equal(1, rotated)
+	req.False(kr.NeedsRotation(raw(mem)))
+
+	rotated, err = svc.RotateSecrets(ctx)
+	req.NoError(err)
+	req.Zero(rotated)
+
+	current.Auth.External.Providers = nil
+	req.NoError(svc.UpdateCurrent(ctx))
+	req.Equal("s3cr3t", current.Auth.External.Providers.FindByHandle("github").Secret)
+
+	// without encryption key, secrets are not encrypted
+	svc = Settings(mem, zap.NewNop(), mockSettingsAccessControl{}, nil, current)
+	req.NoError(svc.Set(ctx, &types.SettingValue{Name: "auth.external.providers.google.secret", Value: sqlTypes.JSONText(`"plain"`)}))
+
+	_, err = svc.RotateSecrets(ctx)
+	req.Equal(ErrNoEncryptionKey, err)
+}
@@ -20,10 +20,11 @@ import (
 
 type (
 	sink struct {
-		signer     internalAuth.Signer
-		actionlog  actionlog.Recorder
-		eventbus   sinkEventDispatcher
-		isMonolith bool
+		signer      internalAuth.Signer
+		actionlog   actionlog.Recorder
+		eventbus    sinkEventDispatcher
+		attachments AttachmentService
+		isMonolith  bool
 	}
 
 	SinkRequestUrlParams struct {
@@ -70,10 +71,11 @@ const (
 
 func Sink() *sink {
 	return &sink{
-		actionlog:  DefaultActionlog,
-		signer:     internalAuth.DefaultSigner,
-		eventbus:   eventbus.Service(),
-		isMonolith: true,
+		actionlog:   DefaultActionlog,
+		signer:      internalAuth.DefaultSigner,
+		eventbus:    eventbus.Service(),
+		attachments: DefaultAttachment,
+		isMonolith:  true,
 	}
 }
 
@@ -294,18 +296,7 @@ func (svc *sink) process(srup *SinkRequestUrlParams, w http.ResponseWriter, r *h
 	case SinkContentTypeMail, "rfc822", "email", "mail":
 		// this is handled by dedicated event that parses raw payload from HTTP request
 		// as rfc882 message.
-		var msg *types.MailMessage
-		msg, err = types.NewMailMessage(body)
-		if err != nil {
-			return SinkErrFailedToCreateEvent(sap).Wrap(err)
-		}
-
-		sap.setMailHeader(&msg.Header)
-
-		err = svc.eventbus.WaitFor(ctx, event.MailOnReceive(msg))
-		if err != nil {
-			return SinkErrFailedToProcess(sap).Wrap(err)
-		}
+		return svc.processMail(ctx, sap, body)
 
 	default:
 		var (
@@ -380,3 +371,57 @@ func (svc *sink) process(srup *SinkRequestUrlParams, w http.ResponseWriter, r *h
 
 	return nil
 }
+
+// ProcessMail handles mail messages received by the embedded SMTP server
+//
+// Message is handled the same way as rfc822 message sent to sink
+// over HTTP and passed to onReceive mail event handlers
+func (svc *sink) ProcessMail(ctx context.Context, body io.Reader) error {
+	sap := &sinkActionProps{contentType: SinkContentTypeMail}
+	return svc.recordAction(ctx, sap, SinkActionReceiveMail, svc.processMail(ctx, sap, body))
+}
+
+// processMail parses rfc822 message and dispatches mail onReceive event
+func (svc *sink) processMail(ctx context.Context, sap *sinkActionProps, body io.Reader) error {
+	msg, err := types.NewMailMessage(body)
+	if err != nil {
+		return SinkErrFailedToCreateEvent(sap).Wrap(err)
+	}
+
+	sap.setMailHeader(&msg.Header)
+
+	if err = svc.storeMailParts(ctx, msg); err != nil {
+		return SinkErrFailedToProcess(sap).Wrap(err)
+	}
+
+	if err = svc.eventbus.WaitFor(ctx, event.MailOnReceive(msg)); err != nil {
+		return SinkErrFailedToProcess(sap).Wrap(err)
+	}
+
+	return nil
+}
+
+// storeMailParts stores attachments and inline parts of the message
+//
+// Handlers get the IDs of the stored attachments and can
+// copy them to the records (see compose attachment service)
+func (svc *sink) storeMailParts(ctx context.Context, msg *types.MailMessage) error {
+	if svc.attachments == nil {
+		return nil
+	}
+
+	for _, p := range msg.Parts {
+		if !p.IsAttachment() && !p.IsInline() {
+			continue
+		}
+
+		att, err := svc.attachments.With(ctx).CreateMailAttachment(p, nil)
+		if err != nil {
+			return err
+		}
+
+		p.AttachmentID = att.ID
+	}
+
+	return nil
+}
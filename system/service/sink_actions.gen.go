@@ -277,6 +277,26 @@ func SinkActionRequest(props ...*sinkActionProps) *sinkAction {
 	return a
 }
 
+// SinkActionReceiveMail returns "system:sink.receiveMail" action
+//
+// This function is auto-generated.
+//
+func SinkActionReceiveMail(props ...*sinkActionProps) *sinkAction {
+	a := &sinkAction{
+		timestamp: time.Now(),
+		resource:  "system:sink",
+		action:    "receiveMail",
+		log:       "mail message received",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
 // *********************************************************************************************************************
 // *********************************************************************************************************************
 // Error constructors
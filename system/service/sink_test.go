@@ -2,7 +2,10 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/system/service/event"
 	"io"
 	"net/http"
 	"reflect"
@@ -11,6 +14,7 @@ import (
 	"time"
 
 	internalAuth "github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/system/types"
 )
 
 func Test_sink_SignURL(t *testing.T) {
@@ -230,3 +234,96 @@ func Test_sink_handleRequest(t *testing.T) {
 		})
 	}
 }
+
+type (
+	sinkEventRecorder struct {
+		ee []eventbus.Event
+	}
+)
+
+func (r *sinkEventRecorder) WaitFor(_ context.Context, ev eventbus.Event) error {
+	r.ee = append(r.ee, ev)
+	return nil
+}
+
+func Test_sink_ProcessMail(t *testing.T) {
+	var (
+		rec = &sinkEventRecorder{}
+		svc = &sink{eventbus: rec}
+		msg = "From: sender@example.tld\r\nTo: inbox@example.tld\r\nSubject: test\r\n\r\nHello\r\n"
+	)
+
+	if err := svc.ProcessMail(context.Background(), strings.NewReader(msg)); err != nil {
+		t.Fatalf("ProcessMail() unexpected error: %v", err)
+	}
+
+	if len(rec.ee) != 1 {
+		t.Fatalf("ProcessMail() expecting 1 event, got %d", len(rec.ee))
+	}
+
+	ev, ok := rec.ee[0].(interface{ Message() *types.MailMessage })
+	if !ok || ev.Message().Subject != "test" {
+		t.Errorf("ProcessMail() expecting mail event with parsed message, got %v", rec.ee[0])
+	}
+
+	if rec.ee[0].EventType() != event.MailOnReceive(nil).EventType() {
+		t.Errorf("ProcessMail() expecting onReceive event, got %s", rec.ee[0].EventType())
+	}
+
+	if err := svc.ProcessMail(context.Background(), strings.NewReader("")); err == nil {
+		t.Errorf("ProcessMail() expecting error on invalid message")
+	}
+}
+
+type (
+	sinkAttachmentRecorder struct {
+		AttachmentService
+		parts []*types.MailMessagePart
+	}
+)
+
+func (r *sinkAttachmentRecorder) With(_ context.Context) AttachmentService {
+	return r
+}
+
+func (r *sinkAttachmentRecorder) CreateMailAttachment(part *types.MailMessagePart, _ map[string]string) (*types.Attachment, error) {
+	r.parts = append(r.parts, part)
+	return &types.Attachment{ID: uint64(len(r.parts)), Name: part.Filename}, nil
+}
+
+func Test_sink_ProcessMailAttachments(t *testing.T) {
+	var (
+		rec = &sinkEventRecorder{}
+		att = &sinkAttachmentRecorder{}
+		svc = &sink{eventbus: rec, attachments: att}
+		msg = "From: sender@example.tld\r\n" +
+			"To: inbox@example.tld\r\n" +
+			"Subject: test\r\n" +
+			"MIME-Version: 1.0\r\n" +
+			"Content-Type: multipart/mixed; boundary=b\r\n" +
+			"\r\n" +
+			"--b\r\n" +
+			"Content-Type: text/plain\r\n" +
+			"\r\n" +
+			"Hello\r\n" +
+			"--b\r\n" +
+			"Content-Type: text/plain\r\n" +
+			"Content-Disposition: attachment; filename=notes.txt\r\n" +
+			"\r\n" +
+			"notes\r\n" +
+			"--b--\r\n"
+	)
+
+	if err := svc.ProcessMail(context.Background(), strings.NewReader(msg)); err != nil {
+		t.Fatalf("ProcessMail() unexpected error: %v", err)
+	}
+
+	if len(att.parts) != 1 || att.parts[0].Filename != "notes.txt" {
+		t.Fatalf("ProcessMail() expecting attachment to be stored, got %v", att.parts)
+	}
+
+	ev, _ := rec.ee[0].(interface{ Message() *types.MailMessage })
+	if aa := ev.Message().Attachments(); len(aa) != 1 || aa[0].AttachmentID != 1 {
+		t.Errorf("ProcessMail() expecting attachment ID on the message part, got %v", aa)
+	}
+}
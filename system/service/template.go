@@ -0,0 +1,526 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	htmlTemplate "html/template"
+	"io"
+	"regexp"
+	textTemplate "text/template"
+
+	"github.com/cortezaproject/corteza-server/pkg/actionlog"
+	internalAuth "github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/pkg/handle"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/system/types"
+)
+
+type (
+	template struct {
+		ac        templateAccessController
+		actionlog actionlog.Recorder
+		store     store.Storer
+
+		// used when template does not exist in the requested language
+		defaultLanguage string
+	}
+
+	templateAccessController interface {
+		CanAccess(context.Context) bool
+		CanCreateTemplate(context.Context) bool
+		CanReadTemplate(context.Context, *types.Template) bool
+		CanUpdateTemplate(context.Context, *types.Template) bool
+		CanDeleteTemplate(context.Context, *types.Template) bool
+		CanRenderTemplate(context.Context, *types.Template) bool
+	}
+
+	TemplateService interface {
+		LookupByID(ctx context.Context, ID uint64) (*types.Template, error)
+		Search(ctx context.Context, f types.TemplateFilter) (types.TemplateSet, types.TemplateFilter, error)
+		Create(ctx context.Context, new *types.Template) (*types.Template, error)
+		Update(ctx context.Context, upd *types.Template) (*types.Template, error)
+		Delete(ctx context.Context, ID uint64) error
+		Undelete(ctx context.Context, ID uint64) error
+
+		Render(ctx context.Context, handle, lang string, vars interface{}) (*types.RenderedTemplate, error)
+		Preview(ctx context.Context, ID uint64, vars map[string]interface{}) (*types.RenderedTemplate, error)
+	}
+
+	// executable template, html/template or text/template
+	templateExecutor interface {
+		Execute(wr io.Writer, data interface{}) error
+	}
+)
+
+const (
+	DefaultTemplateLanguage = "en"
+)
+
+var (
+	validTemplateLanguage = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+)
+
+// Template is a default template service initializer
+func Template(s store.Storer, ac templateAccessController, al actionlog.Recorder) *template {
+	return &template{
+		store:           s,
+		ac:              ac,
+		actionlog:       al,
+		defaultLanguage: DefaultTemplateLanguage,
+	}
+}
+
+func (svc template) LookupByID(ctx context.Context, ID uint64) (tpl *types.Template, err error) {
+	var (
+		taProps = &templateActionProps{template: &types.Template{ID: ID}}
+	)
+
+	err = func() error {
+		if tpl, err = svc.lookupByID(ctx, ID); err != nil {
+			return err
+		}
+
+		taProps.setTemplate(tpl)
+
+		if !svc.ac.CanReadTemplate(ctx, tpl) {
+			return TemplateErrNotAllowedToRead()
+		}
+
+		return nil
+	}()
+
+	return tpl, svc.recordAction(ctx, taProps, TemplateActionLookup, err)
+}
+
+func (svc template) Search(ctx context.Context, tf types.TemplateFilter) (tt types.TemplateSet, f types.TemplateFilter, err error) {
+	var (
+		taProps = &templateActionProps{filter: &tf}
+	)
+
+	// For each fetched item, store backend will check if it is valid or not
+	tf.Check = func(res *types.Template) (bool, error) {
+		if !svc.ac.CanReadTemplate(ctx, res) {
+			return false, nil
+		}
+
+		return true, nil
+	}
+
+	err = func() error {
+		if tf.Deleted > filter.StateExcluded && !svc.ac.CanAccess(ctx) {
+			return TemplateErrNotAllowedToListTemplates()
+		}
+
+		tf.Language = types.NormalizeLanguage(tf.Language)
+
+		if tt, f, err = store.SearchTemplates(ctx, svc.store, tf); err != nil {
+			return err
+		}
+
+		return nil
+	}()
+
+	return tt, f, svc.recordAction(ctx, taProps, TemplateActionSearch, err)
+}
+
+func (svc template) Create(ctx context.Context, new *types.Template) (tpl *types.Template, err error) {
+	var (
+		taProps = &templateActionProps{new: new}
+	)
+
+	err = func() (err error) {
+		if !svc.ac.CanCreateTemplate(ctx) {
+			return TemplateErrNotAllowedToCreate()
+		}
+
+		if err = svc.validate(ctx, new, taProps); err != nil {
+			return
+		}
+
+		new.ID = nextID()
+		new.CreatedAt = *now()
+		new.UpdatedAt = nil
+		new.DeletedAt = nil
+
+		if err = store.CreateTemplate(ctx, svc.store, new); err != nil {
+			return
+		}
+
+		tpl = new
+		return nil
+	}()
+
+	return tpl, svc.recordAction(ctx, taProps, TemplateActionCreate, err)
+}
+
+func (svc template) Update(ctx context.Context, upd *types.Template) (tpl *types.Template, err error) {
+	var (
+		taProps = &templateActionProps{update: upd}
+	)
+
+	err = func() (err error) {
+		if tpl, err = svc.lookupByID(ctx, upd.ID); err != nil {
+			return
+		}
+
+		taProps.setTemplate(tpl)
+
+		if !svc.ac.CanUpdateTemplate(ctx, tpl) {
+			return TemplateErrNotAllowedToUpdate()
+		}
+
+		if err = svc.validate(ctx, upd, taProps); err != nil {
+			return
+		}
+
+		tpl.Handle = upd.Handle
+		tpl.Language = upd.Language
+		tpl.Type = upd.Type
+		tpl.Partial = upd.Partial
+		tpl.Meta = upd.Meta
+		tpl.Template = upd.Template
+		tpl.OwnerID = upd.OwnerID
+		tpl.UpdatedAt = now()
+
+		return store.UpdateTemplate(ctx, svc.store, tpl)
+	}()
+
+	return tpl, svc.recordAction(ctx, taProps, TemplateActionUpdate, err)
+}
+
+func (svc template) Delete(ctx context.Context, ID uint64) (err error) {
+	var (
+		taProps = &templateActionProps{}
+		tpl     *types.Template
+	)
+
+	err = func() (err error) {
+		if tpl, err = svc.lookupByID(ctx, ID); err != nil {
+			return
+		}
+
+		taProps.setTemplate(tpl)
+
+		if !svc.ac.CanDeleteTemplate(ctx, tpl) {
+			return TemplateErrNotAllowedToDelete()
+		}
+
+		tpl.DeletedAt = now()
+		return store.UpdateTemplate(ctx, svc.store, tpl)
+	}()
+
+	return svc.recordAction(ctx, taProps, TemplateActionDelete, err)
+}
+
+func (svc template) Undelete(ctx context.Context, ID uint64) (err error) {
+	var (
+		taProps = &templateActionProps{}
+		tpl     *types.Template
+	)
+
+	err = func() (err error) {
+		if tpl, err = svc.lookupByID(ctx, ID); err != nil {
+			return
+		}
+
+		taProps.setTemplate(tpl)
+
+		if !svc.ac.CanDeleteTemplate(ctx, tpl) {
+			return TemplateErrNotAllowedToUndelete()
+		}
+
+		// restored template must not clash with the one that was created in the meantime
+		if err = svc.uniqueCheck(ctx, tpl, taProps); err != nil {
+			return
+		}
+
+		tpl.DeletedAt = nil
+		return store.UpdateTemplate(ctx, svc.store, tpl)
+	}()
+
+	return svc.recordAction(ctx, taProps, TemplateActionUndelete, err)
+}
+
+// Render renders template in the requested (or fallback) language
+//
+// Preferred language of the current user is used when language is not given.
+// Partials can not be rendered on their own
+func (svc template) Render(ctx context.Context, handle, lang string, vars interface{}) (out *types.RenderedTemplate, err error) {
+	var (
+		taProps = &templateActionProps{template: &types.Template{Handle: handle, Language: lang}}
+		tpl     *types.Template
+	)
+
+	err = func() (err error) {
+		if lang == "" {
+			lang = svc.preferredLanguage(ctx)
+		}
+
+		if tpl, err = svc.resolve(ctx, handle, lang); err != nil {
+			return
+		}
+
+		taProps.setTemplate(tpl)
+
+		if !svc.ac.CanRenderTemplate(ctx, tpl) {
+			return TemplateErrNotAllowedToRender()
+		}
+
+		out, err = svc.exec(ctx, tpl, vars, taProps)
+		return
+	}()
+
+	return out, svc.recordAction(ctx, taProps, TemplateActionRender, err)
+}
+
+// Preview renders template with the given variables
+//
+// Sample variables from template's meta are used when none are given
+func (svc template) Preview(ctx context.Context, ID uint64, vars map[string]interface{}) (out *types.RenderedTemplate, err error) {
+	var (
+		taProps = &templateActionProps{template: &types.Template{ID: ID}}
+		tpl     *types.Template
+	)
+
+	err = func() (err error) {
+		if tpl, err = svc.lookupByID(ctx, ID); err != nil {
+			return
+		}
+
+		taProps.setTemplate(tpl)
+
+		if !svc.ac.CanReadTemplate(ctx, tpl) {
+			return TemplateErrNotAllowedToRead()
+		}
+
+		if len(vars) == 0 {
+			vars = tpl.Meta.Sample
+		}
+
+		out, err = svc.exec(ctx, tpl, vars, taProps)
+		return
+	}()
+
+	return out, svc.recordAction(ctx, taProps, TemplateActionRender, err)
+}
+
+// render renders template without checking access
+//
+// Used by internal services (auth notifications) that
+// send messages on behalf of the system
+func (svc template) render(ctx context.Context, handle, lang string, vars interface{}) (*types.RenderedTemplate, error) {
+	tpl, err := svc.resolve(ctx, handle, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.exec(ctx, tpl, vars, &templateActionProps{template: tpl})
+}
+
+// preferredLanguage returns preferred language of the current user
+func (svc template) preferredLanguage(ctx context.Context) string {
+	userID := internalAuth.GetIdentityFromContext(ctx).Identity()
+	if userID == 0 {
+		return ""
+	}
+
+	u, err := store.LookupUserByID(ctx, svc.store, userID)
+	if err != nil {
+		return ""
+	}
+
+	return u.PreferredLanguage()
+}
+
+func (svc template) lookupByID(ctx context.Context, ID uint64) (*types.Template, error) {
+	if ID == 0 {
+		return nil, TemplateErrInvalidID()
+	}
+
+	tpl, err := store.LookupTemplateByID(ctx, svc.store, ID)
+	if errors.IsNotFound(err) {
+		return nil, TemplateErrNotFound()
+	}
+
+	return tpl, err
+}
+
+// resolve finds non-partial template by handle, trying fallback languages
+func (svc template) resolve(ctx context.Context, handle, lang string) (*types.Template, error) {
+	for _, l := range types.LanguageFallback(lang, svc.defaultLanguage) {
+		tpl, err := store.LookupTemplateByHandleLanguage(ctx, svc.store, handle, l)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		if tpl.Partial {
+			break
+		}
+
+		return tpl, nil
+	}
+
+	return nil, TemplateErrNotFound()
+}
+
+// partials loads partials of the same type, in the language of the template
+//
+// Partial in the most specific language is used when it exists in more than one
+func (svc template) partials(ctx context.Context, tpl *types.Template) (types.TemplateSet, error) {
+	var (
+		langs = types.LanguageFallback(tpl.Language, svc.defaultLanguage)
+		rank  = make(map[string]int)
+		best  = make(map[string]*types.Template)
+		out   = types.TemplateSet{}
+	)
+
+	for i, l := range langs {
+		rank[l] = i
+	}
+
+	pp, _, err := store.SearchTemplates(ctx, svc.store, types.TemplateFilter{
+		Type:    tpl.Type,
+		Partial: filter.StateExclusive,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range pp {
+		r, ok := rank[p.Language]
+		if !ok || p.ID == tpl.ID {
+			continue
+		}
+
+		if b, ok := best[p.Handle]; !ok || r < rank[b.Language] {
+			best[p.Handle] = p
+		}
+	}
+
+	for _, p := range pp {
+		if best[p.Handle] == p {
+			out = append(out, p)
+		}
+	}
+
+	return out, nil
+}
+
+func (svc template) exec(ctx context.Context, tpl *types.Template, vars interface{}, taProps *templateActionProps) (*types.RenderedTemplate, error) {
+	var (
+		buf = &bytes.Buffer{}
+		out = &types.RenderedTemplate{
+			Handle:   tpl.Handle,
+			Language: tpl.Language,
+			Type:     tpl.Type,
+		}
+	)
+
+	pp, err := svc.partials(ctx, tpl)
+	if err != nil {
+		return nil, err
+	}
+
+	x, err := parseTemplate(tpl.Type, tpl.Handle, tpl.Template, pp)
+	if err != nil {
+		return nil, TemplateErrInvalidSyntax(taProps.setDetails(err.Error()))
+	}
+
+	if err = x.Execute(buf, vars); err != nil {
+		return nil, TemplateErrRenderFailed(taProps.setDetails(err.Error()))
+	}
+
+	out.Content = buf.String()
+
+	if tpl.Meta.Subject != "" {
+		if x, err = parseTemplate(types.TemplateTypePlain, "subject", tpl.Meta.Subject, nil); err != nil {
+			return nil, TemplateErrInvalidSyntax(taProps.setDetails(err.Error()))
+		}
+
+		buf.Reset()
+		if err = x.Execute(buf, vars); err != nil {
+			return nil, TemplateErrRenderFailed(taProps.setDetails(err.Error()))
+		}
+
+		out.Subject = buf.String()
+	}
+
+	return out, nil
+}
+
+// validate normalizes and validates template before it is stored
+func (svc template) validate(ctx context.Context, tpl *types.Template, taProps *templateActionProps) error {
+	if tpl.Handle == "" || !handle.IsValid(tpl.Handle) {
+		return TemplateErrInvalidHandle()
+	}
+
+	tpl.Language = types.NormalizeLanguage(tpl.Language)
+	if tpl.Language != "" && !validTemplateLanguage.MatchString(tpl.Language) {
+		return TemplateErrInvalidLanguage()
+	}
+
+	if tpl.Type == "" {
+		tpl.Type = types.TemplateTypeHTML
+	} else if !tpl.Type.IsValid() {
+		return TemplateErrInvalidType()
+	}
+
+	// partials are not known at this point; missing ones
+	// are reported when template is rendered
+	if _, err := parseTemplate(tpl.Type, tpl.Handle, tpl.Template, nil); err != nil {
+		return TemplateErrInvalidSyntax(taProps.setDetails(err.Error()))
+	}
+
+	if _, err := parseTemplate(types.TemplateTypePlain, "subject", tpl.Meta.Subject, nil); err != nil {
+		return TemplateErrInvalidSyntax(taProps.setDetails(err.Error()))
+	}
+
+	return svc.uniqueCheck(ctx, tpl, taProps)
+}
+
+func (svc template) uniqueCheck(ctx context.Context, tpl *types.Template, taProps *templateActionProps) error {
+	if ex, _ := store.LookupTemplateByHandleLanguage(ctx, svc.store, tpl.Handle, tpl.Language); ex != nil && ex.ID > 0 && ex.ID != tpl.ID {
+		return TemplateErrHandleNotUnique()
+	}
+
+	return nil
+}
+
+// parseTemplate parses template source and partials into a single template set
+//
+// HTML templates are contextually escaped (html/template), all other types
+// are parsed as plain text templates
+func parseTemplate(typ types.TemplateType, name, source string, pp types.TemplateSet) (templateExecutor, error) {
+	if typ.IsHTML() {
+		root := htmlTemplate.New(name)
+		for _, p := range pp {
+			if _, err := root.New(p.Handle).Parse(p.Template); err != nil {
+				return nil, err
+			}
+		}
+
+		if _, err := root.Parse(source); err != nil {
+			return nil, err
+		}
+
+		return root, nil
+	}
+
+	root := textTemplate.New(name)
+	for _, p := range pp {
+		if _, err := root.New(p.Handle).Parse(p.Template); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := root.Parse(source); err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
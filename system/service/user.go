@@ -42,8 +42,9 @@ type (
 	}
 
 	userAuth interface {
-		CheckPasswordStrength(string) bool
+		CheckPasswordPolicy(context.Context, uint64, string) error
 		SetPasswordCredentials(context.Context, uint64, string) error
+		Unlock(context.Context, uint64) error
 	}
 
 	userSubscriptionChecker interface {
@@ -85,6 +86,7 @@ type (
 		Delete(id uint64) error
 		Suspend(id uint64) error
 		Unsuspend(id uint64) error
+		Unlock(id uint64) error
 		Undelete(id uint64) error
 
 		SetPassword(userID uint64, password string) error
@@ -257,12 +259,10 @@ func (svc user) FindByAny(ctx context.Context, identifier interface{}) (u *types
 		return
 	}
 
-	rr, _, err := store.SearchRoles(svc.ctx, svc.store, types.RoleFilter{MemberID: u.ID})
-	if err != nil {
+	if err = loadMemberships(svc.ctx, svc.store, u); err != nil {
 		return nil, err
 	}
 
-	u.SetRoles(rr.IDs())
 	return
 }
 
@@ -360,6 +360,8 @@ func (svc user) Find(filter types.UserFilter) (uu types.UserSet, f types.UserFil
 func (svc user) Create(new *types.User) (u *types.User, err error) {
 	var (
 		uaProps = &userActionProps{new: new}
+
+		deferred eventbus.Deferred
 	)
 
 	err = func() (err error) {
@@ -409,16 +411,24 @@ func (svc user) Create(new *types.User) (u *types.User, err error) {
 		// when creating user like this
 		new.EmailConfirmed = true
 
-		if err = store.CreateUser(svc.ctx, svc.store, new); err != nil {
-			return
-		}
+		err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+			if err = store.CreateUser(ctx, s, new); err != nil {
+				return
+			}
+
+			if err = label.Create(ctx, s, new); err != nil {
+				return
+			}
 
-		if err = label.Create(svc.ctx, svc.store, new); err != nil {
+			return deferred.Enqueue(ctx, s, event.UserAfterCreate(new, u))
+		})
+
+		if err != nil {
 			return
 		}
 
-		_ = svc.eventbus.WaitFor(svc.ctx, event.UserAfterCreate(new, u))
-		return
+		_ = deferred.WaitFor(svc.ctx, svc.eventbus)
+		return nil
 	}()
 
 	return new, svc.recordAction(svc.ctx, uaProps, UserActionCreate, err)
@@ -432,6 +442,8 @@ func (svc user) CreateWithAvatar(input *types.User, avatar io.Reader) (out *type
 func (svc user) Update(upd *types.User) (u *types.User, err error) {
 	var (
 		uaProps = &userActionProps{update: upd}
+
+		deferred eventbus.Deferred
 	)
 
 	err = func() (err error) {
@@ -467,28 +479,44 @@ func (svc user) Update(upd *types.User) (u *types.User, err error) {
 		u.Kind = upd.Kind
 		u.UpdatedAt = now()
 
-		if err = svc.eventbus.WaitFor(svc.ctx, event.UserBeforeUpdate(upd, u)); err != nil {
-			return
+		if upd.Meta != nil {
+			if u.Meta == nil {
+				u.Meta = &types.UserMeta{}
+			}
+
+			u.Meta.PreferredLanguage = types.NormalizeLanguage(upd.Meta.PreferredLanguage)
 		}
 
-		if err = uniqueUserCheck(svc.ctx, svc.store, u); err != nil {
+		if err = svc.eventbus.WaitFor(svc.ctx, event.UserBeforeUpdate(upd, u)); err != nil {
 			return
 		}
 
-		if err = store.UpdateUser(svc.ctx, svc.store, u); err != nil {
+		if err = uniqueUserCheck(svc.ctx, svc.store, u); err != nil {
 			return
 		}
 
-		if label.Changed(u.Labels, upd.Labels) {
-			if err = label.Update(svc.ctx, svc.store, upd); err != nil {
+		err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+			if err = store.UpdateUser(ctx, s, u); err != nil {
 				return
 			}
 
-			u.Labels = upd.Labels
+			if label.Changed(u.Labels, upd.Labels) {
+				if err = label.Update(ctx, s, upd); err != nil {
+					return
+				}
+
+				u.Labels = upd.Labels
+			}
+
+			return deferred.Enqueue(ctx, s, event.UserAfterUpdate(upd, u))
+		})
+
+		if err != nil {
+			return
 		}
 
-		_ = svc.eventbus.WaitFor(svc.ctx, event.UserAfterUpdate(upd, u))
-		return
+		_ = deferred.WaitFor(svc.ctx, svc.eventbus)
+		return nil
 	}()
 
 	return u, svc.recordAction(svc.ctx, uaProps, UserActionUpdate, err)
@@ -503,6 +531,8 @@ func (svc user) Delete(userID uint64) (err error) {
 	var (
 		u       *types.User
 		uaProps = &userActionProps{user: &types.User{ID: userID}}
+
+		deferred eventbus.Deferred
 	)
 
 	err = func() (err error) {
@@ -523,11 +553,19 @@ func (svc user) Delete(userID uint64) (err error) {
 		}
 
 		u.DeletedAt = now()
-		if err = store.UpdateUser(svc.ctx, svc.store, u); err != nil {
+		err = store.Tx(svc.ctx, svc.store, func(ctx context.Context, s store.Storer) (err error) {
+			if err = store.UpdateUser(ctx, s, u); err != nil {
+				return
+			}
+
+			return deferred.Enqueue(ctx, s, event.UserAfterDelete(nil, u))
+		})
+
+		if err != nil {
 			return
 		}
 
-		_ = svc.eventbus.WaitFor(svc.ctx, event.UserAfterDelete(nil, u))
+		_ = deferred.WaitFor(svc.ctx, svc.eventbus)
 		return nil
 	}()
 
@@ -636,6 +674,36 @@ func (svc user) Unsuspend(userID uint64) (err error) {
 
 }
 
+// Unlock removes account lock and tracked failed login attempts
+//
+// Unlocking requires the same permission as unsuspending
+func (svc user) Unlock(userID uint64) (err error) {
+	var (
+		u       *types.User
+		uaProps = &userActionProps{user: &types.User{ID: userID}}
+	)
+
+	err = func() (err error) {
+		if userID == 0 {
+			return UserErrInvalidID()
+		}
+
+		if u, err = store.LookupUserByID(svc.ctx, svc.store, userID); err != nil {
+			return
+		}
+
+		uaProps.setUser(u)
+
+		if !svc.ac.CanUnsuspendUser(svc.ctx, u) {
+			return UserErrNotAllowedToUnlock()
+		}
+
+		return svc.auth.Unlock(svc.ctx, userID)
+	}()
+
+	return svc.recordAction(svc.ctx, uaProps, UserActionUnlock, err)
+}
+
 // SetPassword sets new password for a user
 //
 // Expecting setter to have permissions to update modify users and internal authentication enabled
@@ -656,8 +724,8 @@ func (svc user) SetPassword(userID uint64, newPassword string) (err error) {
 			return UserErrNotAllowedToUpdate()
 		}
 
-		if !svc.auth.CheckPasswordStrength(newPassword) {
-			return UserErrPasswordNotSecure()
+		if err := svc.auth.CheckPasswordPolicy(svc.ctx, userID, newPassword); err != nil {
+			return err
 		}
 
 		if err := svc.auth.SetPasswordCredentials(svc.ctx, userID, newPassword); err != nil {
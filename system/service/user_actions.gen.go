@@ -486,6 +486,26 @@ func UserActionUnsuspend(props ...*userActionProps) *userAction {
 	return a
 }
 
+// UserActionUnlock returns "system:user.unlock" action
+//
+// This function is auto-generated.
+//
+func UserActionUnlock(props ...*userActionProps) *userAction {
+	a := &userAction{
+		timestamp: time.Now(),
+		resource:  "system:user",
+		action:    "unlock",
+		log:       "unlocked {user}",
+		severity:  actionlog.Notice,
+	}
+
+	if len(props) > 0 {
+		a.props = props[0]
+	}
+
+	return a
+}
+
 // UserActionSetPassword returns "system:user.setPassword" action
 //
 // This function is auto-generated.
@@ -918,6 +938,38 @@ func UserErrNotAllowedToUnsuspend(mm ...*userActionProps) *errors.Error {
 	return e
 }
 
+// UserErrNotAllowedToUnlock returns "system:user.notAllowedToUnlock" as *errors.Error
+//
+//
+// This function is auto-generated.
+//
+func UserErrNotAllowedToUnlock(mm ...*userActionProps) *errors.Error {
+	var p = &userActionProps{}
+	if len(mm) > 0 {
+		p = mm[0]
+	}
+
+	var e = errors.New(
+		errors.KindInternal,
+
+		p.Format("not allowed to unlock this user", nil),
+
+		errors.Meta("type", "notAllowedToUnlock"),
+		errors.Meta("resource", "system:user"),
+
+		// action log entry; no formatting, it will be applied inside recordAction fn.
+		errors.Meta(userLogMetaKey{}, "failed to unlock {user.handle}; insufficient permissions"),
+		errors.Meta(userPropsMetaKey{}, p),
+
+		errors.StackSkip(1),
+	)
+
+	if len(mm) > 0 {
+	}
+
+	return e
+}
+
 // UserErrHandleNotUnique returns "system:user.handleNotUnique" as *errors.Error
 //
 //
@@ -49,6 +49,39 @@ type (
 
 				// Can users reset their passwords
 				PasswordReset struct{ Enabled bool } `kv:"password-reset"`
+
+				// Protection against brute-force login attempts
+				Lockout struct {
+					// Number of failed attempts (per user or IP) before logins are delayed, 0 disables delays
+					DelayAfter uint `kv:"delay-after"`
+
+					// Number of failed attempts before user account is locked, 0 disables lockout
+					MaxAttempts uint `kv:"max-attempts"`
+
+					// How long (in minutes) account stays locked, 0 locks it until unlocked by an administrator
+					Duration uint
+				} `json:"-"`
+
+				// Requirements for new passwords
+				PasswordPolicy struct {
+					// Minimal number of characters
+					MinLength uint `kv:"min-length"`
+
+					// Character classes that password must contain
+					RequireUpper   bool `kv:"require-upper"`
+					RequireLower   bool `kv:"require-lower"`
+					RequireDigit   bool `kv:"require-digit"`
+					RequireSpecial bool `kv:"require-special"`
+
+					// Number of previous passwords that can not be reused
+					History uint
+
+					// Password expires after given number of days, 0 disables expiration
+					MaxAge uint `kv:"max-age"`
+
+					// Reject passwords from the list of breached passwords
+					CheckBreached bool `kv:"check-breached"`
+				} `kv:"password-policy"`
 			}
 
 			External struct {
@@ -62,6 +62,7 @@ type (
 
 const (
 	AttachmentKindSettings string = "settings"
+	AttachmentKindMail     string = "mail"
 )
 
 func (a *Attachment) SetOriginalImageMeta(width, height int, animated bool) *attachmentFileMeta {
@@ -4,6 +4,7 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/filter"
 	"github.com/jmoiron/sqlx/types"
 	"golang.org/x/crypto/bcrypt"
+	"sort"
 	"time"
 )
 
@@ -22,6 +23,8 @@ type (
 		DeletedAt   *time.Time     `json:"deletedAt,omitempty"`
 	}
 
+	// CredentialsFilter includes deleted credentials by default;
+	// only filter.StateExclusive (deleted only) narrows the search by state
 	CredentialsFilter struct {
 		OwnerID     uint64       `json:"ownerID"`
 		Kind        string       `json:"kind"`
@@ -53,3 +56,35 @@ func (cc CredentialsSet) CompareHashAndPassword(password string) *Credentials {
 
 	return nil
 }
+
+// CompareHashAndPasswordHistory returns true if password matches
+// one of n most recently created credentials, including deleted and expired
+func (cc CredentialsSet) CompareHashAndPasswordHistory(password string, n uint) bool {
+	recent := make(CredentialsSet, len(cc))
+	copy(recent, cc)
+
+	// IDs are increasing; used when credentials were created in the same second
+	sort.Slice(recent, func(i, j int) bool {
+		if recent[i].CreatedAt.Equal(recent[j].CreatedAt) {
+			return recent[i].ID > recent[j].ID
+		}
+
+		return recent[i].CreatedAt.After(recent[j].CreatedAt)
+	})
+
+	if uint(len(recent)) > n {
+		recent = recent[:n]
+	}
+
+	for _, c := range recent {
+		if len(c.Credentials) == 0 {
+			continue
+		}
+
+		if bcrypt.CompareHashAndPassword([]byte(c.Credentials), []byte(password)) == nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"time"
+)
+
+type (
+	// FailedLogin tracks consecutive failed login attempts
+	// for one user ("user:<ID>") or one IP address ("ip:<address>")
+	FailedLogin struct {
+		Key          string     `json:"key"`
+		Failures     uint       `json:"failures"`
+		LastFailedAt time.Time  `json:"lastFailedAt"`
+		LockedAt     *time.Time `json:"lockedAt,omitempty"`
+	}
+)
+
+// Locked returns true if lock is still in effect
+//
+// Zero duration locks until explicitly unlocked
+func (f *FailedLogin) Locked(duration time.Duration, now time.Time) bool {
+	if f == nil || f.LockedAt == nil {
+		return false
+	}
+
+	return duration == 0 || f.LockedAt.Add(duration).After(now)
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"io"
 	"io/ioutil"
 	"net/mail"
@@ -19,8 +20,36 @@ type (
 		// (might contain binary data)
 		RawBody []byte `json:"rawBody,string"`
 
-		// @todo parts
-		// Parts []...
+		// Plain text and HTML body of the message, converted to UTF-8
+		Text string `json:"text"`
+		HTML string `json:"html"`
+
+		// All (non-multipart) parts of the message:
+		// text & html bodies, inline images and attachments
+		Parts []*MailMessagePart `json:"parts"`
+	}
+
+	MailMessagePart struct {
+		ContentType string `json:"contentType"`
+
+		// inline, attachment or empty when not set
+		Disposition string `json:"disposition,omitempty"`
+
+		// Decoded filename of the attachment
+		Filename string `json:"filename,omitempty"`
+
+		// Content-ID without angle brackets, as referenced by "cid:" URLs
+		ContentID string `json:"contentID,omitempty"`
+
+		// Attachments and inline parts of the received messages are
+		// stored as (mail) attachments before they are handled
+		AttachmentID uint64 `json:"attachmentID,string,omitempty"`
+
+		Header mail.Header `json:"header"`
+
+		// Body with content transfer encoding (base64, quoted-printable) removed
+		// will be base64 encoded!
+		Body []byte `json:"body"`
 	}
 
 	MailMessageHeader struct {
@@ -32,6 +61,9 @@ type (
 		ReplyTo []*mail.Address `json:"replyTo"`
 
 		Raw mail.Header `json:"raw"`
+
+		// Raw headers with RFC 2047 encoded-words decoded
+		Decoded mail.Header `json:"decoded"`
 	}
 )
 
@@ -56,9 +88,10 @@ func NewMailMessage(r io.Reader) (out *MailMessage, err error) {
 	out = &MailMessage{}
 
 	out.Header.Raw = msg.Header
+	out.Header.Decoded = decodeMailHeader(msg.Header)
 
 	out.Date, _ = msg.Header.Date()
-	out.Subject = msg.Header.Get("subject")
+	out.Subject = out.Header.Decoded.Get("subject")
 
 	for _, key := range addrKeys {
 		aa, err = msg.Header.AddressList(key)
@@ -87,5 +120,53 @@ func NewMailMessage(r io.Reader) (out *MailMessage, err error) {
 		return
 	}
 
+	out.parseParts()
 	return
 }
+
+// Attachments returns all parts that are sent as attachments
+//
+// Parts with explicit attachment disposition and parts
+// with filename that are not inline are considered attachments
+func (m MailMessage) Attachments() []*MailMessagePart {
+	out := make([]*MailMessagePart, 0)
+	for _, p := range m.Parts {
+		if p.IsAttachment() {
+			out = append(out, p)
+		}
+	}
+
+	return out
+}
+
+// Inline returns all inline parts that can be referenced from the HTML body (cid:...)
+func (m MailMessage) Inline() []*MailMessagePart {
+	out := make([]*MailMessagePart, 0)
+	for _, p := range m.Parts {
+		if p.IsInline() {
+			out = append(out, p)
+		}
+	}
+
+	return out
+}
+
+func (p MailMessagePart) IsAttachment() bool {
+	return p.Disposition == "attachment" || (p.Filename != "" && p.Disposition != "inline")
+}
+
+func (p MailMessagePart) IsInline() bool {
+	return !p.IsAttachment() && p.ContentID != ""
+}
+
+// Size returns size of the decoded part body
+func (p MailMessagePart) Size() int64 {
+	return int64(len(p.Body))
+}
+
+// Open returns decoded body of the part
+//
+// Useful for storing parts as (system or compose) attachments
+func (p MailMessagePart) Open() io.ReadSeeker {
+	return bytes.NewReader(p.Body)
+}
@@ -0,0 +1,211 @@
+package types
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+)
+
+const (
+	// max depth of nested multipart bodies
+	mailMessageMaxPartDepth = 10
+)
+
+var (
+	mailWordDecoder = &mime.WordDecoder{CharsetReader: mailCharsetReader}
+
+	// windows-1252 characters in 0x80-0x9F range;
+	// rest of the charset matches iso-8859-1
+	windows1252 = [32]rune{
+		'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 'Ž', 0x8F,
+		0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9D, 'ž', 'Ÿ',
+	}
+
+	// iso-8859-15 characters that differ from iso-8859-1
+	iso885915 = map[byte]rune{
+		0xA4: '€', 0xA6: 'Š', 0xA8: 'š', 0xB4: 'Ž', 0xB8: 'ž', 0xBC: 'Œ', 0xBD: 'œ', 0xBE: 'Ÿ',
+	}
+)
+
+// parseParts walks through MIME structure of the message body
+//
+// Malformed structure does not fail the message; parts that
+// were parsed are kept and raw body is used as a text
+// when no text or HTML body was found
+func (m *MailMessage) parseParts() {
+	m.Parts = make([]*MailMessagePart, 0)
+
+	err := m.walkPart(textproto.MIMEHeader(m.Header.Raw), bytes.NewReader(m.RawBody), 0)
+	if err != nil && m.Text == "" && m.HTML == "" {
+		m.Text = string(m.RawBody)
+	}
+}
+
+func (m *MailMessage) walkPart(h textproto.MIMEHeader, body io.Reader, depth int) error {
+	ct := h.Get("Content-Type")
+	if ct == "" {
+		ct = "text/plain"
+	}
+
+	mediaType, params, err := mime.ParseMediaType(ct)
+	if err != nil && err != mime.ErrInvalidMediaParameter {
+		mediaType = "application/octet-stream"
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if depth >= mailMessageMaxPartDepth {
+			return fmt.Errorf("too many nested message parts")
+		}
+
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			p, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+
+			if err = m.walkPart(p.Header, p, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	raw, err := ioutil.ReadAll(decodeTransferEncoding(h.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return err
+	}
+
+	part := &MailMessagePart{
+		ContentType: mediaType,
+		ContentID:   strings.Trim(h.Get("Content-ID"), "<> "),
+		Header:      decodeMailHeader(mail.Header(h)),
+		Body:        raw,
+	}
+
+	if disp, dp, err := mime.ParseMediaType(h.Get("Content-Disposition")); err == nil || err == mime.ErrInvalidMediaParameter {
+		part.Disposition = strings.ToLower(disp)
+		part.Filename = dp["filename"]
+	}
+
+	if part.Filename == "" {
+		part.Filename = params["name"]
+	}
+
+	// some clients encode filenames with RFC 2047 instead of RFC 2231
+	part.Filename = decodeMailWords(part.Filename)
+
+	if !part.IsAttachment() {
+		switch mediaType {
+		case "text/plain":
+			if m.Text == "" {
+				m.Text = mailToUTF8(params["charset"], raw)
+			}
+		case "text/html":
+			if m.HTML == "" {
+				m.HTML = mailToUTF8(params["charset"], raw)
+			}
+		}
+	}
+
+	m.Parts = append(m.Parts, part)
+	return nil
+}
+
+func decodeTransferEncoding(enc string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(enc)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	}
+
+	return r
+}
+
+// decodeMailHeader decodes RFC 2047 encoded-words in all header values
+func decodeMailHeader(h mail.Header) mail.Header {
+	out := make(mail.Header, len(h))
+	for k, vv := range h {
+		out[k] = make([]string, len(vv))
+		for i, v := range vv {
+			out[k][i] = decodeMailWords(v)
+		}
+	}
+
+	return out
+}
+
+func decodeMailWords(s string) string {
+	if d, err := mailWordDecoder.DecodeHeader(s); err == nil {
+		return d
+	}
+
+	return s
+}
+
+// mailCharsetReader handles charsets that are not supported by the mime package
+func mailCharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "windows-1252", "cp1252", "iso-8859-15", "latin1":
+		b, err := ioutil.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+
+		return strings.NewReader(mailToUTF8(charset, b)), nil
+	}
+
+	return nil, fmt.Errorf("unhandled charset %q", charset)
+}
+
+// mailToUTF8 converts text from the given charset
+//
+// Only utf-8 and western single-byte charsets are converted,
+// text in other charsets is returned as it is
+func mailToUTF8(charset string, b []byte) string {
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case "iso-8859-1", "latin1":
+		rr := make([]rune, len(b))
+		for i, c := range b {
+			rr[i] = rune(c)
+		}
+
+		return string(rr)
+
+	case "iso-8859-15":
+		rr := make([]rune, len(b))
+		for i, c := range b {
+			if r, ok := iso885915[c]; ok {
+				rr[i] = r
+			} else {
+				rr[i] = rune(c)
+			}
+		}
+
+		return string(rr)
+
+	case "windows-1252", "cp1252":
+		rr := make([]rune, len(b))
+		for i, c := range b {
+			if c >= 0x80 && c <= 0x9F {
+				rr[i] = windows1252[c-0x80]
+			} else {
+				rr[i] = rune(c)
+			}
+		}
+
+		return string(rr)
+	}
+
+	return string(b)
+}
@@ -9,6 +9,13 @@ import (
 )
 
 func Test_mailProcMessage(t *testing.T) {
+	basicsHeader := map[string][]string{
+		"From":       []string{"<sender@testing.cortezaproject.org>"},
+		"To":         []string{"<rcpt@testing.cortezaproject.org>"},
+		"Subject":    []string{"Customer service contact info"},
+		"Message-Id": []string{"<1234@local.machine.example>"},
+	}
+
 	tests := []struct {
 		name    string
 		input   string
@@ -31,14 +38,16 @@ Ola Corteza!
 					From: []*mail.Address{{Address: "sender@testing.cortezaproject.org"}},
 					To:   []*mail.Address{{Address: "rcpt@testing.cortezaproject.org"}},
 
-					Raw: map[string][]string{
-						"From":       []string{"<sender@testing.cortezaproject.org>"},
-						"To":         []string{"<rcpt@testing.cortezaproject.org>"},
-						"Subject":    []string{"Customer service contact info"},
-						"Message-Id": []string{"<1234@local.machine.example>"},
-					},
+					Raw:     basicsHeader,
+					Decoded: basicsHeader,
 				},
 				RawBody: []byte(`Ola Corteza!`),
+				Text:    "Ola Corteza!",
+				Parts: []*MailMessagePart{{
+					ContentType: "text/plain",
+					Header:      basicsHeader,
+					Body:        []byte(`Ola Corteza!`),
+				}},
 			}},
 	}
 
@@ -57,3 +66,85 @@ Ola Corteza!
 		})
 	}
 }
+
+func Test_mailProcMessageParts(t *testing.T) {
+	const input = "From: =?utf-8?q?Jan_Novak?= <sender@testing.cortezaproject.org>\r\n" +
+		"To: <rcpt@testing.cortezaproject.org>\r\n" +
+		"Subject: =?iso-8859-1?q?Ol=E1?= Corteza\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/mixed; boundary=outer\r\n" +
+		"\r\n" +
+		"--outer\r\n" +
+		"Content-Type: multipart/related; boundary=related\r\n" +
+		"\r\n" +
+		"--related\r\n" +
+		"Content-Type: multipart/alternative; boundary=alt\r\n" +
+		"\r\n" +
+		"--alt\r\n" +
+		"Content-Type: text/plain; charset=iso-8859-1\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n" +
+		"\r\n" +
+		"Ol=E1 Corteza!\r\n" +
+		"--alt\r\n" +
+		"Content-Type: text/html; charset=utf-8\r\n" +
+		"\r\n" +
+		"<p>Ola Corteza!</p><img src=\"cid:logo@local\">\r\n" +
+		"--alt--\r\n" +
+		"--related\r\n" +
+		"Content-Type: image/gif\r\n" +
+		"Content-ID: <logo@local>\r\n" +
+		"Content-Disposition: inline\r\n" +
+		"Content-Transfer-Encoding: base64\r\n" +
+		"\r\n" +
+		"R0lGODlhAQABAAAAACw=\r\n" +
+		"--related--\r\n" +
+		"--outer\r\n" +
+		"Content-Type: application/pdf; name=\"=?utf-8?q?ra=C4=8Dun.pdf?=\"\r\n" +
+		"Content-Disposition: attachment; filename*=utf-8''ra%C4%8Dun.pdf\r\n" +
+		"Content-Transfer-Encoding: base64\r\n" +
+		"\r\n" +
+		"JVBERi0xLjQK\r\n" +
+		"--outer--\r\n"
+
+	msg, err := NewMailMessage(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if msg.Subject != "Olá Corteza" {
+		t.Errorf("expecting decoded subject, got %q", msg.Subject)
+	}
+
+	if msg.Header.Decoded.Get("From") != "Jan Novak <sender@testing.cortezaproject.org>" {
+		t.Errorf("expecting decoded from header, got %q", msg.Header.Decoded.Get("From"))
+	}
+
+	if msg.Header.From[0].Name != "Jan Novak" {
+		t.Errorf("expecting decoded from name, got %q", msg.Header.From[0].Name)
+	}
+
+	if msg.Text != "Olá Corteza!" {
+		t.Errorf("expecting text body, got %q", msg.Text)
+	}
+
+	if msg.HTML != `<p>Ola Corteza!</p><img src="cid:logo@local">` {
+		t.Errorf("expecting html body, got %q", msg.HTML)
+	}
+
+	if len(msg.Parts) != 4 {
+		t.Fatalf("expecting 4 parts, got %d", len(msg.Parts))
+	}
+
+	if ii := msg.Inline(); len(ii) != 1 || ii[0].ContentID != "logo@local" || ii[0].ContentType != "image/gif" || ii[0].Size() != 14 {
+		t.Errorf("expecting inline image, got %v", ii)
+	}
+
+	aa := msg.Attachments()
+	if len(aa) != 1 {
+		t.Fatalf("expecting 1 attachment, got %d", len(aa))
+	}
+
+	if aa[0].Filename != "račun.pdf" || aa[0].ContentType != "application/pdf" || string(aa[0].Body) != "%PDF-1.4\n" {
+		t.Errorf("expecting decoded attachment, got %v", aa[0])
+	}
+}
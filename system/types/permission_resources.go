@@ -9,3 +9,4 @@ const ApplicationRBACResource = rbac.Resource("system:application:")
 const OrganisationRBACResource = rbac.Resource("system:organisation:")
 const UserRBACResource = rbac.Resource("system:user:")
 const RoleRBACResource = rbac.Resource("system:role:")
+const TemplateRBACResource = rbac.Resource("system:template:")
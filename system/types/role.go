@@ -14,6 +14,10 @@ type (
 		Name   string `json:"name"`
 		Handle string `json:"handle"`
 
+		// Role inherits rules of the parent role;
+		// members of the role are also considered members of the parent
+		ParentID uint64 `json:"parentID,string,omitempty"`
+
 		Labels map[string]string `json:"labels,omitempty"`
 
 		CreatedAt  time.Time  `json:"createdAt,omitempty"`
@@ -79,3 +83,49 @@ func (set RoleSet) FindByHandle(handle string) *Role {
 
 	return nil
 }
+
+// Ancestors returns all parent roles of the role, closest parent first
+//
+// Roles that are not in the set break the chain;
+// walk stops when cycle is detected.
+func (set RoleSet) Ancestors(roleID uint64) (out RoleSet) {
+	var (
+		visited = map[uint64]bool{roleID: true}
+		r       = set.FindByID(roleID)
+	)
+
+	out = RoleSet{}
+	for r != nil && r.ParentID > 0 && !visited[r.ParentID] {
+		visited[r.ParentID] = true
+
+		if r = set.FindByID(r.ParentID); r != nil {
+			out = append(out, r)
+		}
+	}
+
+	return
+}
+
+// WithAncestors returns IDs of given roles and all of their ancestors
+func (set RoleSet) WithAncestors(roleIDs ...uint64) []uint64 {
+	var (
+		out  = make([]uint64, 0, len(roleIDs))
+		seen = make(map[uint64]bool)
+	)
+
+	for _, roleID := range roleIDs {
+		if !seen[roleID] {
+			seen[roleID] = true
+			out = append(out, roleID)
+		}
+
+		for _, a := range set.Ancestors(roleID) {
+			if !seen[a.ID] {
+				seen[a.ID] = true
+				out = append(out, a.ID)
+			}
+		}
+	}
+
+	return out
+}
@@ -1,13 +1,52 @@
 package types
 
+import (
+	"time"
+)
+
 type (
 	RoleMember struct {
-		RoleID uint64
-		UserID uint64
+		RoleID uint64 `json:"roleID,string"`
+		UserID uint64 `json:"userID,string"`
+
+		// Membership is valid from/until given time;
+		// nil means no restriction
+		ValidFrom  *time.Time `json:"validFrom,omitempty"`
+		ValidUntil *time.Time `json:"validUntil,omitempty"`
 	}
 
 	RoleMemberFilter struct {
 		RoleID uint64
 		UserID uint64
+
+		// Only memberships that are valid at the moment
+		ValidOnly bool
 	}
 )
+
+// IsValidAt checks if membership is valid at the given time
+func (m RoleMember) IsValidAt(t time.Time) bool {
+	if m.ValidFrom != nil && m.ValidFrom.After(t) {
+		return false
+	}
+
+	if m.ValidUntil != nil && !m.ValidUntil.After(t) {
+		return false
+	}
+
+	return true
+}
+
+// NextChange returns the earliest time after t when one of the memberships
+// becomes valid or expires
+func (set RoleMemberSet) NextChange(t time.Time) (next *time.Time) {
+	for _, m := range set {
+		for _, c := range []*time.Time{m.ValidFrom, m.ValidUntil} {
+			if c != nil && c.After(t) && (next == nil || c.Before(*next)) {
+				next = c
+			}
+		}
+	}
+
+	return
+}
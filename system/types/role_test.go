@@ -0,0 +1,50 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleSet_WithAncestors(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		set = RoleSet{
+			{ID: 1},
+			{ID: 2, ParentID: 1},
+			{ID: 3, ParentID: 2},
+			{ID: 4, ParentID: 42},
+
+			// cycle
+			{ID: 5, ParentID: 6},
+			{ID: 6, ParentID: 5},
+		}
+	)
+
+	req.Equal([]uint64{3, 2, 1}, set.WithAncestors(3))
+	req.Equal([]uint64{2, 1, 3}, set.WithAncestors(2, 3))
+	req.Equal([]uint64{4}, set.WithAncestors(4))
+	req.Equal([]uint64{5, 6}, set.WithAncestors(5))
+	req.Equal([]uint64{42}, set.WithAncestors(42))
+}
+
+func TestRoleMemberSet_NextChange(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		now  = time.Now()
+		past = now.Add(-time.Hour)
+		soon = now.Add(time.Minute)
+		next = now.Add(time.Hour)
+	)
+
+	req.Nil(RoleMemberSet{{}}.NextChange(now))
+	req.Nil(RoleMemberSet{{ValidUntil: &past}}.NextChange(now))
+	req.Equal(&soon, RoleMemberSet{{ValidUntil: &next}, {ValidFrom: &soon}}.NextChange(now))
+
+	req.False(RoleMember{ValidUntil: &past}.IsValidAt(now))
+	req.False(RoleMember{ValidFrom: &soon}.IsValidAt(now))
+	req.True(RoleMember{ValidFrom: &past, ValidUntil: &soon}.IsValidAt(now))
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"path"
 	"strings"
 	"time"
 
@@ -21,6 +22,9 @@ type (
 		// Who updated & when
 		UpdatedAt time.Time `json:"updatedAt"`
 		UpdatedBy uint64    `json:"updatedBy"`
+
+		// Set on secret settings; not stored
+		Secret bool `json:"secret,omitempty"`
 	}
 
 	SettingsFilter struct {
@@ -39,8 +43,38 @@ type (
 
 const (
 	settingsFilterPerPageMax = 100
+
+	// SecretSettingMask replaces values of secret settings on read
+	//
+	// Secret setting is not changed when mask is set as its new value
+	SecretSettingMask = "********"
+)
+
+var (
+	// SecretSettings holds patterns (see path.Match) of setting names
+	// that are encrypted at rest and masked on read
+	//
+	// Besides external auth secrets this covers passwords
+	// and tokens (SMTP, corredor, ...) when stored as settings
+	SecretSettings = []string{
+		"auth.external.session-store-secret",
+		"*.secret",
+		"*.password",
+		"*.token",
+	}
 )
 
+// IsSecretSetting returns true if setting with the given name is a secret
+func IsSecretSetting(name string) bool {
+	for _, p := range SecretSettings {
+		if m, _ := path.Match(p, name); m {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (v *SettingValue) SetRawValue(str string) error {
 	var dummy interface{}
 	// Test input to be sure we can save it...
@@ -82,6 +116,26 @@ func (v *SettingValue) Bool() (out bool) {
 	return
 }
 
+// Mask replaces non-empty value of a secret setting with SecretSettingMask
+func (v *SettingValue) Mask() {
+	if !IsSecretSetting(v.Name) {
+		return
+	}
+
+	v.Secret = true
+	switch strings.TrimSpace(string(v.Value)) {
+	case "", `""`, "null":
+		// nothing to hide
+	default:
+		_ = v.SetValue(SecretSettingMask)
+	}
+}
+
+// IsMasked returns true if value of a secret setting is the mask
+func (v *SettingValue) IsMasked() bool {
+	return IsSecretSetting(v.Name) && v.String() == SecretSettingMask
+}
+
 func (v *SettingValue) NormalizeValue() {
 
 }
@@ -110,3 +110,34 @@ func TestSettingValueSet_Changed(t *testing.T) {
 	req.Equal("", out.First("d").String())
 	req.Equal(false, out.First("bool").Bool())
 }
+
+func TestSettingValue_Mask(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		mask = func(name, raw string) *SettingValue {
+			v := &SettingValue{Name: name, Value: types.JSONText(raw)}
+			v.Mask()
+			return v
+		}
+	)
+
+	req.True(IsSecretSetting("auth.external.providers.github.secret"))
+	req.True(IsSecretSetting("auth.external.providers.openid-connect.foo.secret"))
+	req.True(IsSecretSetting("auth.external.session-store-secret"))
+	req.False(IsSecretSetting("auth.external.providers.github.key"))
+	req.False(IsSecretSetting("auth.internal.password-reset.enabled"))
+
+	v := mask("auth.external.providers.github.secret", `"s3cr3t"`)
+	req.True(v.Secret)
+	req.True(v.IsMasked())
+	req.Equal(SecretSettingMask, v.String())
+
+	v = mask("auth.external.providers.github.secret", `""`)
+	req.True(v.Secret)
+	req.False(v.IsMasked())
+
+	v = mask("auth.external.providers.github.key", `"key"`)
+	req.False(v.Secret)
+	req.Equal("key", v.String())
+}
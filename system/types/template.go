@@ -0,0 +1,169 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/filter"
+	"github.com/cortezaproject/corteza-server/pkg/rbac"
+	"github.com/pkg/errors"
+)
+
+type (
+	// Template is a named, localized document template
+	//
+	// Templates with the same handle can exist in multiple languages.
+	// Partials are not rendered on their own, they are included into
+	// other templates of the same type with {{template "handle" .}}
+	Template struct {
+		ID       uint64       `json:"templateID,string"`
+		Handle   string       `json:"handle"`
+		Language string       `json:"language"`
+		Type     TemplateType `json:"type"`
+		Partial  bool         `json:"partial"`
+		Meta     TemplateMeta `json:"meta"`
+		Template string       `json:"template"`
+
+		OwnerID uint64 `json:"ownerID,string"`
+
+		CreatedAt time.Time  `json:"createdAt,omitempty"`
+		UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+		DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	}
+
+	TemplateMeta struct {
+		Short       string `json:"short"`
+		Description string `json:"description"`
+
+		// Subject is rendered as a plain text template
+		// and used as a subject when template is sent as an email
+		Subject string `json:"subject,omitempty"`
+
+		// Sample variables for rendering the preview
+		Sample map[string]interface{} `json:"sample,omitempty"`
+	}
+
+	TemplateType string
+
+	// RenderedTemplate holds the output of the rendered template
+	RenderedTemplate struct {
+		Handle   string       `json:"handle"`
+		Language string       `json:"language"`
+		Type     TemplateType `json:"type"`
+		Subject  string       `json:"subject,omitempty"`
+		Content  string       `json:"content"`
+	}
+
+	TemplateFilter struct {
+		TemplateID []uint64     `json:"templateID"`
+		Handle     string       `json:"handle"`
+		Language   string       `json:"language"`
+		Type       TemplateType `json:"type"`
+		OwnerID    uint64       `json:"ownerID,string"`
+		Query      string       `json:"query"`
+
+		Partial filter.State `json:"partial"`
+		Deleted filter.State `json:"deleted"`
+
+		// Check fn is called by store backend for each resource found function can
+		// modify the resource and return false if store should not return it
+		//
+		// Store then loads additional resources to satisfy the paging parameters
+		Check func(*Template) (bool, error) `json:"-"`
+
+		// Standard helpers for paging and sorting
+		filter.Sorting
+		filter.Paging
+	}
+)
+
+const (
+	TemplateTypeHTML  TemplateType = "text/html"
+	TemplateTypePlain TemplateType = "text/plain"
+)
+
+// IsValid checks if template type is supported
+func (t TemplateType) IsValid() bool {
+	switch t {
+	case TemplateTypeHTML, TemplateTypePlain:
+		return true
+	}
+
+	return false
+}
+
+// IsHTML checks if template output should be HTML escaped
+func (t TemplateType) IsHTML() bool {
+	return t == TemplateTypeHTML
+}
+
+// Resource returns a resource ID for this type
+func (t Template) RBACResource() rbac.Resource {
+	return TemplateRBACResource.AppendID(t.ID)
+}
+
+func (t *Template) DynamicRoles(userID uint64) []uint64 {
+	return nil
+}
+
+// NormalizeLanguage converts language tag to the form templates are stored under
+//
+// Tags are lowercase with dash as a separator (en_US => en-us)
+func NormalizeLanguage(lang string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(lang), "_", "-"))
+}
+
+// LanguageFallback returns languages that are tried when looking
+// up a template for the given language, most specific first
+//
+// Region & script subtags are removed one by one (sr-latn-rs => sr-latn => sr);
+// default language and language-neutral ("") templates are tried last
+func LanguageFallback(lang, def string) []string {
+	var (
+		ll   = make([]string, 0, 4)
+		seen = make(map[string]bool)
+		add  = func(l string) {
+			if !seen[l] {
+				seen[l] = true
+				ll = append(ll, l)
+			}
+		}
+	)
+
+	for lang = NormalizeLanguage(lang); lang != ""; {
+		add(lang)
+		if i := strings.LastIndexByte(lang, '-'); i > 0 {
+			lang = lang[:i]
+		} else {
+			break
+		}
+	}
+
+	if def = NormalizeLanguage(def); def != "" {
+		add(def)
+	}
+
+	add("")
+	return ll
+}
+
+func (meta *TemplateMeta) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*meta = TemplateMeta{}
+		return nil
+	case []uint8:
+		if err := json.Unmarshal(value.([]byte), meta); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into Template.Meta", value)
+		}
+		return nil
+	}
+	return errors.Errorf("Template.Meta: unknown type %T, expected []uint8", value)
+}
+
+func (meta TemplateMeta) Value() (driver.Value, error) {
+	return json.Marshal(meta)
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguageFallback(t *testing.T) {
+	tests := []struct {
+		lang, def string
+		want      []string
+	}{
+		{"", "en", []string{"en", ""}},
+		{"de", "en", []string{"de", "en", ""}},
+		{"de_AT", "en", []string{"de-at", "de", "en", ""}},
+		{"sr-Latn-RS", "en", []string{"sr-latn-rs", "sr-latn", "sr", "en", ""}},
+		{"en-GB", "en", []string{"en-gb", "en", ""}},
+		{"de", "", []string{"de", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			require.Equal(t, tt.want, LanguageFallback(tt.lang, tt.def))
+		})
+	}
+}
@@ -25,6 +25,11 @@ type (
 	// This type is auto-generated.
 	CredentialsSet []*Credentials
 
+	// FailedLoginSet slice of FailedLogin
+	//
+	// This type is auto-generated.
+	FailedLoginSet []*FailedLogin
+
 	// ReminderSet slice of Reminder
 	//
 	// This type is auto-generated.
@@ -45,6 +50,11 @@ type (
 	// This type is auto-generated.
 	SettingValueSet []*SettingValue
 
+	// TemplateSet slice of Template
+	//
+	// This type is auto-generated.
+	TemplateSet []*Template
+
 	// UserSet slice of User
 	//
 	// This type is auto-generated.
@@ -219,6 +229,36 @@ func (set CredentialsSet) IDs() (IDs []uint64) {
 	return
 }
 
+// Walk iterates through every slice item and calls w(FailedLogin) err
+//
+// This function is auto-generated.
+func (set FailedLoginSet) Walk(w func(*FailedLogin) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(FailedLogin) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set FailedLoginSet) Filter(f func(*FailedLogin) (bool, error)) (out FailedLoginSet, err error) {
+	var ok bool
+	out = FailedLoginSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
 // Walk iterates through every slice item and calls w(Reminder) err
 //
 // This function is auto-generated.
@@ -391,6 +431,62 @@ func (set SettingValueSet) Filter(f func(*SettingValue) (bool, error)) (out Sett
 	return
 }
 
+// Walk iterates through every slice item and calls w(Template) err
+//
+// This function is auto-generated.
+func (set TemplateSet) Walk(w func(*Template) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(Template) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set TemplateSet) Filter(f func(*Template) (bool, error)) (out TemplateSet, err error) {
+	var ok bool
+	out = TemplateSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
+// FindByID finds items from slice by its ID property
+//
+// This function is auto-generated.
+func (set TemplateSet) FindByID(ID uint64) *Template {
+	for i := range set {
+		if set[i].ID == ID {
+			return set[i]
+		}
+	}
+
+	return nil
+}
+
+// IDs returns a slice of uint64s from all items in the set
+//
+// This function is auto-generated.
+func (set TemplateSet) IDs() (IDs []uint64) {
+	IDs = make([]uint64, len(set))
+
+	for i := range set {
+		IDs[i] = set[i].ID
+	}
+
+	return
+}
+
 // Walk iterates through every slice item and calls w(User) err
 //
 // This function is auto-generated.
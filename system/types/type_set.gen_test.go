@@ -284,6 +284,62 @@ func TestCredentialsSetIDs(t *testing.T) {
 	}
 }
 
+func TestFailedLoginSetWalk(t *testing.T) {
+	var (
+		value = make(FailedLoginSet, 3)
+		req   = require.New(t)
+	)
+
+	// check walk with no errors
+	{
+		err := value.Walk(func(*FailedLogin) error {
+			return nil
+		})
+		req.NoError(err)
+	}
+
+	// check walk with error
+	req.Error(value.Walk(func(*FailedLogin) error { return fmt.Errorf("walk error") }))
+}
+
+func TestFailedLoginSetFilter(t *testing.T) {
+	var (
+		value = make(FailedLoginSet, 3)
+		req   = require.New(t)
+	)
+
+	// filter nothing
+	{
+		set, err := value.Filter(func(*FailedLogin) (bool, error) {
+			return true, nil
+		})
+		req.NoError(err)
+		req.Equal(len(set), len(value))
+	}
+
+	// filter one item
+	{
+		found := false
+		set, err := value.Filter(func(*FailedLogin) (bool, error) {
+			if !found {
+				found = true
+				return found, nil
+			}
+			return false, nil
+		})
+		req.NoError(err)
+		req.Len(set, 1)
+	}
+
+	// filter error
+	{
+		_, err := value.Filter(func(*FailedLogin) (bool, error) {
+			return false, fmt.Errorf("filter error")
+		})
+		req.Error(err)
+	}
+}
+
 func TestReminderSetWalk(t *testing.T) {
 	var (
 		value = make(ReminderSet, 3)
@@ -576,6 +632,96 @@ func TestSettingValueSetFilter(t *testing.T) {
 	}
 }
 
+func TestTemplateSetWalk(t *testing.T) {
+	var (
+		value = make(TemplateSet, 3)
+		req   = require.New(t)
+	)
+
+	// check walk with no errors
+	{
+		err := value.Walk(func(*Template) error {
+			return nil
+		})
+		req.NoError(err)
+	}
+
+	// check walk with error
+	req.Error(value.Walk(func(*Template) error { return fmt.Errorf("walk error") }))
+}
+
+func TestTemplateSetFilter(t *testing.T) {
+	var (
+		value = make(TemplateSet, 3)
+		req   = require.New(t)
+	)
+
+	// filter nothing
+	{
+		set, err := value.Filter(func(*Template) (bool, error) {
+			return true, nil
+		})
+		req.NoError(err)
+		req.Equal(len(set), len(value))
+	}
+
+	// filter one item
+	{
+		found := false
+		set, err := value.Filter(func(*Template) (bool, error) {
+			if !found {
+				found = true
+				return found, nil
+			}
+			return false, nil
+		})
+		req.NoError(err)
+		req.Len(set, 1)
+	}
+
+	// filter error
+	{
+		_, err := value.Filter(func(*Template) (bool, error) {
+			return false, fmt.Errorf("filter error")
+		})
+		req.Error(err)
+	}
+}
+
+func TestTemplateSetIDs(t *testing.T) {
+	var (
+		value = make(TemplateSet, 3)
+		req   = require.New(t)
+	)
+
+	// construct objects
+	value[0] = new(Template)
+	value[1] = new(Template)
+	value[2] = new(Template)
+	// set ids
+	value[0].ID = 1
+	value[1].ID = 2
+	value[2].ID = 3
+
+	// Find existing
+	{
+		val := value.FindByID(2)
+		req.Equal(uint64(2), val.ID)
+	}
+
+	// Find non-existing
+	{
+		val := value.FindByID(4)
+		req.Nil(val)
+	}
+
+	// List IDs from set
+	{
+		val := value.IDs()
+		req.Equal(len(val), len(value))
+	}
+}
+
 func TestUserSetWalk(t *testing.T) {
 	var (
 		value = make(UserSet, 3)
@@ -37,10 +37,16 @@ type (
 		// we're using this for auth/identifier purposes, to support Roles() func
 		// that satisfies Identifiable interface
 		roles []uint64
+
+		// Time when one of the time-bound memberships starts or ends
+		rolesValidUntil *time.Time
 	}
 
 	UserMeta struct {
 		Avatar string `json:"avatar,omitempty"`
+
+		// Language used for notifications and other rendered templates
+		PreferredLanguage string `json:"preferredLanguage,omitempty"`
 	}
 
 	UserFilter struct {
@@ -111,6 +117,17 @@ func (u *User) SetRoles(rr []uint64) {
 	u.roles = rr
 }
 
+// RolesValidUntil returns time until roles of the user are valid
+//
+// Nil when none of the memberships is time-bound
+func (u User) RolesValidUntil() *time.Time {
+	return u.rolesValidUntil
+}
+
+func (u *User) SetRolesValidUntil(t *time.Time) {
+	u.rolesValidUntil = t
+}
+
 // Resource returns a resource ID for this type
 func (u *User) RBACResource() rbac.Resource {
 	return UserRBACResource.AppendID(u.ID)
@@ -120,6 +137,15 @@ func (u *User) DynamicRoles(userID uint64) []uint64 {
 	return nil
 }
 
+// PreferredLanguage returns user's preferred language or an empty string if not set
+func (u *User) PreferredLanguage() string {
+	if u == nil || u.Meta == nil {
+		return ""
+	}
+
+	return u.Meta.PreferredLanguage
+}
+
 func (meta *UserMeta) Scan(value interface{}) error {
 	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
 	switch value.(type) {
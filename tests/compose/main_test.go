@@ -68,7 +68,7 @@ func InitTestApp() {
 
 	if r == nil {
 		r = chi.NewRouter()
-		r.Use(server.BaseMiddleware(false, logger.Default())...)
+		r.Use(server.BaseMiddleware(false, logger.Default(), nil)...)
 		helpers.BindAuthMiddleware(r)
 		rest.MountRoutes(r)
 	}
@@ -366,18 +366,31 @@ func TestModuleFieldsUpdateExpressions(t *testing.T) {
 	h.a.True(f.Expressions.DisableDefaultFormatters)
 }
 
-func TestModuleFieldsPreventUpdate_ifRecordExists(t *testing.T) {
+func TestModuleFieldsUpdate_ifRecordExists(t *testing.T) {
 	h := newHelper(t)
 	h.clearModules()
 
 	h.allow(types.NamespaceRBACResource.AppendWildcard(), "read")
 	ns := h.makeNamespace("some-namespace")
 	m := h.makeModule(ns, "some-module", &types.ModuleField{ID: id.Next(), Kind: "String", Name: "existing"})
-	h.makeRecord(m, &types.RecordValue{Name: "existing", Value: "value"})
+	valid := h.makeRecord(m, &types.RecordValue{Name: "existing", Value: "42"})
+	invalid := h.makeRecord(m, &types.RecordValue{Name: "existing", Value: "value"})
 	h.allow(types.ModuleRBACResource.AppendWildcard(), "update")
 
 	f := m.Fields[0]
 	fjs := fmt.Sprintf(`{ "name": "%s", "fields": [{ "fieldID": "%d", "name": "existing_edited", "kind": "Number" }, { "name": "new", "kind": "DateTime" }] }`, m.Name, f.ID)
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d", ns.ID, m.ID)).
+		Header("Accept", "application/json").
+		JSON(fjs).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("1 record value(s) can not be converted to the new field kind")).
+		End()
+
+	h.a.Equal("existing", h.lookupModuleByID(m.ID).Fields[0].Name)
+
+	fjs = fmt.Sprintf(`{ "name": "%s", "acceptLosses": true, "fields": [{ "fieldID": "%d", "name": "existing_edited", "kind": "Number" }, { "name": "new", "kind": "DateTime" }] }`, m.Name, f.ID)
 	h.apiInit().
 		Post(fmt.Sprintf("/namespace/%d/module/%d", ns.ID, m.ID)).
 		JSON(fjs).
@@ -392,11 +405,47 @@ func TestModuleFieldsPreventUpdate_ifRecordExists(t *testing.T) {
 	h.a.Len(m.Fields, 2)
 
 	h.a.NotNil(m.Fields[0].UpdatedAt)
-	h.a.Equal(m.Fields[0].Name, "existing")
-	h.a.Equal(m.Fields[0].Kind, "String")
+	h.a.Equal(m.Fields[0].Name, "existing_edited")
+	h.a.Equal(m.Fields[0].Kind, "Number")
 	h.a.Nil(m.Fields[1].UpdatedAt)
 	h.a.Equal(m.Fields[1].Name, "new")
 	h.a.Equal(m.Fields[1].Kind, "DateTime")
+
+	rec := h.lookupRecordByID(m, valid.ID)
+	h.a.NotNil(rec.Values.Get("existing_edited", 0))
+	h.a.Equal("42", rec.Values.Get("existing_edited", 0).Value)
+	h.a.Nil(rec.Values.Get("existing", 0))
+
+	rec = h.lookupRecordByID(m, invalid.ID)
+	h.a.Empty(rec.Values.GetClean())
+}
+
+func TestModuleFieldsUpdateDryRun(t *testing.T) {
+	h := newHelper(t)
+	h.clearModules()
+
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "read")
+	ns := h.makeNamespace("some-namespace")
+	m := h.makeModule(ns, "some-module", &types.ModuleField{ID: id.Next(), Kind: "String", Name: "existing"})
+	h.makeRecord(m, &types.RecordValue{Name: "existing", Value: "42"})
+	h.makeRecord(m, &types.RecordValue{Name: "existing", Value: "value"})
+	h.allow(types.ModuleRBACResource.AppendWildcard(), "update")
+
+	f := m.Fields[0]
+	fjs := fmt.Sprintf(`{ "name": "%s", "dryRun": true, "fields": [{ "fieldID": "%d", "name": "existing", "kind": "Number" }] }`, m.Name, f.ID)
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d", ns.ID, m.ID)).
+		JSON(fjs).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 1)).
+		Assert(jsonpath.Equal(`$.response.set[0].kind`, "invalidConversion")).
+		Assert(jsonpath.Equal(`$.response.set[0].meta.value`, "value")).
+		End()
+
+	m = h.lookupModuleByID(m.ID)
+	h.a.Equal(m.Fields[0].Kind, "String")
 }
 
 func TestModuleDeleteForbidden(t *testing.T) {
@@ -7,12 +7,17 @@ import (
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/id"
 	"github.com/cortezaproject/corteza-server/pkg/rand"
+	"github.com/cortezaproject/corteza-server/pkg/rbac"
 	"github.com/cortezaproject/corteza-server/store"
 	"github.com/cortezaproject/corteza-server/tests/helpers"
+	"github.com/steinfletcher/apitest"
 	jsonpath "github.com/steinfletcher/apitest-jsonpath"
 	"github.com/stretchr/testify/require"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -280,3 +285,237 @@ func TestNamespaceLabels(t *testing.T) {
 		req.NotNil(set.FindByID(ID).Labels)
 	})
 }
+
+func TestNamespaceCloneForbidden(t *testing.T) {
+	h := newHelper(t)
+	h.clearNamespaces()
+
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "read")
+	h.deny(types.ComposeRBACResource, "namespace.create")
+
+	ns := h.makeNamespace("some-namespace")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/clone", ns.ID)).
+		Header("Accept", "application/json").
+		FormData("slug", "some-namespace-copy").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to create namespaces")).
+		End()
+}
+
+func TestNamespaceClone(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+	h.clearPages()
+	h.clearCharts()
+
+	h.allow(types.ComposeRBACResource, "namespace.create")
+	h.allow(types.ComposeRBACResource, "grant")
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "read")
+
+	var (
+		ctx = context.Background()
+		s   = service.DefaultStore
+
+		ns   = h.makeNamespace("clone-source")
+		modA = h.createModule(&types.Module{
+			Handle:      "a",
+			Name:        "A",
+			NamespaceID: ns.ID,
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "name", Kind: "String"},
+				&types.ModuleField{Name: "file", Kind: "File"},
+			},
+		})
+		modB = h.createModule(&types.Module{
+			Handle:      "b",
+			Name:        "B",
+			NamespaceID: ns.ID,
+			Fields: types.ModuleFieldSet{&types.ModuleField{
+				Name:    "a",
+				Kind:    "Record",
+				Options: types.ModuleFieldOptions{"moduleID": strconv.FormatUint(modA.ID, 10)},
+			}},
+		})
+
+		att = &types.Attachment{
+			ID:          id.Next(),
+			Kind:        types.RecordAttachment,
+			Name:        "file.txt",
+			NamespaceID: ns.ID,
+			CreatedAt:   time.Now(),
+		}
+
+		recA = h.makeRecord(modA,
+			&types.RecordValue{Name: "name", Value: "foo"},
+			&types.RecordValue{Name: "file", Value: strconv.FormatUint(att.ID, 10), Ref: att.ID},
+		)
+		recB = h.makeRecord(modB, &types.RecordValue{Name: "a", Value: strconv.FormatUint(recA.ID, 10), Ref: recA.ID})
+
+		chart = &types.Chart{ID: id.Next(), Handle: "chart", NamespaceID: ns.ID, CreatedAt: time.Now()}
+		page  = &types.Page{
+			ID:          id.Next(),
+			Handle:      "page",
+			NamespaceID: ns.ID,
+			ModuleID:    modA.ID,
+			CreatedAt:   time.Now(),
+			Blocks: types.PageBlocks{
+				{Kind: "RecordList", Options: map[string]interface{}{"moduleID": strconv.FormatUint(modB.ID, 10)}},
+				{Kind: "Chart", Options: map[string]interface{}{"chartID": strconv.FormatUint(chart.ID, 10)}},
+			},
+		}
+	)
+
+	att.Meta.Original.Extension = "txt"
+	att.Url = service.DefaultObjectStore.Original(att.ID, "txt")
+	h.noError(service.DefaultObjectStore.Save(att.Url, strings.NewReader("file content")))
+	h.noError(store.CreateComposeAttachment(ctx, s, att))
+
+	chart.Config.Reports = []*types.ChartConfigReport{{ModuleID: modB.ID}}
+	h.noError(store.CreateComposeChart(ctx, s, chart))
+	h.noError(store.CreateComposePage(ctx, s, page))
+	h.allow(types.ModuleRBACResource.AppendWildcard(), "read")
+	h.allow(modA.RBACResource(), "record.read")
+	h.allow(modB.RBACResource(), "record.read")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/clone", ns.ID)).
+		Header("Accept", "application/json").
+		FormData("name", "Clone").
+		FormData("slug", "clone-target").
+		FormData("records", "true").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.name`, "Clone")).
+		Assert(jsonpath.Equal(`$.response.slug`, "clone-target")).
+		End()
+
+	dup, err := store.LookupComposeNamespaceBySlug(ctx, s, "clone-target")
+	h.noError(err)
+	h.a.NotEqual(ns.ID, dup.ID)
+
+	dupA, err := store.LookupComposeModuleByNamespaceIDHandle(ctx, s, dup.ID, "a")
+	h.noError(err)
+	dupB, err := store.LookupComposeModuleByNamespaceIDHandle(ctx, s, dup.ID, "b")
+	h.noError(err)
+	dupB.Fields, _, err = store.SearchComposeModuleFields(ctx, s, types.ModuleFieldFilter{ModuleID: []uint64{dupB.ID}})
+	h.noError(err)
+	h.a.Len(dupB.Fields, 1)
+	h.a.NotEqual(modB.Fields[0].ID, dupB.Fields[0].ID)
+	h.a.Equal(strconv.FormatUint(dupA.ID, 10), dupB.Fields[0].Options.String("moduleID"))
+
+	dupChart, err := store.LookupComposeChartByNamespaceIDHandle(ctx, s, dup.ID, "chart")
+	h.noError(err)
+	h.a.Equal(dupB.ID, dupChart.Config.Reports[0].ModuleID)
+
+	dupPage, err := store.LookupComposePageByNamespaceIDHandle(ctx, s, dup.ID, "page")
+	h.noError(err)
+	h.a.Equal(dupA.ID, dupPage.ModuleID)
+	h.a.Equal(strconv.FormatUint(dupB.ID, 10), dupPage.Blocks[0].Options["moduleID"])
+	h.a.Equal(strconv.FormatUint(dupChart.ID, 10), dupPage.Blocks[1].Options["chartID"])
+
+	rr, _, err := store.SearchComposeRecords(ctx, s, dupA, types.RecordFilter{ModuleID: dupA.ID})
+	h.noError(err)
+	h.a.Len(rr, 1)
+	h.a.NotEqual(recA.ID, rr[0].ID)
+	h.a.Equal("foo", rr[0].Values.Get("name", 0).Value)
+	dupRecA := rr[0]
+
+	dupAtt, err := store.LookupComposeAttachmentByID(ctx, s, dupRecA.Values.Get("file", 0).Ref)
+	h.noError(err)
+	h.a.NotEqual(att.ID, dupAtt.ID)
+	h.a.Equal(dup.ID, dupAtt.NamespaceID)
+	h.a.Equal(strconv.FormatUint(dupAtt.ID, 10), dupRecA.Values.Get("file", 0).Value)
+	fh, err := service.DefaultObjectStore.Open(dupAtt.Url)
+	h.noError(err)
+	content, err := ioutil.ReadAll(fh)
+	h.noError(err)
+	h.a.Equal("file content", string(content))
+
+	rr, _, err = store.SearchComposeRecords(ctx, s, dupB, types.RecordFilter{ModuleID: dupB.ID})
+	h.noError(err)
+	h.a.Len(rr, 1)
+	h.a.NotEqual(recB.ID, rr[0].ID)
+	h.a.Equal(strconv.FormatUint(dupRecA.ID, 10), rr[0].Values.Get("a", 0).Value)
+
+	h.a.Equal(rbac.Allow, rbac.Global().Check(dupA.RBACResource(), "record.read", h.roleID))
+}
+
+func TestNamespaceCloneUnreadable(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+
+	h.allow(types.ComposeRBACResource, "namespace.create")
+	h.allow(types.ComposeRBACResource, "grant")
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "read")
+	h.allow(types.ModuleRBACResource.AppendWildcard(), "read")
+
+	var (
+		ctx = context.Background()
+		s   = service.DefaultStore
+
+		ns  = h.makeNamespace("clone-unreadable")
+		mod = h.createModule(&types.Module{
+			Handle:      "a",
+			Name:        "A",
+			NamespaceID: ns.ID,
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "name", Kind: "String"},
+				&types.ModuleField{Name: "secret", Kind: "String"},
+			},
+		})
+		clone = func(slug string) *apitest.Response {
+			return h.apiInit().
+				Post(fmt.Sprintf("/namespace/%d/clone", ns.ID)).
+				Header("Accept", "application/json").
+				FormData("slug", slug).
+				FormData("records", "true").
+				Expect(t).
+				Status(http.StatusOK)
+		}
+	)
+
+	h.makeRecord(mod,
+		&types.RecordValue{Name: "name", Value: "foo"},
+		&types.RecordValue{Name: "secret", Value: "bar"},
+	)
+
+	// records of modules that can not be read are not copied
+	h.deny(mod.RBACResource(), "record.read")
+	clone("clone-unreadable-records").Assert(helpers.AssertNoErrors).End()
+
+	dup, err := store.LookupComposeNamespaceBySlug(ctx, s, "clone-unreadable-records")
+	h.noError(err)
+	dupMod, err := store.LookupComposeModuleByNamespaceIDHandle(ctx, s, dup.ID, "a")
+	h.noError(err)
+	rr, _, err := store.SearchComposeRecords(ctx, s, dupMod, types.RecordFilter{ModuleID: dupMod.ID})
+	h.noError(err)
+	h.a.Empty(rr)
+
+	// values that can not be read are not copied
+	h.allow(mod.RBACResource(), "record.read")
+	h.deny(mod.Fields.FindByName("secret").RBACResource(), "record.value.read")
+	clone("clone-unreadable-values").Assert(helpers.AssertNoErrors).End()
+
+	dup, err = store.LookupComposeNamespaceBySlug(ctx, s, "clone-unreadable-values")
+	h.noError(err)
+	dupMod, err = store.LookupComposeModuleByNamespaceIDHandle(ctx, s, dup.ID, "a")
+	h.noError(err)
+	rr, _, err = store.SearchComposeRecords(ctx, s, dupMod, types.RecordFilter{ModuleID: dupMod.ID})
+	h.noError(err)
+	h.a.Len(rr, 1)
+	h.a.Equal("foo", rr[0].Values.Get("name", 0).Value)
+	h.a.Nil(rr[0].Values.Get("secret", 0))
+
+	// modules that can not be read can not be cloned
+	h.deny(mod.RBACResource(), "read")
+	clone("clone-unreadable-module").Assert(helpers.AssertError("not allowed to read all modules of this namespace")).End()
+
+	// records can not be cloned without the rules
+	h.allow(mod.RBACResource(), "read")
+	h.deny(types.ComposeRBACResource, "grant")
+	clone("clone-without-grant").Assert(helpers.AssertError("not allowed to clone records without permission to grant")).End()
+}
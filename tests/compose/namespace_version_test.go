@@ -0,0 +1,265 @@
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+)
+
+func (h helper) clearNamespaceVersions() {
+	h.noError(store.TruncateComposeNamespaceVersions(context.Background(), service.DefaultStore))
+}
+
+func (h helper) apiNamespaceVersion(method, url string, v interface{}, form ...string) {
+	var (
+		rsp = struct {
+			Response interface{}
+			Error    struct{ Message string }
+		}{Response: v}
+
+		req = h.apiInit().Method(method).URL(url).Header("Accept", "application/json")
+	)
+
+	for i := 0; i+1 < len(form); i += 2 {
+		req = req.FormData(form[i], form[i+1])
+	}
+
+	req.Expect(h.t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End().
+		JSON(&rsp)
+}
+
+func TestNamespaceVersionForbidden(t *testing.T) {
+	h := newHelper(t)
+	h.clearNamespaces()
+	h.clearNamespaceVersions()
+
+	h.deny(types.NamespaceRBACResource.AppendWildcard(), "manage")
+
+	ns := h.makeNamespace("some-namespace")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/version/draft", ns.ID)).
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to manage versions of this namespace")).
+		End()
+}
+
+func TestNamespaceVersionDiscard(t *testing.T) {
+	h := newHelper(t)
+	h.clearNamespaces()
+	h.clearNamespaceVersions()
+
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "manage")
+
+	ns := h.makeNamespace("some-namespace")
+	url := fmt.Sprintf("/namespace/%d/version/draft", ns.ID)
+
+	h.apiNamespaceVersion(http.MethodPost, url, &types.NamespaceVersion{})
+
+	h.apiInit().
+		Post(url).
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("namespace already has a draft")).
+		End()
+
+	h.apiNamespaceVersion(http.MethodDelete, url, nil)
+
+	h.apiInit().
+		Get(url).
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("namespace does not have a draft")).
+		End()
+}
+
+func TestNamespaceVersionPublishAndRollback(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+	h.clearPages()
+	h.clearNamespaceVersions()
+
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "manage")
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "module.create")
+	h.allow(types.ModuleRBACResource.AppendWildcard(), "update")
+	h.allow(types.ModuleRBACResource.AppendWildcard(), "delete")
+	h.allow(types.PageRBACResource.AppendWildcard(), "update")
+	h.allow(types.PageRBACResource.AppendWildcard(), "delete")
+
+	var (
+		ctx = context.Background()
+		s   = service.DefaultStore
+
+		ns  = h.makeNamespace("versioned")
+		mod = h.createModule(&types.Module{
+			Handle:      "mod_a",
+			Name:        "A",
+			NamespaceID: ns.ID,
+			Fields:      types.ModuleFieldSet{&types.ModuleField{Name: "name", Kind: "String"}},
+		})
+		page = h.repoMakePage(ns, "page")
+
+		draft   = &types.NamespaceVersion{}
+		diff    = types.NamespaceConfigDiff{}
+		version = &types.NamespaceVersion{}
+		history = struct{ Set types.NamespaceVersionSet }{}
+
+		base = fmt.Sprintf("/namespace/%d/version", ns.ID)
+	)
+
+	h.apiNamespaceVersion(http.MethodPost, base+"/draft", draft)
+	h.a.Equal(types.NamespaceVersionDraft, draft.Status)
+	h.a.Len(draft.Config.Modules, 1)
+	h.a.Len(draft.Config.Pages, 1)
+
+	// stage changes: update module & add a field, add a module, remove the page
+	cfg := draft.Config
+	cfg.Modules[0].Name = "A2"
+	cfg.Modules[0].Fields = append(cfg.Modules[0].Fields, &types.ModuleField{Name: "email", Kind: "Email"})
+	cfg.Modules = append(cfg.Modules, &types.Module{Handle: "mod_b", Name: "B", Fields: types.ModuleFieldSet{&types.ModuleField{Name: "title", Kind: "String"}}})
+	cfg.Pages = nil
+
+	enc, err := json.Marshal(cfg)
+	h.noError(err)
+
+	h.apiNamespaceVersion(http.MethodPost, base+"/draft/config", draft, "config", string(enc), "comment", "new module")
+	h.a.Equal("new module", draft.Comment)
+	h.a.Len(draft.Config.Modules, 2)
+	h.a.NotZero(draft.Config.Modules[1].ID, "new module must get an ID")
+
+	h.apiNamespaceVersion(http.MethodGet, fmt.Sprintf("%s/%d/diff", base, draft.ID), &diff)
+	h.a.Len(diff, 3)
+	h.a.Equal(types.NamespaceConfigChanged, diff[0].Change)
+	h.a.Equal(mod.ID, diff[0].ResourceID)
+	h.a.Equal([]string{"fields", "name"}, diff[0].Properties)
+	h.a.Equal(types.NamespaceConfigAdded, diff[1].Change)
+	h.a.Equal("mod_b", diff[1].Handle)
+	h.a.Equal(types.NamespaceConfigRemoved, diff[2].Change)
+	h.a.Equal(page.ID, diff[2].ResourceID)
+
+	// nothing changes before the draft is published
+	h.a.Equal("A", h.lookupModuleByID(mod.ID).Name)
+
+	h.apiNamespaceVersion(http.MethodPost, base+"/draft/publish", version)
+	h.a.Equal(types.NamespaceVersionPublished, version.Status)
+	h.a.NotNil(version.PublishedAt)
+
+	live := h.lookupModuleByID(mod.ID)
+	h.a.Equal("A2", live.Name)
+	live.Fields, _, err = store.SearchComposeModuleFields(ctx, s, types.ModuleFieldFilter{ModuleID: []uint64{mod.ID}})
+	h.noError(err)
+	h.a.Len(live.Fields, 2)
+
+	_, err = store.LookupComposeModuleByNamespaceIDHandle(ctx, s, ns.ID, "mod_b")
+	h.noError(err)
+	h.a.NotNil(h.lookupPageByID(page.ID).DeletedAt)
+
+	h.apiNamespaceVersion(http.MethodGet, base+"/?status=published", &history)
+	h.a.Len(history.Set, 2, "initial and published version expected")
+	h.a.Equal(version.ID, history.Set[0].ID)
+	h.a.Equal("initial configuration", history.Set[1].Comment)
+
+	h.apiNamespaceVersion(http.MethodGet, fmt.Sprintf("%s/%d/diff", base, version.ID), &diff)
+	h.a.Empty(diff, "published version must match the live configuration")
+
+	// roll back to the configuration before the publish
+	h.apiNamespaceVersion(http.MethodPost, fmt.Sprintf("%s/%d/rollback", base, history.Set[1].ID), version)
+	h.a.Equal(types.NamespaceVersionPublished, version.Status)
+
+	live = h.lookupModuleByID(mod.ID)
+	h.a.Equal("A", live.Name)
+	h.a.Len(live.Fields, 1, "field added by the publish must be removed")
+	h.a.Nil(h.lookupPageByID(page.ID).DeletedAt)
+
+	_, err = store.LookupComposeModuleByNamespaceIDHandle(ctx, s, ns.ID, "mod_b")
+	h.a.Error(err)
+
+	h.apiInit().
+		Get(base + "/").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 3)).
+		End()
+}
+
+func TestNamespaceVersionPublishStale(t *testing.T) {
+	h := newHelper(t)
+	h.clearNamespaces()
+	h.clearNamespaceVersions()
+
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "manage")
+
+	var (
+		ns    = h.makeNamespace("stale")
+		draft = &types.NamespaceVersion{}
+		base  = fmt.Sprintf("/namespace/%d/version", ns.ID)
+	)
+
+	h.apiNamespaceVersion(http.MethodPost, base+"/draft", draft)
+
+	// module created after the draft would be removed on publish
+	mod := h.createModule(&types.Module{Handle: "late", Name: "Late", NamespaceID: ns.ID})
+
+	h.apiInit().
+		Post(base+"/draft/publish").
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("namespace configuration changed after the draft was created")).
+		End()
+
+	h.a.Nil(h.lookupModuleByID(mod.ID).DeletedAt)
+}
+
+func TestNamespaceVersionPublishForbidden(t *testing.T) {
+	h := newHelper(t)
+	h.clearNamespaces()
+	h.clearNamespaceVersions()
+
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "manage")
+	h.deny(types.ModuleRBACResource.AppendWildcard(), "update")
+
+	var (
+		ns  = h.makeNamespace("restricted")
+		mod = h.createModule(&types.Module{Handle: "mod", Name: "Mod", NamespaceID: ns.ID})
+
+		draft = &types.NamespaceVersion{}
+		base  = fmt.Sprintf("/namespace/%d/version", ns.ID)
+	)
+
+	h.apiNamespaceVersion(http.MethodPost, base+"/draft", draft)
+
+	cfg := draft.Config
+	cfg.Modules[0].Name = "Changed"
+
+	enc, err := json.Marshal(cfg)
+	h.noError(err)
+
+	h.apiNamespaceVersion(http.MethodPost, base+"/draft/config", draft, "config", string(enc))
+
+	h.apiInit().
+		Post(base+"/draft/publish").
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to update this module")).
+		End()
+
+	h.a.Equal("Mod", h.lookupModuleByID(mod.ID).Name)
+}
@@ -0,0 +1,391 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/id"
+	"github.com/cortezaproject/corteza-server/pkg/rbac"
+	"github.com/cortezaproject/corteza-server/store"
+	sysTypes "github.com/cortezaproject/corteza-server/system/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+	"github.com/steinfletcher/apitest"
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+)
+
+func (h helper) clearRecordChangeRequests() {
+	h.noError(store.TruncateComposeRecordChangeRequests(context.Background(), service.DefaultStore))
+}
+
+// makeApprover creates user with its own role that can read & update records
+func (h helper) makeApprover() *sysTypes.User {
+	u := &sysTypes.User{ID: id.Next()}
+	u.SetRoles([]uint64{id.Next()})
+
+	h.mockPermissions(
+		rbac.AllowRule(u.Roles()[0], types.NamespaceRBACResource.AppendWildcard(), "read"),
+		rbac.AllowRule(u.Roles()[0], types.ModuleRBACResource.AppendWildcard(), "read"),
+		rbac.AllowRule(u.Roles()[0], types.ModuleRBACResource.AppendWildcard(), "record.read"),
+		rbac.AllowRule(u.Roles()[0], types.ModuleRBACResource.AppendWildcard(), "record.update"),
+	)
+
+	return u
+}
+
+// apiInitAs initializes api test with a different user
+func (h helper) apiInitAs(u *sysTypes.User) *apitest.APITest {
+	InitTestApp()
+
+	return apitest.
+		New().
+		Handler(r).
+		Intercept(helpers.ReqHeaderAuthBearer(u))
+}
+
+func (h helper) makeApprovalModule(steps ...*types.ModuleApprovalStep) *types.Module {
+	ns := h.makeNamespace("approval namespace")
+
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "read")
+	h.allow(types.ModuleRBACResource.AppendWildcard(), "read")
+	h.allow(types.ModuleRBACResource.AppendWildcard(), "record.read")
+	h.allow(types.ModuleRBACResource.AppendWildcard(), "record.update")
+
+	return h.createModule(&types.Module{
+		Name:        "discounts",
+		NamespaceID: ns.ID,
+		Fields: types.ModuleFieldSet{
+			&types.ModuleField{Name: "discount", Kind: "Number"},
+		},
+		Approval: &types.ModuleApproval{
+			Condition: "discount > 10",
+			Steps:     steps,
+		},
+	})
+}
+
+func (h helper) updateDiscount(m *types.Module, r *types.Record, discount string) *apitest.Response {
+	return h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d", m.NamespaceID, m.ID, r.ID)).
+		Header("Accept", "application/json").
+		JSON(fmt.Sprintf(`{"values": [{"name": "discount", "value": "%s"}]}`, discount)).
+		Expect(h.t).
+		Status(http.StatusOK)
+}
+
+func (h helper) lookupPendingChangeRequest(r *types.Record) *types.RecordChangeRequest {
+	set, _, err := store.SearchComposeRecordChangeRequests(context.Background(), service.DefaultStore, types.RecordChangeRequestFilter{
+		RecordID: r.ID,
+		Status:   types.RecordChangeRequestPending,
+	})
+
+	h.noError(err)
+	h.a.Len(set, 1)
+	return set[0]
+}
+
+func TestRecordChangeRequestApprove(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+	h.clearRecordChangeRequests()
+
+	var (
+		first  = h.makeApprover()
+		second = h.makeApprover()
+
+		m = h.makeApprovalModule(
+			&types.ModuleApprovalStep{Name: "manager", Roles: first.Roles()},
+			&types.ModuleApprovalStep{Name: "finance", Users: []uint64{second.ID}},
+		)
+
+		r   = h.makeRecord(m, &types.RecordValue{Name: "discount", Value: "5"})
+		url = fmt.Sprintf("/namespace/%d/change-request/", m.NamespaceID)
+	)
+
+	// changes that do not meet the condition are applied directly
+	h.updateDiscount(m, r, "8").Assert(helpers.AssertNoErrors).End()
+	h.a.Equal("8", h.lookupRecordByID(m, r.ID).Values.Get("discount", 0).Value)
+
+	h.updateDiscount(m, r, "20").Assert(helpers.AssertError("change is waiting for approval")).End()
+	h.a.Equal("8", h.lookupRecordByID(m, r.ID).Values.Get("discount", 0).Value)
+
+	h.updateDiscount(m, r, "30").Assert(helpers.AssertError("record has a pending change request")).End()
+
+	cr := h.lookupPendingChangeRequest(r)
+	h.a.Equal([]string{"discount"}, cr.Change.Fields)
+	h.a.Equal("20", cr.Change.Values.Get("discount", 0).Value)
+	h.a.Equal("8", cr.Change.OldValues.Get("discount", 0).Value)
+
+	// requester can see the request but can not approve it
+	h.apiInit().
+		Get(url).
+		Query("approvable", "true").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.NotPresent(`$.response.set[0]`)).
+		End()
+
+	h.apiInit().
+		Post(fmt.Sprintf("%s%d/approve", url, cr.ID)).
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to approve or reject this record change request")).
+		End()
+
+	// second approver can not approve before the first step is complete
+	h.apiInitAs(second).
+		Post(fmt.Sprintf("%s%d/approve", url, cr.ID)).
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to approve or reject this record change request")).
+		End()
+
+	h.apiInitAs(first).
+		Get(url).
+		Query("approvable", "true").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 1)).
+		End()
+
+	h.apiInitAs(first).
+		Post(fmt.Sprintf("%s%d/approve", url, cr.ID)).
+		FormData("comment", "looks good").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.status`, "pending")).
+		Assert(jsonpath.Equal(`$.response.step`, float64(1))).
+		End()
+
+	h.a.Equal("8", h.lookupRecordByID(m, r.ID).Values.Get("discount", 0).Value)
+
+	h.apiInitAs(second).
+		Post(fmt.Sprintf("%s%d/approve", url, cr.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.status`, "approved")).
+		End()
+
+	h.a.Equal("20", h.lookupRecordByID(m, r.ID).Values.Get("discount", 0).Value)
+
+	h.apiInit().
+		Get(fmt.Sprintf("%s%d", url, cr.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.decisions`, 2)).
+		Assert(jsonpath.Equal(`$.response.decisions[0].comment`, "looks good")).
+		Assert(jsonpath.Equal(`$.response.decisions[1].approved`, true)).
+		End()
+}
+
+func TestRecordChangeRequestRequireAll(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+	h.clearRecordChangeRequests()
+
+	var (
+		first  = h.makeApprover()
+		second = h.makeApprover()
+
+		m = h.makeApprovalModule(
+			&types.ModuleApprovalStep{Users: []uint64{first.ID, second.ID}, RequireAll: true},
+		)
+
+		r = h.makeRecord(m, &types.RecordValue{Name: "discount", Value: "5"})
+	)
+
+	h.updateDiscount(m, r, "20").Assert(helpers.AssertError("change is waiting for approval")).End()
+
+	cr := h.lookupPendingChangeRequest(r)
+	url := fmt.Sprintf("/namespace/%d/change-request/%d/approve", m.NamespaceID, cr.ID)
+
+	h.apiInitAs(first).Post(url).Expect(t).Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.status`, "pending")).
+		End()
+
+	h.apiInitAs(first).Post(url).Header("Accept", "application/json").Expect(t).Status(http.StatusOK).
+		Assert(helpers.AssertError("already decided on this approval step")).
+		End()
+
+	h.apiInitAs(second).Post(url).Expect(t).Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.status`, "approved")).
+		End()
+
+	h.a.Equal("20", h.lookupRecordByID(m, r.ID).Values.Get("discount", 0).Value)
+}
+
+func TestRecordChangeRequestReject(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+	h.clearRecordChangeRequests()
+
+	var (
+		approver = h.makeApprover()
+		m        = h.makeApprovalModule(&types.ModuleApprovalStep{Roles: approver.Roles()})
+		r        = h.makeRecord(m, &types.RecordValue{Name: "discount", Value: "5"})
+	)
+
+	h.updateDiscount(m, r, "20").Assert(helpers.AssertError("change is waiting for approval")).End()
+
+	cr := h.lookupPendingChangeRequest(r)
+	url := fmt.Sprintf("/namespace/%d/change-request/%d/", m.NamespaceID, cr.ID)
+
+	h.apiInitAs(approver).
+		Post(url+"reject").
+		FormData("comment", "too much").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.status`, "rejected")).
+		End()
+
+	h.apiInitAs(approver).
+		Post(url+"approve").
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("record change request is not pending")).
+		End()
+
+	h.a.Equal("5", h.lookupRecordByID(m, r.ID).Values.Get("discount", 0).Value)
+
+	// record can be changed again once the request is rejected
+	h.updateDiscount(m, r, "15").Assert(helpers.AssertError("change is waiting for approval")).End()
+}
+
+func TestRecordChangeRequestCancel(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+	h.clearRecordChangeRequests()
+
+	var (
+		approver = h.makeApprover()
+		m        = h.makeApprovalModule(&types.ModuleApprovalStep{Roles: approver.Roles()})
+		r        = h.makeRecord(m, &types.RecordValue{Name: "discount", Value: "5"})
+	)
+
+	h.updateDiscount(m, r, "20").Assert(helpers.AssertError("change is waiting for approval")).End()
+
+	cr := h.lookupPendingChangeRequest(r)
+	url := fmt.Sprintf("/namespace/%d/change-request/%d/cancel", m.NamespaceID, cr.ID)
+
+	h.apiInitAs(approver).
+		Post(url).
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to cancel this record change request")).
+		End()
+
+	h.apiInit().
+		Post(url).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.status`, "canceled")).
+		End()
+
+	h.a.Equal("5", h.lookupRecordByID(m, r.ID).Values.Get("discount", 0).Value)
+}
+
+func TestRecordChangeRequestConflict(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+	h.clearRecordChangeRequests()
+
+	var (
+		approver = h.makeApprover()
+		m        = h.makeApprovalModule(&types.ModuleApprovalStep{Roles: approver.Roles()})
+		r        = h.makeRecord(m, &types.RecordValue{Name: "discount", Value: "5"})
+	)
+
+	h.updateDiscount(m, r, "20").Assert(helpers.AssertError("change is waiting for approval")).End()
+
+	cr := h.lookupPendingChangeRequest(r)
+
+	// record is changed after the request
+	r.Values = types.RecordValueSet{&types.RecordValue{Name: "discount", Value: "7"}}
+	h.noError(store.UpdateComposeRecord(context.Background(), service.DefaultStore, m, r))
+
+	h.apiInitAs(approver).
+		Post(fmt.Sprintf("/namespace/%d/change-request/%d/approve", m.NamespaceID, cr.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.status`, "conflicted")).
+		End()
+
+	h.a.Equal("7", h.lookupRecordByID(m, r.ID).Values.Get("discount", 0).Value)
+}
+
+func TestRecordChangeRequestDelete(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+	h.clearRecordChangeRequests()
+
+	var (
+		approver = h.makeApprover()
+		m        = h.makeApprovalModule(&types.ModuleApprovalStep{Roles: approver.Roles()})
+		r        = h.makeRecord(m, &types.RecordValue{Name: "discount", Value: "20"})
+		url      = fmt.Sprintf("/namespace/%d/module/%d/record/%d", m.NamespaceID, m.ID, r.ID)
+	)
+
+	h.allow(types.ModuleRBACResource.AppendWildcard(), "record.delete")
+	h.mockPermissions(rbac.AllowRule(approver.Roles()[0], types.ModuleRBACResource.AppendWildcard(), "record.delete"))
+
+	h.apiInit().
+		Delete(url).
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("change is waiting for approval")).
+		End()
+
+	h.a.Nil(h.lookupRecordByID(m, r.ID).DeletedAt)
+
+	cr := h.lookupPendingChangeRequest(r)
+	h.a.True(cr.Change.Delete)
+
+	h.apiInitAs(approver).
+		Post(fmt.Sprintf("/namespace/%d/change-request/%d/approve", m.NamespaceID, cr.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.status`, "approved")).
+		End()
+
+	h.a.NotNil(h.lookupRecordByID(m, r.ID).DeletedAt)
+}
+
+func TestModuleCreateInvalidApproval(t *testing.T) {
+	h := newHelper(t)
+	h.clearModules()
+
+	ns := h.makeNamespace("some-namespace")
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "read")
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "module.create")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/", ns.ID)).
+		Header("Accept", "application/json").
+		FormData("name", "some-module").
+		FormData("fields", `[{"name":"discount"}]`).
+		FormData("meta", `{}`).
+		FormData("approval", `{"steps":[{"name":"manager"}]}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError(`invalid approval flow: step 1 has no approvers`)).
+		End()
+}
@@ -0,0 +1,196 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/id"
+	"github.com/cortezaproject/corteza-server/pkg/rbac"
+	"github.com/cortezaproject/corteza-server/store"
+	sysTypes "github.com/cortezaproject/corteza-server/system/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+)
+
+// makeAssigneeModule creates module with user field that assigns dynamic role
+func (h helper) makeAssigneeModule(roleID uint64) *types.Module {
+	ns := h.makeNamespace("dynamic role namespace")
+
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "read")
+	h.allow(types.ModuleRBACResource.AppendWildcard(), "read")
+
+	h.mockPermissions(
+		rbac.AllowRule(roleID, types.ModuleRBACResource.AppendWildcard(), "record.read"),
+		rbac.AllowRule(roleID, types.ModuleRBACResource.AppendWildcard(), "record.update"),
+	)
+
+	return h.makeModule(ns, "tickets",
+		&types.ModuleField{Name: "title"},
+		&types.ModuleField{
+			Name:    "assignee",
+			Kind:    "User",
+			Options: types.ModuleFieldOptions{"dynamicRole": fmt.Sprintf("%d", roleID)},
+		},
+	)
+}
+
+func TestRecordDynamicRoleFromUserField(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+
+	var (
+		m       = h.makeAssigneeModule(id.Next())
+		otherID = id.Next()
+
+		assigned = h.makeRecord(m,
+			&types.RecordValue{Name: "title", Value: "mine"},
+			&types.RecordValue{Name: "assignee", Value: fmt.Sprintf("%d", h.cUser.ID), Ref: h.cUser.ID},
+		)
+
+		other = h.makeRecord(m,
+			&types.RecordValue{Name: "title", Value: "not mine"},
+			&types.RecordValue{Name: "assignee", Value: fmt.Sprintf("%d", otherID), Ref: otherID},
+		)
+	)
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/", m.NamespaceID, m.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 1)).
+		Assert(jsonpath.Equal(`$.response.set[0].recordID`, fmt.Sprintf("%d", assigned.ID))).
+		Assert(jsonpath.Equal(`$.response.set[0].canUpdateRecord`, true)).
+		Assert(jsonpath.Equal(`$.response.set[0].canDeleteRecord`, false)).
+		End()
+
+	// restriction to the user fields is not returned with the filter
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/", m.NamespaceID, m.ID)).
+		Query("query", "title = 'mine'").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 1)).
+		Assert(jsonpath.Equal(`$.response.filter.query`, "title = 'mine'")).
+		End()
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/%d", m.NamespaceID, m.ID, assigned.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/%d", m.NamespaceID, m.ID, other.ID)).
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to read this record")).
+		End()
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d", m.NamespaceID, m.ID, assigned.ID)).
+		JSON(fmt.Sprintf(`{"values": [{"name": "title", "value": "changed"}, {"name": "assignee", "value": "%d"}]}`, h.cUser.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d", m.NamespaceID, m.ID, other.ID)).
+		JSON(`{"values": [{"name": "title", "value": "changed"}]}`).
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to update this record")).
+		End()
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/module/%d/record/%d", m.NamespaceID, m.ID, assigned.ID)).
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to delete this record")).
+		End()
+}
+
+func TestModuleCreateInvalidDynamicRole(t *testing.T) {
+	h := newHelper(t)
+	h.clearModules()
+
+	ns := h.makeNamespace("some-namespace")
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "read")
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "module.create")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/", ns.ID)).
+		Header("Accept", "application/json").
+		JSON(`{"name":"some-module","meta":{},"fields":[{"name":"assignee","kind":"String","options":{"dynamicRole":"42"}}]}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError(`invalid dynamic role: field "assignee" is not a User field`)).
+		End()
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/", ns.ID)).
+		Header("Accept", "application/json").
+		JSON(`{"name":"some-module","meta":{},"fields":[{"name":"assignee","kind":"User","options":{"dynamicRole":"10000"}}]}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError(`invalid dynamic role: invalid role on field "assignee"`)).
+		End()
+}
+
+func TestModuleCreateDynamicRoleRequiresGrant(t *testing.T) {
+	h := newHelper(t)
+	h.clearModules()
+
+	var (
+		ns   = h.makeNamespace("some-namespace")
+		role = &sysTypes.Role{ID: id.Next(), Handle: fmt.Sprintf("role_%d", id.Next()), CreatedAt: time.Now()}
+		url  = fmt.Sprintf("/namespace/%d/module/", ns.ID)
+
+		payload = func(roleID uint64) string {
+			return fmt.Sprintf(`{"name":"some-module","meta":{},"fields":[{"name":"assignee","kind":"User","options":{"dynamicRole":"%d"}}]}`, roleID)
+		}
+	)
+
+	h.noError(store.CreateRole(context.Background(), service.DefaultStore, role))
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "read")
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "module.create")
+
+	h.apiInit().
+		Post(url).
+		Header("Accept", "application/json").
+		JSON(payload(role.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to set dynamic roles of the fields")).
+		End()
+
+	h.allow(types.ComposeRBACResource, "grant")
+
+	h.apiInit().
+		Post(url).
+		Header("Accept", "application/json").
+		JSON(payload(id.Next())).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError(`invalid dynamic role: role on field "assignee" does not exist`)).
+		End()
+
+	h.apiInit().
+		Post(url).
+		JSON(payload(role.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+}
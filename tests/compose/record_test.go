@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"github.com/360EntSecGroup-Skylar/excelize/v2"
 	"github.com/cortezaproject/corteza-server/compose/service"
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/id"
@@ -16,6 +17,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strconv"
 	"testing"
 	"time"
 )
@@ -333,6 +335,172 @@ func TestRecordExport(t *testing.T) {
 	h.a.Equal("name\nd0\nd1\nd2\nd3\nd4\nd5\nd6\nd7\nd8\nd9\n", string(b))
 }
 
+func (h helper) makeRecordExportModules() (acc, con *types.Module) {
+	ns := h.makeNamespace("record export namespace")
+
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "read")
+	h.allow(types.ModuleRBACResource.AppendWildcard(), "read")
+	h.allow(types.ModuleRBACResource.AppendWildcard(), "record.read")
+
+	acc = h.createModule(&types.Module{
+		Name:        "accounts",
+		Handle:      "Account",
+		NamespaceID: ns.ID,
+		Fields:      types.ModuleFieldSet{&types.ModuleField{Name: "name", Kind: "String"}},
+	})
+
+	con = h.createModule(&types.Module{
+		Name:        "contacts",
+		Handle:      "Contact",
+		NamespaceID: ns.ID,
+		Fields: types.ModuleFieldSet{
+			&types.ModuleField{Name: "name", Kind: "String"},
+			&types.ModuleField{Name: "account", Kind: "Record", Options: types.ModuleFieldOptions{
+				"moduleID":   strconv.FormatUint(acc.ID, 10),
+				"labelField": "name",
+			}},
+		},
+	})
+
+	return
+}
+
+func TestRecordExportResolved(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+
+	acc, con := h.makeRecordExportModules()
+	a := h.makeRecord(acc, &types.RecordValue{Name: "name", Value: "Acme"})
+	h.makeRecord(con,
+		&types.RecordValue{Name: "name", Value: "John"},
+		&types.RecordValue{Name: "account", Value: strconv.FormatUint(a.ID, 10), Ref: a.ID},
+	)
+
+	r := h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/export.csv", con.NamespaceID, con.ID)).
+		Query("fields", "name,account,account.name").
+		Query("resolve", "true").
+		Expect(t).
+		Status(http.StatusOK).
+		End()
+
+	b, err := ioutil.ReadAll(r.Response.Body)
+	h.noError(err)
+	h.a.Equal("name,account,account.name\nJohn,Acme,Acme\n", string(b))
+}
+
+func TestRecordExportResolvedUnreadable(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+
+	acc, con := h.makeRecordExportModules()
+	h.deny(acc.Fields[0].RBACResource(), "record.value.read")
+
+	a := h.makeRecord(acc, &types.RecordValue{Name: "name", Value: "Acme"})
+	h.makeRecord(con,
+		&types.RecordValue{Name: "name", Value: "John"},
+		&types.RecordValue{Name: "account", Value: strconv.FormatUint(a.ID, 10), Ref: a.ID},
+	)
+
+	r := h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/export.csv", con.NamespaceID, con.ID)).
+		Query("fields", "name,account,account.name").
+		Query("resolve", "true").
+		Expect(t).
+		Status(http.StatusOK).
+		End()
+
+	b, err := ioutil.ReadAll(r.Response.Body)
+	h.noError(err)
+	h.a.Equal(fmt.Sprintf("name,account,account.name\nJohn,%d,\n", a.ID), string(b))
+}
+
+func TestRecordExportRelatedJSON(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+
+	acc, con := h.makeRecordExportModules()
+	a1 := h.makeRecord(acc, &types.RecordValue{Name: "name", Value: "Acme"})
+	a2 := h.makeRecord(acc, &types.RecordValue{Name: "name", Value: "Initech"})
+	for _, n := range []string{"John", "Jane"} {
+		h.makeRecord(con,
+			&types.RecordValue{Name: "name", Value: n},
+			&types.RecordValue{Name: "account", Value: strconv.FormatUint(a1.ID, 10), Ref: a1.ID},
+		)
+	}
+
+	r := h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/export.json", acc.NamespaceID, acc.ID)).
+		Query("fields", "recordID,name").
+		Query("children", `[{"name":"contacts","module":"Contact","fields":["name"]}]`).
+		Expect(t).
+		Status(http.StatusOK).
+		End()
+
+	b, err := ioutil.ReadAll(r.Response.Body)
+	h.noError(err)
+	h.a.Equal(
+		fmt.Sprintf(`{"contacts":[{"name":"John"},{"name":"Jane"}],"name":"Acme","recordID":"%d"}`+"\n", a1.ID)+
+			fmt.Sprintf(`{"contacts":[],"name":"Initech","recordID":"%d"}`+"\n", a2.ID),
+		string(b),
+	)
+}
+
+func TestRecordExportRelatedXLSX(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+
+	acc, con := h.makeRecordExportModules()
+	a := h.makeRecord(acc, &types.RecordValue{Name: "name", Value: "Acme"})
+	h.makeRecord(con,
+		&types.RecordValue{Name: "name", Value: "John"},
+		&types.RecordValue{Name: "account", Value: strconv.FormatUint(a.ID, 10), Ref: a.ID},
+	)
+
+	r := h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/export.xlsx", acc.NamespaceID, acc.ID)).
+		Query("fields", "recordID,name").
+		Query("children", fmt.Sprintf(`[{"module":"%d","fields":["name"]}]`, con.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		End()
+
+	f, err := excelize.OpenReader(r.Response.Body)
+	h.noError(err)
+
+	rows, err := f.GetRows("Sheet1")
+	h.noError(err)
+	h.a.Equal([][]string{{"recordID", "name"}, {strconv.FormatUint(a.ID, 10), "Acme"}}, rows)
+
+	rows, err = f.GetRows("Contact")
+	h.noError(err)
+	h.a.Equal([][]string{{"parentRecordID", "name"}, {strconv.FormatUint(a.ID, 10), "John"}}, rows)
+}
+
+func TestRecordExportRelatedInvalid(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+
+	acc, _ := h.makeRecordExportModules()
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/export.csv", acc.NamespaceID, acc.ID)).
+		Query("fields", "name").
+		Query("children", `[{"module":"Contact","fields":["name"]}]`).
+		Expect(t).
+		Status(http.StatusBadRequest).
+		End()
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/export.json", acc.NamespaceID, acc.ID)).
+		Query("fields", "name").
+		Query("children", `[{"module":"Lead","fields":["name"]}]`).
+		Expect(t).
+		Status(http.StatusInternalServerError).
+		Body("invalid export definition: module \"Lead\" not found\n").
+		End()
+}
+
 func (h helper) apiInitRecordImport(api *apitest.APITest, url, f string, file []byte) *apitest.Response {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -352,6 +520,18 @@ func (h helper) apiInitRecordImport(api *apitest.APITest, url, f string, file []
 		Status(http.StatusOK)
 }
 
+// makeXlsx creates a single sheet workbook from the given rows
+func (h helper) makeXlsx(rows ...[]interface{}) string {
+	f := excelize.NewFile()
+	for i, row := range rows {
+		h.noError(f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", i+1), &row))
+	}
+
+	buf := &bytes.Buffer{}
+	h.noError(f.Write(buf))
+	return buf.String()
+}
+
 func (h helper) apiRunRecordImport(api *apitest.APITest, url, b string) *apitest.Response {
 	return api.
 		Patch(url).
@@ -378,6 +558,10 @@ func TestRecordImportInit(t *testing.T) {
 			Name:    "f1.json",
 			Content: `{"name":"v1","email":"v2"}` + "\n",
 		},
+		{
+			Name:    "f1.xlsx",
+			Content: h.makeXlsx([]interface{}{"name", "email"}, []interface{}{"v1", "v2"}),
+		},
 	}
 
 	for _, test := range tests {
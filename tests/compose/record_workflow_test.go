@@ -0,0 +1,197 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/id"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+	"github.com/steinfletcher/apitest"
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+)
+
+func (h helper) makeWorkflowModule() *types.Module {
+	ns := h.makeNamespace("workflow namespace")
+
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "read")
+	h.allow(types.ModuleRBACResource.AppendWildcard(), "read")
+	h.allow(types.ModuleRBACResource.AppendWildcard(), "record.read")
+	h.allow(types.ModuleRBACResource.AppendWildcard(), "record.create")
+	h.allow(types.ModuleRBACResource.AppendWildcard(), "record.update")
+
+	return h.createModule(&types.Module{
+		Name:        "quotes",
+		NamespaceID: ns.ID,
+		Fields: types.ModuleFieldSet{
+			&types.ModuleField{Name: "status", Kind: "String"},
+			&types.ModuleField{Name: "amount", Kind: "Number"},
+			&types.ModuleField{Name: "approvedBy", Kind: "String"},
+		},
+		Workflow: &types.ModuleWorkflow{
+			StateField: "status",
+			Initial:    "draft",
+			States: []*types.ModuleWorkflowState{
+				{Name: "draft"},
+				{Name: "submitted"},
+				{Name: "approved", Required: []string{"approvedBy"}},
+			},
+			Transitions: []*types.ModuleWorkflowTransition{
+				{Name: "submit", From: []string{"draft"}, To: "submitted", Guard: "amount > 0"},
+				{Name: "approve", From: []string{"submitted"}, To: "approved", Roles: []uint64{h.roleID}},
+				{Name: "escalate", From: []string{"submitted"}, To: "approved", Roles: []uint64{id.Next()}},
+				{Name: "reject", From: []string{"submitted"}, To: "draft"},
+			},
+		},
+	})
+}
+
+func (h helper) updateWorkflowRecord(m *types.Module, r *types.Record, values string) *apitest.Response {
+	return h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d", m.NamespaceID, m.ID, r.ID)).
+		Header("Accept", "application/json").
+		JSON(fmt.Sprintf(`{"values": %s}`, values)).
+		Expect(h.t).
+		Status(http.StatusOK)
+}
+
+func TestRecordWorkflowInitialState(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+
+	m := h.makeWorkflowModule()
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/", m.NamespaceID, m.ID)).
+		JSON(`{"values": [{"name": "amount", "value": "10"}]}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Contains(`$.response.values[*].value`, "draft")).
+		End()
+}
+
+func TestRecordWorkflowCreateState(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+
+	var (
+		m      = h.makeWorkflowModule()
+		create = func(values string) *apitest.Response {
+			return h.apiInit().
+				Post(fmt.Sprintf("/namespace/%d/module/%d/record/", m.NamespaceID, m.ID)).
+				Header("Accept", "application/json").
+				JSON(fmt.Sprintf(`{"values": %s}`, values)).
+				Expect(t).
+				Status(http.StatusOK)
+		}
+	)
+
+	// records must start in the initial state
+	create(`[{"name": "status", "value": "approved"}, {"name": "approvedBy", "value": "boss"}]`).
+		Assert(helpers.AssertRecordValueError(&types.RecordValueError{Kind: "invalidTransition", Meta: map[string]interface{}{"field": "status"}})).
+		End()
+
+	create(`[{"name": "status", "value": "draft"}]`).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	// without the initial state, records can only be created in
+	// states that can be transitioned to from an empty state
+	m.Workflow.Initial = ""
+	m.Workflow.Transitions = append(m.Workflow.Transitions, &types.ModuleWorkflowTransition{Name: "open", From: []string{""}, To: "draft"})
+	h.noError(store.UpdateComposeModule(context.Background(), service.DefaultStore, m))
+
+	create(`[{"name": "status", "value": "submitted"}, {"name": "amount", "value": "10"}]`).
+		Assert(helpers.AssertRecordValueError(&types.RecordValueError{Kind: "invalidTransition", Meta: map[string]interface{}{"field": "status"}})).
+		End()
+
+	create(`[{"name": "status", "value": "draft"}]`).
+		Assert(helpers.AssertNoErrors).
+		End()
+}
+
+func TestRecordWorkflowTransitions(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+
+	var (
+		m = h.makeWorkflowModule()
+		r = h.makeRecord(m, &types.RecordValue{Name: "status", Value: "draft"})
+	)
+
+	h.updateWorkflowRecord(m, r, `[{"name": "status", "value": "approved"}]`).
+		Assert(helpers.AssertRecordValueError(&types.RecordValueError{Kind: "invalidTransition", Meta: map[string]interface{}{"field": "status"}})).
+		End()
+
+	h.updateWorkflowRecord(m, r, `[{"name": "status", "value": "submitted"}, {"name": "amount", "value": "0"}]`).
+		Assert(helpers.AssertRecordValueError(&types.RecordValueError{Kind: "transitionGuard", Meta: map[string]interface{}{"field": "status"}})).
+		End()
+
+	h.updateWorkflowRecord(m, r, `[{"name": "status", "value": "submitted"}, {"name": "amount", "value": "10"}]`).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/%d", m.NamespaceID, m.ID, r.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.transitions`, 2)).
+		Assert(jsonpath.Equal(`$.response.transitions[0].name`, "approve")).
+		Assert(jsonpath.Equal(`$.response.transitions[1].name`, "reject")).
+		End()
+
+	h.updateWorkflowRecord(m, r, `[{"name": "status", "value": "approved"}, {"name": "amount", "value": "10"}]`).
+		Assert(helpers.AssertRecordValueError(&types.RecordValueError{Kind: "empty", Meta: map[string]interface{}{"field": "approvedBy"}})).
+		End()
+
+	h.updateWorkflowRecord(m, r, `[{"name": "status", "value": "approved"}, {"name": "amount", "value": "10"}, {"name": "approvedBy", "value": "boss"}]`).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.a.Equal("approved", h.lookupRecordByID(m, r.ID).Values.Get("status", 0).Value)
+}
+
+func TestRecordWorkflowTransitionDenied(t *testing.T) {
+	h := newHelper(t)
+	h.clearRecords()
+
+	var (
+		m = h.makeWorkflowModule()
+		r = h.makeRecord(m, &types.RecordValue{Name: "status", Value: "submitted"}, &types.RecordValue{Name: "approvedBy", Value: "boss"})
+	)
+
+	// only transitions that current user can make are allowed
+	m.Workflow.Transitions = m.Workflow.Transitions[2:]
+	h.noError(store.UpdateComposeModule(context.Background(), service.DefaultStore, m))
+
+	h.updateWorkflowRecord(m, r, `[{"name": "status", "value": "approved"}, {"name": "approvedBy", "value": "boss"}]`).
+		Assert(helpers.AssertRecordValueError(&types.RecordValueError{Kind: "transitionDenied", Meta: map[string]interface{}{"field": "status"}})).
+		End()
+}
+
+func TestModuleCreateInvalidWorkflow(t *testing.T) {
+	h := newHelper(t)
+	h.clearModules()
+
+	ns := h.makeNamespace("some-namespace")
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "read")
+	h.allow(types.NamespaceRBACResource.AppendWildcard(), "module.create")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/", ns.ID)).
+		Header("Accept", "application/json").
+		FormData("name", "some-module").
+		FormData("fields", `[{"name":"status"}]`).
+		FormData("meta", `{}`).
+		FormData("workflow", `{"stateField":"state"}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError(`invalid workflow: state field "state" does not exist`)).
+		End()
+}
@@ -0,0 +1,125 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/errors"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/system/service"
+	"github.com/cortezaproject/corteza-server/system/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+)
+
+func (h helper) repoLockUser(u *types.User) {
+	var (
+		now = time.Now()
+	)
+
+	h.noError(store.UpsertFailedLogin(context.Background(), service.DefaultStore, &types.FailedLogin{
+		Key:          "user:" + strconv.FormatUint(u.ID, 10),
+		Failures:     10,
+		LastFailedAt: now,
+		LockedAt:     &now,
+	}))
+}
+
+func TestAuthInternalLoginLocked(t *testing.T) {
+	h := newHelper(t)
+	h.clearUsers()
+
+	service.CurrentSettings.Auth.Internal.Enabled = true
+	defer func() {
+		service.CurrentSettings.Auth.Internal.Enabled = false
+	}()
+
+	u := h.createUserWithEmail(h.randEmail())
+	h.noError(service.DefaultAuth.SetPasswordCredentials(context.Background(), u.ID, "secure password"))
+	h.repoLockUser(u)
+
+	h.apiInit().
+		Post("/auth/internal/login").
+		Header("Accept", "application/json").
+		FormData("email", u.Email).
+		FormData("password", "secure password").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("account is locked due to too many failed login attempts")).
+		End()
+}
+
+func TestUserUnlockForbidden(t *testing.T) {
+	h := newHelper(t)
+	h.clearUsers()
+	h.deny(types.UserRBACResource.AppendWildcard(), "unsuspend")
+
+	u := h.createUserWithEmail(h.randEmail())
+	h.repoLockUser(u)
+
+	h.apiInit().
+		Post(fmt.Sprintf("/users/%d/unlock", u.ID)).
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to unlock this user")).
+		End()
+}
+
+func TestUserUnlock(t *testing.T) {
+	h := newHelper(t)
+	h.clearUsers()
+	h.allow(types.UserRBACResource.AppendWildcard(), "unsuspend")
+
+	u := h.createUserWithEmail(h.randEmail())
+	h.repoLockUser(u)
+
+	h.apiInit().
+		Post(fmt.Sprintf("/users/%d/unlock", u.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	_, err := store.LookupFailedLoginByKey(context.Background(), service.DefaultStore, "user:"+strconv.FormatUint(u.ID, 10))
+	h.a.True(errors.IsNotFound(err))
+}
+
+func TestAuthInternalChangePasswordPolicy(t *testing.T) {
+	h := newHelper(t)
+	h.clearUsers()
+
+	service.CurrentSettings.Auth.Internal.Enabled = true
+	service.CurrentSettings.Auth.Internal.PasswordPolicy.MinLength = 12
+	service.CurrentSettings.Auth.Internal.PasswordPolicy.RequireDigit = true
+	defer func() {
+		service.CurrentSettings.Auth.Internal.Enabled = false
+		service.CurrentSettings.Auth.Internal.PasswordPolicy.MinLength = 0
+		service.CurrentSettings.Auth.Internal.PasswordPolicy.RequireDigit = false
+	}()
+
+	h.createUser(&types.User{ID: h.cUser.ID, Email: h.randEmail()})
+	h.noError(service.DefaultAuth.SetPasswordCredentials(context.Background(), h.cUser.ID, "secure password"))
+
+	h.apiInit().
+		Post("/auth/internal/change-password").
+		Header("Accept", "application/json").
+		FormData("oldPassword", "secure password").
+		FormData("newPassword", "new password").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("password does not meet the requirements; it must contain a digit")).
+		End()
+
+	h.apiInit().
+		Post("/auth/internal/change-password").
+		FormData("oldPassword", "secure password").
+		FormData("newPassword", "new password 42").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+}
@@ -0,0 +1,122 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/id"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/system/service"
+	"github.com/cortezaproject/corteza-server/system/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+	"github.com/steinfletcher/apitest-jsonpath"
+)
+
+func (h helper) clearEventOutbox() {
+	h.noError(store.TruncateEventbusOutboxEvents(context.Background(), service.DefaultStore))
+	h.noError(store.TruncateEventbusDeadLetters(context.Background(), service.DefaultStore))
+}
+
+func (h helper) repoMakeDeadLetter(resourceType string) *eventbus.QueuedEvent {
+	var (
+		now = time.Now()
+		res = &eventbus.QueuedEvent{
+			ID:           id.Next(),
+			ResourceType: resourceType,
+			EventType:    "afterUpdate",
+			Attempts:     5,
+			LastError:    "failed",
+			CreatedAt:    now,
+			ScheduledAt:  now,
+			FailedAt:     &now,
+		}
+	)
+
+	h.a.NoError(store.CreateEventbusDeadLetter(context.Background(), service.DefaultStore, res))
+
+	return res
+}
+
+func TestEventOutboxListDeadLetters(t *testing.T) {
+	h := newHelper(t)
+	h.clearEventOutbox()
+	h.allow(types.SystemRBACResource, "event-outbox.manage")
+
+	h.repoMakeDeadLetter("system:user")
+	h.repoMakeDeadLetter("system:user")
+	h.repoMakeDeadLetter("system:role")
+
+	h.apiInit().
+		Get("/event-outbox/dead-letters").
+		Query("resourceType", "system:user").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 2)).
+		End()
+}
+
+func TestEventOutboxListForbidden(t *testing.T) {
+	h := newHelper(t)
+	h.clearEventOutbox()
+	h.deny(types.SystemRBACResource, "event-outbox.manage")
+
+	h.apiInit().
+		Get("/event-outbox/").
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to manage event outbox")).
+		End()
+}
+
+func TestEventOutboxReplay(t *testing.T) {
+	h := newHelper(t)
+	h.clearEventOutbox()
+	h.allow(types.SystemRBACResource, "event-outbox.manage")
+
+	dl := h.repoMakeDeadLetter("system:user")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/event-outbox/dead-letters/%d/replay", dl.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.attempts`, float64(0))).
+		End()
+
+	_, err := store.LookupEventbusDeadLetterByID(context.Background(), service.DefaultStore, dl.ID)
+	h.a.Error(err)
+
+	qe, err := store.LookupEventbusOutboxEventByID(context.Background(), service.DefaultStore, dl.ID)
+	h.noError(err)
+	h.a.Zero(qe.Attempts)
+	h.a.Nil(qe.FailedAt)
+}
+
+func TestEventOutboxPurge(t *testing.T) {
+	h := newHelper(t)
+	h.clearEventOutbox()
+	h.allow(types.SystemRBACResource, "event-outbox.manage")
+
+	h.repoMakeDeadLetter("system:user")
+	h.repoMakeDeadLetter("system:user")
+	h.repoMakeDeadLetter("system:role")
+
+	h.apiInit().
+		Delete("/event-outbox/dead-letters").
+		Query("resourceType", "system:user").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.count`, float64(2))).
+		End()
+
+	set, _, err := store.SearchEventbusDeadLetters(context.Background(), service.DefaultStore, eventbus.QueuedEventFilter{})
+	h.noError(err)
+	h.a.Len(set, 1)
+}
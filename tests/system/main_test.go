@@ -27,6 +27,7 @@ import (
 	"github.com/steinfletcher/apitest"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"net/http"
 	"os"
 	"testing"
 )
@@ -85,7 +86,15 @@ func InitTestApp() {
 
 	if r == nil {
 		r = chi.NewRouter()
-		r.Use(server.BaseMiddleware(false, logger.Default())...)
+		// requests are made through the (trusted) local proxy
+		trustedProxies, _ := server.ParseTrustedProxies("127.0.0.1")
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				req.RemoteAddr = "127.0.0.1:1234"
+				next.ServeHTTP(w, req)
+			})
+		})
+		r.Use(server.BaseMiddleware(false, logger.Default(), trustedProxies)...)
 		helpers.BindAuthMiddleware(r)
 		rest.MountRoutes(r)
 	}
@@ -0,0 +1,84 @@
+package system
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cortezaproject/corteza-server/pkg/rbac"
+	"github.com/cortezaproject/corteza-server/system/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+)
+
+func TestPermissionsConditionalRule(t *testing.T) {
+	h := newHelper(t)
+	h.mockPermissions(
+		rbac.AllowRule(h.roleID, types.SystemRBACResource, "role.create").
+			WithConditions(&rbac.Conditions{IPRanges: []string{"10.0.0.0/8"}}),
+	)
+
+	h.apiInit().
+		Post("/roles/").
+		Header("X-Real-IP", "10.1.2.3").
+		FormData("name", rs()).
+		FormData("handle", "handle_"+rs()).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.apiInit().
+		Post("/roles/").
+		Header("X-Real-IP", "192.168.1.1").
+		Header("Accept", "application/json").
+		FormData("name", rs()).
+		FormData("handle", "handle_"+rs()).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to create roles")).
+		End()
+}
+
+func TestPermissionsUpdateWithConditions(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.SystemRBACResource, "grant")
+
+	r := h.repoMakeRole()
+
+	h.apiInit().
+		Patch(fmt.Sprintf("/permissions/%d/rules", r.ID)).
+		JSON(`{"rules":[{"resource":"system","operation":"settings.manage","access":"allow","conditions":{"timeFrom":"08:00","timeUntil":"17:00"}}]}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.apiInit().
+		Get(fmt.Sprintf("/permissions/%d/rules", r.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 1)).
+		Assert(jsonpath.Equal(`$.response[0].conditions.timeFrom`, "08:00")).
+		End()
+
+	h.apiInit().
+		Patch(fmt.Sprintf("/permissions/%d/rules", r.ID)).
+		Header("Accept", "application/json").
+		JSON(`{"rules":[{"resource":"system","operation":"settings.manage","access":"allow","conditions":{"timeFrom":"08:00"}}]}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("invalid conditions of rule 'settings.manage' on 'system'")).
+		End()
+
+	// there is no multi-factor authentication to verify the condition with
+	h.apiInit().
+		Patch(fmt.Sprintf("/permissions/%d/rules", r.ID)).
+		Header("Accept", "application/json").
+		JSON(`{"rules":[{"resource":"system","operation":"settings.manage","access":"allow","conditions":{"mfa":true}}]}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("invalid conditions of rule 'settings.manage' on 'system'")).
+		End()
+}
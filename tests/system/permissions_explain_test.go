@@ -0,0 +1,159 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cortezaproject/corteza-server/pkg/rbac"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/system/service"
+	"github.com/cortezaproject/corteza-server/system/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+)
+
+func (h helper) makeRoleMember(r *types.Role, u *types.User) {
+	h.a.NoError(store.CreateRoleMember(context.Background(), service.DefaultStore, &types.RoleMember{RoleID: r.ID, UserID: u.ID}))
+}
+
+func TestPermissionsExplain(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.SystemRBACResource, "grant")
+
+	var (
+		r   = h.repoMakeRole()
+		u   = h.createUserWithEmail(h.randEmail())
+		res = types.RoleRBACResource.AppendID(42)
+	)
+
+	h.makeRoleMember(r, u)
+	h.mockPermissions(
+		rbac.AllowRule(r.ID, types.RoleRBACResource.AppendWildcard(), "read"),
+		rbac.DenyRule(rbac.EveryoneRoleID, types.RoleRBACResource.AppendWildcard(), "update"),
+		rbac.AllowRule(rbac.OwnersDynamicRoleID, res, "update"),
+	)
+
+	h.apiInit().
+		Get("/permissions/explain").
+		Query("userID", fmt.Sprintf("%d", u.ID)).
+		Query("resource", res.String()).
+		Query("operation", "read").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.access`, "allow")).
+		Assert(jsonpath.Len(`$.response.steps`, 2)).
+		Assert(jsonpath.Equal(`$.response.steps[1].resource`, "system:role:*")).
+		Assert(jsonpath.Equal(`$.response.steps[1].rules[0].roleID`, fmt.Sprintf("%d", r.ID))).
+		End()
+
+	h.apiInit().
+		Get("/permissions/explain").
+		Query("userID", fmt.Sprintf("%d", u.ID)).
+		Query("resource", res.String()).
+		Query("operation", "update").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.access`, "deny")).
+		Assert(jsonpath.Len(`$.response.steps`, 4)).
+		Assert(jsonpath.Len(`$.response.conditional`, 1)).
+		End()
+
+	h.apiInit().
+		Get("/permissions/explain").
+		Query("userID", fmt.Sprintf("%d", u.ID)).
+		Query("resource", res.String()).
+		Query("operation", "update").
+		Query("dynamicRoles", fmt.Sprintf("%d", rbac.OwnersDynamicRoleID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.access`, "allow")).
+		Assert(jsonpath.Len(`$.response.steps`, 1)).
+		End()
+
+	// no rule matches, applications can be read by default
+	h.apiInit().
+		Get("/permissions/explain").
+		Query("userID", fmt.Sprintf("%d", u.ID)).
+		Query("resource", types.ApplicationRBACResource.AppendID(42).String()).
+		Query("operation", "read").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.access`, "allow")).
+		Assert(jsonpath.Equal(`$.response.reason`, "no rule matched; allowed by default")).
+		End()
+
+	h.apiInit().
+		Get("/permissions/explain").
+		Query("userID", fmt.Sprintf("%d", u.ID)).
+		Query("resource", types.ApplicationRBACResource.AppendID(42).String()).
+		Query("operation", "update").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.access`, "deny")).
+		Assert(jsonpath.Equal(`$.response.reason`, "no rule matched; denied by default")).
+		End()
+}
+
+func TestPermissionsExplainForbidden(t *testing.T) {
+	h := newHelper(t)
+
+	h.apiInit().
+		Get("/permissions/explain").
+		Query("userID", fmt.Sprintf("%d", h.cUser.ID)).
+		Query("resource", "system").
+		Query("operation", "access").
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to set permissions")).
+		End()
+}
+
+func TestPermissionsWhatIf(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.SystemRBACResource, "grant")
+
+	var (
+		r       = h.repoMakeRole()
+		member  = h.createUserWithEmail(h.randEmail())
+		another = h.createUserWithEmail(h.randEmail())
+	)
+
+	h.makeRoleMember(r, member)
+	h.mockPermissions(
+		rbac.AllowRule(r.ID, types.RoleRBACResource.AppendWildcard(), "read"),
+	)
+
+	h.apiInit().
+		Post("/permissions/what-if").
+		JSON(fmt.Sprintf(`{"rules":[{"roleID":"%d","resource":"system:role:*","operation":"read","access":"deny"}]}`, r.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 1)).
+		Assert(jsonpath.Len(`$.response[0].gained`, 0)).
+		Assert(jsonpath.Len(`$.response[0].lost`, 1)).
+		Assert(jsonpath.Equal(`$.response[0].lost[0].userID`, fmt.Sprintf("%d", member.ID))).
+		Assert(jsonpath.Equal(`$.response[0].lost[0].after`, "deny")).
+		End()
+
+	h.apiInit().
+		Post("/permissions/what-if").
+		JSON(`{"rules":[{"roleID":"1","resource":"system:role:*","operation":"read","access":"allow"}],"resource":"system:role:42"}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response[0].resource`, "system:role:42")).
+		Assert(jsonpath.Contains(`$.response[0].gained[*].userID`, fmt.Sprintf("%d", another.ID))).
+		End()
+
+	// rules must stay intact
+	h.a.Equal(rbac.Inherit, rbac.Global().Check(types.RoleRBACResource.AppendID(42), "read", rbac.EveryoneRoleID))
+}
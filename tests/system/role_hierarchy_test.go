@@ -0,0 +1,127 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/system/service"
+	"github.com/cortezaproject/corteza-server/system/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleUpdateParent(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.RoleRBACResource.AppendWildcard(), "update")
+
+	var (
+		parent = h.repoMakeRole()
+		child  = h.repoMakeRole()
+	)
+
+	h.apiInit().
+		Put(fmt.Sprintf("/roles/%d", child.ID)).
+		FormData("name", child.Name).
+		FormData("handle", child.Handle).
+		FormData("parentID", fmt.Sprintf("%d", parent.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.a.Equal(parent.ID, h.lookupRoleByID(child.ID).ParentID)
+
+	h.apiInit().
+		Put(fmt.Sprintf("/roles/%d", parent.ID)).
+		Header("Accept", "application/json").
+		FormData("name", parent.Name).
+		FormData("handle", parent.Handle).
+		FormData("parentID", fmt.Sprintf("%d", child.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("parent role would create a cycle")).
+		End()
+
+	h.apiInit().
+		Put(fmt.Sprintf("/roles/%d", parent.ID)).
+		Header("Accept", "application/json").
+		FormData("name", parent.Name).
+		FormData("handle", parent.Handle).
+		FormData("parentID", "42").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("invalid parent role")).
+		End()
+}
+
+func TestRoleMemberAddTimeBound(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.RoleRBACResource.AppendWildcard(), "members.manage")
+
+	var (
+		r     = h.repoMakeRole()
+		u     = h.createUserWithEmail(h.randEmail())
+		until = time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	)
+
+	h.apiInit().
+		Post(fmt.Sprintf("/roles/%d/member/%d", r.ID, u.ID)).
+		FormData("validUntil", until.Format(time.RFC3339)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	mm, _, err := store.SearchRoleMembers(context.Background(), service.DefaultStore, types.RoleMemberFilter{RoleID: r.ID})
+	h.a.NoError(err)
+	h.a.Len(mm, 1)
+	h.a.Nil(mm[0].ValidFrom)
+	h.a.NotNil(mm[0].ValidUntil)
+	h.a.True(until.Equal(*mm[0].ValidUntil))
+
+	h.apiInit().
+		Post(fmt.Sprintf("/roles/%d/member/%d", r.ID, u.ID)).
+		Header("Accept", "application/json").
+		FormData("validFrom", until.Format(time.RFC3339)).
+		FormData("validUntil", until.Add(-time.Minute).Format(time.RFC3339)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("membership must be valid until after it is valid from")).
+		End()
+}
+
+func TestRoleMembershipsLoad(t *testing.T) {
+	var (
+		h   = newHelper(t)
+		req = require.New(t)
+		ctx = context.Background()
+
+		past = time.Now().Add(-time.Hour)
+		next = time.Now().Add(time.Hour)
+
+		grandparent = h.repoMakeRole()
+		parent      = h.createRole(&types.Role{Name: "parent", Handle: "h_" + rs(), ParentID: grandparent.ID})
+		child       = h.createRole(&types.Role{Name: "child", Handle: "h_" + rs(), ParentID: parent.ID})
+		expired     = h.repoMakeRole()
+		upcoming    = h.repoMakeRole()
+
+		u = h.createUserWithEmail(h.randEmail())
+	)
+
+	req.NoError(store.CreateRoleMember(ctx, service.DefaultStore,
+		&types.RoleMember{RoleID: child.ID, UserID: u.ID, ValidUntil: &next},
+		&types.RoleMember{RoleID: expired.ID, UserID: u.ID, ValidUntil: &past},
+		&types.RoleMember{RoleID: upcoming.ID, UserID: u.ID, ValidFrom: &next},
+	))
+
+	req.NoError(service.DefaultAuth.LoadRoleMemberships(ctx, u))
+	req.ElementsMatch([]uint64{child.ID, parent.ID, grandparent.ID}, u.Roles())
+
+	// token must expire with the membership
+	req.NotNil(u.RolesValidUntil())
+	req.True(next.Equal(*u.RolesValidUntil()))
+}
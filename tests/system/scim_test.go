@@ -29,7 +29,7 @@ func (h helper) scimApiInit(ffn ...func(*scim.Config)) *apitest.APITest {
 		fn(&scimConfig)
 	}
 
-	scimRoutes.Use(server.BaseMiddleware(false, logger.Default())...)
+	scimRoutes.Use(server.BaseMiddleware(false, logger.Default(), nil)...)
 	scim.Routes(scimRoutes, scimConfig)
 
 	return apitest.
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"github.com/cortezaproject/corteza-server/store"
 	"github.com/cortezaproject/corteza-server/system/service"
 	"github.com/cortezaproject/corteza-server/system/types"
 	"github.com/cortezaproject/corteza-server/tests/helpers"
@@ -127,3 +128,37 @@ func TestSettingsGet_noPermissions(t *testing.T) {
 		Assert(helpers.AssertError("not allowed to read settings")).
 		End()
 }
+
+func TestSettingsListSecretMasked(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.SystemRBACResource, "settings.read")
+	h.allow(types.SystemRBACResource, "settings.manage")
+
+	err := service.DefaultSettings.BulkSet(h.secCtx(), types.SettingValueSet{
+		&types.SettingValue{Name: "t_sys_k3.secret", Value: sqlTypes.JSONText(`"t_sys_secret"`)},
+	})
+	h.a.NoError(err)
+
+	h.apiInit().
+		Get("/settings/").
+		Query("prefix", "t_sys_k3").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response[0].value`, types.SecretSettingMask)).
+		Assert(jsonpath.Equal(`$.response[0].secret`, true)).
+		End()
+
+	// sending mask back does not change the value
+	h.apiInit().
+		Patch("/settings/").
+		JSON(`{"values":[{"name":"t_sys_k3.secret","value":"********"}]}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	s, err := store.LookupSettingByNameOwnedBy(h.secCtx(), service.DefaultStore, "t_sys_k3.secret", 0)
+	h.a.NoError(err)
+	h.a.Equal("t_sys_secret", s.String())
+}
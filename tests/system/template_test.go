@@ -0,0 +1,311 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/id"
+	"github.com/cortezaproject/corteza-server/store"
+	"github.com/cortezaproject/corteza-server/system/service"
+	"github.com/cortezaproject/corteza-server/system/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+	"github.com/steinfletcher/apitest-jsonpath"
+)
+
+func (h helper) clearTemplates() {
+	h.noError(store.TruncateTemplates(context.Background(), service.DefaultStore))
+}
+
+func (h helper) repoMakeTemplate(handle, lang, source string) *types.Template {
+	var res = &types.Template{
+		ID:        id.Next(),
+		CreatedAt: time.Now(),
+		Handle:    handle,
+		Language:  lang,
+		Type:      types.TemplateTypeHTML,
+		Template:  source,
+	}
+
+	h.a.NoError(store.CreateTemplate(context.Background(), service.DefaultStore, res))
+
+	return res
+}
+
+func (h helper) lookupTemplateByID(ID uint64) *types.Template {
+	res, err := store.LookupTemplateByID(context.Background(), service.DefaultStore, ID)
+	h.noError(err)
+	return res
+}
+
+func TestTemplateRead(t *testing.T) {
+	h := newHelper(t)
+	h.clearTemplates()
+	h.allow(types.TemplateRBACResource.AppendWildcard(), "read")
+
+	tpl := h.repoMakeTemplate("tpl_"+rs(), "en", "<p>hello</p>")
+
+	h.apiInit().
+		Get(fmt.Sprintf("/template/%d", tpl.ID)).
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.handle`, tpl.Handle)).
+		Assert(jsonpath.Equal(`$.response.templateID`, fmt.Sprintf("%d", tpl.ID))).
+		End()
+}
+
+func TestTemplateList(t *testing.T) {
+	h := newHelper(t)
+	h.clearTemplates()
+	h.allow(types.TemplateRBACResource.AppendWildcard(), "read")
+
+	h.repoMakeTemplate("tpl_a", "en", "a")
+	h.repoMakeTemplate("tpl_a", "de", "a")
+	h.repoMakeTemplate("tpl_b", "en", "b")
+
+	h.apiInit().
+		Get("/template/").
+		Header("Accept", "application/json").
+		Query("handle", "tpl_a").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 2)).
+		End()
+}
+
+func TestTemplateCreateForbidden(t *testing.T) {
+	h := newHelper(t)
+
+	h.apiInit().
+		Post("/template/").
+		Header("Accept", "application/json").
+		FormData("handle", "tpl_"+rs()).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("not allowed to create templates")).
+		End()
+}
+
+func TestTemplateCreate(t *testing.T) {
+	h := newHelper(t)
+	h.clearTemplates()
+	h.allow(types.SystemRBACResource, "template.create")
+
+	h.apiInit().
+		Post("/template/").
+		Header("Accept", "application/json").
+		FormData("handle", "welcome").
+		FormData("language", "en_US").
+		FormData("template", "<p>Hello {{.name}}</p>").
+		FormData("meta", `{"subject":"Welcome {{.name}}"}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.language`, "en-us")).
+		Assert(jsonpath.Equal(`$.response.type`, "text/html")).
+		Assert(jsonpath.Equal(`$.response.meta.subject`, "Welcome {{.name}}")).
+		End()
+
+	h.apiInit().
+		Post("/template/").
+		Header("Accept", "application/json").
+		FormData("handle", "welcome").
+		FormData("language", "en-us").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("template with this handle and language already exists")).
+		End()
+}
+
+func TestTemplateCreateInvalid(t *testing.T) {
+	h := newHelper(t)
+	h.clearTemplates()
+	h.allow(types.SystemRBACResource, "template.create")
+
+	h.apiInit().
+		Post("/template/").
+		Header("Accept", "application/json").
+		FormData("handle", "broken").
+		FormData("template", "{{if}}").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("invalid template syntax: template: broken:1: missing value for if")).
+		End()
+
+	h.apiInit().
+		Post("/template/").
+		Header("Accept", "application/json").
+		FormData("handle", "broken").
+		FormData("language", "not a language").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("invalid language")).
+		End()
+}
+
+func TestTemplateUpdate(t *testing.T) {
+	h := newHelper(t)
+	h.clearTemplates()
+	h.allow(types.TemplateRBACResource.AppendWildcard(), "update")
+
+	tpl := h.repoMakeTemplate("tpl_"+rs(), "en", "<p>hello</p>")
+
+	h.apiInit().
+		Put(fmt.Sprintf("/template/%d", tpl.ID)).
+		Header("Accept", "application/json").
+		FormData("handle", tpl.Handle).
+		FormData("language", "de").
+		FormData("template", "<p>hallo</p>").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	tpl = h.lookupTemplateByID(tpl.ID)
+	h.a.Equal("de", tpl.Language)
+	h.a.Equal("<p>hallo</p>", tpl.Template)
+	h.a.NotNil(tpl.UpdatedAt)
+}
+
+func TestTemplateDelete(t *testing.T) {
+	h := newHelper(t)
+	h.clearTemplates()
+	h.allow(types.TemplateRBACResource.AppendWildcard(), "delete")
+
+	tpl := h.repoMakeTemplate("tpl_"+rs(), "en", "<p>hello</p>")
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/template/%d", tpl.ID)).
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	tpl = h.lookupTemplateByID(tpl.ID)
+	h.a.NotNil(tpl.DeletedAt)
+}
+
+func TestTemplateRender(t *testing.T) {
+	h := newHelper(t)
+	h.clearTemplates()
+
+	header := h.repoMakeTemplate("header", "", `<h1>{{.name}}</h1>`)
+	header.Partial = true
+	h.noError(store.UpdateTemplate(context.Background(), service.DefaultStore, header))
+
+	headerDe := h.repoMakeTemplate("header", "de", `<h1>Hallo {{.name}}</h1>`)
+	headerDe.Partial = true
+	h.noError(store.UpdateTemplate(context.Background(), service.DefaultStore, headerDe))
+
+	en := h.repoMakeTemplate("welcome", "en", `{{template "header" .}}<p>welcome</p>`)
+	en.Meta.Subject = "Welcome {{.name}}"
+	h.noError(store.UpdateTemplate(context.Background(), service.DefaultStore, en))
+
+	h.repoMakeTemplate("welcome", "de", `{{template "header" .}}<p>willkommen</p>`)
+
+	t.Run("requested language", func(t *testing.T) {
+		h.apiInit().
+			Post("/template/render/welcome").
+			Header("Accept", "application/json").
+			FormData("language", "de-AT").
+			FormData("variables", `{"name":"<b>Ana</b>"}`).
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(helpers.AssertNoErrors).
+			Assert(jsonpath.Equal(`$.response.language`, "de")).
+			Assert(jsonpath.Equal(`$.response.content`, "<h1>Hallo &lt;b&gt;Ana&lt;/b&gt;</h1><p>willkommen</p>")).
+			End()
+	})
+
+	t.Run("default language", func(t *testing.T) {
+		h.apiInit().
+			Post("/template/render/welcome").
+			Header("Accept", "application/json").
+			FormData("language", "fr").
+			FormData("variables", `{"name":"Ana"}`).
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(helpers.AssertNoErrors).
+			Assert(jsonpath.Equal(`$.response.language`, "en")).
+			Assert(jsonpath.Equal(`$.response.subject`, "Welcome Ana")).
+			Assert(jsonpath.Equal(`$.response.content`, "<h1>Ana</h1><p>welcome</p>")).
+			End()
+	})
+
+	t.Run("preferred language", func(t *testing.T) {
+		u := &types.User{
+			ID:        h.cUser.ID,
+			Email:     h.randEmail(),
+			CreatedAt: time.Now(),
+			Meta:      &types.UserMeta{PreferredLanguage: "de"},
+		}
+		h.noError(store.UpsertUser(context.Background(), service.DefaultStore, u))
+
+		h.apiInit().
+			Post("/template/render/welcome").
+			Header("Accept", "application/json").
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(helpers.AssertNoErrors).
+			Assert(jsonpath.Equal(`$.response.language`, "de")).
+			End()
+	})
+
+	t.Run("partials are not rendered", func(t *testing.T) {
+		h.apiInit().
+			Post("/template/render/header").
+			Header("Accept", "application/json").
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(helpers.AssertError("template not found")).
+			End()
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		h.deny(types.TemplateRBACResource.AppendID(en.ID), "render")
+
+		h.apiInit().
+			Post("/template/render/welcome").
+			Header("Accept", "application/json").
+			FormData("language", "en").
+			Expect(t).
+			Status(http.StatusOK).
+			Assert(helpers.AssertError("not allowed to render this template")).
+			End()
+	})
+}
+
+func TestTemplatePreview(t *testing.T) {
+	h := newHelper(t)
+	h.clearTemplates()
+	h.allow(types.TemplateRBACResource.AppendWildcard(), "read")
+
+	tpl := h.repoMakeTemplate("tpl_"+rs(), "en", "<p>{{.name}}</p>")
+	tpl.Meta.Sample = map[string]interface{}{"name": "sample"}
+	h.noError(store.UpdateTemplate(context.Background(), service.DefaultStore, tpl))
+
+	h.apiInit().
+		Post(fmt.Sprintf("/template/%d/preview", tpl.ID)).
+		Header("Accept", "application/json").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.content`, "<p>sample</p>")).
+		End()
+
+	h.apiInit().
+		Post(fmt.Sprintf("/template/%d/preview", tpl.ID)).
+		Header("Accept", "application/json").
+		FormData("variables", `{"name":"given"}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.content`, "<p>given</p>")).
+		End()
+}
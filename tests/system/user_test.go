@@ -357,6 +357,39 @@ func TestUserUpdate(t *testing.T) {
 		End()
 }
 
+func TestUserUpdatePreferredLanguage(t *testing.T) {
+	h := newHelper(t)
+	h.clearUsers()
+
+	u := h.createUser(&types.User{Email: h.randEmail(), Meta: &types.UserMeta{PreferredLanguage: "de"}})
+	h.allow(types.UserRBACResource.AppendWildcard(), "update")
+
+	h.apiInit().
+		Put(fmt.Sprintf("/users/%d", u.ID)).
+		FormData("email", u.Email).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	u, err := store.LookupUserByID(context.Background(), service.DefaultStore, u.ID)
+	h.a.NoError(err)
+	h.a.Equal("de", u.PreferredLanguage())
+
+	h.apiInit().
+		Put(fmt.Sprintf("/users/%d", u.ID)).
+		FormData("email", u.Email).
+		FormData("preferredLanguage", "").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	u, err = store.LookupUserByID(context.Background(), service.DefaultStore, u.ID)
+	h.a.NoError(err)
+	h.a.Empty(u.PreferredLanguage())
+}
+
 func TestUserDeleteForbidden(t *testing.T) {
 	h := newHelper(t)
 	h.clearUsers()